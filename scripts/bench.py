@@ -0,0 +1,223 @@
+from __future__ import annotations
+
+import argparse
+from collections.abc import Callable
+from dataclasses import asdict, dataclass
+from functools import partial
+import json
+from pathlib import Path
+import statistics
+import sys
+import tempfile
+import time
+import tracemalloc
+
+from openpyxl import Workbook
+
+from exstruct import extract, serialize_workbook
+from exstruct.engine import ExStructEngine, StructOptions
+from exstruct.models import CellRow, SheetData, WorkbookData
+from exstruct.ooxml.drawing import _parse_drawing_xml
+
+_XDR = "http://schemas.openxmlformats.org/drawingml/2006/spreadsheetDrawing"
+_A = "http://schemas.openxmlformats.org/drawingml/2006/main"
+
+
+@dataclass(frozen=True)
+class BenchResult:
+    """Timing and allocation figures of one benchmark case."""
+
+    name: str
+    size: int
+    rounds: int
+    min_s: float
+    mean_s: float
+    peak_kib: float
+
+
+def _write_cells_workbook(path: Path, rows: int, cols: int) -> None:
+    """Write a rows x cols workbook of mixed numbers and strings."""
+    wb = Workbook(write_only=True)
+    ws = wb.create_sheet("Data")
+    ws.append([f"col{c}" for c in range(cols)])
+    for r in range(rows):
+        ws.append([r * cols + c if c % 2 else f"v{r}-{c}" for c in range(cols)])
+    wb.save(path)
+
+
+def _drawing_xml(shapes: int) -> bytes:
+    """Build a drawing part with alternating text shapes and connectors."""
+    anchors: list[str] = []
+    for i in range(shapes):
+        x = (i % 50) * 914400
+        y = (i // 50) * 457200
+        xfrm = f'<a:xfrm><a:off x="{x}" y="{y}"/><a:ext cx="914400" cy="457200"/>'
+        if i % 2:
+            body = (
+                f'<xdr:cxnSp><xdr:nvCxnSpPr><xdr:cNvPr id="{i + 2}" '
+                f'name="Connector {i}"/></xdr:nvCxnSpPr><xdr:spPr>{xfrm}</a:xfrm>'
+                '<a:prstGeom prst="straightConnector1"/><a:ln w="9525">'
+                '<a:tailEnd type="triangle"/></a:ln></xdr:spPr></xdr:cxnSp>'
+            )
+        else:
+            body = (
+                f'<xdr:sp><xdr:nvSpPr><xdr:cNvPr id="{i + 2}" name="Box {i}"/>'
+                f'</xdr:nvSpPr><xdr:spPr>{xfrm}</a:xfrm><a:prstGeom prst="rect"/>'
+                f"</xdr:spPr><xdr:txBody><a:p><a:r><a:t>Step {i}</a:t></a:r></a:p>"
+                "</xdr:txBody></xdr:sp>"
+            )
+        anchors.append(f"<xdr:absoluteAnchor>{body}</xdr:absoluteAnchor>")
+    return (
+        f'<xdr:wsDr xmlns:xdr="{_XDR}" xmlns:a="{_A}">{"".join(anchors)}</xdr:wsDr>'
+    ).encode()
+
+
+def _workbook_data(rows: int, cols: int) -> WorkbookData:
+    """Build an in-memory workbook model of rows x cols cells."""
+    cell_rows = [
+        CellRow(
+            r=r + 1,
+            c={str(c): r * cols + c if c % 2 else f"v{r}-{c}" for c in range(cols)},
+        )
+        for r in range(rows)
+    ]
+    sheets = {"Data": SheetData(rows=cell_rows)}
+    return WorkbookData(book_name="bench.xlsx", sheets=sheets)
+
+
+def _measure(
+    name: str, size: int, rounds: int, func: Callable[[], object]
+) -> BenchResult:
+    """Time ``rounds`` calls, then measure peak traced memory of one more call."""
+    func()  # warm up imports and caches
+    timings: list[float] = []
+    for _ in range(rounds):
+        start = time.perf_counter()
+        func()
+        timings.append(time.perf_counter() - start)
+    tracemalloc.start()
+    try:
+        func()
+        _current, peak = tracemalloc.get_traced_memory()
+    finally:
+        tracemalloc.stop()
+    return BenchResult(
+        name=name,
+        size=size,
+        rounds=rounds,
+        min_s=min(timings),
+        mean_s=statistics.fmean(timings),
+        peak_kib=peak / 1024,
+    )
+
+
+def run_benchmarks(
+    scale: float, rounds: int, only: set[str] | None
+) -> list[BenchResult]:
+    """Run the selected cases; ``scale`` multiplies the synthetic input sizes."""
+    rows = max(1, int(20_000 * scale))
+    cols = 20
+    shapes = max(1, int(2_000 * scale))
+    results: list[BenchResult] = []
+    with tempfile.TemporaryDirectory(prefix="exstruct-bench-") as tmp:
+        path = Path(tmp) / "cells.xlsx"
+        if only is None or only & {"cells", "fast-cells"}:
+            _write_cells_workbook(path, rows, cols)
+        if only is None or "cells" in only:
+            extract_light = partial(extract, path, mode="light")
+            results.append(_measure("cells", rows * cols, rounds, extract_light))
+        if only is None or "fast-cells" in only:
+            engine = ExStructEngine(
+                options=StructOptions(mode="light", fast_cells=True)
+            )
+            extract_fast = partial(engine.extract, path)
+            results.append(_measure("fast-cells", rows * cols, rounds, extract_fast))
+    if only is None or "shapes" in only:
+        xml = _drawing_xml(shapes)
+        parse = partial(_parse_drawing_xml, xml, "standard")
+        results.append(_measure("shapes", shapes, rounds, parse))
+    if only is None or "serialize" in only:
+        model = _workbook_data(rows, cols)
+        to_json = partial(serialize_workbook, model, "json")
+        results.append(_measure("serialize", rows * cols, rounds, to_json))
+    return results
+
+
+def find_regressions(
+    results: list[BenchResult], baseline: dict[str, dict[str, float]], threshold: float
+) -> list[str]:
+    """Return a message per case whose time or peak memory grew beyond threshold."""
+    messages: list[str] = []
+    for result in results:
+        base = baseline.get(result.name)
+        if base is None:
+            continue
+        for metric in ("min_s", "peak_kib"):
+            before = base[metric]
+            after = getattr(result, metric)
+            if before > 0 and after > before * (1 + threshold):
+                messages.append(
+                    f"{result.name}.{metric}: {before:.4g} -> {after:.4g} "
+                    f"(+{(after / before - 1) * 100:.0f}%)"
+                )
+    return messages
+
+
+def _print_table(results: list[BenchResult]) -> None:
+    print(f"{'case':<10} {'size':>9} {'min (s)':>9} {'mean (s)':>9} {'peak (KiB)':>11}")
+    for r in results:
+        print(
+            f"{r.name:<10} {r.size:>9} {r.min_s:>9.4f} {r.mean_s:>9.4f} "
+            f"{r.peak_kib:>11.0f}"
+        )
+
+
+def main(argv: list[str] | None = None) -> int:
+    """
+    Benchmark cell extraction, shape parsing, and JSON serialization.
+
+    Synthetic inputs: a 20,000 x 20 cell workbook (light mode, with and without
+    ``fast_cells``), a drawing part with 2,000 shapes and connectors, and a
+    20,000 x 20 cell model serialized to JSON. ``--scale`` shrinks or grows
+    them. With ``--baseline``, exits 1 when a case's best time or peak traced
+    memory regresses beyond ``--threshold``.
+
+    Returns:
+        exit_code (int): 0 on success, 1 on a regression.
+    """
+    parser = argparse.ArgumentParser(description="Run ExStruct performance benchmarks.")
+    parser.add_argument("--scale", type=float, default=1.0, help="Input size factor.")
+    parser.add_argument("--rounds", type=int, default=3, help="Timed calls per case.")
+    parser.add_argument(
+        "--case",
+        action="append",
+        choices=("cells", "fast-cells", "shapes", "serialize"),
+        help="Run only this case (repeatable).",
+    )
+    parser.add_argument("--save", type=Path, help="Write results as JSON baseline.")
+    parser.add_argument("--baseline", type=Path, help="Compare against a saved run.")
+    parser.add_argument(
+        "--threshold",
+        type=float,
+        default=0.25,
+        help="Allowed relative regression against --baseline (default 0.25).",
+    )
+    args = parser.parse_args(argv)
+    results = run_benchmarks(
+        args.scale, args.rounds, set(args.case) if args.case else None
+    )
+    _print_table(results)
+    if args.save is not None:
+        payload = {r.name: asdict(r) for r in results}
+        args.save.write_text(json.dumps(payload, indent=2) + "\n", encoding="utf-8")
+    if args.baseline is None:
+        return 0
+    baseline = json.loads(args.baseline.read_text(encoding="utf-8"))
+    regressions = find_regressions(results, baseline, args.threshold)
+    for message in regressions:
+        print(f"REGRESSION {message}", file=sys.stderr)
+    return 1 if regressions else 0
+
+
+if __name__ == "__main__":
+    raise SystemExit(main())
@@ -0,0 +1,106 @@
+from __future__ import annotations
+
+import argparse
+import hashlib
+from pathlib import Path
+import re
+import zipfile
+
+# Corpus kind -> OOXML part names it collects.
+_KINDS: dict[str, re.Pattern[str]] = {
+    "drawing": re.compile(r"^xl/drawings/[^/]+\.xml$"),
+    "chart": re.compile(r"^xl/charts/chart[^/]*\.xml$"),
+    "rels": re.compile(r"(^|/)_rels/[^/]+\.rels$"),
+}
+_SUFFIXES = {".xlsx", ".xlsm"}
+
+
+def _iter_workbooks(sources: list[Path]) -> list[Path]:
+    """Return workbook files under the given files/directories, sorted."""
+    found: set[Path] = set()
+    for source in sources:
+        if source.is_file():
+            found.add(source)
+            continue
+        found.update(
+            path
+            for path in source.rglob("*")
+            if path.suffix.lower() in _SUFFIXES and not path.name.startswith("~$")
+        )
+    return sorted(found)
+
+
+def _part_kind(name: str) -> str | None:
+    for kind, pattern in _KINDS.items():
+        if pattern.search(name):
+            return kind
+    return None
+
+
+def extract_corpus(sources: list[Path], output_dir: Path) -> dict[str, int]:
+    """Copy drawing, chart, and rels parts of each workbook into ``output_dir``.
+
+    Parts are stored as ``<kind>/<sha1 prefix><suffix>`` so identical parts from
+    different workbooks are written once and reruns are idempotent.
+
+    Returns:
+        Number of new files written per kind.
+    """
+    written = dict.fromkeys(_KINDS, 0)
+    for workbook in _iter_workbooks(sources):
+        try:
+            zf = zipfile.ZipFile(workbook)
+        except zipfile.BadZipFile:
+            continue
+        with zf:
+            for name in zf.namelist():
+                kind = _part_kind(name)
+                if kind is None:
+                    continue
+                data = zf.read(name)
+                digest = hashlib.sha1(data, usedforsecurity=False).hexdigest()[:16]
+                target = output_dir / kind / f"{digest}{Path(name).suffix}"
+                if target.exists():
+                    continue
+                target.parent.mkdir(parents=True, exist_ok=True)
+                target.write_bytes(data)
+                written[kind] += 1
+    return written
+
+
+def main(argv: list[str] | None = None) -> int:
+    """
+    Build the OOXML fuzz corpus from sample workbooks.
+
+    Extracts drawing, chart, and relationship parts from every .xlsx/.xlsm under
+    the given sources (default: ``sample/`` and ``tests/assets/``) into
+    ``tests/fuzz/corpus/<kind>/``, where ``tests/fuzz`` picks them up as seeds.
+
+    Returns:
+        exit_code (int): 0 on success.
+    """
+    project_root = Path(__file__).resolve().parent.parent
+    parser = argparse.ArgumentParser(description="Build the OOXML fuzz corpus.")
+    parser.add_argument(
+        "sources",
+        nargs="*",
+        type=Path,
+        default=[project_root / "sample", project_root / "tests" / "assets"],
+        help="Workbook files or directories to harvest.",
+    )
+    parser.add_argument(
+        "-o",
+        "--output-dir",
+        type=Path,
+        default=project_root / "tests" / "fuzz" / "corpus",
+        help="Corpus directory.",
+    )
+    args = parser.parse_args(argv)
+    written = extract_corpus(args.sources, args.output_dir)
+    for kind, count in written.items():
+        print(f"{kind}: {count} new part(s)")
+    return 0
+
+
+if __name__ == "__main__":
+    raise SystemExit(main())
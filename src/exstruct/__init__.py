@@ -8,25 +8,37 @@ from pathlib import Path
 from typing import TYPE_CHECKING, Literal, TextIO
 
 if TYPE_CHECKING:
+    from .config import ExtractionProfile
     from .core.cells import set_table_detection_params
+    from .core.extractors import register_extractor, unregister_extractor
     from .core.integrate import extract_workbook
+    from .encoders import register_encoder, unregister_encoder
     from .engine import (
         ColorsOptions,
+        ComponentsOptions,
         DestinationOptions,
         ExStructEngine,
         FilterOptions,
         FormatOptions,
+        LimitsOptions,
         OutputOptions,
+        PositionUnit,
+        RunReport,
+        SampleRowsOptions,
         StructOptions,
+        TableParams,
     )
     from .errors import (
         ConfigError,
         ExstructError,
+        LimitExceededError,
         MissingDependencyError,
         PrintAreaError,
         RenderError,
         SerializationError,
+        UnsafeWorkbookError,
     )
+    from .handle import WorkbookHandle
     from .io import serialize_workbook
     from .models import (
         CellRow,
@@ -37,11 +49,17 @@ if TYPE_CHECKING:
         Shape,
         SheetData,
         WorkbookData,
+        append_sheet,
+        as_datetime,
+        as_float,
+        col_alpha_to_index,
         col_index_to_alpha,
         convert_row_keys_to_alpha,
         convert_sheet_keys_to_alpha,
         convert_workbook_keys_to_alpha,
+        merge_workbooks,
     )
+    from .redaction import RedactionOptions, RedactionRule, redact_workbook
     from .render import export_pdf, export_sheet_images
 
 logger = logging.getLogger(__name__)
@@ -53,11 +71,15 @@ __all__ = [
     "export_sheets_as",
     "export_print_areas_as",
     "export_auto_page_breaks",
+    "export_shapes_as",
+    "export_charts_as",
     "export_pdf",
     "export_sheet_images",
     "ExstructError",
     "ConfigError",
     "MissingDependencyError",
+    "LimitExceededError",
+    "UnsafeWorkbookError",
     "RenderError",
     "SerializationError",
     "PrintAreaError",
@@ -73,19 +95,36 @@ __all__ = [
     "PrintAreaView",
     "set_table_detection_params",
     "extract_workbook",
+    "register_extractor",
+    "unregister_extractor",
+    "register_encoder",
+    "unregister_encoder",
     "ExStructEngine",
+    "WorkbookHandle",
     "StructOptions",
+    "RunReport",
     "OutputOptions",
     "FilterOptions",
     "FormatOptions",
     "DestinationOptions",
     "ColorsOptions",
+    "ComponentsOptions",
+    "LimitsOptions",
+    "SampleRowsOptions",
+    "RedactionOptions",
+    "RedactionRule",
+    "redact_workbook",
     "serialize_workbook",
     "export_auto_page_breaks",
     "col_index_to_alpha",
+    "col_alpha_to_index",
     "convert_row_keys_to_alpha",
     "convert_sheet_keys_to_alpha",
     "convert_workbook_keys_to_alpha",
+    "as_float",
+    "as_datetime",
+    "merge_workbooks",
+    "append_sheet",
 ]
 
 
@@ -100,6 +139,12 @@ def _load_engine_attr(name: str) -> object:
     return getattr(engine_module, name)
 
 
+def _load_handle_attr(name: str) -> object:
+    from . import handle as handle_module
+
+    return getattr(handle_module, name)
+
+
 def _load_error_attr(name: str) -> object:
     from . import errors as errors_module
 
@@ -124,12 +169,30 @@ def _load_io_attr(name: str) -> object:
     return getattr(io_module, name)
 
 
+def _load_redaction_attr(name: str) -> object:
+    from . import redaction as redaction_module
+
+    return getattr(redaction_module, name)
+
+
 def _load_core_cells_attr(name: str) -> object:
     from .core import cells as cells_module
 
     return getattr(cells_module, name)
 
 
+def _load_core_extractors_attr(name: str) -> object:
+    from .core import extractors as extractors_module
+
+    return getattr(extractors_module, name)
+
+
+def _load_encoders_attr(name: str) -> object:
+    from . import encoders as encoders_module
+
+    return getattr(encoders_module, name)
+
+
 def _load_core_integrate_attr(name: str) -> object:
     from .core import integrate as integrate_module
 
@@ -138,27 +201,41 @@ def _load_core_integrate_attr(name: str) -> object:
 
 _LAZY_EXPORTS: dict[str, LazyExportLoader] = {
     "ColorsOptions": lambda: _load_engine_attr("ColorsOptions"),
+    "ComponentsOptions": lambda: _load_engine_attr("ComponentsOptions"),
     "ConfigError": lambda: _load_error_attr("ConfigError"),
     "DestinationOptions": lambda: _load_engine_attr("DestinationOptions"),
     "ExStructEngine": lambda: _load_engine_attr("ExStructEngine"),
     "ExstructError": lambda: _load_error_attr("ExstructError"),
     "FilterOptions": lambda: _load_engine_attr("FilterOptions"),
     "FormatOptions": lambda: _load_engine_attr("FormatOptions"),
+    "LimitExceededError": lambda: _load_error_attr("LimitExceededError"),
+    "LimitsOptions": lambda: _load_engine_attr("LimitsOptions"),
     "MissingDependencyError": lambda: _load_error_attr("MissingDependencyError"),
     "OutputOptions": lambda: _load_engine_attr("OutputOptions"),
     "PrintArea": lambda: _load_model_attr("PrintArea"),
     "PrintAreaError": lambda: _load_error_attr("PrintAreaError"),
     "PrintAreaView": lambda: _load_model_attr("PrintAreaView"),
+    "RedactionOptions": lambda: _load_redaction_attr("RedactionOptions"),
+    "RedactionRule": lambda: _load_redaction_attr("RedactionRule"),
     "RenderError": lambda: _load_error_attr("RenderError"),
+    "RunReport": lambda: _load_engine_attr("RunReport"),
+    "SampleRowsOptions": lambda: _load_engine_attr("SampleRowsOptions"),
     "SerializationError": lambda: _load_error_attr("SerializationError"),
     "StructOptions": lambda: _load_engine_attr("StructOptions"),
+    "UnsafeWorkbookError": lambda: _load_error_attr("UnsafeWorkbookError"),
     "WorkbookData": lambda: _load_model_attr("WorkbookData"),
+    "WorkbookHandle": lambda: _load_handle_attr("WorkbookHandle"),
     "CellRow": lambda: _load_model_attr("CellRow"),
     "Chart": lambda: _load_model_attr("Chart"),
     "ChartSeries": lambda: _load_model_attr("ChartSeries"),
     "Shape": lambda: _load_model_attr("Shape"),
     "SheetData": lambda: _load_model_attr("SheetData"),
     "col_index_to_alpha": lambda: _load_model_attr("col_index_to_alpha"),
+    "col_alpha_to_index": lambda: _load_model_attr("col_alpha_to_index"),
+    "as_float": lambda: _load_model_attr("as_float"),
+    "as_datetime": lambda: _load_model_attr("as_datetime"),
+    "merge_workbooks": lambda: _load_model_attr("merge_workbooks"),
+    "append_sheet": lambda: _load_model_attr("append_sheet"),
     "convert_row_keys_to_alpha": lambda: _load_model_attr("convert_row_keys_to_alpha"),
     "convert_sheet_keys_to_alpha": lambda: _load_model_attr(
         "convert_sheet_keys_to_alpha"
@@ -169,6 +246,13 @@ _LAZY_EXPORTS: dict[str, LazyExportLoader] = {
     "export_pdf": lambda: _load_render_attr("export_pdf"),
     "export_sheet_images": lambda: _load_render_attr("export_sheet_images"),
     "extract_workbook": lambda: _load_core_integrate_attr("extract_workbook"),
+    "redact_workbook": lambda: _load_redaction_attr("redact_workbook"),
+    "register_extractor": lambda: _load_core_extractors_attr("register_extractor"),
+    "unregister_extractor": lambda: _load_core_extractors_attr(
+        "unregister_extractor"
+    ),
+    "register_encoder": lambda: _load_encoders_attr("register_encoder"),
+    "unregister_encoder": lambda: _load_encoders_attr("unregister_encoder"),
     "serialize_workbook": lambda: _load_io_attr("serialize_workbook"),
     "set_table_detection_params": lambda: _load_core_cells_attr(
         "set_table_detection_params"
@@ -288,6 +372,7 @@ def export_sheets(
     Export each sheet as an individual JSON file.
 
     - Payload: {book_name, sheet_name, sheet: SheetData}
+    - File names: sanitized sheet names (see ``index.json`` in the directory)
     - Returns: {sheet_name: Path}
 
     Args:
@@ -322,6 +407,7 @@ def export_sheets_as(
     pretty: bool = False,
     indent: int | None = None,
     include_backend_metadata: bool = False,
+    rows_per_file: int | None = None,
 ) -> dict[str, Path]:
     """
     Export each sheet in the given format (json/yaml/toon); returns sheet name to path map.
@@ -332,6 +418,8 @@ def export_sheets_as(
         fmt: Output format; inferred defaults to json.
         pretty: Pretty-print JSON.
         indent: JSON indent width (defaults to 2 when pretty=True and indent is None).
+        rows_per_file: Split sheets with more rows into numbered page files
+            (the map then points to each sheet's first page).
 
     Returns:
         Mapping from sheet name to written file path.
@@ -355,6 +443,97 @@ def export_sheets_as(
         pretty=pretty,
         indent=indent,
         include_backend_metadata=include_backend_metadata,
+        rows_per_file=rows_per_file,
+    )
+
+
+def export_shapes_as(
+    data: WorkbookData,
+    dir_path: str | Path,
+    fmt: Literal["json", "yaml", "yml", "toon"] = "json",
+    *,
+    pretty: bool = False,
+    indent: int | None = None,
+    include_backend_metadata: bool = False,
+) -> dict[str, Path]:
+    """
+    Export the shapes of each sheet as an individual file.
+
+    - Payload: {book_name, sheet_name, shapes: [Shape | Arrow | SmartArt]}
+    - File names: sanitized sheet names (see ``index.json`` in the directory)
+    - Sheets without shapes are skipped.
+
+    Args:
+        data: WorkbookData to split by sheet.
+        dir_path: Output directory.
+        fmt: json/yaml/yml/toon.
+        pretty: Pretty-print JSON output.
+        indent: JSON indent width (defaults to 2 when pretty is True and indent is None).
+
+    Returns:
+        Mapping from sheet name to written file path.
+
+    Examples:
+        >>> from exstruct import export_shapes_as, extract
+        >>> wb = extract("input.xlsx", mode="standard")
+        >>> paths = export_shapes_as(wb, "out_shapes")
+        >>> isinstance(paths, dict)
+        True
+    """
+    from .io import save_shapes
+
+    return save_shapes(
+        data,
+        Path(dir_path),
+        fmt=fmt,
+        pretty=pretty,
+        indent=indent,
+        include_backend_metadata=include_backend_metadata,
+    )
+
+
+def export_charts_as(
+    data: WorkbookData,
+    dir_path: str | Path,
+    fmt: Literal["json", "yaml", "yml", "toon"] = "json",
+    *,
+    pretty: bool = False,
+    indent: int | None = None,
+    include_backend_metadata: bool = False,
+) -> dict[str, Path]:
+    """
+    Export the charts of each sheet as an individual file.
+
+    - Payload: {book_name, sheet_name, charts: [Chart]}
+    - File names: sanitized sheet names (see ``index.json`` in the directory)
+    - Sheets without charts are skipped.
+
+    Args:
+        data: WorkbookData to split by sheet.
+        dir_path: Output directory.
+        fmt: json/yaml/yml/toon.
+        pretty: Pretty-print JSON output.
+        indent: JSON indent width (defaults to 2 when pretty is True and indent is None).
+
+    Returns:
+        Mapping from sheet name to written file path.
+
+    Examples:
+        >>> from exstruct import export_charts_as, extract
+        >>> wb = extract("input.xlsx", mode="standard")
+        >>> paths = export_charts_as(wb, "out_charts")
+        >>> isinstance(paths, dict)
+        True
+    """
+    from .io import save_charts
+
+    return save_charts(
+        data,
+        Path(dir_path),
+        fmt=fmt,
+        pretty=pretty,
+        indent=indent,
+        include_backend_metadata=include_backend_metadata,
     )
 
 
@@ -460,35 +639,89 @@ def export_auto_page_breaks(
 def process_excel(
     file_path: str | Path,
     output_path: str | Path | None = None,
-    out_fmt: str = "json",
+    out_fmt: str | None = None,
     image: bool = False,
     pdf: bool = False,
     dpi: int = 72,
-    mode: ExtractionMode = "standard",
-    pretty: bool = False,
+    mode: ExtractionMode | None = None,
+    pretty: bool | None = None,
     indent: int | None = None,
     sheets_dir: str | Path | None = None,
     print_areas_dir: str | Path | None = None,
     auto_page_breaks_dir: str | Path | None = None,
     stream: TextIO | None = None,
     *,
-    alpha_col: bool = False,
-    include_backend_metadata: bool = False,
+    alpha_col: bool | None = None,
+    include_backend_metadata: bool | None = None,
+    split_size: int | None = None,
+    rows_per_file: int | None = None,
+    tables_dir: str | Path | None = None,
+    tables_format: Literal["parquet", "arrow"] = "parquet",
+    shapes_dir: str | Path | None = None,
+    charts_dir: str | Path | None = None,
+    chart_images_dir: str | Path | None = None,
+    chart_images_format: Literal["svg", "png"] = "svg",
+    snapshots_dir: str | Path | None = None,
+    snapshots_format: Literal["svg", "png"] = "svg",
+    dump_parts_dir: str | Path | None = None,
+    profile: ExtractionProfile | None = None,
+    jq: str | None = None,
+    query: str | None = None,
+    fields: list[str] | None = None,
+    dedupe_strings: bool | None = None,
+    redaction: RedactionOptions | None = None,
+    limits: LimitsOptions | None = None,
+    best_effort: bool | None = None,
+    fast_cells: bool | None = None,
+    stable_ids: bool | None = None,
+    include_shape_paths: bool | None = None,
+    position_unit: PositionUnit | None = None,
+    position_dpi: int | None = None,
+    locale: str | None = None,
+    normalize_text: bool | None = None,
+    include_phonetic: bool | None = None,
+    include_outline: bool | None = None,
+    include_covered_ranges: bool | None = None,
+    infer_print_areas: bool | None = None,
+    include_formulas_r1c1: bool | None = None,
+    compress_formulas: bool | None = None,
+    include_table_stats: bool | None = None,
+    table_params: TableParams | None = None,
+    rank_tables: bool | None = None,
+    include_pivot_caches: bool | None = None,
+    include_power_queries: bool | None = None,
+    include_connections: bool | None = None,
+    include_security_report: bool | None = None,
+    include_named_ranges: bool | None = None,
+    include_styles: bool | None = None,
+    include_metrics: bool | None = None,
+    sample_rows: SampleRowsOptions | None = None,
+    ranges: list[str] | None = None,
+    report: RunReport | None = None,
 ) -> None:
     """
     Convenience wrapper: extract -> serialize (file or stdout) -> optional PDF/PNG.
 
     Args:
-        file_path: Input Excel workbook (path string or Path).
-        output_path: None for stdout; otherwise, write to file (string or Path).
-        out_fmt: json/yaml/yml/toon.
+        file_path: Input Excel workbook (path string or Path), or an
+            ``s3://``, ``gs://``, or ``azblob://`` object URI read into memory.
+        output_path: None for stdout; otherwise, write to file (string or Path)
+            or upload to an ``s3://``, ``gs://``, or ``azblob://`` object URI.
+            A ``.gz``/``.zst`` suffix compresses the file with gzip/zstd.
+        out_fmt: None for the profile's format (json without one), or
+            json/yaml/yml/toon, events for one NDJSON record per non-empty
+            cell, text for plain-text sheet grids, markdown for Markdown
+            documents (headings, tables, callouts), sqlite to write a SQLite
+            database (requires output_path), or the name of an encoder
+            registered with ``register_encoder``.
         image: True to also output PNGs (requires Excel + COM + pypdfium2 and is
             not supported in `mode="libreoffice"`).
         pdf: True to also output PDF (requires Excel + COM + pypdfium2 and is not
             supported in `mode="libreoffice"`).
         dpi: DPI for image output.
-        mode: light/libreoffice/standard/verbose (same meaning as `extract`).
-        pretty: Pretty-print JSON.
+        mode: light/libreoffice/standard/verbose (same meaning as `extract`);
+            None for the profile's mode (standard without one).
+        pretty: Pretty-print JSON; None uses the profile's setting.
         indent: JSON indent width.
         sheets_dir: Directory to write per-sheet files (string or Path).
         print_areas_dir: Directory to write per-print-area files (string or Path).
@@ -496,13 +729,136 @@ def process_excel(
             and not supported in `mode="libreoffice"`).
         stream: IO override when output_path is None.
         alpha_col: When True, convert CellRow column keys to Excel-style
-            ABC names (A, B, ...) instead of 0-based numeric strings.
+            ABC names (A, B, ...) instead of 0-based numeric strings. None
+            uses the profile's setting.
         include_backend_metadata: When True, include shape/chart backend metadata
             fields (`provenance`, `approximation_level`, `confidence`) in output.
+            None uses the profile's setting.
+        split_size: When set, split the output file into numbered parts of at
+            most this many uncompressed bytes plus a ``<stem>.manifest.json``.
+        rows_per_file: When set, sheets_dir files of sheets with more rows are
+            split into ``<sheet>.page001.json``, ... of at most this many rows,
+            each with a ``page`` block (index, count, row numbers, prev/next).
+        tables_dir: Directory to write each table candidate as a typed
+            Parquet/Arrow file (requires pyarrow).
+        tables_format: ``parquet`` or ``arrow`` (Arrow IPC) for tables_dir.
+        shapes_dir: Directory to write one ``{book_name, sheet_name, shapes}``
+            file per sheet with shapes (format follows out_fmt).
+        charts_dir: Directory to write one ``{book_name, sheet_name, charts}``
+            file per sheet with charts (format follows out_fmt).
+        chart_images_dir: Directory to write a preview image of every chart,
+            drawn from its cached or cell-resolved series values, plus an
+            ``index.json``.
+        chart_images_format: ``svg`` or ``png`` (requires Pillow) for
+            chart_images_dir.
+        snapshots_dir: Directory to write a snapshot image of the used region
+            of each sheet (cells, merges, fills, shapes, charts), drawn from
+            the extracted data, plus an ``index.json``.
+        snapshots_format: ``svg`` or ``png`` (requires Pillow) for
+            snapshots_dir.
+        dump_parts_dir: Directory to copy the raw drawing, chart, and table XML
+            parts into (layout below ``xl/`` kept), for comparing with the
+            structured output.
+        profile: Extraction profile (see ``exstruct.config.load_profile``) that
+            supplies table thresholds, component flags, and sheet filters.
+            Arguments given here are applied on top of it with
+            ``exstruct.config.resolve_profile`` (the CLI resolves ``--config``
+            the same way): arguments that are not None take precedence over
+            the profile, so ``False`` turns off a switch the profile enables.
+        jq: jq expression applied to the json/yaml/toon output (requires the jq
+            package); overrides the profile's ``jq``.
+        query: JSONPath (``$.sheets.*.charts[*].title``) or JMESPath
+            (``sheets.Sheet2.rows``, requires jmespath) expression whose result
+            is written instead of the json/yaml/toon payload; overrides the
+            profile's ``query``.
+        fields: Sections to keep (``["shapes", "charts"]``), ``-section`` to
+            drop, and ``section.field`` / ``-section.field`` to keep or drop
+            fields of each item in json/yaml/toon output; overrides the
+            profile's ``fields``.
+        dedupe_strings: Store string cell values repeated on a sheet once in
+            its ``strings`` table and reference them by index from each row's
+            ``s`` map (json/yaml/toon output). None uses the profile's
+            setting.
+        redaction: Redaction rules applied to cell values and shape texts before
+            output; overrides the profile's ``redaction``.
+        limits: Cell/sheet/output size limits; overrides the profile's ``limits``.
+        best_effort: Skip corrupted zip entries and malformed XML parts instead
+            of failing; broken sheets are dropped and listed in ``warnings``.
+            None uses the profile's setting.
+        fast_cells: Stream cell values directly from the sheet XML instead of
+            reading them through pandas. None uses the profile's
+            setting.
+        stable_ids: Use drawing (cNvPr) ids as shape ids and record each
+            shape's ``source_id``. None uses the profile's setting.
+        include_shape_paths: Add simplified polylines of freeform paths and
+            ink strokes to ``Shape.geometry`` (OOXML parsing only). None
+            uses the profile's setting.
+        position_unit: Unit for shape/chart positions (pixels, points, emu,
+            millimeters); None keeps each backend's native unit. Overrides the
+            profile's ``position_unit``.
+        position_dpi: DPI for pixel positions (default 96); overrides the
+            profile's ``position_dpi``.
+        locale: Locale tag (e.g. ``de-DE``, ``ja``) for parsing numbers and
+            dates written as text; overrides the profile's ``locale``.
+        normalize_text: NFKC-normalize cell values and shape texts and clean
+            up invisible characters and whitespace. None uses the profile's setting.
+        include_phonetic: Add phonetic (furigana) readings of text cells to
+            rows. None uses the profile's setting.
+        include_outline: Extract row/column outline groups and their
+            collapsed state; None uses the profile or mode default (verbose).
+        include_covered_ranges: Add each shape's ``covered_range`` and each
+            connector's ``begin_cell``/``end_cell``; None uses the profile or
+            the default (verbose mode and markdown output).
+        infer_print_areas: For sheets without a defined print area, infer one
+            print area per page from the used range and page setup, so
+            ``print_areas_dir`` also slices undecorated sheets. None uses the
+            profile's setting.
+        include_formulas_r1c1: Also report formulas grouped by their R1C1 text
+            in ``formulas_map_r1c1``. None uses the profile's setting.
+        compress_formulas: Replace copied formulas in ``formulas_map`` with
+            one ``formula_blocks`` entry per filled range. None uses the
+            profile's setting.
+        include_table_stats: Add per-column statistics of each table candidate
+            as ``table_stats``. None uses the profile's setting.
+        table_params: Table detection thresholds (``density_min``,
+            ``coverage_min``, ``min_nonempty_cells``, ``table_score_threshold``,
+            ``gap_tolerance``) for this run; keys set here override the
+            profile's ``table_detection``.
+        rank_tables: Score table candidates (``table_scores``) and sort them
+            by confidence. None uses the profile's setting.
+        include_pivot_caches: Recover the records of pivot caches whose
+            source data is gone as ``pivot_caches``. None uses the profile's
+            setting.
+        include_power_queries: List Power Query names and M code as
+            ``power_queries``. None uses the profile's setting.
+        include_connections: List external data connections (credentials
+            masked) and their bound tables as ``connections``. None uses
+            the profile's setting.
+        include_security_report: Add the ``security`` section (digital
+            signatures and parts modified after signing). None uses the
+            profile's setting.
+        include_named_ranges: Attach defined names that refer to ranges to
+            the sheets they cover as ``named_ranges``. None uses the profile's
+            setting.
+        include_styles: Export the workbook style table once as ``styles`` and
+            each sheet's cells per cell format as ``style_map``. None uses
+            the profile's setting.
+        include_metrics: Add the ``metrics`` section (extraction time plus
+            per-sheet parse time, part sizes, and counts). None uses the
+            profile's setting.
+        sample_rows: Keep only a head/tail/random sample of rows per sheet;
+            sampled sheets carry a ``sample`` marker with the total row count.
+            Overrides the profile's ``sample_rows``.
+        ranges: Extraction targets (``name:SalesData`` or ``Sheet1!A1:D20``);
+            rows are clipped to them and other sheets are left out. Overrides
+            the profile's ``ranges``.
+        report: Run report filled with per-component durations and the
+            workbook's counts and warnings (see ``RunReport``).
 
     Raises:
         ConfigError: If `mode="libreoffice"` is combined with PDF/PNG rendering or
-            auto page-break export.
+            auto page-break export, or an object URI with PDF/PNG rendering
+            (or, for the input, with libreoffice mode).
         ValueError: If an unsupported format or mode is given.
         PrintAreaError: When exporting auto page breaks without available data.
         RenderError: When rendering fails (Excel/COM/pypdfium2 issues).
@@ -518,48 +874,101 @@ def process_excel(
 
         >>> process_excel(Path("input.xlsx"), output_path=Path("out.json"), pdf=True)  # doctest: +SKIP
     """
-    from .engine import (
-        DestinationOptions,
-        ExStructEngine,
-        FilterOptions,
-        FormatOptions,
-        OutputOptions,
-        StructOptions,
+    from .config import resolve_profile
+    from .engine import DestinationOptions, ExStructEngine, OutputOptions
+
+    resolved = resolve_profile(
+        profile,
+        mode=mode,
+        format=out_fmt,
+        pretty=pretty,
+        indent=indent,
+        jq=jq,
+        query=query,
+        fields=fields,
+        dedupe_strings=dedupe_strings,
+        alpha_col=alpha_col,
+        include_backend_metadata=include_backend_metadata,
+        ranges=ranges,
+        table_detection=table_params,
+        redaction=redaction,
+        limits=limits,
+        sample_rows=sample_rows,
+        best_effort=best_effort,
+        fast_cells=fast_cells,
+        stable_ids=stable_ids,
+        include_shape_paths=include_shape_paths,
+        position_unit=position_unit,
+        position_dpi=position_dpi,
+        locale=locale,
+        normalize_text=normalize_text,
+        include_phonetic=include_phonetic,
+        include_outline=include_outline,
+        include_covered_ranges=include_covered_ranges,
+        infer_print_areas=infer_print_areas,
+        include_formulas_r1c1=include_formulas_r1c1,
+        compress_formulas=compress_formulas,
+        include_table_stats=include_table_stats,
+        rank_tables=rank_tables,
+        include_pivot_caches=include_pivot_caches,
+        include_power_queries=include_power_queries,
+        include_connections=include_connections,
+        include_security_report=include_security_report,
+        include_named_ranges=include_named_ranges,
+        include_styles=include_styles,
+        include_metrics=include_metrics,
     )
+    options = resolved.to_struct_options()
+    resolved_output = resolved.to_output_options()
 
     engine = ExStructEngine(
-        options=StructOptions(mode=mode, alpha_col=alpha_col),
+        options=options,
         output=OutputOptions(
-            format=FormatOptions(fmt=out_fmt, pretty=pretty, indent=indent),
-            filters=FilterOptions(
-                include_print_areas=None if mode == "light" else True,
-                include_shape_size=True if mode == "verbose" else False,
-                include_chart_size=True if mode == "verbose" else False,
-                include_backend_metadata=include_backend_metadata,
-            ),
+            format=resolved_output.format,
+            filters=resolved_output.filters,
             destinations=DestinationOptions(
                 sheets_dir=sheets_dir,
                 print_areas_dir=print_areas_dir,
                 auto_page_breaks_dir=auto_page_breaks_dir,
+                tables_dir=tables_dir,
+                tables_format=tables_format,
+                shapes_dir=shapes_dir,
+                charts_dir=charts_dir,
+                chart_images_dir=chart_images_dir,
+                chart_images_format=chart_images_format,
+                snapshots_dir=snapshots_dir,
+                snapshots_format=snapshots_format,
+                dump_parts_dir=dump_parts_dir,
+                split_size=split_size,
+                rows_per_file=rows_per_file,
                 stream=stream,
             ),
         ),
     )
-    engine.process(
-        file_path=file_path,
-        output_path=output_path,
-        out_fmt=out_fmt,
-        image=image,
-        pdf=pdf,
-        dpi=dpi,
-        mode=mode,
-        pretty=pretty,
-        indent=indent,
-        sheets_dir=sheets_dir,
-        print_areas_dir=print_areas_dir,
-        auto_page_breaks_dir=auto_page_breaks_dir,
-        stream=stream,
+    from .io.remote import remote_input, remote_output, validate_remote_request
+
+    validate_remote_request(
+        file_path, output_path, mode=options.mode, render=pdf or image
     )
+    with remote_input(file_path) as local_input, remote_output(
+        output_path
+    ) as local_output:
+        engine.process(
+            file_path=local_input,
+            output_path=local_output,
+            out_fmt=resolved_output.format.fmt,
+            image=image,
+            pdf=pdf,
+            dpi=dpi,
+            mode=options.mode,
+            pretty=resolved_output.format.pretty,
+            indent=resolved_output.format.indent,
+            sheets_dir=sheets_dir,
+            print_areas_dir=print_areas_dir,
+            auto_page_breaks_dir=auto_page_breaks_dir,
+            stream=stream,
+            report=report,
+        )
 
 
 def _patch_runtime_annotations() -> None:
@@ -570,6 +979,8 @@ def _patch_runtime_annotations() -> None:
         export_sheets_as: {"data": "_lazy_type('WorkbookData')"},
         export_print_areas_as: {"data": "_lazy_type('WorkbookData')"},
         export_auto_page_breaks: {"data": "_lazy_type('WorkbookData')"},
+        export_shapes_as: {"data": "_lazy_type('WorkbookData')"},
+        export_charts_as: {"data": "_lazy_type('WorkbookData')"},
     }
     for function, function_annotations in annotations_map.items():
         function.__annotations__.update(function_annotations)
@@ -0,0 +1,56 @@
+"""Post-extraction analyzers that derive higher-level structure from sheets."""
+
+from exstruct.analysis.chart_sources import (
+    build_chart_source_index,
+    split_range_reference,
+)
+from exstruct.analysis.fingerprint import sheet_content_hash, table_content_hashes
+from exstruct.analysis.flowchart import build_flowcharts, classify_node_kind
+from exstruct.analysis.formula_audit import (
+    audit_formulas,
+    find_circular_references,
+    find_formula_functions,
+    parse_formula_references,
+)
+from exstruct.analysis.formula_notation import (
+    compress_formulas,
+    r1c1_formulas_map,
+    to_r1c1,
+)
+from exstruct.analysis.label_lookup import LabelMatch, find_by_label
+from exstruct.analysis.overlap import find_shape_overlaps
+from exstruct.analysis.search import search_workbook
+from exstruct.analysis.table_families import (
+    build_table_families,
+    filter_table_families,
+)
+from exstruct.analysis.table_ranking import (
+    rank_table_candidates,
+    table_confidence_scores,
+)
+from exstruct.analysis.table_stats import table_column_stats
+
+__all__ = [
+    "LabelMatch",
+    "audit_formulas",
+    "build_chart_source_index",
+    "build_flowcharts",
+    "build_table_families",
+    "classify_node_kind",
+    "compress_formulas",
+    "filter_table_families",
+    "find_by_label",
+    "find_circular_references",
+    "find_formula_functions",
+    "find_shape_overlaps",
+    "parse_formula_references",
+    "r1c1_formulas_map",
+    "rank_table_candidates",
+    "search_workbook",
+    "sheet_content_hash",
+    "split_range_reference",
+    "table_column_stats",
+    "table_confidence_scores",
+    "table_content_hashes",
+    "to_r1c1",
+]
@@ -0,0 +1,158 @@
+"""Cross-reference charts with the sheet ranges their series read."""
+
+from __future__ import annotations
+
+from collections.abc import Mapping
+from typing import Literal
+
+from ..core.ranges import RangeBounds, parse_range_zero_based
+from ..models import (
+    Chart,
+    ChartRef,
+    ChartSourceIndex,
+    ChartSourceRef,
+    SheetData,
+    TableChartConsumers,
+)
+
+SourceRole = Literal["name", "categories", "values"]
+
+
+def split_range_reference(reference: str) -> list[tuple[str | None, str]]:
+    """Split a chart series reference into (sheet, range) parts.
+
+    Handles a leading ``=``, quoted sheet names (``'My Sheet'!A1``), and
+    union references such as ``(Sheet1!A1:A3,Sheet1!C1:C3)``.
+
+    Args:
+        reference: Series reference text.
+
+    Returns:
+        (sheet, range) pairs; sheet is None for unqualified references.
+    """
+    text = reference.strip().removeprefix("=")
+    if text.startswith("(") and text.endswith(")"):
+        text = text[1:-1]
+    parts: list[tuple[str | None, str]] = []
+    for part in _split_top_level(text):
+        sheet, sep, local = part.rpartition("!")
+        if not sep:
+            parts.append((None, part.replace("$", "")))
+            continue
+        if sheet.startswith("'") and sheet.endswith("'"):
+            sheet = sheet[1:-1].replace("''", "'")
+        parts.append((sheet, local.replace("$", "")))
+    return parts
+
+
+def _split_top_level(text: str) -> list[str]:
+    """Split on commas outside quoted sheet names."""
+    parts: list[str] = []
+    buf: list[str] = []
+    in_quote = False
+    for ch in text:
+        if ch == "'":
+            in_quote = not in_quote
+        if ch == "," and not in_quote:
+            parts.append("".join(buf).strip())
+            buf = []
+            continue
+        buf.append(ch)
+    parts.append("".join(buf).strip())
+    return [part for part in parts if part]
+
+
+def build_chart_source_index(
+    sheets: Mapping[str, SheetData],
+) -> ChartSourceIndex | None:
+    """Build the chart-to-range index of a workbook.
+
+    Args:
+        sheets: Sheets of a workbook keyed by name.
+
+    Returns:
+        ChartSourceIndex, or None when no chart references any range.
+    """
+    tables = {
+        name: [
+            (candidate, bounds)
+            for candidate in sheet.table_candidates
+            if (bounds := parse_range_zero_based(candidate)) is not None
+        ]
+        for name, sheet in sheets.items()
+    }
+    sources: list[ChartSourceRef] = []
+    consumers: dict[tuple[str, str], list[ChartRef]] = {}
+    for sheet_name, sheet in sheets.items():
+        for chart in sheet.charts:
+            chart_ref = ChartRef(sheet=sheet_name, chart=chart.name)
+            for source in _chart_sources(sheet_name, chart, tables):
+                sources.append(source)
+                if source.table_candidate is None:
+                    continue
+                key = (source.source_sheet or sheet_name, source.table_candidate)
+                charts = consumers.setdefault(key, [])
+                if chart_ref not in charts:
+                    charts.append(chart_ref)
+    if not sources:
+        return None
+    return ChartSourceIndex(
+        sources=sources,
+        table_consumers=[
+            TableChartConsumers(sheet=sheet, table_candidate=table, charts=charts)
+            for (sheet, table), charts in consumers.items()
+        ],
+    )
+
+
+def _chart_sources(
+    sheet_name: str,
+    chart: Chart,
+    tables: Mapping[str, list[tuple[str, RangeBounds]]],
+) -> list[ChartSourceRef]:
+    """List the ranges read by every series of a chart."""
+    sources: list[ChartSourceRef] = []
+    for series in chart.series:
+        roles: tuple[tuple[SourceRole, str | None], ...] = (
+            ("name", series.name_range),
+            ("categories", series.x_range),
+            ("values", series.y_range),
+        )
+        for role, reference in roles:
+            if not reference:
+                continue
+            for source_sheet, local in split_range_reference(reference):
+                sources.append(
+                    ChartSourceRef(
+                        sheet=sheet_name,
+                        chart=chart.name,
+                        series=series.name,
+                        role=role,
+                        source_sheet=source_sheet,
+                        range=local,
+                        table_candidate=_find_table(
+                            tables.get(source_sheet or sheet_name), local
+                        ),
+                    )
+                )
+    return sources
+
+
+def _find_table(
+    tables: list[tuple[str, RangeBounds]] | None, local: str
+) -> str | None:
+    """Return the first table candidate intersecting a range."""
+    if not tables:
+        return None
+    bounds = parse_range_zero_based(local)
+    if bounds is None:
+        return None
+    for candidate, table in tables:
+        if (
+            bounds.r1 <= table.r2
+            and table.r1 <= bounds.r2
+            and bounds.c1 <= table.c2
+            and table.c1 <= bounds.c2
+        ):
+            return candidate
+    return None
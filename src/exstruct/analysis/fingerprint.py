@@ -0,0 +1,83 @@
+"""Stable content hashes for sheets and table candidates."""
+
+from __future__ import annotations
+
+from collections.abc import Sequence
+import hashlib
+import json
+
+from ..core.ranges import parse_range_zero_based
+from ..models import CellRow
+
+CellValue = int | float | str
+
+
+def _digest(payload: object) -> str:
+    """Return the SHA-256 hex digest of a canonical JSON payload."""
+    text = json.dumps(payload, ensure_ascii=False, separators=(",", ":"))
+    return hashlib.sha256(text.encode("utf-8")).hexdigest()
+
+
+def _row_cells(row: CellRow) -> list[tuple[int, CellValue]]:
+    """Return (column, value) pairs of a row sorted by column index."""
+    cells: list[tuple[int, CellValue]] = []
+    for key, value in row.c.items():
+        try:
+            cells.append((int(key), value))
+        except ValueError:
+            continue
+    return sorted(cells, key=lambda cell: cell[0])
+
+
+def sheet_content_hash(rows: Sequence[CellRow]) -> str | None:
+    """Hash the cell values of a sheet, including their positions.
+
+    Args:
+        rows: Extracted rows with 0-based numeric column keys.
+
+    Returns:
+        SHA-256 hex digest, or None when the sheet has no cells.
+    """
+    payload = [
+        [row.r, col, value]
+        for row in sorted(rows, key=lambda row: row.r)
+        for col, value in _row_cells(row)
+    ]
+    return _digest(payload) if payload else None
+
+
+def table_content_hashes(
+    rows: Sequence[CellRow], table_candidates: Sequence[str]
+) -> dict[str, str]:
+    """Hash each table candidate independently of its position and row order.
+
+    Cell positions are taken relative to the table origin and rows are sorted
+    before hashing, so a table moved elsewhere or with reordered rows keeps
+    its hash.
+
+    Args:
+        rows: Extracted rows with 0-based numeric column keys.
+        table_candidates: A1 table ranges.
+
+    Returns:
+        Mapping of table range to SHA-256 hex digest (empty tables omitted).
+    """
+    hashes: dict[str, str] = {}
+    for candidate in table_candidates:
+        bounds = parse_range_zero_based(candidate)
+        if bounds is None:
+            continue
+        table_rows: list[str] = []
+        for row in rows:
+            if not bounds.r1 <= row.r - 1 <= bounds.r2:
+                continue
+            cells = [
+                [col - bounds.c1, value]
+                for col, value in _row_cells(row)
+                if bounds.c1 <= col <= bounds.c2
+            ]
+            if cells:
+                table_rows.append(json.dumps(cells, ensure_ascii=False))
+        if table_rows:
+            hashes[candidate] = _digest(sorted(table_rows))
+    return hashes
@@ -0,0 +1,180 @@
+"""Reconstruct flowcharts from shapes and connectors."""
+
+from __future__ import annotations
+
+from collections import deque
+from collections.abc import Sequence
+from typing import Literal
+
+from ..models import Arrow, Flowchart, FlowchartEdge, FlowchartNode, Shape, SmartArt
+
+NodeKind = Literal[
+    "process",
+    "decision",
+    "terminator",
+    "data",
+    "document",
+    "connector",
+    "other",
+]
+
+# Type-label suffixes shared by the COM (MSO_AUTO_SHAPE_TYPE_MAP) and OOXML
+# (PRESET_GEOM_MAP) backends, e.g. "AutoShape-FlowchartDecision".
+_NODE_KIND_BY_TYPE: dict[str, NodeKind] = {
+    "FlowchartProcess": "process",
+    "FlowchartAlternateProcess": "process",
+    "FlowchartPredefinedProcess": "process",
+    "FlowchartPreparation": "process",
+    "FlowchartManualOperation": "process",
+    "Rectangle": "process",
+    "RoundedRectangle": "process",
+    "FlowchartDecision": "decision",
+    "Diamond": "decision",
+    "FlowchartTerminator": "terminator",
+    "Oval": "terminator",
+    "FlowchartData": "data",
+    "FlowchartManualInput": "data",
+    "Parallelogram": "data",
+    "FlowchartDocument": "document",
+    "FlowchartMultidocument": "document",
+    "FlowchartConnector": "connector",
+    "FlowchartOffpageConnector": "connector",
+}
+
+_ARROWHEAD_NONE = 1
+
+
+def classify_node_kind(type_label: str | None) -> NodeKind:
+    """Classify a shape type label into a flowchart node kind.
+
+    Args:
+        type_label: Shape type label such as ``"AutoShape-FlowchartDecision"``.
+
+    Returns:
+        Node kind; ``"other"`` when the shape is not a known flowchart symbol.
+    """
+    if not type_label:
+        return "other"
+    suffix = type_label.rsplit("-", 1)[-1]
+    return _NODE_KIND_BY_TYPE.get(suffix, "other")
+
+
+def build_flowcharts(shapes: Sequence[Shape | Arrow | SmartArt]) -> list[Flowchart]:
+    """Build flowcharts from the shapes and connectors of a sheet.
+
+    Each connected group of shapes joined by connectors becomes one flowchart.
+    Shapes without connections are not included.
+
+    Args:
+        shapes: Shapes extracted from a sheet.
+
+    Returns:
+        Flowcharts ordered by their first node id.
+    """
+    nodes: dict[int, FlowchartNode] = {}
+    for shape in shapes:
+        if isinstance(shape, Shape) and shape.id is not None:
+            nodes[shape.id] = FlowchartNode(
+                id=shape.id,
+                kind=classify_node_kind(shape.type),
+                text=shape.text,
+                type=shape.type,
+            )
+    edges = [
+        edge
+        for shape in shapes
+        if isinstance(shape, Arrow)
+        and (edge := _build_edge(shape, nodes)) is not None
+    ]
+    if not edges:
+        return []
+
+    flowcharts: list[Flowchart] = []
+    for component in _connected_components(edges):
+        component_edges = [e for e in edges if e.source in component]
+        flowcharts.append(
+            Flowchart(
+                nodes=[nodes[node_id] for node_id in sorted(component)],
+                edges=component_edges,
+                order=_topological_order(component, component_edges),
+            )
+        )
+    return flowcharts
+
+
+def _build_edge(arrow: Arrow, nodes: dict[int, FlowchartNode]) -> FlowchartEdge | None:
+    """Build an edge from a connector attached to two known nodes."""
+    source, target = arrow.begin_id, arrow.end_id
+    if source is None or target is None or source not in nodes or target not in nodes:
+        return None
+    begin_head = _has_arrowhead(arrow.begin_arrow_style)
+    end_head = _has_arrowhead(arrow.end_arrow_style)
+    if begin_head and not end_head:
+        source, target = target, source
+    return FlowchartEdge(
+        source=source,
+        target=target,
+        label=arrow.text or None,
+        directed=begin_head != end_head,
+        direction=arrow.direction,
+        begin_arrow_style=arrow.begin_arrow_style,
+        end_arrow_style=arrow.end_arrow_style,
+    )
+
+
+def _has_arrowhead(style: int | None) -> bool:
+    """Return True when an arrow style enum denotes a visible arrowhead."""
+    return style is not None and style != _ARROWHEAD_NONE
+
+
+def _connected_components(edges: Sequence[FlowchartEdge]) -> list[set[int]]:
+    """Group node ids into connected components (ignoring edge direction)."""
+    adjacency: dict[int, set[int]] = {}
+    for edge in edges:
+        adjacency.setdefault(edge.source, set()).add(edge.target)
+        adjacency.setdefault(edge.target, set()).add(edge.source)
+    seen: set[int] = set()
+    components: list[set[int]] = []
+    for start in sorted(adjacency):
+        if start in seen:
+            continue
+        component: set[int] = set()
+        queue = deque([start])
+        while queue:
+            node_id = queue.popleft()
+            if node_id in component:
+                continue
+            component.add(node_id)
+            queue.extend(adjacency[node_id] - component)
+        seen |= component
+        components.append(component)
+    return components
+
+
+def _topological_order(
+    node_ids: set[int], edges: Sequence[FlowchartEdge]
+) -> list[int] | None:
+    """Return a topological order of directed edges, or None on cycles.
+
+    Undirected edges do not constrain the order.
+    """
+    indegree = dict.fromkeys(node_ids, 0)
+    successors: dict[int, list[int]] = {node_id: [] for node_id in node_ids}
+    for edge in edges:
+        if not edge.directed:
+            continue
+        successors[edge.source].append(edge.target)
+        indegree[edge.target] += 1
+    ready = sorted(node_id for node_id, degree in indegree.items() if degree == 0)
+    order: list[int] = []
+    while ready:
+        node_id = ready.pop(0)
+        order.append(node_id)
+        for succ in successors[node_id]:
+            indegree[succ] -= 1
+            if indegree[succ] == 0:
+                ready.append(succ)
+                ready.sort()
+    if len(order) != len(node_ids):
+        return None
+    return order
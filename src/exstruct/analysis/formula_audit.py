@@ -0,0 +1,261 @@
+"""Flag fragile formulas: volatile/external functions and circular references."""
+
+from __future__ import annotations
+
+from collections.abc import Mapping
+import re
+
+from ..models import FormulaAudit, FormulaFunctionUsage, col_index_to_alpha
+
+VOLATILE_FUNCTIONS = frozenset(
+    {
+        "NOW",
+        "TODAY",
+        "RAND",
+        "RANDBETWEEN",
+        "RANDARRAY",
+        "INDIRECT",
+        "OFFSET",
+        "CELL",
+        "INFO",
+    }
+)
+EXTERNAL_FUNCTIONS = frozenset(
+    {
+        "RTD",
+        "WEBSERVICE",
+        "CUBEKPIMEMBER",
+        "CUBEMEMBER",
+        "CUBEMEMBERPROPERTY",
+        "CUBERANKEDMEMBER",
+        "CUBESET",
+        "CUBESETCOUNT",
+        "CUBEVALUE",
+    }
+)
+
+# Function call names, allowing the `_xlfn.` prefix used for newer functions.
+_FUNCTION_CALL = re.compile(r"(?<![\w.])(?:_xlfn\.)?([A-Za-z][\w.]*)\s*\(")
+# String literals and quoted sheet names, which may contain call-like text.
+_QUOTED = re.compile(r'"(?:[^"]|"")*"|\'(?:[^\']|\'\')*\'')
+_STRING = re.compile(r'"(?:[^"]|"")*"')
+# Sheet-qualified references point outside the sheet being analyzed.
+_QUALIFIED_REF = re.compile(
+    r"(?:'(?:[^']|'')*'|[\w.\[\]]+)!\$?[A-Z]*\$?\d*(?::\$?[A-Z]*\$?\d*)?",
+    re.IGNORECASE,
+)
+_CELL_REF = re.compile(
+    r"(?<![\w.$])(?:"
+    r"\$?(?P<c1>[A-Z]{1,3})\$?(?P<r1>\d+)(?::\$?(?P<c2>[A-Z]{1,3})\$?(?P<r2>\d+))?"
+    r"|\$?(?P<col1>[A-Z]{1,3}):\$?(?P<col2>[A-Z]{1,3})"
+    r"|\$?(?P<row1>\d+):\$?(?P<row2>\d+)"
+    r")(?![\w(])",
+    re.IGNORECASE,
+)
+_MAX_ROW = 1048576
+_MAX_COL = 16383
+
+Cell = tuple[int, int]
+_Bounds = tuple[int, int, int, int]
+
+
+def find_formula_functions(formula: str) -> set[str]:
+    """Return the upper-cased names of functions called in a formula.
+
+    Args:
+        formula: Formula text (with or without the leading ``=``).
+
+    Returns:
+        Set of function names, excluding text inside string literals.
+    """
+    stripped = _QUOTED.sub('""', formula)
+    return {match.group(1).upper() for match in _FUNCTION_CALL.finditer(stripped)}
+
+
+def audit_formulas(
+    formulas_map: Mapping[str, list[tuple[int, int]]],
+) -> FormulaAudit | None:
+    """Collect the cells that call volatile or external-data functions.
+
+    Args:
+        formulas_map: Formula text mapped to (row, column) positions, where row
+            is 1-based and column is 0-based.
+
+    Returns:
+        FormulaAudit, or None when nothing is flagged.
+    """
+    volatile: dict[str, list[str]] = {}
+    external: dict[str, list[str]] = {}
+    for formula, positions in formulas_map.items():
+        for name in find_formula_functions(formula):
+            if name in VOLATILE_FUNCTIONS:
+                target = volatile
+            elif name in EXTERNAL_FUNCTIONS:
+                target = external
+            else:
+                continue
+            target.setdefault(name, []).extend(
+                f"{col_index_to_alpha(col)}{row}" for row, col in positions
+            )
+    cycles = find_circular_references(formulas_map)
+    if not volatile and not external and not cycles:
+        return None
+    return FormulaAudit(
+        volatile=_usages(volatile),
+        external=_usages(external),
+        circular_references=[
+            [f"{col_index_to_alpha(col)}{row}" for row, col in cycle]
+            for cycle in cycles
+        ],
+    )
+
+
+def find_circular_references(
+    formulas_map: Mapping[str, list[tuple[int, int]]],
+) -> list[list[Cell]]:
+    """Find groups of formula cells that depend on each other.
+
+    Only references within the same sheet are followed; sheet-qualified
+    references are ignored.
+
+    Args:
+        formulas_map: Formula text mapped to (row, column) positions, where row
+            is 1-based and column is 0-based.
+
+    Returns:
+        Cycles as row-major sorted lists of (row, column) cells, ordered by
+        their first cell. A cell referencing itself forms a single-cell cycle.
+    """
+    refs_by_cell: dict[Cell, list[_Bounds]] = {}
+    for formula, positions in formulas_map.items():
+        refs = parse_formula_references(formula)
+        for position in positions:
+            refs_by_cell[position] = refs
+    graph = {
+        cell: _precedents(refs, refs_by_cell) for cell, refs in refs_by_cell.items()
+    }
+    cycles = [
+        sorted(component)
+        for component in _strongly_connected(graph)
+        if len(component) > 1 or component[0] in graph[component[0]]
+    ]
+    return sorted(cycles)
+
+
+def parse_formula_references(formula: str) -> list[_Bounds]:
+    """Return the unqualified cell ranges referenced by a formula.
+
+    Args:
+        formula: Formula text.
+
+    Returns:
+        (first_row, first_col, last_row, last_col) bounds; rows are 1-based and
+        columns 0-based. Whole-column and whole-row references span the sheet.
+    """
+    text = _QUALIFIED_REF.sub(" ", _STRING.sub('""', formula))
+    refs: list[_Bounds] = []
+    for match in _CELL_REF.finditer(text):
+        groups = match.groupdict()
+        if groups["c1"]:
+            c1 = _col_index(groups["c1"])
+            r1 = int(groups["r1"])
+            c2 = _col_index(groups["c2"]) if groups["c2"] else c1
+            r2 = int(groups["r2"]) if groups["r2"] else r1
+        elif groups["col1"]:
+            c1, c2 = _col_index(groups["col1"]), _col_index(groups["col2"])
+            r1, r2 = 1, _MAX_ROW
+        else:
+            r1, r2 = int(groups["row1"]), int(groups["row2"])
+            c1, c2 = 0, _MAX_COL
+        refs.append((min(r1, r2), min(c1, c2), max(r1, r2), max(c1, c2)))
+    return refs
+
+
+def _col_index(letters: str) -> int:
+    """Convert column letters to a 0-based index."""
+    index = 0
+    for ch in letters.upper():
+        index = index * 26 + ord(ch) - ord("A") + 1
+    return index - 1
+
+
+def _precedents(refs: list[_Bounds], cells: Mapping[Cell, object]) -> set[Cell]:
+    """Return the formula cells covered by a list of references."""
+    found: set[Cell] = set()
+    for r1, c1, r2, c2 in refs:
+        area = (r2 - r1 + 1) * (c2 - c1 + 1)
+        if area <= len(cells):
+            found.update(
+                (row, col)
+                for row in range(r1, r2 + 1)
+                for col in range(c1, c2 + 1)
+                if (row, col) in cells
+            )
+        else:
+            found.update(
+                (row, col) for row, col in cells if r1 <= row <= r2 and c1 <= col <= c2
+            )
+    return found
+
+
+def _strongly_connected(graph: Mapping[Cell, set[Cell]]) -> list[list[Cell]]:
+    """Return strongly connected components (iterative Tarjan)."""
+    index: dict[Cell, int] = {}
+    lowlink: dict[Cell, int] = {}
+    stack: list[Cell] = []
+    on_stack: set[Cell] = set()
+    components: list[list[Cell]] = []
+    for root in graph:
+        if root in index:
+            continue
+        work: list[tuple[Cell, list[Cell]]] = [(root, sorted(graph[root]))]
+        index[root] = lowlink[root] = len(index)
+        stack.append(root)
+        on_stack.add(root)
+        while work:
+            node, pending = work[-1]
+            if pending:
+                succ = pending.pop()
+                if succ not in index:
+                    index[succ] = lowlink[succ] = len(index)
+                    stack.append(succ)
+                    on_stack.add(succ)
+                    work.append((succ, sorted(graph[succ])))
+                elif succ in on_stack:
+                    lowlink[node] = min(lowlink[node], index[succ])
+                continue
+            work.pop()
+            if work:
+                parent = work[-1][0]
+                lowlink[parent] = min(lowlink[parent], lowlink[node])
+            if lowlink[node] == index[node]:
+                components.append(_pop_component(node, stack, on_stack))
+    return components
+
+
+def _pop_component(node: Cell, stack: list[Cell], on_stack: set[Cell]) -> list[Cell]:
+    """Pop the component rooted at node off the Tarjan stack."""
+    component: list[Cell] = []
+    while True:
+        member = stack.pop()
+        on_stack.discard(member)
+        component.append(member)
+        if member == node:
+            return component
+
+
+def _usages(cells_by_function: dict[str, list[str]]) -> list[FormulaFunctionUsage]:
+    """Build usages sorted by function name, with cells in row-major order."""
+    return [
+        FormulaFunctionUsage(
+            function=name,
+            cells=sorted(cells_by_function[name], key=_cell_sort_key),
+        )
+        for name in sorted(cells_by_function)
+    ]
+
+
+def _cell_sort_key(cell: str) -> tuple[int, int, str]:
+    """Sort A1 addresses by row, then column."""
+    letters = cell.rstrip("0123456789")
+    return (int(cell[len(letters) :]), len(letters), letters)
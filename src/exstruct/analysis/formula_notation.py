@@ -0,0 +1,204 @@
+"""Convert A1 formulas to R1C1 notation (``include_formulas_r1c1``).
+
+In R1C1 notation relative references are written as offsets from the formula
+cell (``R[-1]C``) and absolute ones as fixed positions (``R1C3``), so a formula
+filled down or across reads the same in every cell. Grouping ``formulas_map``
+by R1C1 text therefore collapses copied formulas into one entry while keeping
+the ``$`` markers of the original visible as absolute parts, and lets
+``compress_formulas`` replace a filled range with one ``FormulaBlock``.
+"""
+
+from __future__ import annotations
+
+from collections.abc import Mapping
+import re
+
+from openpyxl.formula.tokenizer import Token, Tokenizer
+
+from ..models import FormulaBlock, col_alpha_to_index, col_index_to_alpha
+
+_MAX_COLUMNS = 16384
+_MAX_ROWS = 1048576
+
+_CELL = re.compile(r"(\$?)([A-Za-z]{1,3})(\$?)([0-9]+)")
+_COLUMNS = re.compile(r"(\$?)([A-Za-z]{1,3}):(\$?)([A-Za-z]{1,3})")
+_ROWS = re.compile(r"(\$?)([0-9]+):(\$?)([0-9]+)")
+
+
+def to_r1c1(formula: str, row: int, col: int) -> str:
+    """Rewrite the cell references of an A1 formula in R1C1 notation.
+
+    Args:
+        formula: A1 formula text, with or without the leading ``=``.
+        row: Row of the formula cell (1-based).
+        col: Column of the formula cell (0-based).
+
+    Returns:
+        The formula with cell, column, and row references converted
+        (``=SUM(B$1:B3)`` in C4 becomes ``=SUM(R1C[-1]:R[-1]C[-1])``). Defined
+        names, structured references, and text the tokenizer cannot parse are
+        left unchanged.
+    """
+    text = formula if formula.startswith("=") else f"={formula}"
+    try:
+        tokens = Tokenizer(text).items
+    except Exception:
+        return text
+    parts = ["="]
+    for token in tokens:
+        if token.type == Token.OPERAND and token.subtype == Token.RANGE:
+            parts.append(_convert_reference(token.value, row, col + 1))
+        else:
+            parts.append(token.value)
+    return "".join(parts)
+
+
+def r1c1_formulas_map(
+    formulas_map: Mapping[str, list[tuple[int, int]]],
+) -> dict[str, list[tuple[int, int]]]:
+    """Regroup a formulas map by R1C1 text.
+
+    Args:
+        formulas_map: A1 formula text mapped to (row, column) positions, where
+            row is 1-based and column is 0-based.
+
+    Returns:
+        R1C1 formula text mapped to the same positions; copies of one formula
+        share a single key.
+    """
+    result: dict[str, list[tuple[int, int]]] = {}
+    for formula, positions in formulas_map.items():
+        for row, col in positions:
+            key = to_r1c1(formula, row, col)
+            result.setdefault(key, []).append((row, col))
+    return result
+
+
+def compress_formulas(
+    formulas_map: Mapping[str, list[tuple[int, int]]],
+) -> tuple[list[FormulaBlock], dict[str, list[tuple[int, int]]]]:
+    """Collapse formulas filled down or across into rectangular blocks.
+
+    Cells sharing an R1C1 formula are joined into vertical runs per column,
+    and runs spanning the same rows in adjacent columns into rectangles. Each
+    rectangle of two or more cells becomes a ``FormulaBlock``.
+
+    Args:
+        formulas_map: A1 formula text mapped to (row, column) positions, where
+            row is 1-based and column is 0-based.
+
+    Returns:
+        The blocks in row-major order of their top-left cells, and the
+        formulas map of the cells not covered by any block.
+    """
+    a1_at: dict[tuple[int, int], str] = {}
+    for formula, positions in formulas_map.items():
+        for position in positions:
+            a1_at[position] = formula
+    blocks: list[tuple[int, int, int, int, str]] = []
+    covered: set[tuple[int, int]] = set()
+    for pattern, positions in r1c1_formulas_map(formulas_map).items():
+        for r1, c1, r2, c2 in _rectangles(positions):
+            if r1 == r2 and c1 == c2:
+                continue
+            blocks.append((r1, c1, r2, c2, pattern))
+            covered.update(
+                (row, col) for row in range(r1, r2 + 1) for col in range(c1, c2 + 1)
+            )
+    blocks.sort()
+    remaining: dict[str, list[tuple[int, int]]] = {}
+    for formula, positions in formulas_map.items():
+        kept = [position for position in positions if position not in covered]
+        if kept:
+            remaining[formula] = kept
+    return [
+        FormulaBlock(
+            range=_a1_range(r1, c1, r2, c2), r1c1=pattern, formula=a1_at[(r1, c1)]
+        )
+        for r1, c1, r2, c2, pattern in blocks
+    ], remaining
+
+
+def _rectangles(positions: list[tuple[int, int]]) -> list[tuple[int, int, int, int]]:
+    """Group cells into (r1, c1, r2, c2) rectangles of vertical runs."""
+    runs: list[tuple[int, int, int]] = []
+    for row, col in sorted(set(positions), key=lambda item: (item[1], item[0])):
+        if runs and runs[-1][2] == col and runs[-1][1] == row - 1:
+            runs[-1] = (runs[-1][0], row, col)
+        else:
+            runs.append((row, row, col))
+    rectangles: list[tuple[int, int, int, int]] = []
+    open_by_rows: dict[tuple[int, int], int] = {}
+    for r1, r2, col in runs:
+        index = open_by_rows.get((r1, r2))
+        if index is not None and rectangles[index][3] == col - 1:
+            top, left, bottom, _ = rectangles[index]
+            rectangles[index] = (top, left, bottom, col)
+            continue
+        open_by_rows[(r1, r2)] = len(rectangles)
+        rectangles.append((r1, col, r2, col))
+    return rectangles
+
+
+def _a1_range(r1: int, c1: int, r2: int, c2: int) -> str:
+    return f"{col_index_to_alpha(c1)}{r1}:{col_index_to_alpha(c2)}{r2}"
+
+
+def _convert_reference(value: str, row: int, col: int) -> str:
+    """Convert one range operand, keeping any sheet or workbook prefix."""
+    prefix, sep, local = value.rpartition("!")
+    converted = _convert_local(local, row, col)
+    if converted is None:
+        return value
+    return f"{prefix}{sep}{converted}"
+
+
+def _convert_local(local: str, row: int, col: int) -> str | None:
+    """Convert a sheet-local reference; None when it is not a cell reference."""
+    if match := _COLUMNS.fullmatch(local):
+        first = _column_part(match.group(1), match.group(2), col)
+        last = _column_part(match.group(3), match.group(4), col)
+        if first is None or last is None:
+            return None
+        return first if first == last else f"{first}:{last}"
+    if match := _ROWS.fullmatch(local):
+        first = _row_part(match.group(1), match.group(2), row)
+        last = _row_part(match.group(3), match.group(4), row)
+        if first is None or last is None:
+            return None
+        return first if first == last else f"{first}:{last}"
+    cells = local.split(":")
+    if len(cells) > 2:
+        return None
+    converted: list[str] = []
+    for cell in cells:
+        match = _CELL.fullmatch(cell)
+        if match is None:
+            return None
+        row_part = _row_part(match.group(3), match.group(4), row)
+        col_part = _column_part(match.group(1), match.group(2), col)
+        if row_part is None or col_part is None:
+            return None
+        converted.append(f"{row_part}{col_part}")
+    return ":".join(converted)
+
+
+def _row_part(marker: str, digits: str, row: int) -> str | None:
+    target = int(digits)
+    if not 1 <= target <= _MAX_ROWS:
+        return None
+    return _axis_part("R", marker, target, row)
+
+
+def _column_part(marker: str, letters: str, col: int) -> str | None:
+    target = col_alpha_to_index(letters) + 1
+    if target > _MAX_COLUMNS:
+        return None
+    return _axis_part("C", marker, target, col)
+
+
+def _axis_part(axis: str, marker: str, target: int, origin: int) -> str:
+    if marker:
+        return f"{axis}{target}"
+    offset = target - origin
+    return axis if offset == 0 else f"{axis}[{offset}]"
@@ -0,0 +1,164 @@
+"""Locate label cells by text and read the values next to them."""
+
+from __future__ import annotations
+
+from collections.abc import Callable, Sequence
+from dataclasses import dataclass
+import difflib
+import re
+from typing import Literal
+import unicodedata
+
+from openpyxl.utils import column_index_from_string
+
+from ..models import CellRow, SheetData
+
+CellValue = int | float | str
+LabelMatchMode = Literal["exact", "regex", "fuzzy"]
+LabelDirection = Literal["right", "below"]
+
+
+@dataclass(frozen=True)
+class LabelMatch:
+    """A label cell and the values found next to it.
+
+    Attributes:
+        row: Row of the label (1-based, as ``CellRow.r``).
+        col: Column of the label (0-based).
+        text: Label cell text.
+        score: Match score; 1.0 for exact/regex, the similarity ratio for fuzzy.
+        values: Values read from the label in the lookup direction.
+    """
+
+    row: int
+    col: int
+    text: str
+    score: float
+    values: list[CellValue | None]
+
+
+def find_by_label(
+    sheet: SheetData | Sequence[CellRow],
+    label: str,
+    *,
+    match: LabelMatchMode = "exact",
+    direction: LabelDirection = "right",
+    offset: int | None = None,
+    count: int = 1,
+    threshold: float = 0.8,
+) -> list[LabelMatch]:
+    """Find cells whose text matches a label and read their adjacent values.
+
+    ``exact`` compares trimmed text, ``regex`` searches the trimmed text with
+    ``label`` as a pattern, and ``fuzzy`` compares NFKC-normalized,
+    case-folded text with whitespace removed, so ``合計金額：`` still matches
+    ``合計金額``.
+
+    Args:
+        sheet: Sheet or its rows (numeric or alpha column keys).
+        label: Label text, or a regular expression for ``regex``.
+        match: Matching mode.
+        direction: Read values to the right of or below the label.
+        offset: Distance of the first value from the label. None skips empty
+            cells and reads the nearest non-empty ones.
+        count: Number of values to read.
+        threshold: Minimum similarity (0-1) for ``fuzzy``.
+
+    Returns:
+        Matches in row-major order; fuzzy matches are sorted by descending
+        score first. With ``offset`` set, ``values`` holds exactly ``count``
+        entries (None for empty cells); otherwise up to ``count`` values.
+
+    Raises:
+        ValueError: If ``label`` is not a valid regular expression.
+    """
+    grid = _cell_grid(sheet.rows if isinstance(sheet, SheetData) else sheet)
+    scorer = _scorer(label, match, threshold)
+    matches: list[LabelMatch] = []
+    for (row, col), value in sorted(grid.items()):
+        if not isinstance(value, str):
+            continue
+        score = scorer(value)
+        if score is None:
+            continue
+        values = _adjacent_values(grid, (row, col), direction, offset, count)
+        matches.append(
+            LabelMatch(row=row + 1, col=col, text=value, score=score, values=values)
+        )
+    if match == "fuzzy":
+        matches.sort(key=lambda item: -item.score)
+    return matches
+
+
+def _cell_grid(rows: Sequence[CellRow]) -> dict[tuple[int, int], CellValue]:
+    """Index cell values by (row, column), both 0-based."""
+    grid: dict[tuple[int, int], CellValue] = {}
+    for row in rows:
+        for key, value in row.c.items():
+            col = _column_index(key)
+            if col is not None:
+                grid[(row.r - 1, col)] = value
+    return grid
+
+
+def _column_index(key: str) -> int | None:
+    """Return the 0-based column of a numeric or alpha column key."""
+    if key.isdigit():
+        return int(key)
+    try:
+        return column_index_from_string(key) - 1
+    except ValueError:
+        return None
+
+
+def _scorer(
+    label: str, match: LabelMatchMode, threshold: float
+) -> Callable[[str], float | None]:
+    """Build a function returning a cell text's score, or None if no match."""
+    if match == "regex":
+        try:
+            pattern = re.compile(label)
+        except re.error as exc:
+            raise ValueError(f"Invalid label pattern {label!r}: {exc}") from exc
+        return lambda text: 1.0 if pattern.search(text.strip()) else None
+    if match == "fuzzy":
+        target = _fuzzy_key(label)
+
+        def _fuzzy(text: str) -> float | None:
+            ratio = difflib.SequenceMatcher(None, target, _fuzzy_key(text)).ratio()
+            return ratio if ratio >= threshold else None
+
+        return _fuzzy
+    target = label.strip()
+    return lambda text: 1.0 if text.strip() == target else None
+
+
+def _fuzzy_key(text: str) -> str:
+    normalized = unicodedata.normalize("NFKC", text).casefold()
+    return "".join(normalized.split())
+
+
+def _adjacent_values(
+    grid: dict[tuple[int, int], CellValue],
+    origin: tuple[int, int],
+    direction: LabelDirection,
+    offset: int | None,
+    count: int,
+) -> list[CellValue | None]:
+    row, col = origin
+    step = (0, 1) if direction == "right" else (1, 0)
+    if offset is not None:
+        return [
+            grid.get((row + step[0] * (offset + i), col + step[1] * (offset + i)))
+            for i in range(count)
+        ]
+    ahead = sorted(
+        (position, value)
+        for position, value in grid.items()
+        if (direction == "right" and position[0] == row and position[1] > col)
+        or (direction == "below" and position[1] == col and position[0] > row)
+    )
+    return [value for _position, value in ahead[:count]]
+
+
+__all__ = ["LabelMatch", "find_by_label"]
@@ -0,0 +1,88 @@
+"""Detect overlapping shapes and resolve which one is drawn on top."""
+
+from __future__ import annotations
+
+from collections.abc import Sequence
+from dataclasses import dataclass
+
+from ..models import Arrow, Shape, ShapeOverlap, SmartArt
+
+
+@dataclass(frozen=True)
+class _Box:
+    """Axis-aligned bounds of a shape with its stacking key."""
+
+    shape_id: int
+    left: float
+    top: float
+    right: float
+    bottom: float
+    stack_key: tuple[int, int]
+
+    @property
+    def area(self) -> float:
+        """Return the box area."""
+        return (self.right - self.left) * (self.bottom - self.top)
+
+
+def find_shape_overlaps(
+    shapes: Sequence[Shape | Arrow | SmartArt],
+) -> list[ShapeOverlap]:
+    """Find pairs of overlapping shapes.
+
+    Connectors are ignored because they touch the shapes they connect by design.
+    Shapes without an id or size are skipped. The front shape is the one with
+    the higher ``z_order``; document order breaks ties and missing values.
+
+    Args:
+        shapes: Shapes extracted from a sheet.
+
+    Returns:
+        Overlapping pairs sorted by (back_id, front_id).
+    """
+    boxes = sorted(
+        (box for index, shape in enumerate(shapes) if (box := _to_box(index, shape))),
+        key=lambda box: box.left,
+    )
+    overlaps: list[ShapeOverlap] = []
+    for i, first in enumerate(boxes):
+        for second in boxes[i + 1 :]:
+            if second.left >= first.right:
+                break
+            overlap = _overlap(first, second)
+            if overlap is not None:
+                overlaps.append(overlap)
+    overlaps.sort(key=lambda item: (item.back_id, item.front_id))
+    return overlaps
+
+
+def _to_box(index: int, shape: Shape | Arrow | SmartArt) -> _Box | None:
+    """Convert a sized, identified non-connector shape into a box."""
+    if isinstance(shape, Arrow) or shape.id is None:
+        return None
+    if shape.w is None or shape.h is None or shape.w <= 0 or shape.h <= 0:
+        return None
+    z_order = shape.z_order if shape.z_order is not None else 0
+    return _Box(
+        shape_id=shape.id,
+        left=float(shape.l),
+        top=float(shape.t),
+        right=float(shape.l + shape.w),
+        bottom=float(shape.t + shape.h),
+        stack_key=(z_order, index),
+    )
+
+
+def _overlap(first: _Box, second: _Box) -> ShapeOverlap | None:
+    """Return the overlap between two boxes, or None if they only touch."""
+    width = min(first.right, second.right) - max(first.left, second.left)
+    height = min(first.bottom, second.bottom) - max(first.top, second.top)
+    if width <= 0 or height <= 0:
+        return None
+    back, front = sorted((first, second), key=lambda box: box.stack_key)
+    smaller = min(first.area, second.area)
+    return ShapeOverlap(
+        back_id=back.shape_id,
+        front_id=front.shape_id,
+        overlap_ratio=round(min(width * height / smaller, 1.0), 4),
+    )
@@ -0,0 +1,125 @@
+"""Full-text search over cell values, comments, shape texts, and chart titles."""
+
+from __future__ import annotations
+
+from collections.abc import Mapping
+import re
+from typing import Literal
+
+from openpyxl.utils import get_column_letter
+
+from ..models import Arrow, SearchHit, Shape, SheetData, SmartArt, SmartArtNode
+from .label_lookup import _column_index
+
+# Characters of context kept on each side of a match.
+_CONTEXT_CHARS = 30
+
+SearchKind = Literal["cell", "comment", "shape", "chart"]
+
+
+def search_workbook(
+    sheets: Mapping[str, SheetData],
+    pattern: str,
+    *,
+    ignore_case: bool = False,
+    fixed_strings: bool = False,
+    comments: Mapping[str, Mapping[str, str]] | None = None,
+) -> list[SearchHit]:
+    """Search the text of extracted sheets for a pattern.
+
+    Each text yields at most one hit (its first match), like a line in grep.
+    Numbers are searched in their extracted form (e.g. ``1200.5``).
+
+    Args:
+        sheets: Sheets keyed by name (numeric or alpha column keys).
+        pattern: Regular expression, or literal text with ``fixed_strings``.
+        ignore_case: Match case-insensitively.
+        fixed_strings: Treat ``pattern`` as literal text.
+        comments: Optional ``{sheet: {address: text}}`` cell comments, which
+            are not part of the extracted sheets.
+
+    Returns:
+        Hits in sheet order; within a sheet, cells (row-major), comments,
+        shapes, then charts.
+
+    Raises:
+        ValueError: If ``pattern`` is not a valid regular expression.
+    """
+    try:
+        regex = re.compile(
+            re.escape(pattern) if fixed_strings else pattern,
+            re.IGNORECASE if ignore_case else 0,
+        )
+    except re.error as exc:
+        raise ValueError(f"Invalid search pattern {pattern!r}: {exc}") from exc
+    hits: list[SearchHit] = []
+    for sheet_name, sheet in sheets.items():
+        sheet_comments = (comments or {}).get(sheet_name, {})
+        for kind, location, text in _sheet_texts(sheet, sheet_comments):
+            found = regex.search(text)
+            if found is None:
+                continue
+            hits.append(
+                SearchHit(
+                    sheet=sheet_name,
+                    kind=kind,
+                    location=location,
+                    match=found.group(0),
+                    context=_context(text, found.start(), found.end()),
+                )
+            )
+    return hits
+
+
+def _sheet_texts(
+    sheet: SheetData, comments: Mapping[str, str]
+) -> list[tuple[SearchKind, str, str]]:
+    """List the searchable (kind, location, text) entries of a sheet."""
+    texts: list[tuple[SearchKind, str, str]] = []
+    for row in sheet.rows:
+        cells = sorted(
+            (
+                (col, value)
+                for key, value in row.c.items()
+                if (col := _column_index(key)) is not None
+            ),
+            key=lambda cell: cell[0],
+        )
+        for col, value in cells:
+            texts.append(("cell", f"{get_column_letter(col + 1)}{row.r}", str(value)))
+    for address, text in comments.items():
+        texts.append(("comment", address, text))
+    for index, shape in enumerate(sheet.shapes):
+        location = shape.covered_range or f"shape {shape.id or index + 1}"
+        texts.append(("shape", location, _shape_text(shape)))
+    for chart in sheet.charts:
+        if chart.title:
+            texts.append(("chart", chart.name, chart.title))
+    return texts
+
+
+def _shape_text(shape: Shape | Arrow | SmartArt) -> str:
+    """Return shape text, followed by SmartArt node texts."""
+    if not isinstance(shape, SmartArt):
+        return shape.text
+    texts = [shape.text] if shape.text else []
+    stack: list[SmartArtNode] = list(reversed(shape.nodes))
+    while stack:
+        node = stack.pop()
+        if node.text:
+            texts.append(node.text)
+        stack.extend(reversed(node.kids))
+    return "\n".join(texts)
+
+
+def _context(text: str, start: int, end: int) -> str:
+    """Clip text around a match and flatten line breaks."""
+    left = max(start - _CONTEXT_CHARS, 0)
+    right = min(end + _CONTEXT_CHARS, len(text))
+    snippet = " ".join(text[left:right].splitlines())
+    prefix = "..." if left > 0 else ""
+    suffix = "..." if right < len(text) else ""
+    return f"{prefix}{snippet}{suffix}"
+
+
+__all__ = ["search_workbook"]
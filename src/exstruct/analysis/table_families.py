@@ -0,0 +1,99 @@
+"""Group table candidates that share a header row across sheets."""
+
+from __future__ import annotations
+
+from collections.abc import Mapping, Sequence
+
+from ..core.ranges import RangeBounds, parse_range_zero_based
+from ..models import CellRow, SheetData, TableFamily, TableFamilyMember
+
+# A header needs this many non-empty cells to identify a family.
+_MIN_HEADER_CELLS = 2
+
+
+def build_table_families(sheets: Mapping[str, SheetData]) -> list[TableFamily]:
+    """Find tables on different sheets whose header rows are identical.
+
+    The header of a table candidate is its first non-empty row, read across
+    the table's columns (empty cells as ``""``) with surrounding whitespace
+    stripped. Tables sharing a header on at least two sheets form a family,
+    such as the same table repeated on monthly tabs.
+
+    Args:
+        sheets: Sheets of a workbook keyed by name, with 0-based numeric
+            column keys.
+
+    Returns:
+        Families in order of their first table (sheet order, then table
+        order); each lists its tables in the same order.
+    """
+    groups: dict[tuple[str, ...], list[TableFamilyMember]] = {}
+    for sheet_name, sheet in sheets.items():
+        for candidate in sheet.table_candidates:
+            bounds = parse_range_zero_based(candidate)
+            if bounds is None:
+                continue
+            header = _table_header(sheet.rows, bounds)
+            if header is None:
+                continue
+            groups.setdefault(header, []).append(
+                TableFamilyMember(sheet=sheet_name, table=candidate)
+            )
+    return [
+        TableFamily(headers=list(header), members=members)
+        for header, members in groups.items()
+        if len({member.sheet for member in members}) > 1
+    ]
+
+
+def filter_table_families(
+    families: Sequence[TableFamily], sheets: Mapping[str, SheetData]
+) -> list[TableFamily]:
+    """Drop family members whose sheet or table is no longer present.
+
+    Args:
+        families: Families built from the unfiltered workbook.
+        sheets: Remaining sheets keyed by name.
+
+    Returns:
+        Families that still span at least two sheets.
+    """
+    result: list[TableFamily] = []
+    for family in families:
+        members = [
+            member
+            for member in family.members
+            if member.sheet in sheets
+            and member.table in sheets[member.sheet].table_candidates
+        ]
+        if len({member.sheet for member in members}) > 1:
+            result.append(family.model_copy(update={"members": members}))
+    return result
+
+
+def _table_header(
+    rows: Sequence[CellRow], bounds: RangeBounds
+) -> tuple[str, ...] | None:
+    """Return the first non-empty row of a table, or None if not header-like."""
+    for row in rows:
+        if not bounds.r1 <= row.r - 1 <= bounds.r2:
+            continue
+        cells: dict[int, int | float | str] = {}
+        for key, value in row.c.items():
+            try:
+                col = int(key)
+            except ValueError:
+                continue
+            if bounds.c1 <= col <= bounds.c2:
+                cells[col] = value
+        if not cells:
+            continue
+        header = tuple(
+            str(cells.get(col, "")).strip()
+            for col in range(bounds.c1, bounds.c2 + 1)
+        )
+        has_text = any(isinstance(value, str) for value in cells.values())
+        if has_text and sum(1 for text in header if text) >= _MIN_HEADER_CELLS:
+            return header
+        return None
+    return None
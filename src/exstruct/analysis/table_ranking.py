@@ -0,0 +1,100 @@
+"""Confidence scores and ranking of table candidates (``rank_tables``)."""
+
+from __future__ import annotations
+
+from collections.abc import Mapping, Sequence
+from typing import TYPE_CHECKING
+
+from ..core.ranges import parse_range_zero_based
+from ..models import CellRow, CellValue
+
+if TYPE_CHECKING:
+    from ..core.cells import TableStyleSignals
+
+
+def table_confidence_scores(
+    rows: Sequence[CellRow],
+    table_candidates: Sequence[str],
+    style_signals: Mapping[str, TableStyleSignals] | None = None,
+) -> dict[str, float]:
+    """Score how likely each table candidate is a real table.
+
+    The score averages four signals in 0.0-1.0: the share of bordered cells,
+    header emphasis (a bold or filled first row; a text-only first row above
+    numeric data counts half), the share of non-empty cells, and
+    rectangularity (the share of rows filled at least halfway).
+
+    Args:
+        rows: Extracted rows with 0-based numeric column keys.
+        table_candidates: A1 table ranges.
+        style_signals: Border and header styling per range, as read by
+            ``extract_table_style_signals``; missing ranges score 0 on both.
+
+    Returns:
+        Mapping of table range to score rounded to three decimals.
+    """
+    by_row = {row.r: row for row in rows}
+    signals = style_signals or {}
+    scores: dict[str, float] = {}
+    for candidate in table_candidates:
+        bounds = parse_range_zero_based(candidate)
+        if bounds is None:
+            continue
+        matrix = [
+            [_value(by_row.get(r + 1), c) for c in range(bounds.c1, bounds.c2 + 1)]
+            for r in range(bounds.r1, bounds.r2 + 1)
+        ]
+        style = signals.get(candidate)
+        border = style.border_ratio if style else 0.0
+        header = max(
+            style.header_emphasis if style else 0.0, _text_header_signal(matrix)
+        )
+        total = border + header + _density(matrix) + _rectangularity(matrix)
+        scores[candidate] = round(total / 4, 3)
+    return scores
+
+
+def rank_table_candidates(
+    table_candidates: Sequence[str], scores: Mapping[str, float]
+) -> list[str]:
+    """Order candidates by descending score, keeping ties in their order."""
+    return sorted(table_candidates, key=lambda ref: -scores.get(ref, 0.0))
+
+
+def _value(row: CellRow | None, col: int) -> CellValue | None:
+    if row is None:
+        return None
+    value = row.c.get(str(col))
+    if isinstance(value, str) and not value.strip():
+        return None
+    return value
+
+
+def _density(matrix: list[list[CellValue | None]]) -> float:
+    cells = [value for row in matrix for value in row]
+    if not cells:
+        return 0.0
+    return sum(value is not None for value in cells) / len(cells)
+
+
+def _rectangularity(matrix: list[list[CellValue | None]]) -> float:
+    if not matrix or not matrix[0]:
+        return 0.0
+    width = len(matrix[0])
+    full = sum(
+        1 for row in matrix if sum(value is not None for value in row) * 2 >= width
+    )
+    return full / len(matrix)
+
+
+def _text_header_signal(matrix: list[list[CellValue | None]]) -> float:
+    """0.5 when the first row is all text and the rows below hold numbers."""
+    if len(matrix) < 2:
+        return 0.0
+    header = [value for value in matrix[0] if value is not None]
+    if not header or not all(isinstance(value, str) for value in header):
+        return 0.0
+    has_numbers = any(
+        isinstance(value, int | float) for row in matrix[1:] for value in row
+    )
+    return 0.5 if has_numbers else 0.0
@@ -0,0 +1,253 @@
+"""Per-column statistics of table candidates (``include_table_stats``)."""
+
+from __future__ import annotations
+
+from collections import Counter
+from collections.abc import Mapping, Sequence
+from datetime import datetime
+import re
+import statistics
+
+from ..core.ranges import parse_range_zero_based
+from ..models import (
+    CellRow,
+    CellValue,
+    ColumnAnomaly,
+    ColumnStats,
+    as_datetime,
+    col_index_to_alpha,
+)
+
+_IQR_FACTOR = 1.5
+_MIN_OUTLIER_SAMPLE = 4
+
+# Trailing bracketed text of a header: "Weight [kg]", "金額(千円)", "単価【円】".
+_HEADER_UNIT = re.compile(r"[(\[（［【]\s*([^()\[\]（）［］【】]+?)\s*[)\]）］】]\s*$")
+# Currency part of a locale tag in a number format: [$€-407], [$USD].
+_FORMAT_CURRENCY = re.compile(r"\[\$([^\]\-]+)(?:-[0-9A-Fa-f]+)?\]")
+_FORMAT_SECTION = re.compile(r"\[[^\]]*\]")
+# Longest first, so "US$" wins over "$".
+_CURRENCY_CODES = {
+    "US$": "USD",
+    "USD": "USD",
+    "EUR": "EUR",
+    "GBP": "GBP",
+    "JPY": "JPY",
+    "CNY": "CNY",
+    "KRW": "KRW",
+    "INR": "INR",
+    "CHF": "CHF",
+    "€": "EUR",
+    "£": "GBP",
+    "¥": "JPY",
+    "￥": "JPY",
+    "円": "JPY",
+    "元": "CNY",
+    "₩": "KRW",
+    "₹": "INR",
+    "$": "USD",
+}
+
+
+def table_column_stats(
+    rows: Sequence[CellRow],
+    table_candidates: Sequence[str],
+    *,
+    iqr_factor: float = _IQR_FACTOR,
+    now: datetime | None = None,
+    number_formats: Mapping[tuple[int, int], str] | None = None,
+) -> dict[str, list[ColumnStats]]:
+    """Profile every column of each table candidate.
+
+    The first row of a candidate is taken as its header; the remaining rows
+    are the data the statistics describe. Booleans and text never count as
+    numbers, so ``min``/``max``/``mean`` stay None for text-only columns.
+
+    Each column also lists its anomalies: numbers outside
+    ``iqr_factor`` x IQR of the quartiles (columns with at least four
+    numbers), cells whose type (number, date, text) differs from the column's
+    most common type, and dates later than ``now``.
+
+    ``unit`` is the trailing bracketed text of the header (``Weight [kg]`` ->
+    ``kg``). ``currency`` is the ISO 4217 code found in that unit
+    (``金額(千円)`` -> ``JPY``) or else in the number format of the first data
+    cell showing a currency symbol.
+
+    Args:
+        rows: Extracted rows with 0-based numeric column keys.
+        table_candidates: A1 table ranges.
+        iqr_factor: Multiple of the interquartile range beyond the quartiles
+            at which a number is reported as an outlier.
+        now: Reference time for future dates (defaults to the current time).
+        number_formats: Number format codes keyed by (row, col), with 1-based
+            rows and 0-based columns, as read by ``extract_number_formats``.
+
+    Returns:
+        Mapping of table range to one ``ColumnStats`` per column, left to right.
+    """
+    reference = now or datetime.now()
+    formats = number_formats or {}
+    by_row = {row.r: row for row in rows}
+    result: dict[str, list[ColumnStats]] = {}
+    for candidate in table_candidates:
+        bounds = parse_range_zero_based(candidate)
+        if bounds is None:
+            continue
+        header_row = by_row.get(bounds.r1 + 1)
+        data_rows = range(bounds.r1 + 2, bounds.r2 + 2)
+        result[candidate] = [
+            _column_stats(
+                col,
+                _cell(header_row, col),
+                [(r, _cell(by_row.get(r), col)) for r in data_rows],
+                iqr_factor,
+                reference,
+            ).model_copy(update=_column_units(col, header_row, data_rows, formats))
+            for col in range(bounds.c1, bounds.c2 + 1)
+        ]
+    return result
+
+
+def _cell(row: CellRow | None, col: int) -> CellValue | None:
+    if row is None:
+        return None
+    value = row.c.get(str(col))
+    if isinstance(value, str) and not value.strip():
+        return None
+    return value
+
+
+def _column_stats(
+    col: int,
+    header: CellValue | None,
+    cells: list[tuple[int, CellValue | None]],
+    iqr_factor: float,
+    now: datetime,
+) -> ColumnStats:
+    present = [(r, value) for r, value in cells if value is not None]
+    values = [value for _, value in present]
+    numbers = [float(value) for value in values if _kind(value) == "number"]
+    return ColumnStats(
+        column=col_index_to_alpha(col),
+        header=None if header is None else str(header),
+        count=len(present),
+        null_rate=(len(cells) - len(present)) / len(cells) if cells else 0.0,
+        distinct_count=len(set(values)),
+        min=min(numbers) if numbers else None,
+        max=max(numbers) if numbers else None,
+        mean=sum(numbers) / len(numbers) if numbers else None,
+        anomalies=_anomalies(col, present, numbers, iqr_factor, now),
+    )
+
+
+def _kind(value: CellValue) -> str:
+    """Classify a cell value as number, date (ISO text), or text."""
+    if isinstance(value, int | float) and not isinstance(value, bool):
+        return "number"
+    if isinstance(value, str) and as_datetime(value) is not None:
+        return "date"
+    return "text"
+
+
+def _anomalies(
+    col: int,
+    present: list[tuple[int, CellValue]],
+    numbers: list[float],
+    iqr_factor: float,
+    now: datetime,
+) -> list[ColumnAnomaly]:
+    """Collect outliers, type mismatches, and future dates in row order."""
+    kinds = Counter(_kind(value) for _, value in present)
+    majority = kinds.most_common(1)[0][0] if kinds else None
+    low = high = None
+    if len(numbers) >= _MIN_OUTLIER_SAMPLE:
+        q1, _, q3 = statistics.quantiles(numbers, n=4, method="inclusive")
+        low = q1 - iqr_factor * (q3 - q1)
+        high = q3 + iqr_factor * (q3 - q1)
+    letter = col_index_to_alpha(col)
+    anomalies: list[ColumnAnomaly] = []
+    for r, value in present:
+        cell = f"{letter}{r}"
+        kind = _kind(value)
+        if kind != majority:
+            anomalies.append(
+                ColumnAnomaly(
+                    kind="type_mismatch",
+                    cell=cell,
+                    value=value,
+                    message=f"{kind} in a column of {majority} values",
+                )
+            )
+        if kind == "number" and low is not None and high is not None:
+            if not low <= float(value) <= high:
+                anomalies.append(
+                    ColumnAnomaly(
+                        kind="outlier",
+                        cell=cell,
+                        value=value,
+                        message=f"outside {iqr_factor:g}x IQR [{low:g}, {high:g}]",
+                    )
+                )
+        if kind == "date":
+            moment = as_datetime(value)
+            if moment is not None and _naive(moment) > now:
+                anomalies.append(
+                    ColumnAnomaly(
+                        kind="future_date",
+                        cell=cell,
+                        value=value,
+                        message="date is in the future",
+                    )
+                )
+    return anomalies
+
+
+def _column_units(
+    col: int,
+    header_row: CellRow | None,
+    data_rows: range,
+    formats: Mapping[tuple[int, int], str],
+) -> dict[str, str | None]:
+    """Return the ``unit`` and ``currency`` of one column."""
+    header = _cell(header_row, col)
+    unit = header_unit(header) if isinstance(header, str) else None
+    currency = currency_code(unit) if unit else None
+    for r in data_rows:
+        if currency is not None:
+            break
+        code = formats.get((r, col))
+        currency = format_currency(code) if code else None
+    return {"unit": unit, "currency": currency}
+
+
+def header_unit(header: str) -> str | None:
+    """Return the unit in trailing brackets of a header, e.g. ``kg``."""
+    match = _HEADER_UNIT.search(header)
+    return match.group(1) if match else None
+
+
+def currency_code(text: str) -> str | None:
+    """Return the ISO 4217 code of the first currency symbol or code in text."""
+    for symbol, code in _CURRENCY_CODES.items():
+        if symbol in text:
+            return code
+    return None
+
+
+def format_currency(number_format: str) -> str | None:
+    """Return the currency shown by a number format code.
+
+    Args:
+        number_format: Excel format code (e.g., ``[$€-407]#,##0.00``).
+
+    Returns:
+        ISO 4217 code for known symbols, the raw symbol of an unknown
+        ``[$...]`` locale tag, or None when the format shows no currency.
+    """
+    if match := _FORMAT_CURRENCY.search(number_format):
+        return currency_code(match.group(1)) or match.group(1)
+    return currency_code(_FORMAT_SECTION.sub("", number_format).replace("\\", ""))
+
+
+def _naive(moment: datetime) -> datetime:
+    return moment.replace(tzinfo=None) if moment.tzinfo is not None else moment
@@ -0,0 +1,219 @@
+"""Overlay review findings on a copy of a workbook as comments and fills.
+
+Findings are cell messages produced by checks on the extracted output (e.g. a
+QA script). ``annotate_workbook`` writes them back into a copy of the original
+file as cell comments with a severity-colored fill, so they can be reviewed in
+Excel next to the data they refer to.
+"""
+
+from __future__ import annotations
+
+from collections.abc import Mapping, Sequence
+from pathlib import Path
+import re
+from typing import Literal
+
+from pydantic import BaseModel, ConfigDict, Field, ValidationError, field_validator
+
+from .errors import ConfigError
+
+Severity = Literal["info", "warning", "error"]
+
+SEVERITY_FILLS: dict[Severity, str] = {
+    "info": "#DDEBF7",
+    "warning": "#FFF2CC",
+    "error": "#F8CBAD",
+}
+
+_CELL = re.compile(r"\$?[A-Za-z]{1,3}\$?[1-9][0-9]*")
+_HEX_COLOR = re.compile(r"#?(?:[0-9A-Fa-f]{6}|[0-9A-Fa-f]{8})")
+
+
+class Finding(BaseModel):
+    """One message attached to a cell."""
+
+    model_config = ConfigDict(extra="forbid")
+
+    sheet: str = Field(description="Sheet name.")
+    cell: str = Field(description="Cell address, e.g. B3.")
+    message: str = Field(description="Comment text.")
+    severity: Severity = Field(default="warning", description="Finding severity.")
+    color: str | None = Field(
+        default=None,
+        description="Fill color as #RRGGBB (None: the severity's color).",
+    )
+
+    @field_validator("cell")
+    @classmethod
+    def _check_cell(cls, value: str) -> str:
+        text = value.strip()
+        if not _CELL.fullmatch(text):
+            raise ValueError(f"Invalid cell address: {value!r}")
+        return text.replace("$", "").upper()
+
+    @field_validator("color")
+    @classmethod
+    def _check_color(cls, value: str | None) -> str | None:
+        if value is not None and not _HEX_COLOR.fullmatch(value.strip()):
+            raise ValueError(f"Invalid color {value!r}; use #RRGGBB or #AARRGGBB.")
+        return value
+
+
+def parse_findings(data: object) -> list[Finding]:
+    """Validate findings given as a list or as a ``{"Sheet!A1": message}`` map.
+
+    Args:
+        data: Either a list of finding objects, or a mapping of sheet-qualified
+            cell addresses to a message (or a finding object without
+            ``sheet``/``cell``).
+
+    Returns:
+        Findings in input order.
+
+    Raises:
+        ConfigError: If the findings are malformed.
+    """
+    items: list[object]
+    if isinstance(data, Mapping):
+        items = []
+        for address, value in data.items():
+            sheet, sep, cell = str(address).rpartition("!")
+            if not sep or not sheet:
+                raise ConfigError(
+                    f"Finding key {address!r} must be a sheet-qualified address "
+                    "such as 'Sheet1!B3'."
+                )
+            extra = {"message": value} if isinstance(value, str) else value
+            if not isinstance(extra, Mapping):
+                raise ConfigError(f"Finding {address!r} must be a message or object.")
+            items.append({**extra, "sheet": sheet.strip("'"), "cell": cell})
+    elif isinstance(data, list):
+        items = list(data)
+    else:
+        raise ConfigError("Findings must be a list or a mapping of cell to message.")
+    try:
+        return [Finding.model_validate(item) for item in items]
+    except ValidationError as exc:
+        raise ConfigError(f"Invalid findings: {exc}") from exc
+
+
+def load_findings(path: str | Path) -> list[Finding]:
+    """Load findings from a JSON, YAML (requires pyyaml), or TOML file.
+
+    Args:
+        path: Findings file.
+
+    Returns:
+        Validated findings.
+
+    Raises:
+        ConfigError: If the file cannot be read, parsed, or validated.
+    """
+    from .config import _read_config_data
+
+    findings_path = Path(path)
+    try:
+        data = _read_config_data(findings_path)
+    except ConfigError:
+        raise
+    except OSError as exc:
+        raise ConfigError(
+            f"Failed to read findings '{findings_path}': {exc}"
+        ) from exc
+    except ValueError as exc:
+        raise ConfigError(
+            f"Failed to parse findings '{findings_path}': {exc}"
+        ) from exc
+    return parse_findings(data)
+
+
+def annotate_workbook(
+    input_path: str | Path,
+    findings: Sequence[Finding],
+    output_path: str | Path,
+    *,
+    author: str = "exstruct",
+    highlight: bool = True,
+) -> Path:
+    """Write a copy of a workbook with findings as comments and fills.
+
+    Findings on the same cell are joined into one comment, one line each,
+    prefixed with their severity; an existing comment is kept above them. The
+    fill uses the most severe finding's color unless one sets ``color``.
+
+    Args:
+        input_path: Original workbook (.xlsx/.xlsm).
+        findings: Findings to apply.
+        output_path: Destination; must differ from ``input_path``.
+        author: Comment author.
+        highlight: Also fill annotated cells.
+
+    Returns:
+        Output path.
+
+    Raises:
+        ConfigError: If the paths are invalid or a finding names an unknown
+            sheet.
+    """
+    from openpyxl import load_workbook
+    from openpyxl.comments import Comment
+    from openpyxl.styles import PatternFill
+
+    source = Path(input_path)
+    target = Path(output_path)
+    suffix = source.suffix.lower()
+    if suffix not in (".xlsx", ".xlsm"):
+        raise ConfigError(f"Annotation requires an .xlsx/.xlsm file: {source}")
+    if target.resolve() == source.resolve():
+        raise ConfigError("Output path must differ from the input workbook.")
+    workbook = load_workbook(source, keep_vba=suffix == ".xlsm")
+    try:
+        for (sheet_name, address), group in _group_by_cell(findings).items():
+            if sheet_name not in workbook.sheetnames:
+                raise ConfigError(f"Sheet '{sheet_name}' not found in workbook.")
+            cell = workbook[sheet_name][address]
+            lines = [f"[{item.severity}] {item.message}" for item in group]
+            if cell.comment is not None and cell.comment.text:
+                lines.insert(0, cell.comment.text.rstrip())
+            comment = Comment("\n".join(lines), author)
+            comment.width = 300
+            comment.height = 20 * (len(lines) + 1)
+            cell.comment = comment
+            if highlight:
+                color = _fill_color(group)
+                cell.fill = PatternFill(
+                    fill_type="solid", start_color=color, end_color=color
+                )
+        target.parent.mkdir(parents=True, exist_ok=True)
+        workbook.save(target)
+    finally:
+        workbook.close()
+    return target
+
+
+def _group_by_cell(findings: Sequence[Finding]) -> dict[tuple[str, str], list[Finding]]:
+    groups: dict[tuple[str, str], list[Finding]] = {}
+    for finding in findings:
+        groups.setdefault((finding.sheet, finding.cell), []).append(finding)
+    return groups
+
+
+def _fill_color(group: Sequence[Finding]) -> str:
+    """Return the AARRGGBB fill for a cell's findings."""
+    explicit = [item.color for item in group if item.color is not None]
+    if explicit:
+        raw = explicit[-1].strip().lstrip("#").upper()
+    else:
+        order: list[Severity] = ["info", "warning", "error"]
+        worst = max(group, key=lambda item: order.index(item.severity))
+        raw = SEVERITY_FILLS[worst.severity].lstrip("#")
+    return raw if len(raw) == 8 else f"FF{raw}"
+
+
+__all__ = [
+    "SEVERITY_FILLS",
+    "Finding",
+    "annotate_workbook",
+    "load_findings",
+    "parse_findings",
+]
@@ -0,0 +1,94 @@
+"""CLI subcommand that overlays findings on a copy of a workbook."""
+
+from __future__ import annotations
+
+import argparse
+from pathlib import Path
+import sys
+
+
+def build_annotate_parser() -> argparse.ArgumentParser:
+    """Build the annotate-subcommand CLI parser."""
+
+    parser = argparse.ArgumentParser(
+        prog="exstruct annotate",
+        description=(
+            "Write a copy of a workbook with findings added as cell comments "
+            "and severity-colored fills."
+        ),
+    )
+    parser.add_argument("input", type=Path, help="Excel file (.xlsx/.xlsm)")
+    parser.add_argument(
+        "findings",
+        type=Path,
+        help=(
+            "Findings file (JSON/YAML/TOML): a list of {sheet, cell, message, "
+            "severity, color} objects or a {'Sheet!A1': message} mapping."
+        ),
+    )
+    parser.add_argument(
+        "-o",
+        "--output",
+        type=Path,
+        required=True,
+        help="Annotated copy to write (must differ from the input).",
+    )
+    parser.add_argument(
+        "--author",
+        default="exstruct",
+        help="Comment author (default: exstruct).",
+    )
+    parser.add_argument(
+        "--no-highlight",
+        action="store_true",
+        help="Add comments only; leave cell fills unchanged.",
+    )
+    return parser
+
+
+def run_annotate_cli(argv: list[str]) -> int:
+    """Run the annotate subcommand.
+
+    Args:
+        argv: Arguments following the ``annotate`` command name.
+
+    Returns:
+        Exit code (0 on success, 1 on errors).
+    """
+
+    parser = build_annotate_parser()
+    try:
+        args = parser.parse_args(argv)
+    except SystemExit as exc:
+        return 0 if exc.code in (None, 0) else 1
+
+    input_path: Path = args.input
+    if not input_path.exists():
+        _print_error(f"File not found: {input_path}")
+        return 1
+
+    from exstruct.annotate import annotate_workbook, load_findings
+
+    try:
+        findings = load_findings(args.findings)
+        output = annotate_workbook(
+            input_path,
+            findings,
+            args.output,
+            author=args.author,
+            highlight=not args.no_highlight,
+        )
+    except Exception as exc:
+        _print_error(f"Error: {exc}")
+        return 1
+    print(f"Annotated {len(findings)} finding(s): {output}", flush=True)
+    return 0
+
+
+def _print_error(message: str) -> None:
+    """Print one CLI error to stderr."""
+
+    print(message, file=sys.stderr, flush=True)
+
+
+__all__ = ["build_annotate_parser", "run_annotate_cli"]
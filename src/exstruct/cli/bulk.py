@@ -0,0 +1,383 @@
+"""CLI subcommands that extract many workbooks per run: batch and watch.
+
+``exstruct batch`` extracts every workbook given (files, or directories
+scanned for .xlsx/.xlsm/.xls files) into an output directory and keeps going
+past workbooks that fail. ``exstruct watch`` scans a directory at an interval
+and extracts workbooks when they are added or saved. With ``--notify-url``,
+batch POSTs one ``batch.finished`` summary when it finishes, and watch POSTs a
+``watch.finished`` summary after every scan that extracted workbooks.
+"""
+
+from __future__ import annotations
+
+import argparse
+from collections.abc import Iterable
+from pathlib import Path
+import sys
+import time
+from typing import TYPE_CHECKING
+
+if TYPE_CHECKING:  # pragma: no cover - typing only
+    from exstruct.engine import RunReport
+
+WORKBOOK_SUFFIXES = frozenset({".xlsx", ".xlsm", ".xls"})
+DEFAULT_WATCH_INTERVAL_SECONDS = 2.0
+
+# (modification time in ns, size in bytes) of a workbook file
+_Stamp = tuple[int, int]
+
+
+def _add_extraction_arguments(
+    parser: argparse.ArgumentParser, *, notify_help: str
+) -> None:
+    """Add the output and extraction options shared by batch and watch."""
+
+    from exstruct.encoders import BUILTIN_FORMATS, registered_encoders
+
+    formats = [*BUILTIN_FORMATS, *(encoder.name for encoder in registered_encoders())]
+    parser.add_argument(
+        "-o",
+        "--output-dir",
+        type=Path,
+        required=True,
+        help=(
+            "Directory for one output file per workbook (created if missing). "
+            "Workbooks found in a scanned directory keep their relative path."
+        ),
+    )
+    parser.add_argument(
+        "-f",
+        "--format",
+        default="json",
+        choices=formats,
+        help="Export format. Default: json.",
+    )
+    parser.add_argument(
+        "-m",
+        "--mode",
+        default="standard",
+        choices=["light", "libreoffice", "standard", "verbose"],
+        help="Extraction detail level. Default: standard.",
+    )
+    parser.add_argument(
+        "--pretty",
+        action="store_true",
+        help="Pretty-print JSON output.",
+    )
+    parser.add_argument(
+        "--no-recursive",
+        action="store_true",
+        help="Only scan the top level of directories.",
+    )
+    parser.add_argument("--notify-url", metavar="URL", help=notify_help)
+
+
+def build_batch_parser() -> argparse.ArgumentParser:
+    """Build the batch-subcommand CLI parser."""
+
+    parser = argparse.ArgumentParser(
+        prog="exstruct batch",
+        description=(
+            "Extract many workbooks in one run. Workbooks that fail are reported "
+            "and skipped; the exit code is 1 if any failed."
+        ),
+    )
+    parser.add_argument(
+        "inputs",
+        nargs="+",
+        type=Path,
+        metavar="INPUT",
+        help="Workbook files, or directories to scan for .xlsx/.xlsm/.xls files.",
+    )
+    _add_extraction_arguments(
+        parser,
+        notify_help=(
+            "POST one JSON summary of the batch (workbooks processed, failures, "
+            "output files) to URL when it finishes, successful or not."
+        ),
+    )
+    return parser
+
+
+def build_watch_parser() -> argparse.ArgumentParser:
+    """Build the watch-subcommand CLI parser."""
+
+    parser = argparse.ArgumentParser(
+        prog="exstruct watch",
+        description=(
+            "Watch a directory and extract workbooks when they are added or "
+            "saved, until interrupted (Ctrl+C)."
+        ),
+    )
+    parser.add_argument("directory", type=Path, help="Directory to watch.")
+    _add_extraction_arguments(
+        parser,
+        notify_help=(
+            "POST a JSON summary (workbooks processed, failures, output files) "
+            "to URL after every scan that extracted workbooks."
+        ),
+    )
+    parser.add_argument(
+        "--interval",
+        type=float,
+        default=DEFAULT_WATCH_INTERVAL_SECONDS,
+        metavar="SECONDS",
+        help=(
+            "Seconds between directory scans (default: 2). A workbook is "
+            "extracted once its size and modification time stay the same for "
+            "one interval, so files still being written are not read."
+        ),
+    )
+    parser.add_argument(
+        "--max-scans",
+        type=int,
+        metavar="N",
+        help="Stop after N scans instead of running until interrupted.",
+    )
+    return parser
+
+
+def run_bulk_cli(argv: list[str]) -> int:
+    """Run the batch or watch subcommand named by ``argv[0]``.
+
+    Args:
+        argv: Arguments starting with the subcommand name.
+
+    Returns:
+        Exit code (0 for success, 1 for failure).
+    """
+
+    command, rest = argv[0], argv[1:]
+    if command == "watch":
+        return run_watch_cli(rest)
+    return run_batch_cli(rest)
+
+
+def run_batch_cli(argv: list[str]) -> int:
+    """Run the batch subcommand.
+
+    Args:
+        argv: Arguments following the ``batch`` command name.
+
+    Returns:
+        Exit code (0 when every workbook was extracted, 1 otherwise).
+    """
+
+    parser = build_batch_parser()
+    try:
+        args = parser.parse_args(argv)
+    except SystemExit as exc:
+        return 0 if exc.code in (None, 0) else 1
+
+    missing = [path for path in args.inputs if not path.exists()]
+    if missing:
+        reports = [_failed_report(path, f"File not found: {path}") for path in missing]
+        for report in reports:
+            _print_error(f"Error: {report.error}")
+        _notify(args, reports, [], event="batch.finished")
+        return 1
+
+    from exstruct.encoders import format_suffix
+    from exstruct.ooxml.catalog import iter_catalog_files
+
+    suffix = format_suffix(args.format)
+    jobs: list[tuple[Path, Path]] = []
+    for item in args.inputs:
+        if not item.is_dir():
+            jobs.append((item, args.output_dir / Path(item.name).with_suffix(suffix)))
+            continue
+        for path in iter_catalog_files(
+            item, recursive=not args.no_recursive, suffixes=WORKBOOK_SUFFIXES
+        ):
+            target = args.output_dir / path.relative_to(item).with_suffix(suffix)
+            jobs.append((path, target))
+
+    reports, outputs = _extract_all(jobs, args)
+    _notify(args, reports, outputs, event="batch.finished")
+    return 1 if any(report.status == "error" for report in reports) else 0
+
+
+def run_watch_cli(argv: list[str]) -> int:
+    """Run the watch subcommand.
+
+    Args:
+        argv: Arguments following the ``watch`` command name.
+
+    Returns:
+        Exit code (0 when stopped, 1 if the directory does not exist).
+    """
+
+    parser = build_watch_parser()
+    try:
+        args = parser.parse_args(argv)
+    except SystemExit as exc:
+        return 0 if exc.code in (None, 0) else 1
+
+    directory: Path = args.directory
+    if not directory.is_dir():
+        message = f"Directory not found: {directory}"
+        _print_error(message)
+        _notify(args, [_failed_report(directory, message)], [], event="watch.finished")
+        return 1
+    if args.interval < 0:
+        _print_error("Error: --interval must not be negative.")
+        return 1
+
+    from exstruct.encoders import format_suffix
+
+    suffix = format_suffix(args.format)
+    output_dir: Path = args.output_dir
+    seen: dict[Path, _Stamp] = {}
+    extracted: dict[Path, _Stamp] = {}
+    scans = 0
+    try:
+        while args.max_scans is None or scans < args.max_scans:
+            if scans:
+                time.sleep(args.interval)
+            scans += 1
+            current = _scan(directory, recursive=not args.no_recursive)
+            settled = [
+                path
+                for path, stamp in current.items()
+                if seen.get(path) == stamp and extracted.get(path) != stamp
+            ]
+            seen = current
+            extracted = {
+                path: stamp for path, stamp in extracted.items() if path in current
+            }
+            if not settled:
+                continue
+            jobs = [
+                (path, output_dir / path.relative_to(directory).with_suffix(suffix))
+                for path in settled
+            ]
+            reports, outputs = _extract_all(jobs, args)
+            extracted.update((path, current[path]) for path in settled)
+            _notify(args, reports, outputs, event="watch.finished")
+    except KeyboardInterrupt:
+        pass
+    return 0
+
+
+def _scan(directory: Path, *, recursive: bool) -> dict[Path, _Stamp]:
+    """Return the stamp of every workbook under ``directory``."""
+
+    from exstruct.ooxml.catalog import iter_catalog_files
+
+    stamps: dict[Path, _Stamp] = {}
+    for path in iter_catalog_files(
+        directory, recursive=recursive, suffixes=WORKBOOK_SUFFIXES
+    ):
+        try:
+            stat = path.stat()
+        except OSError:
+            continue
+        stamps[path] = (stat.st_mtime_ns, stat.st_size)
+    return stamps
+
+
+def _extract_all(
+    jobs: Iterable[tuple[Path, Path]], args: argparse.Namespace
+) -> tuple[list[RunReport], list[str]]:
+    """Extract each ``(workbook, output file)`` pair; failures do not stop the run.
+
+    Two workbooks that map to the same output file (``book.xlsx`` and
+    ``book.xls``) are not allowed to overwrite each other: the second fails.
+
+    Returns:
+        One report per workbook, and the output files that were written.
+    """
+
+    reports: list[RunReport] = []
+    outputs: list[str] = []
+    sources: dict[Path, Path] = {}
+    for source, target in jobs:
+        previous = sources.setdefault(target, source)
+        if previous != source:
+            message = f"Output '{target}' is already written for '{previous}'."
+            _print_error(f"Error: {source}: {message}")
+            reports.append(_failed_report(source, message))
+            continue
+        report = _extract_one(source, target, args)
+        reports.append(report)
+        if report.status != "error":
+            outputs.append(str(target))
+    return reports, outputs
+
+
+def _extract_one(
+    source: Path, target: Path, args: argparse.Namespace
+) -> RunReport:
+    """Extract one workbook to ``target`` and return its run report."""
+
+    from exstruct import process_excel
+    from exstruct.engine import RunReport
+
+    report = RunReport(file=str(source))
+    start = time.monotonic()
+    try:
+        target.parent.mkdir(parents=True, exist_ok=True)
+        process_excel(
+            source,
+            target,
+            out_fmt=args.format,
+            mode=args.mode,
+            pretty=args.pretty,
+            report=report,
+        )
+    except Exception as exc:
+        report.status = "error"
+        report.error = str(exc)
+        _print_error(f"Error: {source}: {exc}")
+    else:
+        print(f"{source} -> {target}", flush=True)
+    report.exit_code = 1 if report.status == "error" else 0
+    report.durations["total"] = round(time.monotonic() - start, 4)
+    return report
+
+
+def _failed_report(path: Path, error: str) -> RunReport:
+    """Return the report of a workbook or directory that could not be read."""
+
+    from exstruct.engine import RunReport
+
+    return RunReport(file=str(path), status="error", exit_code=1, error=error)
+
+
+def _notify(
+    args: argparse.Namespace,
+    reports: list[RunReport],
+    outputs: list[str],
+    *,
+    event: str,
+) -> None:
+    """POST the summary of a batch or watch scan to ``--notify-url`` when given.
+
+    A failed notification is printed as a warning and does not change the
+    exit code or stop watching.
+    """
+
+    if args.notify_url is None:
+        return
+    from exstruct.notify import build_run_summary, post_run_summary
+
+    summary = build_run_summary(reports, outputs, event=event)
+    try:
+        post_run_summary(args.notify_url, summary)
+    except Exception as exc:
+        _print_error(f"Warning: {exc}")
+
+
+def _print_error(message: str) -> None:
+    """Print one CLI error to stderr."""
+
+    print(message, file=sys.stderr, flush=True)
+
+
+__all__ = [
+    "build_batch_parser",
+    "build_watch_parser",
+    "run_batch_cli",
+    "run_bulk_cli",
+    "run_watch_cli",
+]
@@ -0,0 +1,127 @@
+"""CLI subcommand for full-text search across a workbook."""
+
+from __future__ import annotations
+
+import argparse
+import json
+import logging
+from pathlib import Path
+import sys
+
+logger = logging.getLogger(__name__)
+
+_COMMENT_SUFFIXES = frozenset({".xlsx", ".xlsm"})
+
+
+def build_grep_parser() -> argparse.ArgumentParser:
+    """Build the grep-subcommand CLI parser."""
+
+    parser = argparse.ArgumentParser(
+        prog="exstruct grep",
+        description=(
+            "Search cell values, comments, shape texts, and chart titles. "
+            "Exits 0 when something matched, 1 when nothing did, 2 on errors."
+        ),
+    )
+    parser.add_argument("pattern", help="Regular expression to search for.")
+    parser.add_argument("input", type=Path, help="Excel file (.xlsx/.xlsm/.xls)")
+    parser.add_argument(
+        "-i",
+        "--ignore-case",
+        action="store_true",
+        help="Match case-insensitively.",
+    )
+    parser.add_argument(
+        "-F",
+        "--fixed-strings",
+        action="store_true",
+        help="Treat the pattern as literal text.",
+    )
+    parser.add_argument(
+        "-m",
+        "--mode",
+        default="standard",
+        choices=["light", "libreoffice", "standard", "verbose"],
+        help="Extraction detail level (light searches cells and comments only).",
+    )
+    parser.add_argument(
+        "--json",
+        action="store_true",
+        help="Print matches as a JSON array.",
+    )
+    parser.add_argument(
+        "--pretty",
+        action="store_true",
+        help="Pretty-print JSON output.",
+    )
+    return parser
+
+
+def run_grep_cli(argv: list[str]) -> int:
+    """Run the grep subcommand.
+
+    Args:
+        argv: Arguments following the ``grep`` command name.
+
+    Returns:
+        Exit code (0 if anything matched, 1 if nothing did, 2 on errors).
+    """
+
+    parser = build_grep_parser()
+    try:
+        args = parser.parse_args(argv)
+    except SystemExit as exc:
+        return 0 if exc.code in (None, 0) else 2
+
+    input_path: Path = args.input
+    if not input_path.exists():
+        _print_error(f"File not found: {input_path}")
+        return 2
+
+    from exstruct import extract
+    from exstruct.analysis import search_workbook
+
+    try:
+        workbook = extract(input_path, mode=args.mode)
+        hits = search_workbook(
+            workbook.sheets,
+            args.pattern,
+            ignore_case=args.ignore_case,
+            fixed_strings=args.fixed_strings,
+            comments=_read_comments(input_path),
+        )
+    except Exception as exc:
+        _print_error(f"Error: {exc}")
+        return 2
+    if args.json:
+        payload = [hit.model_dump(mode="json") for hit in hits]
+        indent = 2 if args.pretty else None
+        print(json.dumps(payload, ensure_ascii=False, indent=indent), flush=True)
+    else:
+        for hit in hits:
+            print(f"{hit.sheet}!{hit.location} [{hit.kind}]: {hit.context}")
+        sys.stdout.flush()
+    return 0 if hits else 1
+
+
+def _read_comments(path: Path) -> dict[str, dict[str, str]]:
+    """Read cell comments, or nothing when the workbook cannot provide them."""
+
+    if path.suffix.lower() not in _COMMENT_SUFFIXES:
+        return {}
+    from exstruct.core.cells import extract_cell_comments
+
+    try:
+        return extract_cell_comments(path)
+    except Exception as exc:
+        logger.warning("Comment extraction failed; skipping comments. (%r)", exc)
+        return {}
+
+
+def _print_error(message: str) -> None:
+    """Print one CLI error to stderr."""
+
+    print(message, file=sys.stderr, flush=True)
+
+
+__all__ = ["build_grep_parser", "run_grep_cli"]
@@ -4,20 +4,55 @@ from __future__ import annotations
 
 import argparse
 from collections.abc import Callable
+from contextlib import AbstractContextManager
 from importlib import import_module
+import json
 from pathlib import Path
 import sys
+import time
 from typing import TYPE_CHECKING, cast
 
 if TYPE_CHECKING:
     from exstruct.cli.availability import ComAvailability
+    from exstruct.engine import RunReport
 
 ProcessExcelFn = Callable[..., None]
 EditPredicateFn = Callable[[list[str]], bool]
 RunEditCliFn = Callable[[list[str]], int]
+RunInspectCliFn = Callable[[list[str]], int]
+RunTemplateCliFn = Callable[[list[str]], int]
+RunGrepCliFn = Callable[[list[str]], int]
+RunAnnotateCliFn = Callable[[list[str]], int]
+RunBulkCliFn = Callable[[list[str]], int]
 ComAvailabilityFn = Callable[[], "ComAvailability"]
 LibreOfficeValidatorFn = Callable[..., Path]
+ParseSizeFn = Callable[[str], int]
+IsRemoteUriFn = Callable[[object], bool]
+LoadProfileFn = Callable[[Path, "str | None"], object]
+ResolveProfileFn = Callable[..., object]
+RedactionFromNamesFn = Callable[..., object]
+ConfigureLoggingFn = Callable[..., None]
+LimitsOptionsFn = Callable[..., object]
+SampleRowsOptionsFn = Callable[..., object]
+RunReportFn = Callable[..., "RunReport"]
+CaptureLogWarningsFn = Callable[[], AbstractContextManager[list[str]]]
+BuildRunSummaryFn = Callable[..., dict[str, object]]
+PostRunSummaryFn = Callable[..., int]
 _EDIT_SUBCOMMAND_NAMES = frozenset({"patch", "make", "ops", "validate"})
+_INSPECT_SUBCOMMAND_NAMES = frozenset({"summary", "catalog"})
+_TEMPLATE_SUBCOMMAND_NAME = "apply-template"
+_GREP_SUBCOMMAND_NAME = "grep"
+_ANNOTATE_SUBCOMMAND_NAME = "annotate"
+_BULK_SUBCOMMAND_NAMES = frozenset({"batch", "watch"})
+
+EXIT_OK = 0
+EXIT_FAILURE = 1
+EXIT_USAGE = 2
+EXIT_NOT_FOUND = 3
+EXIT_INVALID_INPUT = 4
+EXIT_PARTIAL = 5
+# Matched by class name so the CLI does not import openpyxl/xlrd up front.
+_INVALID_INPUT_ERRORS = frozenset({"BadZipFile", "InvalidFileException", "XLRDError"})
 
 
 def _load_process_excel() -> ProcessExcelFn:
@@ -35,6 +70,31 @@ def _load_run_edit_cli() -> RunEditCliFn:
     return cast(RunEditCliFn, module.run_edit_cli)
 
 
+def _load_run_inspect_cli() -> RunInspectCliFn:
+    module = import_module("exstruct.cli.summary")
+    return cast(RunInspectCliFn, module.run_inspect_cli)
+
+
+def _load_run_template_cli() -> RunTemplateCliFn:
+    module = import_module("exstruct.cli.template")
+    return cast(RunTemplateCliFn, module.run_template_cli)
+
+
+def _load_run_grep_cli() -> RunGrepCliFn:
+    module = import_module("exstruct.cli.grep")
+    return cast(RunGrepCliFn, module.run_grep_cli)
+
+
+def _load_run_annotate_cli() -> RunAnnotateCliFn:
+    module = import_module("exstruct.cli.annotate")
+    return cast(RunAnnotateCliFn, module.run_annotate_cli)
+
+
+def _load_run_bulk_cli() -> RunBulkCliFn:
+    module = import_module("exstruct.cli.bulk")
+    return cast(RunBulkCliFn, module.run_bulk_cli)
+
+
 def _load_get_com_availability() -> ComAvailabilityFn:
     module = import_module("exstruct.cli.availability")
     return cast(ComAvailabilityFn, module.get_com_availability)
@@ -48,6 +108,103 @@ def _load_libreoffice_validator() -> LibreOfficeValidatorFn:
     )
 
 
+def _load_parse_size() -> ParseSizeFn:
+    module = import_module("exstruct.io.output")
+    return cast(ParseSizeFn, module.parse_size)
+
+
+def _load_is_remote_uri() -> IsRemoteUriFn:
+    module = import_module("exstruct.io.remote")
+    return cast(IsRemoteUriFn, module.is_remote_uri)
+
+
+def _load_load_profile() -> LoadProfileFn:
+    module = import_module("exstruct.config")
+    return cast(LoadProfileFn, module.load_profile)
+
+
+def _load_resolve_profile() -> ResolveProfileFn:
+    module = import_module("exstruct.config")
+    return cast(ResolveProfileFn, module.resolve_profile)
+
+
+def _load_configure_logging() -> ConfigureLoggingFn:
+    module = import_module("exstruct.core.logging_utils")
+    return cast(ConfigureLoggingFn, module.configure_cli_logging)
+
+
+def _load_limits_options() -> LimitsOptionsFn:
+    module = import_module("exstruct.engine")
+    return cast(LimitsOptionsFn, module.LimitsOptions)
+
+
+def _load_sample_rows_options() -> SampleRowsOptionsFn:
+    module = import_module("exstruct.engine")
+    return cast(SampleRowsOptionsFn, module.SampleRowsOptions)
+
+
+def _load_run_report() -> RunReportFn:
+    module = import_module("exstruct.engine")
+    return cast(RunReportFn, module.RunReport)
+
+
+def _load_capture_log_warnings() -> CaptureLogWarningsFn:
+    module = import_module("exstruct.core.logging_utils")
+    return cast(CaptureLogWarningsFn, module.capture_log_warnings)
+
+
+def _load_notifier() -> tuple[BuildRunSummaryFn, PostRunSummaryFn]:
+    module = import_module("exstruct.notify")
+    return (
+        cast(BuildRunSummaryFn, module.build_run_summary),
+        cast(PostRunSummaryFn, module.post_run_summary),
+    )
+
+
+def _load_redaction_from_names() -> RedactionFromNamesFn:
+    module = import_module("exstruct.redaction")
+    return cast(RedactionFromNamesFn, module.RedactionOptions.from_names)
+
+
+def _load_format_choices() -> list[str]:
+    module = import_module("exstruct.encoders")
+    encoders = module.registered_encoders()
+    return [*module.BUILTIN_FORMATS, *(encoder.name for encoder in encoders)]
+
+
+def _redact_names_arg(value: str) -> list[str]:
+    """Parse a comma-separated --redact value into rule names."""
+
+    names = [name.strip() for name in value.split(",") if name.strip()]
+    if not names:
+        raise argparse.ArgumentTypeError("expected at least one rule name")
+    return names
+
+
+def _fields_arg(value: str) -> list[str]:
+    """Parse a comma-separated --fields value into projection entries."""
+
+    fields = [field.strip() for field in value.split(",") if field.strip()]
+    if not fields:
+        raise argparse.ArgumentTypeError("expected at least one field")
+    return fields
+
+
+def _split_size_arg(value: str) -> int:
+    """Parse a size value such as 100M (--split-size, --max-output-size) into bytes."""
+
+    try:
+        return _load_parse_size()(value)
+    except ValueError as exc:
+        raise argparse.ArgumentTypeError(str(exc)) from exc
+
+
+def _path_or_uri_arg(value: str) -> Path | str:
+    """Keep s3:// gs:// azblob:// URIs as strings; other values become paths."""
+
+    return value if _load_is_remote_uri()(value) else Path(value)
+
+
 def process_excel(*args: object, **kwargs: object) -> None:
     """Compatibility wrapper that resolves `exstruct.process_excel` lazily."""
 
@@ -70,6 +227,76 @@ def run_edit_cli(argv: list[str]) -> int:
     return _load_run_edit_cli()(argv)
 
 
+def is_inspect_subcommand(argv: list[str]) -> bool:
+    """Return whether argv targets the summary or catalog subcommand."""
+
+    if not argv or argv[0] not in _INSPECT_SUBCOMMAND_NAMES:
+        return False
+    return not Path(argv[0]).exists()
+
+
+def run_inspect_cli(argv: list[str]) -> int:
+    """Compatibility wrapper that resolves the summary/catalog CLI lazily."""
+
+    return _load_run_inspect_cli()(argv)
+
+
+def is_template_subcommand(argv: list[str]) -> bool:
+    """Return whether argv targets the apply-template subcommand."""
+
+    if not argv or argv[0] != _TEMPLATE_SUBCOMMAND_NAME:
+        return False
+    return not Path(argv[0]).exists()
+
+
+def run_template_cli(argv: list[str]) -> int:
+    """Compatibility wrapper that resolves the apply-template CLI lazily."""
+
+    return _load_run_template_cli()(argv)
+
+
+def is_grep_subcommand(argv: list[str]) -> bool:
+    """Return whether argv targets the grep subcommand."""
+
+    if not argv or argv[0] != _GREP_SUBCOMMAND_NAME:
+        return False
+    return not Path(argv[0]).exists()
+
+
+def run_grep_cli(argv: list[str]) -> int:
+    """Compatibility wrapper that resolves the grep CLI lazily."""
+
+    return _load_run_grep_cli()(argv)
+
+
+def is_annotate_subcommand(argv: list[str]) -> bool:
+    """Return whether argv targets the annotate subcommand."""
+
+    if not argv or argv[0] != _ANNOTATE_SUBCOMMAND_NAME:
+        return False
+    return not Path(argv[0]).exists()
+
+
+def run_annotate_cli(argv: list[str]) -> int:
+    """Compatibility wrapper that resolves the annotate CLI lazily."""
+
+    return _load_run_annotate_cli()(argv)
+
+
+def is_bulk_subcommand(argv: list[str]) -> bool:
+    """Return whether argv targets the batch or watch subcommand."""
+
+    if not argv or argv[0] not in _BULK_SUBCOMMAND_NAMES:
+        return False
+    return not Path(argv[0]).exists()
+
+
+def run_bulk_cli(argv: list[str]) -> int:
+    """Compatibility wrapper that resolves the batch/watch CLI lazily."""
+
+    return _load_run_bulk_cli()(argv)
+
+
 def get_com_availability() -> ComAvailability:
     """Compatibility wrapper that resolves COM probing lazily."""
 
@@ -117,23 +344,63 @@ def build_parser() -> argparse.ArgumentParser:
             "  exstruct make --output new.xlsx --ops ops.json\n"
             "  exstruct ops list\n"
             "  exstruct ops describe create_chart\n"
-            "  exstruct validate --input book.xlsx"
+            "  exstruct validate --input book.xlsx\n"
+            "  exstruct annotate book.xlsx findings.json -o reviewed.xlsx\n"
+            "\n"
+            "Inspection commands:\n"
+            "  exstruct summary book.xlsx\n"
+            "  exstruct catalog workbooks/ --format csv\n"
+            "  exstruct grep 'invoice' book.xlsx --json\n"
+            "\n"
+            "Many workbooks:\n"
+            "  exstruct batch workbooks/ -o out/ --notify-url URL\n"
+            "  exstruct watch inbox/ -o out/ --notify-url URL\n"
+            "\n"
+            "Template extraction:\n"
+            "  exstruct apply-template form.yaml book.xlsx\n"
+            "\n"
+            "Exit codes:\n"
+            "  0 success, 1 failure, 2 usage error, 3 input not found,\n"
+            "  4 input is not a readable workbook, 5 partial extraction (warnings)"
         ),
         formatter_class=argparse.RawDescriptionHelpFormatter,
     )
-    parser.add_argument("input", type=Path, help="Excel file (.xlsx/.xlsm/.xls)")
+    parser.add_argument(
+        "input",
+        type=_path_or_uri_arg,
+        help="Excel file (.xlsx/.xlsm/.xls) or s3://, gs://, azblob:// URI",
+    )
     parser.add_argument(
         "-o",
         "--output",
-        type=Path,
-        help="Output path. If omitted, writes to stdout.",
+        type=_path_or_uri_arg,
+        help=(
+            "Output path or s3://, gs://, azblob:// URI. If omitted, writes to "
+            "stdout. A .gz or .zst suffix compresses the output (zstd requires "
+            "zstandard)."
+        ),
+    )
+    parser.add_argument(
+        "--split-size",
+        type=_split_size_arg,
+        metavar="SIZE",
+        help=(
+            "Split the output file into numbered parts of at most SIZE "
+            "uncompressed bytes (e.g. 500M) plus a manifest. Requires --output."
+        ),
     )
     parser.add_argument(
         "-f",
         "--format",
-        default="json",
-        choices=["json", "yaml", "yml", "toon"],
-        help="Export format",
+        choices=_load_format_choices(),
+        help=(
+            "Export format. events writes one NDJSON record per non-empty cell; "
+            "text renders tab-separated sheet grids (space-aligned with --pretty); "
+            "markdown renders each sheet as a Markdown document; "
+            "sqlite writes a database and requires --output. Encoders registered "
+            "with exstruct.encoders.register_encoder are listed by name. "
+            "Default: json."
+        ),
     )
     parser.add_argument(
         "--image",
@@ -160,17 +427,16 @@ def build_parser() -> argparse.ArgumentParser:
     parser.add_argument(
         "-m",
         "--mode",
-        default="standard",
         choices=["light", "libreoffice", "standard", "verbose"],
         help=(
             "Extraction detail level. libreoffice is a best-effort rich extraction "
             "mode for .xlsx/.xlsm only and cannot be combined with PDF/PNG rendering "
-            "or auto page-break export."
+            "or auto page-break export. Default: standard."
         ),
     )
     parser.add_argument(
         "--pretty",
-        action="store_true",
+        action=argparse.BooleanOptionalAction,
         help="Pretty-print JSON output (indent=2). Default is compact JSON.",
     )
     parser.add_argument(
@@ -178,28 +444,559 @@ def build_parser() -> argparse.ArgumentParser:
         type=Path,
         help="Optional directory to write one file per sheet (format follows --format).",
     )
+    parser.add_argument(
+        "--rows-per-file",
+        type=int,
+        metavar="N",
+        help=(
+            "Split --sheets-dir files of sheets with more than N rows into "
+            "numbered pages (Sheet1.page001.json, ...) with continuation "
+            "metadata. Requires --sheets-dir."
+        ),
+    )
     parser.add_argument(
         "--print-areas-dir",
         type=Path,
         help="Optional directory to write one file per print area (format follows --format).",
     )
+    parser.add_argument(
+        "--shapes-dir",
+        type=Path,
+        help="Optional directory to write the shapes of each sheet (format follows --format).",
+    )
+    parser.add_argument(
+        "--charts-dir",
+        type=Path,
+        help="Optional directory to write the charts of each sheet (format follows --format).",
+    )
+    parser.add_argument(
+        "--chart-images-dir",
+        type=Path,
+        help=(
+            "Optional directory to write a preview image of every chart, drawn "
+            "from its series data, plus an index.json."
+        ),
+    )
+    parser.add_argument(
+        "--chart-images-format",
+        default="svg",
+        choices=["svg", "png"],
+        help="Image format for --chart-images-dir (png requires Pillow).",
+    )
+    parser.add_argument(
+        "--snapshots-dir",
+        type=Path,
+        help=(
+            "Optional directory to write a snapshot image of each sheet's used "
+            "region (cells, merges, fills, shapes), laid out without Excel."
+        ),
+    )
+    parser.add_argument(
+        "--snapshots-format",
+        default="svg",
+        choices=["svg", "png"],
+        help="Image format for --snapshots-dir (png requires Pillow).",
+    )
+    parser.add_argument(
+        "--infer-print-areas",
+        action=argparse.BooleanOptionalAction,
+        help=(
+            "For sheets without a print area, infer one per printed page from "
+            "the used range and page setup (paper size, orientation, margins, "
+            "scale)."
+        ),
+    )
     _add_auto_page_breaks_argument(parser)
+    parser.add_argument(
+        "--tables-dir",
+        type=Path,
+        help=(
+            "Optional directory to write each detected table as a typed "
+            "Parquet/Arrow file (requires pyarrow)."
+        ),
+    )
+    parser.add_argument(
+        "--tables-format",
+        default="parquet",
+        choices=["parquet", "arrow"],
+        help="Columnar format for --tables-dir (arrow writes Arrow IPC files).",
+    )
+    parser.add_argument(
+        "--dump-parts",
+        type=Path,
+        metavar="DIR",
+        help=(
+            "Copy the raw drawing, chart, and table XML parts into DIR "
+            "(e.g. DIR/charts/chart1.xml) for debugging."
+        ),
+    )
     parser.add_argument(
         "--alpha-col",
-        action="store_true",
+        action=argparse.BooleanOptionalAction,
         help="Output column keys as Excel-style ABC names (A, B, ..., Z, AA, ...) instead of 0-based indices.",
     )
     parser.add_argument(
         "--include-backend-metadata",
-        action="store_true",
+        action=argparse.BooleanOptionalAction,
         help=(
             "Include shape/chart backend metadata fields "
             "(provenance, approximation_level, confidence)."
         ),
     )
+    parser.add_argument(
+        "--jq",
+        metavar="EXPR",
+        help=(
+            "jq expression applied to the json/yaml/toon output before writing, "
+            "e.g. 'del(.sheets[].shapes)' (requires the jq package)."
+        ),
+    )
+    parser.add_argument(
+        "--query",
+        metavar="EXPR",
+        help=(
+            "Output only the result of a JSONPath ('$.sheets.*.charts[*].title') "
+            "or JMESPath ('sheets.Sheet2.rows', requires jmespath) expression."
+        ),
+    )
+    parser.add_argument(
+        "--fields",
+        type=_fields_arg,
+        metavar="LIST",
+        help=(
+            "Comma-separated sections to keep in json/yaml/toon output "
+            "('shapes,charts'), '-section' to drop one, and 'section.field' / "
+            "'-section.field' to keep or drop item fields; use --fields=-rows "
+            "when the list starts with '-'."
+        ),
+    )
+    parser.add_argument(
+        "--dedupe-strings",
+        action=argparse.BooleanOptionalAction,
+        help=(
+            "Store string cell values repeated on a sheet once in its 'strings' "
+            "table and reference them by index from each row's 's' map."
+        ),
+    )
+    parser.add_argument(
+        "--redact",
+        type=_redact_names_arg,
+        metavar="RULES",
+        help=(
+            "Comma-separated built-in redaction rules applied to cell values and "
+            "shape texts before output: email, phone, national_id."
+        ),
+    )
+    parser.add_argument(
+        "--redact-method",
+        choices=["mask", "hash"],
+        default="mask",
+        help=(
+            "Replace redacted values with [REDACTED:<rule>] (mask) or a salted "
+            "SHA-256 prefix (hash). Default: mask."
+        ),
+    )
+    parser.add_argument(
+        "--max-cells",
+        type=int,
+        metavar="N",
+        help="Fail when the workbook has more than N non-empty cells.",
+    )
+    parser.add_argument(
+        "--max-sheets",
+        type=int,
+        metavar="N",
+        help="Fail when the workbook has more than N sheets.",
+    )
+    parser.add_argument(
+        "--max-output-size",
+        type=_split_size_arg,
+        metavar="SIZE",
+        help="Fail when the serialized output exceeds SIZE bytes (e.g. 50M).",
+    )
+    parser.add_argument(
+        "--max-rows-per-sheet",
+        type=int,
+        metavar="N",
+        help=(
+            "Clip each sheet after row N; clipped sheets carry a 'truncation' "
+            "marker with their original extents."
+        ),
+    )
+    parser.add_argument(
+        "--max-cols-per-sheet",
+        type=int,
+        metavar="N",
+        help=(
+            "Clip each sheet after its first N columns; clipped sheets carry a "
+            "'truncation' marker with their original extents."
+        ),
+    )
+    parser.add_argument(
+        "--truncate-on-limit",
+        action="store_true",
+        help=(
+            "Keep the first --max-sheets sheets and --max-cells cells instead of "
+            "failing."
+        ),
+    )
+    parser.add_argument(
+        "--best-effort",
+        action=argparse.BooleanOptionalAction,
+        help=(
+            "Skip corrupted zip entries and malformed XML parts instead of failing; "
+            "broken sheets are dropped and listed in the output warnings."
+        ),
+    )
+    parser.add_argument(
+        "--fast-cells",
+        action=argparse.BooleanOptionalAction,
+        help=(
+            "Stream cell values directly from the sheet XML (faster and lower "
+            "memory on very large or wide sheets)."
+        ),
+    )
+    parser.add_argument(
+        "--stable-ids",
+        action=argparse.BooleanOptionalAction,
+        help=(
+            "Use the drawing's cNvPr ids as shape ids and emit source_id, so ids "
+            "stay the same across runs."
+        ),
+    )
+    parser.add_argument(
+        "--shape-paths",
+        action=argparse.BooleanOptionalAction,
+        help=(
+            "Add simplified polylines of freeform shapes and ink strokes to "
+            "shape geometry (OOXML parsing only)."
+        ),
+    )
+    parser.add_argument(
+        "--position-unit",
+        choices=["pixels", "points", "emu", "millimeters"],
+        default=None,
+        help=(
+            "Unit for shape and chart positions (default: points from Excel/"
+            "LibreOffice, pixels from the OOXML fallback)."
+        ),
+    )
+    parser.add_argument(
+        "--position-dpi",
+        type=int,
+        default=None,
+        help="DPI for pixel positions (default: 96).",
+    )
+    parser.add_argument(
+        "--locale",
+        default=None,
+        help=(
+            "Parse numbers and dates written as text with this locale's "
+            "separators and date order (e.g. en-US, de-DE, fr, ja)."
+        ),
+    )
+    parser.add_argument(
+        "--normalize-text",
+        action=argparse.BooleanOptionalAction,
+        help=(
+            "NFKC-normalize cell values and shape texts, remove invisible "
+            "characters, and collapse whitespace."
+        ),
+    )
+    parser.add_argument(
+        "--include-phonetic",
+        action=argparse.BooleanOptionalAction,
+        help="Include phonetic (furigana) readings of text cells in rows.",
+    )
+    parser.add_argument(
+        "--formulas-r1c1",
+        action=argparse.BooleanOptionalAction,
+        help=(
+            "Also output formulas grouped by R1C1 text (formulas_map_r1c1), "
+            "so copied formulas share one entry; enables formula extraction."
+        ),
+    )
+    parser.add_argument(
+        "--compress-formulas",
+        action=argparse.BooleanOptionalAction,
+        help=(
+            "Output formulas filled down or across once per range "
+            "(formula_blocks) instead of once per cell in formulas_map."
+        ),
+    )
+    parser.add_argument(
+        "--table-stats",
+        action=argparse.BooleanOptionalAction,
+        help=(
+            "Add count, null rate, distinct count, and numeric min/max/mean "
+            "for each table candidate column (table_stats)."
+        ),
+    )
+    parser.add_argument(
+        "--rank-tables",
+        action=argparse.BooleanOptionalAction,
+        help=(
+            "Score table candidates by borders, header styling, density, and "
+            "rectangularity (table_scores) and list the best first."
+        ),
+    )
+    parser.add_argument(
+        "--pivot-caches",
+        action=argparse.BooleanOptionalAction,
+        help=(
+            "Recover the source records of pivot tables whose source data was "
+            "deleted, from the pivot cache (pivot_caches)."
+        ),
+    )
+    parser.add_argument(
+        "--power-queries",
+        action=argparse.BooleanOptionalAction,
+        help="List Power Query (Get & Transform) names and M code (power_queries).",
+    )
+    parser.add_argument(
+        "--connections",
+        action=argparse.BooleanOptionalAction,
+        help=(
+            "List external data connections with masked credentials, refresh "
+            "settings, and bound tables (connections)."
+        ),
+    )
+    parser.add_argument(
+        "--security-report",
+        action=argparse.BooleanOptionalAction,
+        help=(
+            "Add a security section: digital signatures, signer certificates, "
+            "and parts modified after signing."
+        ),
+    )
+    parser.add_argument(
+        "--named-ranges",
+        action=argparse.BooleanOptionalAction,
+        help="Attach defined names to the sheet ranges they cover (named_ranges).",
+    )
+    parser.add_argument(
+        "--styles",
+        action=argparse.BooleanOptionalAction,
+        help=(
+            "Export fonts, fills, borders, and number formats once (styles) and "
+            "each sheet's cells per cell format ID (style_map)."
+        ),
+    )
+    parser.add_argument(
+        "--metrics",
+        action=argparse.BooleanOptionalAction,
+        help=(
+            "Add a metrics section: extraction time and per-sheet parse time, "
+            "part sizes, and row/shape/chart counts."
+        ),
+    )
+    parser.add_argument(
+        "--sample-rows",
+        type=int,
+        metavar="N",
+        help=(
+            "Keep at most N rows per sheet (see --sample-strategy); sampled "
+            "sheets carry a 'sample' marker with the total row count."
+        ),
+    )
+    parser.add_argument(
+        "--sample-strategy",
+        choices=["head", "tail", "random"],
+        help="Rows kept by --sample-rows: first, last, or random. Default: head.",
+    )
+    parser.add_argument(
+        "--sample-seed",
+        type=int,
+        metavar="SEED",
+        help="Random seed for --sample-strategy random (reproducible samples).",
+    )
+    parser.add_argument(
+        "--range",
+        dest="ranges",
+        action="append",
+        metavar="TARGET",
+        help=(
+            "Extract only this range: 'name:SalesData' (defined name) or "
+            "'Sheet1!A1:D20'. Repeat for several targets."
+        ),
+    )
+    parser.add_argument(
+        "--table-score-threshold",
+        type=float,
+        metavar="SCORE",
+        help="Minimum table signal score of a table candidate (default: 0.35).",
+    )
+    parser.add_argument(
+        "--table-density-min",
+        type=float,
+        metavar="RATIO",
+        help=(
+            "Minimum share of non-empty cells in a table candidate; candidates "
+            "below both this and --table-coverage-min are dropped (default: 0.05)."
+        ),
+    )
+    parser.add_argument(
+        "--table-coverage-min",
+        type=float,
+        metavar="RATIO",
+        help="Minimum share of the candidate covered by its data (default: 0.2).",
+    )
+    parser.add_argument(
+        "--table-min-cells",
+        type=int,
+        metavar="N",
+        help="Skip table detection on blocks with fewer non-empty cells (default: 3).",
+    )
+    parser.add_argument(
+        "--table-gap-tolerance",
+        type=int,
+        metavar="N",
+        help=(
+            "Empty rows/columns bridged when grouping cells into one table "
+            "candidate, for sparse layout sheets (default: 0)."
+        ),
+    )
+    parser.add_argument(
+        "--include-outline",
+        action=argparse.BooleanOptionalAction,
+        help=(
+            "Include row/column outline (grouping) levels and collapsed state "
+            "(default: verbose mode only)."
+        ),
+    )
+    parser.add_argument(
+        "--covered-ranges",
+        action=argparse.BooleanOptionalAction,
+        help=(
+            "Add the cell range each shape covers (covered_range) and the cells "
+            "connectors start and end in (begin_cell, end_cell); loads row "
+            "heights and column widths (default: verbose mode and markdown "
+            "output only)."
+        ),
+    )
+    parser.add_argument(
+        "-v",
+        "--verbose",
+        action="count",
+        default=0,
+        help="Log fallbacks and parse failures to stderr (-v: info, -vv: debug).",
+    )
+    parser.add_argument(
+        "-q",
+        "--quiet",
+        action="store_true",
+        help="Only log errors to stderr (overrides -v).",
+    )
+    parser.add_argument(
+        "--log-format",
+        choices=["text", "json"],
+        default="text",
+        help="Log record format on stderr: text or json (one object per line).",
+    )
+    parser.add_argument(
+        "--config",
+        type=Path,
+        help=(
+            "YAML/JSON/TOML config file with extraction profiles. Flags given "
+            "on the command line override the profile."
+        ),
+    )
+    parser.add_argument(
+        "--profile",
+        help="Profile name in --config (defaults to the file's default profile).",
+    )
+    parser.add_argument(
+        "--report",
+        type=Path,
+        metavar="PATH",
+        help=(
+            "Write a JSON run report (status, exit code, durations per "
+            "component, counts, warnings) to PATH."
+        ),
+    )
+    parser.add_argument(
+        "--notify-url",
+        metavar="URL",
+        help=(
+            "POST a JSON summary (files processed, failures, output locations) "
+            "to URL when the run finishes, successful or not."
+        ),
+    )
     return parser
 
 
+# CLI option destinations -> ExtractionProfile fields they override. These
+# options default to None (on/off switches also accept --no-<flag>), so a
+# value other than None was given on the command line.
+_PROFILE_OPTIONS = {
+    "mode": "mode",
+    "format": "format",
+    "pretty": "pretty",
+    "jq": "jq",
+    "query": "query",
+    "fields": "fields",
+    "dedupe_strings": "dedupe_strings",
+    "alpha_col": "alpha_col",
+    "include_backend_metadata": "include_backend_metadata",
+    "ranges": "ranges",
+    "best_effort": "best_effort",
+    "fast_cells": "fast_cells",
+    "stable_ids": "stable_ids",
+    "shape_paths": "include_shape_paths",
+    "position_unit": "position_unit",
+    "position_dpi": "position_dpi",
+    "locale": "locale",
+    "normalize_text": "normalize_text",
+    "include_phonetic": "include_phonetic",
+    "include_outline": "include_outline",
+    "covered_ranges": "include_covered_ranges",
+    "infer_print_areas": "infer_print_areas",
+    "formulas_r1c1": "include_formulas_r1c1",
+    "compress_formulas": "compress_formulas",
+    "table_stats": "include_table_stats",
+    "rank_tables": "rank_tables",
+    "pivot_caches": "include_pivot_caches",
+    "power_queries": "include_power_queries",
+    "connections": "include_connections",
+    "security_report": "include_security_report",
+    "named_ranges": "include_named_ranges",
+    "styles": "include_styles",
+    "metrics": "include_metrics",
+}
+
+
+def _resolve_profile(
+    args: argparse.Namespace, profile: object, **overrides: object
+) -> object:
+    """Apply the options given on the command line on top of the profile.
+
+    Uses ``exstruct.config.resolve_profile`` like ``process_excel`` does, and
+    stores the resolved mode and format back in ``args`` for validation.
+    ``--no-<flag>`` turns off a switch the profile enables.
+    """
+    resolved = _load_resolve_profile()(
+        profile,
+        **{field: getattr(args, dest) for dest, field in _PROFILE_OPTIONS.items()},
+        **overrides,
+    )
+    args.mode = getattr(resolved, "mode", None) or "standard"
+    args.format = getattr(resolved, "format", None) or "json"
+    return resolved
+
+
+def _table_params(args: argparse.Namespace) -> dict[str, float | int] | None:
+    """Collect the table detection thresholds given on the command line."""
+    params = {
+        "table_score_threshold": args.table_score_threshold,
+        "density_min": args.table_density_min,
+        "coverage_min": args.table_coverage_min,
+        "min_nonempty_cells": args.table_min_cells,
+        "gap_tolerance": args.table_gap_tolerance,
+    }
+    overrides = {key: value for key, value in params.items() if value is not None}
+    return overrides or None
+
+
 def _validate_auto_page_breaks_request(args: argparse.Namespace) -> None:
     """Validate runtime requirements for auto page-break export."""
     auto_page_breaks_dir = getattr(args, "auto_page_breaks_dir", None)
@@ -230,6 +1027,214 @@ def _validate_auto_page_breaks_request(args: argparse.Namespace) -> None:
     raise RuntimeError(f"{message}{reason}")
 
 
+def _is_invalid_input(exc: BaseException) -> bool:
+    """Return whether ``exc`` or its causes say the input is not a workbook."""
+    current: BaseException | None = exc
+    while current is not None:
+        if type(current).__name__ in _INVALID_INPUT_ERRORS:
+            return True
+        current = current.__cause__
+    return False
+
+
+def _write_run_report(path: Path, report: RunReport) -> bool:
+    """Write the run report as JSON; returns False (after printing) on failure."""
+    try:
+        path.write_text(
+            json.dumps(report.to_dict(), ensure_ascii=False, indent=2) + "\n",
+            encoding="utf-8",
+        )
+    except OSError as exc:
+        print(f"Error: Failed to write run report to '{path}': {exc}", flush=True)
+        return False
+    return True
+
+
+def _finish(
+    args: argparse.Namespace,
+    report: RunReport | None,
+    code: int,
+    *,
+    error: str | None = None,
+) -> int:
+    """Record the exit code in the report, write it when requested, and return.
+
+    Without a report (the run stopped before extraction), a failed report
+    carrying ``error`` is written instead. With ``--notify-url`` the run
+    summary is POSTed last; a failed notification is reported on stdout but
+    does not change the exit code.
+    """
+    if args.report is None and args.notify_url is None:
+        return code
+    if report is None:
+        report = _load_run_report()(file=str(args.input), status="error", error=error)
+    report.exit_code = code
+    if args.report is not None and not _write_run_report(args.report, report):
+        code = report.exit_code = EXIT_FAILURE
+    if args.notify_url is not None:
+        _send_notification(args, report)
+    return code
+
+
+def _output_locations(args: argparse.Namespace) -> list[str]:
+    """Return the output file and directories of the run (``stdout`` if none)."""
+    locations = [
+        getattr(args, name, None)
+        for name in (
+            "output",
+            "sheets_dir",
+            "print_areas_dir",
+            "auto_page_breaks_dir",
+            "shapes_dir",
+            "charts_dir",
+            "chart_images_dir",
+            "snapshots_dir",
+            "tables_dir",
+            "dump_parts",
+            "report",
+        )
+    ]
+    outputs = [str(location) for location in locations if location is not None]
+    return outputs if args.output is not None else ["stdout", *outputs]
+
+
+def _send_notification(args: argparse.Namespace, report: RunReport) -> None:
+    """POST the run summary to ``--notify-url``; print a warning on failure."""
+    build_run_summary, post_run_summary = _load_notifier()
+    summary = build_run_summary([report], _output_locations(args))
+    try:
+        post_run_summary(args.notify_url, summary)
+    except Exception as exc:
+        print(f"Warning: {exc}", flush=True)
+
+
+def _precheck(args: argparse.Namespace) -> tuple[str, int] | None:
+    """Return the message and exit code of a problem found before extraction."""
+    if isinstance(args.input, Path) and not args.input.exists():
+        return f"File not found: {args.input}", EXIT_NOT_FOUND
+    if args.split_size is not None and args.output is None:
+        return "Error: --split-size requires --output.", EXIT_FAILURE
+    if args.rows_per_file is not None and args.sheets_dir is None:
+        return "Error: --rows-per-file requires --sheets-dir.", EXIT_FAILURE
+    if args.profile is not None and args.config is None:
+        return "Error: --profile requires --config.", EXIT_FAILURE
+    if args.sample_rows is None and (
+        args.sample_strategy is not None or args.sample_seed is not None
+    ):
+        return (
+            "Error: --sample-strategy and --sample-seed require --sample-rows.",
+            EXIT_FAILURE,
+        )
+    return None
+
+
+def _run_extraction(args: argparse.Namespace, report: RunReport) -> None:
+    """Resolve redaction, limits, sampling, and profile, then run ``process_excel``."""
+    redaction = None
+    if args.redact is not None:
+        redaction = _load_redaction_from_names()(
+            args.redact, method=args.redact_method
+        )
+    limits = None
+    if any(
+        value is not None
+        for value in (
+            args.max_cells,
+            args.max_sheets,
+            args.max_output_size,
+            args.max_rows_per_sheet,
+            args.max_cols_per_sheet,
+        )
+    ):
+        limits = _load_limits_options()(
+            max_cells=args.max_cells,
+            max_sheets=args.max_sheets,
+            max_output_bytes=args.max_output_size,
+            max_rows_per_sheet=args.max_rows_per_sheet,
+            max_cols_per_sheet=args.max_cols_per_sheet,
+            on_exceed="truncate" if args.truncate_on_limit else "error",
+        )
+    sample_rows = None
+    if args.sample_rows is not None:
+        sample_rows = _load_sample_rows_options()(
+            strategy=args.sample_strategy or "head",
+            n=args.sample_rows,
+            seed=args.sample_seed,
+        )
+    profile = None
+    if args.config is not None:
+        profile = _load_load_profile()(args.config, args.profile)
+    profile = _resolve_profile(
+        args,
+        profile,
+        table_detection=_table_params(args),
+        redaction=redaction,
+        limits=limits,
+        sample_rows=sample_rows,
+    )
+    _validate_auto_page_breaks_request(args)
+    process_excel(
+        file_path=args.input,
+        output_path=args.output,
+        out_fmt=args.format,
+        image=args.image,
+        pdf=args.pdf,
+        dpi=args.dpi,
+        mode=args.mode,
+        pretty=args.pretty,
+        sheets_dir=args.sheets_dir,
+        print_areas_dir=args.print_areas_dir,
+        auto_page_breaks_dir=getattr(args, "auto_page_breaks_dir", None),
+        alpha_col=args.alpha_col,
+        include_backend_metadata=args.include_backend_metadata,
+        split_size=args.split_size,
+        rows_per_file=args.rows_per_file,
+        tables_dir=args.tables_dir,
+        tables_format=args.tables_format,
+        shapes_dir=args.shapes_dir,
+        charts_dir=args.charts_dir,
+        chart_images_dir=args.chart_images_dir,
+        chart_images_format=args.chart_images_format,
+        snapshots_dir=args.snapshots_dir,
+        snapshots_format=args.snapshots_format,
+        dump_parts_dir=args.dump_parts,
+        profile=profile,
+        jq=args.jq,
+        query=args.query,
+        fields=args.fields,
+        dedupe_strings=args.dedupe_strings,
+        redaction=redaction,
+        limits=limits,
+        best_effort=args.best_effort,
+        fast_cells=args.fast_cells,
+        stable_ids=args.stable_ids,
+        include_shape_paths=args.shape_paths,
+        position_unit=args.position_unit,
+        position_dpi=args.position_dpi,
+        locale=args.locale,
+        normalize_text=args.normalize_text,
+        include_phonetic=args.include_phonetic,
+        include_outline=args.include_outline,
+        include_covered_ranges=args.covered_ranges,
+        infer_print_areas=args.infer_print_areas,
+        include_formulas_r1c1=args.formulas_r1c1,
+        compress_formulas=args.compress_formulas,
+        include_table_stats=args.table_stats,
+        table_params=_table_params(args),
+        rank_tables=args.rank_tables,
+        include_pivot_caches=args.pivot_caches,
+        include_power_queries=args.power_queries,
+        include_connections=args.connections,
+        include_security_report=args.security_report,
+        include_named_ranges=args.named_ranges,
+        include_styles=args.styles,
+        include_metrics=args.metrics,
+        sample_rows=sample_rows,
+        ranges=args.ranges,
+        report=report,
+    )
+
+
 def main(argv: list[str] | None = None) -> int:
     """Run the CLI entrypoint.
 
@@ -237,42 +1242,52 @@ def main(argv: list[str] | None = None) -> int:
         argv: Optional argument list for testing.
 
     Returns:
-        Exit code (0 for success, 1 for failure).
+        Exit code: ``EXIT_OK`` (0), ``EXIT_FAILURE`` (1), ``EXIT_USAGE`` (2,
+        from argparse), ``EXIT_NOT_FOUND`` (3), ``EXIT_INVALID_INPUT`` (4), or
+        ``EXIT_PARTIAL`` (5, output written but the workbook has warnings).
     """
     _ensure_utf8_stdout()
     resolved_argv = list(sys.argv[1:] if argv is None else argv)
     if is_edit_subcommand(resolved_argv):
         return run_edit_cli(resolved_argv)
+    if is_inspect_subcommand(resolved_argv):
+        return run_inspect_cli(resolved_argv)
+    if is_template_subcommand(resolved_argv):
+        return run_template_cli(resolved_argv[1:])
+    if is_grep_subcommand(resolved_argv):
+        return run_grep_cli(resolved_argv[1:])
+    if is_annotate_subcommand(resolved_argv):
+        return run_annotate_cli(resolved_argv[1:])
+    if is_bulk_subcommand(resolved_argv):
+        return run_bulk_cli(resolved_argv)
 
     parser = build_parser()
     args = parser.parse_args(resolved_argv)
+    _load_configure_logging()(
+        verbosity=args.verbose, quiet=args.quiet, log_format=args.log_format
+    )
 
-    input_path: Path = args.input
-    if not input_path.exists():
-        print(f"File not found: {input_path}", flush=True)
-        return 0
+    early_error = _precheck(args)
+    if early_error is not None:
+        message, code = early_error
+        print(message, flush=True)
+        return _finish(args, None, code, error=message)
 
-    try:
-        _validate_auto_page_breaks_request(args)
-        process_excel(
-            file_path=input_path,
-            output_path=args.output,
-            out_fmt=args.format,
-            image=args.image,
-            pdf=args.pdf,
-            dpi=args.dpi,
-            mode=args.mode,
-            pretty=args.pretty,
-            sheets_dir=args.sheets_dir,
-            print_areas_dir=args.print_areas_dir,
-            auto_page_breaks_dir=getattr(args, "auto_page_breaks_dir", None),
-            alpha_col=args.alpha_col,
-            include_backend_metadata=args.include_backend_metadata,
-        )
-        return 0
-    except Exception as exc:
-        print(f"Error: {exc}", flush=True)
-        return 1
+    report = _load_run_report()(file=str(args.input))
+    start = time.monotonic()
+    with _load_capture_log_warnings()() as log_warnings:
+        try:
+            _run_extraction(args, report)
+        except Exception as exc:
+            print(f"Error: {exc}", flush=True)
+            report.status = "error"
+            report.error = str(exc)
+            code = EXIT_INVALID_INPUT if _is_invalid_input(exc) else EXIT_FAILURE
+        else:
+            code = EXIT_PARTIAL if report.status == "partial" else EXIT_OK
+    report.durations["total"] = round(time.monotonic() - start, 4)
+    report.log_warnings = list(log_warnings)
+    return _finish(args, report, code)
 
 
 if __name__ == "__main__":
@@ -0,0 +1,232 @@
+"""CLI subcommands for lightweight workbook summaries and inventories."""
+
+from __future__ import annotations
+
+import argparse
+import json
+from pathlib import Path
+import sys
+from typing import TYPE_CHECKING
+
+if TYPE_CHECKING:  # pragma: no cover - typing only
+    from exstruct.models import WorkbookCatalog
+
+
+def build_summary_parser() -> argparse.ArgumentParser:
+    """Build the summary-subcommand CLI parser."""
+
+    parser = argparse.ArgumentParser(
+        prog="exstruct summary",
+        description=(
+            "Print per-sheet counts and package part sizes without full extraction "
+            "(.xlsx/.xlsm only)."
+        ),
+    )
+    parser.add_argument("input", type=Path, help="Excel file (.xlsx/.xlsm)")
+    parser.add_argument(
+        "--pretty",
+        action="store_true",
+        help="Pretty-print JSON output.",
+    )
+    return parser
+
+
+def build_catalog_parser() -> argparse.ArgumentParser:
+    """Build the catalog-subcommand CLI parser."""
+
+    parser = argparse.ArgumentParser(
+        prog="exstruct catalog",
+        description=(
+            "Summarize every .xlsx/.xlsm workbook under a directory into one "
+            "inventory."
+        ),
+    )
+    parser.add_argument("directory", type=Path, help="Directory to scan.")
+    parser.add_argument(
+        "-o",
+        "--output",
+        type=Path,
+        help="Output path. If omitted, writes to stdout.",
+    )
+    parser.add_argument(
+        "-f",
+        "--format",
+        default="json",
+        choices=["json", "csv"],
+        help="Inventory format.",
+    )
+    parser.add_argument(
+        "--no-recursive",
+        action="store_true",
+        help="Only scan the top-level directory.",
+    )
+    parser.add_argument(
+        "--pretty",
+        action="store_true",
+        help="Pretty-print JSON output.",
+    )
+    parser.add_argument(
+        "--notify-url",
+        metavar="URL",
+        help=(
+            "POST one JSON summary of the scan (workbooks processed, failures, "
+            "output location) to URL when it finishes, successful or not."
+        ),
+    )
+    return parser
+
+
+def run_inspect_cli(argv: list[str]) -> int:
+    """Run the summary or catalog subcommand named by ``argv[0]``.
+
+    Args:
+        argv: Arguments starting with the subcommand name.
+
+    Returns:
+        Exit code (0 for success, 1 for failure).
+    """
+
+    command, rest = argv[0], argv[1:]
+    if command == "catalog":
+        return run_catalog_cli(rest)
+    return run_summary_cli(rest)
+
+
+def run_summary_cli(argv: list[str]) -> int:
+    """Run the summary subcommand.
+
+    Args:
+        argv: Arguments following the ``summary`` command name.
+
+    Returns:
+        Exit code (0 for success, 1 for failure).
+    """
+
+    parser = build_summary_parser()
+    try:
+        args = parser.parse_args(argv)
+    except SystemExit as exc:
+        return 0 if exc.code in (None, 0) else 1
+
+    input_path: Path = args.input
+    if not input_path.exists():
+        _print_error(f"File not found: {input_path}")
+        return 1
+
+    from exstruct.ooxml.summary import summarize_workbook_ooxml
+
+    try:
+        summary = summarize_workbook_ooxml(input_path)
+    except Exception as exc:
+        _print_error(f"Error: {exc}")
+        return 1
+    print(_to_json(summary.model_dump(mode="json"), pretty=args.pretty), flush=True)
+    return 0
+
+
+def run_catalog_cli(argv: list[str]) -> int:
+    """Run the catalog subcommand.
+
+    Args:
+        argv: Arguments following the ``catalog`` command name.
+
+    Returns:
+        Exit code (0 for success, 1 for failure).
+    """
+
+    parser = build_catalog_parser()
+    try:
+        args = parser.parse_args(argv)
+    except SystemExit as exc:
+        return 0 if exc.code in (None, 0) else 1
+
+    directory: Path = args.directory
+    if not directory.is_dir():
+        message = f"Directory not found: {directory}"
+        _print_error(message)
+        _notify_catalog(args, None, error=message)
+        return 1
+
+    from exstruct.ooxml.catalog import build_workbook_catalog, catalog_to_csv
+
+    catalog = build_workbook_catalog(directory, recursive=not args.no_recursive)
+    if args.format == "csv":
+        text = catalog_to_csv(catalog.entries)
+    else:
+        text = _to_json(catalog.model_dump(mode="json"), pretty=args.pretty) + "\n"
+    if args.output is None:
+        sys.stdout.write(text)
+        sys.stdout.flush()
+    else:
+        args.output.write_text(text, encoding="utf-8")
+    _notify_catalog(args, catalog)
+    return 0
+
+
+def _notify_catalog(
+    args: argparse.Namespace,
+    catalog: WorkbookCatalog | None,
+    *,
+    error: str | None = None,
+) -> None:
+    """POST the aggregated catalog summary to ``--notify-url`` when given.
+
+    Every workbook becomes one ``files`` entry (``error`` for unreadable
+    ones); a scan that could not start reports the directory itself. A failed
+    notification is printed as a warning and does not change the exit code.
+    """
+    if args.notify_url is None:
+        return
+    from exstruct.engine import RunReport
+    from exstruct.notify import build_run_summary, post_run_summary
+
+    if catalog is None:
+        reports = [
+            RunReport(
+                file=str(args.directory), status="error", exit_code=1, error=error
+            )
+        ]
+    else:
+        reports = [
+            RunReport(
+                file=entry.path,
+                status="error" if entry.error else "ok",
+                error=entry.error,
+                counts={
+                    "sheets": len(entry.sheets),
+                    "cells": entry.non_empty_cells,
+                    "formulas": entry.formula_count,
+                    "shapes": entry.shape_count,
+                    "charts": entry.chart_count,
+                    "tables": entry.table_count,
+                },
+            )
+            for entry in catalog.entries
+        ]
+    outputs = ["stdout" if args.output is None else str(args.output)]
+    summary = build_run_summary(reports, outputs, event="catalog.finished")
+    try:
+        post_run_summary(args.notify_url, summary)
+    except Exception as exc:
+        _print_error(f"Warning: {exc}")
+
+
+def _to_json(payload: object, *, pretty: bool) -> str:
+    """Serialize a JSON payload."""
+
+    return json.dumps(payload, ensure_ascii=False, indent=2 if pretty else None)
+
+
+def _print_error(message: str) -> None:
+    """Print one CLI error to stderr."""
+
+    print(message, file=sys.stderr, flush=True)
+
+
+__all__ = [
+    "build_catalog_parser",
+    "build_summary_parser",
+    "run_catalog_cli",
+    "run_inspect_cli",
+    "run_summary_cli",
+]
@@ -0,0 +1,92 @@
+"""CLI subcommand for template-based targeted extraction."""
+
+from __future__ import annotations
+
+import argparse
+import json
+from pathlib import Path
+import sys
+
+
+def build_template_parser() -> argparse.ArgumentParser:
+    """Build the apply-template-subcommand CLI parser."""
+
+    parser = argparse.ArgumentParser(
+        prog="exstruct apply-template",
+        description=(
+            "Read the fields named in a template (cell addresses, ranges, or "
+            "labels) and print them as one flat JSON record."
+        ),
+    )
+    parser.add_argument(
+        "template",
+        type=Path,
+        help="Template file (.yaml/.yml requires pyyaml, .json, or .toml).",
+    )
+    parser.add_argument("input", type=Path, help="Excel file (.xlsx/.xlsm/.xls)")
+    parser.add_argument(
+        "-m",
+        "--mode",
+        default="light",
+        choices=["light", "libreoffice", "standard", "verbose"],
+        help="Extraction detail level used to read the workbook.",
+    )
+    parser.add_argument(
+        "-o",
+        "--output",
+        type=Path,
+        help="Output path. If omitted, writes to stdout.",
+    )
+    parser.add_argument(
+        "--pretty",
+        action="store_true",
+        help="Pretty-print JSON output.",
+    )
+    return parser
+
+
+def run_template_cli(argv: list[str]) -> int:
+    """Run the apply-template subcommand.
+
+    Args:
+        argv: Arguments following the ``apply-template`` command name.
+
+    Returns:
+        Exit code (0 for success, 1 for failure).
+    """
+
+    parser = build_template_parser()
+    try:
+        args = parser.parse_args(argv)
+    except SystemExit as exc:
+        return 0 if exc.code in (None, 0) else 1
+
+    input_path: Path = args.input
+    if not input_path.exists():
+        _print_error(f"File not found: {input_path}")
+        return 1
+
+    from exstruct import extract
+    from exstruct.template import apply_template, load_template
+
+    try:
+        template = load_template(args.template)
+        record = apply_template(extract(input_path, mode=args.mode), template)
+    except Exception as exc:
+        _print_error(f"Error: {exc}")
+        return 1
+    text = json.dumps(record, ensure_ascii=False, indent=2 if args.pretty else None)
+    if args.output is None:
+        print(text, flush=True)
+    else:
+        args.output.write_text(text + "\n", encoding="utf-8")
+    return 0
+
+
+def _print_error(message: str) -> None:
+    """Print one CLI error to stderr."""
+
+    print(message, file=sys.stderr, flush=True)
+
+
+__all__ = ["build_template_parser", "run_template_cli"]
@@ -0,0 +1,434 @@
+"""Reusable extraction profiles loaded from YAML/JSON/TOML config files."""
+
+from __future__ import annotations
+
+import json
+from pathlib import Path
+import tomllib
+from typing import Any, cast
+
+from pydantic import BaseModel, ConfigDict, Field, ValidationError
+
+from .engine import (
+    ComponentsOptions,
+    ExtractionMode,
+    FilterOptions,
+    FormatOptions,
+    LimitsOptions,
+    OutputFormat,
+    OutputOptions,
+    PositionUnit,
+    SampleRowsOptions,
+    StructOptions,
+    TableParams,
+)
+from .errors import ConfigError
+from .redaction import RedactionOptions, Redactor
+
+_STRUCT_FLAGS = (
+    "include_cell_links",
+    "include_colors_map",
+    "include_formulas_map",
+    "include_merged_cells",
+    "include_dimensions",
+    "include_cell_errors",
+    "include_outline",
+    "include_covered_ranges",
+)
+_FILTER_FLAGS = (
+    "include_rows",
+    "include_shapes",
+    "include_charts",
+    "include_tables",
+    "include_print_areas",
+    "include_auto_print_areas",
+    "include_shape_size",
+    "include_chart_size",
+    "include_backend_metadata",
+    "include_merged_cells",
+)
+
+
+class TableThresholds(BaseModel):
+    """Table detection threshold overrides (see ``set_table_detection_params``)."""
+
+    model_config = ConfigDict(extra="forbid")
+
+    table_score_threshold: float | None = None
+    density_min: float | None = None
+    coverage_min: float | None = None
+    min_nonempty_cells: int | None = None
+    gap_tolerance: int | None = Field(default=None, ge=0)
+
+    def to_table_params(self) -> TableParams | None:
+        """Return the configured overrides, or None when nothing is set."""
+        params = self.model_dump(exclude_none=True)
+        return cast(TableParams, params) if params else None
+
+
+class ExtractionProfile(BaseModel):
+    """One named set of extraction and output settings.
+
+    Unset fields (None) fall back to the same defaults as the CLI and
+    ``process_excel``, including the mode-dependent ``include_*`` defaults.
+    """
+
+    model_config = ConfigDict(extra="forbid")
+
+    mode: ExtractionMode | None = Field(default=None, description="Extraction mode.")
+    format: OutputFormat | str | None = Field(
+        default=None, description="Output format or registered encoder name."
+    )
+    pretty: bool | None = Field(default=None, description="Pretty-print JSON.")
+    indent: int | None = Field(default=None, description="JSON indent width.")
+    jq: str | None = Field(
+        default=None, description="jq expression applied to the output payload."
+    )
+    query: str | None = Field(
+        default=None,
+        description="JSONPath ('$...') or JMESPath expression selecting the output.",
+    )
+    fields: list[str] | None = Field(
+        default=None,
+        description="Sections/item fields to keep ('shapes') or drop ('-rows').",
+    )
+    dedupe_strings: bool | None = Field(
+        default=None,
+        description="Store repeated string cell values once per sheet.",
+    )
+    alpha_col: bool | None = Field(
+        default=None, description="Use Excel-style column keys (A, B, ...)."
+    )
+    sheets: list[str] | None = Field(
+        default=None, description="Sheet name glob patterns to keep."
+    )
+    exclude_sheets: list[str] = Field(
+        default_factory=list, description="Sheet name glob patterns to drop."
+    )
+    ranges: list[str] | None = Field(
+        default=None,
+        description="Extraction targets ('name:<Defined name>' or '<Sheet>!A1:D20').",
+    )
+    table_detection: TableThresholds = Field(
+        default_factory=TableThresholds, description="Table detection thresholds."
+    )
+    components: ComponentsOptions = Field(
+        default_factory=ComponentsOptions, description="Components to extract."
+    )
+    redaction: RedactionOptions | None = Field(
+        default=None, description="Redaction applied before output."
+    )
+    limits: LimitsOptions = Field(
+        default_factory=LimitsOptions, description="Size guardrails."
+    )
+    best_effort: bool | None = Field(
+        default=None, description="Skip corrupted parts instead of failing."
+    )
+    fast_cells: bool | None = Field(
+        default=None, description="Stream cell values from the sheet XML."
+    )
+    stable_ids: bool | None = Field(
+        default=None, description="Use drawing cNvPr ids as shape ids."
+    )
+    include_shape_paths: bool | None = Field(
+        default=None, description="Add freeform and ink polylines to shapes."
+    )
+    position_unit: PositionUnit | None = Field(
+        default=None, description="Unit for shape and chart positions."
+    )
+    position_dpi: int | None = Field(
+        default=None, gt=0, description="DPI for pixel positions."
+    )
+    locale: str | None = Field(
+        default=None, description="Locale for parsing numbers and dates in text."
+    )
+    normalize_text: bool | None = Field(
+        default=None, description="NFKC-normalize and trim cell and shape text."
+    )
+    include_phonetic: bool | None = Field(
+        default=None, description="Include phonetic (furigana) readings of cells."
+    )
+    infer_print_areas: bool | None = Field(
+        default=None,
+        description="Infer per-page print areas for sheets without a print area.",
+    )
+    include_formulas_r1c1: bool | None = Field(
+        default=None, description="Also group formulas by their R1C1 text."
+    )
+    compress_formulas: bool | None = Field(
+        default=None, description="Compress copied formulas into formula blocks."
+    )
+    include_table_stats: bool | None = Field(
+        default=None, description="Profile each table candidate column."
+    )
+    rank_tables: bool | None = Field(
+        default=None, description="Score table candidates and sort by confidence."
+    )
+    include_pivot_caches: bool | None = Field(
+        default=None, description="Recover pivot cache records of deleted sources."
+    )
+    include_power_queries: bool | None = Field(
+        default=None, description="List Power Query names and M code."
+    )
+    include_connections: bool | None = Field(
+        default=None, description="List external data connections."
+    )
+    include_security_report: bool | None = Field(
+        default=None, description="Report digital signatures and integrity."
+    )
+    include_named_ranges: bool | None = Field(
+        default=None, description="Attach defined names to the sheets they cover."
+    )
+    include_styles: bool | None = Field(
+        default=None, description="Export the workbook style table and style_map."
+    )
+    include_metrics: bool | None = Field(
+        default=None, description="Add extraction timing and size metrics."
+    )
+    sample_rows: SampleRowsOptions | None = Field(
+        default=None, description="Keep only a sample of rows per sheet."
+    )
+    include_cell_links: bool | None = None
+    include_colors_map: bool | None = None
+    include_formulas_map: bool | None = None
+    include_merged_cells: bool | None = None
+    include_dimensions: bool | None = None
+    include_cell_errors: bool | None = None
+    include_outline: bool | None = None
+    include_covered_ranges: bool | None = None
+    include_rows: bool | None = None
+    include_shapes: bool | None = None
+    include_charts: bool | None = None
+    include_tables: bool | None = None
+    include_print_areas: bool | None = None
+    include_auto_print_areas: bool | None = None
+    include_shape_size: bool | None = None
+    include_chart_size: bool | None = None
+    include_backend_metadata: bool | None = None
+
+    def _set_flags(self, names: tuple[str, ...]) -> dict[str, Any]:
+        return {
+            name: getattr(self, name)
+            for name in names
+            if getattr(self, name) is not None
+        }
+
+    def to_struct_options(self) -> StructOptions:
+        """Build extraction-time options from this profile."""
+        return StructOptions(
+            mode=self.mode or "standard",
+            table_params=self.table_detection.to_table_params(),
+            components=self.components,
+            alpha_col=bool(self.alpha_col),
+            transforms=(Redactor(self.redaction),) if self.redaction else (),
+            limits=self.limits,
+            best_effort=bool(self.best_effort),
+            fast_cells=bool(self.fast_cells),
+            stable_ids=bool(self.stable_ids),
+            include_shape_paths=bool(self.include_shape_paths),
+            position_unit=self.position_unit,
+            position_dpi=self.position_dpi,
+            locale=self.locale,
+            normalize_text=bool(self.normalize_text),
+            include_phonetic=bool(self.include_phonetic),
+            infer_print_areas=bool(self.infer_print_areas),
+            include_formulas_r1c1=bool(self.include_formulas_r1c1),
+            compress_formulas=bool(self.compress_formulas),
+            include_table_stats=bool(self.include_table_stats),
+            rank_tables=bool(self.rank_tables),
+            include_pivot_caches=bool(self.include_pivot_caches),
+            include_power_queries=bool(self.include_power_queries),
+            include_connections=bool(self.include_connections),
+            include_security_report=bool(self.include_security_report),
+            include_named_ranges=bool(self.include_named_ranges),
+            include_styles=bool(self.include_styles),
+            include_metrics=bool(self.include_metrics),
+            sample_rows=self.sample_rows,
+            **self._set_flags(_STRUCT_FLAGS),
+        )
+
+    def to_filter_options(self) -> FilterOptions:
+        """Build output filters (component flags and sheet patterns).
+
+        Print areas and shape/chart sizes follow the mode unless the profile
+        sets them: sizes are kept in verbose mode, and print areas in every
+        mode but light (light too with ``infer_print_areas``).
+        """
+        mode = self.mode or "standard"
+        flags: dict[str, Any] = {
+            "include_print_areas": None
+            if mode == "light" and not self.infer_print_areas
+            else True,
+            "include_shape_size": mode == "verbose",
+            "include_chart_size": mode == "verbose",
+        }
+        flags.update(self._set_flags(_FILTER_FLAGS))
+        return FilterOptions(
+            sheets=self.sheets,
+            exclude_sheets=self.exclude_sheets,
+            ranges=self.ranges,
+            **flags,
+        )
+
+    def to_output_options(self) -> OutputOptions:
+        """Build output-time options from this profile."""
+        return OutputOptions(
+            format=FormatOptions(
+                fmt=self.format or "json",
+                pretty=bool(self.pretty),
+                indent=self.indent,
+                jq=self.jq,
+                query=self.query,
+                fields=self.fields,
+                dedupe_strings=bool(self.dedupe_strings),
+            ),
+            filters=self.to_filter_options(),
+        )
+
+
+class ExStructConfig(BaseModel):
+    """Top-level config file: named profiles and an optional default."""
+
+    model_config = ConfigDict(extra="forbid")
+
+    default_profile: str | None = Field(
+        default=None, description="Profile used when none is requested."
+    )
+    profiles: dict[str, ExtractionProfile] = Field(
+        default_factory=dict, description="Named extraction profiles."
+    )
+
+    def get_profile(self, name: str | None = None) -> ExtractionProfile:
+        """Return a profile by name.
+
+        Without a name, ``default_profile`` is used, or the only profile when the
+        file defines exactly one.
+
+        Args:
+            name: Profile name.
+
+        Returns:
+            Matching profile.
+
+        Raises:
+            ConfigError: If the profile is unknown or cannot be chosen implicitly.
+        """
+        available = ", ".join(sorted(self.profiles)) or "(none)"
+        chosen = name or self.default_profile
+        if chosen is None:
+            if len(self.profiles) == 1:
+                return next(iter(self.profiles.values()))
+            raise ConfigError(
+                "No profile selected and no default_profile set. "
+                f"Available: {available}."
+            )
+        profile = self.profiles.get(chosen)
+        if profile is None:
+            raise ConfigError(f"Unknown profile '{chosen}'. Available: {available}.")
+        return profile
+
+
+def resolve_profile(
+    profile: ExtractionProfile | None, **overrides: Any
+) -> ExtractionProfile:
+    """Apply explicitly given settings on top of a profile.
+
+    ``process_excel`` and the CLI both resolve their arguments through this
+    function, so a profile means the same thing to either of them.
+
+    Args:
+        profile: Base profile; None starts from the defaults.
+        **overrides: Profile fields to replace. None leaves the profile value
+            as is; ``False`` turns off a switch the profile enables.
+            ``table_detection`` takes a mapping of thresholds that is merged
+            key by key into the profile's.
+
+    Returns:
+        New profile with the overrides applied.
+
+    Raises:
+        ConfigError: If an override is not a profile field.
+    """
+    base = profile if profile is not None else ExtractionProfile()
+    unknown = sorted(set(overrides) - set(ExtractionProfile.model_fields))
+    if unknown:
+        raise ConfigError(f"Unknown profile settings: {', '.join(unknown)}.")
+    update = {name: value for name, value in overrides.items() if value is not None}
+    thresholds = update.pop("table_detection", None)
+    if thresholds:
+        update["table_detection"] = base.table_detection.model_copy(
+            update=dict(thresholds)
+        )
+    return base.model_copy(update=update)
+
+
+def _read_config_data(path: Path) -> object:
+    text = path.read_text(encoding="utf-8")
+    match path.suffix.lower():
+        case ".yaml" | ".yml":
+            from .io.serialize import _require_yaml
+
+            yaml = _require_yaml()
+            try:
+                return yaml.safe_load(text)
+            except yaml.YAMLError as exc:
+                raise ValueError(str(exc)) from exc
+        case ".json":
+            return json.loads(text)
+        case ".toml":
+            return tomllib.loads(text)
+        case _:
+            raise ConfigError(
+                f"Unsupported config file '{path.name}'. "
+                "Use .yaml, .yml, .json, or .toml."
+            )
+
+
+def load_config(path: str | Path) -> ExStructConfig:
+    """Load and validate an ExStruct config file.
+
+    Args:
+        path: YAML (requires pyyaml), JSON, or TOML config file.
+
+    Returns:
+        Validated config.
+
+    Raises:
+        ConfigError: If the file cannot be read, parsed, or validated.
+    """
+    config_path = Path(path)
+    try:
+        data = _read_config_data(config_path)
+    except ConfigError:
+        raise
+    except OSError as exc:
+        raise ConfigError(f"Failed to read config '{config_path}': {exc}") from exc
+    except ValueError as exc:
+        raise ConfigError(f"Failed to parse config '{config_path}': {exc}") from exc
+    try:
+        return ExStructConfig.model_validate(data or {})
+    except ValidationError as exc:
+        raise ConfigError(f"Invalid config '{config_path}': {exc}") from exc
+
+
+def load_profile(path: str | Path, name: str | None = None) -> ExtractionProfile:
+    """Load a config file and return one of its profiles.
+
+    Args:
+        path: Config file path.
+        name: Profile name; defaults to the file's default/only profile.
+
+    Returns:
+        Selected profile.
+    """
+    return load_config(path).get_profile(name)
+
+
+__all__ = [
+    "ExStructConfig",
+    "ExtractionProfile",
+    "TableThresholds",
+    "load_config",
+    "load_profile",
+    "resolve_profile",
+]
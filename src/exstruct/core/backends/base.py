@@ -2,15 +2,33 @@
 
 from __future__ import annotations
 
+from collections.abc import Collection
 from dataclasses import dataclass
 from typing import Literal, Protocol
 
-from ...models import Arrow, CellRow, Chart, PrintArea, Shape, SmartArt
-from ..cells import MergedCellRange, WorkbookColorsMap, WorkbookFormulasMap
+from ...models import (
+    Arrow,
+    CellError,
+    CellRow,
+    Chart,
+    PrintArea,
+    Shape,
+    SheetOutline,
+    SmartArt,
+)
+from ..cells import (
+    MergedCellRange,
+    SheetDimensions,
+    WorkbookColorsMap,
+    WorkbookFormulasMap,
+)
 
 CellData = dict[str, list[CellRow]]
 PrintAreaData = dict[str, list[PrintArea]]
 MergedCellData = dict[str, list[MergedCellRange]]
+DimensionData = dict[str, SheetDimensions]
+CellErrorData = dict[str, list[CellError]]
+OutlineData = dict[str, SheetOutline]
 ShapeData = dict[str, list[Shape | Arrow | SmartArt]]
 ChartData = dict[str, list[Chart]]
 
@@ -31,8 +49,14 @@ class BackendConfig:
 class Backend(Protocol):
     """Protocol for backend implementations."""
 
-    def extract_cells(self, *, include_links: bool) -> CellData:
-        """Extract cell rows from the workbook."""
+    def extract_cells(
+        self,
+        *,
+        include_links: bool,
+        fast: bool = False,
+        sheets: Collection[str] | None = None,
+    ) -> CellData:
+        """Extract cell rows from the workbook (only ``sheets`` when given)."""
 
     def extract_print_areas(self) -> PrintAreaData:
         """Extract print areas from the workbook."""
@@ -16,7 +16,7 @@ from ..cells import (
     extract_sheet_formulas_map_com,
 )
 from ..charts import get_charts
-from ..ranges import parse_range_zero_based
+from ..ranges import parse_range_zero_based, split_range_list
 from ..shapes import get_shapes_with_position
 from .base import ChartData, MergedCellData, PrintAreaData, RichBackend, ShapeData
 
@@ -52,7 +52,8 @@ class ComBackend:
                 )
             if not raw:
                 continue
-            for part in str(raw).split(","):
+            # PageSetup.PrintArea belongs to this sheet, whatever its prefix says.
+            for part in split_range_list(str(raw)):
                 parsed = _parse_print_area_range(part)
                 if not parsed:
                     continue
@@ -191,13 +192,16 @@ class ComRichBackend(RichBackend):
     """Protocol adapter for COM-based shape and chart extraction."""
 
     workbook: xw.Book
+    stable_ids: bool = False
 
     def extract_shapes(
         self, *, mode: Literal["libreoffice", "standard", "verbose"]
     ) -> ShapeData:
         """Extract sheet shapes through Excel COM using the requested richness mode."""
 
-        return get_shapes_with_position(self.workbook, mode=mode)
+        return get_shapes_with_position(
+            self.workbook, mode=mode, stable_ids=self.stable_ids
+        )
 
     def extract_charts(
         self, *, mode: Literal["libreoffice", "standard", "verbose"]
@@ -254,28 +258,4 @@ def _split_csv_respecting_quotes(raw: str) -> list[str]:
     Returns:
         List of split parts.
     """
-    parts: list[str] = []
-    buf: list[str] = []
-    in_quote = False
-    i = 0
-    while i < len(raw):
-        ch = raw[i]
-        if ch == "'":
-            if in_quote and i + 1 < len(raw) and raw[i + 1] == "'":
-                buf.append("''")
-                i += 2
-                continue
-            in_quote = not in_quote
-            buf.append(ch)
-            i += 1
-            continue
-        if ch == "," and not in_quote:
-            parts.append("".join(buf).strip())
-            buf = []
-            i += 1
-            continue
-        buf.append(ch)
-        i += 1
-    if buf:
-        parts.append("".join(buf).strip())
-    return [p for p in parts if p]
+    return split_range_list(raw)
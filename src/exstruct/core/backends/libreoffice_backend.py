@@ -16,6 +16,7 @@ from ..libreoffice import (
     LibreOfficeSession,
     LibreOfficeWorkbookHandle,
 )
+from ...ooxml.drawing import resolve_stable_ids
 from ..ooxml_drawing import OoxmlConnectorInfo, OoxmlShapeInfo, read_sheet_drawings
 from ..shapes import angle_to_compass, compute_line_angle_deg
 from .base import ChartData, RichBackend, ShapeData
@@ -80,10 +81,12 @@ class LibreOfficeRichBackend(RichBackend):
         session_factory: Callable[
             [], AbstractContextManager[_LibreOfficeRichSession]
         ] = (LibreOfficeSession.from_env),
+        stable_ids: bool = False,
     ) -> None:
         """Store the workbook path and session factory used for lazy LibreOffice extraction."""
 
         self.file_path = file_path
+        self.stable_ids = stable_ids
         self._session_factory = session_factory
         self._chart_geometries: dict[str, list[LibreOfficeChartGeometry]] | None = None
         self._draw_page_shapes: dict[str, list[LibreOfficeDrawPageShape]] | None = None
@@ -128,12 +131,16 @@ class LibreOfficeRichBackend(RichBackend):
                     drawing_connectors=drawing.connectors
                     if drawing is not None
                     else [],
+                    stable_ids=self.stable_ids,
+                    part_name=drawing.part_name if drawing is not None else None,
                 )
                 continue
             if drawing is not None:
                 shape_data[sheet_name] = _build_shapes_from_ooxml(
                     drawing.shapes,
                     drawing.connectors,
+                    stable_ids=self.stable_ids,
+                    part_name=drawing.part_name,
                 )
         return shape_data
 
@@ -185,6 +192,11 @@ class LibreOfficeRichBackend(RichBackend):
                         w=width,
                         h=height,
                         series=chart_info.series,
+                        legend=chart_info.legend,
+                        grouping=chart_info.grouping,
+                        bar_direction=chart_info.bar_direction,
+                        value_axes=chart_info.value_axes,
+                        scatter_style=chart_info.scatter_style,
                         l=left,
                         t=top,
                         provenance="libreoffice_uno",
@@ -261,12 +273,17 @@ class LibreOfficeRichBackend(RichBackend):
 def _build_shapes_from_ooxml(
     shapes: Sequence[OoxmlShapeInfo],
     connectors: Sequence[OoxmlConnectorInfo],
+    *,
+    stable_ids: bool = False,
+    part_name: str | None = None,
 ) -> list[Shape | Arrow | SmartArt]:
     """Build emitted shape models directly from OOXML drawing metadata.
 
     Args:
         shapes: Parsed OOXML shape candidates.
         connectors: Parsed OOXML connector candidates.
+        stable_ids: Use drawing (cNvPr) ids as shape ids and set ``source_id``.
+        part_name: Drawing part name used for ``source_id``.
 
     Returns:
         Emitted shape and arrow models derived from the OOXML drawing anchors.
@@ -275,10 +292,9 @@ def _build_shapes_from_ooxml(
     emitted: list[Shape | Arrow | SmartArt] = []
     drawing_to_shape_id: dict[int, int] = {}
     shape_boxes: dict[int, _ShapeBox] = {}
-    next_shape_id = 0
-    for shape_info in shapes:
-        next_shape_id += 1
-        shape_id = next_shape_id
+    shape_ids = _shape_ids([info.ref.drawing_id for info in shapes], stable_ids)
+    source_part = part_name if stable_ids else None
+    for shape_info, shape_id in zip(shapes, shape_ids, strict=True):
         drawing_to_shape_id[shape_info.ref.drawing_id] = shape_id
         box = _to_shape_box(
             shape_id=shape_id,
@@ -298,7 +314,9 @@ def _build_shapes_from_ooxml(
                 w=shape_info.ref.width,
                 h=shape_info.ref.height,
                 rotation=shape_info.rotation,
+                z_order=shape_info.ref.z_order,
                 type=shape_info.shape_type,
+                source_id=_source_id(source_part, shape_info.ref.drawing_id),
                 provenance="libreoffice_uno",
                 approximation_level="partial",
                 confidence=0.75,
@@ -312,6 +330,9 @@ def _build_shapes_from_ooxml(
             shape_name_to_id={},
             shape_boxes=shape_boxes,
         )
+        begin_x, begin_y, end_x, end_y = _connector_endpoint_coords(
+            connector_info=connector_info, uno_connector=None
+        )
         emitted.append(
             Arrow(
                 id=None,
@@ -321,10 +342,16 @@ def _build_shapes_from_ooxml(
                 w=connector_info.ref.width,
                 h=connector_info.ref.height,
                 rotation=connector_info.rotation,
+                z_order=connector_info.ref.z_order,
                 begin_arrow_style=connector_info.begin_arrow_style,
                 end_arrow_style=connector_info.end_arrow_style,
                 begin_id=begin_id,
                 end_id=end_id,
+                begin_x=begin_x,
+                begin_y=begin_y,
+                end_x=end_x,
+                end_y=end_y,
+                source_id=_source_id(source_part, connector_info.ref.drawing_id),
                 direction=_resolve_direction(
                     connector_info=connector_info,
                     uno_connector=None,
@@ -345,6 +372,8 @@ def _build_shapes_from_draw_page(
     *,
     drawing_shapes: Sequence[OoxmlShapeInfo],
     drawing_connectors: Sequence[OoxmlConnectorInfo],
+    stable_ids: bool = False,
+    part_name: str | None = None,
 ) -> list[Shape | Arrow | SmartArt]:
     """Merge UNO draw-page snapshots with OOXML drawing metadata.
 
@@ -352,6 +381,9 @@ def _build_shapes_from_draw_page(
         snapshots: LibreOffice draw-page snapshots for one worksheet.
         drawing_shapes: OOXML shape candidates for the worksheet.
         drawing_connectors: OOXML connector candidates for the worksheet.
+        stable_ids: Use matched drawing (cNvPr) ids as shape ids and set
+            ``source_id``; unmatched snapshots get ids above the largest one.
+        part_name: Drawing part name used for ``source_id``.
 
     Returns:
         Emitted shape and arrow models built from the combined metadata.
@@ -365,14 +397,17 @@ def _build_shapes_from_draw_page(
     drawing_to_shape_id: dict[int, int] = {}
     shape_name_to_id: dict[str, int] = {}
     shape_boxes: dict[int, _ShapeBox] = {}
-    next_shape_id = 0
     assigned_shapes: list[
         tuple[LibreOfficeDrawPageShape, OoxmlShapeInfo | None, int]
     ] = []
+    paired_shapes = list(zip(snapshot_shapes, matched_shapes, strict=False))
+    shape_ids = _shape_ids(
+        [info.ref.drawing_id if info else None for _, info in paired_shapes],
+        stable_ids,
+    )
+    source_part = part_name if stable_ids else None
 
-    for snapshot, shape_info in zip(snapshot_shapes, matched_shapes, strict=False):
-        next_shape_id += 1
-        shape_id = next_shape_id
+    for (snapshot, shape_info), shape_id in zip(paired_shapes, shape_ids, strict=True):
         assigned_shapes.append((snapshot, shape_info, shape_id))
         if shape_info is not None:
             drawing_to_shape_id[shape_info.ref.drawing_id] = shape_id
@@ -390,7 +425,8 @@ def _build_shapes_from_draw_page(
     shape_index = 0
     connector_index = 0
 
-    for snapshot in snapshots:
+    # Draw-page order is the stacking order (back-most first).
+    for z_order, snapshot in enumerate(snapshots, start=1):
         if snapshot.is_connector:
             connector_info = matched_connectors[connector_index]
             connector_index += 1
@@ -401,6 +437,9 @@ def _build_shapes_from_draw_page(
                 shape_name_to_id=shape_name_to_id,
                 shape_boxes=shape_boxes,
             )
+            begin_x, begin_y, end_x, end_y = _connector_endpoint_coords(
+                connector_info=connector_info, uno_connector=snapshot
+            )
             emitted.append(
                 Arrow(
                     id=None,
@@ -428,6 +467,7 @@ def _build_shapes_from_draw_page(
                         snapshot.rotation,
                         connector_info.rotation if connector_info else None,
                     ),
+                    z_order=z_order,
                     begin_arrow_style=connector_info.begin_arrow_style
                     if connector_info is not None
                     else None,
@@ -436,6 +476,14 @@ def _build_shapes_from_draw_page(
                     else None,
                     begin_id=begin_id,
                     end_id=end_id,
+                    begin_x=begin_x,
+                    begin_y=begin_y,
+                    end_x=end_x,
+                    end_y=end_y,
+                    source_id=_source_id(
+                        source_part,
+                        connector_info.ref.drawing_id if connector_info else None,
+                    ),
                     direction=_resolve_direction(
                         connector_info=connector_info,
                         uno_connector=snapshot,
@@ -478,9 +526,13 @@ def _build_shapes_from_draw_page(
                     shape_snapshot.rotation,
                     shape_info.rotation if shape_info else None,
                 ),
+                z_order=z_order,
                 type=shape_info.shape_type
                 if shape_info is not None and shape_info.shape_type
                 else _shape_type_from_uno(shape_snapshot.shape_type),
+                source_id=_source_id(
+                    source_part, shape_info.ref.drawing_id if shape_info else None
+                ),
                 provenance="libreoffice_uno",
                 approximation_level="partial",
                 confidence=0.75,
@@ -489,6 +541,22 @@ def _build_shapes_from_draw_page(
     return emitted
 
 
+def _shape_ids(drawing_ids: Sequence[int | None], stable_ids: bool) -> list[int]:
+    """Return emitted shape ids: drawing ids when stable, else 1..n."""
+
+    if stable_ids:
+        return resolve_stable_ids(drawing_ids)
+    return list(range(1, len(drawing_ids) + 1))
+
+
+def _source_id(part_name: str | None, drawing_id: int | None) -> str | None:
+    """Return ``part#id`` when both the drawing part and a drawing id are known."""
+
+    if part_name is None or not drawing_id:
+        return None
+    return f"{part_name}#{drawing_id}"
+
+
 def _log_unmatched_ooxml_candidates(
     *,
     sheet_name: str,
@@ -657,6 +725,22 @@ def _connector_endpoints(
     return (start, end)
 
 
+def _connector_endpoint_coords(
+    *,
+    connector_info: OoxmlConnectorInfo | None,
+    uno_connector: LibreOfficeDrawPageShape | None,
+) -> tuple[int | None, int | None, int | None, int | None]:
+    """Return rounded (begin_x, begin_y, end_x, end_y) for an emitted connector."""
+
+    start, end = _connector_endpoints(
+        connector_info=connector_info,
+        uno_connector=uno_connector,
+    )
+    if start is None or end is None:
+        return (None, None, None, None)
+    return (round(start[0]), round(start[1]), round(end[0]), round(end[1]))
+
+
 def _nearest_shape_id(
     point: tuple[float, float] | None, shape_boxes: dict[int, _ShapeBox]
 ) -> int | None:
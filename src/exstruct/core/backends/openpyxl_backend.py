@@ -2,6 +2,7 @@
 
 from __future__ import annotations
 
+from collections.abc import Collection
 from dataclasses import dataclass
 import logging
 from pathlib import Path
@@ -12,15 +13,27 @@ from ..cells import (
     WorkbookColorsMap,
     WorkbookFormulasMap,
     detect_tables_openpyxl,
+    extract_sheet_cell_errors,
     extract_sheet_cells,
     extract_sheet_cells_with_links,
     extract_sheet_colors_map,
+    extract_sheet_dimensions,
     extract_sheet_formulas_map,
     extract_sheet_merged_cells,
+    extract_sheet_names,
+    extract_sheet_outlines,
 )
-from ..ranges import parse_range_zero_based
+from ..fast_cells import extract_sheet_cells_fast
+from ..ranges import parse_range_zero_based, split_range_list
 from ..workbook import openpyxl_workbook
-from .base import CellData, MergedCellData, PrintAreaData
+from .base import (
+    CellData,
+    CellErrorData,
+    DimensionData,
+    MergedCellData,
+    OutlineData,
+    PrintAreaData,
+)
 
 logger = logging.getLogger(__name__)
 
@@ -35,24 +48,47 @@ class OpenpyxlBackend:
 
     file_path: Path
 
-    def extract_cells(self, *, include_links: bool) -> CellData:
+    def extract_cells(
+        self,
+        *,
+        include_links: bool,
+        fast: bool = False,
+        sheets: Collection[str] | None = None,
+    ) -> CellData:
         """Extract cell rows from the workbook.
 
         Args:
             include_links: Whether to include hyperlinks.
+            fast: Stream values from the sheet XML instead of using pandas.
+            sheets: Sheet names to extract; None extracts every sheet.
 
         Returns:
             Mapping of sheet name to cell rows.
         """
-        return (
-            extract_sheet_cells_with_links(self.file_path)
-            if include_links
-            else extract_sheet_cells(self.file_path)
-        )
+        if include_links:
+            data = extract_sheet_cells_with_links(self.file_path, fast=fast)
+        elif fast:
+            data = extract_sheet_cells_fast(self.file_path)
+        else:
+            return extract_sheet_cells(self.file_path, sheets=sheets)
+        if sheets is None:
+            return data
+        return {name: rows for name, rows in data.items() if name in sheets}
+
+    def extract_sheet_names(self) -> list[str]:
+        """Return the worksheet names without reading any cells.
+
+        Returns:
+            Worksheet names in workbook order.
+        """
+        return extract_sheet_names(self.file_path)
 
     def extract_print_areas(self) -> PrintAreaData:
         """Extract print areas per sheet using openpyxl defined names.
 
+        Sheet-scoped ``_xlnm.Print_Area`` names are attributed by their scope;
+        a workbook-level name only fills sheets that have no scoped one.
+
         Returns:
             Mapping of sheet name to print area list.
         """
@@ -60,9 +96,11 @@ class OpenpyxlBackend:
             with openpyxl_workbook(
                 self.file_path, data_only=True, read_only=False
             ) as wb:
-                areas = _extract_print_areas_from_defined_names(wb)
-                if not areas:
-                    areas = _extract_print_areas_from_sheet_props(wb)
+                areas = _extract_print_areas_from_sheet_props(wb)
+                for sheet_name, sheet_areas in (
+                    _extract_print_areas_from_defined_names(wb).items()
+                ):
+                    areas.setdefault(sheet_name, sheet_areas)
                 return areas
         except Exception:
             return {}
@@ -102,6 +140,46 @@ class OpenpyxlBackend:
         except Exception:
             return {}
 
+    def extract_dimensions(self) -> DimensionData:
+        """Extract explicit row heights and column widths per sheet.
+
+        Returns:
+            Mapping of sheet name to sheet dimensions.
+        """
+        try:
+            return extract_sheet_dimensions(self.file_path)
+        except Exception as exc:
+            logger.warning(
+                "Dimension extraction failed; skipping row/column sizes. (%r)", exc
+            )
+            return {}
+
+    def extract_cell_errors(self) -> CellErrorData:
+        """Extract cells holding error values per sheet.
+
+        Returns:
+            Mapping of sheet name to error cells.
+        """
+        try:
+            return extract_sheet_cell_errors(self.file_path)
+        except Exception as exc:
+            logger.warning(
+                "Cell error extraction failed; skipping error cells. (%r)", exc
+            )
+            return {}
+
+    def extract_outlines(self) -> OutlineData:
+        """Extract row/column outline groups per sheet.
+
+        Returns:
+            Mapping of sheet name to outline, for sheets that have groups.
+        """
+        try:
+            return extract_sheet_outlines(self.file_path)
+        except Exception as exc:
+            logger.warning("Outline extraction failed; skipping outline. (%r)", exc)
+            return {}
+
     def extract_formulas_map(self) -> WorkbookFormulasMap | None:
         """
         Extract a mapping of workbook formulas for each sheet.
@@ -143,23 +221,41 @@ class OpenpyxlBackend:
 def _extract_print_areas_from_defined_names(workbook: object) -> PrintAreaData:
     """Extract print areas from defined names in an openpyxl workbook.
 
+    Names scoped to a sheet (``ws.defined_names`` or ``localSheetId``) belong
+    to that sheet regardless of the sheet prefix in their formula, so quoted
+    names containing ``!`` or commas are attributed correctly. Only unscoped
+    names fall back to the sheet named in each destination.
+
     Args:
         workbook: openpyxl workbook instance.
 
     Returns:
         Mapping of sheet name to print area list.
     """
+    areas: PrintAreaData = {}
+    for ws in getattr(workbook, "worksheets", []):
+        local = getattr(ws, "defined_names", None)
+        scoped = local.get("_xlnm.Print_Area") if local is not None else None
+        if scoped is not None and getattr(scoped, "attr_text", None):
+            _append_print_areas(areas, str(ws.title), str(scoped.attr_text))
+
     defined = getattr(workbook, "defined_names", None)
     if defined is None:
-        return {}
+        return areas
     defined_area = defined.get("_xlnm.Print_Area")
     if not defined_area:
-        return {}
-
-    areas: PrintAreaData = {}
-    sheetnames = set(getattr(workbook, "sheetnames", []))
+        return areas
+
+    sheetnames = list(getattr(workbook, "sheetnames", []))
+    scope = getattr(defined_area, "localSheetId", None)
+    if scope is not None:
+        if 0 <= int(scope) < len(sheetnames):
+            sheet_name = sheetnames[int(scope)]
+            if sheet_name not in areas:
+                _append_print_areas(areas, sheet_name, str(defined_area.attr_text))
+        return areas
     for sheet_name, range_str in defined_area.destinations:
-        if sheet_name not in sheetnames:
+        if sheet_name not in sheetnames or sheet_name in areas:
             continue
         _append_print_areas(areas, sheet_name, str(range_str))
     return areas
@@ -192,7 +288,7 @@ def _append_print_areas(areas: PrintAreaData, sheet_name: str, range_str: str) -
         sheet_name: Target sheet name.
         range_str: Raw range string, possibly comma-separated.
     """
-    for part in str(range_str).split(","):
+    for part in split_range_list(str(range_str)):
         parsed = _parse_print_area_range(part)
         if not parsed:
             continue
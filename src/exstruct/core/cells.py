@@ -3,7 +3,7 @@
 from __future__ import annotations
 
 from collections import deque
-from collections.abc import Callable, Sequence
+from collections.abc import Callable, Collection, Mapping, Sequence
 from dataclasses import dataclass
 from decimal import Decimal, InvalidOperation
 import logging
@@ -12,15 +12,27 @@ import os
 from pathlib import Path
 import re
 from typing import Literal
+import zipfile
 
 import numpy as np
 from openpyxl.styles.colors import Color
-from openpyxl.utils import get_column_letter, range_boundaries
+from openpyxl.utils import (
+    column_index_from_string,
+    get_column_letter,
+    range_boundaries,
+)
 from openpyxl.worksheet.worksheet import Worksheet
 import pandas as pd
 import xlwings as xw
 
-from ..models import CellRow
+from ..geometry.grid import (
+    DEFAULT_BASE_COLUMN_WIDTH,
+    DEFAULT_MAX_DIGIT_WIDTH,
+    default_column_width_from_base,
+    max_digit_width,
+)
+from ..models import CellError, CellRow, NamedRange, OutlineGroup, SheetOutline
+from ..sources import open_source
 from .workbook import openpyxl_workbook
 
 logger = logging.getLogger(__name__)
@@ -40,8 +52,12 @@ _DETECTION_CONFIG = {
     "density_min": 0.05,
     "coverage_min": 0.2,
     "min_nonempty_cells": 3,
+    "gap_tolerance": 0,
 }
+# Share of bordered cells above which a border rectangle is kept whole.
+_BORDERED_GRID_MIN_RATIO = 0.9
 _DEFAULT_BACKGROUND_HEX = "FFFFFF"
+_DEFAULT_ROW_HEIGHT_POINTS = 15.0
 _XL_COLOR_NONE = -4142
 _BORDER_CLUSTER_BACKEND_ENV = "EXSTRUCT_BORDER_CLUSTER_BACKEND"
 
@@ -103,6 +119,14 @@ class WorkbookFormulasMap:
         return self.sheets.get(sheet_name)
 
 
+@dataclass(frozen=True)
+class TableStyleSignals:
+    """Formatting evidence that a range is a table (both values 0.0-1.0)."""
+
+    border_ratio: float
+    header_emphasis: float
+
+
 @dataclass(frozen=True)
 class MergedCellRange:
     """Merged cell range with normalized value."""
@@ -114,6 +138,28 @@ class MergedCellRange:
     v: str
 
 
+@dataclass(frozen=True)
+class SheetDimensions:
+    """Explicit row heights and column widths for a single worksheet.
+
+    Attributes:
+        column_widths: 0-based column index string to width (Excel character
+            units); hidden columns are reported as 0.0.
+        row_heights: 1-based row index string to height (points); hidden rows
+            are reported as 0.0.
+        default_column_width: Sheet default column width (character units).
+        default_row_height: Sheet default row height (points).
+        max_digit_width: Maximum digit width in pixels of the workbook default
+            font, the unit of the column widths.
+    """
+
+    column_widths: dict[str, float]
+    row_heights: dict[str, float]
+    default_column_width: float | None
+    default_row_height: float | None
+    max_digit_width: int = DEFAULT_MAX_DIGIT_WIDTH
+
+
 @dataclass(frozen=True)
 class TableScanLimits:
     """Limits for openpyxl border scanning during table detection."""
@@ -698,9 +744,29 @@ def warn_once(key: str, message: str) -> None:
         _warned_keys.add(key)
 
 
-def extract_sheet_cells(file_path: Path) -> dict[str, list[CellRow]]:
-    """Read all sheets via pandas and convert to CellRow list while skipping empty cells."""
-    dfs = pd.read_excel(file_path, header=None, sheet_name=None, dtype=str)
+def extract_sheet_names(file_path: Path) -> list[str]:
+    """Return sheet names in the same order as ``extract_sheet_cells``."""
+    with pd.ExcelFile(open_source(file_path)) as book:
+        return [str(name) for name in book.sheet_names]
+
+
+def extract_sheet_cells(
+    file_path: Path, *, sheets: Collection[str] | None = None
+) -> dict[str, list[CellRow]]:
+    """Read sheets via pandas and convert to CellRow list while skipping empty cells.
+
+    Args:
+        file_path: Workbook path.
+        sheets: Sheet names to read; None reads every sheet.
+    """
+    dfs = pd.read_excel(
+        open_source(file_path),
+        header=None,
+        sheet_name=None
+        if sheets is None
+        else [name for name in extract_sheet_names(file_path) if name in sheets],
+        dtype=str,
+    )
     result: dict[str, list[CellRow]] = {}
     for sheet_name, df in dfs.items():
         df = df.fillna("")
@@ -719,10 +785,16 @@ def extract_sheet_cells(file_path: Path) -> dict[str, list[CellRow]]:
     return result
 
 
-def extract_sheet_cells_with_links(file_path: Path) -> dict[str, list[CellRow]]:
+def extract_sheet_cells_with_links(
+    file_path: Path, *, fast: bool = False
+) -> dict[str, list[CellRow]]:
     """
     Extract cells and hyperlinks per sheet.
 
+    Args:
+        file_path: Workbook path.
+        fast: Read values with the streaming reader in ``fast_cells``.
+
     Returns:
         {sheet_name: [CellRow(r=..., c=..., links={"col_index": url, ...}), ...]}
 
@@ -731,7 +803,12 @@ def extract_sheet_cells_with_links(file_path: Path) -> dict[str, list[CellRow]]:
         - Collects hyperlinks via openpyxl (requires read_only=False because border maps/hyperlinks need full objects).
         - Links are mapped by column index string (e.g., "0") to hyperlink.target.
     """
-    cell_rows = extract_sheet_cells(file_path)
+    if fast:
+        from .fast_cells import extract_sheet_cells_fast
+
+        cell_rows = extract_sheet_cells_fast(file_path)
+    else:
+        cell_rows = extract_sheet_cells(file_path)
     links_by_sheet: dict[str, dict[int, dict[str, str]]] = {}
     with openpyxl_workbook(file_path, data_only=True, read_only=False) as wb:
         for ws in wb.worksheets:
@@ -797,6 +874,447 @@ def extract_sheet_merged_cells(file_path: Path) -> dict[str, list[MergedCellRang
     return merged_by_sheet
 
 
+def extract_sheet_dimensions(file_path: Path) -> dict[str, SheetDimensions]:
+    """Extract explicit row heights and column widths per sheet via openpyxl.
+
+    Only sizes recorded in the workbook are reported; rows and columns that use
+    the sheet defaults are omitted. Column ranges are expanded up to the used
+    column range so trailing "hide everything" ranges stay bounded.
+
+    Args:
+        file_path: Excel workbook path.
+
+    Returns:
+        Mapping of sheet name to SheetDimensions.
+    """
+    dimensions: dict[str, SheetDimensions] = {}
+    with openpyxl_workbook(file_path, data_only=True, read_only=False) as wb:
+        for ws in wb.worksheets:
+            dimensions[ws.title] = _extract_worksheet_dimensions(ws)
+    return dimensions
+
+
+def _extract_worksheet_dimensions(ws: Worksheet) -> SheetDimensions:
+    """Collect explicit dimension entries from a worksheet."""
+    sheet_format = getattr(ws, "sheet_format", None)
+    digit_width = _workbook_max_digit_width(ws)
+    default_width = _positive_float_or_none(
+        getattr(sheet_format, "defaultColWidth", None)
+    )
+    base_width = _positive_float_or_none(getattr(sheet_format, "baseColWidth", None))
+    default_height = _positive_float_or_none(
+        getattr(sheet_format, "defaultRowHeight", None)
+    )
+    max_col = int(getattr(ws, "max_column", 1) or 1)
+
+    column_widths: dict[str, float] = {}
+    for key, dim in ws.column_dimensions.items():
+        start = int(dim.min or column_index_from_string(str(key)))
+        end = int(dim.max or start)
+        end = min(end, max(max_col, start))
+        width = _positive_float_or_none(dim.width)
+        if dim.hidden:
+            value = 0.0
+        elif width is not None:
+            value = width
+        else:
+            continue
+        for col in range(start, end + 1):
+            column_widths[str(col - 1)] = value
+
+    row_heights: dict[str, float] = {}
+    for row_index, row_dim in ws.row_dimensions.items():
+        height = _positive_float_or_none(row_dim.ht)
+        if row_dim.hidden or row_dim.ht == 0:
+            row_heights[str(row_index)] = 0.0
+        elif height is not None:
+            row_heights[str(row_index)] = height
+
+    return SheetDimensions(
+        column_widths=column_widths,
+        row_heights=row_heights,
+        default_column_width=default_width
+        or default_column_width_from_base(
+            base_width or DEFAULT_BASE_COLUMN_WIDTH, digit_width
+        ),
+        default_row_height=default_height or _DEFAULT_ROW_HEIGHT_POINTS,
+        max_digit_width=digit_width,
+    )
+
+
+def _workbook_max_digit_width(ws: Worksheet) -> int:
+    """Return the maximum digit width of the workbook default (Normal) font."""
+    fonts = getattr(ws.parent, "_fonts", None)
+    if not fonts:
+        return DEFAULT_MAX_DIGIT_WIDTH
+    font = fonts[0]
+    size = _positive_float_or_none(getattr(font, "sz", None))
+    return max_digit_width(getattr(font, "name", None), size)
+
+
+def extract_sheet_cell_errors(file_path: Path) -> dict[str, list[CellError]]:
+    """Extract cells holding cached error values per sheet via openpyxl.
+
+    Error positions come from the cached values; a second pass over the
+    formula view attaches the formula for cells whose error is computed.
+
+    Args:
+        file_path: Excel workbook path.
+
+    Returns:
+        Mapping of sheet name to error cells in row-major order.
+    """
+    found: dict[str, dict[tuple[int, int], str]] = {}
+    with openpyxl_workbook(file_path, data_only=True, read_only=True) as wb:
+        for ws in wb.worksheets:
+            errors: dict[tuple[int, int], str] = {}
+            for row in ws.iter_rows():
+                for cell in row:
+                    if getattr(cell, "data_type", None) != "e":
+                        continue
+                    errors[(cell.row, cell.column)] = str(cell.value)
+            found[ws.title] = errors
+    formulas: dict[str, dict[tuple[int, int], str]] = {}
+    if any(found.values()):
+        with openpyxl_workbook(file_path, data_only=False, read_only=False) as wb:
+            for ws in wb.worksheets:
+                sheet_formulas: dict[tuple[int, int], str] = {}
+                for row, col in found.get(ws.title, {}):
+                    cell = ws.cell(row=row, column=col)
+                    if cell.data_type != "f":
+                        continue
+                    formula = _normalize_formula_value(cell.value)
+                    if formula is not None:
+                        sheet_formulas[(row, col)] = formula
+                formulas[ws.title] = sheet_formulas
+    return {
+        sheet_name: [
+            CellError(
+                cell=f"{get_column_letter(col)}{row}",
+                error=error,
+                formula=formulas.get(sheet_name, {}).get((row, col)),
+            )
+            for (row, col), error in sorted(errors.items())
+        ]
+        for sheet_name, errors in found.items()
+    }
+
+
+def extract_cell_comments(file_path: Path) -> dict[str, dict[str, str]]:
+    """Extract cell comment (note) texts per sheet via openpyxl.
+
+    Args:
+        file_path: Excel workbook path (.xlsx/.xlsm).
+
+    Returns:
+        Mapping of sheet name to ``{cell address: comment text}``, for sheets
+        that have comments.
+    """
+    comments: dict[str, dict[str, str]] = {}
+    with openpyxl_workbook(file_path, data_only=True, read_only=False) as wb:
+        for ws in wb.worksheets:
+            sheet_comments = {
+                cell.coordinate: cell.comment.text
+                for row in ws.iter_rows()
+                for cell in row
+                if cell.comment is not None and cell.comment.text
+            }
+            if sheet_comments:
+                comments[ws.title] = sheet_comments
+    return comments
+
+
+def extract_number_formats(
+    file_path: Path, ranges: Mapping[str, Sequence[str]]
+) -> dict[str, dict[tuple[int, int], str]]:
+    """Read the number format codes of cells inside the given ranges.
+
+    Args:
+        file_path: Excel workbook path (.xlsx/.xlsm).
+        ranges: Sheet name to A1 ranges to read (e.g., table candidates).
+
+    Returns:
+        Mapping of sheet name to ``{(row, col): format code}`` with 1-based rows
+        and 0-based columns. ``General`` cells, invalid ranges, and non-OOXML
+        workbooks (.xls) are left out.
+    """
+    if not zipfile.is_zipfile(open_source(file_path)):
+        return {}
+    formats: dict[str, dict[tuple[int, int], str]] = {}
+    with openpyxl_workbook(file_path, data_only=True, read_only=False) as wb:
+        for sheet_name, refs in ranges.items():
+            if not refs or sheet_name not in wb.sheetnames:
+                continue
+            sheet_formats: dict[tuple[int, int], str] = {}
+            for ref in refs:
+                sheet_formats.update(_range_number_formats(wb[sheet_name], ref))
+            if sheet_formats:
+                formats[sheet_name] = sheet_formats
+    return formats
+
+
+def extract_named_ranges(file_path: Path) -> dict[str, list[NamedRange]]:
+    """Resolve defined names that refer to ranges into bounds per sheet.
+
+    Workbook-level and sheet-scoped names are both resolved; a name listing
+    several areas yields one entry per area. Whole-column or whole-row
+    references are clipped to the sheet's used range. Reserved ``_xlnm.``
+    names (print areas, titles, filters), constants, formulas, and external
+    references are skipped.
+
+    Args:
+        file_path: Excel workbook path (.xlsx/.xlsm).
+
+    Returns:
+        Mapping of sheet name to its named ranges in definition order.
+        Non-OOXML workbooks (.xls) yield an empty mapping.
+    """
+    if not zipfile.is_zipfile(open_source(file_path)):
+        return {}
+    named: dict[str, list[NamedRange]] = {}
+    with openpyxl_workbook(file_path, data_only=True, read_only=False) as wb:
+        scoped = [(defined, False) for defined in wb.defined_names.values()]
+        for ws in wb.worksheets:
+            scoped.extend((defined, True) for defined in ws.defined_names.values())
+        for defined, local in scoped:
+            if defined.name.startswith("_xlnm."):
+                continue
+            try:
+                destinations = list(defined.destinations)
+            except Exception:  # noqa: BLE001 - formulas/constants have no range
+                continue
+            for sheet_name, ref in destinations:
+                if sheet_name not in wb.sheetnames:
+                    continue
+                bounds = _named_bounds(wb[sheet_name], ref)
+                if bounds is None:
+                    continue
+                r1, c1, r2, c2 = bounds
+                named.setdefault(sheet_name, []).append(
+                    NamedRange(
+                        name=defined.name, r1=r1, c1=c1, r2=r2, c2=c2, local=local
+                    )
+                )
+    return named
+
+
+def _named_bounds(ws: Worksheet, ref: str) -> tuple[int, int, int, int] | None:
+    """Return (r1, c1, r2, c2) with 1-based rows and 0-based columns."""
+    try:
+        min_col, min_row, max_col, max_row = range_boundaries(ref.replace("$", ""))
+    except (TypeError, ValueError):
+        return None
+    return (
+        min_row or 1,
+        (min_col or 1) - 1,
+        max_row or ws.max_row,
+        (max_col or ws.max_column) - 1,
+    )
+
+
+def extract_table_style_signals(
+    file_path: Path, ranges: Mapping[str, Sequence[str]]
+) -> dict[str, dict[str, TableStyleSignals]]:
+    """Measure borders and header styling of table candidates via openpyxl.
+
+    ``border_ratio`` is the share of cells with any border side.
+    ``header_emphasis`` is the share of non-empty first-row cells that are bold
+    or filled, minus the same share for the rows below, so a sheet styled
+    uniformly scores 0. Excel tables (ListObjects) score 1.0 on both.
+
+    Args:
+        file_path: Excel workbook path (.xlsx/.xlsm).
+        ranges: Sheet name to A1 table candidate ranges.
+
+    Returns:
+        Mapping of sheet name to ``{range: TableStyleSignals}``. Invalid ranges
+        and non-OOXML workbooks (.xls) are left out.
+    """
+    if not zipfile.is_zipfile(open_source(file_path)):
+        return {}
+    signals: dict[str, dict[str, TableStyleSignals]] = {}
+    with openpyxl_workbook(file_path, data_only=True, read_only=False) as wb:
+        for sheet_name, refs in ranges.items():
+            if not refs or sheet_name not in wb.sheetnames:
+                continue
+            ws = wb[sheet_name]
+            list_objects = set(_extract_openpyxl_table_refs(ws))
+            sheet_signals: dict[str, TableStyleSignals] = {}
+            for ref in refs:
+                if ref in list_objects:
+                    sheet_signals[ref] = TableStyleSignals(1.0, 1.0)
+                elif (found := _range_style_signals(ws, ref)) is not None:
+                    sheet_signals[ref] = found
+            signals[sheet_name] = sheet_signals
+    return signals
+
+
+def _range_style_signals(ws: Worksheet, ref: str) -> TableStyleSignals | None:
+    """Compute border and header-emphasis ratios of one range."""
+    try:
+        min_col, min_row, max_col, max_row = range_boundaries(ref)
+    except ValueError:
+        return None
+    rows = list(
+        ws.iter_rows(min_row=min_row, max_row=max_row, min_col=min_col, max_col=max_col)
+    )
+    cells = [cell for row in rows for cell in row]
+    if not cells:
+        return None
+    bordered = sum(1 for cell in cells if _has_border(cell))
+    header = _emphasis_ratio(rows[0])
+    body = _emphasis_ratio([cell for row in rows[1:] for cell in row])
+    return TableStyleSignals(
+        border_ratio=bordered / len(cells),
+        header_emphasis=max(0.0, header - body),
+    )
+
+
+def _has_border(cell: object) -> bool:
+    border = getattr(cell, "border", None)
+    return any(
+        getattr(getattr(border, side, None), "style", None)
+        for side in ("left", "right", "top", "bottom")
+    )
+
+
+def _emphasis_ratio(cells: Sequence[object]) -> float:
+    """Share of non-empty cells that are bold or have a solid fill."""
+    filled = [cell for cell in cells if getattr(cell, "value", None) not in (None, "")]
+    if not filled:
+        return 0.0
+    emphasized = sum(
+        1
+        for cell in filled
+        if getattr(getattr(cell, "font", None), "bold", False)
+        or getattr(getattr(cell, "fill", None), "fill_type", None) == "solid"
+    )
+    return emphasized / len(filled)
+
+
+def _range_number_formats(ws: Worksheet, ref: str) -> dict[tuple[int, int], str]:
+    """Collect non-General number formats of one range (empty when invalid)."""
+    try:
+        min_col, min_row, max_col, max_row = range_boundaries(ref)
+    except ValueError:
+        return {}
+    return {
+        (cell.row, cell.column - 1): cell.number_format
+        for row in ws.iter_rows(
+            min_row=min_row, max_row=max_row, min_col=min_col, max_col=max_col
+        )
+        for cell in row
+        if cell.number_format and cell.number_format != "General"
+    }
+
+
+def extract_sheet_outlines(file_path: Path) -> dict[str, SheetOutline]:
+    """Extract row and column outline (grouping) levels per sheet via openpyxl.
+
+    A group is a run of consecutive rows (or columns) whose outline level is
+    at least its level, so nested groups are reported once per level. A group
+    is collapsed when its summary row/column carries the ``collapsed`` flag, or
+    when all of its members are hidden while the summary stays visible.
+
+    Args:
+        file_path: Excel workbook path.
+
+    Returns:
+        Mapping of sheet name to outline, for sheets that have groups.
+    """
+    outlines: dict[str, SheetOutline] = {}
+    with openpyxl_workbook(file_path, data_only=True, read_only=False) as wb:
+        for ws in wb.worksheets:
+            outline = _extract_worksheet_outline(ws)
+            if outline.rows or outline.columns:
+                outlines[ws.title] = outline
+    return outlines
+
+
+def _extract_worksheet_outline(ws: Worksheet) -> SheetOutline:
+    """Collect outline groups from row and column dimensions."""
+    outline_pr = getattr(ws.sheet_properties, "outlinePr", None)
+    summary_below = getattr(outline_pr, "summaryBelow", None) is not False
+    summary_right = getattr(outline_pr, "summaryRight", None) is not False
+
+    row_dims = _OutlineDims()
+    for row_index, row_dim in ws.row_dimensions.items():
+        row_dims.add(row_index, row_dim)
+    col_dims = _OutlineDims()
+    for key, dim in ws.column_dimensions.items():
+        start = int(dim.min or column_index_from_string(str(key)))
+        for col in range(start, int(dim.max or start) + 1):
+            col_dims.add(col, dim)
+
+    return SheetOutline(
+        rows=row_dims.groups(summary_after=summary_below),
+        columns=col_dims.groups(summary_after=summary_right, offset=-1),
+        summary_below=summary_below,
+        summary_right=summary_right,
+    )
+
+
+class _OutlineDims:
+    """Outline level, hidden, and collapsed flags of rows or columns."""
+
+    def __init__(self) -> None:
+        self.levels: dict[int, int] = {}
+        self.hidden: set[int] = set()
+        self.collapsed: set[int] = set()
+
+    def add(self, index: int, dim: object) -> None:
+        level = int(getattr(dim, "outlineLevel", 0) or 0)
+        if level > 0:
+            self.levels[index] = min(level, 7)
+        if getattr(dim, "hidden", False):
+            self.hidden.add(index)
+        if getattr(dim, "collapsed", False):
+            self.collapsed.add(index)
+
+    def groups(self, *, summary_after: bool, offset: int = 0) -> list[OutlineGroup]:
+        """Return groups per level; ``offset`` shifts the 1-based indexes."""
+        groups: list[OutlineGroup] = []
+        for level in range(1, max(self.levels.values(), default=0) + 1):
+            members = sorted(i for i, lv in self.levels.items() if lv >= level)
+            for start, end in _consecutive_runs(members):
+                summary = end + 1 if summary_after else start - 1
+                collapsed = summary in self.collapsed or (
+                    summary not in self.hidden
+                    and all(i in self.hidden for i in range(start, end + 1))
+                )
+                groups.append(
+                    OutlineGroup(
+                        start=start + offset,
+                        end=end + offset,
+                        level=level,
+                        collapsed=collapsed,
+                        summary=summary + offset if summary >= 1 else None,
+                    )
+                )
+        return sorted(groups, key=lambda group: (group.start, group.level))
+
+
+def _consecutive_runs(indexes: list[int]) -> list[tuple[int, int]]:
+    """Split sorted indexes into (start, end) runs of consecutive values."""
+    runs: list[tuple[int, int]] = []
+    for index in indexes:
+        if runs and runs[-1][1] == index - 1:
+            runs[-1] = (runs[-1][0], index)
+        else:
+            runs.append((index, index))
+    return runs
+
+
+def _positive_float_or_none(value: object) -> float | None:
+    """Return a positive float for numeric input, otherwise None."""
+    if isinstance(value, bool) or not isinstance(value, int | float):
+        return None
+    number = float(value)
+    if number <= 0 or math.isnan(number):
+        return None
+    return number
+
+
 def shrink_to_content(  # noqa: C901
     sheet: xw.Sheet,
     top: int,
@@ -1275,9 +1793,13 @@ def _is_plausible_table(matrix: MatrixInput) -> bool:
 
 
 def _nonempty_clusters(
-    matrix: Sequence[Sequence[object]],
+    matrix: Sequence[Sequence[object]], gap_tolerance: int = 0
 ) -> list[tuple[int, int, int, int]]:
-    """Return bounding boxes of connected components of nonempty cells (4-neighbor)."""
+    """Return bounding boxes of connected components of nonempty cells (4-neighbor).
+
+    Cells up to ``gap_tolerance`` empty rows or columns apart in a straight line
+    count as neighbors.
+    """
     if not matrix:
         return []
     rows = len(matrix)
@@ -1290,6 +1812,11 @@ def _nonempty_clusters(
                 grid[i][j] = True
     visited = [[False] * cols for _ in range(rows)]
     boxes: list[tuple[int, int, int, int]] = []
+    steps = [
+        step
+        for d in range(1, gap_tolerance + 2)
+        for step in ((d, 0), (-d, 0), (0, d), (0, -d))
+    ]
 
     def bfs(sr: int, sc: int) -> tuple[int, int, int, int]:
         """Return bounding box of a connected component starting at (sr, sc)."""
@@ -1299,7 +1826,7 @@ def _nonempty_clusters(
         xs = [sc]
         while q:
             r, c = q.popleft()
-            for dr, dc in ((1, 0), (-1, 0), (0, 1), (0, -1)):
+            for dr, dc in steps:
                 nr, nc = r + dr, c + dc
                 if (
                     0 <= nr < rows
@@ -1387,10 +1914,13 @@ def set_table_detection_params(
     density_min: float | None = None,
     coverage_min: float | None = None,
     min_nonempty_cells: int | None = None,
+    gap_tolerance: int | None = None,
 ) -> None:
     """
     Configure table detection heuristics at runtime.
     Any parameter left as None keeps its current value.
+    gap_tolerance is the number of empty rows/columns bridged when grouping
+    cells into one table candidate (0 = only adjacent cells).
     """
     if table_score_threshold is not None:
         _DETECTION_CONFIG["table_score_threshold"] = table_score_threshold
@@ -1400,6 +1930,10 @@ def set_table_detection_params(
         _DETECTION_CONFIG["coverage_min"] = coverage_min
     if min_nonempty_cells is not None:
         _DETECTION_CONFIG["min_nonempty_cells"] = min_nonempty_cells
+    if gap_tolerance is not None:
+        if gap_tolerance < 0:
+            raise ValueError("gap_tolerance must be >= 0.")
+        _DETECTION_CONFIG["gap_tolerance"] = gap_tolerance
 
 
 def shrink_to_content_openpyxl(  # noqa: C901
@@ -1602,7 +2136,7 @@ def _extract_listobject_tables(sheet: xw.Sheet) -> list[str]:
             addr = rng.Address(RowAbsolute=False, ColumnAbsolute=False)
             tables.append(addr)
     except Exception:
-        pass
+        logger.debug("Failed to read ListObjects via COM.", exc_info=True)
     return tables
 
 
@@ -1717,6 +2251,33 @@ def _rectangles_overlap_for_merge(
     return not (a[1] > b[3] or a[3] < b[1] or a[0] > b[2] or a[2] < b[0])
 
 
+def _bordered_grid_candidate(
+    has_border: np.ndarray,
+    values: list[list[object]],
+    top_row: int,
+    left_col: int,
+    bottom_row: int,
+    right_col: int,
+) -> str | None:
+    """Return the range of a fully bordered table, or None to use value clusters.
+
+    Business forms draw borders around every cell, including blank entry rows
+    and columns that would split a table into value clusters. When nearly all
+    cells of the rectangle are bordered, the borders mark the table boundary.
+    """
+    region = has_border[top_row : bottom_row + 1, left_col : right_col + 1]
+    if region.size == 0 or float(region.mean()) < _BORDERED_GRID_MIN_RATIO:
+        return None
+    if _count_nonempty_cells(values) < _DETECTION_CONFIG["min_nonempty_cells"]:
+        return None
+    if not _is_plausible_table(values):
+        return None
+    return (
+        f"{get_column_letter(left_col)}{top_row}:"
+        f"{get_column_letter(right_col)}{bottom_row}"
+    )
+
+
 def _collect_table_candidates_from_values(
     values: Sequence[Sequence[object]],
     *,
@@ -1741,7 +2302,14 @@ def _collect_table_candidates_from_values(
         return []
 
     results: list[str] = []
-    clusters = _nonempty_clusters(normalized)
+    gap_tolerance = int(_DETECTION_CONFIG.get("gap_tolerance", 0))
+    clusters = [
+        (r0 + sr0, c0 + sc0, r0 + sr1, c0 + sc1)
+        for r0, c0, r1, c1 in _nonempty_clusters(normalized, gap_tolerance)
+        for sr0, sc0, sr1, sc1 in _split_on_blank_lines(
+            [row[c0 : c1 + 1] for row in normalized[r0 : r1 + 1]], gap_tolerance
+        )
+    ]
     for r0, c0, r1, c1 in clusters:
         sub = [row[c0 : c1 + 1] for row in normalized[r0 : r1 + 1]]
         density, coverage = _table_density_metrics(sub)
@@ -1763,6 +2331,102 @@ def _collect_table_candidates_from_values(
     return results
 
 
+def _split_on_blank_lines(
+    matrix: Sequence[Sequence[object]], gap_tolerance: int = 0
+) -> list[tuple[int, int, int, int]]:
+    """Separate side-by-side or stacked tables inside one value cluster.
+
+    Tables sharing rows are often joined only by a caption or merged title
+    spanning both, which connects their cells. Ignoring caption lines (rows or
+    columns holding a single distinct value), the block is cut recursively at
+    runs of blank columns, then blank rows, longer than ``gap_tolerance``.
+
+    Args:
+        matrix: Values of one cluster.
+        gap_tolerance: Blank lines that do not separate tables.
+
+    Returns:
+        (top, left, bottom, right) boxes relative to the matrix, trimmed to
+        their non-empty cells; the whole matrix when nothing separates.
+    """
+    rows = [list(row) for row in matrix]
+    width = max((len(row) for row in rows), default=0)
+    grid = [
+        [j < len(row) and not _is_empty_value(row[j]) for j in range(width)]
+        for row in rows
+    ]
+    content_rows = [i for i, row in enumerate(rows) if _distinct_count(row) > 1]
+    used_cols = [any(grid[i][j] for i in content_rows) for j in range(width)]
+    col_runs = _used_runs(used_cols, gap_tolerance)
+    if len(col_runs) > 1:
+        return [
+            box
+            for c0, c1 in col_runs
+            for box in _split_block(rows, grid, 0, c0, len(rows) - 1, c1, gap_tolerance)
+        ]
+    columns = [[row[j] if j < len(row) else None for row in rows] for j in range(width)]
+    content_cols = [j for j, col in enumerate(columns) if _distinct_count(col) > 1]
+    used_rows = [any(row[j] for j in content_cols) for row in grid]
+    row_runs = _used_runs(used_rows, gap_tolerance)
+    if len(row_runs) > 1:
+        return [
+            box
+            for r0, r1 in row_runs
+            for box in _split_block(rows, grid, r0, 0, r1, width - 1, gap_tolerance)
+        ]
+    return [(0, 0, len(rows) - 1, width - 1)] if rows and width else []
+
+
+def _split_block(
+    rows: list[list[object]],
+    grid: list[list[bool]],
+    top: int,
+    left: int,
+    bottom: int,
+    right: int,
+    gap_tolerance: int,
+) -> list[tuple[int, int, int, int]]:
+    """Trim a block to its non-empty cells and split it further."""
+    cells = [
+        (i, j)
+        for i in range(top, bottom + 1)
+        for j in range(left, right + 1)
+        if grid[i][j]
+    ]
+    if not cells:
+        return []
+    t = min(i for i, _ in cells)
+    b = max(i for i, _ in cells)
+    lft = min(j for _, j in cells)
+    rgt = max(j for _, j in cells)
+    block = [row[lft : rgt + 1] for row in rows[t : b + 1]]
+    return [
+        (t + r0, lft + c0, t + r1, lft + c1)
+        for r0, c0, r1, c1 in _split_on_blank_lines(block, gap_tolerance)
+    ]
+
+
+def _used_runs(used: list[bool], gap_tolerance: int) -> list[tuple[int, int]]:
+    """Group used indexes into runs split by more than gap_tolerance unused ones."""
+    runs: list[tuple[int, int]] = []
+    for index, flag in enumerate(used):
+        if not flag:
+            continue
+        if runs and index - runs[-1][1] - 1 <= gap_tolerance:
+            runs[-1] = (runs[-1][0], index)
+        else:
+            runs.append((index, index))
+    return runs
+
+
+def _distinct_count(values: Sequence[object]) -> int:
+    return len({str(value) for value in values if not _is_empty_value(value)})
+
+
+def _is_empty_value(value: object) -> bool:
+    return value is None or str(value).strip() == ""
+
+
 def _count_nonempty_cells(values: Sequence[Sequence[object]]) -> int:
     """Count non-empty cells in a normalized matrix.
 
@@ -1801,7 +2465,7 @@ def _extract_openpyxl_table_refs(ws: Worksheet) -> list[str]:
             if addr:
                 tables.append(str(addr))
     except Exception:
-        pass
+        logger.debug("Failed to read openpyxl table definitions.", exc_info=True)
     return tables
 
 
@@ -1880,12 +2544,21 @@ def detect_tables_openpyxl(
                 right_edge=right_edge,
                 min_nonempty_ratio=0.0,
             )
-            vals_block = _get_values_block(ws, top_row, left_col, bottom_row, right_col)
-            candidates = _collect_table_candidates_from_values(
-                _normalize_matrix(vals_block),
-                base_top=top_row,
-                base_left=left_col,
-                col_name=get_column_letter,
+            vals_block = _normalize_matrix(
+                _get_values_block(ws, top_row, left_col, bottom_row, right_col)
+            )
+            grid = _bordered_grid_candidate(
+                has_border, vals_block, top_row, left_col, bottom_row, right_col
+            )
+            candidates = (
+                [grid]
+                if grid is not None
+                else _collect_table_candidates_from_values(
+                    vals_block,
+                    base_top=top_row,
+                    base_left=left_col,
+                    col_name=get_column_letter,
+                )
             )
             for addr in candidates:
                 if addr not in dedup:
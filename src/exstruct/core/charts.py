@@ -3,15 +3,108 @@
 from __future__ import annotations
 
 import logging
-from typing import Literal
+from typing import Any, Literal
 
 import xlwings as xw
 
-from ..models import Chart, ChartSeries
+from ..models import (
+    Chart,
+    ChartAxis,
+    ChartDataLabels,
+    ChartErrorBars,
+    ChartLegend,
+    ChartMarker,
+    ChartSeries,
+    ChartTextBox,
+    ChartTrendline,
+)
 from ..models.maps import XL_CHART_TYPE_MAP
 
 logger = logging.getLogger(__name__)
 
+# XlLegendPosition -> legend position
+_LEGEND_POSITION_MAP: dict[
+    int, Literal["right", "left", "top", "bottom", "corner", "custom"]
+] = {
+    -4152: "right",
+    -4131: "left",
+    -4160: "top",
+    -4107: "bottom",
+    2: "corner",
+    -4161: "custom",
+}
+
+# XlDisplayBlanksAs: xlNotPlotted, xlZero, xlInterpolated
+_DISPLAY_BLANKS_AS_MAP: dict[int, Literal["gap", "zero", "span"]] = {
+    1: "gap",
+    2: "zero",
+    3: "span",
+}
+
+# XlMarkerStyle -> marker symbol
+_MARKER_STYLE_MAP: dict[
+    int,
+    Literal[
+        "auto",
+        "none",
+        "circle",
+        "dash",
+        "diamond",
+        "dot",
+        "picture",
+        "plus",
+        "square",
+        "star",
+        "triangle",
+        "x",
+    ],
+] = {
+    -4105: "auto",
+    -4142: "none",
+    8: "circle",
+    -4115: "dash",
+    2: "diamond",
+    -4118: "dot",
+    -4147: "picture",
+    9: "plus",
+    1: "square",
+    5: "star",
+    3: "triangle",
+    -4168: "x",
+}
+
+# XlChartType scatter variants -> scatter style
+_SCATTER_STYLE_MAP: dict[
+    int,
+    Literal["line", "line_marker", "marker", "none", "smooth", "smooth_marker"],
+] = {
+    -4169: "marker",
+    74: "line_marker",
+    75: "line",
+    72: "smooth_marker",
+    73: "smooth",
+}
+
+_XL_VALUE = 2
+_XL_PRIMARY = 1
+_XL_SECONDARY = 2
+_XL_SCALE_LOGARITHMIC = -4133
+
+# XlTrendlineType -> trendline type
+_TRENDLINE_TYPE_MAP: dict[
+    int,
+    Literal[
+        "linear", "exponential", "logarithmic", "polynomial", "power", "moving_average"
+    ],
+] = {
+    -4132: "linear",
+    5: "exponential",
+    -4133: "logarithmic",
+    3: "polynomial",
+    4: "power",
+    6: "moving_average",
+}
+
 
 def _extract_series_args_text(formula: str) -> str | None:  # noqa: C901
     """Extract the outer argument text from '=SERIES(...)'; return None if unmatched."""
@@ -170,6 +263,249 @@ def parse_series_formula(formula: str) -> dict[str, str | None] | None:
     }
 
 
+def _grouping_from_label(
+    chart_type_label: str,
+) -> tuple[
+    Literal["clustered", "stacked", "percent_stacked", "standard"] | None,
+    Literal["vertical", "horizontal"] | None,
+]:
+    """Derive grouping and bar direction from an XL_CHART_TYPE_MAP label."""
+    family = chart_type_label.removeprefix("3D")
+    for prefix in ("Cone", "Cylinder", "Pyramid"):
+        family = family.removeprefix(prefix)
+    bar_direction: Literal["vertical", "horizontal"] | None = None
+    if family.startswith("Bar") and not family.startswith("BarOfPie"):
+        bar_direction = "horizontal"
+    elif family.startswith("Col"):
+        bar_direction = "vertical"
+    elif not family.startswith(("Line", "Area")):
+        return (None, None)
+    if "Stacked100" in family:
+        return ("percent_stacked", bar_direction)
+    if "Stacked" in family:
+        return ("stacked", bar_direction)
+    if "Clustered" in family:
+        return ("clustered", bar_direction)
+    return ("standard", bar_direction)
+
+
+def _read_value_axis(axis: Any, *, secondary: bool) -> ChartAxis:
+    """Read title, scale, and tick-label format from a COM value axis."""
+    result = ChartAxis(secondary=secondary)
+    try:
+        if axis.HasTitle:
+            result.title = str(axis.AxisTitle.Text)
+    except Exception:
+        pass
+    try:
+        result.range = [float(axis.MinimumScale), float(axis.MaximumScale)]
+    except Exception:
+        pass
+    try:
+        number_format = axis.TickLabels.NumberFormat
+        if isinstance(number_format, str) and number_format:
+            result.number_format = number_format
+    except Exception:
+        pass
+    try:
+        if int(axis.ScaleType) == _XL_SCALE_LOGARITHMIC:
+            result.log_base = float(axis.LogBase)
+    except Exception:
+        pass
+    return result
+
+
+def _get_value_axes(chart_com: Any) -> list[ChartAxis]:
+    """Read the primary and (when present) secondary value axes of a COM chart."""
+    axes: list[ChartAxis] = []
+    try:
+        axes.append(
+            _read_value_axis(chart_com.Axes(_XL_VALUE, _XL_PRIMARY), secondary=False)
+        )
+    except Exception:
+        return axes
+    try:
+        if chart_com.HasAxis(_XL_VALUE, _XL_SECONDARY):
+            axes.append(
+                _read_value_axis(
+                    chart_com.Axes(_XL_VALUE, _XL_SECONDARY), secondary=True
+                )
+            )
+    except Exception:
+        pass
+    return axes
+
+
+def _get_legend(chart_com: Any) -> ChartLegend | None:
+    """Read legend visibility and position from a COM chart."""
+    try:
+        if not chart_com.HasLegend:
+            return ChartLegend(visible=False)
+        position = int(chart_com.Legend.Position)
+    except Exception:
+        return None
+    return ChartLegend(visible=True, position=_LEGEND_POSITION_MAP.get(position))
+
+
+def _get_blank_cell_settings(
+    chart_com: Any,
+) -> tuple[bool | None, Literal["gap", "zero", "span"] | None]:
+    """Read PlotVisibleOnly and DisplayBlanksAs from a COM chart."""
+    try:
+        plot_visible_only: bool | None = bool(chart_com.PlotVisibleOnly)
+    except Exception:
+        plot_visible_only = None
+    try:
+        display_blanks_as = _DISPLAY_BLANKS_AS_MAP.get(int(chart_com.DisplayBlanksAs))
+    except Exception:
+        display_blanks_as = None
+    return (plot_visible_only, display_blanks_as)
+
+
+def _get_text_boxes(chart_com: Any) -> list[ChartTextBox]:
+    """Read text-bearing shapes placed inside a COM chart."""
+    boxes: list[ChartTextBox] = []
+    try:
+        shapes = chart_com.Shapes
+        count = int(shapes.Count)
+        area_width = float(chart_com.ChartArea.Width)
+        area_height = float(chart_com.ChartArea.Height)
+    except Exception:
+        return boxes
+    for index in range(1, count + 1):
+        try:
+            shape = shapes.Item(index)
+            text = str(shape.TextFrame2.TextRange.Text or "").strip()
+        except Exception:
+            continue
+        if not text:
+            continue
+        try:
+            x = float(shape.Left) / area_width if area_width else None
+            y = float(shape.Top) / area_height if area_height else None
+        except Exception:
+            x = y = None
+        boxes.append(ChartTextBox(text=text, x=x, y=y))
+    return boxes
+
+
+def _get_data_labels(series_com: Any) -> ChartDataLabels | None:
+    """Read data label settings from a COM series; None when labels are hidden."""
+    try:
+        if not series_com.HasDataLabels:
+            return None
+        labels = series_com.DataLabels()
+        result = ChartDataLabels(
+            show_value=bool(labels.ShowValue),
+            show_percentage=bool(labels.ShowPercentage),
+            show_category_name=bool(labels.ShowCategoryName),
+            show_series_name=bool(labels.ShowSeriesName),
+        )
+    except Exception:
+        return None
+    try:
+        number_format = labels.NumberFormat
+        if isinstance(number_format, str) and number_format:
+            result.number_format = number_format
+    except Exception:
+        pass
+    return result
+
+
+def _is_pie_chart(chart_type_label: str) -> bool:
+    """Return True for pie, pie-of-pie, and doughnut chart type labels."""
+    return "Pie" in chart_type_label or "Doughnut" in chart_type_label
+
+
+def _has_markers(chart_type_label: str) -> bool:
+    """Return True for line, scatter, and radar chart type labels."""
+    return any(kind in chart_type_label for kind in ("Line", "XYScatter", "Radar"))
+
+
+def _get_series_cache(
+    series_com: Any,
+) -> tuple[list[str | None] | None, list[float | None] | None]:
+    """Read the displayed categories and values of a COM series."""
+    try:
+        raw_categories = series_com.XValues
+        raw_values = series_com.Values
+    except Exception:
+        return (None, None)
+    categories = [
+        None if value is None else str(value) for value in raw_categories or ()
+    ]
+    values: list[float | None] = []
+    for value in raw_values or ():
+        try:
+            values.append(None if value is None else float(value))
+        except (TypeError, ValueError):
+            values.append(None)
+    return (categories, values)
+
+
+def _get_trendlines(series_com: Any) -> list[ChartTrendline]:
+    """Read trendline settings from a COM series."""
+    trendlines: list[ChartTrendline] = []
+    try:
+        collection = series_com.Trendlines()
+        count = int(collection.Count)
+    except Exception:
+        return trendlines
+    for index in range(1, count + 1):
+        try:
+            trendline = collection.Item(index)
+            trend_type = _TRENDLINE_TYPE_MAP.get(int(trendline.Type))
+            if trend_type is None:
+                continue
+            trendlines.append(
+                ChartTrendline(
+                    type=trend_type,
+                    name=str(trendline.Name) if trendline.Name else None,
+                    order=int(trendline.Order)
+                    if trend_type == "polynomial"
+                    else None,
+                    period=int(trendline.Period)
+                    if trend_type == "moving_average"
+                    else None,
+                    display_equation=bool(trendline.DisplayEquation),
+                    display_r_squared=bool(trendline.DisplayRSquared),
+                )
+            )
+        except Exception:
+            logger.debug("Failed to read trendline %d.", index, exc_info=True)
+            continue
+    return trendlines
+
+
+def _get_series_style(series_com: Any) -> tuple[ChartMarker | None, bool | None]:
+    """Read marker and line smoothing settings from a COM series."""
+    try:
+        marker: ChartMarker | None = ChartMarker(
+            symbol=_MARKER_STYLE_MAP.get(int(series_com.MarkerStyle)),
+            size=int(series_com.MarkerSize),
+        )
+    except Exception:
+        marker = None
+    try:
+        smooth: bool | None = bool(series_com.Smooth)
+    except Exception:
+        smooth = None
+    return (marker, smooth)
+
+
+def _get_error_bars(series_com: Any) -> list[ChartErrorBars]:
+    """Report error bars on a COM series.
+
+    COM does not expose the error amount settings, so only presence is recorded.
+    """
+    try:
+        if series_com.HasErrorBars:
+            return [ChartErrorBars()]
+    except Exception:
+        pass
+    return []
+
+
 def get_charts(
     sheet: xw.Sheet,
     mode: Literal["light", "libreoffice", "standard", "verbose"] = "standard",
@@ -184,6 +520,15 @@ def get_charts(
         error: str | None = None
         chart_width: int | None = None
         chart_height: int | None = None
+        legend: ChartLegend | None = None
+        value_axes: list[ChartAxis] = []
+        text_boxes: list[ChartTextBox] = []
+        plot_visible_only: bool | None = None
+        display_blanks_as: Literal["gap", "zero", "span"] | None = None
+        scatter_style: (
+            Literal["line", "line_marker", "marker", "none", "smooth", "smooth_marker"]
+            | None
+        ) = None
 
         try:
             chart_com = sheet.api.ChartObjects(ch.name).Chart
@@ -191,6 +536,7 @@ def get_charts(
             chart_type_label = XL_CHART_TYPE_MAP.get(
                 chart_type_num, f"unknown_{chart_type_num}"
             )
+            scatter_style = _SCATTER_STYLE_MAP.get(chart_type_num)
             try:
                 chart_width = int(ch.width)
                 chart_height = int(ch.height)
@@ -203,6 +549,17 @@ def get_charts(
                 name_range = parsed["name_range"] if parsed else None
                 x_range = parsed["x_range"] if parsed else None
                 y_range = parsed["y_range"] if parsed else None
+                size_range = parsed["bubble_size_range"] if parsed else None
+                marker, smooth = (
+                    _get_series_style(s)
+                    if _has_markers(chart_type_label)
+                    else (None, None)
+                )
+                categories, values = (
+                    _get_series_cache(s)
+                    if _is_pie_chart(chart_type_label)
+                    else (None, None)
+                )
 
                 series_list.append(
                     ChartSeries(
@@ -210,6 +567,14 @@ def get_charts(
                         name_range=name_range,
                         x_range=x_range,
                         y_range=y_range,
+                        size_range=size_range,
+                        marker=marker,
+                        smooth=smooth,
+                        categories=categories,
+                        values=values,
+                        data_labels=_get_data_labels(s),
+                        trendlines=_get_trendlines(s),
+                        error_bars=_get_error_bars(s),
                     )
                 )
 
@@ -219,14 +584,30 @@ def get_charts(
                     y_axis_title = y_axis.AxisTitle.Text
                 y_axis_range = [y_axis.MinimumScale, y_axis.MaximumScale]
             except Exception:
+                logger.debug(
+                    "Failed to read value axis of chart %r.", ch.name, exc_info=True
+                )
                 y_axis_title = ""
                 y_axis_range = []
 
+            legend = _get_legend(chart_com)
+            value_axes = _get_value_axes(chart_com)
+            text_boxes = _get_text_boxes(chart_com)
+            plot_visible_only, display_blanks_as = _get_blank_cell_settings(
+                chart_com
+            )
             title = chart_com.ChartTitle.Text if chart_com.HasTitle else None
-        except Exception:
-            logger.warning("Failed to parse chart; returning with error string.")
+        except Exception as exc:
+            logger.warning(
+                "Failed to parse chart %r on sheet %r; returning with error string. "
+                "(%r)",
+                ch.name,
+                sheet.name,
+                exc,
+            )
             title = None
             error = "Failed to build chart JSON structure"
+        grouping, bar_direction = _grouping_from_label(chart_type_label)
 
         charts.append(
             Chart(
@@ -238,6 +619,14 @@ def get_charts(
                 w=chart_width,
                 h=chart_height,
                 series=series_list,
+                legend=legend,
+                grouping=grouping,
+                bar_direction=bar_direction,
+                value_axes=value_axes,
+                text_boxes=text_boxes,
+                plot_visible_only=plot_visible_only,
+                display_blanks_as=display_blanks_as,
+                scatter_style=scatter_style,
                 l=int(ch.left),
                 t=int(ch.top),
                 error=error,
@@ -0,0 +1,168 @@
+"""Registry of custom per-sheet extractors run after the built-in pipeline."""
+
+from __future__ import annotations
+
+from collections.abc import Sequence
+from contextlib import ExitStack
+from dataclasses import dataclass, field
+import logging
+from pathlib import Path
+from typing import Any, Literal, Protocol, runtime_checkable
+import zipfile
+
+from pydantic import JsonValue
+
+from ..errors import ExtractionError
+from ..models import SheetData, WorkbookData
+from ..sources import open_source
+from .workbook import openpyxl_workbook
+
+logger = logging.getLogger(__name__)
+
+
+@dataclass
+class ExtractorContext:
+    """Workbook handles shared by all extractors during one extraction.
+
+    The OOXML package and the openpyxl workbook are opened lazily on first
+    access and closed once every extractor has run.
+
+    Attributes:
+        file_path: Path to the workbook being extracted.
+        mode: Extraction mode of the run.
+    """
+
+    file_path: Path
+    mode: Literal["light", "libreoffice", "standard", "verbose"]
+    _stack: ExitStack = field(default_factory=ExitStack, repr=False)
+    _package: zipfile.ZipFile | None = field(default=None, repr=False)
+    _workbook: Any = field(default=None, repr=False)
+
+    @property
+    def package(self) -> zipfile.ZipFile | None:
+        """OOXML zip package, or None for non-zip (.xls) workbooks."""
+        if self._package is None and zipfile.is_zipfile(open_source(self.file_path)):
+            self._package = self._stack.enter_context(
+                zipfile.ZipFile(open_source(self.file_path))
+            )
+        return self._package
+
+    @property
+    def workbook(self) -> Any:
+        """openpyxl workbook opened with cached values (``data_only=True``)."""
+        if self._workbook is None:
+            self._workbook = self._stack.enter_context(
+                openpyxl_workbook(self.file_path, data_only=True, read_only=False)
+            )
+        return self._workbook
+
+    def close(self) -> None:
+        """Close any handles opened by the context."""
+        self._stack.close()
+        self._package = None
+        self._workbook = None
+
+
+@runtime_checkable
+class Extractor(Protocol):
+    """Custom component extractor.
+
+    ``extract`` is called once per sheet. A non-None return value is stored in
+    ``SheetData.extensions[name]`` and must be JSON-compatible; extractors may
+    also update ``sheet`` in place.
+    """
+
+    @property
+    def name(self) -> str:
+        """Unique extractor name, used as the ``extensions`` key."""
+        ...
+
+    def extract(
+        self, context: ExtractorContext, sheet_name: str, sheet: SheetData
+    ) -> JsonValue | None:
+        """Extract custom data for one sheet."""
+        ...
+
+
+_REGISTRY: dict[str, Extractor] = {}
+
+
+def register_extractor(extractor: Extractor, *, replace: bool = False) -> None:
+    """Register an extractor to run on every subsequent extraction.
+
+    Args:
+        extractor: Extractor instance.
+        replace: Replace an existing extractor with the same name.
+
+    Raises:
+        ValueError: If the name is empty or already registered without replace.
+    """
+    name = extractor.name
+    if not name:
+        raise ValueError("Extractor name must not be empty.")
+    if name in _REGISTRY and not replace:
+        raise ValueError(f"Extractor '{name}' is already registered.")
+    _REGISTRY[name] = extractor
+
+
+def unregister_extractor(name: str) -> None:
+    """Remove a registered extractor; unknown names are ignored."""
+    _REGISTRY.pop(name, None)
+
+
+def registered_extractors() -> tuple[Extractor, ...]:
+    """Return registered extractors in registration order."""
+    return tuple(_REGISTRY.values())
+
+
+def run_extractors(
+    workbook: WorkbookData,
+    file_path: Path,
+    *,
+    mode: Literal["light", "libreoffice", "standard", "verbose"],
+    extractors: Sequence[Extractor] | None = None,
+) -> WorkbookData:
+    """Run extractors over every sheet and store their results.
+
+    Args:
+        workbook: Workbook produced by the extraction pipeline (updated in place).
+        file_path: Path to the source workbook.
+        mode: Extraction mode of the run.
+        extractors: Extractors to run; defaults to the registered ones.
+
+    Returns:
+        The same workbook with ``SheetData.extensions`` populated.
+
+    Raises:
+        ExtractionError: If an extractor raises.
+    """
+    selected = registered_extractors() if extractors is None else tuple(extractors)
+    if not selected:
+        return workbook
+    context = ExtractorContext(file_path=file_path, mode=mode)
+    try:
+        for sheet_name, sheet in workbook.sheets.items():
+            for extractor in selected:
+                try:
+                    result = extractor.extract(context, sheet_name, sheet)
+                except Exception as exc:
+                    raise ExtractionError(
+                        f"Extractor '{extractor.name}' failed on sheet "
+                        f"'{sheet_name}': {exc}"
+                    ) from exc
+                if result is not None:
+                    sheet.extensions[extractor.name] = result
+    finally:
+        context.close()
+    logger.info("Ran %d custom extractor(s).", len(selected))
+    return workbook
+
+
+__all__ = [
+    "Extractor",
+    "ExtractorContext",
+    "register_extractor",
+    "registered_extractors",
+    "run_extractors",
+    "unregister_extractor",
+]
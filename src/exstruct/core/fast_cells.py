@@ -0,0 +1,280 @@
+"""Streaming cell reader for large OOXML worksheets (``fast_cells``).
+
+The default reader goes through pandas/openpyxl, which materializes every
+cell object before CellRows are built. This module streams ``sharedStrings``
+and each ``sheetN.xml`` part directly, keeping only the shared string table
+and one row in memory, and applies the same value conversions so the output
+matches ``extract_sheet_cells``.
+"""
+
+from __future__ import annotations
+
+from datetime import datetime
+from pathlib import Path
+import re
+from xml.etree import ElementTree as ET
+import zipfile
+
+from openpyxl.styles.numbers import (
+    BUILTIN_FORMATS,
+    is_date_format,
+    is_timedelta_format,
+)
+from openpyxl.utils.datetime import (
+    CALENDAR_MAC_1904,
+    CALENDAR_WINDOWS_1900,
+    from_excel,
+    from_ISO8601,
+)
+
+from ..models import CellRow
+from ..ooxml.chart import _read_sheet_files, _read_sheets_info
+from ..ooxml.safety import iterparse_part, open_package, parse_part
+from ..sources import open_source
+from .cells import _coerce_numeric_preserve_format, extract_sheet_cells
+
+_MAIN_NS = "http://schemas.openxmlformats.org/spreadsheetml/2006/main"
+_ROW = f"{{{_MAIN_NS}}}row"
+_CELL = f"{{{_MAIN_NS}}}c"
+_VALUE = f"{{{_MAIN_NS}}}v"
+_INLINE = f"{{{_MAIN_NS}}}is"
+_TEXT = f"{{{_MAIN_NS}}}t"
+_RUN = f"{{{_MAIN_NS}}}r"
+_SI = f"{{{_MAIN_NS}}}si"
+_NS = {"main": _MAIN_NS}
+_COLUMN_RE = re.compile(r"[A-Z]+")
+
+# pandas.read_excel's default na_values: the default reader drops cells whose
+# text is one of these, so the streaming reader does the same.
+_PANDAS_NA_STRINGS = frozenset(
+    {
+        "",
+        "#N/A",
+        "#N/A N/A",
+        "#NA",
+        "-1.#IND",
+        "-1.#QNAN",
+        "-NaN",
+        "-nan",
+        "1.#IND",
+        "1.#QNAN",
+        "<NA>",
+        "N/A",
+        "NA",
+        "NULL",
+        "NaN",
+        "None",
+        "n/a",
+        "nan",
+        "null",
+    }
+)
+
+
+class _NumberFormats:
+    """Style indexes whose number format turns numbers into dates/durations."""
+
+    def __init__(self, date_styles: set[int], timedelta_styles: set[int]) -> None:
+        self.date_styles = date_styles
+        self.timedelta_styles = timedelta_styles
+
+
+def extract_sheet_cells_fast(file_path: Path) -> dict[str, list[CellRow]]:
+    """Stream cell rows of every worksheet without pandas/openpyxl objects.
+
+    Produces the same CellRows as ``extract_sheet_cells`` (non-empty cells,
+    0-based column keys, numeric-looking text coerced). Non-OOXML workbooks
+    (.xls) fall back to ``extract_sheet_cells``.
+
+    Args:
+        file_path: Workbook path.
+
+    Returns:
+        Mapping of sheet name to cell rows, in workbook order.
+
+    Raises:
+        UnsafeWorkbookError: If the package fails the zip-bomb/XML checks.
+    """
+    if not zipfile.is_zipfile(open_source(file_path)):
+        return extract_sheet_cells(file_path)
+    with open_package(file_path) as zf:
+        sheets_info = _read_sheets_info(zf)
+        sheet_files = _read_sheet_files(zf, sheets_info)
+        names = zf.namelist()
+        shared = (
+            _read_shared_strings(zf) if "xl/sharedStrings.xml" in names else []
+        )
+        formats = (
+            _read_number_formats(zf)
+            if "xl/styles.xml" in names
+            else _NumberFormats(set(), set())
+        )
+        epoch = _read_epoch(zf)
+        result: dict[str, list[CellRow]] = {}
+        for name in sheets_info.values():
+            path = sheet_files.get(name)
+            if path is None or path not in names:
+                continue
+            result[name] = _read_sheet_rows(zf, path, shared, formats, epoch)
+    return result
+
+
+def _read_shared_strings(zf: zipfile.ZipFile) -> list[str]:
+    strings: list[str] = []
+    for _event, elem in iterparse_part(zf, "xl/sharedStrings.xml", ("end",)):
+        if elem.tag == _SI:
+            strings.append(_rich_text(elem).replace("x005F_", ""))
+            elem.clear()
+    return strings
+
+
+def _rich_text(elem: ET.Element) -> str:
+    """Concatenate plain and run text, skipping phonetic (rPh) runs."""
+    parts: list[str] = []
+    for child in elem:
+        if child.tag == _TEXT:
+            parts.append(child.text or "")
+        elif child.tag == _RUN:
+            text = child.find(_TEXT)
+            if text is not None:
+                parts.append(text.text or "")
+    return "".join(parts)
+
+
+def _read_number_formats(zf: zipfile.ZipFile) -> _NumberFormats:
+    root = parse_part(zf, "xl/styles.xml")
+    custom: dict[int, str] = {}
+    for fmt in root.iterfind("main:numFmts/main:numFmt", _NS):
+        try:
+            custom[int(fmt.get("numFmtId", ""))] = fmt.get("formatCode", "")
+        except ValueError:
+            continue
+    date_styles: set[int] = set()
+    timedelta_styles: set[int] = set()
+    for index, xf in enumerate(root.iterfind("main:cellXfs/main:xf", _NS)):
+        try:
+            fmt_id = int(xf.get("numFmtId", "0"))
+        except ValueError:
+            continue
+        code = custom.get(fmt_id) or BUILTIN_FORMATS.get(fmt_id, "General")
+        if is_date_format(code):
+            date_styles.add(index)
+        if is_timedelta_format(code):
+            timedelta_styles.add(index)
+    return _NumberFormats(date_styles, timedelta_styles)
+
+
+def _read_epoch(zf: zipfile.ZipFile) -> datetime:
+    try:
+        root = parse_part(zf, "xl/workbook.xml")
+    except (KeyError, ET.ParseError):
+        return CALENDAR_WINDOWS_1900
+    props = root.find("main:workbookPr", _NS)
+    date1904 = props is not None and props.get("date1904") in {"1", "true"}
+    return CALENDAR_MAC_1904 if date1904 else CALENDAR_WINDOWS_1900
+
+
+def _read_sheet_rows(
+    zf: zipfile.ZipFile,
+    path: str,
+    shared: list[str],
+    formats: _NumberFormats,
+    epoch: datetime,
+) -> list[CellRow]:
+    rows: list[CellRow] = []
+    row_index = 0
+    for _event, elem in iterparse_part(zf, path, ("end",)):
+        if elem.tag != _ROW:
+            continue
+        row_index = _int_or(elem.get("r"), row_index + 1)
+        values: dict[str, int | float | str] = {}
+        col = 0
+        for cell in elem.iter(_CELL):
+            col = _column_index(cell.get("r"), col + 1)
+            text = _cell_text(cell, shared, formats, epoch)
+            if text is None or text in _PANDAS_NA_STRINGS or not text.strip():
+                continue
+            values[str(col - 1)] = _coerce_numeric_preserve_format(text)
+        if values:
+            rows.append(CellRow(r=row_index, c=values))
+        elem.clear()
+    return rows
+
+
+def _cell_text(  # noqa: C901
+    cell: ET.Element,
+    shared: list[str],
+    formats: _NumberFormats,
+    epoch: datetime,
+) -> str | None:
+    """Return the cell value as the default reader's string, or None if empty.
+
+    Mirrors openpyxl's worksheet reader (data_only) followed by pandas'
+    conversion: errors are dropped, integral numbers lose their ``.0``, and
+    date-formatted numbers become ``str(datetime)``.
+    """
+    data_type = cell.get("t", "n")
+    if data_type == "inlineStr":
+        inline = cell.find(_INLINE)
+        return _rich_text(inline) if inline is not None else None
+    raw = cell.findtext(_VALUE)
+    if raw is None or data_type == "e":
+        return None
+    if data_type == "s":
+        try:
+            return shared[int(raw)]
+        except (ValueError, IndexError):
+            return None
+    if data_type == "str":
+        return raw
+    if data_type == "b":
+        return str(raw.strip() in {"1", "true"})
+    if data_type == "d":
+        try:
+            return str(from_ISO8601(raw))
+        except ValueError:
+            return None
+    try:
+        number: int | float = (
+            float(raw) if any(ch in raw for ch in ".Ee") else int(raw)
+        )
+    except ValueError:
+        return None
+    style = _int_or(cell.get("s"), 0)
+    if style in formats.date_styles:
+        try:
+            return str(
+                from_excel(
+                    number, epoch, timedelta=style in formats.timedelta_styles
+                )
+            )
+        except (OverflowError, ValueError):
+            return None
+    if isinstance(number, float) and number.is_integer():
+        return str(int(number))
+    return str(number)
+
+
+def _column_index(ref: str | None, default: int) -> int:
+    """Return the 1-based column of an ``A1`` reference."""
+    if not ref:
+        return default
+    match = _COLUMN_RE.match(ref)
+    if match is None:
+        return default
+    index = 0
+    for ch in match.group(0):
+        index = index * 26 + ord(ch) - 64
+    return index
+
+
+def _int_or(value: str | None, default: int) -> int:
+    if value is None:
+        return default
+    try:
+        return int(value)
+    except ValueError:
+        return default
+
+
+__all__ = ["extract_sheet_cells_fast"]
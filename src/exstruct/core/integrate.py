@@ -2,15 +2,25 @@
 
 from __future__ import annotations
 
+from collections.abc import Collection
+from contextlib import ExitStack
 from pathlib import Path
+from tempfile import TemporaryDirectory
 from typing import Literal
+import zipfile
 
 from ..constraints import validate_libreoffice_extraction_request
 from ..models import WorkbookData
+from ..ooxml.safety import check_workbook_file
+from ..ooxml.units import PositionUnit
+from ..sources import open_source
+from .extractors import run_extractors
 from .pipeline import resolve_extraction_inputs, run_extraction_pipeline
+from .recovery import RecoveryReport, repair_package
+from .workbook import shared_openpyxl_workbooks
 
 
-def extract_workbook(  # noqa: C901
+def extract_workbook(
     file_path: str | Path,
     mode: Literal["light", "libreoffice", "standard", "verbose"] = "standard",
     *,
@@ -23,11 +33,32 @@ def extract_workbook(  # noqa: C901
     include_formulas_map: bool | None = None,
     include_merged_cells: bool | None = None,
     include_merged_values_in_rows: bool = True,
+    include_dimensions: bool | None = None,
+    include_cell_errors: bool | None = None,
+    include_outline: bool | None = None,
+    include_covered_ranges: bool | None = None,
+    include_cells: bool = True,
+    include_shapes: bool = True,
+    include_charts: bool = True,
+    include_tables: bool = True,
+    best_effort: bool = False,
+    fast_cells: bool = False,
+    stable_ids: bool = False,
+    include_shape_paths: bool = False,
+    position_unit: PositionUnit | None = None,
+    position_dpi: int | None = None,
+    locale: str | None = None,
+    include_phonetic: bool = False,
+    infer_print_areas: bool = False,
+    sheets: Collection[str] | None = None,
 ) -> WorkbookData:
     """
     Extract a workbook into a structured WorkbookData representation.
 
     May fall back to cells+tables extraction if Excel COM automation is unavailable.
+    Every step that reads the file through openpyxl shares one workbook load.
+    Extractors registered via ``exstruct.core.extractors.register_extractor`` run
+    last and store their results in ``SheetData.extensions``.
 
     Parameters:
         file_path (str | Path): Path to the workbook file.
@@ -41,12 +72,49 @@ def extract_workbook(  # noqa: C901
         include_formulas_map (bool | None): Include a map of cell formulas; `None` uses mode defaults.
         include_merged_cells (bool | None): Include merged cell ranges; `None` uses mode defaults.
         include_merged_values_in_rows (bool): Preserve merged cell values in row-wise output.
+        include_dimensions (bool | None): Include row heights and column widths; `None` uses mode defaults.
+        include_cell_errors (bool | None): Include cells holding error values; `None` uses mode defaults.
+        include_outline (bool | None): Include row/column outline groups; `None` uses mode defaults.
+        include_covered_ranges (bool | None): Compute the cell range each shape
+            covers; `None` follows `include_dimensions`.
+        include_cells (bool): Read cell values; when False, sheets are listed with empty rows.
+        include_shapes (bool): Extract shapes (COM, LibreOffice, or OOXML fallback).
+        include_charts (bool): Extract charts (COM, LibreOffice, or OOXML fallback).
+        include_tables (bool): Run table candidate detection.
+        best_effort (bool): Skip corrupted zip entries and malformed XML parts
+            instead of failing; broken sheets are dropped and every skipped
+            part is reported in ``WorkbookData.warnings``.
+        fast_cells (bool): Stream cell values from the sheet XML instead of
+            reading them through pandas; faster and leaner on large sheets.
+        stable_ids (bool): Use the drawing's cNvPr ids as shape ids (instead
+            of per-run numbering) and record ``source_id`` where the drawing
+            part is known, so ids survive re-extraction.
+        include_shape_paths (bool): Add simplified polylines of freeform
+            paths and ink strokes to ``Shape.geometry`` (OOXML parsing only).
+        position_unit (PositionUnit | None): Emit shape and chart positions in
+            pixels, points, EMU, or millimeters. None keeps each backend's
+            native unit (points for COM/LibreOffice, pixels for OOXML).
+        position_dpi (int | None): DPI for pixel positions (None -> 96).
+        locale (str | None): Locale tag (e.g. ``de-DE``, ``ja``) used to parse
+            cell text such as ``1.234,56``, ``１，２３４`` or ``15.01.2024``
+            into numbers and dates. None keeps plain parsing.
+        include_phonetic (bool): Attach phonetic (furigana) readings of text
+            cells to rows as ``phonetic``.
+        infer_print_areas (bool): For sheets without a defined print area,
+            split the used range into pages from the page setup and report
+            them as ``print_areas``.
+        sheets (Collection[str] | None): Extract only these sheets (names not
+            in the workbook are ignored); None extracts every sheet.
 
     Returns:
         WorkbookData: The extracted workbook representation.
 
     Raises:
         ConfigError: If `mode="libreoffice"` is used with auto page-break extraction.
+        ExtractionError: If a registered extractor fails, or if ``best_effort``
+            cannot recover the workbook structure.
+        UnsafeWorkbookError: If the package looks like a zip bomb or its XML
+            declares entities.
         ValueError: If `mode` is not one of "light", "libreoffice", "standard", or "verbose".
     """
     normalized_file_path = validate_libreoffice_extraction_request(
@@ -54,8 +122,97 @@ def extract_workbook(  # noqa: C901
         mode=mode,
         include_auto_page_breaks=include_auto_page_breaks,
     )
+    check_workbook_file(normalized_file_path)
+    with ExitStack() as stack:
+        stack.enter_context(shared_openpyxl_workbooks())
+        recovered = _repair(normalized_file_path, stack) if best_effort else None
+        source_path = normalized_file_path if recovered is None else recovered[0]
+        workbook = _extract(
+            source_path,
+            mode=mode,
+            include_cell_links=include_cell_links,
+            include_print_areas=include_print_areas,
+            include_auto_page_breaks=include_auto_page_breaks,
+            include_colors_map=include_colors_map,
+            include_default_background=include_default_background,
+            ignore_colors=ignore_colors,
+            include_formulas_map=include_formulas_map,
+            include_merged_cells=include_merged_cells,
+            include_merged_values_in_rows=include_merged_values_in_rows,
+            include_dimensions=include_dimensions,
+            include_cell_errors=include_cell_errors,
+            include_outline=include_outline,
+            include_covered_ranges=include_covered_ranges,
+            include_cells=include_cells,
+            include_shapes=include_shapes,
+            include_charts=include_charts,
+            include_tables=include_tables,
+            fast_cells=fast_cells,
+            stable_ids=stable_ids,
+            include_shape_paths=include_shape_paths,
+            position_unit=position_unit,
+            position_dpi=position_dpi,
+            locale=locale,
+            include_phonetic=include_phonetic,
+            infer_print_areas=infer_print_areas,
+            sheets=sheets,
+        )
+    if recovered is not None:
+        _apply_recovery_report(workbook, recovered[1])
+    return workbook
+
+
+def _repair(
+    file_path: Path, stack: ExitStack
+) -> tuple[Path, RecoveryReport] | None:
+    """Repair a corrupted package into a temporary directory owned by ``stack``."""
+    if not zipfile.is_zipfile(open_source(file_path)):
+        return None
+    tmp_dir = stack.enter_context(TemporaryDirectory(prefix="exstruct-"))
+    return repair_package(file_path, Path(tmp_dir))
+
+
+def _apply_recovery_report(workbook: WorkbookData, report: RecoveryReport) -> None:
+    """Drop sheets that could not be read and record the recovery warnings."""
+    for sheet in report.broken_sheets:
+        workbook.sheets.pop(sheet, None)
+    workbook.warnings = [*workbook.warnings, *report.warnings]
+
+
+def _extract(
+    file_path: Path,
+    *,
+    mode: Literal["light", "libreoffice", "standard", "verbose"],
+    include_cell_links: bool | None,
+    include_print_areas: bool | None,
+    include_auto_page_breaks: bool,
+    include_colors_map: bool | None,
+    include_default_background: bool,
+    ignore_colors: set[str] | None,
+    include_formulas_map: bool | None,
+    include_merged_cells: bool | None,
+    include_merged_values_in_rows: bool,
+    include_dimensions: bool | None,
+    include_cell_errors: bool | None,
+    include_outline: bool | None,
+    include_covered_ranges: bool | None,
+    include_cells: bool,
+    include_shapes: bool,
+    include_charts: bool,
+    include_tables: bool,
+    fast_cells: bool,
+    stable_ids: bool,
+    include_shape_paths: bool,
+    position_unit: PositionUnit | None,
+    position_dpi: int | None,
+    locale: str | None,
+    include_phonetic: bool,
+    infer_print_areas: bool,
+    sheets: Collection[str] | None,
+) -> WorkbookData:
+    """Run the extraction pipeline and registered extractors on one file."""
     inputs = resolve_extraction_inputs(
-        normalized_file_path,
+        file_path,
         mode=mode,
         include_cell_links=include_cell_links,
         include_print_areas=include_print_areas,
@@ -66,6 +223,23 @@ def extract_workbook(  # noqa: C901
         include_formulas_map=include_formulas_map,
         include_merged_cells=include_merged_cells,
         include_merged_values_in_rows=include_merged_values_in_rows,
+        include_dimensions=include_dimensions,
+        include_cell_errors=include_cell_errors,
+        include_outline=include_outline,
+        include_covered_ranges=include_covered_ranges,
+        include_cells=include_cells,
+        include_shapes=include_shapes,
+        include_charts=include_charts,
+        include_tables=include_tables,
+        fast_cells=fast_cells,
+        stable_ids=stable_ids,
+        include_shape_paths=include_shape_paths,
+        position_unit=position_unit,
+        position_dpi=position_dpi,
+        locale=locale,
+        include_phonetic=include_phonetic,
+        infer_print_areas=infer_print_areas,
+        sheets=sheets,
     )
     result = run_extraction_pipeline(inputs)
-    return result.workbook
+    return run_extractors(result.workbook, inputs.file_path, mode=mode)
@@ -0,0 +1,218 @@
+"""Size guardrails for extracting untrusted workbooks."""
+
+from __future__ import annotations
+
+import logging
+from pathlib import Path
+import zipfile
+
+from openpyxl.utils import range_boundaries
+
+from ..errors import LimitExceededError
+from ..models import CellRow, SheetData, SheetTruncation, WorkbookData
+from ..ooxml.summary import summarize_workbook_ooxml
+from ..sources import open_source
+from .ranges import RangeBounds, clip_rows, column_index
+
+logger = logging.getLogger(__name__)
+
+
+def check_package_limits(
+    file_path: Path, *, max_cells: int | None, max_sheets: int | None
+) -> None:
+    """Reject an OOXML workbook before extraction when it is too large.
+
+    Sheet and non-empty cell counts are read by streaming the package parts,
+    so an oversized upload fails without materializing its cells. Non-zip
+    (.xls) workbooks are only checked after extraction.
+
+    Args:
+        file_path: Workbook path.
+        max_cells: Maximum non-empty cells across all sheets (None = unlimited).
+        max_sheets: Maximum number of sheets (None = unlimited).
+
+    Raises:
+        LimitExceededError: If a limit is exceeded.
+    """
+    if (max_cells is None and max_sheets is None) or not zipfile.is_zipfile(
+        open_source(file_path)
+    ):
+        return
+    summary = summarize_workbook_ooxml(file_path)
+    _raise_if_exceeded(
+        sheets=len(summary.sheets),
+        cells=sum(sheet.non_empty_cells for sheet in summary.sheets),
+        max_cells=max_cells,
+        max_sheets=max_sheets,
+    )
+
+
+def enforce_workbook_limits(
+    workbook: WorkbookData,
+    *,
+    max_cells: int | None,
+    max_sheets: int | None,
+    truncate: bool,
+) -> WorkbookData:
+    """Check or truncate an extracted workbook against cell/sheet limits.
+
+    Truncation keeps the first ``max_sheets`` sheets and the first ``max_cells``
+    cells in sheet and row order; the rest are dropped with a warning.
+
+    Args:
+        workbook: Extracted workbook.
+        max_cells: Maximum cells across all rows (None = unlimited).
+        max_sheets: Maximum number of sheets (None = unlimited).
+        truncate: Truncate instead of raising.
+
+    Returns:
+        The workbook, truncated when needed.
+
+    Raises:
+        LimitExceededError: If a limit is exceeded and ``truncate`` is False.
+    """
+    cells = sum(len(row.c) for sheet in workbook.sheets.values() for row in sheet.rows)
+    if not truncate:
+        _raise_if_exceeded(
+            sheets=len(workbook.sheets),
+            cells=cells,
+            max_cells=max_cells,
+            max_sheets=max_sheets,
+        )
+        return workbook
+    names = list(workbook.sheets)
+    if max_sheets is not None and len(names) > max_sheets:
+        logger.warning(
+            "Workbook has %d sheets; keeping the first %d.", len(names), max_sheets
+        )
+        workbook.sheets = {name: workbook.sheets[name] for name in names[:max_sheets]}
+    if max_cells is not None and cells > max_cells:
+        logger.warning("Workbook has %d cells; keeping the first %d.", cells, max_cells)
+        _truncate_cells(workbook, max_cells)
+    return workbook
+
+
+def clip_sheet_extents(
+    workbook: WorkbookData, *, max_rows: int | None, max_cols: int | None
+) -> WorkbookData:
+    """Clip each sheet to its first ``max_rows`` rows and ``max_cols`` columns.
+
+    Rows, cell-position maps (formulas, colors, styles), and error cells
+    outside ``A1`` to the ``max_rows``/``max_cols`` corner are dropped. Clipped
+    sheets get ``SheetData.truncation`` with the sheet's original extents, so
+    partial output is explicit rather than an error.
+
+    Args:
+        workbook: Extracted workbook.
+        max_rows: Last row kept, 1-based (None = unlimited).
+        max_cols: Number of columns kept from column A (None = unlimited).
+
+    Returns:
+        The workbook with clipped sheets.
+    """
+    if max_rows is None and max_cols is None:
+        return workbook
+    for name, sheet in workbook.sheets.items():
+        cells = [(row.r, column_index(key)) for row in sheet.rows for key in row.c]
+        if not cells:
+            continue
+        last_row = max(r for r, _ in cells)
+        last_col = max(c for _, c in cells) + 1
+        if (max_rows is None or last_row <= max_rows) and (
+            max_cols is None or last_col <= max_cols
+        ):
+            continue
+        bounds = RangeBounds(
+            r1=0,
+            c1=0,
+            r2=(max_rows if max_rows is not None else last_row) - 1,
+            c2=(max_cols if max_cols is not None else last_col) - 1,
+        )
+        _clip_sheet(sheet, bounds)
+        sheet.truncation = SheetTruncation(
+            original_rows=last_row,
+            original_cols=last_col,
+            max_rows=max_rows,
+            max_cols=max_cols,
+        )
+        logger.warning(
+            "Sheet '%s' spans %d rows x %d columns; clipped to %s x %s.",
+            name,
+            last_row,
+            last_col,
+            max_rows if max_rows is not None else last_row,
+            max_cols if max_cols is not None else last_col,
+        )
+    return workbook
+
+
+def check_output_size(text: str, max_output_bytes: int | None) -> None:
+    """Reject serialized output larger than ``max_output_bytes`` (UTF-8).
+
+    Raises:
+        LimitExceededError: If the output is too large.
+    """
+    if max_output_bytes is None:
+        return
+    size = len(text.encode("utf-8"))
+    if size > max_output_bytes:
+        raise LimitExceededError(
+            f"Output is {size} bytes, exceeding max_output_bytes={max_output_bytes}."
+        )
+
+
+def _truncate_cells(workbook: WorkbookData, budget: int) -> None:
+    """Drop cells (and then rows) beyond the budget, in sheet and row order."""
+    for sheet in workbook.sheets.values():
+        kept: list[CellRow] = []
+        for row in sheet.rows:
+            if budget <= 0:
+                break
+            if len(row.c) > budget:
+                row.c = dict(list(row.c.items())[:budget])
+            budget -= len(row.c)
+            kept.append(row)
+        sheet.rows = kept
+
+
+def _clip_sheet(sheet: SheetData, bounds: RangeBounds) -> None:
+    """Drop rows, cell-position map entries, and errors outside ``bounds``."""
+
+    def inside(r: int, c: int) -> bool:
+        return r - 1 <= bounds.r2 and c <= bounds.c2
+
+    sheet.rows = clip_rows(sheet.rows, [bounds])
+    for name in ("formulas_map", "colors_map", "style_map"):
+        clipped: dict[str, list[tuple[int, int]]] = {}
+        for key, cells in getattr(sheet, name).items():
+            kept = [(r, c) for r, c in cells if inside(r, c)]
+            if kept:
+                clipped[key] = kept
+        setattr(sheet, name, clipped)
+    kept_errors = []
+    for error in sheet.errors:
+        min_col, min_row, _, _ = range_boundaries(error.cell.replace("$", ""))
+        if min_row is not None and min_col is not None and inside(min_row, min_col - 1):
+            kept_errors.append(error)
+    sheet.errors = kept_errors
+
+
+def _raise_if_exceeded(
+    *, sheets: int, cells: int, max_cells: int | None, max_sheets: int | None
+) -> None:
+    if max_sheets is not None and sheets > max_sheets:
+        raise LimitExceededError(
+            f"Workbook has {sheets} sheets, exceeding max_sheets={max_sheets}."
+        )
+    if max_cells is not None and cells > max_cells:
+        raise LimitExceededError(
+            f"Workbook has {cells} non-empty cells, exceeding max_cells={max_cells}."
+        )
+
+
+__all__ = [
+    "check_output_size",
+    "check_package_limits",
+    "clip_sheet_extents",
+    "enforce_workbook_limits",
+]
@@ -1,9 +1,16 @@
 from __future__ import annotations
 
+from collections.abc import Iterator
+from contextlib import contextmanager
+import json
 import logging
+import sys
+from typing import TextIO
 
 from ..errors import FallbackReason
 
+_ROOT_LOGGER = "exstruct"
+
 
 def log_fallback(logger: logging.Logger, reason: FallbackReason, message: str) -> None:
     """Log a standardized fallback warning.
@@ -14,3 +21,127 @@ def log_fallback(logger: logging.Logger, reason: FallbackReason, message: str) -
         message: Human-readable detail message.
     """
     logger.warning("[%s] %s", reason.value, message)
+
+
+class _ForwardHandler(logging.Handler):
+    """Handler re-emitting records through another logger's handlers."""
+
+    def __init__(self, target: logging.Logger) -> None:
+        super().__init__()
+        self.target = target
+
+    def emit(self, record: logging.LogRecord) -> None:
+        if self.target.isEnabledFor(record.levelno):
+            self.target.handle(record)
+
+
+@contextmanager
+def route_logs_to(target: logging.Logger | None) -> Iterator[None]:
+    """Send records of the ``exstruct`` logger tree to ``target`` for a block.
+
+    While active, records are not propagated to the root logger and the
+    ``exstruct`` level follows the target's effective level, so the caller's
+    logger decides both where records go and how verbose they are.
+
+    Args:
+        target: Injected logger; None (or a logger inside the ``exstruct``
+            tree) leaves logging untouched.
+    """
+    if target is None or target.name.split(".")[0] == _ROOT_LOGGER:
+        yield
+        return
+    package_logger = logging.getLogger(_ROOT_LOGGER)
+    handler = _ForwardHandler(target)
+    previous = (package_logger.level, package_logger.propagate)
+    package_logger.addHandler(handler)
+    package_logger.setLevel(target.getEffectiveLevel())
+    package_logger.propagate = False
+    try:
+        yield
+    finally:
+        package_logger.removeHandler(handler)
+        package_logger.setLevel(previous[0])
+        package_logger.propagate = previous[1]
+
+
+class _CaptureHandler(logging.Handler):
+    """Handler collecting the messages of warning and error records."""
+
+    def __init__(self, messages: list[str]) -> None:
+        super().__init__(level=logging.WARNING)
+        self.messages = messages
+
+    def emit(self, record: logging.LogRecord) -> None:
+        self.messages.append(record.getMessage())
+
+
+@contextmanager
+def capture_log_warnings() -> Iterator[list[str]]:
+    """Collect warning and error messages of the ``exstruct`` logger tree.
+
+    Yields:
+        List that receives each message while the block runs.
+    """
+    messages: list[str] = []
+    handler = _CaptureHandler(messages)
+    package_logger = logging.getLogger(_ROOT_LOGGER)
+    package_logger.addHandler(handler)
+    try:
+        yield messages
+    finally:
+        package_logger.removeHandler(handler)
+
+
+class _CliHandler(logging.StreamHandler[TextIO]):
+    """stderr handler installed by ``configure_cli_logging``.
+
+    Resolves ``sys.stderr`` on every record so redirected streams are honored.
+    """
+
+    def emit(self, record: logging.LogRecord) -> None:
+        self.stream = sys.stderr
+        super().emit(record)
+
+
+class JsonLogFormatter(logging.Formatter):
+    """Format records as one JSON object per line."""
+
+    def format(self, record: logging.LogRecord) -> str:
+        payload: dict[str, object] = {
+            "time": self.formatTime(record, "%Y-%m-%dT%H:%M:%S%z"),
+            "level": record.levelname,
+            "logger": record.name,
+            "message": record.getMessage(),
+        }
+        if record.exc_info:
+            payload["exc_info"] = self.formatException(record.exc_info)
+        return json.dumps(payload, ensure_ascii=False)
+
+
+def configure_cli_logging(
+    *, verbosity: int = 0, quiet: bool = False, log_format: str = "text"
+) -> None:
+    """Attach a stderr handler to the ``exstruct`` logger for CLI runs.
+
+    Args:
+        verbosity: 0 logs warnings, 1 (``-v``) adds info, 2+ (``-vv``) adds debug.
+        quiet: Only log errors; takes precedence over verbosity.
+        log_format: ``text`` or ``json`` (one JSON object per line).
+    """
+    if quiet:
+        level = logging.ERROR
+    else:
+        level = {0: logging.WARNING, 1: logging.INFO}.get(verbosity, logging.DEBUG)
+    handler = _CliHandler()
+    if log_format == "json":
+        handler.setFormatter(JsonLogFormatter())
+    else:
+        handler.setFormatter(logging.Formatter("%(levelname)s %(name)s: %(message)s"))
+    package_logger = logging.getLogger(_ROOT_LOGGER)
+    for existing in list(package_logger.handlers):
+        if isinstance(existing, _CliHandler):
+            package_logger.removeHandler(existing)
+    handler.setLevel(level)
+    package_logger.addHandler(handler)
+    if level < package_logger.getEffectiveLevel():
+        package_logger.setLevel(level)
@@ -1,19 +1,30 @@
 from __future__ import annotations
 
-from dataclasses import dataclass
-
+from dataclasses import dataclass, field
+
+from ..analysis import (
+    audit_formulas,
+    build_chart_source_index,
+    build_flowcharts,
+    build_table_families,
+    find_shape_overlaps,
+    sheet_content_hash,
+    table_content_hashes,
+)
 from ..models import (
     Arrow,
+    CellError,
     CellRow,
     Chart,
     MergedCells,
     PrintArea,
     Shape,
     SheetData,
+    SheetOutline,
     SmartArt,
     WorkbookData,
 )
-from .cells import MergedCellRange
+from .cells import MergedCellRange, SheetDimensions
 
 
 @dataclass(frozen=True)
@@ -30,6 +41,9 @@ class SheetRawData:
         formulas_map: Mapping of formula strings to (row, column) positions.
         colors_map: Mapping of color keys to (row, column) positions.
         merged_cells: Extracted merged cell ranges.
+        dimensions: Extracted row heights and column widths.
+        errors: Cells holding error values.
+        outline: Row/column outline groups.
     """
 
     rows: list[CellRow]
@@ -41,6 +55,9 @@ class SheetRawData:
     formulas_map: dict[str, list[tuple[int, int]]]
     colors_map: dict[str, list[tuple[int, int]]]
     merged_cells: list[MergedCellRange]
+    dimensions: SheetDimensions | None = None
+    errors: list[CellError] = field(default_factory=list)
+    outline: SheetOutline | None = None
 
 
 @dataclass(frozen=True)
@@ -65,6 +82,7 @@ def build_sheet_data(raw: SheetRawData) -> SheetData:
     Returns:
         SheetData model instance.
     """
+    dimensions = raw.dimensions
     return SheetData(
         rows=raw.rows,
         shapes=raw.shapes,
@@ -75,6 +93,18 @@ def build_sheet_data(raw: SheetRawData) -> SheetData:
         formulas_map=raw.formulas_map,
         colors_map=raw.colors_map,
         merged_cells=_build_merged_cells(raw.merged_cells),
+        column_widths=dimensions.column_widths if dimensions else {},
+        row_heights=dimensions.row_heights if dimensions else {},
+        default_column_width=dimensions.default_column_width if dimensions else None,
+        default_row_height=dimensions.default_row_height if dimensions else None,
+        max_digit_width=dimensions.max_digit_width if dimensions else None,
+        flowcharts=build_flowcharts(raw.shapes),
+        shape_overlaps=find_shape_overlaps(raw.shapes),
+        errors=raw.errors,
+        outline=raw.outline,
+        formula_audit=audit_formulas(raw.formulas_map),
+        content_hash=sheet_content_hash(raw.rows),
+        table_hashes=table_content_hashes(raw.rows, raw.table_candidates),
     )
 
 
@@ -105,4 +135,9 @@ def build_workbook_data(raw: WorkbookRawData) -> WorkbookData:
         WorkbookData model instance.
     """
     sheets = {name: build_sheet_data(sheet) for name, sheet in raw.sheets.items()}
-    return WorkbookData(book_name=raw.book_name, sheets=sheets)
+    return WorkbookData(
+        book_name=raw.book_name,
+        sheets=sheets,
+        chart_sources=build_chart_source_index(sheets),
+        table_families=build_table_families(sheets),
+    )
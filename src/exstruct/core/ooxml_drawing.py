@@ -9,7 +9,25 @@ from zipfile import ZipFile
 
 from defusedxml import ElementTree
 
-from ..models import ChartSeries
+from ..models import ChartAxis, ChartLegend, ChartSeries
+from ..ooxml.chart import (
+    PIE_CHART_TAGS,
+    BarDirection,
+    ChartGrouping,
+    ScatterStyle,
+    grouped_chart_type,
+    parse_chart_grouping,
+    parse_data_labels,
+    parse_error_bars,
+    parse_legend,
+    parse_scatter_style,
+    parse_series_cache,
+    parse_series_style,
+    parse_trendlines,
+    parse_value_axes,
+)
+from ..ooxml.compat import resolve_alternate_content
+from ..ooxml.safety import open_package, parse_xml, read_part
 
 _NS = {
     "a": "http://schemas.openxmlformats.org/drawingml/2006/main",
@@ -64,6 +82,7 @@ class DrawingShapeRef:
     top: int | None
     width: int | None
     height: int | None
+    z_order: int | None = None
 
 
 @dataclass(frozen=True)
@@ -97,6 +116,11 @@ class OoxmlChartInfo:
     anchor_top: int | None
     anchor_width: int | None
     anchor_height: int | None
+    legend: ChartLegend | None = None
+    grouping: ChartGrouping | None = None
+    bar_direction: BarDirection | None = None
+    value_axes: list[ChartAxis] = field(default_factory=list)
+    scatter_style: ScatterStyle | None = None
 
 
 @dataclass(frozen=True)
@@ -134,12 +158,13 @@ class SheetDrawingData:
     shapes: list[OoxmlShapeInfo] = field(default_factory=list)
     connectors: list[OoxmlConnectorInfo] = field(default_factory=list)
     charts: list[OoxmlChartInfo] = field(default_factory=list)
+    part_name: str | None = None
 
 
 def read_sheet_drawings(file_path: Path) -> dict[str, SheetDrawingData]:
     """Read worksheet drawing metadata directly from OOXML parts."""
     result: dict[str, SheetDrawingData] = {}
-    with ZipFile(file_path) as archive:
+    with open_package(file_path) as archive:
         for sheet_name, sheet_xml_path in _iter_sheet_xml_paths(archive):
             drawing_path = _resolve_sheet_drawing_path(archive, sheet_xml_path)
             if drawing_path is None:
@@ -151,8 +176,8 @@ def read_sheet_drawings(file_path: Path) -> dict[str, SheetDrawingData]:
 def _iter_sheet_xml_paths(archive: ZipFile) -> list[tuple[str, str]]:
     """Return workbook sheet names paired with their OOXML worksheet paths."""
 
-    workbook_xml = archive.read("xl/workbook.xml")
-    workbook_root = ElementTree.fromstring(workbook_xml)
+    workbook_xml = read_part(archive, "xl/workbook.xml")
+    workbook_root = parse_xml(workbook_xml)
     rel_map = _read_relationships(archive, "xl/_rels/workbook.xml.rels")
     paths: list[tuple[str, str]] = []
     for sheet in workbook_root.findall("spreadsheetml:sheets/spreadsheetml:sheet", _NS):
@@ -184,7 +209,8 @@ def _resolve_sheet_drawing_path(archive: ZipFile, sheet_xml_path: str) -> str |
 def _parse_sheet_drawing(archive: ZipFile, drawing_path: str) -> SheetDrawingData:
     """Parse shapes, connectors, and charts from a drawing part."""
 
-    root = ElementTree.fromstring(archive.read(drawing_path))
+    root = parse_xml(read_part(archive, drawing_path))
+    resolve_alternate_content(root)
     rel_map = {}
     drawing_rels_path = _rels_path(drawing_path)
     if drawing_rels_path in archive.namelist():
@@ -193,6 +219,7 @@ def _parse_sheet_drawing(archive: ZipFile, drawing_path: str) -> SheetDrawingDat
     shapes: list[OoxmlShapeInfo] = []
     connectors: list[OoxmlConnectorInfo] = []
     charts: list[OoxmlChartInfo] = []
+    z_order = 0
     for anchor in root:
         if _local_name(anchor.tag) not in {
             "absoluteAnchor",
@@ -200,13 +227,17 @@ def _parse_sheet_drawing(archive: ZipFile, drawing_path: str) -> SheetDrawingDat
             "twoCellAnchor",
         }:
             continue
+        # Anchors are stored back-to-front, so document order is the z-order.
+        z_order += 1
         if (shape_node := anchor.find("xdr:sp", _NS)) is not None:
-            shape_info = _parse_shape_node(anchor, shape_node)
+            shape_info = _parse_shape_node(anchor, shape_node, z_order=z_order)
             if shape_info is not None:
                 shapes.append(shape_info)
             continue
         if (connector_node := anchor.find("xdr:cxnSp", _NS)) is not None:
-            connector_info = _parse_connector_node(anchor, connector_node)
+            connector_info = _parse_connector_node(
+                anchor, connector_node, z_order=z_order
+            )
             if connector_info is not None:
                 connectors.append(connector_info)
             continue
@@ -214,12 +245,16 @@ def _parse_sheet_drawing(archive: ZipFile, drawing_path: str) -> SheetDrawingDat
             chart_info = _parse_chart_node(archive, anchor, graphic_frame, rel_map)
             if chart_info is not None:
                 charts.append(chart_info)
-    return SheetDrawingData(shapes=shapes, connectors=connectors, charts=charts)
+    return SheetDrawingData(
+        shapes=shapes, connectors=connectors, charts=charts, part_name=drawing_path
+    )
 
 
 def _parse_shape_node(
     anchor: ElementTree.Element,
     node: ElementTree.Element,
+    *,
+    z_order: int | None = None,
 ) -> OoxmlShapeInfo | None:
     """Parse an OOXML shape node into an ``OoxmlShapeInfo`` record."""
 
@@ -246,6 +281,7 @@ def _parse_shape_node(
         top=top,
         width=width,
         height=height,
+        z_order=z_order,
     )
     dx = None if width is None else (-width if flip_h else width)
     dy = None if height is None else (-height if flip_v else height)
@@ -262,6 +298,8 @@ def _parse_shape_node(
 def _parse_connector_node(
     anchor: ElementTree.Element,
     node: ElementTree.Element,
+    *,
+    z_order: int | None = None,
 ) -> OoxmlConnectorInfo | None:
     """Parse an OOXML connector node into an ``OoxmlConnectorInfo`` record."""
 
@@ -288,6 +326,7 @@ def _parse_connector_node(
         top=top,
         width=width,
         height=height,
+        z_order=z_order,
     )
     connector_props = node.find("xdr:nvCxnSpPr/xdr:cNvCxnSpPr", _NS)
     start_node = (
@@ -340,7 +379,7 @@ def _parse_chart_node(
     target = relationship.target
     if target not in archive.namelist():
         return None
-    chart_root = ElementTree.fromstring(archive.read(target))
+    chart_root = parse_xml(read_part(archive, target))
     left, top, width, height, _rotation, _flip_h, _flip_v = _parse_xfrm_geometry(
         node.find("xdr:xfrm", _NS)
     )
@@ -351,6 +390,7 @@ def _parse_chart_node(
         width=width,
         height=height,
     )
+    grouping, bar_direction = _extract_chart_grouping(chart_root)
     return OoxmlChartInfo(
         name=name,
         chart_type=_extract_chart_type(chart_root),
@@ -362,9 +402,23 @@ def _parse_chart_node(
         anchor_top=top,
         anchor_width=width,
         anchor_height=height,
+        legend=_extract_chart_legend(chart_root),
+        grouping=grouping,
+        bar_direction=bar_direction,
+        value_axes=_extract_value_axes(chart_root),
+        scatter_style=_extract_scatter_style(chart_root),
     )
 
 
+def _extract_chart_legend(chart_root: ElementTree.Element) -> ChartLegend | None:
+    """Extract legend settings from a chart part."""
+
+    chart = chart_root.find("c:chart", _NS)
+    if chart is None:
+        return None
+    return parse_legend(chart)
+
+
 def _extract_chart_type(chart_root: ElementTree.Element) -> str:
     """Extract the ExStruct chart type label from a chart part."""
 
@@ -375,11 +429,9 @@ def _extract_chart_type(chart_root: ElementTree.Element) -> str:
         tag = _local_name(child.tag)
         if tag not in _CHART_TAGS:
             continue
-        if tag == "barChart":
-            bar_dir = child.find("c:barDir", _NS)
-            if bar_dir is not None and bar_dir.attrib.get("val") == "bar":
-                return "Bar"
-            return "Column"
+        grouped = grouped_chart_type(tag, *parse_chart_grouping(child))
+        if grouped is not None:
+            return grouped
         return {
             "areaChart": "Area",
             "bubbleChart": "Bubble",
@@ -395,6 +447,38 @@ def _extract_chart_type(chart_root: ElementTree.Element) -> str:
     return "unknown"
 
 
+def _extract_chart_grouping(
+    chart_root: ElementTree.Element,
+) -> tuple[ChartGrouping | None, BarDirection | None]:
+    """Extract grouping and bar direction of the first chart type in a part."""
+
+    plot_area = chart_root.find("c:chart/c:plotArea", _NS)
+    if plot_area is None:
+        return (None, None)
+    for child in plot_area:
+        if _local_name(child.tag) in _CHART_TAGS:
+            return parse_chart_grouping(child)
+    return (None, None)
+
+
+def _extract_value_axes(chart_root: ElementTree.Element) -> list[ChartAxis]:
+    """Extract the primary and secondary value axes from a chart part."""
+
+    plot_area = chart_root.find("c:chart/c:plotArea", _NS)
+    if plot_area is None:
+        return []
+    return parse_value_axes(plot_area)
+
+
+def _extract_scatter_style(chart_root: ElementTree.Element) -> ScatterStyle | None:
+    """Extract the scatter style from a chart part."""
+
+    plot_area = chart_root.find("c:chart/c:plotArea", _NS)
+    if plot_area is None:
+        return None
+    return parse_scatter_style(plot_area)
+
+
 def _extract_chart_title(chart_root: ElementTree.Element) -> str | None:
     """Extract a chart title from a chart part."""
 
@@ -439,8 +523,10 @@ def _extract_chart_series(chart_root: ElementTree.Element) -> list[ChartSeries]:
         return []
     series: list[ChartSeries] = []
     for chart_node in plot_area:
-        if _local_name(chart_node.tag) not in _CHART_TAGS:
+        tag = _local_name(chart_node.tag)
+        if tag not in _CHART_TAGS:
             continue
+        group_labels = chart_node.find("c:dLbls", _NS)
         for series_node in chart_node.findall("c:ser", _NS):
             name_range = series_node.findtext(
                 "c:tx/c:strRef/c:f", default=None, namespaces=_NS
@@ -467,12 +553,32 @@ def _extract_chart_series(chart_root: ElementTree.Element) -> list[ChartSeries]:
                 "c:yVal/c:strRef/c:f",
                 "c:val/c:numRef/c:f",
             )
+            size_range = _extract_series_range(
+                series_node, "c:bubbleSize/c:numRef/c:f"
+            )
+            marker, smooth = parse_series_style(series_node)
+            series_labels = series_node.find("c:dLbls", _NS)
+            categories, values = (
+                parse_series_cache(series_node)
+                if tag in PIE_CHART_TAGS
+                else (None, None)
+            )
             series.append(
                 ChartSeries(
                     name=literal_name or name_range or "",
                     name_range=name_range,
                     x_range=x_range,
                     y_range=y_range,
+                    size_range=size_range,
+                    marker=marker,
+                    smooth=smooth,
+                    categories=categories,
+                    values=values,
+                    data_labels=parse_data_labels(
+                        series_labels if series_labels is not None else group_labels
+                    ),
+                    trendlines=parse_trendlines(series_node),
+                    error_bars=parse_error_bars(series_node),
                 )
             )
     return series
@@ -632,10 +738,15 @@ def _marker_to_points(
     row_off = _find_int_text(marker, "xdr:rowOff")
     if col is None or row is None:
         return (None, None)
-    left = int(
-        round(col * _DEFAULT_COLUMN_WIDTH_POINTS + (col_off or 0) / _EMU_PER_POINT)
-    )
-    top = int(round(row * _DEFAULT_ROW_HEIGHT_POINTS + (row_off or 0) / _EMU_PER_POINT))
+    try:
+        left = int(
+            round(col * _DEFAULT_COLUMN_WIDTH_POINTS + (col_off or 0) / _EMU_PER_POINT)
+        )
+        top = int(
+            round(row * _DEFAULT_ROW_HEIGHT_POINTS + (row_off or 0) / _EMU_PER_POINT)
+        )
+    except OverflowError:
+        return (None, None)
     return (left, top)
 
 
@@ -644,7 +755,7 @@ def _read_relationships(
 ) -> dict[str, OoxmlRelationship]:
     """Read a relationships part into a relationship-id keyed metadata map."""
 
-    root = ElementTree.fromstring(archive.read(rels_path))
+    root = parse_xml(read_part(archive, rels_path))
     base_path = _base_dir(_source_path_from_rels(rels_path))
     rel_map: dict[str, OoxmlRelationship] = {}
     for rel in root.findall("rel:Relationship", _NS):
@@ -719,7 +830,7 @@ def _emu_attr_to_points(node: ElementTree.Element | None, attr: str) -> int | No
         return None
     try:
         return int(round(int(raw) / _EMU_PER_POINT))
-    except ValueError:
+    except (ValueError, OverflowError):
         return None
 
 
@@ -0,0 +1,221 @@
+"""Infer print areas for sheets without one (``infer_print_areas``).
+
+Excel prints the used range of a sheet that has no ``_xlnm.Print_Area``,
+splitting it into pages by paper size, orientation, margins, and scale. This
+module repeats that pagination from the page setup stored in the workbook so
+undecorated sheets still yield per-page ``PrintArea`` slices. Column widths are
+converted with the workbook default font's digit width like ``shape_ranges``,
+but page boundaries can still be off by a row or column compared to Excel's own
+renderer, which also scales for printer resolution.
+"""
+
+from __future__ import annotations
+
+from collections.abc import Callable, Collection
+from pathlib import Path
+import zipfile
+
+from openpyxl.worksheet.worksheet import Worksheet
+
+from ..geometry.grid import column_width_to_points
+from ..models import PrintArea
+from ..sources import open_source
+from .backends.base import PrintAreaData
+from .cells import SheetDimensions, _extract_worksheet_dimensions
+from .workbook import openpyxl_workbook
+
+# Paper sizes (width, height) in inches keyed by the OOXML paperSize code.
+_PAPER_SIZES_INCHES: dict[int, tuple[float, float]] = {
+    1: (8.5, 11.0),  # Letter
+    5: (8.5, 14.0),  # Legal
+    7: (7.25, 10.5),  # Executive
+    8: (297 / 25.4, 420 / 25.4),  # A3
+    9: (210 / 25.4, 297 / 25.4),  # A4
+    11: (148 / 25.4, 210 / 25.4),  # A5
+    12: (257 / 25.4, 364 / 25.4),  # B4 (JIS)
+    13: (182 / 25.4, 257 / 25.4),  # B5 (JIS)
+}
+_DEFAULT_PAPER_SIZE = 9
+_POINTS_PER_INCH = 72.0
+_DEFAULT_MARGINS_INCHES = (0.7, 0.7, 0.75, 0.75)  # left, right, top, bottom
+
+
+def infer_print_areas(
+    file_path: Path, *, skip: Collection[str] = ()
+) -> PrintAreaData:
+    """Split the used range of each sheet into pages like Excel would print it.
+
+    Args:
+        file_path: Workbook path (.xlsx/.xlsm).
+        skip: Sheet names to leave out, typically those with a defined print
+            area.
+
+    Returns:
+        Mapping of sheet name to one ``PrintArea`` per inferred page, in
+        printing order. Empty sheets and non-OOXML workbooks (.xls) yield
+        nothing.
+    """
+    if not zipfile.is_zipfile(open_source(file_path)):
+        return {}
+    areas: PrintAreaData = {}
+    with openpyxl_workbook(file_path, data_only=True, read_only=False) as wb:
+        for ws in wb.worksheets:
+            if ws.title in skip:
+                continue
+            pages = _infer_sheet_pages(ws)
+            if pages:
+                areas[ws.title] = pages
+    return areas
+
+
+def _infer_sheet_pages(ws: Worksheet) -> list[PrintArea]:
+    """Paginate the used range of one worksheet."""
+    bounds = _value_bounds(ws)
+    if bounds is None:
+        return []
+    r1, c1, r2, c2 = bounds
+    dimensions = _extract_worksheet_dimensions(ws)
+    page_width, page_height = _printable_size(ws)
+    fit_width, fit_height = _fit_to_pages(ws)
+    col_spans = _split_axis(
+        c1,
+        c2,
+        _column_size(dimensions),
+        page_width if fit_width != 1 else float("inf"),
+        {brk - 1 for brk in _break_ids(ws, "col_breaks")},
+    )
+    row_spans = _split_axis(
+        r1,
+        r2,
+        _row_size(dimensions),
+        page_height if fit_height != 1 else float("inf"),
+        set(_break_ids(ws, "row_breaks")),
+    )
+    order = str(getattr(ws.page_setup, "pageOrder", None) or "downThenOver")
+    if order == "overThenDown":
+        return [
+            PrintArea(r1=top, c1=left, r2=bottom, c2=right)
+            for top, bottom in row_spans
+            for left, right in col_spans
+        ]
+    return [
+        PrintArea(r1=top, c1=left, r2=bottom, c2=right)
+        for left, right in col_spans
+        for top, bottom in row_spans
+    ]
+
+
+def _value_bounds(ws: Worksheet) -> tuple[int, int, int, int] | None:
+    """Return (r1 1-based, c1 0-based, r2, c2) of the non-empty cells."""
+    rows: list[int] = []
+    cols: list[int] = []
+    for row in ws.iter_rows():
+        for cell in row:
+            if cell.value is None or cell.value == "":
+                continue
+            rows.append(int(cell.row))
+            cols.append(int(cell.column) - 1)
+    if not rows:
+        return None
+    return min(rows), min(cols), max(rows), max(cols)
+
+
+def _printable_size(ws: Worksheet) -> tuple[float, float]:
+    """Return the printable page (width, height) in sheet points at 100%."""
+    setup = ws.page_setup
+    paper = _PAPER_SIZES_INCHES.get(
+        _int_or(setup.paperSize, _DEFAULT_PAPER_SIZE),
+        _PAPER_SIZES_INCHES[_DEFAULT_PAPER_SIZE],
+    )
+    width, height = paper
+    if setup.orientation == "landscape":
+        width, height = height, width
+    margins = ws.page_margins
+    left, right, top, bottom = (
+        _float_or(getattr(margins, name, None), default)
+        for name, default in zip(
+            ("left", "right", "top", "bottom"), _DEFAULT_MARGINS_INCHES, strict=True
+        )
+    )
+    scale = _int_or(setup.scale, 100)
+    factor = _POINTS_PER_INCH * 100 / (scale if scale > 0 else 100)
+    return (
+        max(width - left - right, 0.1) * factor,
+        max(height - top - bottom, 0.1) * factor,
+    )
+
+
+def _fit_to_pages(ws: Worksheet) -> tuple[int | None, int | None]:
+    """Return the fit-to-page (width, height) page counts, or None when unset."""
+    props = getattr(ws.sheet_properties, "pageSetUpPr", None)
+    if props is None or not getattr(props, "fitToPage", False):
+        return None, None
+    setup = ws.page_setup
+    return _int_or(setup.fitToWidth, 1), _int_or(setup.fitToHeight, 1)
+
+
+def _break_ids(ws: Worksheet, attr: str) -> list[int]:
+    """Return the manual page break positions (last row/column of a page)."""
+    breaks = getattr(ws, attr, None)
+    return [int(brk.id) for brk in getattr(breaks, "brk", []) if brk.id]
+
+
+def _column_size(dimensions: SheetDimensions) -> Callable[[int], float]:
+    mdw = dimensions.max_digit_width
+    default = column_width_to_points(dimensions.default_column_width, mdw)
+
+    def size(col: int) -> float:
+        width = dimensions.column_widths.get(str(col))
+        return default if width is None else column_width_to_points(width, mdw)
+
+    return size
+
+
+def _row_size(dimensions: SheetDimensions) -> Callable[[int], float]:
+    def size(row: int) -> float:
+        return dimensions.row_heights.get(str(row), dimensions.default_row_height)
+
+    return size
+
+
+def _split_axis(
+    start: int,
+    end: int,
+    size_of: Callable[[int], float],
+    capacity: float,
+    breaks_after: set[int],
+) -> list[tuple[int, int]]:
+    """Split ``start..end`` into spans that fit ``capacity`` or end at a break.
+
+    A row or column larger than a whole page still gets a page of its own.
+    """
+    spans: list[tuple[int, int]] = []
+    span_start = start
+    used = 0.0
+    for index in range(start, end + 1):
+        size = size_of(index)
+        if index > span_start and used + size > capacity:
+            spans.append((span_start, index - 1))
+            span_start = index
+            used = 0.0
+        used += size
+        if index in breaks_after and index < end:
+            spans.append((span_start, index))
+            span_start = index + 1
+            used = 0.0
+    spans.append((span_start, end))
+    return spans
+
+
+def _int_or(value: object, default: int) -> int:
+    try:
+        return int(str(value))
+    except (TypeError, ValueError):
+        return default
+
+
+def _float_or(value: object, default: float) -> float:
+    try:
+        return float(str(value))
+    except (TypeError, ValueError):
+        return default
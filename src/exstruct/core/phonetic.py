@@ -0,0 +1,179 @@
+"""Phonetic guide (furigana) readings of cell text (``include_phonetic``).
+
+Japanese cell text typed through an IME keeps its reading as phonetic runs
+(``rPh``) next to the base text, in shared strings or inline strings. Each run
+covers the base characters ``sb``..``eb``; the reading of a cell is its base
+text with every covered span replaced by the run text, so ``東京都`` with
+the run ``トウキョウ`` over ``東京`` and ``ト`` over ``都`` reads
+``トウキョウト``.
+"""
+
+from __future__ import annotations
+
+from pathlib import Path
+from xml.etree import ElementTree as ET
+import zipfile
+
+from ..models import CellRow
+from ..ooxml.chart import _read_sheet_files, _read_sheets_info
+from ..ooxml.safety import iterparse_part, open_package
+from ..sources import open_source
+from .fast_cells import (
+    _CELL,
+    _INLINE,
+    _MAIN_NS,
+    _ROW,
+    _SI,
+    _TEXT,
+    _VALUE,
+    _column_index,
+    _int_or,
+    _rich_text,
+)
+
+_PHONETIC_RUN = f"{{{_MAIN_NS}}}rPh"
+
+SheetPhonetic = dict[int, dict[str, str]]
+
+
+def extract_phonetic(file_path: Path) -> dict[str, SheetPhonetic]:
+    """Read the phonetic readings of every worksheet's text cells.
+
+    Args:
+        file_path: Workbook path.
+
+    Returns:
+        Mapping of sheet name to ``{row (1-based): {column (0-based str):
+        reading}}``, listing only cells with phonetic runs. Non-OOXML
+        workbooks (.xls) yield an empty mapping.
+
+    Raises:
+        UnsafeWorkbookError: If the package fails the zip-bomb/XML checks.
+    """
+    if not zipfile.is_zipfile(open_source(file_path)):
+        return {}
+    with open_package(file_path) as zf:
+        sheets_info = _read_sheets_info(zf)
+        sheet_files = _read_sheet_files(zf, sheets_info)
+        names = zf.namelist()
+        shared = (
+            _read_shared_readings(zf) if "xl/sharedStrings.xml" in names else []
+        )
+        result: dict[str, SheetPhonetic] = {}
+        for name in sheets_info.values():
+            path = sheet_files.get(name)
+            if path is None or path not in names:
+                continue
+            readings = _read_sheet_readings(zf, path, shared)
+            if readings:
+                result[name] = readings
+    return result
+
+
+def phonetic_reading(elem: ET.Element) -> str | None:
+    """Return the reading of a rich text element (``si`` or ``is``).
+
+    Args:
+        elem: Element holding ``t``/``r`` base text and ``rPh`` runs.
+
+    Returns:
+        Base text with the spans covered by phonetic runs replaced by their
+        text, or None if the element has no non-empty phonetic run.
+    """
+    base = _rich_text(elem)
+    runs: list[tuple[int, int, str]] = []
+    for run in elem.iterfind(_PHONETIC_RUN):
+        text = run.findtext(_TEXT) or ""
+        start = _int_or(run.get("sb"), -1)
+        end = _int_or(run.get("eb"), -1)
+        if text and 0 <= start < end <= len(base):
+            runs.append((start, end, text))
+    if not runs:
+        return None
+    parts: list[str] = []
+    position = 0
+    for start, end, text in sorted(runs):
+        if start < position:
+            continue
+        parts.append(base[position:start])
+        parts.append(text)
+        position = end
+    parts.append(base[position:])
+    return "".join(parts)
+
+
+def apply_phonetic(
+    cell_data: dict[str, list[CellRow]], phonetic: dict[str, SheetPhonetic]
+) -> dict[str, list[CellRow]]:
+    """Attach readings to the cell rows they belong to.
+
+    Args:
+        cell_data: Cell rows per sheet.
+        phonetic: Readings from ``extract_phonetic``.
+
+    Returns:
+        Cell rows with ``phonetic`` set for extracted cells that have a
+        reading; other rows are returned as is.
+    """
+    result: dict[str, list[CellRow]] = {}
+    for sheet_name, rows in cell_data.items():
+        sheet_readings = phonetic.get(sheet_name, {})
+        updated: list[CellRow] = []
+        for row in rows:
+            readings = {
+                key: reading
+                for key, reading in sheet_readings.get(row.r, {}).items()
+                if key in row.c
+            }
+            updated.append(
+                row.model_copy(update={"phonetic": readings}) if readings else row
+            )
+        result[sheet_name] = updated
+    return result
+
+
+def _read_shared_readings(zf: zipfile.ZipFile) -> list[str | None]:
+    readings: list[str | None] = []
+    for _event, elem in iterparse_part(zf, "xl/sharedStrings.xml", ("end",)):
+        if elem.tag == _SI:
+            reading = phonetic_reading(elem)
+            readings.append(
+                reading.replace("x005F_", "") if reading is not None else None
+            )
+            elem.clear()
+    return readings
+
+
+def _read_sheet_readings(
+    zf: zipfile.ZipFile, path: str, shared: list[str | None]
+) -> SheetPhonetic:
+    readings: SheetPhonetic = {}
+    row_index = 0
+    for _event, elem in iterparse_part(zf, path, ("end",)):
+        if elem.tag != _ROW:
+            continue
+        row_index = _int_or(elem.get("r"), row_index + 1)
+        col = 0
+        for cell in elem.iter(_CELL):
+            col = _column_index(cell.get("r"), col + 1)
+            reading = _cell_reading(cell, shared)
+            if reading is not None:
+                readings.setdefault(row_index, {})[str(col - 1)] = reading
+        elem.clear()
+    return readings
+
+
+def _cell_reading(cell: ET.Element, shared: list[str | None]) -> str | None:
+    data_type = cell.get("t")
+    if data_type == "inlineStr":
+        inline = cell.find(_INLINE)
+        return phonetic_reading(inline) if inline is not None else None
+    if data_type != "s":
+        return None
+    try:
+        return shared[int(cell.findtext(_VALUE) or "")]
+    except (ValueError, IndexError):
+        return None
+
+
+__all__ = ["apply_phonetic", "extract_phonetic", "phonetic_reading"]
@@ -2,7 +2,7 @@
 
 from __future__ import annotations
 
-from collections.abc import Callable, Sequence
+from collections.abc import Callable, Collection, Sequence
 from dataclasses import dataclass, field
 import logging
 import os
@@ -12,23 +12,28 @@ from typing import Literal
 
 import xlwings as xw
 
-from ..errors import FallbackReason
+from ..errors import FallbackReason, UnsafeWorkbookError
 from ..models import (
     Arrow,
+    CellError,
     CellRow,
     Chart,
     PrintArea,
     Shape,
+    SheetOutline,
     SmartArt,
     WorkbookData,
 )
 from ..ooxml import get_charts_ooxml, get_shapes_ooxml
+from ..ooxml.units import DEFAULT_DPI, PositionScale, PositionUnit
+from ..sources import is_memory_source
 from .backends.base import RichBackend
 from .backends.com_backend import ComBackend, ComRichBackend
 from .backends.libreoffice_backend import LibreOfficeRichBackend
 from .backends.openpyxl_backend import OpenpyxlBackend
 from .cells import (
     MergedCellRange,
+    SheetDimensions,
     WorkbookColorsMap,
     WorkbookFormulasMap,
     detect_tables,
@@ -38,13 +43,20 @@ from .charts import get_charts
 from .libreoffice import LibreOfficeUnavailableError
 from .logging_utils import log_fallback
 from .modeling import SheetRawData, WorkbookRawData, build_workbook_data
+from .page_layout import infer_print_areas
+from .phonetic import apply_phonetic, extract_phonetic
+from .shape_ranges import ShapeUnit, assign_covered_ranges
 from .shapes import get_shapes_with_position
+from .value_locale import apply_value_locale, resolve_value_locale
 from .workbook import xlwings_workbook
 
 ExtractionMode = Literal["light", "libreoffice", "standard", "verbose"]
 CellData = dict[str, list[CellRow]]
 PrintAreaData = dict[str, list[PrintArea]]
 MergedCellData = dict[str, list[MergedCellRange]]
+DimensionData = dict[str, SheetDimensions]
+CellErrorData = dict[str, list[CellError]]
+OutlineData = dict[str, SheetOutline]
 ShapeData = dict[str, list[Shape | Arrow | SmartArt]]
 ChartData = dict[str, list[Chart]]
 
@@ -68,6 +80,26 @@ class ExtractionInputs:
         use_com_for_formulas: Whether to use COM for formulas extraction.
         include_merged_cells: Whether to include merged cell ranges.
         include_merged_values_in_rows: Whether to keep merged values in rows.
+        include_dimensions: Whether to include row heights and column widths.
+        include_cell_errors: Whether to include cells holding error values.
+        include_outline: Whether to include row/column outline groups.
+        include_covered_ranges: Whether to compute the cell range each shape covers.
+        include_cells: Whether to read cell values (sheets stay listed when False).
+        include_shapes: Whether to extract shapes.
+        include_charts: Whether to extract charts.
+        include_tables: Whether to run table candidate detection.
+        fast_cells: Whether to stream cell values directly from the sheet XML.
+        stable_ids: Whether shape ids come from the drawing (cNvPr) ids.
+        include_shape_paths: Whether OOXML shape geometry keeps its polylines.
+        position_unit: Unit for shape/chart positions; None keeps the backend's
+            native unit (points for COM/LibreOffice, pixels for OOXML).
+        position_dpi: Dots per inch for pixel positions.
+        locale: Locale tag used to re-parse numeric and date text in cells.
+        include_phonetic: Whether to attach phonetic (furigana) readings to rows.
+        infer_print_areas: Whether to infer per-page print areas for sheets
+            without a defined one.
+        sheets: Sheet names to extract; None extracts every sheet. Per-sheet
+            work (cell reading, table detection, assembly) is limited to them.
     """
 
     file_path: Path
@@ -82,6 +114,23 @@ class ExtractionInputs:
     use_com_for_formulas: bool
     include_merged_cells: bool
     include_merged_values_in_rows: bool
+    include_dimensions: bool = False
+    include_cell_errors: bool = False
+    include_outline: bool = False
+    include_covered_ranges: bool = False
+    include_cells: bool = True
+    include_shapes: bool = True
+    include_charts: bool = True
+    include_tables: bool = True
+    fast_cells: bool = False
+    stable_ids: bool = False
+    include_shape_paths: bool = False
+    position_unit: PositionUnit | None = None
+    position_dpi: int = DEFAULT_DPI
+    locale: str | None = None
+    include_phonetic: bool = False
+    infer_print_areas: bool = False
+    sheets: frozenset[str] | None = None
 
 
 @dataclass
@@ -97,6 +146,9 @@ class ExtractionArtifacts:
         shape_data: Extracted shapes per sheet.
         chart_data: Extracted charts per sheet.
         merged_cell_data: Extracted merged cell ranges per sheet.
+        dimension_data: Extracted row heights and column widths per sheet.
+        cell_error_data: Extracted error value cells per sheet.
+        outline_data: Extracted row/column outline groups per sheet.
     """
 
     cell_data: CellData = field(default_factory=dict)
@@ -107,6 +159,9 @@ class ExtractionArtifacts:
     shape_data: ShapeData = field(default_factory=dict)
     chart_data: ChartData = field(default_factory=dict)
     merged_cell_data: MergedCellData = field(default_factory=dict)
+    dimension_data: DimensionData = field(default_factory=dict)
+    cell_error_data: CellErrorData = field(default_factory=dict)
+    outline_data: OutlineData = field(default_factory=dict)
 
 
 ExtractionStep = Callable[[ExtractionInputs, ExtractionArtifacts], None]
@@ -201,6 +256,23 @@ def resolve_extraction_inputs(
     include_formulas_map: bool | None,
     include_merged_cells: bool | None,
     include_merged_values_in_rows: bool,
+    include_dimensions: bool | None = None,
+    include_cell_errors: bool | None = None,
+    include_outline: bool | None = None,
+    include_covered_ranges: bool | None = None,
+    include_cells: bool = True,
+    include_shapes: bool = True,
+    include_charts: bool = True,
+    include_tables: bool = True,
+    fast_cells: bool = False,
+    stable_ids: bool = False,
+    include_shape_paths: bool = False,
+    position_unit: PositionUnit | None = None,
+    position_dpi: int | None = None,
+    locale: str | None = None,
+    include_phonetic: bool = False,
+    infer_print_areas: bool = False,
+    sheets: Collection[str] | None = None,
 ) -> ExtractionInputs:
     """Resolve include flags and normalize inputs for the pipeline.
 
@@ -216,16 +288,40 @@ def resolve_extraction_inputs(
         include_formulas_map: Whether to include formulas map; None uses mode defaults.
         include_merged_cells: Whether to include merged cell ranges; None uses mode defaults.
         include_merged_values_in_rows: Whether to keep merged values in rows.
+        include_dimensions: Whether to include row heights and column widths; None uses mode defaults.
+        include_cell_errors: Whether to include error value cells; None uses mode defaults.
+        include_outline: Whether to include outline groups; None uses mode defaults.
+        include_covered_ranges: Whether to compute shape covered ranges; None
+            follows include_dimensions so no extra workbook load is needed.
+        include_cells: Whether to read cell values.
+        include_shapes: Whether to extract shapes.
+        include_charts: Whether to extract charts.
+        include_tables: Whether to detect table candidates.
+        fast_cells: Whether to use the streaming cell reader.
+        stable_ids: Whether to use drawing (cNvPr) ids as shape ids.
+        include_shape_paths: Whether to keep freeform and ink polylines.
+        position_unit: Unit for shape/chart positions; None keeps backend units.
+        position_dpi: Dots per inch for pixel positions; None defaults to 96.
+        locale: Locale tag for parsing numeric and date text; None disables it.
+        include_phonetic: Whether to attach phonetic readings to cell rows.
+        infer_print_areas: Whether to infer print areas for sheets without one.
+        sheets: Sheet names to extract; None extracts every sheet.
 
     Returns:
         Resolved ExtractionInputs.
 
     Raises:
-        ValueError: If an unsupported mode is provided.
+        ValueError: If an unsupported mode or locale, or a non-positive DPI is
+            provided.
     """
     allowed_modes: set[str] = {"light", "libreoffice", "standard", "verbose"}
     if mode not in allowed_modes:
         raise ValueError(f"Unsupported mode: {mode}")
+    resolved_dpi = position_dpi if position_dpi is not None else DEFAULT_DPI
+    if resolved_dpi <= 0:
+        raise ValueError(f"position_dpi must be positive: {resolved_dpi}")
+    if locale is not None:
+        resolve_value_locale(locale)
 
     normalized_file_path = file_path if isinstance(file_path, Path) else Path(file_path)
     file_suffix = normalized_file_path.suffix.lower()
@@ -266,6 +362,20 @@ def resolve_extraction_inputs(
     )
     if not include_merged_values_in_rows:
         resolved_merged_cells = True
+    resolved_dimensions = (
+        include_dimensions if include_dimensions is not None else mode == "verbose"
+    )
+    resolved_cell_errors = (
+        include_cell_errors if include_cell_errors is not None else mode != "light"
+    )
+    resolved_outline = (
+        include_outline if include_outline is not None else mode == "verbose"
+    )
+    resolved_covered_ranges = (
+        include_covered_ranges
+        if include_covered_ranges is not None
+        else resolved_dimensions
+    )
 
     return ExtractionInputs(
         file_path=normalized_file_path,
@@ -280,6 +390,23 @@ def resolve_extraction_inputs(
         use_com_for_formulas=use_com_for_formulas,
         include_merged_cells=resolved_merged_cells,
         include_merged_values_in_rows=include_merged_values_in_rows,
+        include_dimensions=resolved_dimensions,
+        include_cell_errors=resolved_cell_errors,
+        include_outline=resolved_outline,
+        include_covered_ranges=resolved_covered_ranges,
+        include_cells=include_cells,
+        include_shapes=include_shapes,
+        include_charts=include_charts,
+        include_tables=include_tables,
+        fast_cells=fast_cells,
+        stable_ids=stable_ids,
+        include_shape_paths=include_shape_paths,
+        position_unit=position_unit,
+        position_dpi=resolved_dpi,
+        locale=locale,
+        include_phonetic=include_phonetic,
+        infer_print_areas=infer_print_areas,
+        sheets=None if sheets is None else frozenset(sheets),
     )
 
 
@@ -337,6 +464,21 @@ def build_pre_com_pipeline(inputs: ExtractionInputs) -> list[ExtractionStep]:
                 step=step_extract_merged_cells_openpyxl,
                 enabled=lambda _inputs: _inputs.include_merged_cells,
             ),
+            StepConfig(
+                name="dimensions_openpyxl",
+                step=step_extract_dimensions_openpyxl,
+                enabled=lambda _inputs: _inputs.include_dimensions,
+            ),
+            StepConfig(
+                name="cell_errors_openpyxl",
+                step=step_extract_cell_errors_openpyxl,
+                enabled=lambda _inputs: _inputs.include_cell_errors,
+            ),
+            StepConfig(
+                name="outline_openpyxl",
+                step=step_extract_outline_openpyxl,
+                enabled=lambda _inputs: _inputs.include_outline,
+            ),
         ),
         "libreoffice": (
             StepConfig(
@@ -365,6 +507,21 @@ def build_pre_com_pipeline(inputs: ExtractionInputs) -> list[ExtractionStep]:
                 step=step_extract_merged_cells_openpyxl,
                 enabled=lambda _inputs: _inputs.include_merged_cells,
             ),
+            StepConfig(
+                name="dimensions_openpyxl",
+                step=step_extract_dimensions_openpyxl,
+                enabled=lambda _inputs: _inputs.include_dimensions,
+            ),
+            StepConfig(
+                name="cell_errors_openpyxl",
+                step=step_extract_cell_errors_openpyxl,
+                enabled=lambda _inputs: _inputs.include_cell_errors,
+            ),
+            StepConfig(
+                name="outline_openpyxl",
+                step=step_extract_outline_openpyxl,
+                enabled=lambda _inputs: _inputs.include_outline,
+            ),
         ),
         "standard": (
             StepConfig(
@@ -394,6 +551,21 @@ def build_pre_com_pipeline(inputs: ExtractionInputs) -> list[ExtractionStep]:
                 step=step_extract_merged_cells_openpyxl,
                 enabled=lambda _inputs: _inputs.include_merged_cells,
             ),
+            StepConfig(
+                name="dimensions_openpyxl",
+                step=step_extract_dimensions_openpyxl,
+                enabled=lambda _inputs: _inputs.include_dimensions,
+            ),
+            StepConfig(
+                name="cell_errors_openpyxl",
+                step=step_extract_cell_errors_openpyxl,
+                enabled=lambda _inputs: _inputs.include_cell_errors,
+            ),
+            StepConfig(
+                name="outline_openpyxl",
+                step=step_extract_outline_openpyxl,
+                enabled=lambda _inputs: _inputs.include_outline,
+            ),
         ),
         "verbose": (
             StepConfig(
@@ -423,6 +595,21 @@ def build_pre_com_pipeline(inputs: ExtractionInputs) -> list[ExtractionStep]:
                 step=step_extract_merged_cells_openpyxl,
                 enabled=lambda _inputs: _inputs.include_merged_cells,
             ),
+            StepConfig(
+                name="dimensions_openpyxl",
+                step=step_extract_dimensions_openpyxl,
+                enabled=lambda _inputs: _inputs.include_dimensions,
+            ),
+            StepConfig(
+                name="cell_errors_openpyxl",
+                step=step_extract_cell_errors_openpyxl,
+                enabled=lambda _inputs: _inputs.include_cell_errors,
+            ),
+            StepConfig(
+                name="outline_openpyxl",
+                step=step_extract_outline_openpyxl,
+                enabled=lambda _inputs: _inputs.include_outline,
+            ),
         ),
     }
     steps: list[ExtractionStep] = []
@@ -447,12 +634,14 @@ def build_com_pipeline(inputs: ExtractionInputs) -> list[ComExtractionStep]:
         ComStepConfig(
             name="shapes_com",
             step=step_extract_shapes_com,
-            enabled=lambda _inputs: _inputs.mode in {"standard", "verbose"},
+            enabled=lambda _inputs: _inputs.mode in {"standard", "verbose"}
+            and _inputs.include_shapes,
         ),
         ComStepConfig(
             name="charts_com",
             step=step_extract_charts_com,
-            enabled=lambda _inputs: _inputs.mode in {"standard", "verbose"},
+            enabled=lambda _inputs: _inputs.mode in {"standard", "verbose"}
+            and _inputs.include_charts,
         ),
         ComStepConfig(
             name="print_areas_com",
@@ -566,12 +755,36 @@ def step_extract_cells(
 ) -> None:
     """Extract cell rows, optionally including hyperlinks.
 
+    When cells are disabled, only the sheet names are read so that every sheet
+    still appears (with empty rows) in the output. With ``inputs.sheets`` only
+    those sheets are read, and later steps assemble just the sheets found
+    here. With a locale, text values are re-parsed as locale-formatted numbers
+    and dates; with ``include_phonetic``, rows get the phonetic readings of
+    their cells.
+
     Args:
         inputs: Pipeline inputs.
         artifacts: Artifact container to update.
     """
     backend = OpenpyxlBackend(inputs.file_path)
-    artifacts.cell_data = backend.extract_cells(include_links=inputs.include_cell_links)
+    if not inputs.include_cells:
+        artifacts.cell_data = {
+            name: []
+            for name in backend.extract_sheet_names()
+            if inputs.sheets is None or name in inputs.sheets
+        }
+        return
+    artifacts.cell_data = backend.extract_cells(
+        include_links=inputs.include_cell_links,
+        fast=inputs.fast_cells,
+        sheets=inputs.sheets,
+    )
+    if inputs.locale is not None:
+        artifacts.cell_data = apply_value_locale(artifacts.cell_data, inputs.locale)
+    if inputs.include_phonetic:
+        artifacts.cell_data = apply_phonetic(
+            artifacts.cell_data, extract_phonetic(inputs.file_path)
+        )
 
 
 def step_extract_print_areas_openpyxl(
@@ -580,12 +793,26 @@ def step_extract_print_areas_openpyxl(
     """
     Extract print areas from the workbook and populate artifacts.print_area_data.
 
+    With ``infer_print_areas``, sheets without a defined print area get one
+    area per page inferred from their used range and page setup.
+
     Parameters:
         inputs (ExtractionInputs): Pipeline inputs containing the file path and extraction options.
         artifacts (ExtractionArtifacts): Mutable artifact container; `artifacts.print_area_data` will be set to the extracted print area mapping.
     """
     backend = OpenpyxlBackend(inputs.file_path)
     artifacts.print_area_data = backend.extract_print_areas()
+    if inputs.infer_print_areas:
+        try:
+            inferred = infer_print_areas(
+                inputs.file_path, skip=set(artifacts.print_area_data)
+            )
+        except Exception as exc:
+            logger.warning(
+                "Print area inference failed; skipping inferred areas. (%r)", exc
+            )
+            return
+        artifacts.print_area_data = {**artifacts.print_area_data, **inferred}
 
 
 def step_extract_formulas_map_openpyxl(
@@ -639,6 +866,45 @@ def step_extract_merged_cells_openpyxl(
     artifacts.merged_cell_data = backend.extract_merged_cells()
 
 
+def step_extract_dimensions_openpyxl(
+    inputs: ExtractionInputs, artifacts: ExtractionArtifacts
+) -> None:
+    """Extract row heights and column widths via openpyxl.
+
+    Args:
+        inputs: Pipeline inputs.
+        artifacts: Artifact container to update.
+    """
+    backend = OpenpyxlBackend(inputs.file_path)
+    artifacts.dimension_data = backend.extract_dimensions()
+
+
+def step_extract_cell_errors_openpyxl(
+    inputs: ExtractionInputs, artifacts: ExtractionArtifacts
+) -> None:
+    """Extract cells holding error values via openpyxl.
+
+    Args:
+        inputs: Pipeline inputs.
+        artifacts: Artifact container to update.
+    """
+    backend = OpenpyxlBackend(inputs.file_path)
+    artifacts.cell_error_data = backend.extract_cell_errors()
+
+
+def step_extract_outline_openpyxl(
+    inputs: ExtractionInputs, artifacts: ExtractionArtifacts
+) -> None:
+    """Extract row/column outline (grouping) levels via openpyxl.
+
+    Args:
+        inputs: Pipeline inputs.
+        artifacts: Artifact container to update.
+    """
+    backend = OpenpyxlBackend(inputs.file_path)
+    artifacts.outline_data = backend.extract_outlines()
+
+
 def step_extract_shapes_com(
     inputs: ExtractionInputs, artifacts: ExtractionArtifacts, workbook: xw.Book
 ) -> None:
@@ -649,7 +915,9 @@ def step_extract_shapes_com(
         artifacts: Artifact container to update.
         workbook: xlwings workbook instance.
     """
-    artifacts.shape_data = get_shapes_with_position(workbook, mode=inputs.mode)
+    artifacts.shape_data = get_shapes_with_position(
+        workbook, mode=inputs.mode, stable_ids=inputs.stable_ids
+    )
 
 
 def step_extract_charts_com(
@@ -905,6 +1173,10 @@ def collect_sheet_raw_data(
     auto_page_break_data: PrintAreaData | None = None,
     formulas_map_data: WorkbookFormulasMap | None = None,
     colors_map_data: WorkbookColorsMap | None = None,
+    dimension_data: DimensionData | None = None,
+    cell_error_data: CellErrorData | None = None,
+    outline_data: OutlineData | None = None,
+    include_tables: bool = True,
 ) -> dict[str, SheetRawData]:
     """
     Collect per-sheet raw extraction data and assemble SheetRawData for each sheet.
@@ -923,6 +1195,10 @@ def collect_sheet_raw_data(
         auto_page_break_data (PrintAreaData | None): Optional auto page-break areas keyed by sheet name.
         formulas_map_data (WorkbookFormulasMap | None): Optional per-sheet formulas map to include in SheetRawData.
         colors_map_data (WorkbookColorsMap | None): Optional per-sheet colors map to include in SheetRawData.
+        dimension_data (DimensionData | None): Optional row heights and column widths keyed by sheet name.
+        cell_error_data (CellErrorData | None): Optional error value cells keyed by sheet name.
+        outline_data (OutlineData | None): Optional outline groups keyed by sheet name.
+        include_tables (bool): If False, skip table candidate detection.
 
     Returns:
         dict[str, SheetRawData]: Mapping from sheet name to the assembled SheetRawData.
@@ -940,7 +1216,7 @@ def collect_sheet_raw_data(
             rows=filtered_rows,
             shapes=shape_data.get(sheet_name, []),
             charts=chart_data.get(sheet_name, []) if mode != "light" else [],
-            table_candidates=detect_tables(sheet, mode=mode),
+            table_candidates=detect_tables(sheet, mode=mode) if include_tables else [],
             print_areas=print_area_data.get(sheet_name, []) if print_area_data else [],
             auto_print_areas=auto_page_break_data.get(sheet_name, [])
             if auto_page_break_data
@@ -948,6 +1224,9 @@ def collect_sheet_raw_data(
             formulas_map=_resolve_sheet_formulas_map(formulas_map_data, sheet_name),
             colors_map=_resolve_sheet_colors_map(colors_map_data, sheet_name),
             merged_cells=merged_cells,
+            dimensions=dimension_data.get(sheet_name) if dimension_data else None,
+            errors=cell_error_data.get(sheet_name, []) if cell_error_data else [],
+            outline=outline_data.get(sheet_name) if outline_data else None,
         )
         result[sheet_name] = sheet_raw
     return result
@@ -960,10 +1239,10 @@ def resolve_rich_backend(
 ) -> RichBackend:
     """Resolve the rich extraction backend for the requested mode."""
     if inputs.mode == "libreoffice":
-        return LibreOfficeRichBackend(inputs.file_path)
+        return LibreOfficeRichBackend(inputs.file_path, stable_ids=inputs.stable_ids)
     if workbook is None:
         raise ValueError("COM workbook is required for COM-backed rich extraction.")
-    return ComRichBackend(workbook)
+    return ComRichBackend(workbook, stable_ids=inputs.stable_ids)
 
 
 def _run_libreoffice_pipeline(
@@ -989,7 +1268,8 @@ def _run_libreoffice_pipeline(
             FallbackReason.LIBREOFFICE_PIPELINE_FAILED,
         )
     try:
-        artifacts.shape_data = rich_backend.extract_shapes(mode=rich_mode)
+        if inputs.include_shapes:
+            artifacts.shape_data = rich_backend.extract_shapes(mode=rich_mode)
     except LibreOfficeUnavailableError as exc:
         artifacts.shape_data = {}
         artifacts.chart_data = {}
@@ -1005,7 +1285,8 @@ def _run_libreoffice_pipeline(
             FallbackReason.LIBREOFFICE_PIPELINE_FAILED,
         )
     try:
-        artifacts.chart_data = rich_backend.extract_charts(mode=rich_mode)
+        if inputs.include_charts:
+            artifacts.chart_data = rich_backend.extract_charts(mode=rich_mode)
     except LibreOfficeUnavailableError as exc:
         artifacts.chart_data = {}
         return fallback(
@@ -1093,16 +1374,28 @@ def run_extraction_pipeline(inputs: ExtractionInputs) -> PipelineResult:
             "SKIP_COM_TESTS is set; skipping COM/xlwings access.",
             FallbackReason.SKIP_COM_TESTS,
         )
+    if is_memory_source(inputs.file_path):
+        return _fallback(
+            "Workbook is held in memory; Excel COM needs a file on disk.",
+            FallbackReason.COM_UNAVAILABLE,
+        )
 
     try:
         with xlwings_workbook(inputs.file_path) as workbook:
             state.com_attempted = True
             try:
                 run_com_pipeline(plan.com_steps, inputs, artifacts, workbook)
+                shape_data, chart_data = _convert_point_positions(
+                    inputs,
+                    _annotate_covered_ranges(
+                        inputs, artifacts, artifacts.shape_data, unit="points"
+                    ),
+                    artifacts.chart_data,
+                )
                 raw_sheets = collect_sheet_raw_data(
                     cell_data=artifacts.cell_data,
-                    shape_data=artifacts.shape_data,
-                    chart_data=artifacts.chart_data,
+                    shape_data=shape_data,
+                    chart_data=chart_data,
                     merged_cell_data=artifacts.merged_cell_data,
                     workbook=workbook,
                     mode=inputs.mode,
@@ -1115,6 +1408,12 @@ def run_extraction_pipeline(inputs: ExtractionInputs) -> PipelineResult:
                     else None,
                     formulas_map_data=artifacts.formulas_map_data,
                     colors_map_data=artifacts.colors_map_data,
+                    dimension_data=artifacts.dimension_data
+                    if inputs.include_dimensions
+                    else None,
+                    cell_error_data=artifacts.cell_error_data,
+                    outline_data=artifacts.outline_data,
+                    include_tables=inputs.include_tables,
                 )
                 raw_workbook = WorkbookRawData(
                     book_name=inputs.file_path.name, sheets=raw_sheets
@@ -1137,14 +1436,122 @@ def run_extraction_pipeline(inputs: ExtractionInputs) -> PipelineResult:
         )
 
 
+def _annotate_covered_ranges(
+    inputs: ExtractionInputs,
+    artifacts: ExtractionArtifacts,
+    shape_data: ShapeData,
+    *,
+    unit: ShapeUnit,
+    dpi: int = DEFAULT_DPI,
+) -> ShapeData:
+    """Attach the covered cell range to every extracted shape.
+
+    Runs only when ``include_covered_ranges`` is set. Row heights and column
+    widths come from the dimension step; when it did not run (covered ranges
+    requested explicitly without ``include_dimensions``) they are loaded via
+    openpyxl here, and defaults are used if that fails.
+
+    Args:
+        inputs: Pipeline inputs.
+        artifacts: Artifact container holding dimension data.
+        shape_data: Shapes keyed by sheet name.
+        unit: Unit of the shape coordinates.
+        dpi: Dots per inch of pixel coordinates.
+
+    Returns:
+        Shape data with ``covered_range`` populated.
+    """
+    if not inputs.include_covered_ranges or not any(shape_data.values()):
+        return shape_data
+    if not artifacts.dimension_data:
+        backend = OpenpyxlBackend(inputs.file_path)
+        artifacts.dimension_data = backend.extract_dimensions()
+    return {
+        sheet_name: assign_covered_ranges(
+            shapes, artifacts.dimension_data.get(sheet_name), unit=unit, dpi=dpi
+        )
+        for sheet_name, shapes in shape_data.items()
+    }
+
+
+_BOX_FIELDS = ("l", "t", "w", "h")
+_ARROW_FIELDS = (*_BOX_FIELDS, "begin_x", "begin_y", "end_x", "end_y")
+
+
+def _convert_point_positions(
+    inputs: ExtractionInputs, shape_data: ShapeData, chart_data: ChartData
+) -> tuple[ShapeData, ChartData]:
+    """Convert COM/LibreOffice positions (points) to ``inputs.position_unit``.
+
+    Args:
+        inputs: Pipeline inputs.
+        shape_data: Shapes keyed by sheet name, in points.
+        chart_data: Charts keyed by sheet name, in points.
+
+    Returns:
+        Shape and chart data in the requested unit (unchanged when no unit,
+        or points, was requested).
+    """
+    if inputs.position_unit is None or inputs.position_unit == "points":
+        return shape_data, chart_data
+    scale = PositionScale(inputs.position_unit, inputs.position_dpi)
+    converted_shapes: ShapeData = {
+        sheet_name: [
+            shape.model_copy(
+                update=_scaled_fields(
+                    shape,
+                    _ARROW_FIELDS if isinstance(shape, Arrow) else _BOX_FIELDS,
+                    scale,
+                )
+            )
+            for shape in shapes
+        ]
+        for sheet_name, shapes in shape_data.items()
+    }
+    converted_charts: ChartData = {
+        sheet_name: [
+            chart.model_copy(update=_scaled_fields(chart, _BOX_FIELDS, scale))
+            for chart in charts
+        ]
+        for sheet_name, charts in chart_data.items()
+    }
+    return converted_shapes, converted_charts
+
+
+def _scaled_fields(
+    model: Shape | Arrow | SmartArt | Chart,
+    names: tuple[str, ...],
+    scale: PositionScale,
+) -> dict[str, int | None]:
+    """Return the named point fields of a model converted with ``scale``."""
+    update: dict[str, int | None] = {}
+    for name in names:
+        value = getattr(model, name)
+        update[name] = None if value is None else scale.from_points(value)
+    return update
+
+
+def _ooxml_position_scale(inputs: ExtractionInputs) -> PositionScale:
+    """Return the output scale for OOXML positions (pixels unless overridden)."""
+    return PositionScale(inputs.position_unit or "pixels", inputs.position_dpi)
+
+
 def _extract_shapes_ooxml_fallback(
-    file_path: Path, mode: ExtractionMode
+    file_path: Path,
+    mode: ExtractionMode,
+    *,
+    stable_ids: bool = False,
+    scale: PositionScale = PositionScale(),
+    include_paths: bool = False,
 ) -> ShapeData:
     """Extract shapes using OOXML parser as fallback.
 
     Args:
         file_path: Path to the Excel workbook.
         mode: Extraction mode.
+        stable_ids: Use drawing (cNvPr) ids as shape ids.
+        scale: Output unit for positions and sizes.
+        include_paths: Keep freeform and ink polylines in ``Shape.geometry``.
 
     Returns:
         Shape data per sheet.
@@ -1152,25 +1559,37 @@ def _extract_shapes_ooxml_fallback(
     if mode == "light":
         return {}
     try:
-        raw_shapes = get_shapes_ooxml(file_path, mode=mode)
+        raw_shapes = get_shapes_ooxml(
+            file_path,
+            mode=mode,
+            stable_ids=stable_ids,
+            scale=scale,
+            include_paths=include_paths,
+        )
         # Convert dict[str, list[Shape]] to ShapeData (dict[str, list[Shape | Arrow | SmartArt]])
         result: ShapeData = {}
         for sheet_name, shapes in raw_shapes.items():
             result[sheet_name] = list(shapes)
         return result
+    except UnsafeWorkbookError:
+        raise
     except Exception as exc:
         logger.warning("OOXML shape extraction failed: %s", exc)
         return {}
 
 
 def _extract_charts_ooxml_fallback(
-    file_path: Path, mode: ExtractionMode
+    file_path: Path,
+    mode: ExtractionMode,
+    *,
+    scale: PositionScale = PositionScale(),
 ) -> ChartData:
     """Extract charts using OOXML parser as fallback.
 
     Args:
         file_path: Path to the Excel workbook.
         mode: Extraction mode.
+        scale: Output unit for positions and sizes.
 
     Returns:
         Chart data per sheet.
@@ -1178,7 +1597,9 @@ def _extract_charts_ooxml_fallback(
     if mode == "light":
         return {}
     try:
-        return get_charts_ooxml(file_path, mode=mode)
+        return get_charts_ooxml(file_path, mode=mode, scale=scale)
+    except UnsafeWorkbookError:
+        raise
     except Exception as exc:
         logger.warning("OOXML chart extraction failed: %s", exc)
         return {}
@@ -1220,11 +1641,42 @@ def build_cells_tables_workbook(
     ):
         formulas_map_data = backend.extract_formulas_map()
 
+    # Shapes and charts already in artifacts come from COM/LibreOffice and use
+    # points; they are converted once their covered ranges are known.
+    artifacts.shape_data, artifacts.chart_data = _convert_point_positions(
+        inputs,
+        _annotate_covered_ranges(
+            inputs, artifacts, artifacts.shape_data, unit="points"
+        ),
+        artifacts.chart_data,
+    )
+
     # Extract shapes and charts via OOXML parser (cross-platform fallback)
     # Populate artifacts so include_rich_artifacts can use them
     if not include_rich_artifacts and inputs.mode != "light":
-        ooxml_shapes = _extract_shapes_ooxml_fallback(inputs.file_path, inputs.mode)
-        ooxml_charts = _extract_charts_ooxml_fallback(inputs.file_path, inputs.mode)
+        scale = _ooxml_position_scale(inputs)
+        ooxml_shapes = (
+            _annotate_covered_ranges(
+                inputs,
+                artifacts,
+                _extract_shapes_ooxml_fallback(
+                    inputs.file_path,
+                    inputs.mode,
+                    stable_ids=inputs.stable_ids,
+                    scale=scale,
+                    include_paths=inputs.include_shape_paths,
+                ),
+                unit=scale.unit,
+                dpi=scale.dpi,
+            )
+            if inputs.include_shapes
+            else {}
+        )
+        ooxml_charts = (
+            _extract_charts_ooxml_fallback(inputs.file_path, inputs.mode, scale=scale)
+            if inputs.include_charts
+            else {}
+        )
         if ooxml_shapes:
             for sn, sv in ooxml_shapes.items():
                 if sn not in artifacts.shape_data:
@@ -1244,7 +1696,11 @@ def build_cells_tables_workbook(
         sheet_formulas = (
             formulas_map_data.get_sheet(sheet_name) if formulas_map_data else None
         )
-        tables = backend.detect_tables(sheet_name, mode=inputs.mode)
+        tables = (
+            backend.detect_tables(sheet_name, mode=inputs.mode)
+            if inputs.include_tables
+            else []
+        )
         logger.info(
             "detect_tables for %s completed in %.2fs",
             sheet_name,
@@ -1272,6 +1728,11 @@ def build_cells_tables_workbook(
             formulas_map=sheet_formulas.formulas_map if sheet_formulas else {},
             colors_map=sheet_colors.colors_map if sheet_colors else {},
             merged_cells=merged_cells,
+            dimensions=artifacts.dimension_data.get(sheet_name)
+            if inputs.include_dimensions
+            else None,
+            errors=artifacts.cell_error_data.get(sheet_name, []),
+            outline=artifacts.outline_data.get(sheet_name),
         )
     raw = WorkbookRawData(book_name=inputs.file_path.name, sheets=sheets)
     return build_workbook_data(raw)
@@ -1,9 +1,13 @@
 from __future__ import annotations
 
+from collections.abc import Mapping, Sequence
 from dataclasses import dataclass
 
 from openpyxl.utils import range_boundaries
 
+from ..errors import ConfigError
+from ..models import CellRow, SheetData, col_alpha_to_index
+
 
 @dataclass(frozen=True)
 class RangeBounds:
@@ -35,7 +39,8 @@ def parse_range_zero_based(range_str: str) -> RangeBounds | None:
     if not cleaned:
         return None
     if "!" in cleaned:
-        cleaned = cleaned.split("!", 1)[1]
+        # Quoted sheet names may contain "!", the cell reference never does.
+        cleaned = cleaned.rsplit("!", 1)[1]
     try:
         min_col, min_row, max_col, max_row = range_boundaries(cleaned)
     except Exception:
@@ -46,3 +51,147 @@ def parse_range_zero_based(range_str: str) -> RangeBounds | None:
         r2=max_row - 1,
         c2=max_col - 1,
     )
+
+
+def split_range_list(raw: str) -> list[str]:
+    """Split a comma-separated range list, keeping quoted sheet names intact.
+
+    Commas inside single-quoted sheet names (``'Q1, Q2'!A1:B2``) do not split,
+    and a doubled quote (``''``) inside a quoted name is kept as is.
+
+    Args:
+        raw: Range list such as a print area or a defined name's formula.
+
+    Returns:
+        Non-empty, stripped parts in order.
+    """
+    parts: list[str] = []
+    buf: list[str] = []
+    in_quote = False
+    i = 0
+    while i < len(raw):
+        ch = raw[i]
+        if ch == "'":
+            if in_quote and i + 1 < len(raw) and raw[i + 1] == "'":
+                buf.append("''")
+                i += 2
+                continue
+            in_quote = not in_quote
+            buf.append(ch)
+            i += 1
+            continue
+        if ch == "," and not in_quote:
+            parts.append("".join(buf).strip())
+            buf = []
+            i += 1
+            continue
+        buf.append(ch)
+        i += 1
+    if buf:
+        parts.append("".join(buf).strip())
+    return [p for p in parts if p]
+
+
+def resolve_range_targets(
+    targets: Sequence[str], sheets: Mapping[str, SheetData]
+) -> dict[str, list[RangeBounds]]:
+    """Resolve extraction targets to zero-based bounds per sheet.
+
+    Args:
+        targets: ``name:<defined name>`` (matched case-insensitively against
+            ``SheetData.named_ranges``, every area of the name) or
+            ``<Sheet>!A1:D20``.
+        sheets: Extracted sheets.
+
+    Returns:
+        Mapping of sheet name to the bounds targeted on it.
+
+    Raises:
+        ConfigError: If a name is not defined, a sheet does not exist, or a
+            target is not a range.
+    """
+    resolved: dict[str, list[RangeBounds]] = {}
+    for target in targets:
+        if target.startswith("name:"):
+            name = target.removeprefix("name:").strip()
+            named = _named_targets(name, sheets)
+            if not named:
+                raise ConfigError(f"Defined name {name!r} does not refer to a range.")
+            for sheet_name, bounds in named:
+                resolved.setdefault(sheet_name, []).append(bounds)
+            continue
+        sheet_name, _, ref = target.rpartition("!")
+        if sheet_name.startswith("'") and sheet_name.endswith("'"):
+            sheet_name = sheet_name[1:-1].replace("''", "'")
+        target_bounds = parse_range_zero_based(ref) if sheet_name else None
+        if target_bounds is None:
+            raise ConfigError(
+                f"Invalid range target {target!r}; use 'name:<Name>' or "
+                "'<Sheet>!A1:D20'."
+            )
+        if sheet_name not in sheets:
+            raise ConfigError(f"Sheet {sheet_name!r} of {target!r} was not found.")
+        resolved.setdefault(sheet_name, []).append(target_bounds)
+    return resolved
+
+
+def _named_targets(
+    name: str, sheets: Mapping[str, SheetData]
+) -> list[tuple[str, RangeBounds]]:
+    """Areas of every named range called ``name`` (case-insensitive)."""
+    return [
+        (
+            sheet_name,
+            RangeBounds(r1=named.r1 - 1, c1=named.c1, r2=named.r2 - 1, c2=named.c2),
+        )
+        for sheet_name, sheet in sheets.items()
+        for named in sheet.named_ranges
+        if named.name.casefold() == name.casefold()
+    ]
+
+
+def clip_rows(rows: Sequence[CellRow], areas: Sequence[RangeBounds]) -> list[CellRow]:
+    """Keep only the cells of rows that fall inside any of the areas.
+
+    Works with numeric and alpha (``alpha_col``) column keys; links and
+    phonetic readings are clipped with their cells.
+
+    Args:
+        rows: Extracted rows (1-based ``r``).
+        areas: Zero-based bounds.
+
+    Returns:
+        Clipped rows; rows left without cells are dropped.
+    """
+    clipped: list[CellRow] = []
+    for row in rows:
+        spans = [(a.c1, a.c2) for a in areas if a.r1 <= row.r - 1 <= a.r2]
+        cells = {k: v for k, v in row.c.items() if _in_spans(k, spans)}
+        if not cells:
+            continue
+        clipped.append(
+            CellRow(
+                r=row.r,
+                c=cells,
+                links={k: v for k, v in row.links.items() if _in_spans(k, spans)}
+                if row.links
+                else None,
+                phonetic={
+                    k: v for k, v in row.phonetic.items() if _in_spans(k, spans)
+                }
+                if row.phonetic
+                else None,
+            )
+        )
+    return clipped
+
+
+def column_index(key: str) -> int:
+    """Zero-based column index of a numeric or alpha (``alpha_col``) key."""
+    return int(key) if key.isdigit() else col_alpha_to_index(key)
+
+
+def _in_spans(key: str, spans: Sequence[tuple[int, int]]) -> bool:
+    """Whether a numeric or alpha column key lies in any (c1, c2) span."""
+    col = column_index(key)
+    return any(c1 <= col <= c2 for c1, c2 in spans)
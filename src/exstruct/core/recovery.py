@@ -0,0 +1,162 @@
+"""Best-effort recovery of partially corrupted OOXML packages."""
+
+from __future__ import annotations
+
+from dataclasses import dataclass, field
+import logging
+from pathlib import Path
+import posixpath
+import re
+from xml.etree import ElementTree as ET
+from zipfile import ZIP_DEFLATED, BadZipFile, ZipFile
+import zlib
+
+from ..errors import ExtractionError
+from ..ooxml.chart import _read_sheet_files, _read_sheets_info
+from ..ooxml.safety import open_package, parse_xml, read_part
+
+logger = logging.getLogger(__name__)
+
+_EMPTY_WORKSHEET = (
+    b'<?xml version="1.0" encoding="UTF-8" standalone="yes"?>\n'
+    b'<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">'
+    b"<sheetData/></worksheet>"
+)
+_CONTENT_TYPES = "[Content_Types].xml"
+_REQUIRED_PARTS = (_CONTENT_TYPES, "xl/workbook.xml", "xl/_rels/workbook.xml.rels")
+_XML_SUFFIXES = (".xml", ".rels")
+_RELATIONSHIP = re.compile(rb"<Relationship\b[^>]*/>")
+_OVERRIDE = re.compile(rb"<Override\b[^>]*/>")
+_ATTR = r'\b{}="([^"]*)"'
+
+
+@dataclass
+class RecoveryReport:
+    """Outcome of repairing a package.
+
+    Attributes:
+        warnings: Human-readable description of every skipped part.
+        broken_sheets: Names of sheets whose worksheet part was unusable.
+    """
+
+    warnings: list[str] = field(default_factory=list)
+    broken_sheets: list[str] = field(default_factory=list)
+
+
+def repair_package(
+    file_path: Path, dest_dir: Path
+) -> tuple[Path, RecoveryReport] | None:
+    """Write a readable copy of a package with corrupted parts removed.
+
+    Unreadable zip entries and malformed XML parts are dropped together with
+    the relationships and content-type overrides pointing at them; corrupted
+    worksheets are replaced by empty ones and reported in ``broken_sheets``.
+
+    Args:
+        file_path: Workbook path (xlsx/xlsm).
+        dest_dir: Directory receiving the repaired copy (same file name).
+
+    Returns:
+        Repaired path and report, or None when every part is readable.
+
+    Raises:
+        ExtractionError: If the workbook structure itself is corrupted.
+        UnsafeWorkbookError: If the package fails the zip-bomb checks.
+    """
+    with open_package(file_path) as zf:
+        parts, broken = _read_parts(zf)
+        if not broken:
+            return None
+        for name in _REQUIRED_PARTS:
+            if name in broken:
+                raise ExtractionError(
+                    f"Cannot recover {file_path.name}: {name} is corrupted."
+                )
+        sheet_files = _read_sheet_files(zf, _read_sheets_info(zf))
+    sheet_parts = {path: sheet for sheet, path in sheet_files.items()}
+    report = RecoveryReport()
+    for name, reason in broken.items():
+        sheet = sheet_parts.get(name)
+        if sheet is not None:
+            parts[name] = _EMPTY_WORKSHEET
+            report.broken_sheets.append(sheet)
+            report.warnings.append(f"Skipped sheet '{sheet}' ({name}): {reason}")
+        else:
+            report.warnings.append(f"Skipped part {name}: {reason}")
+    dropped = {name for name in broken if name not in sheet_parts}
+    _unlink_dropped_parts(parts, dropped)
+    repaired = dest_dir / file_path.name
+    with ZipFile(repaired, "w", compression=ZIP_DEFLATED) as out:
+        for name, data in parts.items():
+            out.writestr(name, data)
+    for warning in report.warnings:
+        logger.warning(warning)
+    return repaired, report
+
+
+def _read_parts(zf: ZipFile) -> tuple[dict[str, bytes], dict[str, str]]:
+    """Read every part; return readable contents and reasons for broken ones."""
+    parts: dict[str, bytes] = {}
+    broken: dict[str, str] = {}
+    for info in zf.infolist():
+        if info.is_dir():
+            continue
+        try:
+            data = read_part(zf, info.filename)
+        except (BadZipFile, zlib.error, EOFError, OSError) as exc:
+            broken[info.filename] = f"unreadable zip entry ({exc})"
+            continue
+        if info.filename.endswith(_XML_SUFFIXES):
+            try:
+                parse_xml(data)
+            except ET.ParseError as exc:
+                broken[info.filename] = f"malformed XML ({exc})"
+                continue
+        parts[info.filename] = data
+    return parts, broken
+
+
+def _unlink_dropped_parts(parts: dict[str, bytes], dropped: set[str]) -> None:
+    """Remove relationships and content-type overrides targeting dropped parts."""
+    if not dropped:
+        return
+    for name in list(parts):
+        if name.endswith(".rels"):
+            base = _rels_source_dir(name)
+
+            def keep_relationship(match: re.Match[bytes], base: str = base) -> bytes:
+                target = _resolve_target(match.group(0), base)
+                return b"" if target in dropped else match.group(0)
+
+            parts[name] = _RELATIONSHIP.sub(keep_relationship, parts[name])
+
+    def keep_override(match: re.Match[bytes]) -> bytes:
+        part_name = (_attr(match.group(0), "PartName") or "").lstrip("/")
+        return b"" if part_name in dropped else match.group(0)
+
+    parts[_CONTENT_TYPES] = _OVERRIDE.sub(keep_override, parts[_CONTENT_TYPES])
+
+
+def _rels_source_dir(rels_path: str) -> str:
+    """Return the directory of the part a ``_rels/*.rels`` file belongs to."""
+    return posixpath.dirname(posixpath.dirname(rels_path))
+
+
+def _resolve_target(element: bytes, base: str) -> str | None:
+    """Resolve the package path of a Relationship element (None if external)."""
+    if _attr(element, "TargetMode") == "External":
+        return None
+    target = _attr(element, "Target")
+    if target is None:
+        return None
+    if target.startswith("/"):
+        return target.lstrip("/")
+    return posixpath.normpath(posixpath.join(base, target))
+
+
+def _attr(element: bytes, name: str) -> str | None:
+    match = re.search(_ATTR.format(name).encode(), element)
+    return match.group(1).decode("utf-8") if match else None
+
+
+__all__ = ["RecoveryReport", "repair_package"]
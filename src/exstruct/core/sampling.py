@@ -0,0 +1,64 @@
+"""Row sampling for previews and schema inference on large workbooks."""
+
+from __future__ import annotations
+
+import logging
+import random
+from typing import Literal
+
+from ..models import RowSample, WorkbookData
+
+logger = logging.getLogger(__name__)
+
+SampleStrategy = Literal["head", "tail", "random"]
+
+
+def sample_workbook_rows(
+    workbook: WorkbookData,
+    *,
+    strategy: SampleStrategy,
+    n: int,
+    seed: int | None = None,
+) -> WorkbookData:
+    """Keep at most ``n`` rows per sheet and mark sampled sheets.
+
+    ``head`` and ``tail`` keep the first or last rows; ``random`` draws rows
+    without replacement (reproducible with ``seed``) and keeps them in sheet
+    order. Sheets with more than ``n`` rows get ``SheetData.sample``; other
+    sheet data (maps, shapes, charts, table candidates) is left as extracted.
+
+    Args:
+        workbook: Extracted workbook.
+        strategy: ``head``, ``tail``, or ``random``.
+        n: Rows to keep per sheet.
+        seed: Random seed for ``random`` (None = nondeterministic).
+
+    Returns:
+        The workbook with sampled rows.
+
+    Raises:
+        ValueError: If ``n`` is not positive.
+    """
+    if n <= 0:
+        raise ValueError(f"n must be positive: {n}")
+    for name, sheet in workbook.sheets.items():
+        total = len(sheet.rows)
+        if total <= n:
+            continue
+        if strategy == "head":
+            sheet.rows = sheet.rows[:n]
+        elif strategy == "tail":
+            sheet.rows = sheet.rows[-n:]
+        else:
+            picked = sorted(random.Random(seed).sample(range(total), n))  # noqa: S311
+            sheet.rows = [sheet.rows[index] for index in picked]
+        sheet.sample = RowSample(
+            strategy=strategy, n=n, seed=seed, total_rows=total, sampled_rows=n
+        )
+        logger.info(
+            "Sampled %d of %d rows on sheet '%s' (%s).", n, total, name, strategy
+        )
+    return workbook
+
+
+__all__ = ["SampleStrategy", "sample_workbook_rows"]
@@ -0,0 +1,117 @@
+"""Map shapes to the cell ranges they cover."""
+
+from __future__ import annotations
+
+from collections.abc import Sequence
+
+from ..geometry.a1 import format_cell
+from ..geometry.grid import SheetGeometry, column_width_to_points
+from ..models import Arrow, BaseShape, Shape, SmartArt
+from ..ooxml.units import DEFAULT_DPI, PositionUnit
+from .cells import SheetDimensions
+
+ShapeUnit = PositionUnit
+
+
+def _sheet_geometry(dimensions: SheetDimensions | None) -> SheetGeometry:
+    """Build the sheet geometry from extracted sheet dimensions."""
+    if dimensions is None:
+        return SheetGeometry.from_sizes()
+    return SheetGeometry.from_sizes(
+        dimensions.column_widths,
+        dimensions.row_heights,
+        default_column_width=dimensions.default_column_width,
+        default_row_height=dimensions.default_row_height,
+        max_digit_width=dimensions.max_digit_width,
+    )
+
+
+def compute_covered_range(
+    shape: BaseShape,
+    dimensions: SheetDimensions | None,
+    *,
+    unit: ShapeUnit = "points",
+    dpi: int = DEFAULT_DPI,
+) -> str | None:
+    """Compute the A1-style cell range covered by a shape.
+
+    Args:
+        shape: Shape with left/top offsets and optional size.
+        dimensions: Sheet row heights and column widths (defaults when None).
+        unit: Unit of the shape coordinates.
+        dpi: Dots per inch of pixel coordinates.
+
+    Returns:
+        Covered range such as ``"B2:D5"``, or None when the shape has no size.
+    """
+    return _covered_range(shape, _sheet_geometry(dimensions), unit=unit, dpi=dpi)
+
+
+def _covered_range(
+    shape: BaseShape, geometry: SheetGeometry, *, unit: ShapeUnit, dpi: int
+) -> str | None:
+    """Return the range covered by a shape, or None when it has no size."""
+    if shape.w is None or shape.h is None:
+        return None
+    return geometry.range_at(shape.l, shape.t, shape.w, shape.h, unit=unit, dpi=dpi)
+
+
+def assign_covered_ranges(
+    shapes: Sequence[Shape | Arrow | SmartArt],
+    dimensions: SheetDimensions | None,
+    *,
+    unit: ShapeUnit = "points",
+    dpi: int = DEFAULT_DPI,
+) -> list[Shape | Arrow | SmartArt]:
+    """Return shapes annotated with the cell range each one covers.
+
+    Connectors additionally get the cells containing their start and end points.
+
+    Args:
+        shapes: Shapes extracted from a sheet.
+        dimensions: Sheet row heights and column widths (defaults when None).
+        unit: Unit of the shape coordinates.
+        dpi: Dots per inch of pixel coordinates.
+
+    Returns:
+        New shape list with ``covered_range`` populated where computable.
+    """
+    if not shapes:
+        return []
+    geometry = _sheet_geometry(dimensions)
+    annotated: list[Shape | Arrow | SmartArt] = []
+    for shape in shapes:
+        update: dict[str, str | None] = {
+            "covered_range": _covered_range(shape, geometry, unit=unit, dpi=dpi)
+        }
+        if isinstance(shape, Arrow):
+            update["begin_cell"] = _point_cell(
+                shape.begin_x, shape.begin_y, geometry, unit=unit, dpi=dpi
+            )
+            update["end_cell"] = _point_cell(
+                shape.end_x, shape.end_y, geometry, unit=unit, dpi=dpi
+            )
+        annotated.append(shape.model_copy(update=update))
+    return annotated
+
+
+def _point_cell(
+    x: int | None,
+    y: int | None,
+    geometry: SheetGeometry,
+    *,
+    unit: ShapeUnit,
+    dpi: int,
+) -> str | None:
+    """Return the A1 address of the cell containing a point."""
+    if x is None or y is None:
+        return None
+    return format_cell(*geometry.cell_at(x, y, unit=unit, dpi=dpi))
+
+
+__all__ = [
+    "ShapeUnit",
+    "assign_covered_ranges",
+    "column_width_to_points",
+    "compute_covered_range",
+]
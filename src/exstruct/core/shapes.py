@@ -3,6 +3,7 @@
 from __future__ import annotations
 
 from collections.abc import Iterable, Iterator
+import logging
 import math
 from typing import Literal, Protocol, SupportsInt, cast, runtime_checkable
 
@@ -11,6 +12,9 @@ from xlwings import Book
 
 from ..models import Arrow, Shape, SmartArt, SmartArtNode
 from ..models.maps import MSO_AUTO_SHAPE_TYPE_MAP, MSO_SHAPE_TYPE_MAP
+from ..ooxml.drawing import compute_connector_points, resolve_stable_ids
+
+logger = logging.getLogger(__name__)
 
 
 def compute_line_angle_deg(w: float, h: float) -> float:
@@ -85,6 +89,149 @@ def has_arrow(style_val: object) -> bool:
         return False
 
 
+def _get_z_order(shp: xw.Shape) -> int | None:
+    """Return the 1-based stacking position of a shape (higher is in front)."""
+    try:
+        return int(shp.api.ZOrderPosition)
+    except Exception:
+        return None
+
+
+def _set_connector_endpoints(arrow: Arrow, shp: xw.Shape) -> None:
+    """Record connector start/end points from its box, flips, and rotation."""
+    try:
+        flip_h = bool(shp.api.HorizontalFlip)
+        flip_v = bool(shp.api.VerticalFlip)
+    except Exception:
+        flip_h = flip_v = False
+    try:
+        start, end = compute_connector_points(
+            float(shp.left),
+            float(shp.top),
+            float(shp.width),
+            float(shp.height),
+            flip_h=flip_h,
+            flip_v=flip_v,
+            rotation=arrow.rotation,
+        )
+    except Exception:
+        return
+    arrow.begin_x = round(start[0])
+    arrow.begin_y = round(start[1])
+    arrow.end_x = round(end[0])
+    arrow.end_y = round(end[1])
+
+
+# MsoParagraphAlignment -> text alignment
+_TEXT_ALIGN_MAP: dict[
+    int, Literal["left", "center", "right", "justify", "distributed"]
+] = {
+    1: "left",
+    2: "center",
+    3: "right",
+    4: "justify",
+    5: "distributed",
+    6: "justify",
+    7: "justify",
+}
+
+# MsoVerticalAnchor -> vertical text anchor
+_TEXT_ANCHOR_MAP: dict[int, Literal["top", "middle", "bottom"]] = {
+    1: "top",
+    2: "top",
+    3: "middle",
+    4: "bottom",
+    5: "bottom",
+}
+
+
+# MsoTextOrientation -> text orientation
+_TEXT_ORIENTATION_MAP: dict[
+    int,
+    Literal[
+        "horizontal",
+        "vertical",
+        "vertical270",
+        "stacked",
+        "east_asian_vertical",
+        "mongolian_vertical",
+    ],
+] = {
+    1: "horizontal",
+    2: "vertical270",
+    3: "vertical",
+    4: "east_asian_vertical",
+    5: "stacked",
+    6: "horizontal",
+}
+
+
+def _set_text_layout(shape: Shape, shp: xw.Shape) -> None:
+    """Record text alignment, vertical anchor, wrap, and orientation."""
+    try:
+        frame = shp.api.TextFrame2
+    except Exception:
+        return
+    try:
+        align = int(frame.TextRange.ParagraphFormat.Alignment)
+        shape.text_align = _TEXT_ALIGN_MAP.get(align)
+    except Exception:
+        pass
+    try:
+        shape.text_anchor = _TEXT_ANCHOR_MAP.get(int(frame.VerticalAnchor))
+    except Exception:
+        pass
+    try:
+        shape.text_wrap = bool(frame.WordWrap)
+    except Exception:
+        pass
+    try:
+        shape.text_orientation = _TEXT_ORIENTATION_MAP.get(int(frame.Orientation))
+    except Exception:
+        pass
+
+
+def _set_click_actions(shape: Shape, shp: xw.Shape) -> None:
+    """Record the click hyperlink and assigned macro of a shape."""
+    try:
+        on_action = str(shp.api.OnAction or "")
+    except Exception:
+        on_action = ""
+    shape.on_action = on_action or None
+    try:
+        # Shape.Hyperlink raises when the shape has no hyperlink.
+        hyperlink = shp.api.Hyperlink
+        address = str(hyperlink.Address or "")
+        sub_address = str(hyperlink.SubAddress or "")
+    except Exception:
+        return
+    link = f"{address}#{sub_address}" if sub_address else address
+    shape.link = link or None
+
+
+def _set_line_format(arrow: Arrow, shp: xw.Shape) -> None:
+    """Record arrowhead sizes, dash style, and weight of a connector line."""
+    try:
+        line = shp.api.Line
+    except Exception:
+        return
+    try:
+        arrow.begin_arrow_width = int(line.BeginArrowheadWidth)
+        arrow.begin_arrow_length = int(line.BeginArrowheadLength)
+        arrow.end_arrow_width = int(line.EndArrowheadWidth)
+        arrow.end_arrow_length = int(line.EndArrowheadLength)
+    except Exception:
+        pass
+    try:
+        arrow.line_dash_style = int(line.DashStyle)
+    except Exception:
+        pass
+    try:
+        arrow.line_weight = float(line.Weight)
+    except Exception:
+        pass
+
+
 def iter_shapes_recursive(shp: xw.Shape) -> Iterator[xw.Shape]:
     """Yield shapes recursively, including group children."""
     yield shp
@@ -305,8 +452,30 @@ def _extract_smartart_nodes(smartart: _SmartArtLike | None) -> list[SmartArtNode
     return _build_smartart_tree(nodes_info)
 
 
+def _com_drawing_id(shp: xw.Shape) -> int | None:
+    """Return Excel's ``Shape.ID`` (the drawing cNvPr id), or None if unreadable."""
+    try:
+        return int(shp.api.ID)
+    except Exception:
+        return None
+
+
+def _apply_stable_ids(
+    shapes: list[Shape | Arrow | SmartArt],
+    excel_names: list[tuple[str, int]],
+    stable_shapes: list[tuple[int, int | None]],
+) -> list[tuple[str, int]]:
+    """Replace sequential shape ids with drawing ids; return remapped names."""
+    stable = resolve_stable_ids([drawing_id for _, drawing_id in stable_shapes])
+    mapping = {seq_id: new_id for (seq_id, _), new_id in zip(stable_shapes, stable)}
+    for shape in shapes:
+        if shape.id is not None:
+            shape.id = mapping.get(shape.id, shape.id)
+    return [(name, mapping.get(seq_id, seq_id)) for name, seq_id in excel_names]
+
+
 def get_shapes_with_position(  # noqa: C901
-    workbook: Book, mode: str = "standard"
+    workbook: Book, mode: str = "standard", *, stable_ids: bool = False
 ) -> dict[str, list[Shape | Arrow | SmartArt]]:
     """
     Scan all shapes in each worksheet and collect their positional and metadata information.
@@ -314,6 +483,7 @@ def get_shapes_with_position(  # noqa: C901
     Parameters:
         workbook (Book): The xlwings workbook to scan.
         mode (str): Output detail level; "light" skips most shapes, "standard" includes shapes with text or relationships, and "verbose" includes full size/rotation details.
+        stable_ids (bool): Use each shape's Excel ``Shape.ID`` (the drawing's cNvPr id) as its id instead of sequential numbering.
 
     Returns:
         dict[str, list[Shape | Arrow | SmartArt]]: Mapping of sheet name to a list of collected shape objects (Shape, Arrow, or SmartArt) containing position (left/top), optional size (width/height), textual content, and other captured metadata (ids, directions, connections, layout/nodes for SmartArt).
@@ -323,6 +493,7 @@ def get_shapes_with_position(  # noqa: C901
         shapes: list[Shape | Arrow | SmartArt] = []
         excel_names: list[tuple[str, int]] = []
         node_index = 0
+        stable_shapes: list[tuple[int, int | None]] = []
         pending_connections: list[tuple[Arrow, str | None, str | None]] = []
         for root in sheet.shapes:
             for shp in iter_shapes_recursive(root):
@@ -346,12 +517,24 @@ def get_shapes_with_position(  # noqa: C901
                     except Exception:
                         autoshape_type_str = None
                 except Exception:
+                    logger.debug(
+                        "Failed to read type of shape %r on sheet %r.",
+                        shape_name,
+                        sheet.name,
+                        exc_info=True,
+                    )
                     type_num = None
                     shape_type_str = None
                     autoshape_type_str = None
                 try:
                     text = shp.text.strip() if shp.text else ""
                 except Exception:
+                    logger.debug(
+                        "Failed to read text of shape %r on sheet %r.",
+                        shape_name,
+                        sheet.name,
+                        exc_info=True,
+                    )
                     text = ""
 
                 if mode == "light":
@@ -400,6 +583,8 @@ def get_shapes_with_position(  # noqa: C901
                 if not is_relationship_geom:
                     node_index += 1
                     shape_id = node_index
+                    if stable_ids:
+                        stable_shapes.append((shape_id, _com_drawing_id(shp)))
 
                 excel_name = shape_name if isinstance(shape_name, str) else None
 
@@ -460,6 +645,9 @@ def get_shapes_with_position(  # noqa: C901
                         approximation_level="direct",
                         confidence=1.0,
                     )
+                    if text:
+                        _set_text_layout(shape_obj, shp)
+                    _set_click_actions(shape_obj, shp)
                 if excel_name:
                     if shape_id is not None:
                         excel_names.append((excel_name, shape_id))
@@ -478,6 +666,8 @@ def get_shapes_with_position(  # noqa: C901
                                 shape_obj.rotation = rot
                         except Exception:
                             pass
+                        if isinstance(shape_obj, Arrow):
+                            _set_connector_endpoints(shape_obj, shp)
                         try:
                             begin_style = int(shp.api.Line.BeginArrowheadStyle)
                             end_style = int(shp.api.Line.EndArrowheadStyle)
@@ -486,6 +676,8 @@ def get_shapes_with_position(  # noqa: C901
                                 shape_obj.end_arrow_style = end_style
                         except Exception:
                             pass
+                        if isinstance(shape_obj, Arrow):
+                            _set_line_format(shape_obj, shp)
                         # Connector begin/end connected shapes (if this shape is a connector).
                         try:
                             connector = shp.api.ConnectorFormat
@@ -517,11 +709,20 @@ def get_shapes_with_position(  # noqa: C901
                                 shape_obj.rotation = rot
                         except Exception:
                             pass
-                except Exception:
-                    pass
+                except Exception as exc:
+                    logger.warning(
+                        "Failed to read details of shape %r on sheet %r; "
+                        "keeping partial data. (%r)",
+                        shape_name,
+                        sheet.name,
+                        exc,
+                    )
+                shape_obj.z_order = _get_z_order(shp)
                 if isinstance(shape_obj, Arrow):
                     pending_connections.append((shape_obj, begin_name, end_name))
                 shapes.append(shape_obj)
+        if stable_ids:
+            excel_names = _apply_stable_ids(shapes, excel_names, stable_shapes)
         if pending_connections:
             name_to_id = {name: sid for name, sid in excel_names}
             for shape_obj, begin_name, end_name in pending_connections:
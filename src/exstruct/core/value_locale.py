@@ -0,0 +1,214 @@
+"""Locale-aware parsing of cell text (``locale``).
+
+The default readers only turn plain ``1234`` / ``-12.5`` text into numbers.
+With a locale, text that is still a string afterwards is parsed again with
+that locale's decimal and grouping separators (``1,234.56`` for ``en``,
+``1.234,56`` for ``de``) and date order, after NFKC-normalizing full-width
+digits and signs (``１，２３４``). Text already read as a plain number keeps
+that reading, because numeric cells reach this point in the same form.
+"""
+
+from __future__ import annotations
+
+from dataclasses import dataclass
+from datetime import datetime
+import re
+import unicodedata
+from typing import Literal
+
+from ..models import CellRow
+from .cells import _coerce_numeric_preserve_format
+
+DateOrder = Literal["ymd", "mdy", "dmy"]
+
+
+@dataclass(frozen=True)
+class ValueLocale:
+    """Separators and date order used to parse cell text.
+
+    Attributes:
+        decimal: Decimal separator.
+        groups: Accepted digit-grouping separators.
+        date_order: Field order of numeric dates with a trailing year.
+        negative_marks: Prefixes read as a minus sign besides ``-``.
+    """
+
+    decimal: str
+    groups: str
+    date_order: DateOrder
+    negative_marks: str = ""
+
+
+_DOT_DECIMAL = ValueLocale(".", ",", "mdy")
+_COMMA_DECIMAL = ValueLocale(",", ".", "dmy")
+# NFKC turns no-break and narrow no-break spaces into plain spaces.
+_SPACE_GROUPED = ValueLocale(",", " ", "dmy")
+_EAST_ASIAN = ValueLocale(".", ",", "ymd")
+
+_LOCALES: dict[str, ValueLocale] = {
+    "en": _DOT_DECIMAL,
+    "en-gb": ValueLocale(".", ",", "dmy"),
+    "en-au": ValueLocale(".", ",", "dmy"),
+    "en-in": ValueLocale(".", ",", "dmy"),
+    "ja": ValueLocale(".", ",", "ymd", negative_marks="▲△"),
+    "zh": _EAST_ASIAN,
+    "ko": _EAST_ASIAN,
+    "de": _COMMA_DECIMAL,
+    "de-ch": ValueLocale(".", "'\u2019", "dmy"),
+    "es": _COMMA_DECIMAL,
+    "it": _COMMA_DECIMAL,
+    "nl": _COMMA_DECIMAL,
+    "pt": _COMMA_DECIMAL,
+    "id": _COMMA_DECIMAL,
+    "tr": _COMMA_DECIMAL,
+    "da": _COMMA_DECIMAL,
+    "fr": _SPACE_GROUPED,
+    "ru": _SPACE_GROUPED,
+    "uk": _SPACE_GROUPED,
+    "pl": _SPACE_GROUPED,
+    "cs": _SPACE_GROUPED,
+    "fi": _SPACE_GROUPED,
+    "nb": _SPACE_GROUPED,
+    "sv": ValueLocale(",", " ", "ymd"),
+}
+
+# NFKC leaves these minus signs alone.
+_MINUS_SIGNS = str.maketrans({"\u2212": "-", "\u2012": "-", "\u2013": "-"})
+_YEAR_FIRST_RE = re.compile(
+    r"^(\d{4})\s*[-/.年]\s*(\d{1,2})\s*[-/.月]\s*(\d{1,2})\s*日?$"
+)
+_YEAR_LAST_RE = re.compile(r"^(\d{1,2})[-/.](\d{1,2})[-/.](\d{4})$")
+
+
+def resolve_value_locale(tag: str) -> ValueLocale:
+    """Return the parsing rules for a locale tag such as ``de-DE`` or ``ja``.
+
+    The full tag is looked up first, then its language subtag.
+
+    Args:
+        tag: BCP 47 style locale tag (case-insensitive, ``_`` accepted).
+
+    Returns:
+        Parsing rules for the locale.
+
+    Raises:
+        ValueError: If the locale is not supported.
+    """
+    key = tag.strip().lower().replace("_", "-")
+    value_locale = _LOCALES.get(key) or _LOCALES.get(key.split("-", 1)[0])
+    if value_locale is None:
+        supported = ", ".join(sorted(_LOCALES))
+        raise ValueError(f"Unsupported locale: {tag!r} (supported: {supported})")
+    return value_locale
+
+
+def parse_localized_value(text: str, value_locale: ValueLocale) -> int | float | str:
+    """Parse locale-formatted number or date text.
+
+    Args:
+        text: Cell text.
+        value_locale: Parsing rules.
+
+    Returns:
+        int/float for numbers, ``str(datetime)`` (as for date-formatted
+        cells) for dates, otherwise the original text.
+    """
+    normalized = unicodedata.normalize("NFKC", text).strip().translate(_MINUS_SIGNS)
+    if not normalized:
+        return text
+    number = _parse_number(normalized, value_locale)
+    if number is not None:
+        return number
+    date = _parse_date(normalized, value_locale.date_order)
+    return text if date is None else str(date)
+
+
+def _parse_number(text: str, value_locale: ValueLocale) -> int | float | None:
+    """Return the number written in ``text`` with the locale's separators."""
+    sign = ""
+    if text[0] in value_locale.negative_marks or text[0] in "+-":
+        sign = "-" if text[0] != "+" else ""
+        text = text[1:].lstrip()
+    integer, sep, fraction = text.partition(value_locale.decimal)
+    if sep and not fraction.isdecimal():
+        return None
+    digits = _ungroup(integer, value_locale.groups)
+    if digits is None:
+        return None
+    canonical = f"{sign}{digits}.{fraction}" if sep else f"{sign}{digits}"
+    value = _coerce_numeric_preserve_format(canonical)
+    return None if isinstance(value, str) else value
+
+
+def _ungroup(integer: str, groups: str) -> str | None:
+    """Strip grouping separators, requiring groups of three digits."""
+    if integer.isdecimal() and integer.isascii():
+        return integer
+    for group in groups:
+        if group not in integer:
+            continue
+        head, *rest = integer.split(group)
+        if (
+            1 <= len(head) <= 3
+            and head.isdecimal()
+            and all(len(part) == 3 and part.isdecimal() for part in rest)
+        ):
+            return "".join([head, *rest])
+        return None
+    return None
+
+
+def _parse_date(text: str, date_order: DateOrder) -> datetime | None:
+    """Return the date written in ``text``; year-first dates work in any locale."""
+    match = _YEAR_FIRST_RE.match(text)
+    if match is not None:
+        year, month, day = (int(part) for part in match.groups())
+    else:
+        match = _YEAR_LAST_RE.match(text)
+        if match is None or date_order == "ymd":
+            return None
+        first, second, year = (int(part) for part in match.groups())
+        month, day = (first, second) if date_order == "mdy" else (second, first)
+    try:
+        return datetime(year, month, day)
+    except ValueError:
+        return None
+
+
+def apply_value_locale(
+    cell_data: dict[str, list[CellRow]], tag: str
+) -> dict[str, list[CellRow]]:
+    """Re-parse string cell values with a locale.
+
+    Args:
+        cell_data: Cell rows per sheet.
+        tag: Locale tag (see ``resolve_value_locale``).
+
+    Returns:
+        Cell rows with locale-formatted numbers and dates converted; rows
+        without changes are returned as is.
+    """
+    value_locale = resolve_value_locale(tag)
+    result: dict[str, list[CellRow]] = {}
+    for sheet_name, rows in cell_data.items():
+        converted: list[CellRow] = []
+        for row in rows:
+            values = {
+                key: parse_localized_value(value, value_locale)
+                if isinstance(value, str)
+                else value
+                for key, value in row.c.items()
+            }
+            converted.append(
+                row if values == row.c else row.model_copy(update={"c": values})
+            )
+        result[sheet_name] = converted
+    return result
+
+
+__all__ = [
+    "ValueLocale",
+    "apply_value_locale",
+    "parse_localized_value",
+    "resolve_value_locale",
+]
@@ -2,6 +2,7 @@ from __future__ import annotations
 
 from collections.abc import Iterator
 from contextlib import contextmanager
+from contextvars import ContextVar
 import logging
 from pathlib import Path
 from typing import Any
@@ -10,9 +11,45 @@ import warnings
 from openpyxl import load_workbook
 import xlwings as xw
 
+from ..sources import open_source
+
 logger = logging.getLogger(__name__)
 
-__all__ = ["openpyxl_workbook", "xlwings_workbook", "_find_open_workbook", "xw"]
+__all__ = [
+    "openpyxl_workbook",
+    "shared_openpyxl_workbooks",
+    "xlwings_workbook",
+    "_find_open_workbook",
+    "xw",
+]
+
+_SharedKey = tuple[Path, bool]
+_SHARED_WORKBOOKS: ContextVar[dict[_SharedKey, Any] | None] = ContextVar(
+    "exstruct_shared_openpyxl_workbooks", default=None
+)
+
+
+@contextmanager
+def shared_openpyxl_workbooks() -> Iterator[None]:
+    """Load each workbook once for every ``openpyxl_workbook`` call in the block.
+
+    Extraction reads the same file from many steps (cells, dimensions,
+    outline, number formats, style signals, defined names, ...). Inside this
+    block, full (``read_only=False``) loads are kept per path and
+    ``data_only`` flag and closed when the block exits. Nested blocks reuse
+    the outer one; the scope is per thread (context variable).
+    """
+    if _SHARED_WORKBOOKS.get() is not None:
+        yield
+        return
+    shared: dict[_SharedKey, Any] = {}
+    token = _SHARED_WORKBOOKS.set(shared)
+    try:
+        yield
+    finally:
+        _SHARED_WORKBOOKS.reset(token)
+        for wb in shared.values():
+            _close_workbook(wb)
 
 
 @contextmanager
@@ -22,6 +59,9 @@ def openpyxl_workbook(
     """
     Open an openpyxl Workbook for temporary use and ensure it is closed on exit.
 
+    Inside ``shared_openpyxl_workbooks`` a full load is reused instead and
+    closed when that block exits.
+
     Parameters:
         file_path (Path): Path to the workbook file.
         data_only (bool): If True, read stored cell values instead of formulas.
@@ -30,6 +70,24 @@ def openpyxl_workbook(
     Yields:
         openpyxl.workbook.workbook.Workbook: The opened workbook instance.
     """
+    shared = _SHARED_WORKBOOKS.get()
+    if shared is not None and not read_only:
+        key = (file_path, data_only)
+        if key not in shared:
+            shared[key] = _load_workbook(
+                file_path, data_only=data_only, read_only=False
+            )
+        yield shared[key]
+        return
+    wb = _load_workbook(file_path, data_only=data_only, read_only=read_only)
+    try:
+        yield wb
+    finally:
+        _close_workbook(wb)
+
+
+def _load_workbook(file_path: Path, *, data_only: bool, read_only: bool) -> Any:
+    """Load a workbook via openpyxl with its known-harmless warnings silenced."""
     with warnings.catch_warnings():
         warnings.filterwarnings(
             "ignore",
@@ -49,14 +107,17 @@ def openpyxl_workbook(
             category=UserWarning,
             module="openpyxl",
         )
-        wb = load_workbook(file_path, data_only=data_only, read_only=read_only)
+        return load_workbook(
+            open_source(file_path), data_only=data_only, read_only=read_only
+        )
+
+
+def _close_workbook(wb: Any) -> None:
+    """Close an openpyxl workbook, logging instead of raising on failure."""
     try:
-        yield wb
-    finally:
-        try:
-            wb.close()
-        except Exception as exc:
-            logger.debug("Failed to close openpyxl workbook. (%r)", exc)
+        wb.close()
+    except Exception as exc:
+        logger.debug("Failed to close openpyxl workbook. (%r)", exc)
 
 
 @contextmanager
@@ -0,0 +1,113 @@
+"""Registry of custom output encoders selectable by format name."""
+
+from __future__ import annotations
+
+from typing import TYPE_CHECKING, Protocol, runtime_checkable
+
+if TYPE_CHECKING:
+    from .models import WorkbookData
+
+BUILTIN_FORMATS: tuple[str, ...] = (
+    "json",
+    "yaml",
+    "yml",
+    "toon",
+    "events",
+    "text",
+    "markdown",
+    "sqlite",
+)
+
+# File suffix of each built-in format's output
+_FORMAT_SUFFIXES = {
+    "json": ".json",
+    "yaml": ".yaml",
+    "yml": ".yaml",
+    "toon": ".toon",
+    "events": ".ndjson",
+    "text": ".txt",
+    "markdown": ".md",
+    "sqlite": ".sqlite",
+}
+
+
+class ByteWriter(Protocol):
+    """Writable binary target handed to encoders (file or compressor)."""
+
+    def write(self, data: bytes, /) -> object: ...
+
+
+@runtime_checkable
+class Encoder(Protocol):
+    """Custom output format selected by ``name`` (e.g. ``--format msgpack``).
+
+    ``encode`` receives the workbook after sheet/component filters are applied
+    and writes its bytes to ``output``; compression follows the output path's
+    ``.gz``/``.zst`` suffix and the file is written atomically. Raising an
+    exception aborts the write and leaves any previous file untouched.
+    """
+
+    @property
+    def name(self) -> str:
+        """Format name used by ``--format`` and ``FormatOptions.fmt``."""
+        ...
+
+    def encode(self, output: ByteWriter, workbook: WorkbookData) -> None:
+        """Write one workbook to ``output``."""
+        ...
+
+
+_REGISTRY: dict[str, Encoder] = {}
+
+
+def register_encoder(encoder: Encoder, *, replace: bool = False) -> None:
+    """Register an encoder as an output format.
+
+    Args:
+        encoder: Encoder instance.
+        replace: Replace an existing encoder with the same name.
+
+    Raises:
+        ValueError: If the name is empty, a built-in format, or already
+            registered without replace.
+    """
+    name = encoder.name
+    if not name:
+        raise ValueError("Encoder name must not be empty.")
+    if name.lower() in BUILTIN_FORMATS:
+        raise ValueError(f"'{name}' is a built-in format and cannot be replaced.")
+    if name in _REGISTRY and not replace:
+        raise ValueError(f"Encoder '{name}' is already registered.")
+    _REGISTRY[name] = encoder
+
+
+def unregister_encoder(name: str) -> None:
+    """Remove a registered encoder; unknown names are ignored."""
+    _REGISTRY.pop(name, None)
+
+
+def registered_encoders() -> tuple[Encoder, ...]:
+    """Return registered encoders in registration order."""
+    return tuple(_REGISTRY.values())
+
+
+def get_encoder(name: str) -> Encoder | None:
+    """Return the encoder registered under ``name``, or None."""
+    return _REGISTRY.get(name)
+
+
+def format_suffix(fmt: str) -> str:
+    """Return the output file suffix for a format (``.<name>`` for encoders)."""
+    return _FORMAT_SUFFIXES.get(fmt, f".{fmt}")
+
+
+__all__ = [
+    "BUILTIN_FORMATS",
+    "ByteWriter",
+    "Encoder",
+    "format_suffix",
+    "get_encoder",
+    "register_encoder",
+    "registered_encoders",
+    "unregister_encoder",
+]
@@ -2,11 +2,14 @@
 
 from __future__ import annotations
 
-from collections.abc import Iterator
-from contextlib import contextmanager
-from dataclasses import dataclass, field
+from collections.abc import Callable, Collection, Iterator
+from contextlib import AbstractContextManager, contextmanager, nullcontext
+from dataclasses import asdict, dataclass, field, replace
+from fnmatch import fnmatchcase
+import logging
 from pathlib import Path
-from typing import Literal, TextIO, TypedDict, cast
+import time
+from typing import TYPE_CHECKING, Literal, TextIO, TypedDict, cast
 
 from pydantic import BaseModel, ConfigDict, Field
 
@@ -15,9 +18,23 @@ from .constraints import (
     validate_libreoffice_extraction_request,
     validate_libreoffice_process_request,
 )
-from .models import SheetData, WorkbookData
+from .core.logging_utils import route_logs_to
+from .encoders import ByteWriter, Encoder, format_suffix, get_encoder
+from .errors import ConfigError, ExtractionError, SerializationError
+from .models import ChartSourceIndex, SheetData, TableFamily, WorkbookData
+from .models.types import JsonStructure, PositionUnit
+
+if TYPE_CHECKING:
+    from .core.ranges import RangeBounds
+    from .handle import WorkbookHandle
 
 ExtractionMode = Literal["light", "libreoffice", "standard", "verbose"]
+SideOutputFormat = Literal["json", "yaml", "yml", "toon"]
+TextFormat = Literal["json", "yaml", "yml", "toon", "events", "text", "markdown"]
+OutputFormat = Literal[
+    "json", "yaml", "yml", "toon", "events", "text", "markdown", "sqlite"
+]
+WorkbookTransform = Callable[[WorkbookData], WorkbookData | None]
 
 
 def set_table_detection_params(
@@ -26,6 +43,7 @@ def set_table_detection_params(
     density_min: float | None = None,
     coverage_min: float | None = None,
     min_nonempty_cells: int | None = None,
+    gap_tolerance: int | None = None,
 ) -> None:
     """Lazily proxy table-detection configuration updates."""
     from .core.cells import (
@@ -37,6 +55,7 @@ def set_table_detection_params(
         density_min=density_min,
         coverage_min=coverage_min,
         min_nonempty_cells=min_nonempty_cells,
+        gap_tolerance=gap_tolerance,
     )
 
 
@@ -53,6 +72,24 @@ def extract_workbook(
     include_formulas_map: bool | None = None,
     include_merged_cells: bool | None = None,
     include_merged_values_in_rows: bool = True,
+    include_dimensions: bool | None = None,
+    include_cell_errors: bool | None = None,
+    include_outline: bool | None = None,
+    include_covered_ranges: bool | None = None,
+    include_cells: bool = True,
+    include_shapes: bool = True,
+    include_charts: bool = True,
+    include_tables: bool = True,
+    best_effort: bool = False,
+    fast_cells: bool = False,
+    stable_ids: bool = False,
+    include_shape_paths: bool = False,
+    position_unit: PositionUnit | None = None,
+    position_dpi: int | None = None,
+    locale: str | None = None,
+    include_phonetic: bool = False,
+    infer_print_areas: bool = False,
+    sheets: Collection[str] | None = None,
 ) -> WorkbookData:
     """Lazily proxy workbook extraction."""
     from .core.integrate import extract_workbook as extract_workbook_impl
@@ -69,9 +106,45 @@ def extract_workbook(
         include_formulas_map=include_formulas_map,
         include_merged_cells=include_merged_cells,
         include_merged_values_in_rows=include_merged_values_in_rows,
+        include_dimensions=include_dimensions,
+        include_cell_errors=include_cell_errors,
+        include_outline=include_outline,
+        include_covered_ranges=include_covered_ranges,
+        include_cells=include_cells,
+        include_shapes=include_shapes,
+        include_charts=include_charts,
+        include_tables=include_tables,
+        best_effort=best_effort,
+        fast_cells=fast_cells,
+        stable_ids=stable_ids,
+        include_shape_paths=include_shape_paths,
+        position_unit=position_unit,
+        position_dpi=position_dpi,
+        locale=locale,
+        include_phonetic=include_phonetic,
+        infer_print_areas=infer_print_areas,
+        sheets=sheets,
     )
 
 
+def build_chart_source_index(
+    sheets: dict[str, SheetData],
+) -> ChartSourceIndex | None:
+    """Lazily proxy chart source index construction."""
+    from .analysis import build_chart_source_index as build_chart_source_index_impl
+
+    return build_chart_source_index_impl(sheets)
+
+
+def filter_table_families(
+    families: list[TableFamily], sheets: dict[str, SheetData]
+) -> list[TableFamily]:
+    """Lazily proxy table family filtering."""
+    from .analysis import filter_table_families as filter_table_families_impl
+
+    return filter_table_families_impl(families, sheets)
+
+
 def convert_workbook_keys_to_alpha(workbook: WorkbookData) -> WorkbookData:
     """Lazily proxy workbook key conversion."""
     from .models import (
@@ -83,11 +156,15 @@ def convert_workbook_keys_to_alpha(workbook: WorkbookData) -> WorkbookData:
 
 def serialize_workbook(
     model: WorkbookData,
-    fmt: Literal["json", "yaml", "yml", "toon"] = "json",
+    fmt: TextFormat = "json",
     *,
     pretty: bool = False,
     indent: int | None = None,
     include_backend_metadata: bool = False,
+    jq: str | None = None,
+    query: str | None = None,
+    fields: list[str] | None = None,
+    dedupe_strings: bool = False,
 ) -> str:
     """Lazily proxy workbook serialization."""
     from .io import serialize_workbook as serialize_workbook_impl
@@ -98,6 +175,10 @@ def serialize_workbook(
         pretty=pretty,
         indent=indent,
         include_backend_metadata=include_backend_metadata,
+        jq=jq,
+        query=query,
+        fields=fields,
+        dedupe_strings=dedupe_strings,
     )
 
 
@@ -109,6 +190,7 @@ def save_sheets(
     pretty: bool = False,
     indent: int | None = None,
     include_backend_metadata: bool = False,
+    rows_per_file: int | None = None,
 ) -> dict[str, Path]:
     """Lazily proxy per-sheet export."""
     from .io import save_sheets as save_sheets_impl
@@ -120,9 +202,161 @@ def save_sheets(
         pretty=pretty,
         indent=indent,
         include_backend_metadata=include_backend_metadata,
+        rows_per_file=rows_per_file,
+    )
+
+
+def save_shapes(
+    workbook: WorkbookData,
+    output_dir: Path,
+    fmt: Literal["json", "yaml", "yml", "toon"] = "json",
+    *,
+    pretty: bool = False,
+    indent: int | None = None,
+    include_backend_metadata: bool = False,
+) -> dict[str, Path]:
+    """Lazily proxy per-sheet shapes export."""
+    from .io import save_shapes as save_shapes_impl
+
+    return save_shapes_impl(
+        workbook,
+        output_dir,
+        fmt=fmt,
+        pretty=pretty,
+        indent=indent,
+        include_backend_metadata=include_backend_metadata,
+    )
+
+
+def save_charts(
+    workbook: WorkbookData,
+    output_dir: Path,
+    fmt: Literal["json", "yaml", "yml", "toon"] = "json",
+    *,
+    pretty: bool = False,
+    indent: int | None = None,
+    include_backend_metadata: bool = False,
+) -> dict[str, Path]:
+    """Lazily proxy per-sheet charts export."""
+    from .io import save_charts as save_charts_impl
+
+    return save_charts_impl(
+        workbook,
+        output_dir,
+        fmt=fmt,
+        pretty=pretty,
+        indent=indent,
+        include_backend_metadata=include_backend_metadata,
+    )
+
+
+def save_as_sqlite(
+    workbook: WorkbookData, path: Path, *, include_backend_metadata: bool = False
+) -> None:
+    """Lazily proxy SQLite export."""
+    from .io.sqlite import save_as_sqlite as save_as_sqlite_impl
+
+    save_as_sqlite_impl(
+        workbook, path, include_backend_metadata=include_backend_metadata
     )
 
 
+def save_tables(
+    workbook: WorkbookData,
+    output_dir: Path,
+    fmt: Literal["parquet", "arrow"] = "parquet",
+) -> dict[str, Path]:
+    """Lazily proxy Parquet/Arrow table export."""
+    from .io.tables import save_tables as save_tables_impl
+
+    return save_tables_impl(workbook, output_dir, fmt=fmt)
+
+
+def save_chart_images(
+    workbook: WorkbookData,
+    output_dir: Path,
+    fmt: Literal["svg", "png"] = "svg",
+) -> dict[str, Path]:
+    """Lazily proxy chart preview image export."""
+    from .io.chart_images import save_chart_images as save_chart_images_impl
+
+    return save_chart_images_impl(workbook, output_dir, fmt=fmt)
+
+
+def save_sheet_snapshots(
+    workbook: WorkbookData,
+    output_dir: Path,
+    fmt: Literal["svg", "png"] = "svg",
+    *,
+    unit: PositionUnit = "pixels",
+    dpi: int = 96,
+) -> dict[str, Path]:
+    """Lazily proxy sheet snapshot export."""
+    from .io.snapshots import save_sheet_snapshots as save_sheet_snapshots_impl
+
+    return save_sheet_snapshots_impl(workbook, output_dir, fmt, unit=unit, dpi=dpi)
+
+
+def dump_parts(file_path: Path, output_dir: Path) -> dict[str, Path]:
+    """Lazily proxy the raw OOXML part dump."""
+    from .io.parts import dump_parts as dump_parts_impl
+
+    return dump_parts_impl(file_path, output_dir)
+
+
+def write_output_text(
+    path: Path, text: str, *, split_size: int | None = None
+) -> list[Path]:
+    """Lazily proxy compressed/split output writing."""
+    from .io.output import write_output_text as write_output_text_impl
+
+    return write_output_text_impl(path, text, split_size=split_size)
+
+
+def workbook_payload(
+    model: WorkbookData,
+    *,
+    include_backend_metadata: bool = False,
+    jq: str | None = None,
+    query: str | None = None,
+    fields: list[str] | None = None,
+    dedupe_strings: bool = False,
+) -> JsonStructure:
+    """Lazily proxy building the serializable workbook payload."""
+    from .io import workbook_payload as workbook_payload_impl
+
+    return workbook_payload_impl(
+        model,
+        include_backend_metadata=include_backend_metadata,
+        jq=jq,
+        query=query,
+        fields=fields,
+        dedupe_strings=dedupe_strings,
+    )
+
+
+def write_output_json(
+    path: Path,
+    payload: JsonStructure,
+    *,
+    indent: int | None = None,
+    max_bytes: int | None = None,
+) -> list[Path]:
+    """Lazily proxy streaming JSON output writing."""
+    from .io.output import write_output_json as write_output_json_impl
+
+    return write_output_json_impl(path, payload, indent=indent, max_bytes=max_bytes)
+
+
+def write_output_encoded(
+    path: Path, encode: Callable[[ByteWriter], object]
+) -> list[Path]:
+    """Lazily proxy custom-encoder output writing."""
+    from .io.output import write_output_encoded as write_output_encoded_impl
+
+    return write_output_encoded_impl(path, encode)
+
+
 def save_print_area_views(
     workbook: WorkbookData,
     output_dir: Path,
@@ -223,6 +457,7 @@ class TableParams(TypedDict, total=False):
     density_min: float
     coverage_min: float
     min_nonempty_cells: int
+    gap_tolerance: int
 
 
 class ColorsOptions(BaseModel):
@@ -251,6 +486,92 @@ class ColorsOptions(BaseModel):
         return set(self.ignore_colors)
 
 
+class ComponentsOptions(BaseModel):
+    """Which workbook components to extract.
+
+    Disabled components are skipped during extraction rather than filtered
+    afterwards, so e.g. a shapes-only run never reads cell values. Shapes and
+    charts are never extracted in light mode regardless of these flags.
+
+    Examples:
+        >>> ComponentsOptions(cells=False, tables=False, charts=False)
+    """
+
+    model_config = ConfigDict(extra="forbid")
+
+    cells: bool = Field(
+        default=True,
+        description="Read cell values (sheets are still listed when False).",
+    )
+    shapes: bool = Field(default=True, description="Extract shapes and connectors.")
+    charts: bool = Field(default=True, description="Extract charts.")
+    tables: bool = Field(default=True, description="Detect table candidates.")
+    print_areas: bool | None = Field(
+        default=None, description="Extract print areas; None -> auto (True)."
+    )
+
+
+class LimitsOptions(BaseModel):
+    """Size guardrails for untrusted workbooks.
+
+    Sheet and cell limits are checked against the OOXML package before
+    extraction (and against the extracted data for .xls). With
+    ``on_exceed="truncate"`` the workbook is extracted and cut down to the
+    first sheets/cells instead; ``max_output_bytes`` always raises.
+    ``max_rows_per_sheet`` and ``max_cols_per_sheet`` always clip: sheets
+    extending past them keep the top-left part and are marked with
+    ``SheetData.truncation``.
+
+    Examples:
+        >>> LimitsOptions(max_cells=1_000_000, max_sheets=50)
+    """
+
+    model_config = ConfigDict(extra="forbid")
+
+    max_cells: int | None = Field(
+        default=None, gt=0, description="Maximum non-empty cells in the workbook."
+    )
+    max_sheets: int | None = Field(
+        default=None, gt=0, description="Maximum number of sheets."
+    )
+    max_output_bytes: int | None = Field(
+        default=None, gt=0, description="Maximum serialized output size in bytes."
+    )
+    max_rows_per_sheet: int | None = Field(
+        default=None, gt=0, description="Clip sheets after this row (1-based)."
+    )
+    max_cols_per_sheet: int | None = Field(
+        default=None, gt=0, description="Clip sheets after this many columns."
+    )
+    on_exceed: Literal["error", "truncate"] = Field(
+        default="error",
+        description="Raise LimitExceededError, or truncate cells/sheets.",
+    )
+
+
+class SampleRowsOptions(BaseModel):
+    """Row sampling for previews and schema inference.
+
+    Each sheet keeps at most ``n`` rows (the first, the last, or a random
+    draw in sheet order) and sampled sheets are marked with
+    ``SheetData.sample``. Sampling runs after cell extraction, so combine it
+    with ``fast_cells`` on very large workbooks.
+
+    Examples:
+        >>> SampleRowsOptions(strategy="random", n=200, seed=7)
+    """
+
+    model_config = ConfigDict(extra="forbid")
+
+    strategy: Literal["head", "tail", "random"] = Field(
+        default="head", description="Keep the first, last, or random rows."
+    )
+    n: int = Field(gt=0, description="Rows to keep per sheet.")
+    seed: int | None = Field(
+        default=None, description="Random seed for reproducible random samples."
+    )
+
+
 @dataclass(frozen=True)
 class StructOptions:
     """
@@ -269,9 +590,103 @@ class StructOptions:
         include_formulas_map: Whether to extract formulas map.
         include_merged_cells: Whether to extract merged cell ranges.
         include_merged_values_in_rows: Whether to keep merged values in rows.
+        include_dimensions: Whether to extract row heights and column widths.
+        include_cell_errors: Whether to extract cells holding error values.
+        include_outline: Whether to extract row/column outline (grouping)
+            levels and their collapsed state.
+        include_covered_ranges: Whether to compute the cell range each shape
+            covers (``Shape.covered_range``) and the cells connectors start and
+            end in (``begin_cell``/``end_cell``). None enables it only when row
+            heights and column widths are extracted anyway (verbose or
+            ``include_dimensions``) and for markdown output from ``process``;
+            True also loads them just for this.
         colors: Color extraction options.
+        components: Which components (cells, shapes, charts, tables, print
+            areas) to extract.
+        transforms: Callables run in order on the extracted workbook (after
+            alpha_col conversion). Each may modify the workbook in place and
+            return None, or return a replacement workbook.
         alpha_col: When True, convert CellRow column keys to Excel-style
             ABC names (A, B, ..., Z, AA, ...) instead of 0-based numeric strings.
+        limits: Cell/sheet/output size guardrails.
+        best_effort: Skip corrupted zip entries and malformed XML parts instead
+            of failing; broken sheets are dropped and reported in
+            ``WorkbookData.warnings``.
+        fast_cells: Stream cell values straight from the sheet and shared
+            string XML instead of going through pandas/openpyxl. Produces the
+            same rows with roughly half the time and memory on wide sheets.
+        stable_ids: Use each shape's drawing (cNvPr) id as ``Shape.id`` instead
+            of sequential per-run numbering, and set ``source_id`` to
+            ``<drawing part>#<id>`` when the drawing part is known. Ids then
+            stay the same across runs and edits elsewhere in the sheet.
+        include_shape_paths: Add simplified polylines of freeform shape paths
+            and ink strokes to ``Shape.geometry.paths``. The path bounding box
+            is always reported; both come from OOXML parsing only, so shapes
+            extracted through Excel COM or LibreOffice carry no geometry.
+        position_unit: Unit for shape and chart positions and sizes
+            (``pixels``, ``points``, ``emu``, or ``millimeters``). None keeps
+            each backend's native unit: points from Excel COM/LibreOffice,
+            pixels at 96 DPI from the OOXML fallback.
+        position_dpi: DPI used for pixel positions (None -> 96).
+        locale: Locale tag (``en-US``, ``de``, ``fr``, ``ja``, ...) used to
+            parse cell text that plain parsing leaves as strings: grouped and
+            comma-decimal numbers, full-width digits, and numeric dates (which
+            become ``YYYY-MM-DD 00:00:00`` like date-formatted cells). None
+            disables it.
+        normalize_text: Apply NFKC normalization and whitespace cleanup
+            (invisible characters removed, spaces collapsed, text trimmed) to
+            string cell values and shape texts before ``transforms`` run.
+        include_phonetic: Attach the phonetic guide (furigana) readings of
+            Japanese text cells to each row as ``phonetic`` (OOXML workbooks
+            only).
+        infer_print_areas: For sheets without a defined print area, split the
+            used range into pages from the paper size, orientation, margins,
+            scale, and manual breaks, and report each page in ``print_areas``
+            (OOXML workbooks only; ignored when print areas are excluded).
+        include_formulas_r1c1: Also report ``formulas_map_r1c1``, the formulas
+            regrouped by R1C1 text so copied formulas share one entry. Turns on
+            ``formulas_map`` unless ``include_formulas_map`` is set to False.
+        compress_formulas: Move formulas filled down or across into
+            ``formula_blocks`` (one R1C1 pattern per range such as ``C2:C5000``)
+            and keep only the remaining cells in ``formulas_map``. Enables
+            ``formulas_map`` unless ``include_formulas_map`` is set to False.
+        include_table_stats: Report ``table_stats``, the count, null rate,
+            distinct count, numeric min/max/mean, anomalies, and header unit or
+            currency of each table candidate column, taking the first candidate
+            row as the header.
+        rank_tables: Report ``table_scores``, a 0.0-1.0 confidence per table
+            candidate from borders, header styling, density, and
+            rectangularity, and sort ``table_candidates`` by it (highest first).
+        include_pivot_caches: Rebuild the records of pivot caches whose source
+            sheet or range no longer exists (or is another workbook) into
+            ``WorkbookData.pivot_caches`` (OOXML workbooks only).
+        include_power_queries: Decode the DataMashup part into
+            ``WorkbookData.power_queries`` (query names and M code; OOXML
+            workbooks only).
+        include_connections: List ``xl/connections.xml`` connections with
+            masked credentials, refresh settings, and the query/pivot tables
+            bound to them in ``WorkbookData.connections`` (OOXML workbooks only).
+        include_security_report: Report package digital signatures (signer,
+            certificate validity) and parts modified after signing in
+            ``WorkbookData.security``.
+        include_named_ranges: Resolve defined names that refer to ranges into
+            bounds on the sheets they cover (``SheetData.named_ranges``).
+            Also resolved, but not output, for ``name:`` range targets of
+            ``FilterOptions.ranges``.
+        include_styles: Export the style table (fonts, fills, borders, number
+            formats, cell formats, and the default font) once as
+            ``WorkbookData.styles`` and the cells using each cell format as
+            ``SheetData.style_map`` (OOXML workbooks only).
+        include_metrics: Add ``WorkbookData.metrics``: the extraction wall
+            time and, per sheet, the time to parse its worksheet part, the part
+            and related part (drawings, charts, ...) sizes, and row, cell,
+            shape, chart, and table candidate counts.
+        logger: Logger receiving ExStruct's diagnostics (fallbacks, shapes or
+            charts that failed to parse) during extraction, at its own level.
+            None leaves the standard ``exstruct`` loggers as configured.
+        sample_rows: Keep only a head/tail/random sample of rows per sheet
+            and mark sampled sheets with ``SheetData.sample``. None keeps all
+            rows.
     """
 
     mode: ExtractionMode = "standard"
@@ -283,22 +698,87 @@ class StructOptions:
     include_formulas_map: bool | None = None  # None -> auto: verbose=True, others=False
     include_merged_cells: bool | None = None  # None -> auto: light=False, others=True
     include_merged_values_in_rows: bool = True
+    include_dimensions: bool | None = None  # None -> auto: verbose=True, others=False
+    include_cell_errors: bool | None = None  # None -> auto: light=False, others=True
+    include_outline: bool | None = None  # None -> auto: verbose=True, others=False
+    include_covered_ranges: bool | None = None  # None -> auto: with dimensions
     colors: ColorsOptions = field(default_factory=ColorsOptions)
+    components: ComponentsOptions = field(default_factory=ComponentsOptions)
+    transforms: tuple[WorkbookTransform, ...] = ()
     alpha_col: bool = False
+    limits: LimitsOptions = field(default_factory=LimitsOptions)
+    best_effort: bool = False
+    fast_cells: bool = False
+    stable_ids: bool = False
+    include_shape_paths: bool = False
+    position_unit: PositionUnit | None = None
+    position_dpi: int | None = None  # None -> 96
+    locale: str | None = None
+    normalize_text: bool = False
+    include_phonetic: bool = False
+    infer_print_areas: bool = False
+    include_formulas_r1c1: bool = False
+    compress_formulas: bool = False
+    include_table_stats: bool = False
+    rank_tables: bool = False
+    include_pivot_caches: bool = False
+    include_power_queries: bool = False
+    include_connections: bool = False
+    include_security_report: bool = False
+    include_named_ranges: bool = False
+    include_styles: bool = False
+    include_metrics: bool = False
+    logger: logging.Logger | None = None
+    sample_rows: SampleRowsOptions | None = None
 
 
 class FormatOptions(BaseModel):
     """Formatting options for serialization."""
 
     model_config = ConfigDict(arbitrary_types_allowed=True)
-    fmt: Literal["json", "yaml", "yml", "toon"] = Field(
-        default="json", description="Serialization format."
+    fmt: OutputFormat | str = Field(
+        default="json",
+        description=(
+            "Serialization format (sqlite writes a database file) or the name of "
+            "an encoder registered with exstruct.encoders.register_encoder."
+        ),
     )
     pretty: bool = Field(default=False, description="Pretty-print JSON output.")
     indent: int | None = Field(
         default=None,
         description="Indent width for JSON (defaults to 2 when pretty is True).",
     )
+    jq: str | None = Field(
+        default=None,
+        description=(
+            "jq expression applied to the json/yaml/toon payload before writing "
+            "(requires the jq package)."
+        ),
+    )
+    query: str | None = Field(
+        default=None,
+        description=(
+            "JSONPath (starting with '$') or JMESPath expression whose result is "
+            "written instead of the json/yaml/toon payload (JMESPath requires "
+            "the jmespath package)."
+        ),
+    )
+    fields: list[str] | None = Field(
+        default=None,
+        description=(
+            "Projection over the json/yaml/toon payload: section names to keep "
+            "(e.g. 'shapes'), '-section' to drop, and 'section.field' / "
+            "'-section.field' to keep or drop fields of each item."
+        ),
+    )
+    dedupe_strings: bool = Field(
+        default=False,
+        description=(
+            "Store string cell values repeated on a sheet once in its 'strings' "
+            "table and reference them by index from each row's 's' map "
+            "(json/yaml/toon output)."
+        ),
+    )
 
 
 class FilterOptions(BaseModel):
@@ -336,6 +816,35 @@ class FilterOptions(BaseModel):
     include_merged_cells: bool = Field(
         default=True, description="Include merged cell ranges."
     )
+    sheets: list[str] | None = Field(
+        default=None,
+        description="Sheet name glob patterns to keep (None keeps every sheet).",
+    )
+    exclude_sheets: list[str] = Field(
+        default_factory=list, description="Sheet name glob patterns to drop."
+    )
+    ranges: list[str] | None = Field(
+        default=None,
+        description=(
+            "Extraction targets, 'name:<Defined name>' or '<Sheet>!A1:D20': rows "
+            "are clipped to them and sheets without a target are left out."
+        ),
+    )
+
+    def sheet_selected(self, name: str) -> bool:
+        """Return whether a sheet passes the sheets/exclude_sheets patterns.
+
+        Args:
+            name: Sheet name.
+
+        Returns:
+            True when the sheet should be kept.
+        """
+        if self.sheets is not None and not any(
+            fnmatchcase(name, pattern) for pattern in self.sheets
+        ):
+            return False
+        return not any(fnmatchcase(name, pattern) for pattern in self.exclude_sheets)
 
 
 class DestinationOptions(BaseModel):
@@ -351,6 +860,61 @@ class DestinationOptions(BaseModel):
     auto_page_breaks_dir: str | Path | None = Field(
         default=None, description="Directory to write auto page-break files."
     )
+    tables_dir: str | Path | None = Field(
+        default=None,
+        description="Directory to write table candidates as Parquet/Arrow files.",
+    )
+    shapes_dir: str | Path | None = Field(
+        default=None, description="Directory to write per-sheet shapes files."
+    )
+    charts_dir: str | Path | None = Field(
+        default=None, description="Directory to write per-sheet charts files."
+    )
+    tables_format: Literal["parquet", "arrow"] = Field(
+        default="parquet", description="Columnar format for tables_dir output."
+    )
+    chart_images_dir: str | Path | None = Field(
+        default=None,
+        description="Directory to write an SVG/PNG preview of every chart.",
+    )
+    chart_images_format: Literal["svg", "png"] = Field(
+        default="svg",
+        description="Image format for chart_images_dir (png requires Pillow).",
+    )
+    snapshots_dir: str | Path | None = Field(
+        default=None,
+        description=(
+            "Directory to write an SVG/PNG snapshot of the used region of each "
+            "sheet, laid out from the extracted cells, merges, and shapes."
+        ),
+    )
+    snapshots_format: Literal["svg", "png"] = Field(
+        default="svg",
+        description="Image format for snapshots_dir (png requires Pillow).",
+    )
+    dump_parts_dir: str | Path | None = Field(
+        default=None,
+        description=(
+            "Directory to copy the raw drawing/chart/table XML parts of the "
+            "source workbook into (process only)."
+        ),
+    )
+    split_size: int | None = Field(
+        default=None,
+        gt=0,
+        description=(
+            "Split the primary output file into numbered parts of at most this "
+            "many uncompressed bytes, plus a manifest."
+        ),
+    )
+    rows_per_file: int | None = Field(
+        default=None,
+        gt=0,
+        description=(
+            "Split each sheets_dir file with more rows into numbered pages of "
+            "at most this many rows, linked by page metadata."
+        ),
+    )
     stream: TextIO | None = Field(
         default=None, description="Stream override for primary output (stdout/file)."
     )
@@ -378,11 +942,76 @@ class OutputOptions(BaseModel):
     )
 
 
+@dataclass
+class RunReport:
+    """
+    Machine-readable summary of one ``process`` run (CLI ``--report``).
+
+    Attributes:
+        file: Input workbook path.
+        status: ``ok``, ``partial`` (the workbook has ``warnings``, e.g. parts
+            skipped by best_effort), or ``error``.
+        exit_code: CLI exit code; None outside the CLI.
+        error: Error message when the run failed.
+        durations: Seconds spent per component (``extract``, ``export``,
+            ``dump_parts``, ``render``) plus ``total``.
+        counts: Sheets, rows, cells, shapes, charts, and table candidates
+            in the extracted workbook.
+        warnings: ``WorkbookData.warnings`` of the extracted workbook.
+        log_warnings: Warning and error messages logged during the run.
+    """
+
+    file: str = ""
+    status: Literal["ok", "partial", "error"] = "ok"
+    exit_code: int | None = None
+    error: str | None = None
+    durations: dict[str, float] = field(default_factory=dict)
+    counts: dict[str, int] = field(default_factory=dict)
+    warnings: list[str] = field(default_factory=list)
+    log_warnings: list[str] = field(default_factory=list)
+
+    @contextmanager
+    def timed(self, component: str) -> Iterator[None]:
+        """Add the wall time spent in the block to ``durations[component]``."""
+        start = time.monotonic()
+        try:
+            yield
+        finally:
+            elapsed = self.durations.get(component, 0.0) + time.monotonic() - start
+            self.durations[component] = round(elapsed, 4)
+
+    def record_workbook(self, workbook: WorkbookData) -> None:
+        """Fill ``counts`` and ``warnings`` from an extracted workbook."""
+        sheets = workbook.sheets.values()
+        self.counts = {
+            "sheets": len(workbook.sheets),
+            "rows": sum(len(sheet.rows) for sheet in sheets),
+            "cells": sum(len(row.c) for sheet in sheets for row in sheet.rows),
+            "shapes": sum(len(sheet.shapes) for sheet in sheets),
+            "charts": sum(len(sheet.charts) for sheet in sheets),
+            "table_candidates": sum(len(sheet.table_candidates) for sheet in sheets),
+        }
+        self.warnings = list(workbook.warnings)
+        if self.warnings and self.status == "ok":
+            self.status = "partial"
+
+    def to_dict(self) -> dict[str, object]:
+        """Return the report as a JSON-compatible dict."""
+        return asdict(self)
+
+
+def _timed(report: RunReport | None, component: str) -> AbstractContextManager[None]:
+    """Time a block into ``report`` when one is given."""
+    return nullcontext() if report is None else report.timed(component)
+
+
 class ExStructEngine:
     """
     Configurable engine for ExStruct extraction and export.
 
-    Instances are immutable; override options per call if needed.
+    Options are immutable; override them per call if needed. Long-lived
+    services that watch a file use ``open`` to keep the extracted workbook and
+    re-extract only the sheets and components that changed.
 
     Key behaviors:
         - StructOptions: extraction mode and optional table detection params.
@@ -397,6 +1026,8 @@ class ExStructEngine:
                 - Writes to file/stdout; optionally per-sheet and per-print-area files
             process(file_path, ...)
                 - One-shot extract->export (CLI equivalent), with optional PDF/PNG
+            open(path, mode=None) -> WorkbookHandle
+                - Extract once, then invalidate/refresh single sheets or components
     """
 
     def __init__(
@@ -413,6 +1044,28 @@ class ExStructEngine:
         """Factory to create an engine with default options."""
         return ExStructEngine()
 
+    @staticmethod
+    def from_config(path: str | Path, profile: str | None = None) -> ExStructEngine:
+        """Factory to create an engine from a profile in a config file.
+
+        Args:
+            path: YAML/JSON/TOML config file (see ``exstruct.config``).
+            profile: Profile name; defaults to the file's default/only profile.
+
+        Returns:
+            Engine configured with the profile's extraction and output options.
+
+        Raises:
+            ConfigError: If the file is invalid or the profile cannot be found.
+        """
+        from .config import load_profile
+
+        selected = load_profile(path, profile)
+        return ExStructEngine(
+            options=selected.to_struct_options(),
+            output=selected.to_output_options(),
+        )
+
     def _apply_table_params(self) -> None:
         """Apply table parameter overrides if configured."""
         if self.options.table_params:
@@ -484,7 +1137,10 @@ class ExStructEngine:
         return self.output.filters.include_auto_print_areas
 
     def _filter_sheet(
-        self, sheet: SheetData, include_auto_override: bool | None = None
+        self,
+        sheet: SheetData,
+        include_auto_override: bool | None = None,
+        areas: list[RangeBounds] | None = None,
     ) -> SheetData:
         """
         Return a filtered copy of a SheetData according to the engine's output filters and resolved size/print-area flags.
@@ -492,17 +1148,20 @@ class ExStructEngine:
         Parameters:
             sheet: The original SheetData to filter.
             include_auto_override: If not None, overrides the engine's automatic decision for including auto page-break areas; if None, the engine's auto rule is used.
+            areas: Range targets on this sheet; when given, rows are clipped to them.
 
         Returns:
-            A new SheetData where:
-              - rows are kept only if include_rows is enabled; otherwise an empty list.
+            A copy of ``sheet`` where:
+              - rows are kept only if include_rows is enabled; otherwise an empty list. With areas, only cells inside them are kept.
               - shapes are kept only if include_shapes is enabled; when kept and shape-size inclusion is disabled, each shape's width and height are cleared.
               - charts are kept only if include_charts is enabled; when kept and chart-size inclusion is disabled, each chart's width and height are cleared.
-              - table_candidates are kept only if include_tables is enabled; otherwise an empty list.
-              - colors_map and formulas_map are preserved as-is.
+              - table_candidates, table_hashes, table_scores, and table_stats are kept only if include_tables is enabled; otherwise empty.
               - print_areas are kept only if print areas are included by the engine; otherwise an empty list.
               - auto_print_areas are kept only if auto page-break areas are included (after applying include_auto_override); otherwise an empty list.
-              - merged_cells are kept only if include_merged_cells is enabled; otherwise set to None.
+              - merged_cells and merged_ranges are kept only if include_merged_cells is enabled; otherwise None and an empty list.
+              - named_ranges are kept only if include_named_ranges is enabled; otherwise an empty list.
+              - flowcharts and shape_overlaps are kept only if include_shapes is enabled; otherwise empty lists.
+              - every other field is copied as-is.
         """
         include_shape_size, include_chart_size = self._resolve_size_flags()
         include_print_areas = self._include_print_areas()
@@ -511,34 +1170,38 @@ class ExStructEngine:
             if include_auto_override is not None
             else self._include_auto_print_areas()
         )
-        return SheetData(
-            rows=sheet.rows if self.output.filters.include_rows else [],
-            shapes=[
-                s if include_shape_size else s.model_copy(update={"w": None, "h": None})
-                for s in sheet.shapes
+        filters = self.output.filters
+        rows = sheet.rows if filters.include_rows else []
+        if areas is not None:
+            from .core.ranges import clip_rows
+
+            rows = clip_rows(rows, areas)
+        update: dict[str, object] = {"rows": rows}
+        if not filters.include_shapes:
+            update.update(shapes=[], flowcharts=[], shape_overlaps=[])
+        elif not include_shape_size:
+            update["shapes"] = [
+                s.model_copy(update={"w": None, "h": None}) for s in sheet.shapes
             ]
-            if self.output.filters.include_shapes
-            else [],
-            charts=[
-                c if include_chart_size else c.model_copy(update={"w": None, "h": None})
-                for c in sheet.charts
+        if not filters.include_charts:
+            update["charts"] = []
+        elif not include_chart_size:
+            update["charts"] = [
+                c.model_copy(update={"w": None, "h": None}) for c in sheet.charts
             ]
-            if self.output.filters.include_charts
-            else [],
-            table_candidates=sheet.table_candidates
-            if self.output.filters.include_tables
-            else [],
-            colors_map=sheet.colors_map,
-            formulas_map=sheet.formulas_map,
-            print_areas=sheet.print_areas if include_print_areas else [],
-            auto_print_areas=sheet.auto_print_areas if include_auto_print_areas else [],
-            merged_cells=sheet.merged_cells
-            if self.output.filters.include_merged_cells
-            else None,
-            merged_ranges=sheet.merged_ranges
-            if self.output.filters.include_merged_cells
-            else [],
-        )
+        if not filters.include_tables:
+            update.update(
+                table_candidates=[], table_hashes={}, table_scores={}, table_stats={}
+            )
+        if not include_print_areas:
+            update["print_areas"] = []
+        if not include_auto_print_areas:
+            update["auto_print_areas"] = []
+        if not self.options.include_named_ranges:
+            update["named_ranges"] = []
+        if not filters.include_merged_cells:
+            update.update(merged_cells=None, merged_ranges=[])
+        return sheet.model_copy(update=update)
 
     def _filter_workbook(
         self, wb: WorkbookData, *, include_auto_override: bool | None = None
@@ -552,11 +1215,35 @@ class ExStructEngine:
         Returns:
             Filtered WorkbookData.
         """
+        targets: dict[str, list[RangeBounds]] | None = None
+        if self.output.filters.ranges is not None:
+            from .core.ranges import resolve_range_targets
+
+            targets = resolve_range_targets(self.output.filters.ranges, wb.sheets)
         filtered = {
-            name: self._filter_sheet(sheet, include_auto_override=include_auto_override)
+            name: self._filter_sheet(
+                sheet,
+                include_auto_override=include_auto_override,
+                areas=targets.get(name) if targets is not None else None,
+            )
             for name, sheet in wb.sheets.items()
+            if self.output.filters.sheet_selected(name)
+            and (targets is None or name in targets)
         }
-        return WorkbookData(book_name=wb.book_name, sheets=filtered)
+        # Rebuilt from the filtered sheets so excluded charts/tables drop out.
+        return WorkbookData(
+            book_name=wb.book_name,
+            sheets=filtered,
+            chart_sources=build_chart_source_index(filtered),
+            table_families=filter_table_families(wb.table_families, filtered),
+            warnings=wb.warnings,
+            pivot_caches=wb.pivot_caches,
+            power_queries=wb.power_queries,
+            connections=wb.connections,
+            security=wb.security,
+            styles=wb.styles,
+            metrics=wb.metrics,
+        )
 
     @staticmethod
     def _ensure_path(path: str | Path) -> Path:
@@ -631,43 +1318,264 @@ class ExStructEngine:
             or effective_auto_page_breaks_dir is not None
         )
 
+    def open(
+        self, file_path: str | Path, *, mode: ExtractionMode | None = None
+    ) -> WorkbookHandle:
+        """Extract a workbook and keep it for partial re-extraction.
+
+        Parameters:
+            file_path (str | Path): Path to the .xlsx/.xlsm/.xls file to extract.
+            mode (ExtractionMode | None): Extraction mode; None uses the engine's.
+
+        Returns:
+            WorkbookHandle: Handle holding the extracted workbook; mark edited
+            sheets with ``invalidate`` and call ``refresh`` to re-extract them.
+        """
+        from .handle import WorkbookHandle
+
+        return WorkbookHandle(
+            self,
+            self._ensure_path(file_path),
+            mode=mode or self.options.mode,
+            include_auto_page_breaks=self._resolve_include_auto_page_breaks(),
+        )
+
     def _extract_workbook_with_options(
         self,
         file_path: str | Path,
         *,
         mode: ExtractionMode,
         include_auto_page_breaks: bool,
+        sheets: Collection[str] | None = None,
     ) -> WorkbookData:
         """Extract a workbook with already-resolved validation-sensitive options."""
+        from .core.workbook import shared_openpyxl_workbooks
 
+        with route_logs_to(self.options.logger), shared_openpyxl_workbooks():
+            return self._extract_and_transform(
+                file_path,
+                mode=mode,
+                include_auto_page_breaks=include_auto_page_breaks,
+                sheets=sheets,
+            )
+
+    def _extract_and_transform(
+        self,
+        file_path: str | Path,
+        *,
+        mode: ExtractionMode,
+        include_auto_page_breaks: bool,
+        sheets: Collection[str] | None = None,
+    ) -> WorkbookData:
+        """Run extraction, alpha_col conversion, and transforms.
+
+        With ``sheets`` only those sheets are extracted and the workbook-level
+        parts (pivot caches, Power Query, connections, security, metrics) are
+        skipped.
+        """
+
+        from .core.limits import (
+            check_package_limits,
+            clip_sheet_extents,
+            enforce_workbook_limits,
+        )
+
+        started = time.monotonic()
         normalized_file_path = validate_libreoffice_extraction_request(
             file_path,
             mode=mode,
             include_auto_page_breaks=include_auto_page_breaks,
         )
+        limits = self.options.limits
+        if limits.on_exceed == "error" and not self.options.best_effort:
+            check_package_limits(
+                normalized_file_path,
+                max_cells=limits.max_cells,
+                max_sheets=limits.max_sheets,
+            )
         with self._table_params_scope():
             workbook = extract_workbook(
                 normalized_file_path,
                 mode=mode,
                 include_cell_links=self.options.include_cell_links,
-                include_print_areas=None,
+                include_print_areas=self.options.components.print_areas,
                 include_auto_page_breaks=include_auto_page_breaks,
                 include_colors_map=self.options.include_colors_map,
                 include_default_background=self.options.colors.include_default_background,
                 ignore_colors=self.options.colors.ignore_colors_set(),
-                include_formulas_map=self.options.include_formulas_map,
+                include_formulas_map=True
+                if (
+                    self.options.include_formulas_r1c1
+                    or self.options.compress_formulas
+                )
+                and self.options.include_formulas_map is None
+                else self.options.include_formulas_map,
                 include_merged_cells=self.options.include_merged_cells,
                 include_merged_values_in_rows=self.options.include_merged_values_in_rows,
+                include_dimensions=self.options.include_dimensions,
+                include_cell_errors=self.options.include_cell_errors,
+                include_outline=self.options.include_outline,
+                include_covered_ranges=self.options.include_covered_ranges,
+                include_cells=self.options.components.cells,
+                include_shapes=self.options.components.shapes,
+                include_charts=self.options.components.charts,
+                include_tables=self.options.components.tables,
+                best_effort=self.options.best_effort,
+                fast_cells=self.options.fast_cells,
+                stable_ids=self.options.stable_ids,
+                include_shape_paths=self.options.include_shape_paths,
+                position_unit=self.options.position_unit,
+                position_dpi=self.options.position_dpi,
+                locale=self.options.locale,
+                include_phonetic=self.options.include_phonetic,
+                infer_print_areas=self.options.infer_print_areas,
+                sheets=sheets,
+            )
+        workbook = clip_sheet_extents(
+            workbook,
+            max_rows=limits.max_rows_per_sheet,
+            max_cols=limits.max_cols_per_sheet,
+        )
+        workbook = enforce_workbook_limits(
+            workbook,
+            max_cells=limits.max_cells,
+            max_sheets=limits.max_sheets,
+            truncate=limits.on_exceed == "truncate",
+        )
+        if self.options.sample_rows is not None:
+            from .core.sampling import sample_workbook_rows
+
+            sample = self.options.sample_rows
+            workbook = sample_workbook_rows(
+                workbook, strategy=sample.strategy, n=sample.n, seed=sample.seed
+            )
+        if self.options.include_formulas_r1c1:
+            from .analysis import r1c1_formulas_map
+
+            for sheet in workbook.sheets.values():
+                sheet.formulas_map_r1c1 = r1c1_formulas_map(sheet.formulas_map)
+        if self.options.compress_formulas:
+            from .analysis import compress_formulas
+
+            for sheet in workbook.sheets.values():
+                sheet.formula_blocks, sheet.formulas_map = compress_formulas(
+                    sheet.formulas_map
+                )
+        if self.options.rank_tables:
+            self._rank_tables(workbook, normalized_file_path)
+        if sheets is None:
+            self._attach_workbook_parts(workbook, normalized_file_path)
+        if self.options.include_named_ranges or any(
+            target.startswith("name:") for target in self.output.filters.ranges or []
+        ):
+            self._attach_named_ranges(workbook, normalized_file_path)
+        if self.options.include_styles:
+            self._attach_styles(workbook, normalized_file_path)
+        if self.options.include_table_stats:
+            from .analysis import table_column_stats
+            from .core.cells import extract_number_formats
+
+            number_formats = extract_number_formats(
+                normalized_file_path,
+                {
+                    name: sheet.table_candidates
+                    for name, sheet in workbook.sheets.items()
+                },
+            )
+            for name, sheet in workbook.sheets.items():
+                sheet.table_stats = table_column_stats(
+                    sheet.rows,
+                    sheet.table_candidates,
+                    number_formats=number_formats.get(name),
+                )
+        if self.options.include_metrics and sheets is None:
+            from .metrics import collect_metrics
+
+            workbook.metrics = collect_metrics(
+                workbook,
+                normalized_file_path,
+                extract_seconds=time.monotonic() - started,
             )
         if self.options.alpha_col:
             workbook = convert_workbook_keys_to_alpha(workbook)
+        if self.options.normalize_text:
+            from .text_normalization import TextNormalizer
+
+            TextNormalizer()(workbook)
+        return self._apply_transforms(workbook)
+
+    def _attach_workbook_parts(self, workbook: WorkbookData, file_path: Path) -> None:
+        """Fill the requested workbook-level parts that no sheet owns."""
+        if self.options.include_pivot_caches:
+            from .ooxml.pivot import read_pivot_caches
+
+            workbook.pivot_caches = read_pivot_caches(file_path)
+        if self.options.include_power_queries:
+            from .ooxml.power_query import read_power_queries
+
+            workbook.power_queries = read_power_queries(file_path)
+        if self.options.include_connections:
+            from .ooxml.connections import read_connections
+
+            workbook.connections = read_connections(file_path)
+        if self.options.include_security_report:
+            from .ooxml.signatures import read_security_report
+
+            workbook.security = read_security_report(file_path)
+
+    @staticmethod
+    def _attach_named_ranges(workbook: WorkbookData, file_path: Path) -> None:
+        """Fill SheetData.named_ranges from the workbook's defined names."""
+        from .core.cells import extract_named_ranges
+
+        named = extract_named_ranges(file_path)
+        for name, sheet in workbook.sheets.items():
+            sheet.named_ranges = named.get(name, [])
+
+    @staticmethod
+    def _attach_styles(workbook: WorkbookData, file_path: Path) -> None:
+        """Fill WorkbookData.styles and each sheet's style_map."""
+        from .ooxml.styles import read_style_maps, read_style_table
+
+        workbook.styles = read_style_table(file_path)
+        style_maps = read_style_maps(file_path)
+        for name, sheet in workbook.sheets.items():
+            sheet.style_map = style_maps.get(name, {})
+
+    def _rank_tables(self, workbook: WorkbookData, file_path: Path) -> None:
+        """Score table candidates and sort them by descending confidence."""
+        from .analysis import rank_table_candidates, table_confidence_scores
+        from .core.cells import extract_table_style_signals
+
+        signals = extract_table_style_signals(
+            file_path,
+            {name: sheet.table_candidates for name, sheet in workbook.sheets.items()},
+        )
+        for name, sheet in workbook.sheets.items():
+            sheet.table_scores = table_confidence_scores(
+                sheet.rows, sheet.table_candidates, signals.get(name)
+            )
+            sheet.table_candidates = rank_table_candidates(
+                sheet.table_candidates, sheet.table_scores
+            )
+
+    def _apply_transforms(self, workbook: WorkbookData) -> WorkbookData:
+        """Run StructOptions.transforms in order."""
+        for transform in self.options.transforms:
+            name = getattr(transform, "__name__", repr(transform))
+            try:
+                result = transform(workbook)
+            except Exception as exc:
+                raise ExtractionError(f"Transform '{name}' failed: {exc}") from exc
+            if result is not None:
+                workbook = result
         return workbook
 
     def serialize(
         self,
         data: WorkbookData,
         *,
-        fmt: Literal["json", "yaml", "yml", "toon"] | None = None,
+        fmt: TextFormat | None = None,
         pretty: bool | None = None,
         indent: int | None = None,
     ) -> str:
@@ -679,38 +1587,63 @@ class ExStructEngine:
             fmt: Serialization format; defaults to OutputOptions.format.fmt.
             pretty: Whether to pretty-print JSON output.
             indent: Indentation to use when pretty-printing JSON.
+
+        Raises:
+            SerializationError: If the format is sqlite, which only writes files.
+            LimitExceededError: If the output exceeds LimitsOptions.max_output_bytes.
         """
+        from .core.limits import check_output_size
+
         filtered = self._filter_workbook(data)
-        use_fmt = fmt or self.output.format.fmt
+        chosen_fmt = fmt or self.output.format.fmt
+        if chosen_fmt == "sqlite":
+            raise SerializationError(
+                "sqlite output is a database file; use export() with output_path."
+            )
+        use_fmt = cast(TextFormat, chosen_fmt)
         use_pretty = self.output.format.pretty if pretty is None else pretty
         use_indent = self.output.format.indent if indent is None else indent
 
-        return serialize_workbook(
+        text = serialize_workbook(
             filtered,
             fmt=use_fmt,
             pretty=use_pretty,
             indent=use_indent,
             include_backend_metadata=self.output.filters.include_backend_metadata,
+            jq=self.output.format.jq,
+            query=self.output.format.query,
+            fields=self.output.format.fields,
+            dedupe_strings=self.output.format.dedupe_strings,
         )
+        check_output_size(text, self.options.limits.max_output_bytes)
+        return text
 
     def export(
         self,
         data: WorkbookData,
         output_path: str | Path | None = None,
         *,
-        fmt: Literal["json", "yaml", "yml", "toon"] | None = None,
+        fmt: OutputFormat | str | None = None,
         pretty: bool | None = None,
         indent: int | None = None,
         sheets_dir: str | Path | None = None,
         print_areas_dir: str | Path | None = None,
         auto_page_breaks_dir: str | Path | None = None,
+        tables_dir: str | Path | None = None,
+        shapes_dir: str | Path | None = None,
+        charts_dir: str | Path | None = None,
+        chart_images_dir: str | Path | None = None,
+        snapshots_dir: str | Path | None = None,
         stream: TextIO | None = None,
     ) -> None:
         """
         Write filtered workbook data to a file or stream.
 
-        Includes optional per-sheet and per-print-area outputs when destinations are
-        provided.
+        Includes optional per-sheet, per-print-area, and per-sheet shapes/charts
+        outputs when destinations are provided. A ``.gz``/``.zst`` output_path is compressed, and
+        DestinationOptions.split_size splits it into numbered parts. The sqlite
+        format writes a database to output_path instead of text, and a registered
+        encoder name writes that encoder's bytes to output_path or the stream.
 
         Args:
             data: Workbook to serialize and write.
@@ -722,9 +1655,18 @@ class ExStructEngine:
             print_areas_dir: Directory for per-print-area outputs when provided (str or Path).
             auto_page_breaks_dir: Directory for auto page-break outputs (str or Path; COM
                 environments only).
+            tables_dir: Directory for Parquet/Arrow table outputs (str or Path).
+            shapes_dir: Directory for per-sheet shapes outputs (str or Path).
+            charts_dir: Directory for per-sheet charts outputs (str or Path).
+            chart_images_dir: Directory for chart preview images (str or Path).
+            snapshots_dir: Directory for sheet snapshot images (str or Path).
             stream: Stream override when output_path is None.
+
+        Raises:
+            ConfigError: If sqlite is written to stdout, sqlite/events/text or an
+                encoder format is combined with per-file outputs, or payload
+                transforms are set for sqlite or an encoder format.
         """
-        text = self.serialize(data, fmt=fmt, pretty=pretty, indent=indent)
         target_stream = stream or self.output.destinations.stream
         chosen_fmt = fmt or self.output.format.fmt
         chosen_sheets_dir = (
@@ -742,6 +1684,31 @@ class ExStructEngine:
             if auto_page_breaks_dir is not None
             else self.output.destinations.auto_page_breaks_dir
         )
+        chosen_tables_dir = (
+            tables_dir
+            if tables_dir is not None
+            else self.output.destinations.tables_dir
+        )
+        chosen_shapes_dir = (
+            shapes_dir
+            if shapes_dir is not None
+            else self.output.destinations.shapes_dir
+        )
+        chosen_charts_dir = (
+            charts_dir
+            if charts_dir is not None
+            else self.output.destinations.charts_dir
+        )
+        chosen_chart_images_dir = (
+            chart_images_dir
+            if chart_images_dir is not None
+            else self.output.destinations.chart_images_dir
+        )
+        chosen_snapshots_dir = (
+            snapshots_dir
+            if snapshots_dir is not None
+            else self.output.destinations.snapshots_dir
+        )
 
         normalized_output_path = self._ensure_optional_path(output_path)
         normalized_sheets_dir = self._ensure_optional_path(chosen_sheets_dir)
@@ -750,16 +1717,56 @@ class ExStructEngine:
             chosen_auto_page_breaks_dir
         )
 
-        if normalized_output_path is not None:
-            normalized_output_path.write_text(text, encoding="utf-8")
+        has_side_outputs = any(
+            target is not None
+            for target in (
+                chosen_sheets_dir,
+                chosen_print_areas_dir,
+                chosen_auto_page_breaks_dir,
+                chosen_shapes_dir,
+                chosen_charts_dir,
+            )
+        )
+        encoder = get_encoder(chosen_fmt)
+        whole_workbook_fmt = encoder is not None or chosen_fmt == "sqlite"
+        if has_side_outputs and (
+            whole_workbook_fmt or chosen_fmt in ("events", "text", "markdown")
+        ):
+            raise ConfigError(
+                f"{chosen_fmt} format cannot be combined with per-sheet, "
+                "per-print-area, auto page-break, shapes, or charts outputs."
+            )
+        if whole_workbook_fmt:
+            self._reject_payload_transforms(chosen_fmt)
+        # Formats are checked above, so the casts only narrow the type.
+        text_fmt = cast(TextFormat, chosen_fmt)
+        side_fmt = cast(SideOutputFormat, chosen_fmt)
+        if encoder is not None:
+            self._export_encoded(data, normalized_output_path, encoder, target_stream)
+        elif chosen_fmt == "sqlite":
+            self._export_sqlite(data, normalized_output_path)
+        elif normalized_output_path is not None:
+            self._write_output(
+                data,
+                normalized_output_path,
+                fmt=text_fmt,
+                pretty=pretty,
+                indent=indent,
+            )
         elif (
             normalized_output_path is None
             and chosen_sheets_dir is None
             and chosen_print_areas_dir is None
             and chosen_auto_page_breaks_dir is None
+            and chosen_tables_dir is None
+            and chosen_shapes_dir is None
+            and chosen_charts_dir is None
+            and chosen_chart_images_dir is None
+            and chosen_snapshots_dir is None
         ):
             import sys
 
+            text = self.serialize(data, fmt=text_fmt, pretty=pretty, indent=indent)
             stream_target = target_stream or sys.stdout
             stream_target.write(text)
             if not text.endswith("\n"):
@@ -770,10 +1777,11 @@ class ExStructEngine:
             save_sheets(
                 filtered,
                 normalized_sheets_dir,
-                fmt=chosen_fmt,
+                fmt=side_fmt,
                 pretty=self.output.format.pretty if pretty is None else pretty,
                 indent=self.output.format.indent if indent is None else indent,
                 include_backend_metadata=self.output.filters.include_backend_metadata,
+                rows_per_file=self.output.destinations.rows_per_file,
             )
 
         if normalized_print_areas_dir is not None:
@@ -783,7 +1791,7 @@ class ExStructEngine:
                 save_print_area_views(
                     filtered,
                     normalized_print_areas_dir,
-                    fmt=chosen_fmt,
+                    fmt=side_fmt,
                     pretty=self.output.format.pretty if pretty is None else pretty,
                     indent=self.output.format.indent if indent is None else indent,
                     include_shapes=self.output.filters.include_shapes,
@@ -799,7 +1807,7 @@ class ExStructEngine:
             save_auto_page_break_views(
                 filtered,
                 normalized_auto_page_breaks_dir,
-                fmt=chosen_fmt,
+                fmt=side_fmt,
                 pretty=self.output.format.pretty if pretty is None else pretty,
                 indent=self.output.format.indent if indent is None else indent,
                 include_shapes=self.output.filters.include_shapes,
@@ -809,8 +1817,194 @@ class ExStructEngine:
                 include_backend_metadata=self.output.filters.include_backend_metadata,
             )
 
+        self._export_components(
+            data,
+            self._ensure_optional_path(chosen_shapes_dir),
+            self._ensure_optional_path(chosen_charts_dir),
+            fmt=side_fmt,
+            pretty=self.output.format.pretty if pretty is None else pretty,
+            indent=self.output.format.indent if indent is None else indent,
+        )
+
+        normalized_tables_dir = self._ensure_optional_path(chosen_tables_dir)
+        if normalized_tables_dir is not None:
+            save_tables(
+                self._filter_workbook(data),
+                normalized_tables_dir,
+                fmt=self.output.destinations.tables_format,
+            )
+
+        normalized_chart_images_dir = self._ensure_optional_path(
+            chosen_chart_images_dir
+        )
+        if normalized_chart_images_dir is not None:
+            save_chart_images(
+                self._filter_workbook(data),
+                normalized_chart_images_dir,
+                fmt=self.output.destinations.chart_images_format,
+            )
+
+        normalized_snapshots_dir = self._ensure_optional_path(chosen_snapshots_dir)
+        if normalized_snapshots_dir is not None:
+            save_sheet_snapshots(
+                self._filter_workbook(data),
+                normalized_snapshots_dir,
+                self.output.destinations.snapshots_format,
+                unit=self.options.position_unit or "pixels",
+                dpi=self.options.position_dpi or 96,
+            )
+
         return None
 
+    def _export_components(
+        self,
+        data: WorkbookData,
+        shapes_dir: Path | None,
+        charts_dir: Path | None,
+        *,
+        fmt: SideOutputFormat,
+        pretty: bool,
+        indent: int | None,
+    ) -> None:
+        """Write per-sheet shapes/charts files for the requested directories."""
+        if shapes_dir is None and charts_dir is None:
+            return
+        filtered = self._filter_workbook(data)
+        include_backend_metadata = self.output.filters.include_backend_metadata
+        if shapes_dir is not None:
+            save_shapes(
+                filtered,
+                shapes_dir,
+                fmt=fmt,
+                pretty=pretty,
+                indent=indent,
+                include_backend_metadata=include_backend_metadata,
+            )
+        if charts_dir is not None:
+            save_charts(
+                filtered,
+                charts_dir,
+                fmt=fmt,
+                pretty=pretty,
+                indent=indent,
+                include_backend_metadata=include_backend_metadata,
+            )
+
+    def _write_output(
+        self,
+        data: WorkbookData,
+        output_path: Path,
+        *,
+        fmt: TextFormat,
+        pretty: bool | None,
+        indent: int | None,
+    ) -> None:
+        """Write the serialized workbook to ``output_path``.
+
+        Unsplit JSON is encoded straight into the file instead of being built
+        as one string first; other formats go through ``serialize``.
+        """
+        split_size = self.output.destinations.split_size
+        if fmt != "json" or split_size is not None:
+            write_output_text(
+                output_path,
+                self.serialize(data, fmt=fmt, pretty=pretty, indent=indent),
+                split_size=split_size,
+            )
+            return
+        payload = workbook_payload(
+            self._filter_workbook(data),
+            include_backend_metadata=self.output.filters.include_backend_metadata,
+            jq=self.output.format.jq,
+            query=self.output.format.query,
+            fields=self.output.format.fields,
+            dedupe_strings=self.output.format.dedupe_strings,
+        )
+        use_pretty = self.output.format.pretty if pretty is None else pretty
+        use_indent = self.output.format.indent if indent is None else indent
+        write_output_json(
+            output_path,
+            payload,
+            indent=2 if use_pretty and use_indent is None else use_indent,
+            max_bytes=self.options.limits.max_output_bytes,
+        )
+
+    def _reject_payload_transforms(self, fmt: str) -> None:
+        """Reject jq/query/fields/dedupe_strings for formats without a payload.
+
+        Raises:
+            ConfigError: If any payload transform is configured.
+        """
+        if self.output.format.jq is not None:
+            raise ConfigError(f"jq expressions cannot be applied to {fmt} output.")
+        if self.output.format.query is not None:
+            raise ConfigError(f"query expressions cannot be applied to {fmt} output.")
+        if self.output.format.fields:
+            raise ConfigError(f"field projections cannot be applied to {fmt} output.")
+        if self.output.format.dedupe_strings:
+            raise ConfigError(
+                f"string dictionary encoding cannot be applied to {fmt} output."
+            )
+
+    def _export_encoded(
+        self,
+        data: WorkbookData,
+        output_path: Path | None,
+        encoder: Encoder,
+        stream: TextIO | None,
+    ) -> None:
+        """Write the filtered workbook with a registered encoder.
+
+        Output goes to ``output_path`` when given; otherwise the bytes are
+        written to the stream's binary buffer, or decoded as UTF-8 for text-only
+        streams.
+
+        Raises:
+            SerializationError: If binary output targets a text-only stream.
+        """
+        filtered = self._filter_workbook(data)
+        if output_path is not None:
+            write_output_encoded(
+                output_path, lambda sink: encoder.encode(sink, filtered)
+            )
+            return
+        import io
+        import sys
+
+        target = stream or sys.stdout
+        buffer = io.BytesIO()
+        encoder.encode(buffer, filtered)
+        binary = getattr(target, "buffer", None)
+        if binary is not None:
+            target.flush()
+            binary.write(buffer.getvalue())
+            binary.flush()
+            return
+        try:
+            target.write(buffer.getvalue().decode("utf-8"))
+        except UnicodeDecodeError as exc:
+            raise SerializationError(
+                f"{encoder.name} output is binary; write it to an output path."
+            ) from exc
+
+    def _export_sqlite(
+        self,
+        data: WorkbookData,
+        output_path: Path | None,
+    ) -> None:
+        """Write the filtered workbook to a SQLite database file.
+
+        Raises:
+            ConfigError: If no output path is given.
+        """
+        if output_path is None:
+            raise ConfigError("sqlite format requires an output path.")
+        save_as_sqlite(
+            self._filter_workbook(data),
+            output_path,
+            include_backend_metadata=self.output.filters.include_backend_metadata,
+        )
+
     def process(
         self,
         file_path: str | Path,
@@ -827,6 +2021,7 @@ class ExStructEngine:
         print_areas_dir: str | Path | None = None,
         auto_page_breaks_dir: str | Path | None = None,
         stream: TextIO | None = None,
+        report: RunReport | None = None,
     ) -> None:
         """
         One-shot extract->export wrapper (CLI equivalent) with optional PDF/PNG output.
@@ -834,7 +2029,10 @@ class ExStructEngine:
         Args:
             file_path: Input Excel workbook path (str or Path).
             output_path: Target file path (str or Path); writes to stdout when None.
-            out_fmt: Serialization format for structured output.
+            out_fmt: Serialization format for structured output. For
+                ``markdown``, shape covered ranges are computed unless
+                ``StructOptions.include_covered_ranges`` is set explicitly, so
+                shape callouts can be placed at their rows.
             image: Whether to export PNGs alongside structured output. Requires Excel
                 COM and is not supported in `mode="libreoffice"`.
             pdf: Whether to export a PDF snapshot alongside structured output.
@@ -848,6 +2046,11 @@ class ExStructEngine:
             auto_page_breaks_dir: Directory for auto page-break outputs (str or Path).
                 Requires Excel COM and is not supported in `mode="libreoffice"`.
             stream: Stream override when writing to stdout.
+            report: Run report that receives per-component durations and the
+                extracted workbook's counts and warnings.
+
+        DestinationOptions.dump_parts_dir additionally copies the workbook's raw
+        drawing, chart, and table XML parts into that directory.
 
         Raises:
             ConfigError: If `mode="libreoffice"` is combined with PDF/PNG rendering
@@ -878,37 +2081,55 @@ class ExStructEngine:
             image=image,
         )
 
-        if normalized_auto_page_breaks_dir is None:
-            wb = self.extract(normalized_file_path, mode=chosen_mode)
-        else:
-            wb = self.extract(
-                normalized_file_path,
-                mode=chosen_mode,
-                _auto_page_breaks_dir_override=effective_auto_page_breaks_dir,
-            )
         chosen_fmt = out_fmt or self.output.format.fmt
-        self.export(
-            wb,
-            output_path=normalized_output_path,
-            fmt=chosen_fmt,  # type: ignore[arg-type]
-            pretty=pretty,
-            indent=indent,
-            sheets_dir=normalized_sheets_dir,
-            print_areas_dir=normalized_print_areas_dir,
-            auto_page_breaks_dir=effective_auto_page_breaks_dir,
-            stream=stream,
+        extractor = self
+        if chosen_fmt == "markdown" and self.options.include_covered_ranges is None:
+            extractor = ExStructEngine(
+                replace(self.options, include_covered_ranges=True), self.output
+            )
+        with _timed(report, "extract"):
+            if normalized_auto_page_breaks_dir is None:
+                wb = extractor.extract(normalized_file_path, mode=chosen_mode)
+            else:
+                wb = extractor.extract(
+                    normalized_file_path,
+                    mode=chosen_mode,
+                    _auto_page_breaks_dir_override=effective_auto_page_breaks_dir,
+                )
+        if report is not None:
+            report.record_workbook(wb)
+        with _timed(report, "export"):
+            self.export(
+                wb,
+                output_path=normalized_output_path,
+                fmt=chosen_fmt,
+                pretty=pretty,
+                indent=indent,
+                sheets_dir=normalized_sheets_dir,
+                print_areas_dir=normalized_print_areas_dir,
+                auto_page_breaks_dir=effective_auto_page_breaks_dir,
+                stream=stream,
+            )
+        dump_parts_dir = self._ensure_optional_path(
+            self.output.destinations.dump_parts_dir
         )
+        if dump_parts_dir is not None:
+            with _timed(report, "dump_parts"):
+                dump_parts(normalized_file_path, dump_parts_dir)
 
         if pdf or image:
+            if normalized_output_path is not None:
+                from .io.output import strip_compression_suffix
+
+                normalized_output_path = strip_compression_suffix(
+                    normalized_output_path
+                )
             base_target = normalized_output_path or normalized_file_path.with_suffix(
-                ".yaml"
-                if chosen_fmt in ("yaml", "yml")
-                else ".toon"
-                if chosen_fmt == "toon"
-                else ".json"
+                format_suffix(chosen_fmt)
             )
             pdf_path = base_target.with_suffix(".pdf")
-            export_pdf(normalized_file_path, pdf_path)
-            if image:
-                images_dir = pdf_path.parent / f"{pdf_path.stem}_images"
-                export_sheet_images(normalized_file_path, images_dir, dpi=dpi)
+            with _timed(report, "render"):
+                export_pdf(normalized_file_path, pdf_path)
+                if image:
+                    images_dir = pdf_path.parent / f"{pdf_path.stem}_images"
+                    export_sheet_images(normalized_file_path, images_dir, dpi=dpi)
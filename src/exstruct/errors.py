@@ -33,6 +33,14 @@ class OutputError(ExstructError):
     """Raised when writing outputs to disk or streams fails."""
 
 
+class LimitExceededError(ExstructError):
+    """Raised when a workbook or its output exceeds a configured size limit."""
+
+
+class UnsafeWorkbookError(ExstructError):
+    """Raised when a workbook package looks malicious (zip bomb, XML entities)."""
+
+
 class PrintAreaError(ExstructError, ValueError):
     """Raised when print-area specific processing fails (also a ValueError for compatibility)."""
 
@@ -0,0 +1,44 @@
+"""Coordinate mapping between A1 addresses, cells, and sheet offsets.
+
+Shape and chart positions (``l``/``t``/``w``/``h``) are offsets from the
+top-left corner of the sheet; ``SheetGeometry`` relates them to cells using
+the extracted column widths and row heights, and the EMU helpers convert raw
+DrawingML coordinates.
+"""
+
+from exstruct.geometry.a1 import format_cell, format_range, parse_cell, parse_range
+from exstruct.geometry.grid import (
+    CellRect,
+    SheetGeometry,
+    column_width_to_pixels,
+    column_width_to_points,
+    default_column_width_from_base,
+    max_digit_width,
+)
+from exstruct.ooxml.units import (
+    PositionScale,
+    PositionUnit,
+    emu_to_pixels,
+    emu_to_points,
+    pixels_to_emu,
+    points_to_emu,
+)
+
+__all__ = [
+    "CellRect",
+    "PositionScale",
+    "PositionUnit",
+    "SheetGeometry",
+    "column_width_to_pixels",
+    "column_width_to_points",
+    "default_column_width_from_base",
+    "emu_to_pixels",
+    "emu_to_points",
+    "format_cell",
+    "format_range",
+    "max_digit_width",
+    "parse_cell",
+    "parse_range",
+    "pixels_to_emu",
+    "points_to_emu",
+]
@@ -0,0 +1,99 @@
+"""A1 address parsing and formatting in the extractor's coordinate convention.
+
+Rows are 1-based and columns 0-based, matching ``CellRow.r`` and the column
+keys of ``CellRow.c``.
+"""
+
+from __future__ import annotations
+
+import re
+
+from ..models import col_alpha_to_index, col_index_to_alpha
+
+_CELL_PATTERN = re.compile(r"^\$?([A-Za-z]{1,3})\$?([1-9][0-9]*)$")
+
+
+def _strip_sheet(ref: str) -> str:
+    text = ref.strip().removeprefix("=")
+    # Quoted sheet names may contain "!", the cell reference never does.
+    return text.rsplit("!", 1)[1] if "!" in text else text
+
+
+def parse_cell(ref: str) -> tuple[int, int]:
+    """Parse an A1 cell address into (row, col).
+
+    Accepts ``$`` markers and a ``Sheet!`` prefix.
+
+    Args:
+        ref: Cell address such as ``"B3"`` or ``"Sheet1!$B$3"``.
+
+    Returns:
+        (1-based row, 0-based column).
+
+    Raises:
+        ValueError: If ``ref`` is not a cell address.
+
+    Examples:
+        >>> parse_cell("B3")
+        (3, 1)
+    """
+    match = _CELL_PATTERN.match(_strip_sheet(ref))
+    if match is None:
+        raise ValueError(f"Invalid cell address: {ref!r}")
+    return int(match.group(2)), col_alpha_to_index(match.group(1))
+
+
+def format_cell(row: int, col: int) -> str:
+    """Format (1-based row, 0-based column) as an A1 address.
+
+    Raises:
+        ValueError: If row is below 1 or col is negative.
+
+    Examples:
+        >>> format_cell(3, 1)
+        'B3'
+    """
+    if row < 1:
+        raise ValueError(f"Row must be 1 or greater, got {row}")
+    return f"{col_index_to_alpha(col)}{row}"
+
+
+def parse_range(ref: str) -> tuple[int, int, int, int]:
+    """Parse an A1 range (or single cell) into normalized bounds.
+
+    Args:
+        ref: Range such as ``"A1:C5"``, ``"Sheet1!$C$5:$A$1"``, or ``"B2"``.
+
+    Returns:
+        (r1, c1, r2, c2) with 1-based rows, 0-based columns, and r1 <= r2,
+        c1 <= c2.
+
+    Raises:
+        ValueError: If ``ref`` is not a range.
+
+    Examples:
+        >>> parse_range("C5:A1")
+        (1, 0, 5, 2)
+    """
+    local = _strip_sheet(ref)
+    start, _, end = local.partition(":")
+    r1, c1 = parse_cell(start)
+    r2, c2 = parse_cell(end) if end else (r1, c1)
+    return min(r1, r2), min(c1, c2), max(r1, r2), max(c1, c2)
+
+
+def format_range(r1: int, c1: int, r2: int, c2: int) -> str:
+    """Format bounds as an A1 range, collapsing single cells to one address.
+
+    Examples:
+        >>> format_range(1, 0, 5, 2)
+        'A1:C5'
+        >>> format_range(2, 1, 2, 1)
+        'B2'
+    """
+    start = format_cell(r1, c1)
+    end = format_cell(r2, c2)
+    return start if start == end else f"{start}:{end}"
+
+
+__all__ = ["format_cell", "format_range", "parse_cell", "parse_range"]
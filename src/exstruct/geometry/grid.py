@@ -0,0 +1,355 @@
+"""Sheet grid geometry: cell positions from column widths and row heights.
+
+Column widths are stored by Excel in character units of the workbook's
+default font and row heights in points. Widths are converted to pixels with
+the ECMA-376 formula (Part 1, 18.3.1.13) using the maximum digit width of that
+font; rows and columns without an explicit size use the sheet defaults.
+"""
+
+from __future__ import annotations
+
+from bisect import bisect_left, bisect_right
+from collections.abc import Mapping
+from dataclasses import dataclass
+import math
+from typing import NamedTuple
+
+from ..models import SheetData
+from ..ooxml.units import DEFAULT_DPI, POINTS_PER_INCH, PositionScale, PositionUnit
+from .a1 import format_range, parse_range
+
+MAX_COLUMNS = 16384
+MAX_ROWS = 1048576
+DEFAULT_BASE_COLUMN_WIDTH = 8
+DEFAULT_ROW_HEIGHT_POINTS = 15.0
+DEFAULT_MAX_DIGIT_WIDTH = 7  # Calibri 11, the default font of new workbooks.
+_COLUMN_PADDING_PIXELS = 5
+_POINTS_PER_PIXEL = 0.75
+# Advance width of the digits 0-9 as a fraction of the em. Values marked
+# "calibrated" reproduce Excel's default column width (72px at 11pt for the
+# Japanese defaults) rather than the font metrics, as Excel rounds hinted
+# glyph widths.
+_DIGIT_WIDTH_EM: dict[str, float] = {
+    "calibri": 1038 / 2048,
+    "aptos narrow": 1038 / 2048,  # calibrated
+    "arial": 1139 / 2048,
+    "helvetica": 1139 / 2048,
+    "times new roman": 1024 / 2048,
+    "tahoma": 1118 / 2048,
+    "verdana": 1303 / 2048,
+    "courier new": 1229 / 2048,
+    "consolas": 1126 / 2048,
+    "yu gothic": 0.55,  # calibrated
+    "游ゴシック": 0.55,  # calibrated
+    "ms pgothic": 0.55,  # calibrated
+    "ｍｓ ｐゴシック": 0.55,  # calibrated
+}
+
+
+def max_digit_width(font_name: str | None, size: float | None) -> int:
+    """Return the maximum digit width in pixels (96 DPI) of a font.
+
+    Unknown fonts use Calibri's digit width scaled to ``size``.
+
+    Args:
+        font_name: Font family name (e.g. ``"Calibri"``).
+        size: Font size in points (None -> 11).
+
+    Returns:
+        Width of the widest digit in whole pixels (at least 1).
+    """
+    ratio = _DIGIT_WIDTH_EM.get(
+        (font_name or "").strip().lower(), _DIGIT_WIDTH_EM["calibri"]
+    )
+    pixels = (size or 11.0) * DEFAULT_DPI / POINTS_PER_INCH * ratio
+    return max(round(pixels), 1)
+
+
+def column_width_to_pixels(
+    width: float, max_digit_width: int = DEFAULT_MAX_DIGIT_WIDTH
+) -> int:
+    """Convert a stored column width (character units) to pixels.
+
+    Implements ``Truncate(((256 * width + Truncate(128 / mdw)) / 256) * mdw)``
+    from ECMA-376; the stored width already includes the cell padding.
+
+    Args:
+        width: Column width as stored in ``<col width>``.
+        max_digit_width: Maximum digit width of the default font in pixels.
+
+    Returns:
+        Column width in pixels (0 for hidden/zero-width columns).
+    """
+    if width <= 0:
+        return 0
+    mdw = max_digit_width
+    return math.trunc(((256 * width + math.trunc(128 / mdw)) / 256) * mdw)
+
+
+def column_width_to_points(
+    width: float, max_digit_width: int = DEFAULT_MAX_DIGIT_WIDTH
+) -> float:
+    """Convert a stored column width (character units) to points.
+
+    Args:
+        width: Column width as stored in ``<col width>``.
+        max_digit_width: Maximum digit width of the default font in pixels.
+
+    Returns:
+        Column width in points (0.0 for hidden/zero-width columns).
+    """
+    return column_width_to_pixels(width, max_digit_width) * _POINTS_PER_PIXEL
+
+
+def default_column_width_from_base(
+    base_width: float = DEFAULT_BASE_COLUMN_WIDTH,
+    max_digit_width: int = DEFAULT_MAX_DIGIT_WIDTH,
+) -> float:
+    """Return the stored width of columns without ``<col>`` entries.
+
+    Used when ``<sheetFormatPr>`` has no ``defaultColWidth``: Excel pads
+    ``baseColWidth`` digits by 5 pixels and rounds up to a multiple of 8 pixels
+    (64px, stored as 9.140625, for Calibri 11).
+
+    Args:
+        base_width: ``baseColWidth`` in characters (default 8).
+        max_digit_width: Maximum digit width of the default font in pixels.
+
+    Returns:
+        Default column width in stored character units.
+    """
+    pixels = math.trunc(base_width * max_digit_width + _COLUMN_PADDING_PIXELS)
+    pixels = math.ceil(pixels / 8) * 8
+    return math.trunc(pixels / max_digit_width * 256) / 256
+
+
+@dataclass(frozen=True)
+class _Axis:
+    """Sparse axis of explicit sizes over a default size (all in points)."""
+
+    indices: tuple[int, ...]
+    sizes: tuple[float, ...]
+    starts: tuple[float, ...]
+    default_size: float
+    first_index: int
+    limit: int
+
+    @classmethod
+    def build(
+        cls,
+        explicit: dict[int, float],
+        *,
+        default_size: float,
+        first_index: int,
+        limit: int,
+    ) -> _Axis:
+        """Build an axis with precomputed start offsets for explicit entries."""
+        indices = tuple(sorted(i for i in explicit if first_index <= i < limit))
+        sizes = tuple(explicit[i] for i in indices)
+        starts: list[float] = []
+        offset = 0.0
+        prev = first_index
+        for index, size in zip(indices, sizes, strict=True):
+            offset += (index - prev) * default_size
+            starts.append(offset)
+            offset += size
+            prev = index + 1
+        return cls(
+            indices=indices,
+            sizes=sizes,
+            starts=tuple(starts),
+            default_size=default_size,
+            first_index=first_index,
+            limit=limit,
+        )
+
+    def size(self, index: int) -> float:
+        """Return the size of the entry at ``index``."""
+        pos = bisect_left(self.indices, index)
+        if pos < len(self.indices) and self.indices[pos] == index:
+            return self.sizes[pos]
+        return self.default_size
+
+    def offset(self, index: int) -> float:
+        """Return the offset of the start edge of the entry at ``index``."""
+        pos = bisect_left(self.indices, index) - 1
+        if pos < 0:
+            return (index - self.first_index) * self.default_size
+        end = self.starts[pos] + self.sizes[pos]
+        return end + (index - self.indices[pos] - 1) * self.default_size
+
+    def locate(self, position: float) -> int:
+        """Return the index of the cell containing the given offset."""
+        if position <= 0:
+            return self.first_index
+        pos = bisect_right(self.starts, position) - 1
+        if pos >= 0:
+            start = self.starts[pos]
+            end = start + self.sizes[pos]
+            if position < end:
+                return self.indices[pos]
+            base_index = self.indices[pos] + 1
+            base_offset = end
+        else:
+            base_index = self.first_index
+            base_offset = 0.0
+        if self.default_size <= 0:
+            return min(base_index, self.limit - 1)
+        steps = int((position - base_offset) // self.default_size)
+        return min(base_index + steps, self.limit - 1)
+
+
+class CellRect(NamedTuple):
+    """Position and size of a cell or range (in the requested unit)."""
+
+    x: float
+    y: float
+    w: float
+    h: float
+
+
+@dataclass(frozen=True)
+class SheetGeometry:
+    """Map between cell coordinates and sheet offsets.
+
+    Rows are 1-based and columns 0-based, like ``CellRow``. Offsets are
+    measured from the top-left corner of A1; every method takes the unit of
+    its positions (``points`` by default, or ``pixels`` / ``emu`` /
+    ``millimeters`` like ``StructOptions.position_unit``).
+    """
+
+    columns: _Axis
+    rows: _Axis
+
+    @classmethod
+    def from_sizes(
+        cls,
+        column_widths: Mapping[str, float] | None = None,
+        row_heights: Mapping[str, float] | None = None,
+        *,
+        default_column_width: float | None = None,
+        default_row_height: float | None = None,
+        max_digit_width: int | None = None,
+    ) -> SheetGeometry:
+        """Build a geometry from extracted sizes.
+
+        Args:
+            column_widths: Widths in character units keyed by 0-based column.
+            row_heights: Heights in points keyed by 1-based row.
+            default_column_width: Default width in character units (None ->
+                Excel's default for ``baseColWidth`` 8).
+            default_row_height: Default height in points.
+            max_digit_width: Maximum digit width of the workbook default font
+                in pixels (None -> 7, Calibri 11).
+        """
+        mdw = max_digit_width or DEFAULT_MAX_DIGIT_WIDTH
+        default_width = default_column_width or default_column_width_from_base(
+            DEFAULT_BASE_COLUMN_WIDTH, mdw
+        )
+        columns = _Axis.build(
+            {
+                int(key): column_width_to_points(width, mdw)
+                for key, width in (column_widths or {}).items()
+            },
+            default_size=column_width_to_points(default_width, mdw),
+            first_index=0,
+            limit=MAX_COLUMNS,
+        )
+        rows = _Axis.build(
+            {int(key): height for key, height in (row_heights or {}).items()},
+            default_size=default_row_height or DEFAULT_ROW_HEIGHT_POINTS,
+            first_index=1,
+            limit=MAX_ROWS + 1,
+        )
+        return cls(columns=columns, rows=rows)
+
+    @classmethod
+    def from_sheet(cls, sheet: SheetData) -> SheetGeometry:
+        """Build a geometry from an extracted sheet's sizes."""
+        return cls.from_sizes(
+            sheet.column_widths,
+            sheet.row_heights,
+            default_column_width=sheet.default_column_width,
+            default_row_height=sheet.default_row_height,
+            max_digit_width=sheet.max_digit_width,
+        )
+
+    def range_rect(
+        self, ref: str, *, unit: PositionUnit = "points", dpi: int = DEFAULT_DPI
+    ) -> CellRect:
+        """Return the rectangle covered by an A1 cell or range."""
+        r1, c1, r2, c2 = parse_range(ref)
+        return self.cell_rect(r1, c1, r2, c2, unit=unit, dpi=dpi)
+
+    def cell_rect(
+        self,
+        row: int,
+        col: int,
+        row_end: int | None = None,
+        col_end: int | None = None,
+        *,
+        unit: PositionUnit = "points",
+        dpi: int = DEFAULT_DPI,
+    ) -> CellRect:
+        """Return the rectangle of a cell, or of a block up to (row_end, col_end)."""
+        scale = PositionScale(unit, dpi).points_per_unit
+        left = self.columns.offset(col)
+        top = self.rows.offset(row)
+        right = self.columns.offset((col if col_end is None else col_end) + 1)
+        bottom = self.rows.offset((row if row_end is None else row_end) + 1)
+        return CellRect(
+            left / scale, top / scale, (right - left) / scale, (bottom - top) / scale
+        )
+
+    def cell_at(
+        self,
+        x: float,
+        y: float,
+        *,
+        unit: PositionUnit = "points",
+        dpi: int = DEFAULT_DPI,
+    ) -> tuple[int, int]:
+        """Return (row, col) of the cell containing a point."""
+        scale = PositionScale(unit, dpi).points_per_unit
+        return self.rows.locate(y * scale), self.columns.locate(x * scale)
+
+    def range_at(
+        self,
+        left: float,
+        top: float,
+        width: float,
+        height: float,
+        *,
+        unit: PositionUnit = "points",
+        dpi: int = DEFAULT_DPI,
+    ) -> str:
+        """Return the A1 range covered by a rectangle such as a shape's bounds.
+
+        The right and bottom edges are exclusive, so a rectangle ending exactly
+        on a grid line stays in the prior cell.
+        """
+        scale = PositionScale(unit, dpi).points_per_unit
+        x1 = left * scale
+        y1 = top * scale
+        x2 = x1 + max(width, 0) * scale
+        y2 = y1 + max(height, 0) * scale
+        c1 = self.columns.locate(x1)
+        r1 = self.rows.locate(y1)
+        c2 = max(self.columns.locate(math.nextafter(x2, -math.inf)), c1)
+        r2 = max(self.rows.locate(math.nextafter(y2, -math.inf)), r1)
+        return format_range(r1, c1, r2, c2)
+
+
+__all__ = [
+    "DEFAULT_BASE_COLUMN_WIDTH",
+    "DEFAULT_MAX_DIGIT_WIDTH",
+    "DEFAULT_ROW_HEIGHT_POINTS",
+    "MAX_COLUMNS",
+    "MAX_ROWS",
+    "CellRect",
+    "SheetGeometry",
+    "column_width_to_pixels",
+    "column_width_to_points",
+    "default_column_width_from_base",
+    "max_digit_width",
+]
@@ -0,0 +1,220 @@
+"""Open workbook handles for partial re-extraction after edits.
+
+``ExStructEngine.open`` extracts a workbook once and keeps the result. A
+long-lived service watching the file marks what changed with ``invalidate``
+(one sheet, optionally limited to some components) and calls ``refresh``,
+which re-extracts only those sheets and components and merges them into the
+held ``WorkbookData``. Workbook-level parts (pivot caches, Power Query,
+connections, security, metrics) are re-read only after ``invalidate()``
+without a sheet name, which re-runs the full pipeline.
+"""
+
+from __future__ import annotations
+
+from collections.abc import Iterable
+from dataclasses import replace
+from pathlib import Path
+import threading
+from typing import TYPE_CHECKING, Literal, get_args
+
+from .models import SheetData, WorkbookData
+
+if TYPE_CHECKING:
+    from .engine import ExStructEngine, ExtractionMode
+
+Component = Literal["cells", "shapes", "charts", "tables", "print_areas"]
+COMPONENTS: tuple[Component, ...] = get_args(Component)
+
+# SheetData fields refreshed with each component; "cells" owns all the rest.
+_COMPONENT_FIELDS: dict[Component, frozenset[str]] = {
+    "shapes": frozenset({"shapes", "flowcharts", "shape_overlaps"}),
+    "charts": frozenset({"charts"}),
+    "tables": frozenset(
+        {"table_candidates", "table_hashes", "table_scores", "table_stats"}
+    ),
+    "print_areas": frozenset({"print_areas", "auto_print_areas"}),
+}
+_CELL_FIELDS = frozenset(SheetData.model_fields).difference(
+    *_COMPONENT_FIELDS.values()
+)
+
+
+class WorkbookHandle:
+    """Extracted workbook kept open for per-sheet, per-component refreshes.
+
+    Create it with ``ExStructEngine.open``. Invalidations accumulate until the
+    next ``refresh``; sheets invalidated with the same components are
+    re-extracted together in one pass. Safe to share between threads.
+
+    Attributes:
+        file_path: Path of the workbook.
+        mode: Extraction mode used for every (re-)extraction.
+    """
+
+    def __init__(
+        self,
+        engine: ExStructEngine,
+        file_path: Path,
+        *,
+        mode: ExtractionMode,
+        include_auto_page_breaks: bool,
+    ) -> None:
+        """Run the initial full extraction (see ``ExStructEngine.open``)."""
+        self.file_path = file_path
+        self.mode = mode
+        self._engine = engine
+        self._include_auto_page_breaks = include_auto_page_breaks
+        self._lock = threading.Lock()
+        self._stale: dict[str, frozenset[Component]] = {}
+        self._stale_workbook = False
+        self._workbook = self._extract(engine, None)
+
+    @property
+    def sheet_names(self) -> list[str]:
+        """Names of the sheets currently held, in workbook order."""
+        with self._lock:
+            return list(self._workbook.sheets)
+
+    def invalidate(
+        self,
+        sheet_name: str | None = None,
+        components: Iterable[Component] | None = None,
+    ) -> None:
+        """Mark a sheet, or some of its components, for re-extraction.
+
+        Args:
+            sheet_name: Sheet that changed. A name not held yet (new sheet) is
+                extracted in full; a sheet that no longer exists is dropped.
+                None marks the whole workbook, including added or removed
+                sheets and workbook-level parts.
+            components: Components to re-extract (``cells``, ``shapes``,
+                ``charts``, ``tables``, ``print_areas``); None means all.
+
+        Raises:
+            ValueError: If a component name is unknown.
+        """
+        selected = frozenset(COMPONENTS if components is None else components)
+        unknown = sorted(selected.difference(COMPONENTS))
+        if unknown:
+            raise ValueError(
+                f"Unknown component(s): {', '.join(unknown)}. "
+                f"Choose from: {', '.join(COMPONENTS)}."
+            )
+        with self._lock:
+            if sheet_name is None:
+                self._stale_workbook = True
+                return
+            if sheet_name not in self._workbook.sheets:
+                selected = frozenset(COMPONENTS)
+            stale = self._stale.get(sheet_name, frozenset())
+            self._stale[sheet_name] = stale | selected
+
+    def refresh(self) -> WorkbookData:
+        """Re-extract everything invalidated so far.
+
+        Returns:
+            WorkbookData: A copy of the up-to-date workbook.
+        """
+        with self._lock:
+            if self._stale_workbook:
+                self._workbook = self._extract(self._engine, None)
+            else:
+                self._refresh_sheets()
+            self._stale.clear()
+            self._stale_workbook = False
+            return self._workbook.model_copy(deep=True)
+
+    def _refresh_sheets(self) -> None:
+        """Re-extract stale sheets grouped by their stale components."""
+        groups: dict[frozenset[Component], list[str]] = {}
+        for sheet_name, components in self._stale.items():
+            groups.setdefault(components, []).append(sheet_name)
+        if not groups:
+            return
+        for components, sheet_names in groups.items():
+            partial = self._extract(self._partial_engine(components), sheet_names)
+            self._merge(partial, sheet_names, components)
+        from .analysis import build_chart_source_index, build_table_families
+
+        sheets = self._workbook.sheets
+        self._workbook.chart_sources = build_chart_source_index(sheets)
+        self._workbook.table_families = build_table_families(sheets)
+
+    def _merge(
+        self,
+        partial: WorkbookData,
+        sheet_names: list[str],
+        components: frozenset[Component],
+    ) -> None:
+        """Copy the re-extracted components of ``sheet_names`` into the workbook."""
+        fields = set(_CELL_FIELDS) if "cells" in components else set()
+        for component in components.difference({"cells"}):
+            fields |= _COMPONENT_FIELDS[component]
+        sheets = self._workbook.sheets
+        for sheet_name in sheet_names:
+            fresh = partial.sheets.get(sheet_name)
+            if fresh is None:
+                sheets.pop(sheet_name, None)
+            elif sheet_name not in sheets:
+                sheets[sheet_name] = fresh
+            else:
+                sheets[sheet_name] = sheets[sheet_name].model_copy(
+                    update={name: getattr(fresh, name) for name in fields}
+                )
+        if "cells" in components and partial.styles is not None:
+            self._workbook.styles = partial.styles
+
+    def _partial_engine(self, components: frozenset[Component]) -> ExStructEngine:
+        """Return an engine that extracts only ``components``.
+
+        Cell values are still read for a tables-only refresh because table
+        hashes, scores and statistics are computed from them.
+        """
+        from .engine import ExStructEngine
+
+        options = self._engine.options
+        if "cells" not in components:
+            options = replace(
+                options,
+                include_cell_links=False,
+                include_colors_map=False,
+                include_formulas_map=False,
+                include_cell_errors=False,
+                include_outline=False,
+                include_phonetic=False,
+                include_formulas_r1c1=False,
+                compress_formulas=False,
+                include_named_ranges=False,
+                include_styles=False,
+            )
+        if "tables" not in components:
+            options = replace(options, include_table_stats=False, rank_tables=False)
+        options = replace(
+            options,
+            components=options.components.model_copy(
+                update={
+                    "cells": bool(components & {"cells", "tables"}),
+                    "shapes": options.components.shapes and "shapes" in components,
+                    "charts": options.components.charts and "charts" in components,
+                    "tables": options.components.tables and "tables" in components,
+                    "print_areas": options.components.print_areas
+                    if "print_areas" in components
+                    else False,
+                }
+            ),
+        )
+        return ExStructEngine(options, self._engine.output)
+
+    def _extract(
+        self, engine: ExStructEngine, sheet_names: list[str] | None
+    ) -> WorkbookData:
+        """Run ``engine``'s extraction on this workbook (all sheets when None)."""
+        return engine._extract_workbook_with_options(
+            self.file_path,
+            mode=self.mode,
+            include_auto_page_breaks=self._include_auto_page_breaks,
+            sheets=sheet_names,
+        )
+
+
+__all__ = ["COMPONENTS", "Component", "WorkbookHandle"]
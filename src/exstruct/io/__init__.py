@@ -1,10 +1,11 @@
 from __future__ import annotations
 
+from collections.abc import Iterable, Mapping
 import logging
 from pathlib import Path
 import re
 import time
-from typing import Literal, cast
+from typing import Any, Literal, TypeVar, cast
 
 from ..core.ranges import RangeBounds, parse_range_zero_based
 from ..errors import OutputError, SerializationError
@@ -20,6 +21,7 @@ from ..models import (
     WorkbookData,
 )
 from ..models.types import JsonStructure
+from .output import atomic_write, write_output_json
 from .serialize import (
     _FORMAT_HINTS,
     _ensure_format_hint,
@@ -29,6 +31,14 @@ from .serialize import (
 )
 
 logger = logging.getLogger(__name__)
+TValue = TypeVar("TValue")
+
+SHEET_INDEX_FILENAME = "index.json"
+_WINDOWS_RESERVED_NAMES = frozenset(
+    {"CON", "PRN", "AUX", "NUL"}
+    | {f"COM{i}" for i in range(1, 10)}
+    | {f"LPT{i}" for i in range(1, 10)}
+)
 _BACKEND_METADATA_CLEAR = {
     "provenance": None,
     "approximation_level": None,
@@ -129,10 +139,11 @@ def dict_without_empty_values(obj: object) -> JsonStructure:
 
 
 def _write_text(path: Path, text: str) -> None:
-    """Write UTF-8 text to disk, wrapping IO errors."""
+    """Write UTF-8 text to disk atomically, wrapping IO errors."""
     start = time.monotonic()
     try:
-        path.write_text(text, encoding="utf-8")
+        with atomic_write(path) as handle:
+            handle.write(text.encode("utf-8"))
     except Exception as exc:
         raise OutputError(f"Failed to write output to '{path}'.") from exc
     logger.info("Wrote output to %s in %.2fs", path, time.monotonic() - start)
@@ -146,14 +157,8 @@ def save_as_json(
     indent: int | None = None,
     include_backend_metadata: bool = False,
 ) -> None:
-    text = serialize_workbook(
-        model,
-        fmt="json",
-        pretty=pretty,
-        indent=indent,
-        include_backend_metadata=include_backend_metadata,
-    )
-    _write_text(path, text)
+    payload = workbook_payload(model, include_backend_metadata=include_backend_metadata)
+    write_output_json(path, payload, indent=2 if pretty and indent is None else indent)
 
 
 def save_as_yaml(
@@ -179,11 +184,74 @@ def save_as_toon(
 
 
 def _sanitize_sheet_filename(name: str) -> str:
-    """Make a sheet name safe for filesystem usage."""
-    safe = re.sub(r"[\\/:*?\"<>|]", "_", name)
+    """Make a sheet name safe for filesystem usage.
+
+    Path separators, characters Windows rejects, and control characters
+    become ``_``; trailing dots and spaces are dropped, and Windows device
+    names (``CON``, ``COM1``, ...) get a leading ``_``.
+    """
+    safe = re.sub(r"[\\/:*?\"<>|\x00-\x1f]", "_", name).rstrip(". ")
+    if safe.split(".", 1)[0].upper() in _WINDOWS_RESERVED_NAMES:
+        safe = f"_{safe}"
     return safe or "sheet"
 
 
+def _unique_stems(names: Iterable[str], *, reserved: Iterable[str] = ()) -> list[str]:
+    """Return sanitized file stems for ``names`` (in order) that never collide.
+
+    Stems are compared case-insensitively (Windows and macOS file systems
+    ignore case); a stem already taken, or listed in ``reserved``, gets a
+    ``_2``, ``_3``, ... suffix in order.
+    """
+    used = {stem.lower() for stem in reserved}
+    stems: list[str] = []
+    for name in names:
+        base = _sanitize_sheet_filename(name)
+        stem = base
+        counter = 2
+        while stem.lower() in used:
+            stem = f"{base}_{counter}"
+            counter += 1
+        used.add(stem.lower())
+        stems.append(stem)
+    return stems
+
+
+def _unique_sheet_stems(
+    sheet_names: Iterable[str], *, reserved: Iterable[str] = ()
+) -> dict[str, str]:
+    """Map sheet names to sanitized file stems that never collide."""
+    names = list(sheet_names)
+    return dict(zip(names, _unique_stems(names, reserved=reserved), strict=True))
+
+
+def _sheet_file_stems(sheet_names: Iterable[str]) -> dict[str, str]:
+    """Return per-sheet file stems, keeping the index file name free."""
+    return _unique_sheet_stems(sheet_names, reserved=(Path(SHEET_INDEX_FILENAME).stem,))
+
+
+def _write_sheet_index(
+    output_dir: Path,
+    book_name: str,
+    written: Mapping[str, Path],
+    *,
+    pages: Mapping[str, list[Path]] | None = None,
+) -> None:
+    """Write ``index.json`` mapping each written file back to its sheet name.
+
+    Sheets split by ``rows_per_file`` also list all their ``files`` in order.
+    """
+    entries: list[JsonStructure] = []
+    for sheet_name, path in written.items():
+        entry: dict[str, JsonStructure] = {"sheet_name": sheet_name, "file": path.name}
+        if pages and sheet_name in pages:
+            entry["files"] = [page.name for page in pages[sheet_name]]
+        entries.append(entry)
+    payload: dict[str, JsonStructure] = {"book_name": book_name, "sheets": entries}
+    text = _serialize_payload_from_hint(payload, "json", pretty=True, indent=2)
+    _write_text(output_dir / SHEET_INDEX_FILENAME, text)
+
+
 def _parse_range_zero_based(range_str: str) -> RangeBounds | None:
     """Parse an Excel range string into zero-based bounds.
 
@@ -200,39 +268,44 @@ def _row_in_area(row: CellRow, area: PrintArea) -> bool:
     return area.r1 <= row.r <= area.r2
 
 
-def _filter_row_to_area(
-    row: CellRow, area: PrintArea, *, normalize: bool = False
-) -> CellRow | None:
-    if not _row_in_area(row, area):
-        return None
-
-    filtered_cells: dict[str, int | float | str] = {}
-    filtered_links: dict[str, str] = {}
-
-    for col_idx_str, value in row.c.items():
+def _filter_columns_to_area(
+    values: Mapping[str, TValue], area: PrintArea, *, normalize: bool
+) -> dict[str, TValue]:
+    filtered: dict[str, TValue] = {}
+    for col_idx_str, value in values.items():
         try:
             col_idx = int(col_idx_str)
         except Exception:
             continue
         if area.c1 <= col_idx <= area.c2:
             key = str(col_idx - area.c1) if normalize else col_idx_str
-            filtered_cells[key] = value
-
-    if row.links:
-        for col_idx_str, url in row.links.items():
-            try:
-                col_idx = int(col_idx_str)
-            except Exception:
-                continue
-            if area.c1 <= col_idx <= area.c2:
-                key = str(col_idx - area.c1) if normalize else col_idx_str
-                filtered_links[key] = url
+            filtered[key] = value
+    return filtered
 
+
+def _filter_row_to_area(
+    row: CellRow, area: PrintArea, *, normalize: bool = False
+) -> CellRow | None:
+    if not _row_in_area(row, area):
+        return None
+
+    filtered_cells = _filter_columns_to_area(row.c, area, normalize=normalize)
+    filtered_links = _filter_columns_to_area(
+        row.links or {}, area, normalize=normalize
+    )
     if not filtered_cells and not filtered_links:
         return None
+    filtered_phonetic = _filter_columns_to_area(
+        row.phonetic or {}, area, normalize=normalize
+    )
 
     new_row_idx = row.r - area.r1 if normalize else row.r
-    return CellRow(r=new_row_idx, c=filtered_cells, links=filtered_links or None)
+    return CellRow(
+        r=new_row_idx,
+        c=filtered_cells,
+        links=filtered_links or None,
+        phonetic=filtered_phonetic or None,
+    )
 
 
 def _filter_table_candidates_to_area(
@@ -452,13 +525,14 @@ def save_print_area_views(
     output_dir.mkdir(parents=True, exist_ok=True)
     written: dict[str, Path] = {}
     suffix = {"json": ".json", "yaml": ".yaml", "toon": ".toon"}[format_hint]
+    stems = _unique_sheet_stems(views)
 
     for sheet_name, sheet_views in views.items():
         for idx, view in enumerate(sheet_views):
             key = f"{sheet_name}#{idx + 1}"
             area = view.area
             file_name = (
-                f"{_sanitize_sheet_filename(sheet_name)}"
+                f"{stems[sheet_name]}"
                 f"_area{idx + 1}_r{area.r1}-{area.r2}_c{area.c1}-{area.c2}{suffix}"
             )
             path = output_dir / file_name
@@ -515,13 +589,14 @@ def save_auto_page_break_views(
     output_dir.mkdir(parents=True, exist_ok=True)
     written: dict[str, Path] = {}
     suffix = {"json": ".json", "yaml": ".yaml", "toon": ".toon"}[format_hint]
+    stems = _unique_sheet_stems(views)
 
     for sheet_name, sheet_views in views.items():
         for idx, view in enumerate(sheet_views):
             key = f"{sheet_name}#auto#{idx + 1}"
             area = view.area
             file_name = (
-                f"{_sanitize_sheet_filename(sheet_name)}"
+                f"{stems[sheet_name]}"
                 f"_auto_page{idx + 1}_r{area.r1}-{area.r2}_c{area.c1}-{area.c2}{suffix}"
             )
             path = output_dir / file_name
@@ -536,34 +611,123 @@ def save_auto_page_break_views(
     return written
 
 
+def workbook_payload(
+    model: WorkbookData,
+    *,
+    include_backend_metadata: bool = False,
+    jq: str | None = None,
+    query: str | None = None,
+    fields: list[str] | None = None,
+    dedupe_strings: bool = False,
+) -> JsonStructure:
+    """
+    Build the JSON-compatible payload that json/yaml/toon output serializes.
+
+    Empty values are dropped, backend metadata is removed unless requested,
+    and ``fields`` / ``dedupe_strings`` / ``jq`` / ``query`` are applied as in
+    ``serialize_workbook``.
+    """
+    dump_start = time.monotonic()
+    model_for_dump = (
+        model if include_backend_metadata else _without_workbook_backend_metadata(model)
+    )
+    payload = dict_without_empty_values(
+        model_for_dump.model_dump(exclude_none=True, by_alias=True)
+    )
+    if fields:
+        from .transform import apply_fields
+
+        payload = apply_fields(payload, fields)
+    if dedupe_strings:
+        from .transform import encode_strings
+
+        payload = encode_strings(payload)
+    if jq is not None:
+        from .transform import apply_jq
+
+        payload = apply_jq(payload, jq)
+    if query is not None:
+        from .transform import apply_query
+
+        payload = apply_query(payload, query)
+    logger.info(
+        "serialize_workbook model_dump completed in %.2fs",
+        time.monotonic() - dump_start,
+    )
+    return payload
+
+
 def serialize_workbook(
     model: WorkbookData,
-    fmt: Literal["json", "yaml", "yml", "toon"] = "json",
+    fmt: Literal[
+        "json", "yaml", "yml", "toon", "events", "text", "markdown"
+    ] = "json",
     *,
     pretty: bool = False,
     indent: int | None = None,
     include_backend_metadata: bool = False,
+    jq: str | None = None,
+    query: str | None = None,
+    fields: list[str] | None = None,
+    dedupe_strings: bool = False,
 ) -> str:
     """
     Convert WorkbookData to string in the requested format without writing to disk.
+
+    The ``events`` format emits one NDJSON record per non-empty cell and
+    ``text`` renders sheets as plain-text grids (space-aligned when pretty), and
+    ``markdown`` renders sheets as Markdown documents (headings, tables,
+    shape-text callouts, and chart descriptions).
+    ``jq`` runs a jq expression over the payload before json/yaml/toon output
+    (requires the jq package); ``query`` then evaluates a JSONPath (``$...``)
+    or JMESPath expression and outputs its result instead. ``fields`` keeps or
+    drops payload sections and item fields before both (see ``apply_fields``).
+    ``dedupe_strings`` then stores repeated string cell values once per sheet
+    in a ``strings`` table referenced from each row's ``s`` map (see
+    ``encode_strings``).
     """
     total_start = time.monotonic()
+    if jq is not None and fmt in ("events", "text", "markdown"):
+        raise SerializationError(
+            f"jq expressions apply to json/yaml/toon output, not {fmt}."
+        )
+    if query is not None and fmt in ("events", "text", "markdown"):
+        raise SerializationError(
+            f"query expressions apply to json/yaml/toon output, not {fmt}."
+        )
+    if fields and fmt in ("events", "text", "markdown"):
+        raise SerializationError(
+            f"field projections apply to json/yaml/toon output, not {fmt}."
+        )
+    if dedupe_strings and fmt in ("events", "text", "markdown"):
+        raise SerializationError(
+            f"string dictionary encoding applies to json/yaml/toon output, not {fmt}."
+        )
+    if fmt == "events":
+        from .events import cell_events_to_ndjson
+
+        return cell_events_to_ndjson(model)
+    if fmt == "text":
+        from .text import render_workbook_text
+
+        return render_workbook_text(model, aligned=pretty)
+    if fmt == "markdown":
+        from .markdown import render_workbook_markdown
+
+        return render_workbook_markdown(model)
     format_hint = _ensure_format_hint(
         fmt,
         allowed=_FORMAT_HINTS,
         error_type=SerializationError,
         error_message="Unsupported export format '{fmt}'. Allowed: json, yaml, yml, toon.",
     )
-    dump_start = time.monotonic()
-    model_for_dump = (
-        model if include_backend_metadata else _without_workbook_backend_metadata(model)
-    )
-    filtered_dict = dict_without_empty_values(
-        model_for_dump.model_dump(exclude_none=True, by_alias=True)
-    )
-    logger.info(
-        "serialize_workbook model_dump completed in %.2fs",
-        time.monotonic() - dump_start,
+    filtered_dict = workbook_payload(
+        model,
+        include_backend_metadata=include_backend_metadata,
+        jq=jq,
+        query=query,
+        fields=fields,
+        dedupe_strings=dedupe_strings,
     )
     serialize_start = time.monotonic()
     result = _serialize_payload_from_hint(
@@ -590,11 +754,13 @@ def save_sheets_as_json(
 ) -> dict[str, Path]:
     """
     Save each sheet as an individual JSON file.
-    Contents include book_name and the sheet's SheetData.
+    Contents include book_name and the sheet's SheetData; ``index.json`` maps
+    the filesystem-safe file names back to sheet names.
     Returns a map of sheet name -> written path.
     """
     output_dir.mkdir(parents=True, exist_ok=True)
     written: dict[str, Path] = {}
+    stems = _sheet_file_stems(workbook.sheets)
     for sheet_name, sheet_data in workbook.sheets.items():
         payload_sheet = (
             sheet_data
@@ -608,13 +774,14 @@ def save_sheets_as_json(
                 "sheet": payload_sheet.model_dump(exclude_none=True, by_alias=True),
             }
         )
-        file_name = f"{_sanitize_sheet_filename(sheet_name)}.json"
+        file_name = f"{stems[sheet_name]}.json"
         path = output_dir / file_name
         text = _serialize_payload_from_hint(
             payload, "json", pretty=pretty, indent=indent
         )
         _write_text(path, text)
         written[sheet_name] = path
+    _write_sheet_index(output_dir, workbook.book_name, written)
     return written
 
 
@@ -626,11 +793,19 @@ def save_sheets(
     pretty: bool = False,
     indent: int | None = None,
     include_backend_metadata: bool = False,
+    rows_per_file: int | None = None,
 ) -> dict[str, Path]:
     """
     Save each sheet as an individual file in the specified format (json/yaml/toon).
-    Payload includes book_name and the sheet's SheetData.
+    Payload includes book_name and the sheet's SheetData; ``index.json`` maps
+    the filesystem-safe file names back to sheet names.
+
+    With ``rows_per_file``, a sheet with more rows is written as numbered pages
+    (``Sheet1.page001.json``, ...) of at most that many rows each; see
+    ``_save_sheet_pages``. The returned map points to each sheet's first page.
     """
+    if rows_per_file is not None and rows_per_file < 1:
+        raise ValueError("rows_per_file must be at least 1.")
     format_hint = _ensure_format_hint(
         fmt,
         allowed=_FORMAT_HINTS,
@@ -640,12 +815,28 @@ def save_sheets(
 
     output_dir.mkdir(parents=True, exist_ok=True)
     written: dict[str, Path] = {}
+    pages: dict[str, list[Path]] = {}
+    stems = _sheet_file_stems(workbook.sheets)
+    suffix = {"json": ".json", "yaml": ".yaml", "toon": ".toon"}[format_hint]
     for sheet_name, sheet_data in workbook.sheets.items():
         payload_sheet = (
             sheet_data
             if include_backend_metadata
             else _without_sheet_backend_metadata(sheet_data)
         )
+        if rows_per_file is not None and len(payload_sheet.rows) > rows_per_file:
+            pages[sheet_name] = _save_sheet_pages(
+                workbook.book_name,
+                sheet_name,
+                payload_sheet.model_dump(exclude_none=True, by_alias=True),
+                output_dir / f"{stems[sheet_name]}{suffix}",
+                format_hint,
+                rows_per_file=rows_per_file,
+                pretty=pretty,
+                indent=indent,
+            )
+            written[sheet_name] = pages[sheet_name][0]
+            continue
         payload = dict_without_empty_values(
             {
                 "book_name": workbook.book_name,
@@ -653,28 +844,201 @@ def save_sheets(
                 "sheet": payload_sheet.model_dump(exclude_none=True, by_alias=True),
             }
         )
-        suffix = {"json": ".json", "yaml": ".yaml", "toon": ".toon"}[format_hint]
-        file_name = f"{_sanitize_sheet_filename(sheet_name)}{suffix}"
+        file_name = f"{stems[sheet_name]}{suffix}"
         path = output_dir / file_name
         text = _serialize_payload_from_hint(
             payload, format_hint, pretty=pretty, indent=indent
         )
         _write_text(path, text)
         written[sheet_name] = path
+    _write_sheet_index(output_dir, workbook.book_name, written, pages=pages)
+    return written
+
+
+def _save_sheet_pages(
+    book_name: str,
+    sheet_name: str,
+    sheet: dict[str, Any],
+    path: Path,
+    format_hint: str,
+    *,
+    rows_per_file: int,
+    pretty: bool,
+    indent: int | None,
+) -> list[Path]:
+    """Write a sheet's rows across numbered page files.
+
+    The first page carries every other sheet field (shapes, charts, merged
+    cells, ...) with the first rows; later pages carry only ``rows``. Each page
+    has a ``page`` block with its ``index`` and the page ``count``, the sheet's
+    ``total_rows``, the ``first_row``/``last_row`` numbers it holds, and the
+    ``prev``/``next`` file names to follow.
+    """
+    rows = sheet.get("rows", [])
+    chunks = [
+        rows[start : start + rows_per_file]
+        for start in range(0, len(rows), rows_per_file)
+    ]
+    width = max(3, len(str(len(chunks))))
+    paths = [
+        path.with_name(f"{path.stem}.page{index:0{width}d}{path.suffix}")
+        for index in range(1, len(chunks) + 1)
+    ]
+    for index, (chunk, target) in enumerate(zip(chunks, paths, strict=True)):
+        content = {**sheet, "rows": chunk} if index == 0 else {"rows": chunk}
+        payload = dict_without_empty_values(
+            {
+                "book_name": book_name,
+                "sheet_name": sheet_name,
+                "page": {
+                    "index": index + 1,
+                    "count": len(chunks),
+                    "rows_per_file": rows_per_file,
+                    "total_rows": len(rows),
+                    "first_row": chunk[0]["r"],
+                    "last_row": chunk[-1]["r"],
+                    "prev": paths[index - 1].name if index > 0 else None,
+                    "next": paths[index + 1].name if index + 1 < len(paths) else None,
+                },
+                "sheet": content,
+            }
+        )
+        text = _serialize_payload_from_hint(
+            payload, format_hint, pretty=pretty, indent=indent
+        )
+        _write_text(target, text)
+    return paths
+
+
+def save_shapes(
+    workbook: WorkbookData,
+    output_dir: Path,
+    fmt: Literal["json", "yaml", "yml", "toon"] = "json",
+    *,
+    pretty: bool = False,
+    indent: int | None = None,
+    include_backend_metadata: bool = False,
+) -> dict[str, Path]:
+    """
+    Save the shapes of each sheet as an individual file (json/yaml/toon).
+    Payload includes book_name, sheet_name, and the sheet's shapes; sheets
+    without shapes are skipped. Returns a map of sheet name -> written path.
+    """
+    payloads = {
+        sheet_name: [
+            (
+                shape
+                if include_backend_metadata
+                else _without_shape_backend_metadata(shape)
+            ).model_dump(exclude_none=True, by_alias=True)
+            for shape in sheet.shapes
+        ]
+        for sheet_name, sheet in workbook.sheets.items()
+        if sheet.shapes
+    }
+    return _save_sheet_components(
+        workbook.book_name,
+        payloads,
+        "shapes",
+        output_dir,
+        fmt,
+        pretty=pretty,
+        indent=indent,
+    )
+
+
+def save_charts(
+    workbook: WorkbookData,
+    output_dir: Path,
+    fmt: Literal["json", "yaml", "yml", "toon"] = "json",
+    *,
+    pretty: bool = False,
+    indent: int | None = None,
+    include_backend_metadata: bool = False,
+) -> dict[str, Path]:
+    """
+    Save the charts of each sheet as an individual file (json/yaml/toon).
+    Payload includes book_name, sheet_name, and the sheet's charts; sheets
+    without charts are skipped. Returns a map of sheet name -> written path.
+    """
+    payloads = {
+        sheet_name: [
+            (
+                chart
+                if include_backend_metadata
+                else _without_chart_backend_metadata(chart)
+            ).model_dump(exclude_none=True, by_alias=True)
+            for chart in sheet.charts
+        ]
+        for sheet_name, sheet in workbook.sheets.items()
+        if sheet.charts
+    }
+    return _save_sheet_components(
+        workbook.book_name,
+        payloads,
+        "charts",
+        output_dir,
+        fmt,
+        pretty=pretty,
+        indent=indent,
+    )
+
+
+def _save_sheet_components(
+    book_name: str,
+    payloads: Mapping[str, list[dict[str, object]]],
+    component: Literal["shapes", "charts"],
+    output_dir: Path,
+    fmt: Literal["json", "yaml", "yml", "toon"],
+    *,
+    pretty: bool,
+    indent: int | None,
+) -> dict[str, Path]:
+    """Write one ``{book_name, sheet_name, <component>}`` file per sheet plus the index."""
+    format_hint = _ensure_format_hint(
+        fmt,
+        allowed=_FORMAT_HINTS,
+        error_type=SerializationError,
+        error_message=f"Unsupported {component} export format '{{fmt}}'. "
+        "Allowed: json, yaml, yml, toon.",
+    )
+    if not payloads:
+        logger.info("No %s found; skipping export to %s", component, output_dir)
+        return {}
+
+    output_dir.mkdir(parents=True, exist_ok=True)
+    written: dict[str, Path] = {}
+    suffix = {"json": ".json", "yaml": ".yaml", "toon": ".toon"}[format_hint]
+    stems = _sheet_file_stems(payloads)
+    for sheet_name, items in payloads.items():
+        payload = dict_without_empty_values(
+            {"book_name": book_name, "sheet_name": sheet_name, component: items}
+        )
+        path = output_dir / f"{stems[sheet_name]}{suffix}"
+        text = _serialize_payload_from_hint(
+            payload, format_hint, pretty=pretty, indent=indent
+        )
+        _write_text(path, text)
+        written[sheet_name] = path
+    _write_sheet_index(output_dir, book_name, written)
     return written
 
 
 __all__ = [
+    "SHEET_INDEX_FILENAME",
     "dict_without_empty_values",
     "save_as_json",
     "save_as_yaml",
     "save_as_toon",
     "save_sheets",
     "save_sheets_as_json",
+    "save_shapes",
+    "save_charts",
     "build_print_area_views",
     "save_print_area_views",
     "save_auto_page_break_views",
     "serialize_workbook",
+    "workbook_payload",
     "_require_yaml",
     "_require_toon",
 ]
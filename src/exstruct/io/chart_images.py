@@ -0,0 +1,388 @@
+"""SVG/PNG preview thumbnails of extracted charts drawn from their series data."""
+
+from __future__ import annotations
+
+from dataclasses import dataclass
+import logging
+import math
+from pathlib import Path
+from typing import Literal
+
+from ..analysis.chart_sources import split_range_reference
+from ..core.ranges import parse_range_zero_based
+from ..errors import OutputError, SerializationError
+from ..models import Chart, ChartSeries, SheetData, WorkbookData
+from ..models.types import JsonStructure
+from . import SHEET_INDEX_FILENAME, _unique_stems, _write_text
+from .output import atomic_write
+from .scene import (
+    Label,
+    Line,
+    Primitive,
+    Rect,
+    Wedge,
+    render_png as render_scene_png,
+    render_svg as render_scene_svg,
+    require_pillow,
+)
+from .serialize import _serialize_payload_from_hint
+from .tables import _column_index
+
+logger = logging.getLogger(__name__)
+
+ChartImageFormat = Literal["svg", "png"]
+CellValue = int | float | str
+
+_WIDTH = 320
+_HEIGHT = 200
+_MARGIN = 16
+_TITLE_HEIGHT = 24
+# Office theme accent colors, in the order Excel assigns them to series.
+_PALETTE = ("#4472C4", "#ED7D31", "#A5A5A5", "#FFC000", "#5B9BD5", "#70AD47")
+_AXIS_COLOR = "#808080"
+
+
+@dataclass(frozen=True)
+class PlotSeries:
+    """Numeric series data resolved for drawing.
+
+    Attributes:
+        name: Series display name.
+        values: Y values (None for blank or non-numeric cells).
+        x_values: Numeric X values for scatter charts, when resolvable.
+    """
+
+    name: str
+    values: list[float | None]
+    x_values: list[float | None] | None = None
+
+
+def _cell_lookup(sheet: SheetData) -> dict[tuple[int, int], CellValue]:
+    """Map 0-based (row, col) to the extracted cell value."""
+    cells: dict[tuple[int, int], CellValue] = {}
+    for row in sheet.rows:
+        for key, value in row.c.items():
+            col = _column_index(key)
+            if col is not None:
+                cells[(row.r - 1, col)] = value
+    return cells
+
+
+def resolve_range_values(
+    workbook: WorkbookData, sheet_name: str, reference: str
+) -> list[CellValue | None]:
+    """Read the cells a series reference points at from the extracted rows.
+
+    Unqualified references resolve against ``sheet_name``; union references
+    are read part by part, each in row-major order.
+
+    Args:
+        workbook: Extracted workbook holding the referenced rows.
+        sheet_name: Sheet of the chart, used for unqualified references.
+        reference: Series reference such as ``Sheet1!$B$2:$B$5``.
+
+    Returns:
+        Cell values (None for empty cells or unknown sheets).
+    """
+    values: list[CellValue | None] = []
+    lookups: dict[str, dict[tuple[int, int], CellValue]] = {}
+    for part_sheet, local in split_range_reference(reference):
+        target = part_sheet or sheet_name
+        bounds = parse_range_zero_based(local)
+        if bounds is None:
+            continue
+        sheet = workbook.sheets.get(target)
+        if sheet is not None and target not in lookups:
+            lookups[target] = _cell_lookup(sheet)
+        cells = lookups.get(target, {})
+        values.extend(
+            cells.get((r, c))
+            for r in range(bounds.r1, bounds.r2 + 1)
+            for c in range(bounds.c1, bounds.c2 + 1)
+        )
+    return values
+
+
+def _number(value: CellValue | None) -> float | None:
+    if isinstance(value, bool) or value is None:
+        return None
+    if isinstance(value, int | float):
+        return float(value) if math.isfinite(value) else None
+    try:
+        number = float(value.replace(",", ""))
+    except ValueError:
+        return None
+    return number if math.isfinite(number) else None
+
+
+def plot_series(
+    workbook: WorkbookData, sheet_name: str, series: ChartSeries
+) -> PlotSeries:
+    """Resolve a chart series to numbers, preferring the cached values."""
+    if series.values is not None:
+        values = list(series.values)
+    elif series.y_range:
+        raw = resolve_range_values(workbook, sheet_name, series.y_range)
+        values = [_number(value) for value in raw]
+    else:
+        values = []
+    x_values: list[float | None] | None = None
+    if series.x_range:
+        raw_x = resolve_range_values(workbook, sheet_name, series.x_range)
+        x_values = [_number(value) for value in raw_x]
+    return PlotSeries(name=series.name, values=values, x_values=x_values)
+
+
+def _chart_kind(chart: Chart) -> Literal["pie", "bar", "scatter", "line"]:
+    kind = chart.chart_type.lower()
+    if "pie" in kind or "doughnut" in kind:
+        return "pie"
+    if "bar" in kind or "column" in kind:
+        return "bar"
+    if "scatter" in kind or "xy" in kind or "bubble" in kind:
+        return "scatter"
+    return "line"
+
+
+@dataclass(frozen=True)
+class _Frame:
+    """Plot area in image coordinates."""
+
+    x0: float
+    y0: float
+    x1: float
+    y1: float
+
+    def scale(self, lo: float, hi: float, value: float, *, vertical: bool) -> float:
+        ratio = (value - lo) / (hi - lo)
+        if vertical:
+            return self.y1 - ratio * (self.y1 - self.y0)
+        return self.x0 + ratio * (self.x1 - self.x0)
+
+
+def _bounds(values: list[float]) -> tuple[float, float]:
+    lo = min([0.0, *values])
+    hi = max([0.0, *values])
+    return (lo, hi) if hi > lo else (lo, lo + 1.0)
+
+
+def _bar_scene(
+    chart: Chart, series: list[PlotSeries], frame: _Frame
+) -> list[Primitive]:
+    present = [v for s in series for v in s.values if v is not None]
+    count = max((len(s.values) for s in series), default=0)
+    if not present or count == 0:
+        return []
+    lo, hi = _bounds(present)
+    horizontal = chart.bar_direction == "horizontal"
+    extent = (frame.y1 - frame.y0) if horizontal else (frame.x1 - frame.x0)
+    slot = extent / count
+    bar = slot * 0.8 / len(series)
+    zero = frame.scale(lo, hi, 0.0, vertical=not horizontal)
+    scene: list[Primitive] = []
+    for index, item in enumerate(series):
+        color = _PALETTE[index % len(_PALETTE)]
+        for position, value in enumerate(item.values):
+            if value is None:
+                continue
+            end = frame.scale(lo, hi, value, vertical=not horizontal)
+            offset = position * slot + slot * 0.1 + index * bar
+            if horizontal:
+                y = frame.y0 + offset
+                scene.append(Rect(min(zero, end), y, abs(end - zero), bar, color))
+            else:
+                x = frame.x0 + offset
+                scene.append(Rect(x, min(zero, end), bar, abs(end - zero), color))
+    axis = (
+        [(zero, frame.y0), (zero, frame.y1)]
+        if horizontal
+        else [(frame.x0, zero), (frame.x1, zero)]
+    )
+    scene.append(Line(axis, _AXIS_COLOR))
+    return scene
+
+
+def _points(item: PlotSeries, *, scatter: bool) -> list[tuple[float, float] | None]:
+    """Pair values with X positions; None marks a gap."""
+    xs = item.x_values if scatter and item.x_values else None
+    points: list[tuple[float, float] | None] = []
+    for index, value in enumerate(item.values):
+        x = float(index) if xs is None else (xs[index] if index < len(xs) else None)
+        points.append((x, value) if x is not None and value is not None else None)
+    return points
+
+
+def _line_scene(
+    series: list[PlotSeries], frame: _Frame, *, scatter: bool
+) -> list[Primitive]:
+    all_points = [_points(item, scatter=scatter) for item in series]
+    present = [point for points in all_points for point in points if point]
+    if not present:
+        return []
+    x_lo = min(point[0] for point in present)
+    x_hi = max(point[0] for point in present)
+    x_hi = x_hi if x_hi > x_lo else x_lo + 1.0
+    y_lo, y_hi = _bounds([point[1] for point in present])
+    zero = frame.scale(y_lo, y_hi, 0.0, vertical=True)
+    scene: list[Primitive] = [Line([(frame.x0, zero), (frame.x1, zero)], _AXIS_COLOR)]
+    for index, points in enumerate(all_points):
+        color = _PALETTE[index % len(_PALETTE)]
+        placed = [
+            (
+                frame.scale(x_lo, x_hi, point[0], vertical=False),
+                frame.scale(y_lo, y_hi, point[1], vertical=True),
+            )
+            if point
+            else None
+            for point in points
+        ]
+        if scatter:
+            scene.extend(
+                Rect(p[0] - 2, p[1] - 2, 4, 4, color) for p in placed if p is not None
+            )
+            continue
+        segment: list[tuple[float, float]] = []
+        for point in [*placed, None]:
+            if point is not None:
+                segment.append(point)
+            elif segment:
+                scene.append(Line(segment, color))
+                segment = []
+    return scene
+
+
+def _pie_scene(series: list[PlotSeries], frame: _Frame) -> list[Primitive]:
+    values = [v for v in (series[0].values if series else []) if v and v > 0]
+    total = sum(values)
+    if total <= 0:
+        return []
+    cx = (frame.x0 + frame.x1) / 2
+    cy = (frame.y0 + frame.y1) / 2
+    r = min(frame.x1 - frame.x0, frame.y1 - frame.y0) / 2
+    scene: list[Primitive] = []
+    start = -90.0
+    for index, value in enumerate(values):
+        end = start + 360.0 * value / total
+        scene.append(Wedge(cx, cy, r, start, end, _PALETTE[index % len(_PALETTE)]))
+        start = end
+    return scene
+
+
+def chart_scene(
+    chart: Chart,
+    series: list[PlotSeries],
+    *,
+    width: int = _WIDTH,
+    height: int = _HEIGHT,
+) -> list[Primitive]:
+    """Lay out a chart preview: background, title, and plotted series."""
+    scene: list[Primitive] = [Rect(0, 0, width, height, "#FFFFFF")]
+    top = _MARGIN
+    if chart.title:
+        scene.append(Label(width / 2, _MARGIN / 2, chart.title))
+        top = _TITLE_HEIGHT
+    frame = _Frame(_MARGIN, top, width - _MARGIN, height - _MARGIN)
+    kind = _chart_kind(chart)
+    if kind == "pie":
+        scene.extend(_pie_scene(series, frame))
+    elif kind == "bar":
+        scene.extend(_bar_scene(chart, series, frame))
+    else:
+        scene.extend(_line_scene(series, frame, scatter=kind == "scatter"))
+    return scene
+
+
+def render_svg(
+    scene: list[Primitive], *, width: int = _WIDTH, height: int = _HEIGHT
+) -> str:
+    """Render a chart scene as a standalone SVG document."""
+    return render_scene_svg(scene, width=width, height=height)
+
+
+def render_png(
+    scene: list[Primitive], *, width: int = _WIDTH, height: int = _HEIGHT
+) -> bytes:
+    """Render a chart scene as PNG bytes (requires Pillow)."""
+    return render_scene_png(scene, width=width, height=height)
+
+
+def save_chart_images(
+    workbook: WorkbookData,
+    output_dir: Path,
+    fmt: ChartImageFormat = "svg",
+) -> dict[str, Path]:
+    """Write a preview image of every chart plus an ``index.json``.
+
+    Series values come from the chart's cached values when present, otherwise
+    from the extracted cells their ``y_range`` (and, for scatter charts,
+    ``x_range``) refers to. Files are named ``<sheet>_<chart>.svg`` (or
+    ``.png``) using the per-sheet file naming rules; the index lists
+    ``{sheet_name, chart_name, file}`` for each image.
+
+    Args:
+        workbook: Workbook whose charts are drawn.
+        output_dir: Target directory.
+        fmt: ``svg`` (no dependencies) or ``png`` (requires Pillow).
+
+    Returns:
+        Map of ``Sheet/chart name`` keys to written paths.
+
+    Raises:
+        SerializationError: If the format is unsupported.
+        MissingDependencyError: If PNG output is requested without Pillow.
+        OutputError: If writing fails.
+    """
+    if fmt not in ("svg", "png"):
+        raise SerializationError(
+            f"Unsupported chart image format '{fmt}'. Allowed: svg, png."
+        )
+    charts = [
+        (sheet_name, chart)
+        for sheet_name, sheet in workbook.sheets.items()
+        for chart in sheet.charts
+    ]
+    if not charts:
+        logger.info("No charts found; skipping chart images in %s", output_dir)
+        return {}
+    if fmt == "png":
+        require_pillow()
+    output_dir.mkdir(parents=True, exist_ok=True)
+    stems = _unique_stems(
+        [f"{sheet_name}_{chart.name}" for sheet_name, chart in charts],
+        reserved=(Path(SHEET_INDEX_FILENAME).stem,),
+    )
+    written: dict[str, Path] = {}
+    entries: list[JsonStructure] = []
+    for (sheet_name, chart), stem in zip(charts, stems, strict=True):
+        series = [plot_series(workbook, sheet_name, s) for s in chart.series]
+        scene = chart_scene(chart, series)
+        path = output_dir / f"{stem}.{fmt}"
+        data = render_png(scene) if fmt == "png" else render_svg(scene).encode("utf-8")
+        try:
+            with atomic_write(path) as handle:
+                handle.write(data)
+        except Exception as exc:
+            raise OutputError(f"Failed to write output to '{path}'.") from exc
+        written[f"{sheet_name}/{chart.name}"] = path
+        entries.append(
+            {"sheet_name": sheet_name, "chart_name": chart.name, "file": path.name}
+        )
+    index: dict[str, JsonStructure] = {
+        "book_name": workbook.book_name,
+        "charts": entries,
+    }
+    text = _serialize_payload_from_hint(index, "json", pretty=True, indent=2)
+    _write_text(output_dir / SHEET_INDEX_FILENAME, text)
+    return written
+
+
+__all__ = [
+    "ChartImageFormat",
+    "PlotSeries",
+    "chart_scene",
+    "plot_series",
+    "render_png",
+    "render_svg",
+    "resolve_range_values",
+    "save_chart_images",
+]
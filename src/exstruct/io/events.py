@@ -0,0 +1,59 @@
+"""Flat per-cell event records (NDJSON) for search indexing."""
+
+from __future__ import annotations
+
+from collections.abc import Iterator
+import json
+
+from ..models import WorkbookData, col_index_to_alpha
+from .tables import _column_index
+
+_VALUE_TYPES: dict[type, str] = {int: "int", float: "float", str: "str"}
+
+
+def iter_cell_events(workbook: WorkbookData) -> Iterator[dict[str, object]]:
+    """Yield one flat record per non-empty cell.
+
+    Records hold ``book``, ``sheet``, ``row`` (1-based), ``col`` (0-based),
+    ``a1``, ``value``, ``type`` (int / float / str), and ``link`` when the cell
+    has a hyperlink. ``value`` is always text so that search indexes keep a
+    single field mapping; ``type`` tells consumers how to parse it.
+
+    Args:
+        workbook: Workbook to flatten.
+
+    Yields:
+        Cell event records in sheet, row, and column order.
+    """
+    for sheet_name, sheet in workbook.sheets.items():
+        for row in sorted(sheet.rows, key=lambda row: row.r):
+            links = row.links or {}
+            cells = [
+                (col, key, value)
+                for key, value in row.c.items()
+                if (col := _column_index(key)) is not None
+            ]
+            for col, key, value in sorted(cells, key=lambda cell: cell[0]):
+                event: dict[str, object] = {
+                    "book": workbook.book_name,
+                    "sheet": sheet_name,
+                    "row": row.r,
+                    "col": col,
+                    "a1": f"{col_index_to_alpha(col)}{row.r}",
+                    "value": str(value),
+                    "type": _VALUE_TYPES.get(type(value), "str"),
+                }
+                if key in links:
+                    event["link"] = links[key]
+                yield event
+
+
+def cell_events_to_ndjson(workbook: WorkbookData) -> str:
+    """Render cell events as newline-delimited JSON (one record per line)."""
+    return "".join(
+        json.dumps(event, ensure_ascii=False) + "\n"
+        for event in iter_cell_events(workbook)
+    )
+
+
+__all__ = ["cell_events_to_ndjson", "iter_cell_events"]
@@ -0,0 +1,215 @@
+"""Markdown document rendering of spec-style workbooks."""
+
+from __future__ import annotations
+
+from dataclasses import dataclass
+
+from ..core.ranges import RangeBounds, parse_range_zero_based
+from ..models import Arrow, Chart, Shape, SheetData, SmartArt, WorkbookData
+from .tables import _column_index
+
+_Cells = dict[int, dict[int, str]]
+
+
+@dataclass(frozen=True)
+class _Block:
+    """Rendered Markdown block anchored at a 0-based sheet row."""
+
+    row: int
+    order: int
+    text: str
+
+
+def _inline(value: int | float | str) -> str:
+    """Render a value on one line, keeping line breaks as ``<br>``."""
+    text = str(value).replace("\r\n", "\n").strip()
+    return text.replace("\n", "<br>")
+
+
+def _table_cell(text: str) -> str:
+    return text.replace("|", "\\|")
+
+
+def _cells(sheet: SheetData) -> _Cells:
+    """Map 0-based row -> 0-based column -> rendered value."""
+    cells: _Cells = {}
+    for row in sheet.rows:
+        for key, value in row.c.items():
+            col = _column_index(key)
+            text = _inline(value)
+            if col is not None and text:
+                cells.setdefault(row.r - 1, {})[col] = text
+    return cells
+
+
+def _merged_titles(sheet: SheetData) -> set[tuple[int, int]]:
+    """Top-left cells (0-based) of merged ranges spanning several columns."""
+    bounds: list[RangeBounds] = []
+    if sheet.merged_cells is not None:
+        bounds.extend(
+            RangeBounds(r1=r1 - 1, c1=c1, r2=r2 - 1, c2=c2)
+            for r1, c1, r2, c2, _value in sheet.merged_cells.items
+        )
+    bounds.extend(
+        parsed
+        for parsed in (parse_range_zero_based(ref) for ref in sheet.merged_ranges)
+        if parsed is not None
+    )
+    return {(b.r1, b.c1) for b in bounds if b.c2 > b.c1}
+
+
+def _table_blocks(cells: _Cells, tables: list[RangeBounds]) -> list[_Block]:
+    """Render each table candidate as a pipe table with its first row as header."""
+    blocks: list[_Block] = []
+    for bounds in tables:
+        columns = range(bounds.c1, bounds.c2 + 1)
+        rows = [
+            [_table_cell(cells.get(r, {}).get(c, "")) for c in columns]
+            for r in range(bounds.r1, bounds.r2 + 1)
+            if any(c in cells.get(r, {}) for c in columns)
+        ]
+        if not rows:
+            continue
+        lines = [
+            "| " + " | ".join(rows[0]) + " |",
+            "| " + " | ".join("---" for _ in columns) + " |",
+        ]
+        lines.extend("| " + " | ".join(row) + " |" for row in rows[1:])
+        blocks.append(_Block(bounds.r1, 1, "\n".join(lines)))
+    return blocks
+
+
+def _text_blocks(
+    cells: _Cells,
+    tables: list[RangeBounds],
+    titles: set[tuple[int, int]],
+) -> list[_Block]:
+    """Render rows outside tables as headings or paragraphs.
+
+    A row holding only the value of a merged range spanning several columns
+    becomes a ``##`` heading; a row holding one value right above a table
+    becomes a ``###`` heading. Consecutive other rows form one paragraph.
+    """
+    table_starts = {bounds.r1 for bounds in tables}
+    blocks: list[_Block] = []
+    paragraph: list[str] = []
+    paragraph_row = 0
+    previous = -2
+    for r in sorted(cells):
+        if any(bounds.r1 <= r <= bounds.r2 for bounds in tables):
+            continue
+        row_cells = cells[r]
+        heading = _heading(r, row_cells, titles, table_starts)
+        if paragraph and (heading is not None or r != previous + 1):
+            blocks.append(_Block(paragraph_row, 0, "  \n".join(paragraph)))
+            paragraph = []
+        previous = r
+        if heading is not None:
+            blocks.append(_Block(r, 0, heading))
+            continue
+        if not paragraph:
+            paragraph_row = r
+        paragraph.append(" | ".join(row_cells[c] for c in sorted(row_cells)))
+    if paragraph:
+        blocks.append(_Block(paragraph_row, 0, "  \n".join(paragraph)))
+    return blocks
+
+
+def _heading(
+    r: int,
+    row_cells: dict[int, str],
+    titles: set[tuple[int, int]],
+    table_starts: set[int],
+) -> str | None:
+    if len(row_cells) != 1:
+        return None
+    ((c, text),) = row_cells.items()
+    text = text.replace("<br>", " ")
+    if (r, c) in titles:
+        return f"## {text}"
+    if r + 1 in table_starts:
+        return f"### {text}"
+    return None
+
+
+def _callout(shape: Shape | Arrow | SmartArt) -> str | None:
+    """Render shape text as a ``> [!NOTE]`` callout, or None without text."""
+    if isinstance(shape, SmartArt):
+        lines = [f"{shape.layout}:"]
+        lines.extend(f"- {_inline(node.text)}" for node in shape.nodes)
+    elif shape.text:
+        lines = shape.text.replace("\r\n", "\n").splitlines()
+    else:
+        return None
+    return "\n".join(["> [!NOTE]", *(f"> {line}".rstrip() for line in lines)])
+
+
+def _shape_row(shape: Shape | Arrow | SmartArt, last_row: int) -> int:
+    bounds = (
+        parse_range_zero_based(shape.covered_range) if shape.covered_range else None
+    )
+    return bounds.r1 if bounds is not None else last_row + 1
+
+
+def _chart_description(chart: Chart) -> str:
+    title = f' "{_inline(chart.title)}"' if chart.title else ""
+    series = ", ".join(
+        f"{s.name} ({s.y_range})" if s.y_range else s.name for s in chart.series
+    )
+    detail = f": {series}" if series else ""
+    return f"*Chart {chart.name}{title} ({chart.chart_type}){detail}*"
+
+
+def render_sheet_markdown(sheet_name: str, sheet: SheetData) -> str:
+    """Render one sheet as a Markdown section.
+
+    Args:
+        sheet_name: Sheet name used as the ``#`` heading.
+        sheet: Sheet to render.
+
+    Returns:
+        Markdown text ending with a newline.
+    """
+    cells = _cells(sheet)
+    tables = [
+        bounds
+        for bounds in (parse_range_zero_based(ref) for ref in sheet.table_candidates)
+        if bounds is not None
+    ]
+    blocks = _table_blocks(cells, tables)
+    blocks.extend(_text_blocks(cells, tables, _merged_titles(sheet)))
+    last_row = max(cells, default=-1)
+    blocks.extend(
+        _Block(_shape_row(shape, last_row), 2, callout)
+        for shape in sheet.shapes
+        if (callout := _callout(shape)) is not None
+    )
+    blocks.sort(key=lambda block: (block.row, block.order))
+    parts = [f"# {sheet_name}", *(block.text for block in blocks)]
+    parts.extend(_chart_description(chart) for chart in sheet.charts)
+    return "\n\n".join(parts) + "\n"
+
+
+def render_workbook_markdown(workbook: WorkbookData) -> str:
+    """Render every sheet as a Markdown document, top to bottom.
+
+    Each sheet starts with a ``#`` heading. Table candidates become pipe
+    tables headed by their first row; a value alone on a row becomes a heading
+    when it is the value of a merged range spanning several columns (``##``)
+    or sits right above a table (``###``); other rows become paragraphs with
+    cells separated by ``|``. Shape and SmartArt texts are placed as
+    ``> [!NOTE]`` callouts at the row of their covered range, and charts are
+    described after the sheet content.
+
+    Args:
+        workbook: Workbook to render.
+
+    Returns:
+        Markdown document.
+    """
+    return "\n".join(
+        render_sheet_markdown(name, sheet) for name, sheet in workbook.sheets.items()
+    )
+
+
+__all__ = ["render_sheet_markdown", "render_workbook_markdown"]
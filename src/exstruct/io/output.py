@@ -0,0 +1,424 @@
+"""Atomic, compressed, and size-split output files for serialized workbooks.
+
+Files under a directory registered with ``upload_target`` (object storage
+output, see ``exstruct.io.remote``) are buffered in memory by ``atomic_write``
+and handed to the target's upload callback instead of touching the disk.
+"""
+
+from __future__ import annotations
+
+from collections.abc import Callable, Iterator
+from contextlib import contextmanager
+import gzip
+import hashlib
+import importlib
+import io
+import json
+import logging
+import os
+from pathlib import Path
+import re
+import threading
+import time
+from types import ModuleType
+from typing import BinaryIO, Literal, Protocol
+import uuid
+
+from ..errors import (
+    ExstructError,
+    LimitExceededError,
+    MissingDependencyError,
+    OutputError,
+)
+from ..models.types import JsonStructure
+
+logger = logging.getLogger(__name__)
+
+Compression = Literal["gzip", "zstd"]
+
+COMPRESSION_SUFFIXES: dict[str, Compression] = {".gz": "gzip", ".zst": "zstd"}
+_SIZE_UNITS = {"": 1, "K": 1024, "M": 1024**2, "G": 1024**3}
+_SIZE_PATTERN = re.compile(r"^\s*(\d+)\s*([KMG]?)(?:I?B)?\s*$", re.IGNORECASE)
+_STREAM_CHUNK_CHARS = 1 << 16
+
+UploadFn = Callable[[Path, BinaryIO], None]
+_UPLOAD_LOCK = threading.Lock()
+_UPLOAD_TARGETS: dict[Path, UploadFn] = {}
+
+
+class _ByteSink(Protocol):
+    """Writable binary target (file, gzip file, or zstd stream writer)."""
+
+    def write(self, data: bytes, /) -> object: ...
+
+
+@contextmanager
+def atomic_path(path: Path) -> Iterator[Path]:
+    """Yield a temporary sibling of ``path`` that replaces it on success.
+
+    The temporary file sits in the same directory, so ``os.replace`` moves it
+    into place atomically: readers see either the previous file or the
+    complete new one, never a partial write. It is removed if the block raises.
+    """
+    tmp_path = path.with_name(f".{path.name}.{uuid.uuid4().hex[:8]}.tmp")
+    try:
+        yield tmp_path
+        os.replace(tmp_path, path)
+    finally:
+        tmp_path.unlink(missing_ok=True)
+
+
+@contextmanager
+def atomic_write(path: Path) -> Iterator[BinaryIO]:
+    """Open a binary file that appears at ``path`` only once fully written.
+
+    Under an ``upload_target`` directory the file is written to a memory
+    buffer that is uploaded once the block succeeds.
+    """
+    upload = upload_target_for(path)
+    if upload is not None:
+        buffer = io.BytesIO()
+        yield buffer
+        buffer.seek(0)
+        upload(path, buffer)
+        return
+    with atomic_path(path) as tmp_path, tmp_path.open("xb") as handle:
+        yield handle
+
+
+@contextmanager
+def upload_target(root: Path, upload: UploadFn) -> Iterator[Path]:
+    """Send files written under ``root`` to ``upload`` instead of the disk.
+
+    ``root`` is a virtual directory and never has to exist. ``upload`` gets
+    each completed file's path and a buffer positioned at its start.
+
+    Raises:
+        ValueError: If ``root`` is already registered.
+    """
+    with _UPLOAD_LOCK:
+        if root in _UPLOAD_TARGETS:
+            raise ValueError(f"Upload target '{root}' is already registered.")
+        _UPLOAD_TARGETS[root] = upload
+    try:
+        yield root
+    finally:
+        with _UPLOAD_LOCK:
+            _UPLOAD_TARGETS.pop(root, None)
+
+
+def upload_target_for(path: Path) -> UploadFn | None:
+    """Return the upload callback for a path under an ``upload_target``."""
+    return next(
+        (_UPLOAD_TARGETS[p] for p in path.parents if p in _UPLOAD_TARGETS), None
+    )
+
+
+def detect_compression(path: Path) -> Compression | None:
+    """Return the compression implied by the output file extension."""
+    return COMPRESSION_SUFFIXES.get(path.suffix.lower())
+
+
+def strip_compression_suffix(path: Path) -> Path:
+    """Drop a trailing ``.gz``/``.zst`` suffix (``out.json.gz`` -> ``out.json``)."""
+    return path.with_suffix("") if detect_compression(path) else path
+
+
+def parse_size(value: str) -> int:
+    """Parse a byte size such as ``1048576``, ``512K``, ``100M`` or ``2GB``.
+
+    Args:
+        value: Size text; units are binary (K=1024).
+
+    Returns:
+        Positive size in bytes.
+
+    Raises:
+        ValueError: If the text is not a positive size.
+    """
+    match = _SIZE_PATTERN.match(value)
+    if match is None:
+        raise ValueError(f"Invalid size '{value}'. Use e.g. 1048576, 512K, 100M, 2G.")
+    size = int(match.group(1)) * _SIZE_UNITS[match.group(2).upper()]
+    if size <= 0:
+        raise ValueError(f"Size must be positive: '{value}'.")
+    return size
+
+
+def part_path(path: Path, index: int, *, width: int = 3) -> Path:
+    """Return the numbered part path (``out.json.gz`` -> ``out.part001.json.gz``)."""
+    base = strip_compression_suffix(path)
+    suffix = base.suffix + path.suffix if base != path else base.suffix
+    return path.with_name(f"{base.stem}.part{index:0{width}d}{suffix}")
+
+
+def manifest_path(path: Path) -> Path:
+    """Return the manifest path for a split output (``out.manifest.json``)."""
+    base = strip_compression_suffix(path)
+    return path.with_name(f"{base.stem}.manifest.json")
+
+
+def split_utf8(data: bytes, split_size: int) -> list[bytes]:
+    """Split UTF-8 bytes into chunks of at most ``split_size`` bytes.
+
+    Chunk boundaries never fall inside a multi-byte character, so every chunk
+    decodes on its own. A chunk may exceed ``split_size`` only when the size is
+    smaller than a single character.
+    """
+    chunks: list[bytes] = []
+    start = 0
+    while start < len(data):
+        end = min(start + split_size, len(data))
+        while end < len(data) and end > start and data[end] & 0xC0 == 0x80:
+            end -= 1
+        if end == start:
+            end = min(start + split_size, len(data))
+            while end < len(data) and data[end] & 0xC0 == 0x80:
+                end += 1
+        chunks.append(data[start:end])
+        start = end
+    return chunks
+
+
+def compress_bytes(data: bytes, compression: Compression | None) -> bytes:
+    """Compress bytes with gzip or zstd; returns ``data`` unchanged for None."""
+    if compression == "gzip":
+        return gzip.compress(data, mtime=0)
+    if compression == "zstd":
+        return bytes(_require_zstd().ZstdCompressor().compress(data))
+    return data
+
+
+def write_output_text(
+    path: Path, text: str, *, split_size: int | None = None
+) -> list[Path]:
+    """Write serialized output, compressing and splitting it when requested.
+
+    Compression follows the extension (``.gz`` gzip, ``.zst`` zstd). With
+    ``split_size``, the UTF-8 text is cut into numbered parts of at most that
+    many uncompressed bytes and a ``<stem>.manifest.json`` lists them in order.
+    Each part is compressed on its own; gzip members and zstd frames can be
+    concatenated, so joining the parts in order yields the full output.
+
+    Args:
+        path: Output path.
+        text: Serialized output.
+        split_size: Maximum uncompressed bytes per part, or None for one file.
+
+    Returns:
+        Written paths (parts followed by the manifest when split).
+
+    Raises:
+        MissingDependencyError: If zstd output is requested without zstandard.
+        OutputError: If writing fails.
+    """
+    start = time.monotonic()
+    compression = detect_compression(path)
+    data = text.encode("utf-8")
+    if split_size is None:
+        _write_bytes(path, compress_bytes(data, compression))
+        logger.info("Wrote output to %s in %.2fs", path, time.monotonic() - start)
+        return [path]
+
+    chunks = split_utf8(data, split_size) or [b""]
+    width = max(3, len(str(len(chunks))))
+    written: list[Path] = []
+    parts: list[dict[str, object]] = []
+    for index, chunk in enumerate(chunks, start=1):
+        target = part_path(path, index, width=width)
+        payload = compress_bytes(chunk, compression)
+        _write_bytes(target, payload)
+        written.append(target)
+        parts.append(
+            {
+                "file": target.name,
+                "size": len(payload),
+                "uncompressed_size": len(chunk),
+                "sha256": hashlib.sha256(payload).hexdigest(),
+            }
+        )
+    manifest = {
+        "output": path.name,
+        "compression": compression,
+        "split_size": split_size,
+        "total_uncompressed_size": len(data),
+        "parts": parts,
+    }
+    target = manifest_path(path)
+    _write_bytes(target, json.dumps(manifest, indent=2).encode("utf-8"))
+    written.append(target)
+    logger.info(
+        "Wrote %d output parts for %s in %.2fs",
+        len(parts),
+        path,
+        time.monotonic() - start,
+    )
+    return written
+
+
+def write_output_json(
+    path: Path,
+    payload: JsonStructure,
+    *,
+    indent: int | None = None,
+    max_bytes: int | None = None,
+) -> list[Path]:
+    """Stream a JSON payload into ``path`` without building the whole text.
+
+    ``json.JSONEncoder.iterencode`` chunks are encoded and written (through
+    gzip or zstd, following the extension) as they are produced, so peak
+    memory stays near the payload itself. The file is written atomically;
+    exceeding ``max_bytes`` of uncompressed output aborts the write and
+    leaves any previous file untouched. Streamed zstd frames do not record
+    the content size, so decompress them with a streaming reader.
+
+    Args:
+        path: Output path.
+        payload: JSON-compatible payload.
+        indent: JSON indent width, or None for compact output.
+        max_bytes: Maximum uncompressed UTF-8 bytes, or None for no limit.
+
+    Returns:
+        The written path.
+
+    Raises:
+        LimitExceededError: If the output exceeds ``max_bytes``.
+        MissingDependencyError: If zstd output is requested without zstandard.
+        OutputError: If writing fails.
+    """
+    start = time.monotonic()
+    compression = detect_compression(path)
+    encoder = json.JSONEncoder(ensure_ascii=False, indent=indent)
+    try:
+        with atomic_write(path) as handle, _compressed(handle, compression) as sink:
+            _stream_chunks(encoder.iterencode(payload), sink, max_bytes)
+    except ExstructError:
+        raise
+    except Exception as exc:
+        raise OutputError(f"Failed to write output to '{path}'.") from exc
+    logger.info("Wrote output to %s in %.2fs", path, time.monotonic() - start)
+    return [path]
+
+
+def write_output_encoded(
+    path: Path, encode: Callable[[_ByteSink], object]
+) -> list[Path]:
+    """Write output produced by ``encode`` (e.g. a custom encoder) into ``path``.
+
+    ``encode`` receives a binary sink that compresses following the extension;
+    the file is written atomically, so a failing ``encode`` leaves any
+    previous file untouched.
+
+    Args:
+        path: Output path.
+        encode: Callable writing the output bytes to the given sink.
+
+    Returns:
+        The written path.
+
+    Raises:
+        MissingDependencyError: If zstd output is requested without zstandard.
+        OutputError: If encoding or writing fails.
+    """
+    start = time.monotonic()
+    compression = detect_compression(path)
+    try:
+        with atomic_write(path) as handle, _compressed(handle, compression) as sink:
+            encode(sink)
+    except ExstructError:
+        raise
+    except Exception as exc:
+        raise OutputError(f"Failed to write output to '{path}'.") from exc
+    logger.info("Wrote output to %s in %.2fs", path, time.monotonic() - start)
+    return [path]
+
+
+@contextmanager
+def _compressed(
+    handle: BinaryIO, compression: Compression | None
+) -> Iterator[_ByteSink]:
+    """Wrap ``handle`` in a streaming gzip/zstd compressor, or pass it through."""
+    if compression == "gzip":
+        with gzip.GzipFile(filename="", mode="wb", fileobj=handle, mtime=0) as sink:
+            yield sink
+    elif compression == "zstd":
+        compressor = _require_zstd().ZstdCompressor()
+        with compressor.stream_writer(handle, closefd=False) as sink:
+            yield sink
+    else:
+        yield handle
+
+
+def _stream_chunks(
+    chunks: Iterator[str], sink: _ByteSink, max_bytes: int | None
+) -> None:
+    """Write text chunks as UTF-8 in batches, enforcing ``max_bytes``."""
+    pending: list[str] = []
+    pending_chars = 0
+    total = 0
+    for chunk in chunks:
+        pending.append(chunk)
+        pending_chars += len(chunk)
+        if pending_chars >= _STREAM_CHUNK_CHARS:
+            total += _flush_chunks(pending, sink, total, max_bytes)
+            pending_chars = 0
+    _flush_chunks(pending, sink, total, max_bytes)
+
+
+def _flush_chunks(
+    pending: list[str], sink: _ByteSink, total: int, max_bytes: int | None
+) -> int:
+    """Write and clear pending chunks; returns the bytes written.
+
+    Raises:
+        LimitExceededError: If ``total`` plus this batch exceeds ``max_bytes``.
+    """
+    data = "".join(pending).encode("utf-8")
+    pending.clear()
+    if max_bytes is not None and total + len(data) > max_bytes:
+        raise LimitExceededError(f"Output exceeds max_output_bytes={max_bytes}.")
+    sink.write(data)
+    return len(data)
+
+
+def _write_bytes(path: Path, payload: bytes) -> None:
+    """Write bytes atomically, wrapping IO errors."""
+    try:
+        with atomic_write(path) as handle:
+            handle.write(payload)
+    except ExstructError:
+        raise
+    except Exception as exc:
+        raise OutputError(f"Failed to write output to '{path}'.") from exc
+
+
+def _require_zstd() -> ModuleType:
+    """Ensure zstandard is installed; otherwise raise with guidance."""
+    try:
+        module = importlib.import_module("zstandard")
+    except ImportError as e:
+        raise MissingDependencyError(
+            "zstd output requires zstandard. Install it via "
+            "`pip install zstandard` or add the 'zstd' extra."
+        ) from e
+    return module
+
+
+__all__ = [
+    "COMPRESSION_SUFFIXES",
+    "UploadFn",
+    "atomic_path",
+    "atomic_write",
+    "compress_bytes",
+    "detect_compression",
+    "manifest_path",
+    "parse_size",
+    "part_path",
+    "split_utf8",
+    "strip_compression_suffix",
+    "upload_target",
+    "upload_target_for",
+    "write_output_encoded",
+    "write_output_json",
+    "write_output_text",
+]
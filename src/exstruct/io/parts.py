@@ -0,0 +1,76 @@
+"""Raw OOXML part dump (``--dump-parts``) for debugging extraction output."""
+
+from __future__ import annotations
+
+from collections.abc import Sequence
+import logging
+from pathlib import Path
+from typing import Literal
+import zipfile
+
+from ..ooxml.safety import open_package, read_part
+from ..sources import open_source
+from . import _sanitize_sheet_filename
+
+logger = logging.getLogger(__name__)
+
+PartKind = Literal["drawings", "charts", "tables"]
+DEFAULT_PART_KINDS: tuple[PartKind, ...] = ("drawings", "charts", "tables")
+
+
+def dump_parts(
+    file_path: Path,
+    output_dir: Path,
+    kinds: Sequence[PartKind] = DEFAULT_PART_KINDS,
+) -> dict[str, Path]:
+    """Copy the raw drawing, chart, and table parts of a workbook to a directory.
+
+    Parts keep their package layout below ``xl/`` (``drawings/drawing1.xml``,
+    ``drawings/_rels/drawing1.xml.rels``, ``charts/chart1.xml``, ...), so they
+    can be compared with the JSON output. Every path component is made
+    filename-safe, and part names with empty, ``.`` or ``..`` components are
+    skipped so nothing is written outside ``output_dir``. Non-OOXML workbooks
+    (.xls) have no parts and are skipped with a warning.
+
+    Args:
+        file_path: Workbook path.
+        output_dir: Target directory; created when parts are written.
+        kinds: Part folders under ``xl/`` to dump.
+
+    Returns:
+        Map of part name to written path.
+
+    Raises:
+        UnsafeWorkbookError: If the package or a part fails the size checks.
+    """
+    if not zipfile.is_zipfile(open_source(file_path)):
+        logger.warning("Not an OOXML package; no parts dumped from %s", file_path)
+        return {}
+    prefixes = tuple(f"xl/{kind}/" for kind in kinds)
+    written: dict[str, Path] = {}
+    with open_package(file_path) as zf:
+        for name in zf.namelist():
+            if not name.startswith(prefixes) or name.endswith("/"):
+                continue
+            relative = _safe_relative_path(name.removeprefix("xl/"))
+            if relative is None:
+                logger.warning("Skipping part with an unsafe name: %r", name)
+                continue
+            target = output_dir / relative
+            target.parent.mkdir(parents=True, exist_ok=True)
+            target.write_bytes(read_part(zf, name))
+            written[name] = target
+    if not written:
+        logger.info("No %s parts found in %s", "/".join(kinds), file_path)
+    return written
+
+
+def _safe_relative_path(name: str) -> Path | None:
+    """Return a filesystem-safe relative path for a part name, or None."""
+    components = name.split("/")
+    if any(component in {"", ".", ".."} for component in components):
+        return None
+    return Path(*(_sanitize_sheet_filename(component) for component in components))
+
+
+__all__ = ["DEFAULT_PART_KINDS", "PartKind", "dump_parts"]
@@ -0,0 +1,408 @@
+"""Protocol Buffers encoding of workbooks following ``schemas/exstruct.proto``.
+
+The encoder writes the proto3 wire format directly, so no generated code or
+protobuf runtime is needed on the producing side. ``PROTO_SCHEMA`` mirrors the
+``.proto`` file field for field; consumers compile the ``.proto`` with protoc.
+"""
+
+from __future__ import annotations
+
+from collections.abc import Iterable, Mapping
+import logging
+from pathlib import Path
+import struct
+import time
+from typing import Any, NamedTuple, cast
+
+from ..errors import OutputError
+from ..models import SheetData, WorkbookData
+from . import _without_sheet_backend_metadata
+from .output import atomic_write
+
+logger = logging.getLogger(__name__)
+
+_SCALAR_TYPES = frozenset({"int64", "double", "string", "bool"})
+_WIRE_VARINT = 0
+_WIRE_FIXED64 = 1
+_WIRE_LEN = 2
+
+
+class ProtoField(NamedTuple):
+    """One field of a message in ``schemas/exstruct.proto``."""
+
+    name: str
+    number: int
+    type: str
+    repeated: bool = False
+
+
+def _fields(
+    *specs: tuple[str, int, str] | tuple[str, int, str, bool],
+) -> tuple[ProtoField, ...]:
+    """Build fields from ``(name, number, type[, repeated])`` tuples."""
+    return tuple(ProtoField(*spec) for spec in specs)
+
+
+_SHAPE_COMMON: tuple[tuple[str, int, str], ...] = (
+    ("id", 1, "int64"),
+    ("text", 2, "string"),
+    ("l", 3, "int64"),
+    ("t", 4, "int64"),
+    ("w", 5, "int64"),
+    ("h", 6, "int64"),
+    ("rotation", 7, "double"),
+    ("covered_range", 8, "string"),
+    ("z_order", 9, "int64"),
+    ("provenance", 10, "string"),
+    ("approximation_level", 11, "string"),
+    ("confidence", 12, "double"),
+    ("source_id", 13, "string"),
+)
+
+PROTO_SCHEMA: dict[str, tuple[ProtoField, ...]] = {
+    "WorkbookData": _fields(
+        ("book_name", 1, "string"), ("sheets", 2, "NamedSheet", True)
+    ),
+    "NamedSheet": _fields(("name", 1, "string"), ("sheet", 2, "SheetData")),
+    "SheetData": _fields(
+        ("rows", 1, "CellRow", True),
+        ("shapes", 2, "ShapeItem", True),
+        ("charts", 3, "Chart", True),
+        ("table_candidates", 4, "string", True),
+        ("print_areas", 5, "PrintArea", True),
+        ("auto_print_areas", 6, "PrintArea", True),
+        ("formulas_map", 7, "FormulaCells", True),
+        ("colors_map", 8, "FormulaCells", True),
+        ("merged_cells", 9, "MergedCell", True),
+        ("merged_ranges", 10, "string", True),
+        ("column_widths", 11, "SizeEntry", True),
+        ("row_heights", 12, "SizeEntry", True),
+        ("default_column_width", 13, "double"),
+        ("default_row_height", 14, "double"),
+        ("errors", 15, "CellError", True),
+        ("content_hash", 16, "string"),
+        ("table_hashes", 17, "StringEntry", True),
+        ("formulas_map_r1c1", 18, "FormulaCells", True),
+        ("formula_blocks", 19, "FormulaBlock", True),
+        ("table_stats", 20, "TableStats", True),
+        ("table_scores", 21, "SizeEntry", True),
+        ("named_ranges", 22, "NamedRange", True),
+        ("max_digit_width", 23, "int64"),
+        ("style_map", 24, "FormulaCells", True),
+    ),
+    "CellRow": _fields(
+        ("r", 1, "int64"),
+        ("c", 2, "CellEntry", True),
+        ("links", 3, "StringEntry", True),
+        ("phonetic", 4, "StringEntry", True),
+    ),
+    "CellEntry": _fields(("key", 1, "string"), ("value", 2, "CellValue")),
+    "CellValue": _fields(
+        ("int_value", 1, "int64"),
+        ("double_value", 2, "double"),
+        ("string_value", 3, "string"),
+    ),
+    "StringEntry": _fields(("key", 1, "string"), ("value", 2, "string")),
+    "SizeEntry": _fields(("key", 1, "string"), ("value", 2, "double")),
+    "CellRef": _fields(("r", 1, "int64"), ("c", 2, "int64")),
+    "FormulaCells": _fields(("key", 1, "string"), ("cells", 2, "CellRef", True)),
+    "FormulaBlock": _fields(
+        ("range", 1, "string"), ("r1c1", 2, "string"), ("formula", 3, "string")
+    ),
+    "TableStats": _fields(("key", 1, "string"), ("columns", 2, "ColumnStats", True)),
+    "ColumnStats": _fields(
+        ("column", 1, "string"),
+        ("header", 2, "string"),
+        ("count", 3, "int64"),
+        ("null_rate", 4, "double"),
+        ("distinct_count", 5, "int64"),
+        ("min", 6, "double"),
+        ("max", 7, "double"),
+        ("mean", 8, "double"),
+        ("anomalies", 9, "ColumnAnomaly", True),
+        ("unit", 10, "string"),
+        ("currency", 11, "string"),
+    ),
+    "ColumnAnomaly": _fields(
+        ("kind", 1, "string"),
+        ("cell", 2, "string"),
+        ("value", 3, "CellValue"),
+        ("message", 4, "string"),
+    ),
+    "MergedCell": _fields(
+        ("r1", 1, "int64"),
+        ("c1", 2, "int64"),
+        ("r2", 3, "int64"),
+        ("c2", 4, "int64"),
+        ("value", 5, "string"),
+    ),
+    "PrintArea": _fields(
+        ("r1", 1, "int64"), ("c1", 2, "int64"), ("r2", 3, "int64"), ("c2", 4, "int64")
+    ),
+    "NamedRange": _fields(
+        ("name", 1, "string"),
+        ("r1", 2, "int64"),
+        ("c1", 3, "int64"),
+        ("r2", 4, "int64"),
+        ("c2", 5, "int64"),
+        ("local", 6, "bool"),
+    ),
+    "CellError": _fields(
+        ("cell", 1, "string"), ("error", 2, "string"), ("formula", 3, "string")
+    ),
+    "ShapeItem": _fields(
+        ("shape", 1, "Shape"), ("arrow", 2, "Arrow"), ("smartart", 3, "SmartArt")
+    ),
+    "Shape": _fields(
+        *_SHAPE_COMMON,
+        ("type", 20, "string"),
+        ("text_align", 21, "string"),
+        ("text_anchor", 22, "string"),
+        ("text_wrap", 23, "bool"),
+        ("text_orientation", 24, "string"),
+        ("link", 25, "string"),
+        ("on_action", 26, "string"),
+    ),
+    "Arrow": _fields(
+        *_SHAPE_COMMON,
+        ("begin_arrow_style", 20, "int64"),
+        ("end_arrow_style", 21, "int64"),
+        ("begin_arrow_width", 22, "int64"),
+        ("begin_arrow_length", 23, "int64"),
+        ("end_arrow_width", 24, "int64"),
+        ("end_arrow_length", 25, "int64"),
+        ("line_dash_style", 26, "int64"),
+        ("line_weight", 27, "double"),
+        ("begin_id", 28, "int64"),
+        ("end_id", 29, "int64"),
+        ("direction", 30, "string"),
+        ("begin_x", 31, "int64"),
+        ("begin_y", 32, "int64"),
+        ("end_x", 33, "int64"),
+        ("end_y", 34, "int64"),
+        ("begin_cell", 35, "string"),
+        ("end_cell", 36, "string"),
+    ),
+    "SmartArt": _fields(
+        *_SHAPE_COMMON,
+        ("layout", 20, "string"),
+        ("nodes", 21, "SmartArtNode", True),
+    ),
+    "SmartArtNode": _fields(("text", 1, "string"), ("kids", 2, "SmartArtNode", True)),
+    "Chart": _fields(
+        ("name", 1, "string"),
+        ("chart_type", 2, "string"),
+        ("title", 3, "string"),
+        ("y_axis_title", 4, "string"),
+        ("y_axis_range", 5, "double", True),
+        ("w", 6, "int64"),
+        ("h", 7, "int64"),
+        ("series", 8, "ChartSeries", True),
+        ("l", 9, "int64"),
+        ("t", 10, "int64"),
+        ("grouping", 11, "string"),
+        ("bar_direction", 12, "string"),
+        ("error", 13, "string"),
+        ("provenance", 14, "string"),
+        ("approximation_level", 15, "string"),
+        ("confidence", 16, "double"),
+        ("scatter_style", 17, "string"),
+    ),
+    "ChartSeries": _fields(
+        ("name", 1, "string"),
+        ("name_range", 2, "string"),
+        ("x_range", 3, "string"),
+        ("y_range", 4, "string"),
+        ("size_range", 5, "string"),
+    ),
+}
+
+
+def _varint(value: int) -> bytes:
+    """Encode an int64 as a base-128 varint (negatives use two's complement)."""
+    value &= (1 << 64) - 1
+    out = bytearray()
+    while True:
+        byte = value & 0x7F
+        value >>= 7
+        if value:
+            out.append(byte | 0x80)
+        else:
+            out.append(byte)
+            return bytes(out)
+
+
+def _tag(number: int, wire_type: int) -> bytes:
+    return _varint((number << 3) | wire_type)
+
+
+def _scalar(field_type: str, value: Any) -> bytes:
+    """Encode a scalar value without its tag."""
+    if field_type == "double":
+        return struct.pack("<d", float(value))
+    if field_type == "string":
+        data = str(value).encode("utf-8")
+        return _varint(len(data)) + data
+    return _varint(int(value))
+
+
+def _wire_type(field_type: str) -> int:
+    if field_type == "double":
+        return _WIRE_FIXED64
+    if field_type in ("int64", "bool"):
+        return _WIRE_VARINT
+    return _WIRE_LEN
+
+
+def encode_message(message: str, payload: Mapping[str, object]) -> bytes:
+    """Encode a proto-shaped payload as the named message.
+
+    Keys missing from the schema are ignored and None values are skipped.
+    Repeated numeric fields use packed encoding.
+
+    Args:
+        message: Message name in ``PROTO_SCHEMA``.
+        payload: Field name to value mapping (nested messages as mappings).
+
+    Returns:
+        Serialized message bytes.
+    """
+    out = bytearray()
+    for field in PROTO_SCHEMA[message]:
+        value = payload.get(field.name)
+        if value is None:
+            continue
+        values = list(cast(Iterable[object], value)) if field.repeated else [value]
+        if not values:
+            continue
+        if field.type not in _SCALAR_TYPES:
+            for item in values:
+                data = encode_message(field.type, cast(Mapping[str, object], item))
+                out += _tag(field.number, _WIRE_LEN) + _varint(len(data)) + data
+        elif field.repeated and field.type != "string":
+            data = b"".join(_scalar(field.type, item) for item in values)
+            out += _tag(field.number, _WIRE_LEN) + _varint(len(data)) + data
+        else:
+            for item in values:
+                out += _tag(field.number, _wire_type(field.type))
+                out += _scalar(field.type, item)
+    return bytes(out)
+
+
+def _cell_value(value: int | float | str) -> dict[str, object]:
+    if isinstance(value, int):
+        return {"int_value": value}
+    if isinstance(value, float):
+        return {"double_value": value}
+    return {"string_value": value}
+
+
+def _entries(mapping: Mapping[str, object]) -> list[dict[str, object]]:
+    return [{"key": key, "value": value} for key, value in mapping.items()]
+
+
+def _cell_refs(mapping: Mapping[str, list[tuple[int, int]]]) -> list[object]:
+    return [
+        {"key": key, "cells": [{"r": r, "c": c} for r, c in cells]}
+        for key, cells in mapping.items()
+    ]
+
+
+def _sheet_payload(sheet: SheetData) -> dict[str, object]:
+    """Reshape SheetData into the field layout of the SheetData message."""
+    payload: dict[str, object] = sheet.model_dump(exclude_none=True, by_alias=True)
+    payload["rows"] = [
+        {
+            "r": row.r,
+            "c": [
+                {"key": key, "value": _cell_value(value)}
+                for key, value in row.c.items()
+            ],
+            "links": _entries(row.links or {}),
+            "phonetic": _entries(row.phonetic or {}),
+        }
+        for row in sheet.rows
+    ]
+    payload["shapes"] = [
+        {shape.kind: shape.model_dump(exclude_none=True)} for shape in sheet.shapes
+    ]
+    payload["formulas_map"] = _cell_refs(sheet.formulas_map)
+    payload["formulas_map_r1c1"] = _cell_refs(sheet.formulas_map_r1c1)
+    payload["colors_map"] = _cell_refs(sheet.colors_map)
+    payload["style_map"] = _cell_refs(sheet.style_map)
+    payload["merged_cells"] = [
+        {"r1": r1, "c1": c1, "r2": r2, "c2": c2, "value": value}
+        for r1, c1, r2, c2, value in (
+            sheet.merged_cells.items if sheet.merged_cells else []
+        )
+    ]
+    payload["column_widths"] = _entries(sheet.column_widths)
+    payload["row_heights"] = _entries(sheet.row_heights)
+    payload["table_hashes"] = _entries(sheet.table_hashes)
+    payload["table_scores"] = _entries(sheet.table_scores)
+    payload["table_stats"] = [
+        {
+            "key": key,
+            "columns": [
+                {
+                    **column.model_dump(),
+                    "anomalies": [
+                        {**anomaly.model_dump(), "value": _cell_value(anomaly.value)}
+                        for anomaly in column.anomalies
+                    ],
+                }
+                for column in columns
+            ],
+        }
+        for key, columns in sheet.table_stats.items()
+    ]
+    return payload
+
+
+def to_protobuf(
+    model: WorkbookData, *, include_backend_metadata: bool = False
+) -> bytes:
+    """Serialize a workbook as a ``exstruct.v1.WorkbookData`` protobuf message.
+
+    Args:
+        model: Workbook to serialize.
+        include_backend_metadata: Keep shape/chart backend metadata fields.
+
+    Returns:
+        Protobuf wire-format bytes.
+    """
+    sheets: list[dict[str, object]] = []
+    for sheet_name, sheet in model.sheets.items():
+        if not include_backend_metadata:
+            sheet = _without_sheet_backend_metadata(sheet)
+        sheets.append({"name": sheet_name, "sheet": _sheet_payload(sheet)})
+    return encode_message(
+        "WorkbookData", {"book_name": model.book_name, "sheets": sheets}
+    )
+
+
+def save_as_protobuf(
+    model: WorkbookData, path: Path, *, include_backend_metadata: bool = False
+) -> None:
+    """Write a workbook as a binary protobuf file.
+
+    Raises:
+        OutputError: If the file cannot be written.
+    """
+    start = time.monotonic()
+    data = to_protobuf(model, include_backend_metadata=include_backend_metadata)
+    try:
+        with atomic_write(path) as handle:
+            handle.write(data)
+    except Exception as exc:
+        raise OutputError(f"Failed to write output to '{path}'.") from exc
+    logger.info("Wrote output to %s in %.2fs", path, time.monotonic() - start)
+
+
+__all__ = [
+    "PROTO_SCHEMA",
+    "ProtoField",
+    "encode_message",
+    "save_as_protobuf",
+    "to_protobuf",
+]
@@ -0,0 +1,258 @@
+"""Object storage URIs (``s3://``, ``gs://``, ``azblob://``) for input and output.
+
+Nothing is staged on the local disk. A remote input is streamed into memory
+and registered under its URI as an in-memory workbook (``exstruct.sources``),
+which every reader opens with random access; concurrent runs on the same
+object share one download. Excel COM, LibreOffice and PDF/PNG rendering need
+a real file and are not available for it. Output files (including split
+parts, manifests and compressed output) are written to memory buffers by
+``atomic_write`` and streamed to the bucket from those buffers with the SDK's
+multipart / resumable uploads.
+
+The SDKs are imported on demand:
+
+- ``s3://bucket/key``: ``boto3`` with its default credential chain.
+- ``gs://bucket/name``: ``google-cloud-storage`` with application default
+  credentials.
+- ``azblob://container/name``: ``azure-storage-blob`` with the connection
+  string in ``AZURE_STORAGE_CONNECTION_STRING``.
+"""
+
+from __future__ import annotations
+
+from collections.abc import Iterator
+from contextlib import contextmanager
+import importlib
+import io
+import logging
+import os
+from pathlib import Path, PurePosixPath
+import time
+from types import ModuleType
+from typing import Any, BinaryIO, NamedTuple
+import uuid
+
+from ..errors import (
+    ConfigError,
+    ExtractionError,
+    MissingDependencyError,
+    OutputError,
+)
+from ..sources import MEMORY_ROOT, memory_source
+from .output import upload_target
+
+logger = logging.getLogger(__name__)
+
+REMOTE_SCHEMES = ("s3", "gs", "azblob")
+_AZURE_CONNECTION_ENV = "AZURE_STORAGE_CONNECTION_STRING"
+
+
+class RemoteUri(NamedTuple):
+    """Parsed object storage URI."""
+
+    scheme: str
+    bucket: str
+    key: str
+
+    @property
+    def name(self) -> str:
+        """Return the object's base name (``book.xlsx``)."""
+        return PurePosixPath(self.key).name
+
+    def sibling(self, name: str) -> RemoteUri:
+        """Return the URI of another object under the same prefix."""
+        parent = str(PurePosixPath(self.key).parent)
+        key = name if parent == "." else f"{parent}/{name}"
+        return RemoteUri(self.scheme, self.bucket, key)
+
+    def __str__(self) -> str:
+        return f"{self.scheme}://{self.bucket}/{self.key}"
+
+
+def is_remote_uri(value: object) -> bool:
+    """Return whether ``value`` is a string naming an object storage URI."""
+    if not isinstance(value, str):
+        return False
+    scheme, sep, _ = value.partition("://")
+    return bool(sep) and scheme.lower() in REMOTE_SCHEMES
+
+
+def parse_remote_uri(value: str) -> RemoteUri:
+    """Split ``scheme://bucket/key`` into its parts.
+
+    Raises:
+        ValueError: If the scheme is unsupported or the bucket or key is empty.
+    """
+    scheme, sep, rest = value.partition("://")
+    bucket, _, key = rest.partition("/")
+    if not sep or scheme.lower() not in REMOTE_SCHEMES:
+        raise ValueError(
+            f"Unsupported URI '{value}'; expected one of "
+            + ", ".join(f"{name}://" for name in REMOTE_SCHEMES)
+        )
+    if not bucket or not key or key.endswith("/"):
+        raise ValueError(f"URI '{value}' must name a bucket and an object key.")
+    return RemoteUri(scheme.lower(), bucket, key)
+
+
+def download(uri: RemoteUri, handle: BinaryIO) -> None:
+    """Stream an object into a writable binary handle."""
+    if uri.scheme == "s3":
+        _s3_client().download_fileobj(uri.bucket, uri.key, handle)
+    elif uri.scheme == "gs":
+        _gcs_blob(uri).download_to_file(handle)
+    else:
+        _azure_blob(uri).download_blob().readinto(handle)
+
+
+def upload(handle: BinaryIO, uri: RemoteUri) -> None:
+    """Stream a readable binary handle into an object, replacing it."""
+    if uri.scheme == "s3":
+        _s3_client().upload_fileobj(handle, uri.bucket, uri.key)
+    elif uri.scheme == "gs":
+        _gcs_blob(uri).upload_from_file(handle)
+    else:
+        _azure_blob(uri).upload_blob(handle, overwrite=True)
+
+
+def validate_remote_request(
+    file_path: str | Path,
+    output_path: str | Path | None,
+    *,
+    mode: str,
+    render: bool,
+) -> None:
+    """Reject options that need the workbook or outputs as local files.
+
+    Raises:
+        ConfigError: If a URI is combined with PDF/PNG rendering, or a remote
+            input with libreoffice mode.
+    """
+    remote_in = is_remote_uri(file_path)
+    if render and (remote_in or is_remote_uri(output_path)):
+        raise ConfigError(
+            "PDF/PNG rendering needs local files; it is not supported with "
+            "object storage URIs."
+        )
+    if remote_in and mode == "libreoffice":
+        raise ConfigError(
+            "libreoffice mode needs a local workbook file; it is not supported "
+            "with object storage URIs."
+        )
+
+
+@contextmanager
+def remote_input(file_path: str | Path) -> Iterator[Path]:
+    """Yield a workbook path for an input, fetching object storage URIs first.
+
+    Local paths are yielded unchanged. A remote object is streamed into memory
+    and yielded as a virtual in-memory workbook path that keeps the object's
+    file name (so ``book_name`` matches the object) until the block exits.
+    Blocks open at the same time on one URI share a single download.
+
+    Raises:
+        ExtractionError: If the object cannot be downloaded.
+    """
+    if not is_remote_uri(file_path):
+        yield Path(file_path)
+        return
+    uri = parse_remote_uri(str(file_path))
+    with memory_source(str(uri), uri.name, lambda: _download_bytes(uri)) as path:
+        yield path
+
+
+@contextmanager
+def remote_output(output_path: str | Path | None) -> Iterator[Path | None]:
+    """Yield an output path, uploading what is written when it is a URI.
+
+    For an object storage URI, the yielded path is virtual (a fresh
+    ``<memory>/<id>/`` directory per block, so concurrent runs never clash):
+    every file written next to it through ``atomic_write`` (the output, its
+    numbered parts and manifest) is buffered in memory and uploaded under the
+    URI's prefix as soon as it is complete.
+
+    Raises:
+        OutputError: If an upload fails.
+    """
+    if output_path is None or not is_remote_uri(output_path):
+        yield None if output_path is None else Path(output_path)
+        return
+    uri = parse_remote_uri(str(output_path))
+    root = MEMORY_ROOT / uuid.uuid4().hex
+
+    def _send(path: Path, handle: BinaryIO) -> None:
+        _upload_stream(handle, uri.sibling(path.relative_to(root).as_posix()))
+
+    with upload_target(root, _send):
+        yield root / uri.name
+
+
+def _download_bytes(uri: RemoteUri) -> bytes:
+    buffer = io.BytesIO()
+    start = time.monotonic()
+    try:
+        download(uri, buffer)
+    except (ConfigError, MissingDependencyError):
+        raise
+    except Exception as exc:
+        raise ExtractionError(f"Failed to download '{uri}'.") from exc
+    logger.info("Downloaded %s in %.2fs", uri, time.monotonic() - start)
+    return buffer.getvalue()
+
+
+def _upload_stream(handle: BinaryIO, uri: RemoteUri) -> None:
+    start = time.monotonic()
+    try:
+        upload(handle, uri)
+    except (ConfigError, MissingDependencyError):
+        raise
+    except Exception as exc:
+        raise OutputError(f"Failed to upload output to '{uri}'.") from exc
+    logger.info("Uploaded %s in %.2fs", uri, time.monotonic() - start)
+
+
+def _s3_client() -> Any:
+    return _require("boto3", "s3://", "s3", "boto3").client("s3")
+
+
+def _gcs_blob(uri: RemoteUri) -> Any:
+    storage = _require(
+        "google.cloud.storage", "gs://", "gcs", "google-cloud-storage"
+    )
+    return storage.Client().bucket(uri.bucket).blob(uri.key)
+
+
+def _azure_blob(uri: RemoteUri) -> Any:
+    blob = _require("azure.storage.blob", "azblob://", "azure", "azure-storage-blob")
+    connection = os.environ.get(_AZURE_CONNECTION_ENV)
+    if not connection:
+        raise ConfigError(
+            f"azblob:// URIs require the {_AZURE_CONNECTION_ENV} "
+            "environment variable."
+        )
+    service = blob.BlobServiceClient.from_connection_string(connection)
+    return service.get_blob_client(container=uri.bucket, blob=uri.key)
+
+
+def _require(module: str, scheme: str, extra: str, package: str) -> ModuleType:
+    """Import an SDK module or raise with installation guidance."""
+    try:
+        return importlib.import_module(module)
+    except ImportError as e:
+        raise MissingDependencyError(
+            f"{scheme} URIs require {package}. Install it via "
+            f"`pip install {package}` or add the '{extra}' extra."
+        ) from e
+
+
+__all__ = [
+    "REMOTE_SCHEMES",
+    "RemoteUri",
+    "download",
+    "is_remote_uri",
+    "parse_remote_uri",
+    "remote_input",
+    "remote_output",
+    "upload",
+    "validate_remote_request",
+]
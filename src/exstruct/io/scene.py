@@ -0,0 +1,200 @@
+"""Drawing primitives shared by the SVG/PNG preview renderers.
+
+Renderers lay out a list of primitives in pixel coordinates (origin at the
+top-left corner) and hand it to ``render_svg`` or ``render_png``, so both
+formats stay in sync without pulling in a plotting library.
+"""
+
+from __future__ import annotations
+
+from dataclasses import dataclass
+import importlib
+import io
+import math
+from types import ModuleType
+from typing import Literal
+from xml.sax.saxutils import escape
+
+from ..errors import MissingDependencyError
+
+TextAnchor = Literal["start", "middle", "end"]
+
+
+@dataclass(frozen=True)
+class Rect:
+    """Axis-aligned rectangle; ``fill`` or ``stroke`` may be None."""
+
+    x: float
+    y: float
+    w: float
+    h: float
+    fill: str | None
+    stroke: str | None = None
+
+
+@dataclass(frozen=True)
+class Line:
+    """Open polyline."""
+
+    points: list[tuple[float, float]]
+    stroke: str
+    width: float = 2.0
+
+
+@dataclass(frozen=True)
+class Wedge:
+    """Pie slice from ``start`` to ``end`` degrees (clockwise from 3 o'clock)."""
+
+    cx: float
+    cy: float
+    r: float
+    start: float
+    end: float
+    fill: str
+
+
+@dataclass(frozen=True)
+class Label:
+    """Single line of text vertically centered on ``y``.
+
+    ``rotation`` turns the line clockwise by that many degrees around
+    ``(x, y)`` (90 for top-to-bottom vertical text).
+    """
+
+    x: float
+    y: float
+    text: str
+    anchor: TextAnchor = "middle"
+    size: int = 12
+    fill: str = "#000000"
+    rotation: float = 0.0
+
+
+Primitive = Rect | Line | Wedge | Label
+
+
+def _svg_wedge(wedge: Wedge) -> str:
+    if wedge.end - wedge.start >= 359.999:
+        return (
+            f'<circle cx="{wedge.cx:.1f}" cy="{wedge.cy:.1f}" r="{wedge.r:.1f}" '
+            f'fill="{wedge.fill}"/>'
+        )
+    x1 = wedge.cx + wedge.r * math.cos(math.radians(wedge.start))
+    y1 = wedge.cy + wedge.r * math.sin(math.radians(wedge.start))
+    x2 = wedge.cx + wedge.r * math.cos(math.radians(wedge.end))
+    y2 = wedge.cy + wedge.r * math.sin(math.radians(wedge.end))
+    large = 1 if wedge.end - wedge.start > 180 else 0
+    return (
+        f'<path d="M{wedge.cx:.1f},{wedge.cy:.1f} L{x1:.1f},{y1:.1f} '
+        f'A{wedge.r:.1f},{wedge.r:.1f} 0 {large} 1 {x2:.1f},{y2:.1f} Z" '
+        f'fill="{wedge.fill}"/>'
+    )
+
+
+def _svg_element(item: Primitive) -> str:
+    if isinstance(item, Rect):
+        stroke = f' stroke="{item.stroke}"' if item.stroke else ""
+        return (
+            f'<rect x="{item.x:.1f}" y="{item.y:.1f}" width="{item.w:.1f}" '
+            f'height="{item.h:.1f}" fill="{item.fill or "none"}"{stroke}/>'
+        )
+    if isinstance(item, Line):
+        points = " ".join(f"{x:.1f},{y:.1f}" for x, y in item.points)
+        return (
+            f'<polyline points="{points}" fill="none" stroke="{item.stroke}" '
+            f'stroke-width="{item.width:g}"/>'
+        )
+    if isinstance(item, Wedge):
+        return _svg_wedge(item)
+    rotate = (
+        f' transform="rotate({item.rotation:g} {item.x:.1f} {item.y:.1f})"'
+        if item.rotation
+        else ""
+    )
+    return (
+        f'<text x="{item.x:.1f}" y="{item.y:.1f}" font-family="sans-serif" '
+        f'font-size="{item.size}" fill="{item.fill}" text-anchor="{item.anchor}" '
+        f'dominant-baseline="middle"{rotate}>{escape(item.text)}</text>'
+    )
+
+
+def render_svg(scene: list[Primitive], *, width: int, height: int) -> str:
+    """Render a scene as a standalone SVG document."""
+    body = "\n".join(f"  {_svg_element(item)}" for item in scene)
+    return (
+        '<svg xmlns="http://www.w3.org/2000/svg" '
+        f'width="{width}" height="{height}" viewBox="0 0 {width} {height}">\n'
+        f"{body}\n</svg>\n"
+    )
+
+
+def require_pillow() -> ModuleType:
+    """Ensure Pillow is installed and return ``PIL.ImageDraw``."""
+    try:
+        module = importlib.import_module("PIL.ImageDraw")
+    except ImportError as e:
+        raise MissingDependencyError(
+            "PNG images require Pillow. Install it via `pip install pillow` "
+            "or add the 'render' extra."
+        ) from e
+    return module
+
+
+def render_png(scene: list[Primitive], *, width: int, height: int) -> bytes:
+    """Render a scene as PNG bytes (requires Pillow)."""
+    image_draw = require_pillow()
+    image = importlib.import_module("PIL.Image").new("RGB", (width, height), "white")
+    draw = image_draw.Draw(image)
+    for item in scene:
+        if isinstance(item, Rect):
+            box = [item.x, item.y, item.x + item.w, item.y + item.h]
+            draw.rectangle(box, fill=item.fill, outline=item.stroke)
+        elif isinstance(item, Line) and len(item.points) > 1:
+            draw.line(item.points, fill=item.stroke, width=max(round(item.width), 1))
+        elif isinstance(item, Wedge):
+            left, top = item.cx - item.r, item.cy - item.r
+            box = [left, top, item.cx + item.r, item.cy + item.r]
+            draw.pieslice(box, item.start, item.end, fill=item.fill)
+        elif isinstance(item, Label) and item.rotation:
+            _paste_rotated_label(image, image_draw, item)
+        elif isinstance(item, Label):
+            text_width = draw.textlength(item.text)
+            x = item.x
+            if item.anchor == "middle":
+                x -= text_width / 2
+            elif item.anchor == "end":
+                x -= text_width
+            draw.text((x, item.y - 6), item.text, fill=item.fill)
+    buffer = io.BytesIO()
+    image.save(buffer, format="PNG")
+    return buffer.getvalue()
+
+
+def _paste_rotated_label(image: object, image_draw: ModuleType, item: Label) -> None:
+    """Draw a label on its own transparent layer, rotate it, and paste it."""
+    pil_image = importlib.import_module("PIL.Image")
+    probe = image_draw.Draw(pil_image.new("RGBA", (1, 1)))
+    width = max(round(probe.textlength(item.text)), 1)
+    layer = pil_image.new("RGBA", (width, item.size + 2), (255, 255, 255, 0))
+    image_draw.Draw(layer).text((0, 0), item.text, fill=item.fill)
+    rotated = layer.rotate(-item.rotation, expand=True)
+    # Move from the anchor along the rotated baseline to the text's center.
+    shift = width * {"start": 0.5, "middle": 0.0, "end": -0.5}[item.anchor]
+    angle = math.radians(item.rotation)
+    cx = item.x + shift * math.cos(angle)
+    cy = item.y + shift * math.sin(angle)
+    box = (round(cx - rotated.width / 2), round(cy - rotated.height / 2))
+    image.paste(rotated, box, rotated)  # type: ignore[attr-defined]
+
+
+__all__ = [
+    "Label",
+    "Line",
+    "Primitive",
+    "Rect",
+    "TextAnchor",
+    "Wedge",
+    "render_png",
+    "render_svg",
+    "require_pillow",
+]
@@ -0,0 +1,457 @@
+"""SVG/PNG snapshots of sheet regions laid out from the extracted model.
+
+The layout engine places cells on a grid built from the sheet's column widths
+and row heights (``geometry.SheetGeometry``), then draws background fills from
+``colors_map``, merged cells as single boxes, cell values, and shapes/charts
+at their anchored positions. Nothing is read from Excel or LibreOffice, so
+snapshots work on any platform, but fonts, borders, and number formats are not
+reproduced.
+"""
+
+from __future__ import annotations
+
+from dataclasses import dataclass
+import logging
+from pathlib import Path
+import re
+from typing import Literal
+
+from ..core.ranges import RangeBounds, parse_range_zero_based
+from ..errors import OutputError, SerializationError
+from ..geometry import SheetGeometry
+from ..models import Arrow, Chart, Shape, SheetData, SmartArt, WorkbookData
+from ..ooxml.units import DEFAULT_DPI, PositionScale, PositionUnit
+from . import _sheet_file_stems, _write_sheet_index
+from .output import atomic_write
+from .scene import (
+    Label,
+    Line,
+    Primitive,
+    Rect,
+    render_png,
+    render_svg,
+    require_pillow,
+)
+from .tables import _column_index
+
+logger = logging.getLogger(__name__)
+
+SnapshotFormat = Literal["svg", "png"]
+
+_PIXELS_PER_POINT = DEFAULT_DPI / 72
+# Regions are clipped so one huge sheet cannot produce a gigantic image.
+MAX_SNAPSHOT_ROWS = 200
+MAX_SNAPSHOT_COLUMNS = 50
+_FONT_SIZE = 11
+_CELL_PADDING = 3.0
+_GRID_COLOR = "#D9D9D9"
+_SHAPE_COLOR = "#4472C4"
+_CHART_FILL = "#F2F2F2"
+_CHART_STROKE = "#808080"
+_HEX_COLOR = re.compile(r"^[0-9A-F]{6}$")
+# Shape text orientations drawn as a rotated line, and as upright columns.
+_ROTATED_TEXT = {"vertical": 90.0, "vertical270": 270.0}
+_UPRIGHT_VERTICAL_TEXT = frozenset(
+    {"stacked", "east_asian_vertical", "mongolian_vertical"}
+)
+
+
+@dataclass(frozen=True)
+class SheetGrid:
+    """Pixel edges of the rows and columns of a sheet region.
+
+    Attributes:
+        bounds: Region in zero-based coordinates.
+        col_edges: Left edge of each column plus the right edge of the last.
+        row_edges: Top edge of each row plus the bottom edge of the last.
+        origin_x: Sheet offset of the region's left edge, in points.
+        origin_y: Sheet offset of the region's top edge, in points.
+    """
+
+    bounds: RangeBounds
+    col_edges: list[float]
+    row_edges: list[float]
+    origin_x: float
+    origin_y: float
+
+    @property
+    def width(self) -> int:
+        """Image width in pixels."""
+        return max(round(self.col_edges[-1]), 1)
+
+    @property
+    def height(self) -> int:
+        """Image height in pixels."""
+        return max(round(self.row_edges[-1]), 1)
+
+    def box(
+        self, r1: int, c1: int, r2: int, c2: int
+    ) -> tuple[float, float, float, float]:
+        """Return (x, y, w, h) of a zero-based cell range clipped to the region."""
+        b = self.bounds
+        left = self.col_edges[max(c1, b.c1) - b.c1]
+        right = self.col_edges[min(c2, b.c2) - b.c1 + 1]
+        top = self.row_edges[max(r1, b.r1) - b.r1]
+        bottom = self.row_edges[min(r2, b.r2) - b.r1 + 1]
+        return left, top, right - left, bottom - top
+
+    def to_pixels(self, x_points: float, y_points: float) -> tuple[float, float]:
+        """Convert sheet offsets in points to image pixels."""
+        return (
+            (x_points - self.origin_x) * _PIXELS_PER_POINT,
+            (y_points - self.origin_y) * _PIXELS_PER_POINT,
+        )
+
+
+def sheet_grid(sheet: SheetData, bounds: RangeBounds) -> SheetGrid:
+    """Lay out the rows and columns of ``bounds`` in pixels."""
+    geometry = SheetGeometry.from_sheet(sheet)
+    # Geometry rows are 1-based; the region is zero-based.
+    origin_x = geometry.columns.offset(bounds.c1)
+    origin_y = geometry.rows.offset(bounds.r1 + 1)
+    return SheetGrid(
+        bounds=bounds,
+        col_edges=[
+            (geometry.columns.offset(col) - origin_x) * _PIXELS_PER_POINT
+            for col in range(bounds.c1, bounds.c2 + 2)
+        ],
+        row_edges=[
+            (geometry.rows.offset(row) - origin_y) * _PIXELS_PER_POINT
+            for row in range(bounds.r1 + 1, bounds.r2 + 3)
+        ],
+        origin_x=origin_x,
+        origin_y=origin_y,
+    )
+
+
+def used_region(sheet: SheetData) -> RangeBounds | None:
+    """Return the zero-based bounds of cell values, merges, and shapes."""
+    rows: list[int] = []
+    cols: list[int] = []
+    for row in sheet.rows:
+        for key in row.c:
+            col = _column_index(key)
+            if col is not None:
+                rows.append(row.r - 1)
+                cols.append(col)
+    if sheet.merged_cells is not None:
+        for r1, c1, r2, c2, _ in sheet.merged_cells.items:
+            rows.extend((r1 - 1, r2 - 1))
+            cols.extend((c1, c2))
+    for shape in sheet.shapes:
+        covered = parse_range_zero_based(shape.covered_range or "")
+        if covered is not None:
+            rows.extend((covered.r1, covered.r2))
+            cols.extend((covered.c1, covered.c2))
+    if not rows:
+        return None
+    return RangeBounds(r1=min(rows), c1=min(cols), r2=max(rows), c2=max(cols))
+
+
+def _clip(bounds: RangeBounds) -> RangeBounds:
+    r2 = min(bounds.r2, bounds.r1 + MAX_SNAPSHOT_ROWS - 1)
+    c2 = min(bounds.c2, bounds.c1 + MAX_SNAPSHOT_COLUMNS - 1)
+    if (r2, c2) != (bounds.r2, bounds.c2):
+        logger.info(
+            "Snapshot region clipped to %d rows x %d columns.",
+            r2 - bounds.r1 + 1,
+            c2 - bounds.c1 + 1,
+        )
+    return RangeBounds(r1=bounds.r1, c1=bounds.c1, r2=r2, c2=c2)
+
+
+def _fit_text(text: str, width: float) -> str:
+    """Cut the first line of ``text`` to what fits in ``width`` pixels."""
+    line = text.split("\n", 1)[0]
+    budget = width - 2 * _CELL_PADDING
+    used = 0.0
+    for index, char in enumerate(line):
+        # Wide (CJK) characters take about twice the width of Latin ones.
+        used += _FONT_SIZE * (1.0 if ord(char) > 0x2E7F else 0.55)
+        if used > budget:
+            return line[:index]
+    return line
+
+
+def _cell_fills(sheet: SheetData) -> dict[tuple[int, int], str]:
+    """Map zero-based (row, col) to the RGB background fill of the cell."""
+    fills: dict[tuple[int, int], str] = {}
+    for key, cells in sheet.colors_map.items():
+        if not _HEX_COLOR.match(key) or key == "FFFFFF":
+            continue
+        for row, col in cells:
+            fills[(row - 1, col)] = f"#{key}"
+    return fills
+
+
+def _grid_scene(
+    grid: SheetGrid, fills: dict[tuple[int, int], str]
+) -> list[Primitive]:
+    b = grid.bounds
+    scene: list[Primitive] = [
+        Rect(*grid.box(row, col, row, col), fill=color)
+        for (row, col), color in fills.items()
+        if b.r1 <= row <= b.r2 and b.c1 <= col <= b.c2
+    ]
+    bottom, right = grid.row_edges[-1], grid.col_edges[-1]
+    scene.extend(Line([(x, 0.0), (x, bottom)], _GRID_COLOR, 1) for x in grid.col_edges)
+    scene.extend(Line([(0.0, y), (right, y)], _GRID_COLOR, 1) for y in grid.row_edges)
+    return scene
+
+
+def _merged_areas(
+    sheet: SheetData, bounds: RangeBounds
+) -> dict[tuple[int, int], tuple[int, int, int, int]]:
+    """Map each zero-based cell inside a visible merge to the merge bounds."""
+    areas: dict[tuple[int, int], tuple[int, int, int, int]] = {}
+    items = sheet.merged_cells.items if sheet.merged_cells is not None else []
+    for r1, c1, r2, c2, _ in items:
+        area = (r1 - 1, c1, r2 - 1, c2)
+        if area[2] < bounds.r1 or area[0] > bounds.r2:
+            continue
+        if c2 < bounds.c1 or c1 > bounds.c2:
+            continue
+        for r in range(area[0], area[2] + 1):
+            for c in range(c1, c2 + 1):
+                areas[(r, c)] = area
+    return areas
+
+
+def _value_label(
+    value: int | float | str, box: tuple[float, float, float, float]
+) -> Label | None:
+    x, y, w, h = box
+    text = _fit_text(str(value), w)
+    if not text:
+        return None
+    if isinstance(value, int | float) and not isinstance(value, bool):
+        return Label(x + w - _CELL_PADDING, y + h / 2, text, "end", _FONT_SIZE)
+    return Label(x + _CELL_PADDING, y + h / 2, text, "start", _FONT_SIZE)
+
+
+def _cell_scene(sheet: SheetData, grid: SheetGrid) -> list[Primitive]:
+    b = grid.bounds
+    fills = _cell_fills(sheet)
+    scene = _grid_scene(grid, fills)
+    merged = _merged_areas(sheet, b)
+    for area in dict.fromkeys(merged.values()):
+        fill = fills.get((area[0], area[1]), "#FFFFFF")
+        scene.append(Rect(*grid.box(*area), fill=fill, stroke=_GRID_COLOR))
+    for row in sheet.rows:
+        r = row.r - 1
+        if not b.r1 <= r <= b.r2:
+            continue
+        for key, value in row.c.items():
+            c = _column_index(key)
+            if c is None or not b.c1 <= c <= b.c2:
+                continue
+            area = merged.get((r, c), (r, c, r, c))
+            if area[:2] != (r, c):
+                continue  # Only the top-left cell of a merge holds the value.
+            label = _value_label(value, grid.box(*area))
+            if label is not None:
+                scene.append(label)
+    return scene
+
+
+def _arrow_line(arrow: Arrow, grid: SheetGrid, ppu: float) -> Line | None:
+    """Draw a connector from its endpoints, or its bounding box diagonal."""
+    if (
+        arrow.begin_x is not None
+        and arrow.begin_y is not None
+        and arrow.end_x is not None
+        and arrow.end_y is not None
+    ):
+        start = grid.to_pixels(arrow.begin_x * ppu, arrow.begin_y * ppu)
+        end = grid.to_pixels(arrow.end_x * ppu, arrow.end_y * ppu)
+    elif arrow.w is not None and arrow.h is not None:
+        start = grid.to_pixels(arrow.l * ppu, arrow.t * ppu)
+        end = grid.to_pixels((arrow.l + arrow.w) * ppu, (arrow.t + arrow.h) * ppu)
+    else:
+        return None
+    return Line([start, end], _SHAPE_COLOR)
+
+
+def _shape_box(
+    shape: Shape | SmartArt, grid: SheetGrid, ppu: float
+) -> list[Primitive]:
+    if shape.w is None or shape.h is None:
+        return []
+    x, y = grid.to_pixels(shape.l * ppu, shape.t * ppu)
+    w = shape.w * ppu * _PIXELS_PER_POINT
+    h = shape.h * ppu * _PIXELS_PER_POINT
+    box: list[Primitive] = [Rect(x, y, w, h, fill="#FFFFFF", stroke=_SHAPE_COLOR)]
+    text = shape.text
+    if isinstance(shape, SmartArt) and not text:
+        text = " / ".join(node.text for node in shape.nodes)
+    orientation = (shape.text_orientation if isinstance(shape, Shape) else None) or ""
+    rotation = _ROTATED_TEXT.get(orientation)
+    if rotation is not None:
+        label = _fit_text(text, h)
+        if label:
+            box.append(
+                Label(x + w / 2, y + h / 2, label, size=_FONT_SIZE, rotation=rotation)
+            )
+    elif orientation in _UPRIGHT_VERTICAL_TEXT:
+        box.extend(_stacked_labels(text, x + w / 2, y, h))
+    else:
+        label = _fit_text(text, w)
+        if label:
+            box.append(Label(x + w / 2, y + h / 2, label, size=_FONT_SIZE))
+    return box
+
+
+def _stacked_labels(text: str, cx: float, top: float, h: float) -> list[Primitive]:
+    """Draw the first line of ``text`` one upright character per row."""
+    line = text.split("\n", 1)[0]
+    fit = max(int((h - 2 * _CELL_PADDING) // _FONT_SIZE), 0)
+    return [
+        Label(cx, top + _CELL_PADDING + _FONT_SIZE * (row + 0.5), char, size=_FONT_SIZE)
+        for row, char in enumerate(line[:fit])
+        if not char.isspace()
+    ]
+
+
+def _drawing_scene(
+    sheet: SheetData, grid: SheetGrid, scale: PositionScale
+) -> list[Primitive]:
+    ppu = scale.points_per_unit
+    scene: list[Primitive] = []
+    for shape in sorted(sheet.shapes, key=lambda shape: shape.z_order or 0):
+        if isinstance(shape, Arrow):
+            line = _arrow_line(shape, grid, ppu)
+            scene.extend([line] if line is not None else [])
+        else:
+            scene.extend(_shape_box(shape, grid, ppu))
+    for chart in sheet.charts:
+        scene.extend(_chart_box(chart, grid, ppu))
+    return scene
+
+
+def _chart_box(chart: Chart, grid: SheetGrid, ppu: float) -> list[Primitive]:
+    if chart.w is None or chart.h is None:
+        return []
+    x, y = grid.to_pixels(chart.l * ppu, chart.t * ppu)
+    w = chart.w * ppu * _PIXELS_PER_POINT
+    h = chart.h * ppu * _PIXELS_PER_POINT
+    box: list[Primitive] = [Rect(x, y, w, h, fill=_CHART_FILL, stroke=_CHART_STROKE)]
+    label = _fit_text(chart.title or chart.name, w)
+    if label:
+        box.append(Label(x + w / 2, y + h / 2, label, size=_FONT_SIZE))
+    return box
+
+
+def sheet_snapshot_scene(
+    sheet: SheetData,
+    region: str | None = None,
+    *,
+    unit: PositionUnit = "pixels",
+    dpi: int = DEFAULT_DPI,
+) -> tuple[list[Primitive], int, int] | None:
+    """Lay out a sheet region as drawing primitives.
+
+    Args:
+        sheet: Extracted sheet to draw.
+        region: A1 range to draw (e.g. ``"A1:F20"``); defaults to the used
+            region of values, merged cells, and shapes.
+        unit: Unit of the shape and chart positions in ``sheet``.
+        dpi: DPI of pixel positions.
+
+    Returns:
+        (primitives, width, height) in pixels, or None when there is nothing
+        to draw or ``region`` is not a valid range.
+    """
+    bounds = parse_range_zero_based(region) if region else used_region(sheet)
+    if bounds is None:
+        return None
+    grid = sheet_grid(sheet, _clip(bounds))
+    scene: list[Primitive] = [Rect(0, 0, grid.width, grid.height, fill="#FFFFFF")]
+    scene.extend(_cell_scene(sheet, grid))
+    scene.extend(_drawing_scene(sheet, grid, PositionScale(unit, dpi)))
+    return scene, grid.width, grid.height
+
+
+def render_sheet_snapshot(
+    sheet: SheetData,
+    region: str | None = None,
+    *,
+    fmt: SnapshotFormat = "svg",
+    unit: PositionUnit = "pixels",
+    dpi: int = DEFAULT_DPI,
+) -> bytes | None:
+    """Render a sheet region as SVG or PNG bytes (None when empty)."""
+    laid_out = sheet_snapshot_scene(sheet, region, unit=unit, dpi=dpi)
+    if laid_out is None:
+        return None
+    scene, width, height = laid_out
+    if fmt == "png":
+        return render_png(scene, width=width, height=height)
+    return render_svg(scene, width=width, height=height).encode("utf-8")
+
+
+def save_sheet_snapshots(
+    workbook: WorkbookData,
+    output_dir: Path,
+    fmt: SnapshotFormat = "svg",
+    *,
+    unit: PositionUnit = "pixels",
+    dpi: int = DEFAULT_DPI,
+) -> dict[str, Path]:
+    """Write a snapshot of the used region of every sheet plus an ``index.json``.
+
+    Files follow the per-sheet naming rules (``<sheet>.svg`` or ``.png``);
+    empty sheets are skipped.
+
+    Args:
+        workbook: Workbook whose sheets are drawn.
+        output_dir: Target directory.
+        fmt: ``svg`` (no dependencies) or ``png`` (requires Pillow).
+        unit: Unit of the shape and chart positions in ``workbook``.
+        dpi: DPI of pixel positions.
+
+    Returns:
+        Map of sheet name to written path.
+
+    Raises:
+        SerializationError: If the format is unsupported.
+        MissingDependencyError: If PNG output is requested without Pillow.
+        OutputError: If writing fails.
+    """
+    if fmt not in ("svg", "png"):
+        raise SerializationError(
+            f"Unsupported snapshot format '{fmt}'. Allowed: svg, png."
+        )
+    if fmt == "png":
+        require_pillow()
+    stems = _sheet_file_stems(workbook.sheets)
+    written: dict[str, Path] = {}
+    for sheet_name, sheet in workbook.sheets.items():
+        data = render_sheet_snapshot(sheet, fmt=fmt, unit=unit, dpi=dpi)
+        if data is None:
+            continue
+        output_dir.mkdir(parents=True, exist_ok=True)
+        path = output_dir / f"{stems[sheet_name]}.{fmt}"
+        try:
+            with atomic_write(path) as handle:
+                handle.write(data)
+        except Exception as exc:
+            raise OutputError(f"Failed to write output to '{path}'.") from exc
+        written[sheet_name] = path
+    if not written:
+        logger.info("No sheet content found; skipping snapshots in %s", output_dir)
+        return {}
+    _write_sheet_index(output_dir, workbook.book_name, written)
+    return written
+
+
+__all__ = [
+    "MAX_SNAPSHOT_COLUMNS",
+    "MAX_SNAPSHOT_ROWS",
+    "SheetGrid",
+    "SnapshotFormat",
+    "render_sheet_snapshot",
+    "save_sheet_snapshots",
+    "sheet_grid",
+    "sheet_snapshot_scene",
+    "used_region",
+]
@@ -0,0 +1,236 @@
+"""SQLite database export of extraction results for ad-hoc SQL queries."""
+
+from __future__ import annotations
+
+from collections.abc import Iterator
+import json
+import logging
+from pathlib import Path
+import sqlite3
+import time
+
+from ..core.ranges import parse_range_zero_based
+from ..errors import OutputError
+from ..models import SheetData, WorkbookData, col_index_to_alpha
+from . import _without_sheet_backend_metadata
+from .output import atomic_path, atomic_write, upload_target_for
+from .tables import _column_index
+
+logger = logging.getLogger(__name__)
+
+SQLITE_SCHEMA = """
+CREATE TABLE sheets (
+    sheet_id INTEGER PRIMARY KEY,
+    book_name TEXT NOT NULL,
+    name TEXT NOT NULL,
+    position INTEGER NOT NULL,
+    content_hash TEXT
+);
+CREATE TABLE cells (
+    sheet_id INTEGER NOT NULL REFERENCES sheets(sheet_id),
+    row INTEGER NOT NULL,
+    col INTEGER NOT NULL,
+    a1 TEXT NOT NULL,
+    value,
+    value_type TEXT NOT NULL,
+    link TEXT
+);
+CREATE INDEX cells_position ON cells (sheet_id, row, col);
+CREATE TABLE shapes (
+    sheet_id INTEGER NOT NULL REFERENCES sheets(sheet_id),
+    shape_id INTEGER,
+    kind TEXT NOT NULL,
+    type TEXT,
+    text TEXT,
+    l INTEGER,
+    t INTEGER,
+    w INTEGER,
+    h INTEGER,
+    covered_range TEXT,
+    data TEXT NOT NULL
+);
+CREATE TABLE charts (
+    sheet_id INTEGER NOT NULL REFERENCES sheets(sheet_id),
+    name TEXT NOT NULL,
+    chart_type TEXT NOT NULL,
+    title TEXT,
+    l INTEGER,
+    t INTEGER,
+    w INTEGER,
+    h INTEGER,
+    series_count INTEGER NOT NULL,
+    data TEXT NOT NULL
+);
+CREATE TABLE tables (
+    sheet_id INTEGER NOT NULL REFERENCES sheets(sheet_id),
+    range TEXT NOT NULL,
+    first_row INTEGER,
+    first_col INTEGER,
+    last_row INTEGER,
+    last_col INTEGER,
+    content_hash TEXT
+);
+"""
+
+_VALUE_TYPES: dict[type, str] = {int: "int", float: "float", str: "str"}
+
+
+def _to_json(payload: object) -> str:
+    """Serialize a model dump compactly for the ``data`` columns."""
+    return json.dumps(payload, ensure_ascii=False, separators=(",", ":"))
+
+
+def _cell_records(sheet_id: int, sheet: SheetData) -> Iterator[tuple[object, ...]]:
+    """Yield one ``cells`` row per non-empty cell."""
+    for row in sheet.rows:
+        links = row.links or {}
+        for key, value in row.c.items():
+            col = _column_index(key)
+            if col is None:
+                continue
+            yield (
+                sheet_id,
+                row.r,
+                col,
+                f"{col_index_to_alpha(col)}{row.r}",
+                value,
+                _VALUE_TYPES.get(type(value), "str"),
+                links.get(key),
+            )
+
+
+def _table_records(sheet_id: int, sheet: SheetData) -> Iterator[tuple[object, ...]]:
+    """Yield one ``tables`` row per table candidate (1-based bounds)."""
+    hashes = sheet.table_hashes or {}
+    for table_range in sheet.table_candidates:
+        bounds = parse_range_zero_based(table_range)
+        corners = (
+            (bounds.r1 + 1, bounds.c1 + 1, bounds.r2 + 1, bounds.c2 + 1)
+            if bounds is not None
+            else (None, None, None, None)
+        )
+        yield (sheet_id, table_range, *corners, hashes.get(table_range))
+
+
+def _insert_sheet(
+    connection: sqlite3.Connection,
+    sheet_id: int,
+    sheet_name: str,
+    sheet: SheetData,
+    book_name: str,
+) -> None:
+    """Insert one sheet and its cells, shapes, charts, and tables."""
+    connection.execute(
+        "INSERT INTO sheets VALUES (?, ?, ?, ?, ?)",
+        (sheet_id, book_name, sheet_name, sheet_id - 1, sheet.content_hash),
+    )
+    connection.executemany(
+        "INSERT INTO cells VALUES (?, ?, ?, ?, ?, ?, ?)",
+        _cell_records(sheet_id, sheet),
+    )
+    connection.executemany(
+        "INSERT INTO shapes VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
+        [
+            (
+                sheet_id,
+                shape.id,
+                shape.kind,
+                getattr(shape, "type", None),
+                shape.text,
+                shape.l,
+                shape.t,
+                shape.w,
+                shape.h,
+                shape.covered_range,
+                _to_json(shape.model_dump(exclude_none=True, by_alias=True)),
+            )
+            for shape in sheet.shapes
+        ],
+    )
+    connection.executemany(
+        "INSERT INTO charts VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
+        [
+            (
+                sheet_id,
+                chart.name,
+                chart.chart_type,
+                chart.title,
+                chart.l,
+                chart.t,
+                chart.w,
+                chart.h,
+                len(chart.series),
+                _to_json(chart.model_dump(exclude_none=True, by_alias=True)),
+            )
+            for chart in sheet.charts
+        ],
+    )
+    connection.executemany(
+        "INSERT INTO tables VALUES (?, ?, ?, ?, ?, ?, ?)",
+        _table_records(sheet_id, sheet),
+    )
+
+
+def _write_database(
+    connection: sqlite3.Connection,
+    model: WorkbookData,
+    include_backend_metadata: bool,
+) -> None:
+    """Create the schema and insert every sheet of ``model``."""
+    connection.executescript(SQLITE_SCHEMA)
+    for sheet_id, (sheet_name, sheet) in enumerate(model.sheets.items(), start=1):
+        if not include_backend_metadata:
+            sheet = _without_sheet_backend_metadata(sheet)
+        _insert_sheet(connection, sheet_id, sheet_name, sheet, model.book_name)
+
+
+def save_as_sqlite(
+    model: WorkbookData, path: Path, *, include_backend_metadata: bool = False
+) -> None:
+    """Write a workbook into a new SQLite database.
+
+    The database has ``sheets``, ``cells``, ``shapes``, ``charts``, and
+    ``tables`` tables keyed by ``sheet_id``; shapes and charts also keep their
+    full model as JSON in ``data``. The database is built in a temporary file
+    that atomically replaces any existing file at ``path`` once complete; for
+    an upload target (object storage output) it is built in memory instead.
+
+    Args:
+        model: Workbook to export.
+        path: Database file path.
+        include_backend_metadata: Keep shape/chart backend metadata in ``data``.
+
+    Raises:
+        OutputError: If the database cannot be written.
+    """
+    start = time.monotonic()
+    try:
+        if upload_target_for(path) is not None:
+            with atomic_write(path) as handle:
+                handle.write(_database_image(model, include_backend_metadata))
+        else:
+            with atomic_path(path) as tmp_path:
+                connection = sqlite3.connect(tmp_path)
+                try:
+                    with connection:
+                        _write_database(connection, model, include_backend_metadata)
+                finally:
+                    connection.close()
+    except (OSError, sqlite3.Error) as exc:
+        raise OutputError(f"Failed to write output to '{path}'.") from exc
+    logger.info("Wrote output to %s in %.2fs", path, time.monotonic() - start)
+
+
+
+def _database_image(model: WorkbookData, include_backend_metadata: bool) -> bytes:
+    """Build the database in memory and return its file contents."""
+    connection = sqlite3.connect(":memory:")
+    try:
+        with connection:
+            _write_database(connection, model, include_backend_metadata)
+        return connection.serialize()
+    finally:
+        connection.close()
+
+
+__all__ = ["SQLITE_SCHEMA", "save_as_sqlite"]
@@ -0,0 +1,244 @@
+"""Columnar (Parquet / Arrow IPC) export of detected table candidates."""
+
+from __future__ import annotations
+
+from collections.abc import Iterator, Sequence
+from dataclasses import dataclass
+import importlib
+import logging
+from pathlib import Path
+from types import ModuleType
+from typing import Any, Literal
+
+from openpyxl.utils import column_index_from_string
+
+from ..core.ranges import parse_range_zero_based
+from ..errors import MissingDependencyError, SerializationError
+from ..models import CellRow, WorkbookData, col_index_to_alpha
+from . import _sanitize_sheet_filename
+from .output import atomic_path
+
+logger = logging.getLogger(__name__)
+
+TableFormat = Literal["parquet", "arrow"]
+ColumnType = Literal["int64", "float64", "string"]
+TableValue = int | float | str | None
+
+_TABLE_SUFFIXES: dict[str, str] = {"parquet": ".parquet", "arrow": ".arrow"}
+
+
+@dataclass(frozen=True)
+class TableFrame:
+    """Column-oriented values of one table candidate."""
+
+    sheet: str
+    range: str
+    columns: list[str]
+    types: list[ColumnType]
+    values: list[list[TableValue]]
+
+
+def _column_index(key: str) -> int | None:
+    """Return the 0-based column index of a numeric or alpha column key."""
+    if key.isdigit():
+        return int(key)
+    try:
+        return column_index_from_string(key) - 1
+    except ValueError:
+        return None
+
+
+def infer_column_type(values: Sequence[TableValue]) -> ColumnType:
+    """Infer the narrowest column type holding every non-null value.
+
+    Args:
+        values: Column values (None for empty cells).
+
+    Returns:
+        ``int64`` when all values are integers, ``float64`` when all are
+        numeric, otherwise ``string``.
+    """
+    present = [value for value in values if value is not None]
+    if present and all(
+        isinstance(value, int) and not isinstance(value, bool) for value in present
+    ):
+        return "int64"
+    if present and all(isinstance(value, int | float) for value in present):
+        return "float64"
+    return "string"
+
+
+def _coerce(value: TableValue, column_type: ColumnType) -> TableValue:
+    """Convert one value to the column type, keeping None."""
+    if value is None:
+        return None
+    if column_type == "float64":
+        return float(value)
+    if column_type == "string":
+        return str(value)
+    return value
+
+
+def _header_names(header: Sequence[TableValue], c1: int) -> list[str]:
+    """Build unique column names from a header row, falling back to letters."""
+    names: list[str] = []
+    seen: dict[str, int] = {}
+    for offset, value in enumerate(header):
+        name = str(value).strip() if value is not None else ""
+        name = name or col_index_to_alpha(c1 + offset)
+        count = seen.get(name, 0) + 1
+        seen[name] = count
+        names.append(name if count == 1 else f"{name}_{count}")
+    return names
+
+
+def build_table_frame(
+    sheet_name: str, rows: Sequence[CellRow], table_range: str
+) -> TableFrame | None:
+    """Collect a table candidate's cells into typed columns.
+
+    The first row becomes the header when it holds only text and the table has
+    more rows; otherwise columns are named by their Excel letters.
+
+    Args:
+        sheet_name: Sheet holding the table.
+        rows: Extracted rows (numeric or alpha column keys).
+        table_range: A1 range of the table candidate.
+
+    Returns:
+        TableFrame, or None when the range is invalid or holds no cells.
+    """
+    bounds = parse_range_zero_based(table_range)
+    if bounds is None:
+        return None
+    width = bounds.c2 - bounds.c1 + 1
+    grid: list[list[TableValue]] = []
+    for row in sorted(rows, key=lambda row: row.r):
+        if not bounds.r1 <= row.r - 1 <= bounds.r2:
+            continue
+        cells: list[TableValue] = [None] * width
+        for key, value in row.c.items():
+            col = _column_index(key)
+            if col is not None and bounds.c1 <= col <= bounds.c2:
+                cells[col - bounds.c1] = value
+        if any(cell is not None for cell in cells):
+            grid.append(cells)
+    if not grid:
+        return None
+
+    first = grid[0]
+    has_header = len(grid) > 1 and all(
+        isinstance(cell, str) for cell in first if cell is not None
+    )
+    columns = _header_names(first if has_header else [None] * width, bounds.c1)
+    body = grid[1:] if has_header else grid
+    values: list[list[TableValue]] = []
+    types: list[ColumnType] = []
+    for index in range(width):
+        column = [row[index] for row in body]
+        column_type = infer_column_type(column)
+        types.append(column_type)
+        values.append([_coerce(value, column_type) for value in column])
+    return TableFrame(
+        sheet=sheet_name,
+        range=table_range,
+        columns=columns,
+        types=types,
+        values=values,
+    )
+
+
+def iter_table_frames(workbook: WorkbookData) -> Iterator[TableFrame]:
+    """Yield a TableFrame for every table candidate in the workbook."""
+    for sheet_name, sheet in workbook.sheets.items():
+        for table_range in sheet.table_candidates:
+            frame = build_table_frame(sheet_name, sheet.rows, table_range)
+            if frame is not None:
+                yield frame
+
+
+def save_tables(
+    workbook: WorkbookData,
+    output_dir: Path,
+    fmt: TableFormat = "parquet",
+) -> dict[str, Path]:
+    """Write each table candidate as a Parquet or Arrow IPC file.
+
+    Files are named ``<sheet>_<A1-range>.parquet`` (or ``.arrow``); the sheet
+    name and range are stored in the schema metadata.
+
+    Args:
+        workbook: Workbook whose table candidates are exported.
+        output_dir: Target directory.
+        fmt: ``parquet`` or ``arrow`` (Arrow IPC file format).
+
+    Returns:
+        Map of ``Sheet!A1:C10`` keys to written paths.
+
+    Raises:
+        SerializationError: If the format is unsupported.
+        MissingDependencyError: If pyarrow is not installed.
+    """
+    if fmt not in _TABLE_SUFFIXES:
+        raise SerializationError(
+            f"Unsupported table export format '{fmt}'. Allowed: parquet, arrow."
+        )
+    frames = list(iter_table_frames(workbook))
+    if not frames:
+        logger.info("No table candidates found; skipping export to %s", output_dir)
+        return {}
+    pa = _require_pyarrow()
+    output_dir.mkdir(parents=True, exist_ok=True)
+    written: dict[str, Path] = {}
+    for frame in frames:
+        table = _to_arrow_table(pa, frame)
+        range_part = frame.range.replace(":", "-")
+        file_name = (
+            f"{_sanitize_sheet_filename(frame.sheet)}_{range_part}"
+            f"{_TABLE_SUFFIXES[fmt]}"
+        )
+        path = output_dir / file_name
+        with atomic_path(path) as tmp_path:
+            if fmt == "parquet":
+                importlib.import_module("pyarrow.parquet").write_table(table, tmp_path)
+            else:
+                with pa.ipc.new_file(tmp_path, table.schema) as writer:
+                    writer.write_table(table)
+        written[f"{frame.sheet}!{frame.range}"] = path
+    return written
+
+
+def _to_arrow_table(pa: ModuleType, frame: TableFrame) -> Any:
+    """Convert a TableFrame into a pyarrow Table."""
+    arrow_types = {"int64": pa.int64(), "float64": pa.float64(), "string": pa.string()}
+    fields = [
+        pa.field(name, arrow_types[column_type])
+        for name, column_type in zip(frame.columns, frame.types, strict=True)
+    ]
+    schema = pa.schema(fields, metadata={"sheet": frame.sheet, "range": frame.range})
+    arrays = [
+        pa.array(values, type=field.type)
+        for values, field in zip(frame.values, fields, strict=True)
+    ]
+    return pa.Table.from_arrays(arrays, schema=schema)
+
+
+def _require_pyarrow() -> ModuleType:
+    """Ensure pyarrow is installed; otherwise raise with guidance."""
+    try:
+        module = importlib.import_module("pyarrow")
+    except ImportError as e:
+        raise MissingDependencyError(
+            "Parquet/Arrow table export requires pyarrow. Install it via "
+            "`pip install pyarrow` or add the 'parquet' extra."
+        ) from e
+    return module
+
+
+__all__ = [
+    "TableFrame",
+    "build_table_frame",
+    "infer_column_type",
+    "iter_table_frames",
+    "save_tables",
+]
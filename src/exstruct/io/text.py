@@ -0,0 +1,144 @@
+"""Plain-text rendering of workbooks for compact LLM context."""
+
+from __future__ import annotations
+
+from collections.abc import Sequence
+import unicodedata
+
+from ..models import (
+    Arrow,
+    CellRow,
+    Shape,
+    SheetData,
+    SmartArt,
+    WorkbookData,
+    col_index_to_alpha,
+)
+from .tables import _column_index
+
+
+def _cell_text(value: int | float | str) -> str:
+    """Render one cell value on a single line."""
+    text = str(value)
+    return text.replace("\r\n", "\\n").replace("\n", "\\n").replace("\t", " ")
+
+
+def _display_width(text: str) -> int:
+    """Return the monospace display width, counting wide East Asian chars as 2."""
+    return sum(2 if unicodedata.east_asian_width(ch) in "WF" else 1 for ch in text)
+
+
+def _pad(text: str, width: int) -> str:
+    return text + " " * (width - _display_width(text))
+
+
+def _grid(rows: Sequence[CellRow]) -> list[list[str]]:
+    """Build a text grid with a column header row and a row-number column."""
+    cells: dict[int, dict[int, str]] = {}
+    for row in rows:
+        for key, value in row.c.items():
+            col = _column_index(key)
+            if col is not None:
+                cells.setdefault(row.r, {})[col] = _cell_text(value)
+    if not cells:
+        return []
+    columns = sorted({col for row_cells in cells.values() for col in row_cells})
+    first, last = columns[0], columns[-1]
+    grid = [[""] + [col_index_to_alpha(col) for col in range(first, last + 1)]]
+    for r in sorted(cells):
+        row_cells = cells[r]
+        grid.append(
+            [str(r)] + [row_cells.get(col, "") for col in range(first, last + 1)]
+        )
+    return grid
+
+
+def _render_grid(grid: list[list[str]], *, aligned: bool) -> list[str]:
+    if not aligned:
+        return ["\t".join(line).rstrip("\t") for line in grid]
+    widths = [
+        max(_display_width(line[i]) for line in grid) for i in range(len(grid[0]))
+    ]
+    return [
+        "  ".join(_pad(text, widths[i]) for i, text in enumerate(line)).rstrip()
+        for line in grid
+    ]
+
+
+def _shape_ref(shape_id: int | None) -> str:
+    return f"#{shape_id}" if shape_id is not None else "?"
+
+
+def _shape_annotation(shape: Shape | Arrow | SmartArt) -> str | None:
+    """Describe a shape on one line, or None when it carries no information."""
+    label = f"#{shape.id}" if shape.id is not None else ""
+    where = f" @{shape.covered_range}" if shape.covered_range else ""
+    if isinstance(shape, Arrow):
+        if shape.begin_id is None and shape.end_id is None and not shape.text:
+            return None
+        link = f" {_shape_ref(shape.begin_id)} -> {_shape_ref(shape.end_id)}"
+        text = f" {_cell_text(shape.text)}" if shape.text else ""
+        return f"[arrow{label}{where}]{link}{text}"
+    if isinstance(shape, SmartArt):
+        nodes = " / ".join(_cell_text(node.text) for node in shape.nodes)
+        return f"[smartart{label}{where}] {shape.layout}: {nodes}"
+    if not shape.text:
+        return None
+    kind = shape.type or "shape"
+    return f"[{kind}{label}{where}] {_cell_text(shape.text)}"
+
+
+def render_sheet_text(
+    sheet_name: str, sheet: SheetData, *, aligned: bool = False
+) -> str:
+    """Render one sheet as a text grid followed by shape and chart annotations.
+
+    Args:
+        sheet_name: Sheet name used in the header line.
+        sheet: Sheet to render.
+        aligned: Pad columns with spaces instead of separating them by tabs.
+
+    Returns:
+        Rendered sheet text ending with a newline.
+    """
+    lines = [f"=== {sheet_name} ==="]
+    lines.extend(_render_grid(_grid(sheet.rows), aligned=aligned))
+    annotations = [
+        note
+        for note in (_shape_annotation(shape) for shape in sheet.shapes)
+        if note is not None
+    ]
+    for chart in sheet.charts:
+        title = f' "{_cell_text(chart.title)}"' if chart.title else ""
+        series = ", ".join(
+            f"{s.name} ({s.y_range})" if s.y_range else s.name for s in chart.series
+        )
+        annotations.append(f"[chart {chart.name}] {chart.chart_type}{title}: {series}")
+    if annotations:
+        lines.append("")
+        lines.extend(annotations)
+    return "\n".join(lines) + "\n"
+
+
+def render_workbook_text(workbook: WorkbookData, *, aligned: bool = False) -> str:
+    """Render every sheet as plain text separated by blank lines.
+
+    Cells form a grid headed by column letters with row numbers in the first
+    column; empty rows are omitted. Shape texts, connectors, SmartArt nodes,
+    and charts follow the grid as one-line ``[kind #id @range]`` annotations.
+
+    Args:
+        workbook: Workbook to render.
+        aligned: Pad columns with spaces (East Asian wide characters count as
+            two columns) instead of using tab separators.
+
+    Returns:
+        Plain-text rendering of the workbook.
+    """
+    return "\n".join(
+        render_sheet_text(name, sheet, aligned=aligned)
+        for name, sheet in workbook.sheets.items()
+    )
+
+
+__all__ = ["render_sheet_text", "render_workbook_text"]
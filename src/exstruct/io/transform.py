@@ -0,0 +1,425 @@
+"""jq, query, field projections, and string dictionary encoding applied to the
+serialized workbook payload."""
+
+from __future__ import annotations
+
+from collections import Counter
+from dataclasses import dataclass
+import importlib
+import re
+from types import ModuleType
+from typing import cast
+
+from ..errors import MissingDependencyError, SerializationError
+from ..models import SheetData, WorkbookData
+from ..models.types import JsonStructure
+
+
+def _require_jq() -> ModuleType:
+    """Ensure the jq bindings are installed; otherwise raise with guidance."""
+    try:
+        module = importlib.import_module("jq")
+    except ImportError as e:
+        raise MissingDependencyError(
+            "--jq requires the jq package. Install it via `pip install jq` "
+            "or add the 'jq' extra."
+        ) from e
+    return module
+
+
+def apply_jq(payload: JsonStructure, expression: str) -> JsonStructure:
+    """Run a jq expression over a workbook payload.
+
+    A single result replaces the payload; an expression producing several
+    results (e.g. ``.sheets[]``) yields them as a list.
+
+    Args:
+        payload: Workbook payload as produced for JSON output.
+        expression: jq program, e.g. ``del(.sheets[].shapes)``.
+
+    Returns:
+        Transformed payload.
+
+    Raises:
+        MissingDependencyError: If the jq package is not installed.
+        SerializationError: If the expression is invalid or fails at runtime.
+    """
+    jq = _require_jq()
+    try:
+        program = jq.compile(expression)
+        results: list[JsonStructure] = program.input_value(payload).all()
+    except ValueError as exc:
+        raise SerializationError(f"jq expression failed: {exc}") from exc
+    return results[0] if len(results) == 1 else results
+
+
+def _require_jmespath() -> ModuleType:
+    """Ensure jmespath is installed; otherwise raise with guidance."""
+    try:
+        module = importlib.import_module("jmespath")
+    except ImportError as e:
+        raise MissingDependencyError(
+            "JMESPath queries require the jmespath package. Install it via "
+            "`pip install jmespath` or add the 'query' extra."
+        ) from e
+    return module
+
+
+def apply_query(payload: JsonStructure, expression: str) -> JsonStructure:
+    """Evaluate a JSONPath or JMESPath expression against a workbook payload.
+
+    Expressions starting with ``$`` are JSONPath and return the list of matched
+    values, e.g. ``$.sheets.*.charts[*].title``. The supported subset is child
+    names (``.name``, ``['name']``), wildcards, array indexes and slices, and
+    recursive descent (``..title``). Any other expression is JMESPath (requires
+    the jmespath package), e.g. ``sheets.Sheet2.rows``, and returns its result.
+
+    Args:
+        payload: Workbook payload as produced for JSON output.
+        expression: JSONPath or JMESPath expression.
+
+    Returns:
+        Query result.
+
+    Raises:
+        MissingDependencyError: If a JMESPath query runs without jmespath.
+        SerializationError: If the expression is invalid.
+    """
+    if expression.lstrip().startswith("$"):
+        steps = _parse_jsonpath(expression.strip())
+        nodes: list[JsonStructure] = [payload]
+        for deep, selector in steps:
+            if deep:
+                nodes = [item for node in nodes for item in _descendants(node)]
+            nodes = [item for node in nodes for item in _select(node, selector)]
+        return nodes
+    jmespath = _require_jmespath()
+    try:
+        result: JsonStructure = jmespath.search(expression, payload)
+    except jmespath.exceptions.JMESPathError as exc:
+        raise SerializationError(f"query expression failed: {exc}") from exc
+    return result
+
+
+_JSONPATH_STEP = re.compile(
+    r"(?P<dots>\.\.?)(?P<name>\*|[^.\[\]]+)?|\[(?P<bracket>[^\]]*)\]"
+)
+_Selector = tuple[str, object]
+
+
+def _parse_jsonpath(expression: str) -> list[tuple[bool, _Selector]]:
+    """Split a JSONPath expression into (recursive, selector) steps."""
+    steps: list[tuple[bool, _Selector]] = []
+    position = 1
+    deep = False
+    while position < len(expression):
+        step = _JSONPATH_STEP.match(expression, position)
+        if step is None:
+            raise SerializationError(
+                f"query expression failed: unexpected {expression[position:]!r}"
+            )
+        position = step.end()
+        if step.group("dots") is not None:
+            name = step.group("name")
+            if name is None:
+                if step.group("dots") != ".." or deep:
+                    raise SerializationError(
+                        f"query expression failed: empty step in {expression!r}"
+                    )
+                deep = True
+                continue
+            steps.append((deep or step.group("dots") == "..", _name_selector(name)))
+        else:
+            steps.append((deep, _bracket_selector(step.group("bracket"), expression)))
+        deep = False
+    if deep:
+        raise SerializationError(
+            f"query expression failed: {expression!r} ends with '..'"
+        )
+    return steps
+
+
+def _name_selector(name: str) -> _Selector:
+    return ("wildcard", None) if name == "*" else ("name", name)
+
+
+def _bracket_selector(content: str, expression: str) -> _Selector:
+    text = content.strip()
+    if text == "*":
+        return ("wildcard", None)
+    if len(text) >= 2 and text[0] == text[-1] and text[0] in "'\"":
+        return ("name", text[1:-1])
+    try:
+        if ":" in text:
+            start, _, stop = text.partition(":")
+            return ("slice", (_optional_int(start), _optional_int(stop)))
+        return ("index", int(text))
+    except ValueError:
+        raise SerializationError(
+            f"query expression failed: unsupported selector [{content}] in "
+            f"{expression!r}"
+        ) from None
+
+
+def _optional_int(text: str) -> int | None:
+    return int(text) if text.strip() else None
+
+
+def _select(node: JsonStructure, selector: _Selector) -> list[JsonStructure]:
+    kind, arg = selector
+    if kind == "wildcard":
+        if isinstance(node, dict):
+            return list(node.values())
+        return list(node) if isinstance(node, list) else []
+    if kind == "name":
+        if isinstance(node, dict) and isinstance(arg, str) and arg in node:
+            return [node[arg]]
+        return []
+    if not isinstance(node, list):
+        return []
+    if kind == "index" and isinstance(arg, int):
+        return [node[arg]] if -len(node) <= arg < len(node) else []
+    if isinstance(arg, tuple):
+        return node[arg[0] : arg[1]]
+    return []
+
+
+def _descendants(node: JsonStructure) -> list[JsonStructure]:
+    """Return a node and all nested values, depth first."""
+    result: list[JsonStructure] = [node]
+    children: list[JsonStructure] = []
+    if isinstance(node, dict):
+        children = list(node.values())
+    elif isinstance(node, list):
+        children = node
+    for child in children:
+        result.extend(_descendants(child))
+    return result
+
+
+_WORKBOOK_KEYS = frozenset({"book_name", "sheets"})
+
+
+def _section_names(model: type[SheetData] | type[WorkbookData]) -> frozenset[str]:
+    names = {field.alias or name for name, field in model.model_fields.items()}
+    return frozenset(names) - _WORKBOOK_KEYS
+
+
+@dataclass(frozen=True)
+class _Projection:
+    """Parsed ``fields`` spec: kept/dropped sections and per-item fields."""
+
+    include: frozenset[str]
+    exclude: frozenset[str]
+    keep_fields: dict[str, frozenset[str]]
+    drop_fields: dict[str, frozenset[str]]
+
+    def project(self, node: dict[str, JsonStructure]) -> dict[str, JsonStructure]:
+        """Apply the projection to a workbook or sheet mapping."""
+        result: dict[str, JsonStructure] = {}
+        for key, value in node.items():
+            if key in self.exclude:
+                continue
+            if self.include and key not in self.include and key not in _WORKBOOK_KEYS:
+                continue
+            result[key] = self._trim(key, value)
+        return result
+
+    def _trim(self, section: str, value: JsonStructure) -> JsonStructure:
+        keep = self.keep_fields.get(section)
+        drop = self.drop_fields.get(section, frozenset())
+        if keep is None and not drop:
+            return value
+        if isinstance(value, list):
+            return [self._trim_item(item, keep, drop) for item in value]
+        return self._trim_item(value, keep, drop)
+
+    @staticmethod
+    def _trim_item(
+        item: JsonStructure, keep: frozenset[str] | None, drop: frozenset[str]
+    ) -> JsonStructure:
+        if not isinstance(item, dict):
+            return item
+        return {
+            key: value
+            for key, value in item.items()
+            if key not in drop and (keep is None or key in keep)
+        }
+
+
+def _parse_fields(fields: list[str]) -> _Projection:
+    """Parse ``section``, ``-section``, ``section.field``, and ``-section.field``."""
+    known = _section_names(SheetData) | _section_names(WorkbookData)
+    include: set[str] = set()
+    exclude: set[str] = set()
+    keep_fields: dict[str, set[str]] = {}
+    drop_fields: dict[str, set[str]] = {}
+    for raw in fields:
+        entry = raw.strip()
+        negate = entry.startswith("-")
+        section, _, field = entry.lstrip("-").partition(".")
+        if section not in known:
+            raise SerializationError(
+                f"Unknown field {raw!r}. Known sections: {', '.join(sorted(known))}."
+            )
+        if negate and field:
+            drop_fields.setdefault(section, set()).add(field)
+        elif negate:
+            exclude.add(section)
+        else:
+            include.add(section)
+            if field:
+                keep_fields.setdefault(section, set()).add(field)
+    return _Projection(
+        include=frozenset(include),
+        exclude=frozenset(exclude),
+        keep_fields={key: frozenset(value) for key, value in keep_fields.items()},
+        drop_fields={key: frozenset(value) for key, value in drop_fields.items()},
+    )
+
+
+def apply_fields(payload: JsonStructure, fields: list[str]) -> JsonStructure:
+    """Keep or drop top-level sections and per-item fields of a workbook payload.
+
+    Entries name a sheet section (``rows``, ``shapes``, ...) or a workbook
+    section (``warnings``, ``chart_sources``, ...). Listing sections keeps only
+    those (``book_name`` and ``sheets`` always stay); ``-section`` drops one.
+    ``section.field`` keeps only the listed fields of each item in the section
+    and ``-section.field`` drops that field, e.g. ``-shapes.text``.
+
+    Args:
+        payload: Workbook payload as produced for JSON output.
+        fields: Projection entries, e.g. ``["shapes", "charts"]`` or
+            ``["-rows", "-charts.series"]``.
+
+    Returns:
+        Projected payload.
+
+    Raises:
+        SerializationError: If an entry names an unknown section.
+    """
+    projection = _parse_fields([field for field in fields if field.strip()])
+    if not isinstance(payload, dict):
+        return payload
+    result = projection.project(payload)
+    sheets = result.get("sheets")
+    if isinstance(sheets, dict):
+        result["sheets"] = {
+            name: projection.project(sheet) if isinstance(sheet, dict) else sheet
+            for name, sheet in sheets.items()
+        }
+    return result
+
+
+def encode_strings(payload: JsonStructure) -> JsonStructure:
+    """Store repeated string cell values once per sheet and reference them.
+
+    Strings occurring in more than one cell of a sheet are listed in the
+    sheet's ``strings`` table (in first-seen order) and their cells move from
+    the row's ``c`` map to an ``s`` map of column key to table index:
+    ``{"r": 2, "c": {"0": 10}, "s": {"1": 0}}`` with ``"strings": ["Open"]``
+    reads as ``{"0": 10, "1": "Open"}``. Sheets without repeats are unchanged.
+
+    Args:
+        payload: Workbook payload as produced for JSON output.
+
+    Returns:
+        Payload with dictionary-encoded rows.
+    """
+    if not isinstance(payload, dict) or not isinstance(payload.get("sheets"), dict):
+        return payload
+    sheets = cast(dict[str, JsonStructure], payload["sheets"])
+    return {
+        **payload,
+        "sheets": {name: _encode_sheet(sheet) for name, sheet in sheets.items()},
+    }
+
+
+def _encode_sheet(sheet: JsonStructure) -> JsonStructure:
+    rows = sheet.get("rows") if isinstance(sheet, dict) else None
+    if not isinstance(sheet, dict) or not isinstance(rows, list):
+        return sheet
+    counts = Counter(
+        value
+        for row in rows
+        if isinstance(row, dict) and isinstance(row.get("c"), dict)
+        for value in cast(dict[str, JsonStructure], row["c"]).values()
+        if isinstance(value, str)
+    )
+    index: dict[str, int] = {}
+    for row in rows:
+        cells = row.get("c") if isinstance(row, dict) else None
+        for value in cells.values() if isinstance(cells, dict) else ():
+            if isinstance(value, str) and counts[value] > 1 and value not in index:
+                index[value] = len(index)
+    if not index:
+        return sheet
+    encoded_rows: list[JsonStructure] = []
+    for row in rows:
+        cells = row.get("c") if isinstance(row, dict) else None
+        if not isinstance(row, dict) or not isinstance(cells, dict):
+            encoded_rows.append(row)
+            continue
+        plain = {k: v for k, v in cells.items() if not _is_str_in(v, index)}
+        refs: dict[str, JsonStructure] = {
+            k: index[cast(str, v)] for k, v in cells.items() if _is_str_in(v, index)
+        }
+        encoded = {key: value for key, value in row.items() if key != "c"}
+        if plain:
+            encoded["c"] = plain
+        if refs:
+            encoded["s"] = refs
+        encoded_rows.append(encoded)
+    return {**sheet, "rows": encoded_rows, "strings": list(index)}
+
+
+def _is_str_in(value: JsonStructure, index: dict[str, int]) -> bool:
+    return isinstance(value, str) and value in index
+
+
+def decode_strings(payload: JsonStructure) -> JsonStructure:
+    """Reverse ``encode_strings``: inline ``s`` references back into ``c``.
+
+    Args:
+        payload: Workbook payload written with string dictionary encoding.
+
+    Returns:
+        Payload with plain ``c`` maps and no ``strings`` tables.
+    """
+    if not isinstance(payload, dict) or not isinstance(payload.get("sheets"), dict):
+        return payload
+    sheets = cast(dict[str, JsonStructure], payload["sheets"])
+    decoded: dict[str, JsonStructure] = {}
+    for name, sheet in sheets.items():
+        table = sheet.get("strings") if isinstance(sheet, dict) else None
+        if not isinstance(sheet, dict) or not isinstance(table, list):
+            decoded[name] = sheet
+            continue
+        rows: list[JsonStructure] = []
+        for row in cast(list[JsonStructure], sheet.get("rows", [])):
+            refs = row.get("s") if isinstance(row, dict) else None
+            if not isinstance(row, dict) or not isinstance(refs, dict):
+                rows.append(row)
+                continue
+            cells = dict(cast(dict[str, JsonStructure], row.get("c", {})))
+            cells.update({key: table[cast(int, i)] for key, i in refs.items()})
+            cells = dict(sorted(cells.items(), key=lambda item: _column_order(item[0])))
+            plain = {key: value for key, value in row.items() if key != "s"}
+            rows.append({**plain, "c": cells})
+        sheet_without_table = {k: v for k, v in sheet.items() if k != "strings"}
+        decoded[name] = {**sheet_without_table, "rows": rows}
+    return {**payload, "sheets": decoded}
+
+
+def _column_order(key: str) -> tuple[int, str]:
+    """Sort numeric column keys numerically and alpha keys by length then name."""
+    return (int(key), "") if key.isdigit() else (len(key), key)
+
+
+__all__ = [
+    "apply_fields",
+    "apply_jq",
+    "apply_query",
+    "decode_strings",
+    "encode_strings",
+]
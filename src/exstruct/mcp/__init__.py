@@ -16,6 +16,7 @@ from .extract_runner import (
     run_extract,
 )
 from .io import PathPolicy
+from .job_queue import ExtractJob, ExtractJobQueue
 from .patch_runner import (
     FormulaIssue,
     MakeRequest,
@@ -51,8 +52,10 @@ from .tools import (
     CaptureSheetImagesToolOutput,
     DescribeOpToolInput,
     DescribeOpToolOutput,
+    ExtractJobToolOutput,
     ExtractToolInput,
     ExtractToolOutput,
+    GetExtractJobToolInput,
     ListOpsToolOutput,
     MakeToolInput,
     MakeToolOutput,
@@ -71,6 +74,7 @@ from .tools import (
     run_capture_sheet_images_tool,
     run_describe_op_tool,
     run_extract_tool,
+    run_get_extract_job_tool,
     run_list_ops_tool,
     run_make_tool,
     run_patch_tool,
@@ -78,6 +82,7 @@ from .tools import (
     run_read_formulas_tool,
     run_read_json_chunk_tool,
     run_read_range_tool,
+    run_submit_extract_job_tool,
     run_validate_input_tool,
 )
 from .validate_input import (
@@ -93,12 +98,16 @@ __all__ = [
     "CaptureSheetImagesToolOutput",
     "DescribeOpToolInput",
     "DescribeOpToolOutput",
+    "ExtractJob",
+    "ExtractJobQueue",
+    "ExtractJobToolOutput",
     "ExtractRequest",
     "ExtractResult",
     "ExtractOptions",
     "ExtractToolInput",
     "ExtractToolOutput",
     "FormulaIssue",
+    "GetExtractJobToolInput",
     "FormulaReadItem",
     "ListOpsToolOutput",
     "MakeRequest",
@@ -141,6 +150,7 @@ __all__ = [
     "run_extract",
     "run_describe_op_tool",
     "run_extract_tool",
+    "run_get_extract_job_tool",
     "run_list_ops_tool",
     "run_make",
     "run_make_tool",
@@ -155,5 +165,6 @@ __all__ = [
     "run_read_cells_tool",
     "run_read_formulas_tool",
     "run_read_range_tool",
+    "run_submit_extract_job_tool",
     "run_validate_input_tool",
 ]
@@ -2,11 +2,13 @@ from __future__ import annotations
 
 import logging
 from pathlib import Path
+import time
 from typing import Literal
 
 from pydantic import BaseModel, Field
 
-from exstruct import ExtractionMode, process_excel
+from exstruct import ExtractionMode, RunReport, process_excel
+from exstruct.metrics import MetricsRegistry
 
 from .io import PathPolicy
 from .shared.output_path import (
@@ -19,6 +21,9 @@ logger = logging.getLogger(__name__)
 
 OnConflictPolicy = Literal["overwrite", "skip", "rename"]
 
+# Extraction runs of this process, served by the exstruct_get_metrics tool.
+EXTRACTION_METRICS = MetricsRegistry()
+
 
 class WorkbookMeta(BaseModel):
     """Lightweight workbook metadata for MCP responses."""
@@ -124,18 +129,28 @@ def run_extract(
     )
     pretty = options.pretty if options.pretty is not None else False
 
-    process_excel(
-        file_path=resolved_input,
-        output_path=output_path,
-        out_fmt=request.format,
-        mode=request.mode,
-        pretty=pretty,
-        indent=options.indent,
-        sheets_dir=sheets_dir,
-        print_areas_dir=print_areas_dir,
-        auto_page_breaks_dir=auto_page_breaks_dir,
-        alpha_col=options.alpha_col,
-        include_backend_metadata=options.include_backend_metadata,
+    report = RunReport(file=str(resolved_input))
+    start = time.monotonic()
+    try:
+        process_excel(
+            file_path=resolved_input,
+            output_path=output_path,
+            out_fmt=request.format,
+            mode=request.mode,
+            pretty=pretty,
+            indent=options.indent,
+            sheets_dir=sheets_dir,
+            print_areas_dir=print_areas_dir,
+            auto_page_breaks_dir=auto_page_breaks_dir,
+            alpha_col=options.alpha_col,
+            include_backend_metadata=options.include_backend_metadata,
+            report=report,
+        )
+    except Exception:
+        EXTRACTION_METRICS.observe("error", time.monotonic() - start)
+        raise
+    EXTRACTION_METRICS.observe(
+        report.status, time.monotonic() - start, report.counts
     )
     meta, meta_warnings = _try_read_workbook_meta(resolved_input)
     warnings.extend(meta_warnings)
@@ -0,0 +1,184 @@
+"""Asynchronous extraction jobs for the MCP server.
+
+Extracting a large workbook can take minutes, longer than many MCP clients
+wait for a tool call. ``ExtractJobQueue`` runs extraction requests on a bounded
+worker pool and keeps each job's status and result until its TTL expires, so a
+client can submit a job and poll for it instead of holding the call open.
+"""
+
+from __future__ import annotations
+
+from collections.abc import Callable
+from concurrent.futures import ThreadPoolExecutor
+from datetime import UTC, datetime
+import logging
+import threading
+import time
+from typing import Literal
+from uuid import uuid4
+
+from pydantic import BaseModel, Field
+
+from .extract_runner import (
+    ExtractRequest,
+    ExtractResult,
+    _resolve_input_path,
+    run_extract,
+)
+from .io import PathPolicy
+
+logger = logging.getLogger(__name__)
+
+JobStatus = Literal["queued", "running", "succeeded", "failed"]
+
+DEFAULT_JOB_WORKERS = 2
+DEFAULT_JOB_RESULT_TTL_SECONDS = 3600.0
+
+
+class ExtractJob(BaseModel):
+    """Status of an asynchronous extraction job."""
+
+    job_id: str = Field(..., description="Job identifier returned on submit.")
+    status: JobStatus = Field(default="queued", description="Job state.")
+    xlsx_path: str = Field(..., description="Resolved input workbook path.")
+    submitted_at: datetime = Field(..., description="Submission time (UTC).")
+    started_at: datetime | None = Field(
+        default=None, description="Time a worker picked the job up (UTC)."
+    )
+    finished_at: datetime | None = Field(
+        default=None, description="Completion time (UTC)."
+    )
+    result: ExtractResult | None = Field(
+        default=None, description="Extraction result when succeeded."
+    )
+    error: str | None = Field(default=None, description="Failure message.")
+
+
+class ExtractJobQueue:
+    """Run extraction requests on a bounded worker pool.
+
+    Jobs are queued beyond ``max_workers`` and run in submission order.
+    Finished jobs are dropped ``result_ttl_seconds`` after completion; the
+    output files they wrote are left in place.
+    """
+
+    def __init__(
+        self,
+        *,
+        max_workers: int = DEFAULT_JOB_WORKERS,
+        max_input_bytes: int | None = None,
+        result_ttl_seconds: float = DEFAULT_JOB_RESULT_TTL_SECONDS,
+        runner: Callable[..., ExtractResult] = run_extract,
+        clock: Callable[[], float] = time.monotonic,
+    ) -> None:
+        """Create a queue.
+
+        Args:
+            max_workers: Number of extractions that run at the same time.
+            max_input_bytes: Largest accepted input workbook (None -> no limit).
+            result_ttl_seconds: How long finished jobs stay retrievable.
+            runner: Extraction function (``run_extract`` signature).
+            clock: Monotonic clock used for expiry.
+        """
+        if max_workers < 1:
+            raise ValueError("max_workers must be at least 1.")
+        self._executor = ThreadPoolExecutor(
+            max_workers=max_workers, thread_name_prefix="exstruct-job"
+        )
+        self._max_input_bytes = max_input_bytes
+        self._ttl = result_ttl_seconds
+        self._runner = runner
+        self._clock = clock
+        self._lock = threading.Lock()
+        self._jobs: dict[str, ExtractJob] = {}
+        self._expires: dict[str, float] = {}
+
+    def submit(
+        self, request: ExtractRequest, *, policy: PathPolicy | None = None
+    ) -> ExtractJob:
+        """Validate a request and queue it.
+
+        Args:
+            request: Extraction request.
+            policy: Optional path policy for access control.
+
+        Returns:
+            The queued job.
+
+        Raises:
+            FileNotFoundError: If the input file does not exist.
+            ValueError: If the path violates the policy or the file exceeds
+                ``max_input_bytes``.
+        """
+        resolved = _resolve_input_path(request.xlsx_path, policy=policy)
+        size = resolved.stat().st_size
+        if self._max_input_bytes is not None and size > self._max_input_bytes:
+            raise ValueError(
+                f"Input file is {size} bytes, over the limit of "
+                f"{self._max_input_bytes} bytes: {resolved}"
+            )
+        job = ExtractJob(
+            job_id=uuid4().hex,
+            xlsx_path=str(resolved),
+            submitted_at=datetime.now(UTC),
+        )
+        with self._lock:
+            self._purge_expired()
+            self._jobs[job.job_id] = job
+        self._executor.submit(self._run, job.job_id, request, policy)
+        return job.model_copy()
+
+    def get(self, job_id: str) -> ExtractJob:
+        """Return the current status of a job.
+
+        Raises:
+            KeyError: If the job is unknown or its result has expired.
+        """
+        with self._lock:
+            self._purge_expired()
+            job = self._jobs.get(job_id)
+            if job is None:
+                raise KeyError(f"Unknown or expired job: {job_id}")
+            return job.model_copy()
+
+    def shutdown(self, *, wait: bool = True) -> None:
+        """Stop accepting work and optionally wait for running jobs."""
+        self._executor.shutdown(wait=wait, cancel_futures=not wait)
+
+    def _run(
+        self, job_id: str, request: ExtractRequest, policy: PathPolicy | None
+    ) -> None:
+        self._update(job_id, status="running", started_at=datetime.now(UTC))
+        try:
+            result = self._runner(request, policy=policy)
+        except Exception as exc:
+            logger.warning("Extraction job %s failed: %s", job_id, exc)
+            self._finish(job_id, status="failed", error=str(exc))
+            return
+        self._finish(job_id, status="succeeded", result=result)
+
+    def _finish(self, job_id: str, **update: object) -> None:
+        self._update(job_id, finished_at=datetime.now(UTC), **update)
+        with self._lock:
+            self._expires[job_id] = self._clock() + self._ttl
+
+    def _update(self, job_id: str, **update: object) -> None:
+        with self._lock:
+            job = self._jobs.get(job_id)
+            if job is not None:
+                self._jobs[job_id] = job.model_copy(update=update)
+
+    def _purge_expired(self) -> None:
+        now = self._clock()
+        for job_id in [key for key, at in self._expires.items() if at <= now]:
+            del self._expires[job_id]
+            self._jobs.pop(job_id, None)
+
+
+__all__ = [
+    "DEFAULT_JOB_RESULT_TTL_SECONDS",
+    "DEFAULT_JOB_WORKERS",
+    "ExtractJob",
+    "ExtractJobQueue",
+    "JobStatus",
+]
@@ -20,6 +20,11 @@ from exstruct import ExtractionMode
 
 from .extract_runner import OnConflictPolicy
 from .io import PathPolicy
+from .job_queue import (
+    DEFAULT_JOB_RESULT_TTL_SECONDS,
+    DEFAULT_JOB_WORKERS,
+    ExtractJobQueue,
+)
 from .op_schema import build_patch_tool_mini_schema
 from .patch.normalize import (
     build_patch_op_error_message as _normalize_build_patch_op_error_message,
@@ -31,11 +36,14 @@ from .tools import (
     CaptureSheetImagesToolOutput,
     DescribeOpToolInput,
     DescribeOpToolOutput,
+    ExtractJobToolOutput,
     ExtractToolInput,
     ExtractToolOutput,
+    GetExtractJobToolInput,
     ListOpsToolOutput,
     MakeToolInput,
     MakeToolOutput,
+    MetricsToolOutput,
     PatchToolInput,
     PatchToolOutput,
     ReadCellsToolInput,
@@ -52,13 +60,16 @@ from .tools import (
     run_capture_sheet_images_tool,
     run_describe_op_tool,
     run_extract_tool,
+    run_get_extract_job_tool,
     run_list_ops_tool,
     run_make_tool,
+    run_metrics_tool,
     run_patch_tool,
     run_read_cells_tool,
     run_read_formulas_tool,
     run_read_json_chunk_tool,
     run_read_range_tool,
+    run_submit_extract_job_tool,
     run_validate_input_tool,
 )
 
@@ -84,6 +95,19 @@ class ServerConfig(BaseModel):
         description="Optional bridge directory for mirrored artifacts.",
     )
     warmup: bool = Field(default=False, description="Warm up heavy imports on start.")
+    job_workers: int = Field(
+        default=DEFAULT_JOB_WORKERS,
+        ge=1,
+        description="Extraction jobs that run at the same time.",
+    )
+    max_input_bytes: int | None = Field(
+        default=None, ge=1, description="Largest workbook accepted by jobs."
+    )
+    job_result_ttl: float = Field(
+        default=DEFAULT_JOB_RESULT_TTL_SECONDS,
+        gt=0,
+        description="Seconds finished extraction jobs stay retrievable.",
+    )
 
 
 def main(argv: list[str] | None = None) -> int:
@@ -126,12 +150,21 @@ def run_server(config: ServerConfig) -> None:
     logger.info("MCP root: %s", policy.normalize_root())
     if config.warmup:
         _warmup_exstruct()
+    job_queue = ExtractJobQueue(
+        max_workers=config.job_workers,
+        max_input_bytes=config.max_input_bytes,
+        result_ttl_seconds=config.job_result_ttl,
+    )
     app = _create_app(
         policy,
         on_conflict=config.on_conflict,
         artifact_bridge_dir=config.artifact_bridge_dir,
+        job_queue=job_queue,
     )
-    app.run()
+    try:
+        app.run()
+    finally:
+        job_queue.shutdown(wait=False)
 
 
 def _parse_args(argv: list[str] | None) -> ServerConfig:
@@ -173,6 +206,23 @@ def _parse_args(argv: list[str] | None) -> ServerConfig:
         action="store_true",
         help="Warm up heavy imports on startup to reduce tool latency.",
     )
+    parser.add_argument(
+        "--job-workers",
+        type=int,
+        default=DEFAULT_JOB_WORKERS,
+        help="Number of extraction jobs that run concurrently.",
+    )
+    parser.add_argument(
+        "--max-input-bytes",
+        type=int,
+        help="Reject extraction jobs for workbooks larger than this size.",
+    )
+    parser.add_argument(
+        "--job-result-ttl",
+        type=float,
+        default=DEFAULT_JOB_RESULT_TTL_SECONDS,
+        help="Seconds to keep finished extraction job results.",
+    )
     args = parser.parse_args(argv)
     return ServerConfig(
         root=args.root,
@@ -182,6 +232,9 @@ def _parse_args(argv: list[str] | None) -> ServerConfig:
         on_conflict=args.on_conflict,
         artifact_bridge_dir=args.artifact_bridge_dir,
         warmup=bool(args.warmup),
+        job_workers=args.job_workers,
+        max_input_bytes=args.max_input_bytes,
+        job_result_ttl=args.job_result_ttl,
     )
 
 
@@ -228,11 +281,13 @@ def _create_app(
     *,
     on_conflict: OnConflictPolicy,
     artifact_bridge_dir: Path | None = None,
+    job_queue: ExtractJobQueue | None = None,
 ) -> FastMCP:
     """Create the MCP FastMCP application.
 
     Args:
         policy: Path policy for filesystem access.
+        job_queue: Queue for asynchronous extraction jobs.
 
     Returns:
         FastMCP application instance.
@@ -245,6 +300,7 @@ def _create_app(
         policy,
         default_on_conflict=on_conflict,
         artifact_bridge_dir=artifact_bridge_dir,
+        job_queue=job_queue,
     )
     return app
 
@@ -255,6 +311,7 @@ def _register_tools(
     *,
     default_on_conflict: OnConflictPolicy,
     artifact_bridge_dir: Path | None = None,
+    job_queue: ExtractJobQueue | None = None,
 ) -> None:
     """Register MCP tools for the server.
 
@@ -263,6 +320,7 @@ def _register_tools(
         policy: Path policy for filesystem access.
         default_on_conflict: Default conflict policy used when tool input omits it.
         artifact_bridge_dir: Optional directory for artifact mirroring handoff.
+        job_queue: Queue for asynchronous extraction jobs (None -> defaults).
     """
 
     async def _extract_tool(  # pylint: disable=redefined-builtin
@@ -315,6 +373,12 @@ def _register_tools(
     tool = app.tool(name="exstruct_extract")
     tool(_extract_tool)
 
+    _register_extract_job_tools(
+        app,
+        policy=policy,
+        queue=job_queue or ExtractJobQueue(),
+        default_on_conflict=default_on_conflict,
+    )
     _register_capture_sheet_images_tool(app, policy=policy)
 
     async def _read_json_chunk_tool(  # pylint: disable=redefined-builtin
@@ -506,6 +570,18 @@ def _register_tools(
     runtime_info_tool = app.tool(name="exstruct_get_runtime_info")
     runtime_info_tool(_runtime_info_tool)
 
+    async def _metrics_tool() -> MetricsToolOutput:
+        """Return extraction metrics of this server for capacity planning.
+
+        Returns:
+            Run counts by outcome, a duration histogram, and extracted item
+            totals in the Prometheus text exposition format.
+        """
+        return run_metrics_tool()
+
+    metrics_tool = app.tool(name="exstruct_get_metrics")
+    metrics_tool(_metrics_tool)
+
     _register_op_schema_tools(app)
 
     async def _patch_tool(
@@ -737,6 +813,70 @@ Returns:
     return f"{base_description.strip()}\n\n{build_patch_tool_mini_schema()}"
 
 
+def _register_extract_job_tools(
+    app: FastMCP,
+    *,
+    policy: PathPolicy,
+    queue: ExtractJobQueue,
+    default_on_conflict: OnConflictPolicy,
+) -> None:
+    """Register the asynchronous extraction job MCP tools."""
+
+    async def _submit_extract_job_tool(  # pylint: disable=redefined-builtin
+        xlsx_path: str,
+        mode: ExtractionMode = "standard",
+        format: Literal["json", "yaml", "yml", "toon"] = "json",  # noqa: A002
+        out_dir: str | None = None,
+        out_name: str | None = None,
+        on_conflict: OnConflictPolicy | None = None,
+        options: dict[str, Any] | None = None,
+    ) -> ExtractJobToolOutput:
+        """Queue an extraction and return a job ID without waiting for it.
+
+        Use for large workbooks whose extraction may take minutes, then poll
+        exstruct_get_extract_job. Arguments are the same as exstruct_extract.
+
+        Returns:
+            Job ID and status ("queued").
+        """
+        payload = ExtractToolInput(
+            xlsx_path=xlsx_path,
+            mode=mode,
+            format=format,
+            out_dir=out_dir,
+            out_name=out_name,
+            on_conflict=on_conflict,
+            options=options or {},
+        )
+        work = functools.partial(
+            run_submit_extract_job_tool,
+            payload,
+            queue=queue,
+            policy=policy,
+            on_conflict=on_conflict or default_on_conflict,
+        )
+        return cast(ExtractJobToolOutput, await anyio.to_thread.run_sync(work))
+
+    submit_tool = app.tool(name="exstruct_submit_extract")
+    submit_tool(_submit_extract_job_tool)
+
+    async def _get_extract_job_tool(job_id: str) -> ExtractJobToolOutput:
+        """Return the status of an extraction job.
+
+        Args:
+            job_id: Job ID returned by exstruct_submit_extract.
+
+        Returns:
+            Status ("queued", "running", "succeeded", "failed") with the
+            extraction result once succeeded or the error once failed.
+        """
+        payload = GetExtractJobToolInput(job_id=job_id)
+        return run_get_extract_job_tool(payload, queue=queue)
+
+    get_job_tool = app.tool(name="exstruct_get_extract_job")
+    get_job_tool(_get_extract_job_tool)
+
+
 def _register_capture_sheet_images_tool(app: FastMCP, *, policy: PathPolicy) -> None:
     """Register the sheet image capture MCP tool."""
 
@@ -16,6 +16,7 @@ from .chunk_reader import (
     read_json_chunk,
 )
 from .extract_runner import (
+    EXTRACTION_METRICS,
     ExtractOptions,
     ExtractRequest,
     ExtractResult,
@@ -24,6 +25,7 @@ from .extract_runner import (
     run_extract,
 )
 from .io import PathPolicy
+from .job_queue import ExtractJob, ExtractJobQueue, JobStatus
 from .op_schema import (
     get_patch_op_schema,
     list_patch_op_schemas,
@@ -91,6 +93,24 @@ class ExtractToolOutput(BaseModel):
     engine: Literal["internal_api", "cli_subprocess"] = "internal_api"
 
 
+class GetExtractJobToolInput(BaseModel):
+    """MCP tool input for extraction job status."""
+
+    job_id: str
+
+
+class ExtractJobToolOutput(BaseModel):
+    """MCP tool output for an asynchronous extraction job."""
+
+    job_id: str
+    status: JobStatus
+    submitted_at: str
+    started_at: str | None = None
+    finished_at: str | None = None
+    result: ExtractToolOutput | None = None
+    error: str | None = None
+
+
 class CaptureSheetImagesToolInput(BaseModel):
     """MCP tool input for sheet image capture."""
 
@@ -243,6 +263,13 @@ class RuntimeInfoToolOutput(BaseModel):
     path_examples: RuntimePathExamples
 
 
+class MetricsToolOutput(BaseModel):
+    """MCP tool output for extraction metrics of the server process."""
+
+    content_type: str = "text/plain; version=0.0.4"
+    text: str = Field(description="Metrics in the Prometheus text format.")
+
+
 class OpSummary(BaseModel):
     """Short op metadata for list output."""
 
@@ -389,6 +416,50 @@ def run_extract_tool(
     return _to_tool_output(result)
 
 
+def run_submit_extract_job_tool(
+    payload: ExtractToolInput,
+    *,
+    queue: ExtractJobQueue,
+    policy: PathPolicy | None = None,
+    on_conflict: OnConflictPolicy | None = None,
+) -> ExtractJobToolOutput:
+    """Queue an extraction and return its job ID.
+
+    Args:
+        payload: Tool input payload (same as the extraction tool).
+        queue: Job queue of the server.
+        policy: Optional path policy for access control.
+
+    Returns:
+        The queued job.
+    """
+    request = ExtractRequest(
+        xlsx_path=Path(payload.xlsx_path),
+        mode=payload.mode,
+        format=payload.format,
+        out_dir=Path(payload.out_dir) if payload.out_dir else None,
+        out_name=payload.out_name,
+        on_conflict=payload.on_conflict or on_conflict or "overwrite",
+        options=payload.options,
+    )
+    return _to_job_tool_output(queue.submit(request, policy=policy))
+
+
+def run_get_extract_job_tool(
+    payload: GetExtractJobToolInput, *, queue: ExtractJobQueue
+) -> ExtractJobToolOutput:
+    """Return the status, and once finished the result, of an extraction job.
+
+    Raises:
+        ValueError: If the job is unknown or its result has expired.
+    """
+    try:
+        job = queue.get(payload.job_id)
+    except KeyError as exc:
+        raise ValueError(exc.args[0]) from exc
+    return _to_job_tool_output(job)
+
+
 def run_capture_sheet_images_tool(
     payload: CaptureSheetImagesToolInput,
     *,
@@ -619,6 +690,19 @@ def _to_tool_output(result: ExtractResult) -> ExtractToolOutput:
     )
 
 
+def _to_job_tool_output(job: ExtractJob) -> ExtractJobToolOutput:
+    """Convert an extraction job to tool output with ISO 8601 timestamps."""
+    return ExtractJobToolOutput(
+        job_id=job.job_id,
+        status=job.status,
+        submitted_at=job.submitted_at.isoformat(),
+        started_at=job.started_at.isoformat() if job.started_at else None,
+        finished_at=job.finished_at.isoformat() if job.finished_at else None,
+        result=_to_tool_output(job.result) if job.result else None,
+        error=job.error,
+    )
+
+
 def _to_capture_sheet_images_tool_output(
     result: CaptureSheetImagesResult,
 ) -> CaptureSheetImagesToolOutput:
@@ -722,6 +806,11 @@ def _to_validate_input_output(
     )
 
 
+def run_metrics_tool() -> MetricsToolOutput:
+    """Return extraction run counters of this server in Prometheus format."""
+    return MetricsToolOutput(text=EXTRACTION_METRICS.render())
+
+
 def run_list_ops_tool() -> ListOpsToolOutput:
     """Return available patch operations and their short descriptions."""
     return ListOpsToolOutput(
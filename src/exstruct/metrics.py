@@ -0,0 +1,140 @@
+"""Extraction timing and size metrics (``include_metrics``).
+
+``collect_metrics`` builds the ``metrics`` output section from an extracted
+workbook; ``MetricsRegistry`` accumulates runs of a long-lived process (the MCP
+server) and renders them in the Prometheus text exposition format.
+"""
+
+from __future__ import annotations
+
+from bisect import bisect_left
+from collections.abc import Sequence
+import math
+from pathlib import Path
+import threading
+import zipfile
+
+from .models import ExtractionMetrics, SheetData, SheetMetrics, WorkbookData
+from .sources import open_source, source_size
+
+DEFAULT_DURATION_BUCKETS: tuple[float, ...] = (0.1, 0.5, 1, 2.5, 5, 10, 30, 60, 120)
+_COUNT_KEYS = ("sheets", "rows", "cells", "shapes", "charts", "table_candidates")
+
+
+def collect_metrics(
+    workbook: WorkbookData, file_path: Path, *, extract_seconds: float
+) -> ExtractionMetrics:
+    """Build per-sheet metrics for an extracted workbook.
+
+    Worksheet parts are parsed once more on their own to time each sheet,
+    since extraction runs each component over all sheets at once.
+
+    Args:
+        workbook: Extracted workbook (counts are taken from its sheets).
+        file_path: Workbook path, for part sizes and parse timing.
+        extract_seconds: Wall time of the extraction.
+
+    Returns:
+        Metrics with one entry per extracted sheet.
+    """
+    parts: dict[str, SheetMetrics] = {}
+    if zipfile.is_zipfile(open_source(file_path)):
+        from .ooxml.summary import measure_sheet_parts
+
+        parts = measure_sheet_parts(file_path)
+    return ExtractionMetrics(
+        extract_seconds=round(extract_seconds, 4),
+        file_bytes=source_size(file_path),
+        sheets={
+            name: _sheet_metrics(sheet, parts.get(name))
+            for name, sheet in workbook.sheets.items()
+        },
+    )
+
+
+def _sheet_metrics(sheet: SheetData, part: SheetMetrics | None) -> SheetMetrics:
+    return (part or SheetMetrics()).model_copy(
+        update={
+            "rows": len(sheet.rows),
+            "cells": sum(len(row.c) for row in sheet.rows),
+            "shapes": len(sheet.shapes),
+            "charts": len(sheet.charts),
+            "table_candidates": len(sheet.table_candidates),
+        }
+    )
+
+
+class MetricsRegistry:
+    """Thread-safe counters of extraction runs in Prometheus format.
+
+    Example:
+        >>> registry = MetricsRegistry()
+        >>> registry.observe("ok", 0.8, {"sheets": 2, "rows": 10})
+        >>> "exstruct_extractions_total{status=\\"ok\\"} 1" in registry.render()
+        True
+    """
+
+    def __init__(self, buckets: Sequence[float] = DEFAULT_DURATION_BUCKETS) -> None:
+        """Create an empty registry with the given duration histogram buckets."""
+        self._lock = threading.Lock()
+        self._buckets = tuple(sorted(buckets))
+        self._bucket_counts = [0] * (len(self._buckets) + 1)
+        self._runs: dict[str, int] = {}
+        self._seconds_sum = 0.0
+        self._items: dict[str, int] = dict.fromkeys(_COUNT_KEYS, 0)
+
+    def observe(
+        self, status: str, seconds: float, counts: dict[str, int] | None = None
+    ) -> None:
+        """Record one run.
+
+        Args:
+            status: Run outcome (``ok``, ``partial``, or ``error``).
+            seconds: Wall time of the run.
+            counts: Extracted item counts (``RunReport.counts`` keys).
+        """
+        with self._lock:
+            self._runs[status] = self._runs.get(status, 0) + 1
+            self._seconds_sum += seconds
+            self._bucket_counts[bisect_left(self._buckets, seconds)] += 1
+            for key, value in (counts or {}).items():
+                if key in self._items:
+                    self._items[key] += value
+
+    def render(self) -> str:
+        """Return the counters in the Prometheus text exposition format."""
+        with self._lock:
+            lines = [
+                "# HELP exstruct_extractions_total Extraction runs by outcome.",
+                "# TYPE exstruct_extractions_total counter",
+                *(
+                    f'exstruct_extractions_total{{status="{status}"}} {count}'
+                    for status, count in sorted(self._runs.items())
+                ),
+                "# HELP exstruct_extraction_duration_seconds Wall time per run.",
+                "# TYPE exstruct_extraction_duration_seconds histogram",
+            ]
+            cumulative = 0
+            for bound, count in zip(
+                (*self._buckets, math.inf), self._bucket_counts, strict=True
+            ):
+                cumulative += count
+                label = "+Inf" if math.isinf(bound) else f"{bound:g}"
+                lines.append(
+                    "exstruct_extraction_duration_seconds_bucket"
+                    f'{{le="{label}"}} {cumulative}'
+                )
+            lines += [
+                f"exstruct_extraction_duration_seconds_sum {self._seconds_sum:.6f}",
+                f"exstruct_extraction_duration_seconds_count {cumulative}",
+                "# HELP exstruct_extracted_items_total Extracted items by kind.",
+                "# TYPE exstruct_extracted_items_total counter",
+                *(
+                    f'exstruct_extracted_items_total{{kind="{kind}"}} {value}'
+                    for kind, value in self._items.items()
+                ),
+            ]
+        return "\n".join(lines) + "\n"
+
+
+__all__ = ["DEFAULT_DURATION_BUCKETS", "MetricsRegistry", "collect_metrics"]
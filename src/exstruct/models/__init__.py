@@ -3,11 +3,15 @@
 from __future__ import annotations
 
 from collections.abc import Generator
+from datetime import datetime, timedelta
 import json
 from pathlib import Path
+import re
 from typing import Literal, TypeVar
 
-from pydantic import BaseModel, ConfigDict, Field
+from pydantic import BaseModel, ConfigDict, Field, JsonValue
+
+CellValue = int | float | str
 
 
 def _default_merged_cells_schema() -> list[Literal["r1", "c1", "r2", "c2", "v"]]:
@@ -20,7 +24,17 @@ class BaseShape(BaseModel):
 
     id: int | None = Field(
         default=None,
-        description="Sequential shape id within the sheet (if applicable).",
+        description=(
+            "Sequential shape id within the sheet (if applicable); the drawing's "
+            "own cNvPr id when stable ids are requested."
+        ),
+    )
+    source_id: str | None = Field(
+        default=None,
+        description=(
+            "Drawing part and cNvPr id (e.g. 'xl/drawings/drawing1.xml#5'), set "
+            "when stable ids are requested and the drawing part is known."
+        ),
     )
     text: str = Field(description="Visible text content of the shape.")
     l: int = Field(description="Left offset (Excel units).")  # noqa: E741
@@ -30,6 +44,14 @@ class BaseShape(BaseModel):
     rotation: float | None = Field(
         default=None, description="Rotation angle in degrees."
     )
+    covered_range: str | None = Field(
+        default=None,
+        description="Cell range covered by the shape bounds (e.g., 'B2:D5').",
+    )
+    z_order: int | None = Field(
+        default=None,
+        description="Stacking position within the sheet (1 = back-most).",
+    )
     provenance: Literal["excel_com", "libreoffice_uno"] | None = Field(
         default=None, description="Backend provenance for this shape."
     )
@@ -45,11 +67,77 @@ class BaseShape(BaseModel):
     )
 
 
+class ShapeGeometry(BaseModel):
+    """Drawn geometry of a freeform shape or ink annotation."""
+
+    kind: Literal["freeform", "ink"] = Field(
+        description="freeform for custom geometry shapes, ink for pen strokes."
+    )
+    l: int = Field(description="Path bounding box left (Excel units).")  # noqa: E741
+    t: int = Field(description="Path bounding box top (Excel units).")
+    w: int = Field(description="Path bounding box width (Excel units).")
+    h: int = Field(description="Path bounding box height (Excel units).")
+    paths: list[list[tuple[int, int]]] | None = Field(
+        default=None,
+        description=(
+            "Simplified polylines (x, y points in sheet coordinates), one per "
+            "subpath or ink stroke; only when shape paths are requested. "
+            "Rotation is not applied."
+        ),
+    )
+
+
 class Shape(BaseShape):
     """Normal shape metadata."""
 
     kind: Literal["shape"] = Field(default="shape", description="Shape kind.")
     type: str | None = Field(default=None, description="Excel shape type name.")
+    text_align: (
+        Literal["left", "center", "right", "justify", "distributed"] | None
+    ) = Field(default=None, description="Horizontal alignment of the shape text.")
+    text_anchor: Literal["top", "middle", "bottom"] | None = Field(
+        default=None, description="Vertical anchor of the shape text."
+    )
+    text_wrap: bool | None = Field(
+        default=None, description="Whether the shape text wraps within the shape."
+    )
+    text_orientation: (
+        Literal[
+            "horizontal",
+            "vertical",
+            "vertical270",
+            "stacked",
+            "east_asian_vertical",
+            "mongolian_vertical",
+        ]
+        | None
+    ) = Field(
+        default=None,
+        description=(
+            "Text direction: vertical/vertical270 rotate lines 90/270 degrees; "
+            "stacked and east_asian_vertical keep characters upright in "
+            "top-to-bottom columns (right to left for east_asian_vertical, left "
+            "to right for mongolian_vertical). text keeps the reading order."
+        ),
+    )
+    geometry: ShapeGeometry | None = Field(
+        default=None,
+        description=(
+            "Path bounding box (and optional polylines) of freeform shapes and "
+            "ink annotations; OOXML parsing only."
+        ),
+    )
+    link: str | None = Field(
+        default=None,
+        description=(
+            "Click hyperlink target: a URL or file path, or '#Sheet!A1' for a "
+            "location in the workbook."
+        ),
+    )
+    on_action: str | None = Field(
+        default=None,
+        description="Name of the macro assigned to the shape (e.g. 'Submit_Click').",
+    )
 
 
 class Arrow(BaseShape):
@@ -62,6 +150,28 @@ class Arrow(BaseShape):
     end_arrow_style: int | None = Field(
         default=None, description="Arrow style enum for the end of a connector."
     )
+    begin_arrow_width: int | None = Field(
+        default=None,
+        description="Arrowhead width enum for the start (1=narrow, 2=medium, 3=wide).",
+    )
+    begin_arrow_length: int | None = Field(
+        default=None,
+        description="Arrowhead length enum for the start (1=short, 2=medium, 3=long).",
+    )
+    end_arrow_width: int | None = Field(
+        default=None,
+        description="Arrowhead width enum for the end (1=narrow, 2=medium, 3=wide).",
+    )
+    end_arrow_length: int | None = Field(
+        default=None,
+        description="Arrowhead length enum for the end (1=short, 2=medium, 3=long).",
+    )
+    line_dash_style: int | None = Field(
+        default=None, description="Line dash style enum (MsoLineDashStyle)."
+    )
+    line_weight: float | None = Field(
+        default=None, description="Line weight in points."
+    )
     begin_id: int | None = Field(
         default=None,
         description=(
@@ -77,6 +187,24 @@ class Arrow(BaseShape):
     direction: Literal["E", "SE", "S", "SW", "W", "NW", "N", "NE"] | None = Field(
         default=None, description="Connector direction (compass heading)."
     )
+    begin_x: int | None = Field(
+        default=None, description="Connector start X (same units as l)."
+    )
+    begin_y: int | None = Field(
+        default=None, description="Connector start Y (same units as t)."
+    )
+    end_x: int | None = Field(
+        default=None, description="Connector end X (same units as l)."
+    )
+    end_y: int | None = Field(
+        default=None, description="Connector end Y (same units as t)."
+    )
+    begin_cell: str | None = Field(
+        default=None, description="Cell containing the connector start (e.g., 'B3')."
+    )
+    end_cell: str | None = Field(
+        default=None, description="Cell containing the connector end (e.g., 'D7')."
+    )
 
 
 class SmartArtNode(BaseModel):
@@ -125,6 +253,115 @@ class CellRow(BaseModel):
     links: dict[str, str] | None = Field(
         default=None, description="Optional hyperlinks per column index."
     )
+    phonetic: dict[str, str] | None = Field(
+        default=None,
+        description="Optional phonetic (furigana) readings per column index.",
+    )
+
+
+class ChartDataLabels(BaseModel):
+    """Data label settings for a chart series."""
+
+    show_value: bool = Field(default=False, description="Whether values are shown.")
+    show_percentage: bool = Field(
+        default=False, description="Whether percentages are shown (pie charts)."
+    )
+    show_category_name: bool = Field(
+        default=False, description="Whether category names are shown."
+    )
+    show_series_name: bool = Field(
+        default=False, description="Whether the series name is shown."
+    )
+    number_format: str | None = Field(
+        default=None, description="Number format code applied to the labels."
+    )
+
+
+class ChartTrendline(BaseModel):
+    """Trendline settings for a chart series."""
+
+    type: Literal[
+        "linear", "exponential", "logarithmic", "polynomial", "power", "moving_average"
+    ] = Field(description="Trendline regression type.")
+    name: str | None = Field(default=None, description="Custom trendline name.")
+    order: int | None = Field(
+        default=None, description="Polynomial order (polynomial trendlines only)."
+    )
+    period: int | None = Field(
+        default=None, description="Period (moving-average trendlines only)."
+    )
+    display_equation: bool = Field(
+        default=False, description="Whether the equation is displayed on the chart."
+    )
+    display_r_squared: bool = Field(
+        default=False, description="Whether the R-squared value is displayed."
+    )
+
+
+class ChartErrorBars(BaseModel):
+    """Error bar settings for a chart series."""
+
+    direction: Literal["x", "y"] | None = Field(
+        default=None, description="Axis the error bars apply to."
+    )
+    bar_type: Literal["both", "plus", "minus"] | None = Field(
+        default=None, description="Which side(s) of the value the bars extend to."
+    )
+    value_type: (
+        Literal["fixed", "percentage", "std_dev", "std_err", "custom"] | None
+    ) = Field(default=None, description="How the error amount is determined.")
+    value: float | None = Field(
+        default=None, description="Error amount for fixed/percentage/std_dev types."
+    )
+
+
+class ChartLegend(BaseModel):
+    """Legend settings for a chart."""
+
+    visible: bool = Field(description="Whether the legend is displayed.")
+    position: Literal["right", "left", "top", "bottom", "corner", "custom"] | None = (
+        Field(default=None, description="Legend position when visible.")
+    )
+
+
+class ChartAxis(BaseModel):
+    """Value axis settings for a chart."""
+
+    secondary: bool = Field(
+        default=False, description="Whether this is the secondary value axis."
+    )
+    title: str = Field(default="", description="Axis title.")
+    range: list[float] = Field(
+        default_factory=list, description="Axis range [min, max] when fixed."
+    )
+    number_format: str | None = Field(
+        default=None, description="Number format code of the tick labels."
+    )
+    log_base: float | None = Field(
+        default=None, description="Logarithm base when the axis is log-scaled."
+    )
+
+
+class ChartMarker(BaseModel):
+    """Data point marker of a line, scatter, or radar series."""
+
+    symbol: Literal[
+        "auto",
+        "none",
+        "circle",
+        "dash",
+        "diamond",
+        "dot",
+        "picture",
+        "plus",
+        "square",
+        "star",
+        "triangle",
+        "x",
+    ] | None = Field(default=None, description="Marker symbol (None if unknown).")
+    size: int | None = Field(
+        default=None, description="Marker size in points (2-72; None if default)."
+    )
 
 
 class ChartSeries(BaseModel):
@@ -140,6 +377,46 @@ class ChartSeries(BaseModel):
     y_range: str | None = Field(
         default=None, description="Range reference for Y axis values."
     )
+    size_range: str | None = Field(
+        default=None, description="Range reference for bubble sizes (bubble charts)."
+    )
+    marker: ChartMarker | None = Field(
+        default=None, description="Marker settings (None if not specified)."
+    )
+    smooth: bool | None = Field(
+        default=None,
+        description="Whether the series line is smoothed (None if not specified).",
+    )
+    data_labels: ChartDataLabels | None = Field(
+        default=None, description="Data label settings (None if labels are hidden)."
+    )
+    categories: list[str | None] | None = Field(
+        default=None,
+        description="Cached category labels (pie/doughnut charts only).",
+    )
+    values: list[float | None] | None = Field(
+        default=None, description="Cached values (pie/doughnut charts only)."
+    )
+    trendlines: list[ChartTrendline] = Field(
+        default_factory=list, description="Trendlines attached to the series."
+    )
+    error_bars: list[ChartErrorBars] = Field(
+        default_factory=list, description="Error bars attached to the series."
+    )
+
+
+class ChartTextBox(BaseModel):
+    """Free-floating text box drawn inside a chart (footnote, annotation)."""
+
+    text: str = Field(description="Text content of the text box.")
+    x: float | None = Field(
+        default=None,
+        description="Left edge as a fraction of the chart width (0.0-1.0).",
+    )
+    y: float | None = Field(
+        default=None,
+        description="Top edge as a fraction of the chart height (0.0-1.0).",
+    )
 
 
 class Chart(BaseModel):
@@ -155,6 +432,47 @@ class Chart(BaseModel):
     w: int | None = Field(default=None, description="Chart width (None if unknown).")
     h: int | None = Field(default=None, description="Chart height (None if unknown).")
     series: list[ChartSeries] = Field(description="Series included in the chart.")
+    legend: ChartLegend | None = Field(
+        default=None, description="Legend settings (None if unknown)."
+    )
+    grouping: Literal["clustered", "stacked", "percent_stacked", "standard"] | None = (
+        Field(
+            default=None,
+            description="Series grouping for bar, column, line, and area charts.",
+        )
+    )
+    bar_direction: Literal["vertical", "horizontal"] | None = Field(
+        default=None, description="Bar orientation (vertical = column chart)."
+    )
+    value_axes: list[ChartAxis] = Field(
+        default_factory=list,
+        description="Value axes (primary first, then secondary when present).",
+    )
+    text_boxes: list[ChartTextBox] = Field(
+        default_factory=list,
+        description="Text boxes and text-bearing shapes placed inside the chart.",
+    )
+    plot_visible_only: bool | None = Field(
+        default=None,
+        description=(
+            "Whether series skip cells in hidden rows and columns (plotVisOnly); "
+            "None if unknown."
+        ),
+    )
+    display_blanks_as: Literal["gap", "zero", "span"] | None = Field(
+        default=None,
+        description=(
+            "How empty cells are plotted: gap, zero, or span (lines connect "
+            "across them); None if unknown."
+        ),
+    )
+    scatter_style: (
+        Literal["line", "line_marker", "marker", "none", "smooth", "smooth_marker"]
+        | None
+    ) = Field(
+        default=None,
+        description="Scatter chart style (lines, markers, smoothing); None otherwise.",
+    )
     l: int = Field(description="Left offset (Excel units).")  # noqa: E741
     t: int = Field(description="Top offset (Excel units).")
     error: str | None = Field(
@@ -184,6 +502,240 @@ class PrintArea(BaseModel):
     c2: int = Field(description="End column (0-based, inclusive).")
 
 
+class NamedRange(BaseModel):
+    """Defined name resolved to the cell bounds it refers to on one sheet."""
+
+    name: str = Field(description="Defined name (e.g., SalesData).")
+    r1: int = Field(description="Start row (1-based).")
+    c1: int = Field(description="Start column (0-based).")
+    r2: int = Field(description="End row (1-based, inclusive).")
+    c2: int = Field(description="End column (0-based, inclusive).")
+    local: bool = Field(
+        default=False, description="Whether the name is scoped to its sheet."
+    )
+
+
+class FlowchartNode(BaseModel):
+    """Node of a reconstructed flowchart."""
+
+    id: int = Field(description="Shape id of the node.")
+    kind: Literal[
+        "process",
+        "decision",
+        "terminator",
+        "data",
+        "document",
+        "connector",
+        "other",
+    ] = Field(description="Flowchart node kind derived from the shape type.")
+    text: str = Field(description="Visible text of the node shape.")
+    type: str | None = Field(default=None, description="Excel shape type name.")
+
+
+class FlowchartEdge(BaseModel):
+    """Directed edge of a reconstructed flowchart."""
+
+    source: int = Field(description="Node id the edge starts from.")
+    target: int = Field(description="Node id the edge points to.")
+    label: str | None = Field(default=None, description="Connector text, if any.")
+    directed: bool = Field(
+        default=True,
+        description="False when arrowheads do not indicate a direction.",
+    )
+    direction: Literal["E", "SE", "S", "SW", "W", "NW", "N", "NE"] | None = Field(
+        default=None, description="Connector direction (compass heading)."
+    )
+    begin_arrow_style: int | None = Field(
+        default=None, description="Arrow style enum for the start of the connector."
+    )
+    end_arrow_style: int | None = Field(
+        default=None, description="Arrow style enum for the end of the connector."
+    )
+
+
+class Flowchart(BaseModel):
+    """Flowchart reconstructed from connected shapes."""
+
+    nodes: list[FlowchartNode] = Field(
+        default_factory=list, description="Nodes in the flowchart."
+    )
+    edges: list[FlowchartEdge] = Field(
+        default_factory=list, description="Edges between flowchart nodes."
+    )
+    order: list[int] | None = Field(
+        default=None,
+        description="Topological order of node ids (None when the graph has cycles).",
+    )
+
+
+class ShapeOverlap(BaseModel):
+    """Pair of overlapping shapes ordered by stacking position."""
+
+    back_id: int = Field(description="Shape id of the shape drawn behind.")
+    front_id: int = Field(description="Shape id of the shape drawn on top.")
+    overlap_ratio: float = Field(
+        ge=0.0,
+        le=1.0,
+        description="Intersection area divided by the smaller shape area.",
+    )
+
+
+class CellError(BaseModel):
+    """Cell holding an error value such as #N/A or #REF!."""
+
+    cell: str = Field(description="A1 address of the cell.")
+    error: str = Field(description="Error value (e.g., #N/A, #REF!, #VALUE!).")
+    formula: str | None = Field(
+        default=None, description="Formula producing the error (None for constants)."
+    )
+
+
+class OutlineGroup(BaseModel):
+    """Consecutive rows or columns grouped at one outline level."""
+
+    start: int = Field(description="First grouped row (1-based) or column (0-based).")
+    end: int = Field(description="Last grouped row (1-based) or column (0-based).")
+    level: int = Field(ge=1, le=7, description="Outline level (1 = outermost).")
+    collapsed: bool = Field(
+        default=False, description="Whether the group is collapsed (hidden)."
+    )
+    summary: int | None = Field(
+        default=None,
+        description="Summary (subtotal) row or column next to the group.",
+    )
+
+
+class SheetOutline(BaseModel):
+    """Row and column grouping (outline) of a worksheet."""
+
+    rows: list[OutlineGroup] = Field(
+        default_factory=list,
+        description="Row groups ordered by start row, outer groups first.",
+    )
+    columns: list[OutlineGroup] = Field(
+        default_factory=list,
+        description="Column groups ordered by start column, outer groups first.",
+    )
+    summary_below: bool = Field(
+        default=True, description="Whether summary rows follow their group."
+    )
+    summary_right: bool = Field(
+        default=True,
+        description="Whether summary columns are right of their group.",
+    )
+
+
+class FormulaFunctionUsage(BaseModel):
+    """Cells whose formulas call a given function."""
+
+    function: str = Field(description="Upper-cased function name (e.g., NOW).")
+    cells: list[str] = Field(
+        default_factory=list, description="A1 addresses of the calling cells."
+    )
+
+
+class FormulaAudit(BaseModel):
+    """Formula patterns that make a workbook fragile to recalculation."""
+
+    volatile: list[FormulaFunctionUsage] = Field(
+        default_factory=list,
+        description="Volatile functions recalculated on every change.",
+    )
+    external: list[FormulaFunctionUsage] = Field(
+        default_factory=list,
+        description="Functions that pull data from external sources.",
+    )
+    circular_references: list[list[str]] = Field(
+        default_factory=list,
+        description="Groups of A1 cells whose formulas depend on each other.",
+    )
+
+
+class FormulaBlock(BaseModel):
+    """A rectangle of cells filled with one copied formula."""
+
+    range: str = Field(description="Covered cells in A1 notation (e.g., C2:C500).")
+    r1c1: str = Field(description="Shared formula in R1C1 notation.")
+    formula: str = Field(description="A1 formula of the top-left cell.")
+
+
+class ColumnAnomaly(BaseModel):
+    """A suspicious cell found while profiling a table column."""
+
+    kind: Literal["outlier", "type_mismatch", "future_date"] = Field(
+        description="Anomaly type."
+    )
+    cell: str = Field(description="Cell address in A1 notation.")
+    value: CellValue = Field(description="Cell value.")
+    message: str = Field(description="Human-readable explanation.")
+
+
+class ColumnStats(BaseModel):
+    """Profile of one column of a table candidate (rows below the header)."""
+
+    column: str = Field(description="Column letter (e.g., C).")
+    header: str | None = Field(
+        default=None, description="Header text from the first table row."
+    )
+    count: int = Field(description="Non-empty cells.")
+    null_rate: float = Field(description="Share of empty cells (0.0-1.0).")
+    distinct_count: int = Field(description="Distinct non-empty values.")
+    min: float | None = Field(
+        default=None, description="Smallest numeric value (None without numbers)."
+    )
+    max: float | None = Field(
+        default=None, description="Largest numeric value (None without numbers)."
+    )
+    mean: float | None = Field(
+        default=None, description="Mean of numeric values (None without numbers)."
+    )
+    anomalies: list[ColumnAnomaly] = Field(
+        default_factory=list,
+        description="Outliers, type mismatches, and future dates, in row order.",
+    )
+    unit: str | None = Field(
+        default=None, description="Unit in trailing header brackets (e.g., kg)."
+    )
+    currency: str | None = Field(
+        default=None,
+        description="ISO 4217 code from the header unit or the number format.",
+    )
+
+
+class RowSample(BaseModel):
+    """Marks a sheet whose rows were sampled rather than fully extracted."""
+
+    strategy: Literal["head", "tail", "random"] = Field(
+        description="How rows were chosen."
+    )
+    n: int = Field(description="Requested rows per sheet.")
+    seed: int | None = Field(
+        default=None, description="Random seed (random strategy only)."
+    )
+    total_rows: int = Field(description="Rows on the sheet before sampling.")
+    sampled_rows: int = Field(description="Rows kept in SheetData.rows.")
+
+
+class SheetTruncation(BaseModel):
+    """Marks a sheet clipped by max_rows_per_sheet / max_cols_per_sheet."""
+
+    truncated: bool = Field(default=True, description="Always true.")
+    original_rows: int = Field(
+        description="Last row (1-based) holding a value before clipping."
+    )
+    original_cols: int = Field(
+        description="Columns from A to the last column holding a value before "
+        "clipping."
+    )
+    max_rows: int | None = Field(
+        default=None, description="Row limit applied (None = not clipped by rows)."
+    )
+    max_cols: int | None = Field(
+        default=None,
+        description="Column limit applied (None = not clipped by columns).",
+    )
+
+
 class SheetData(BaseModel):
     """Structured data for a single sheet."""
 
@@ -205,6 +757,11 @@ class SheetData(BaseModel):
     auto_print_areas: list[PrintArea] = Field(
         default_factory=list, description="COM-computed auto page-break areas."
     )
+    named_ranges: list[NamedRange] = Field(
+        default_factory=list,
+        description="Defined names covering ranges of this sheet "
+        "(only with include_named_ranges).",
+    )
     formulas_map: dict[str, list[tuple[int, int]]] = Field(
         default_factory=dict,
         description=(
@@ -212,6 +769,20 @@ class SheetData(BaseModel):
             "where row is 1-based and column is 0-based."
         ),
     )
+    formulas_map_r1c1: dict[str, list[tuple[int, int]]] = Field(
+        default_factory=dict,
+        description=(
+            "formulas_map regrouped by R1C1 formula text, so copies of one "
+            "formula share a key (only with include_formulas_r1c1)."
+        ),
+    )
+    formula_blocks: list[FormulaBlock] = Field(
+        default_factory=list,
+        description=(
+            "Copied formulas compressed to one pattern per filled range; their "
+            "cells are left out of formulas_map (only with compress_formulas)."
+        ),
+    )
     colors_map: dict[str, list[tuple[int, int]]] = Field(
         default_factory=dict,
         description=(
@@ -219,6 +790,14 @@ class SheetData(BaseModel):
             "where row is 1-based and column is 0-based."
         ),
     )
+    style_map: dict[str, list[tuple[int, int]]] = Field(
+        default_factory=dict,
+        description=(
+            "Mapping of cell format IDs (indexes into WorkbookData.styles."
+            "cell_formats) to (row, column) tuples; cells with the default "
+            "format 0 are omitted (only with include_styles)."
+        ),
+    )
     merged_cells: MergedCells | None = Field(
         default=None, description="Merged cell ranges on the sheet."
     )
@@ -229,6 +808,134 @@ class SheetData(BaseModel):
             "Used in alpha_col-oriented output."
         ),
     )
+    column_widths: dict[str, float] = Field(
+        default_factory=dict,
+        description=(
+            "Explicit column widths in Excel character units keyed by 0-based "
+            "column index; hidden columns are 0.0."
+        ),
+    )
+    row_heights: dict[str, float] = Field(
+        default_factory=dict,
+        description=(
+            "Explicit row heights in points keyed by 1-based row index; "
+            "hidden rows are 0.0."
+        ),
+    )
+    default_column_width: float | None = Field(
+        default=None,
+        description="Default column width in Excel character units.",
+    )
+    default_row_height: float | None = Field(
+        default=None, description="Default row height in points."
+    )
+    max_digit_width: int | None = Field(
+        default=None,
+        description=(
+            "Maximum digit width in pixels of the workbook default font; the "
+            "pixel size of one column width character unit."
+        ),
+    )
+    flowcharts: list[Flowchart] = Field(
+        default_factory=list,
+        description="Flowcharts reconstructed from shapes and connectors.",
+    )
+    shape_overlaps: list[ShapeOverlap] = Field(
+        default_factory=list,
+        description="Overlapping shape pairs (requires shape sizes).",
+    )
+    errors: list[CellError] = Field(
+        default_factory=list,
+        description="Cells holding error values, in row-major order.",
+    )
+    outline: SheetOutline | None = Field(
+        default=None,
+        description="Row/column outline groups (None when the sheet has none).",
+    )
+    content_hash: str | None = Field(
+        default=None,
+        description="SHA-256 of cell values and positions (None for empty sheets).",
+    )
+    table_hashes: dict[str, str] = Field(
+        default_factory=dict,
+        description=(
+            "SHA-256 per table candidate, independent of table position and "
+            "row order."
+        ),
+    )
+    table_scores: dict[str, float] = Field(
+        default_factory=dict,
+        description="Confidence (0.0-1.0) per table candidate; candidates are "
+        "sorted by it (only with rank_tables).",
+    )
+    table_stats: dict[str, list[ColumnStats]] = Field(
+        default_factory=dict,
+        description="Per-column statistics of each table candidate "
+        "(only with include_table_stats).",
+    )
+    formula_audit: FormulaAudit | None = Field(
+        default=None,
+        description="Volatile/external functions and circular references "
+        "(requires formulas).",
+    )
+    sample: RowSample | None = Field(
+        default=None,
+        description="Set when rows were sampled (only with sample_rows); rows "
+        "then hold a subset of the sheet.",
+    )
+    truncation: SheetTruncation | None = Field(
+        default=None,
+        description="Set when the sheet was clipped by max_rows_per_sheet or "
+        "max_cols_per_sheet; holds the original extents.",
+    )
+    extensions: dict[str, JsonValue] = Field(
+        default_factory=dict,
+        description="Output of registered custom extractors, keyed by name.",
+    )
+
+    def cell(self, address: str) -> CellValue | None:
+        """Return the value of one cell.
+
+        Args:
+            address: A1-style address (e.g. ``"B3"``); ``$`` markers and a
+                ``Sheet!`` prefix are ignored. Works with numeric and alpha
+                column keys.
+
+        Returns:
+            Cell value, or None if the cell was not extracted (empty).
+
+        Raises:
+            ValueError: If the address is not a single cell.
+        """
+        row, col = _parse_cell_address(address)
+        for cell_row in self.rows:
+            if cell_row.r == row:
+                return _row_values(cell_row).get(col)
+        return None
+
+    def cell_range(self, ref: str) -> list[list[CellValue | None]]:
+        """Return the values of a rectangular range, row by row.
+
+        Args:
+            ref: A1-style range (e.g. ``"A1:C5"``) or single cell.
+
+        Returns:
+            One list per row of the range with one entry per column; cells that
+            were not extracted are None.
+
+        Raises:
+            ValueError: If the range is not valid.
+        """
+        start, _, end = ref.partition(":")
+        r1, c1 = _parse_cell_address(start)
+        r2, c2 = _parse_cell_address(end) if end else (r1, c1)
+        r1, r2 = sorted((r1, r2))
+        c1, c2 = sorted((c1, c2))
+        values = {row.r: _row_values(row) for row in self.rows if r1 <= row.r <= r2}
+        return [
+            [values.get(row, {}).get(col) for col in range(c1, c2 + 1)]
+            for row in range(r1, r2 + 1)
+        ]
 
     def _as_payload(
         self, *, include_backend_metadata: bool = False
@@ -333,6 +1040,335 @@ class SheetData(BaseModel):
         return dest
 
 
+class ChartRef(BaseModel):
+    """Locator of a chart within a workbook."""
+
+    sheet: str = Field(description="Sheet hosting the chart.")
+    chart: str = Field(description="Chart name.")
+
+
+class ChartSourceRef(BaseModel):
+    """A range read by one chart series."""
+
+    sheet: str = Field(description="Sheet hosting the chart.")
+    chart: str = Field(description="Chart name.")
+    series: str = Field(description="Series display name.")
+    role: Literal["name", "categories", "values"] = Field(
+        description="What the series reads from the range."
+    )
+    source_sheet: str | None = Field(
+        default=None,
+        description="Sheet the range lives on (None for unqualified references).",
+    )
+    range: str = Field(description="Referenced range without the sheet prefix.")
+    table_candidate: str | None = Field(
+        default=None,
+        description="Table candidate on the source sheet that the range intersects.",
+    )
+
+
+class TableChartConsumers(BaseModel):
+    """Charts that read from a table candidate."""
+
+    sheet: str = Field(description="Sheet owning the table candidate.")
+    table_candidate: str = Field(description="Table candidate range.")
+    charts: list[ChartRef] = Field(
+        default_factory=list, description="Charts reading from the table."
+    )
+
+
+class ChartSourceIndex(BaseModel):
+    """Workbook-level index of which ranges charts read."""
+
+    sources: list[ChartSourceRef] = Field(
+        default_factory=list, description="Ranges referenced by chart series."
+    )
+    table_consumers: list[TableChartConsumers] = Field(
+        default_factory=list,
+        description="Table candidates with the charts that read them.",
+    )
+
+
+class TableFamilyMember(BaseModel):
+    """Table candidate belonging to a table family."""
+
+    sheet: str = Field(description="Sheet name.")
+    table: str = Field(description="Table candidate range (A1 notation).")
+
+
+class TableFamily(BaseModel):
+    """Tables on different sheets that share an identical header row."""
+
+    headers: list[str] = Field(
+        description="Shared header texts, left to right (empty cells as '')."
+    )
+    members: list[TableFamilyMember] = Field(
+        description="Tables of the family in sheet order."
+    )
+
+
+class PivotCache(BaseModel):
+    """Source records kept in a pivot table cache."""
+
+    cache_id: int = Field(description="Workbook cacheId of the pivot cache.")
+    source_sheet: str | None = Field(
+        default=None, description="Sheet named by the cache source."
+    )
+    source_range: str | None = Field(
+        default=None, description="Source range in A1 notation (e.g., A1:D120)."
+    )
+    source_name: str | None = Field(
+        default=None, description="Defined name or table used as the source."
+    )
+    source_external: bool = Field(
+        default=False, description="Whether the source is another workbook or a query."
+    )
+    source_missing: bool = Field(
+        default=False, description="Whether the source is gone from the workbook."
+    )
+    columns: list[str] = Field(
+        default_factory=list, description="Cache field names, left to right."
+    )
+    records: list[list[CellValue | None]] = Field(
+        default_factory=list, description="Cached source rows, one value per column."
+    )
+
+
+class PowerQuery(BaseModel):
+    """Power Query (Get & Transform) query defined in the workbook."""
+
+    name: str = Field(description="Query name as shown in the Queries pane.")
+    formula: str = Field(description="M expression of the query (let ... in ...).")
+
+
+ConnectionKind = Literal[
+    "odbc", "dao", "file", "web", "oledb", "text", "ado", "dsp", "other"
+]
+
+
+class ConnectionBinding(BaseModel):
+    """Query table or pivot table that reads from a data connection."""
+
+    kind: Literal["query_table", "pivot_table"] = Field(
+        description="Object refreshed from the connection."
+    )
+    sheet: str = Field(description="Sheet holding the object.")
+    name: str | None = Field(default=None, description="Table or pivot table name.")
+    range: str | None = Field(
+        default=None, description="Range of the object in A1 notation."
+    )
+
+
+class DataConnection(BaseModel):
+    """External data connection from xl/connections.xml."""
+
+    id: int = Field(description="Connection id referenced by query tables/caches.")
+    name: str = Field(description="Connection name.")
+    description: str | None = Field(default=None, description="Connection description.")
+    kind: ConnectionKind = Field(description="Source type (ODBC, OLEDB, web, ...).")
+    connection_string: str | None = Field(
+        default=None, description="Connection string with credentials masked."
+    )
+    command: str | None = Field(
+        default=None, description="Command text (SQL, table, or query name)."
+    )
+    url: str | None = Field(
+        default=None, description="Web query URL with user info masked."
+    )
+    source_file: str | None = Field(
+        default=None, description="Source file of text/file connections."
+    )
+    refresh_on_load: bool = Field(
+        default=False, description="Whether data refreshes when the file opens."
+    )
+    background_refresh: bool = Field(
+        default=False, description="Whether refresh runs in the background."
+    )
+    refresh_interval: int | None = Field(
+        default=None, description="Automatic refresh interval in minutes."
+    )
+    bindings: list[ConnectionBinding] = Field(
+        default_factory=list, description="Sheets/tables bound to the connection."
+    )
+
+
+class DigitalSignature(BaseModel):
+    """XML digital signature on the package and the state of its signed parts."""
+
+    part: str = Field(description="Signature part (e.g., _xmlsignatures/sig1.xml).")
+    signer: str | None = Field(
+        default=None, description="Certificate subject (e.g., CN=Jane Doe, O=Acme)."
+    )
+    issuer: str | None = Field(default=None, description="Certificate issuer.")
+    serial: str | None = Field(
+        default=None, description="Certificate serial number (hex)."
+    )
+    valid_from: str | None = Field(
+        default=None, description="Certificate validity start (ISO 8601)."
+    )
+    valid_to: str | None = Field(
+        default=None, description="Certificate validity end (ISO 8601)."
+    )
+    signed_at: str | None = Field(
+        default=None, description="Signing time claimed by the signature."
+    )
+    comment: str | None = Field(
+        default=None, description="Purpose entered when signing."
+    )
+    signed_parts: list[str] = Field(
+        default_factory=list, description="Parts covered by the signature."
+    )
+    modified_parts: list[str] = Field(
+        default_factory=list,
+        description="Signed parts whose digest changed or that were removed.",
+    )
+    unverified_parts: list[str] = Field(
+        default_factory=list,
+        description="Signed parts whose digest could not be recomputed "
+        "(relationship transforms, unknown algorithms).",
+    )
+    intact: bool = Field(
+        default=True, description="Whether no signed part was modified."
+    )
+
+
+class SecurityReport(BaseModel):
+    """Security-related findings about the workbook package."""
+
+    signed: bool = Field(default=False, description="Whether the package is signed.")
+    signatures: list[DigitalSignature] = Field(
+        default_factory=list, description="Digital signatures on the package."
+    )
+    unsigned_parts: list[str] = Field(
+        default_factory=list,
+        description="Parts of a signed package that no signature covers.",
+    )
+
+
+class StyleFont(BaseModel):
+    """Font entry of the workbook style table."""
+
+    name: str | None = Field(default=None, description="Font family name.")
+    size: float | None = Field(default=None, description="Font size in points.")
+    bold: bool = Field(default=False, description="Bold weight.")
+    italic: bool = Field(default=False, description="Italic style.")
+    underline: str | None = Field(
+        default=None, description="Underline style (single, double, ...)."
+    )
+    strike: bool = Field(default=False, description="Strikethrough.")
+    color: str | None = Field(
+        default=None,
+        description="Font color (hex RGB, or theme:<n>[:<tint>] / indexed:<n>).",
+    )
+
+
+class StyleFill(BaseModel):
+    """Fill entry of the workbook style table."""
+
+    pattern: str | None = Field(
+        default=None, description="Pattern type (solid, gray125, ...); None = none."
+    )
+    fg_color: str | None = Field(
+        default=None, description="Pattern foreground color (the solid fill color)."
+    )
+    bg_color: str | None = Field(default=None, description="Pattern background color.")
+
+
+class BorderSide(BaseModel):
+    """One edge of a border entry."""
+
+    style: str = Field(description="Line style (thin, medium, dashed, double, ...).")
+    color: str | None = Field(default=None, description="Line color.")
+
+
+class StyleBorder(BaseModel):
+    """Border entry of the workbook style table; missing edges are not drawn."""
+
+    left: BorderSide | None = Field(default=None, description="Left edge.")
+    right: BorderSide | None = Field(default=None, description="Right edge.")
+    top: BorderSide | None = Field(default=None, description="Top edge.")
+    bottom: BorderSide | None = Field(default=None, description="Bottom edge.")
+    diagonal: BorderSide | None = Field(default=None, description="Diagonal line.")
+
+
+class NumberFormat(BaseModel):
+    """Number format referenced by the workbook's cell formats."""
+
+    id: int = Field(description="numFmtId (0-163 are built in).")
+    code: str = Field(description="Format code (e.g., '#,##0.00').")
+
+
+class CellFormat(BaseModel):
+    """Cell format (``cellXfs`` entry) combining style table entries by index."""
+
+    font_id: int = Field(default=0, description="Index into StyleTable.fonts.")
+    fill_id: int = Field(default=0, description="Index into StyleTable.fills.")
+    border_id: int = Field(default=0, description="Index into StyleTable.borders.")
+    num_fmt_id: int = Field(
+        default=0, description="NumberFormat.id (0 = General)."
+    )
+    horizontal: str | None = Field(
+        default=None, description="Horizontal alignment (left, center, ...)."
+    )
+    vertical: str | None = Field(
+        default=None, description="Vertical alignment (top, center, ...)."
+    )
+    wrap_text: bool = Field(default=False, description="Whether text wraps.")
+
+
+class StyleTable(BaseModel):
+    """Workbook style table; ``SheetData.style_map`` keys index ``cell_formats``."""
+
+    default_font: StyleFont | None = Field(
+        default=None,
+        description="Font of the Normal style, which sets column width units.",
+    )
+    fonts: list[StyleFont] = Field(default_factory=list, description="Fonts.")
+    fills: list[StyleFill] = Field(default_factory=list, description="Fills.")
+    borders: list[StyleBorder] = Field(default_factory=list, description="Borders.")
+    number_formats: list[NumberFormat] = Field(
+        default_factory=list,
+        description="Custom and built-in number formats used by cell_formats.",
+    )
+    cell_formats: list[CellFormat] = Field(
+        default_factory=list, description="Cell formats in cellXfs order."
+    )
+
+
+class SheetMetrics(BaseModel):
+    """Parse time, package size, and output counts of one sheet."""
+
+    parse_seconds: float = Field(
+        default=0.0,
+        description="Time to stream-parse the worksheet part (0 for .xls).",
+    )
+    part_bytes: int = Field(
+        default=0, description="Uncompressed size of the worksheet XML part."
+    )
+    related_bytes: int = Field(
+        default=0,
+        description="Uncompressed size of the parts the sheet references "
+        "(drawings, charts, images, comments, tables).",
+    )
+    rows: int = Field(default=0, description="Extracted rows.")
+    cells: int = Field(default=0, description="Extracted non-empty cells.")
+    shapes: int = Field(default=0, description="Extracted shapes.")
+    charts: int = Field(default=0, description="Extracted charts.")
+    table_candidates: int = Field(default=0, description="Table candidates.")
+
+
+class ExtractionMetrics(BaseModel):
+    """Extraction timing and size metrics for capacity planning."""
+
+    extract_seconds: float = Field(
+        description="Wall time of the whole extraction, all sheets included."
+    )
+    file_bytes: int = Field(description="Size of the workbook file.")
+    sheets: dict[str, SheetMetrics] = Field(
+        default_factory=dict, description="Metrics per sheet name."
+    )
+
+
 class WorkbookData(BaseModel):
     """Workbook-level container with per-sheet data."""
 
@@ -340,6 +1376,45 @@ class WorkbookData(BaseModel):
     sheets: dict[str, SheetData] = Field(
         description="Mapping of sheet name to SheetData."
     )
+    chart_sources: ChartSourceIndex | None = Field(
+        default=None,
+        description="Chart-to-range cross-reference index (None without charts).",
+    )
+    table_families: list[TableFamily] = Field(
+        default_factory=list,
+        description="Tables with identical headers across sheets (e.g., monthly tabs).",
+    )
+    warnings: list[str] = Field(
+        default_factory=list,
+        description="Parts skipped by best-effort extraction of a corrupted file.",
+    )
+    pivot_caches: list[PivotCache] = Field(
+        default_factory=list,
+        description="Pivot cache records whose source data no longer exists "
+        "(only with include_pivot_caches).",
+    )
+    power_queries: list[PowerQuery] = Field(
+        default_factory=list,
+        description="Power Query names and M code (only with include_power_queries).",
+    )
+    connections: list[DataConnection] = Field(
+        default_factory=list,
+        description="External data connections (only with include_connections).",
+    )
+    security: SecurityReport | None = Field(
+        default=None,
+        description="Signature/integrity report (only with include_security_report).",
+    )
+    styles: StyleTable | None = Field(
+        default=None,
+        description="Fonts, fills, borders, and number formats referenced by "
+        "SheetData.style_map (only with include_styles).",
+    )
+    metrics: ExtractionMetrics | None = Field(
+        default=None,
+        description="Extraction timing and per-sheet size metrics "
+        "(only with include_metrics).",
+    )
 
     def to_json(
         self,
@@ -434,6 +1509,89 @@ class WorkbookData(BaseModel):
         yield from self.sheets.items()
 
 
+class SheetSummary(BaseModel):
+    """Size and content counts for a single sheet."""
+
+    name: str = Field(description="Sheet name.")
+    used_range: str | None = Field(
+        default=None, description="A1 range spanning non-empty cells (None if empty)."
+    )
+    row_count: int = Field(default=0, description="Rows in the used range.")
+    column_count: int = Field(default=0, description="Columns in the used range.")
+    non_empty_cells: int = Field(
+        default=0, description="Cells holding a value or formula."
+    )
+    formula_count: int = Field(default=0, description="Cells holding a formula.")
+    shape_count: int = Field(default=0, description="Drawing objects except charts.")
+    chart_count: int = Field(default=0, description="Embedded charts.")
+    table_count: int = Field(default=0, description="Excel tables (ListObjects).")
+    part_size: int = Field(
+        default=0, description="Uncompressed size of the sheet XML part in bytes."
+    )
+
+
+class WorkbookSummary(BaseModel):
+    """Lightweight workbook overview read from the package without extraction."""
+
+    book_name: str = Field(description="Workbook file name.")
+    file_size: int = Field(description="Workbook file size in bytes.")
+    sheets: list[SheetSummary] = Field(
+        default_factory=list, description="Per-sheet summaries in workbook order."
+    )
+    part_sizes: dict[str, int] = Field(
+        default_factory=dict,
+        description="Uncompressed size in bytes of each package part.",
+    )
+
+
+class CatalogEntry(BaseModel):
+    """Inventory row for one workbook in a catalog."""
+
+    path: str = Field(description="Workbook path relative to the catalog root.")
+    file_size: int = Field(default=0, description="Workbook file size in bytes.")
+    sheets: list[str] = Field(
+        default_factory=list, description="Sheet names in workbook order."
+    )
+    non_empty_cells: int = Field(default=0, description="Non-empty cells in total.")
+    formula_count: int = Field(default=0, description="Formula cells in total.")
+    shape_count: int = Field(default=0, description="Shapes in total.")
+    chart_count: int = Field(default=0, description="Charts in total.")
+    table_count: int = Field(default=0, description="Excel tables in total.")
+    features: list[str] = Field(
+        default_factory=list,
+        description="Package features in use (e.g., macros, pivot_tables).",
+    )
+    error: str | None = Field(
+        default=None, description="Why the workbook could not be summarized."
+    )
+
+
+class WorkbookCatalog(BaseModel):
+    """Inventory of workbooks found under a directory."""
+
+    root: str = Field(description="Directory that was scanned.")
+    entries: list[CatalogEntry] = Field(
+        default_factory=list, description="One entry per workbook, sorted by path."
+    )
+
+
+class SearchHit(BaseModel):
+    """One text match found by a workbook search."""
+
+    sheet: str = Field(description="Sheet name.")
+    kind: Literal["cell", "comment", "shape", "chart"] = Field(
+        description="Where the match was found."
+    )
+    location: str = Field(
+        description=(
+            "Cell address for cells and comments, covered range (or shape id) "
+            "for shapes, chart name for charts."
+        )
+    )
+    match: str = Field(description="Matched text.")
+    context: str = Field(description="Text around the match, clipped with '...'.")
+
+
 class PrintAreaView(BaseModel):
     """Slice of a sheet restricted to a print area (manual or auto)."""
 
@@ -566,6 +1724,32 @@ class PrintAreaView(BaseModel):
 # ---------------------------------------------------------------------------
 
 
+def col_alpha_to_index(name: str) -> int:
+    """Convert an Excel-style column name to a 0-based column index.
+
+    Args:
+        name: Column name (case-insensitive), e.g. ``"A"`` or ``"AA"``.
+
+    Returns:
+        0-based column index.
+
+    Raises:
+        ValueError: If the name is not a column name.
+
+    Examples:
+        >>> col_alpha_to_index("A")
+        0
+        >>> col_alpha_to_index("AA")
+        26
+    """
+    if not name or not name.isascii() or not name.isalpha():
+        raise ValueError(f"Invalid column name: {name!r}")
+    index = 0
+    for char in name.upper():
+        index = index * 26 + ord(char) - 64
+    return index - 1
+
+
 def col_index_to_alpha(index: int) -> str:
     """Convert a 0-based column index to an Excel-style column name.
 
@@ -619,12 +1803,20 @@ def convert_row_keys_to_alpha(row: CellRow) -> CellRow:
             row.links, row_index=row.r, field_name="links"
         )
 
-    return CellRow(r=row.r, c=new_c, links=new_links)
+    new_phonetic: dict[str, str] | None = None
+    if row.phonetic:
+        new_phonetic = _convert_mapping_keys_to_alpha(
+            row.phonetic, row_index=row.r, field_name="phonetic"
+        )
+
+    return CellRow(r=row.r, c=new_c, links=new_links, phonetic=new_phonetic)
 
 
 def convert_sheet_keys_to_alpha(sheet: SheetData) -> SheetData:
     """Return a new SheetData with all CellRow column keys converted to ABC-style.
 
+    Column width keys are converted as well.
+
     Args:
         sheet: Original SheetData.
 
@@ -638,6 +1830,10 @@ def convert_sheet_keys_to_alpha(sheet: SheetData) -> SheetData:
             sheet.merged_cells.items
         )
         updated_fields["merged_cells"] = None
+    if sheet.column_widths:
+        updated_fields["column_widths"] = {
+            _alpha_key(key): width for key, width in sheet.column_widths.items()
+        }
     return sheet.model_copy(update=updated_fields)
 
 
@@ -657,6 +1853,214 @@ def convert_workbook_keys_to_alpha(workbook: WorkbookData) -> WorkbookData:
     return workbook.model_copy(update={"sheets": new_sheets})
 
 
+# ---------------------------------------------------------------------------
+# Combining extractions
+# ---------------------------------------------------------------------------
+
+MergeConflict = Literal["rename", "append", "replace", "keep", "error"]
+
+
+def merge_workbooks(
+    *workbooks: WorkbookData,
+    on_conflict: MergeConflict = "rename",
+    book_name: str | None = None,
+) -> WorkbookData:
+    """Combine several extracted workbooks into one.
+
+    Sheets keep their order (workbook by workbook). When a sheet name is
+    already taken, ``on_conflict`` decides: ``rename`` adds a ``" (2)"``,
+    ``" (3)"``, ... suffix, ``append`` appends the rows with ``append_sheet``,
+    ``replace`` keeps the later sheet, ``keep`` the earlier one, and ``error``
+    raises. Warnings are kept, prefixed with their book name. Cross-sheet
+    indexes (``chart_sources``, ``table_families``) are not carried over;
+    rebuild them from the merged sheets if needed.
+
+    Args:
+        *workbooks: Workbooks to combine, in order.
+        on_conflict: Policy for duplicate sheet names.
+        book_name: Name of the result (default: the first workbook's name).
+
+    Returns:
+        New workbook; the inputs are not modified.
+
+    Raises:
+        ValueError: If no workbook is given, or a sheet name is duplicated
+            with ``on_conflict="error"``.
+    """
+    if not workbooks:
+        raise ValueError("merge_workbooks requires at least one workbook.")
+    sheets: dict[str, SheetData] = {}
+    warnings: list[str] = []
+    for workbook in workbooks:
+        for name, sheet in workbook.sheets.items():
+            if name not in sheets or on_conflict == "replace":
+                sheets[name] = sheet
+            elif on_conflict == "rename":
+                sheets[_unique_sheet_name(name, sheets)] = sheet
+            elif on_conflict == "append":
+                sheets[name] = append_sheet(sheets[name], sheet)
+            elif on_conflict == "error":
+                raise ValueError(
+                    f"Duplicate sheet name {name!r} in {workbook.book_name!r}."
+                )
+        warnings.extend(f"{workbook.book_name}: {item}" for item in workbook.warnings)
+    return WorkbookData(
+        book_name=book_name or workbooks[0].book_name,
+        sheets=sheets,
+        warnings=warnings,
+    )
+
+
+def append_sheet(
+    first: SheetData, second: SheetData, *, skip_rows: int = 0
+) -> SheetData:
+    """Append the rows of one sheet below another.
+
+    The rows of ``second`` are renumbered to start right after the last row of
+    ``first`` and its table candidates are shifted with them. Other fields
+    (shapes, charts, maps, ...) are taken from ``first`` because their
+    positions do not carry over.
+
+    Args:
+        first: Sheet to append to.
+        second: Sheet whose rows are appended.
+        skip_rows: Number of leading rows of ``second`` to drop, e.g. ``1`` for
+            a repeated header row.
+
+    Returns:
+        New sheet; the inputs are not modified.
+    """
+    rows = second.rows[skip_rows:]
+    if not rows:
+        return first.model_copy()
+    last_row = max((row.r for row in first.rows), default=0)
+    offset = last_row + 1 - rows[0].r if first.rows else 0
+    appended = [row.model_copy(update={"r": row.r + offset}) for row in rows]
+    candidates = [
+        shifted
+        for ref in second.table_candidates
+        if (shifted := _shift_range_rows(ref, offset, rows[0].r + offset))
+    ]
+    return first.model_copy(
+        update={
+            "rows": [*first.rows, *appended],
+            "table_candidates": [*first.table_candidates, *candidates],
+        }
+    )
+
+
+def _unique_sheet_name(name: str, taken: dict[str, SheetData]) -> str:
+    """Return ``name (n)`` with the smallest n >= 2 that is not taken."""
+    index = 2
+    while f"{name} ({index})" in taken:
+        index += 1
+    return f"{name} ({index})"
+
+
+def _shift_range_rows(ref: str, offset: int, min_row: int) -> str | None:
+    """Move an A1 range by ``offset`` rows, clipping it to ``min_row``."""
+    start, _, end = ref.partition(":")
+    try:
+        r1, c1 = _parse_cell_address(start)
+        r2, c2 = _parse_cell_address(end) if end else (r1, c1)
+    except ValueError:
+        return None
+    if r2 + offset < min_row:
+        return None
+    top_left = f"{col_index_to_alpha(c1)}{max(r1 + offset, min_row)}"
+    if not end:
+        return top_left
+    return f"{top_left}:{col_index_to_alpha(c2)}{r2 + offset}"
+
+
+# ---------------------------------------------------------------------------
+# Typed cell value accessors
+# ---------------------------------------------------------------------------
+
+_CELL_ADDRESS = re.compile(r"\$?([A-Za-z]{1,3})\$?([1-9][0-9]*)")
+# Serial 0 of the 1900 date system for serials from 61 on; earlier serials are
+# one day later because Excel counts the fictitious 1900-02-29 (serial 60).
+_EXCEL_EPOCH = datetime(1899, 12, 30)
+
+
+def as_float(value: CellValue | None) -> float | None:
+    """Read a cell value as a number.
+
+    Args:
+        value: Cell value, e.g. from ``SheetData.cell``.
+
+    Returns:
+        The number for int/float values and for numeric text (surrounding
+        whitespace and ``,`` thousands separators allowed), else None.
+
+    Examples:
+        >>> as_float("1,234.5")
+        1234.5
+        >>> as_float("n/a") is None
+        True
+    """
+    if value is None:
+        return None
+    if isinstance(value, int | float):
+        return float(value)
+    try:
+        return float(value.strip().replace(",", ""))
+    except ValueError:
+        return None
+
+
+def as_datetime(value: CellValue | None) -> datetime | None:
+    """Read a cell value as a date/time.
+
+    Args:
+        value: Cell value, e.g. from ``SheetData.cell``.
+
+    Returns:
+        The parsed value for ISO 8601 text (as extracted for date cells, e.g.
+        ``"2024-05-31"`` or ``"2024-05-31T09:30:00"``), the date for an Excel
+        serial number (1900 date system), else None.
+
+    Examples:
+        >>> as_datetime("2024-05-31")
+        datetime.datetime(2024, 5, 31, 0, 0)
+        >>> as_datetime(45443)
+        datetime.datetime(2024, 5, 31, 0, 0)
+    """
+    if value is None:
+        return None
+    if isinstance(value, int | float):
+        if value < 0:
+            return None
+        return _EXCEL_EPOCH + timedelta(days=value if value >= 61 else value + 1)
+    try:
+        return datetime.fromisoformat(value.strip())
+    except ValueError:
+        return None
+
+
+def _parse_cell_address(address: str) -> tuple[int, int]:
+    """Parse an A1 address into (1-based row, 0-based column)."""
+    text = address.rsplit("!", 1)[-1].strip()
+    match = _CELL_ADDRESS.fullmatch(text)
+    if match is None:
+        raise ValueError(f"Invalid cell address: {address!r}")
+    return int(match.group(2)), col_alpha_to_index(match.group(1))
+
+
+def _row_values(row: CellRow) -> dict[int, CellValue]:
+    """Key a row's values by 0-based column, accepting numeric or alpha keys."""
+    values: dict[int, CellValue] = {}
+    for key, value in row.c.items():
+        if key.isdigit():
+            values[int(key)] = value
+        else:
+            try:
+                values[col_alpha_to_index(key)] = value
+            except ValueError:
+                continue
+    return values
+
+
 def _alpha_key(key: str) -> str:
     """Convert a numeric string key to alpha, passing non-numeric keys through."""
     try:
@@ -2,7 +2,12 @@ from __future__ import annotations
 
 """Shared JSON-compatible type aliases used across ExStruct."""
 
+from typing import Literal
+
 JsonPrimitive = str | int | float | bool | None
 JsonStructure = JsonPrimitive | list["JsonStructure"] | dict[str, "JsonStructure"]
 
-__all__ = ["JsonPrimitive", "JsonStructure"]
+# Unit for shape/chart positions and sizes (see ``exstruct.ooxml.units``).
+PositionUnit = Literal["pixels", "points", "emu", "millimeters"]
+
+__all__ = ["JsonPrimitive", "JsonStructure", "PositionUnit"]
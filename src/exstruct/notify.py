@@ -0,0 +1,121 @@
+"""Run completion notifications (CLI ``--notify-url``).
+
+When a run finishes, a JSON summary is POSTed to a webhook so orchestration
+systems can react without polling for output files. The payload lists every
+processed file with its status and error, the failure count, and where the
+output was written. Single-file extraction runs send ``run.finished``; a
+``catalog`` scan sends one aggregated ``catalog.finished`` summary covering
+every workbook it visited, ``batch`` sends ``batch.finished`` for all its
+workbooks, and ``watch`` sends ``watch.finished`` after each scan that
+extracted workbooks.
+"""
+
+from __future__ import annotations
+
+from collections.abc import Sequence
+from datetime import UTC, datetime
+import json
+import logging
+from typing import TYPE_CHECKING
+from urllib.parse import urlsplit
+import urllib.request
+
+from .errors import OutputError
+
+if TYPE_CHECKING:  # pragma: no cover - typing only
+    from .engine import RunReport
+
+logger = logging.getLogger(__name__)
+
+DEFAULT_NOTIFY_TIMEOUT_SECONDS = 10.0
+_USER_AGENT = "exstruct-notify"
+
+
+def build_run_summary(
+    reports: Sequence[RunReport],
+    outputs: Sequence[str],
+    *,
+    event: str = "run.finished",
+) -> dict[str, object]:
+    """Build the notification payload for a finished run.
+
+    Args:
+        reports: One report per processed file.
+        outputs: Output locations (files, directories, or object URIs).
+        event: Event name (``run.finished``, ``catalog.finished``,
+            ``batch.finished``, or ``watch.finished``).
+
+    Returns:
+        JSON-compatible summary with ``event``, ``status``, ``files_processed``,
+        ``failures``, ``files``, ``outputs``, and ``finished_at``.
+    """
+    failures = sum(1 for report in reports if report.status == "error")
+    if failures:
+        status = "error"
+    elif any(report.status == "partial" for report in reports):
+        status = "partial"
+    else:
+        status = "ok"
+    return {
+        "event": event,
+        "status": status,
+        "files_processed": len(reports),
+        "failures": failures,
+        "files": [
+            {
+                "file": report.file,
+                "status": report.status,
+                "exit_code": report.exit_code,
+                "error": report.error,
+                "counts": report.counts,
+                "duration_seconds": report.durations.get("total"),
+            }
+            for report in reports
+        ],
+        "outputs": list(outputs),
+        "finished_at": datetime.now(UTC).isoformat(),
+    }
+
+
+def post_run_summary(
+    url: str,
+    summary: dict[str, object],
+    *,
+    timeout: float = DEFAULT_NOTIFY_TIMEOUT_SECONDS,
+) -> int:
+    """POST a run summary as JSON to a webhook.
+
+    Args:
+        url: ``http://`` or ``https://`` webhook URL.
+        summary: Payload from ``build_run_summary``.
+        timeout: Seconds to wait for the webhook.
+
+    Returns:
+        HTTP status code of the response.
+
+    Raises:
+        ValueError: If the URL is not http(s).
+        OutputError: If the request fails or the webhook returns an error.
+    """
+    if urlsplit(url).scheme not in {"http", "https"}:
+        raise ValueError(f"Notification URL must be http(s): {url}")
+    request = urllib.request.Request(
+        url,
+        data=json.dumps(summary, ensure_ascii=False).encode("utf-8"),
+        headers={"Content-Type": "application/json", "User-Agent": _USER_AGENT},
+        method="POST",
+    )
+    try:
+        with urllib.request.urlopen(request, timeout=timeout) as response:  # noqa: S310
+            status = int(response.status)
+    except Exception as exc:
+        raise OutputError(f"Failed to send notification to '{url}'.") from exc
+    logger.info("Sent run notification to %s (HTTP %d)", url, status)
+    return status
+
+
+__all__ = [
+    "DEFAULT_NOTIFY_TIMEOUT_SECONDS",
+    "build_run_summary",
+    "post_run_summary",
+]
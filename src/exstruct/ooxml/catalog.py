@@ -0,0 +1,167 @@
+"""Inventory of many workbooks built from per-file package summaries."""
+
+from __future__ import annotations
+
+from collections.abc import Collection, Iterable, Iterator
+import csv
+import io
+from pathlib import Path
+
+from exstruct.models import CatalogEntry, WorkbookCatalog, WorkbookSummary
+from exstruct.ooxml.summary import summarize_workbook_ooxml
+
+CATALOG_SUFFIXES = frozenset({".xlsx", ".xlsm"})
+CATALOG_CSV_COLUMNS = (
+    "path",
+    "file_size",
+    "sheets",
+    "non_empty_cells",
+    "formula_count",
+    "shape_count",
+    "chart_count",
+    "table_count",
+    "features",
+    "error",
+)
+
+# Package part prefixes that mark a feature, checked against every part name.
+_PART_FEATURES: tuple[tuple[str, str], ...] = (
+    ("xl/vbaProject.bin", "macros"),
+    ("xl/pivotTables/", "pivot_tables"),
+    ("xl/externalLinks/", "external_links"),
+    ("xl/connections.xml", "data_connections"),
+    ("xl/queryTables/", "query_tables"),
+    ("xl/slicers/", "slicers"),
+    ("xl/comments", "comments"),
+    ("xl/threadedComments/", "comments"),
+    ("xl/media/", "images"),
+    ("customXml/", "custom_xml"),
+)
+
+
+def iter_catalog_files(
+    root: Path,
+    *,
+    recursive: bool = True,
+    suffixes: Collection[str] = CATALOG_SUFFIXES,
+) -> Iterator[Path]:
+    """Yield workbook files under a directory in sorted order.
+
+    Excel lock files (``~$book.xlsx``) are skipped.
+
+    Args:
+        root: Directory to scan.
+        recursive: Whether to descend into subdirectories.
+        suffixes: Lower-case file suffixes to yield.
+
+    Yields:
+        Paths of .xlsx/.xlsm files (or of ``suffixes``).
+    """
+    candidates = root.rglob("*") if recursive else root.glob("*")
+    for path in sorted(candidates):
+        if (
+            path.is_file()
+            and path.suffix.lower() in suffixes
+            and not path.name.startswith("~$")
+        ):
+            yield path
+
+
+def detect_package_features(summary: WorkbookSummary) -> list[str]:
+    """List the features a workbook uses, from its summary counts and parts.
+
+    Args:
+        summary: Workbook summary.
+
+    Returns:
+        Sorted feature names.
+    """
+    features: set[str] = set()
+    if any(sheet.formula_count for sheet in summary.sheets):
+        features.add("formulas")
+    if any(sheet.chart_count for sheet in summary.sheets):
+        features.add("charts")
+    if any(sheet.shape_count for sheet in summary.sheets):
+        features.add("shapes")
+    if any(sheet.table_count for sheet in summary.sheets):
+        features.add("tables")
+    for part_name in summary.part_sizes:
+        for prefix, feature in _PART_FEATURES:
+            if part_name.startswith(prefix):
+                features.add(feature)
+    return sorted(features)
+
+
+def build_workbook_catalog(root: Path, *, recursive: bool = True) -> WorkbookCatalog:
+    """Summarize every workbook under a directory.
+
+    Files that cannot be read are kept with ``error`` set instead of aborting
+    the scan.
+
+    Args:
+        root: Directory to scan.
+        recursive: Whether to descend into subdirectories.
+
+    Returns:
+        WorkbookCatalog with one entry per workbook.
+    """
+    entries = [
+        _catalog_entry(root, path)
+        for path in iter_catalog_files(root, recursive=recursive)
+    ]
+    return WorkbookCatalog(root=str(root), entries=entries)
+
+
+def _catalog_entry(root: Path, path: Path) -> CatalogEntry:
+    """Build the catalog entry for one workbook."""
+    relative = path.relative_to(root).as_posix()
+    try:
+        summary = summarize_workbook_ooxml(path)
+    except Exception as exc:
+        return CatalogEntry(
+            path=relative, file_size=path.stat().st_size, error=repr(exc)
+        )
+    sheets = summary.sheets
+    return CatalogEntry(
+        path=relative,
+        file_size=summary.file_size,
+        sheets=[sheet.name for sheet in sheets],
+        non_empty_cells=sum(sheet.non_empty_cells for sheet in sheets),
+        formula_count=sum(sheet.formula_count for sheet in sheets),
+        shape_count=sum(sheet.shape_count for sheet in sheets),
+        chart_count=sum(sheet.chart_count for sheet in sheets),
+        table_count=sum(sheet.table_count for sheet in sheets),
+        features=detect_package_features(summary),
+    )
+
+
+def catalog_to_csv(entries: Iterable[CatalogEntry]) -> str:
+    """Render catalog entries as CSV with one row per workbook.
+
+    List columns (``sheets``, ``features``) are joined with ``|``.
+
+    Args:
+        entries: Catalog entries.
+
+    Returns:
+        CSV text including a header row.
+    """
+    buffer = io.StringIO()
+    writer = csv.writer(buffer, lineterminator="\n")
+    writer.writerow(CATALOG_CSV_COLUMNS)
+    for entry in entries:
+        writer.writerow(
+            [
+                entry.path,
+                entry.file_size,
+                "|".join(entry.sheets),
+                entry.non_empty_cells,
+                entry.formula_count,
+                entry.shape_count,
+                entry.chart_count,
+                entry.table_count,
+                "|".join(entry.features),
+                entry.error or "",
+            ]
+        )
+    return buffer.getvalue()
@@ -12,8 +12,21 @@ from typing import TYPE_CHECKING, Literal
 from xml.etree import ElementTree as ET
 from zipfile import ZipFile
 
-from exstruct.models import Chart, ChartSeries
-from exstruct.ooxml.units import emu_to_pixels
+from exstruct.models import (
+    Chart,
+    ChartAxis,
+    ChartDataLabels,
+    ChartErrorBars,
+    ChartLegend,
+    ChartMarker,
+    ChartSeries,
+    ChartTextBox,
+    ChartTrendline,
+)
+from exstruct.ooxml.compat import resolve_alternate_content
+from exstruct.ooxml.safety import open_package, parse_xml, read_part
+from exstruct.ooxml.units import PositionScale
+from exstruct.sources import source_exists
 
 if TYPE_CHECKING:
     from xml.etree.ElementTree import Element
@@ -47,6 +60,7 @@ NS = {
     "a": "http://schemas.openxmlformats.org/drawingml/2006/main",
     "r": "http://schemas.openxmlformats.org/officeDocument/2006/relationships",
     "xdr": "http://schemas.openxmlformats.org/drawingml/2006/spreadsheetDrawing",
+    "cdr": "http://schemas.openxmlformats.org/drawingml/2006/chartDrawing",
 }
 
 # Mapping from OOXML chart element tags to chart type names
@@ -69,6 +83,483 @@ CHART_TYPE_MAP: dict[str, str] = {
     "ofPieChart": "PieOfPie",
 }
 
+# Chart tags whose series carry cached categories and values in the output
+PIE_CHART_TAGS: frozenset[str] = frozenset(
+    {"pieChart", "pie3DChart", "doughnutChart", "ofPieChart"}
+)
+
+ChartGrouping = Literal["clustered", "stacked", "percent_stacked", "standard"]
+BarDirection = Literal["vertical", "horizontal"]
+DisplayBlanksAs = Literal["gap", "zero", "span"]
+MarkerSymbol = Literal[
+    "auto",
+    "none",
+    "circle",
+    "dash",
+    "diamond",
+    "dot",
+    "picture",
+    "plus",
+    "square",
+    "star",
+    "triangle",
+    "x",
+]
+ScatterStyle = Literal[
+    "line", "line_marker", "marker", "none", "smooth", "smooth_marker"
+]
+
+# Chart tags that carry a c:grouping element, with their default grouping
+_GROUPED_CHART_TAGS: dict[str, ChartGrouping] = {
+    "barChart": "clustered",
+    "bar3DChart": "clustered",
+    "lineChart": "standard",
+    "line3DChart": "standard",
+    "areaChart": "standard",
+    "area3DChart": "standard",
+}
+
+# Mapping from OOXML grouping values to grouping labels
+GROUPING_MAP: dict[str, ChartGrouping] = {
+    "clustered": "clustered",
+    "stacked": "stacked",
+    "percentStacked": "percent_stacked",
+    "standard": "standard",
+}
+
+# Chart type label suffix for each grouping (matches XL_CHART_TYPE_MAP names)
+_GROUPING_SUFFIX: dict[ChartGrouping, str] = {
+    "clustered": "Clustered",
+    "stacked": "Stacked",
+    "percent_stacked": "Stacked100",
+    "standard": "",
+}
+
+# Mapping from OOXML trendlineType values to trendline types
+TRENDLINE_TYPE_MAP: dict[
+    str,
+    Literal[
+        "linear", "exponential", "logarithmic", "polynomial", "power", "moving_average"
+    ],
+] = {
+    "linear": "linear",
+    "exp": "exponential",
+    "log": "logarithmic",
+    "poly": "polynomial",
+    "power": "power",
+    "movingAvg": "moving_average",
+}
+
+# Mapping from OOXML errValType values to error amount types
+ERROR_VALUE_TYPE_MAP: dict[
+    str, Literal["fixed", "percentage", "std_dev", "std_err", "custom"]
+] = {
+    "fixedVal": "fixed",
+    "percentage": "percentage",
+    "stdDev": "std_dev",
+    "stdErr": "std_err",
+    "cust": "custom",
+}
+
+# Mapping from OOXML errDir / errBarType values to error bar settings
+ERROR_DIRECTION_MAP: dict[str, Literal["x", "y"]] = {"x": "x", "y": "y"}
+ERROR_BAR_TYPE_MAP: dict[str, Literal["both", "plus", "minus"]] = {
+    "both": "both",
+    "plus": "plus",
+    "minus": "minus",
+}
+
+# Mapping from OOXML legendPos values to legend positions
+LEGEND_POSITION_MAP: dict[
+    str, Literal["right", "left", "top", "bottom", "corner", "custom"]
+] = {
+    "r": "right",
+    "l": "left",
+    "t": "top",
+    "b": "bottom",
+    "tr": "corner",
+}
+
+# Mapping from OOXML dispBlanksAs values to empty cell plotting
+DISPLAY_BLANKS_AS_MAP: dict[str, DisplayBlanksAs] = {
+    "gap": "gap",
+    "zero": "zero",
+    "span": "span",
+}
+
+# Mapping from OOXML marker symbol values to marker symbols
+MARKER_SYMBOL_MAP: dict[str, MarkerSymbol] = {
+    "auto": "auto",
+    "none": "none",
+    "circle": "circle",
+    "dash": "dash",
+    "diamond": "diamond",
+    "dot": "dot",
+    "picture": "picture",
+    "plus": "plus",
+    "square": "square",
+    "star": "star",
+    "triangle": "triangle",
+    "x": "x",
+}
+
+# Mapping from OOXML scatterStyle values to scatter styles
+SCATTER_STYLE_MAP: dict[str, ScatterStyle] = {
+    "line": "line",
+    "lineMarker": "line_marker",
+    "marker": "marker",
+    "none": "none",
+    "smooth": "smooth",
+    "smoothMarker": "smooth_marker",
+}
+
+# Excel's row limit; larger ptCount/idx values only come from malformed caches.
+MAX_CACHE_POINTS = 1_048_576
+
+
+def parse_chart_grouping(
+    type_elem: Element,
+) -> tuple[ChartGrouping | None, BarDirection | None]:
+    """Extract series grouping and bar direction from a chart type element.
+
+    Args:
+        type_elem: Chart type element such as c:barChart.
+
+    Returns:
+        Tuple of (grouping, bar_direction); None where not applicable.
+    """
+    tag = type_elem.tag.split("}")[-1]
+    default = _GROUPED_CHART_TAGS.get(tag)
+    if default is None:
+        return (None, None)
+    grouping_elem = type_elem.find("c:grouping", NS)
+    grouping = default
+    if grouping_elem is not None:
+        grouping = GROUPING_MAP.get(grouping_elem.get("val", ""), default)
+    bar_direction: BarDirection | None = None
+    if tag in ("barChart", "bar3DChart"):
+        bar_dir = type_elem.find("c:barDir", NS)
+        is_bar = bar_dir is not None and bar_dir.get("val") == "bar"
+        bar_direction = "horizontal" if is_bar else "vertical"
+    return (grouping, bar_direction)
+
+
+def grouped_chart_type(
+    tag: str, grouping: ChartGrouping | None, bar_direction: BarDirection | None
+) -> str | None:
+    """Build an XL_CHART_TYPE_MAP-style label for grouped chart types.
+
+    Args:
+        tag: Chart type element tag (e.g. ``"barChart"``).
+        grouping: Series grouping from :func:`parse_chart_grouping`.
+        bar_direction: Bar direction from :func:`parse_chart_grouping`.
+
+    Returns:
+        Label such as ``"ColumnStacked100"``, or None for other chart types.
+    """
+    if grouping is None:
+        return None
+    prefix = "3D" if "3D" in tag else ""
+    if tag in ("barChart", "bar3DChart"):
+        base = "Bar" if bar_direction == "horizontal" else "Column"
+        if grouping == "standard":
+            # Only 3D columns support the standard (true 3D) grouping.
+            return f"{prefix}{base}" if prefix else f"{base}Clustered"
+        return f"{prefix}{base}{_GROUPING_SUFFIX[grouping]}"
+    if tag in ("lineChart", "line3DChart"):
+        return "3DLine" if prefix else f"Line{_GROUPING_SUFFIX[grouping]}"
+    if tag in ("areaChart", "area3DChart"):
+        return f"{prefix}Area{_GROUPING_SUFFIX[grouping]}"
+    return None
+
+
+def _bool_child(parent: Element, tag: str) -> bool:
+    """Read a CT_Boolean child element (missing val means true).
+
+    Args:
+        parent: Element containing the boolean child.
+        tag: Child tag such as ``"c:showVal"``.
+
+    Returns:
+        Boolean value; False when the child is absent.
+    """
+    elem = parent.find(tag, NS)
+    if elem is None:
+        return False
+    return elem.get("val", "1") in ("1", "true")
+
+
+def parse_blank_cell_settings(
+    chart_elem: Element,
+) -> tuple[bool | None, DisplayBlanksAs | None]:
+    """Extract how hidden and empty cells are plotted.
+
+    Args:
+        chart_elem: c:chart element.
+
+    Returns:
+        Tuple of (plot_visible_only, display_blanks_as); None for settings
+        the chart does not specify.
+    """
+    plot_vis_only = (
+        _bool_child(chart_elem, "c:plotVisOnly")
+        if chart_elem.find("c:plotVisOnly", NS) is not None
+        else None
+    )
+    disp_blanks_as = chart_elem.find("c:dispBlanksAs", NS)
+    display_blanks_as = (
+        DISPLAY_BLANKS_AS_MAP.get(disp_blanks_as.get("val", "zero"))
+        if disp_blanks_as is not None
+        else None
+    )
+    return (plot_vis_only, display_blanks_as)
+
+
+def parse_legend(chart_elem: Element) -> ChartLegend:
+    """Extract legend visibility and position from a chart element.
+
+    Args:
+        chart_elem: c:chart element.
+
+    Returns:
+        ChartLegend model (not visible when the chart has no legend).
+    """
+    legend = chart_elem.find("c:legend", NS)
+    if legend is None:
+        return ChartLegend(visible=False)
+    if legend.find("c:layout/c:manualLayout", NS) is not None:
+        return ChartLegend(visible=True, position="custom")
+    pos_elem = legend.find("c:legendPos", NS)
+    pos = pos_elem.get("val", "r") if pos_elem is not None else "r"
+    return ChartLegend(visible=True, position=LEGEND_POSITION_MAP.get(pos))
+
+
+def _int_child(parent: Element, tag: str) -> int | None:
+    """Read an integer ``val`` attribute from a child element."""
+    elem = parent.find(tag, NS)
+    if elem is None:
+        return None
+    try:
+        return int(elem.get("val", ""))
+    except ValueError:
+        return None
+
+
+def parse_trendlines(ser_elem: Element) -> list[ChartTrendline]:
+    """Extract trendline settings from a series element.
+
+    Args:
+        ser_elem: c:ser element.
+
+    Returns:
+        Trendlines in document order; unknown types are skipped.
+    """
+    trendlines: list[ChartTrendline] = []
+    for trendline in ser_elem.findall("c:trendline", NS):
+        type_elem = trendline.find("c:trendlineType", NS)
+        if type_elem is None:
+            continue
+        trend_type = TRENDLINE_TYPE_MAP.get(type_elem.get("val", "linear"))
+        if trend_type is None:
+            continue
+        name_elem = trendline.find("c:name", NS)
+        trendlines.append(
+            ChartTrendline(
+                type=trend_type,
+                name=name_elem.text if name_elem is not None else None,
+                order=_int_child(trendline, "c:order")
+                if trend_type == "polynomial"
+                else None,
+                period=_int_child(trendline, "c:period")
+                if trend_type == "moving_average"
+                else None,
+                display_equation=_bool_child(trendline, "c:dispEq"),
+                display_r_squared=_bool_child(trendline, "c:dispRSqr"),
+            )
+        )
+    return trendlines
+
+
+def parse_series_style(ser_elem: Element) -> tuple[ChartMarker | None, bool | None]:
+    """Extract marker and line smoothing settings from a series element.
+
+    Args:
+        ser_elem: c:ser element.
+
+    Returns:
+        Tuple of (marker, smooth); None for settings the series does not specify.
+    """
+    marker: ChartMarker | None = None
+    marker_elem = ser_elem.find("c:marker", NS)
+    if marker_elem is not None:
+        symbol_elem = marker_elem.find("c:symbol", NS)
+        marker = ChartMarker(
+            symbol=MARKER_SYMBOL_MAP.get(symbol_elem.get("val", ""))
+            if symbol_elem is not None
+            else None,
+            size=_int_child(marker_elem, "c:size"),
+        )
+    smooth = (
+        _bool_child(ser_elem, "c:smooth")
+        if ser_elem.find("c:smooth", NS) is not None
+        else None
+    )
+    return (marker, smooth)
+
+
+def parse_scatter_style(plot_area: Element) -> ScatterStyle | None:
+    """Extract the scatter style of the first scatter chart in a plot area.
+
+    Args:
+        plot_area: c:plotArea element.
+
+    Returns:
+        Scatter style, or None when the plot area has no scatter chart.
+    """
+    style_elem = plot_area.find("c:scatterChart/c:scatterStyle", NS)
+    if style_elem is None:
+        return None
+    return SCATTER_STYLE_MAP.get(style_elem.get("val", "marker"))
+
+
+def parse_error_bars(ser_elem: Element) -> list[ChartErrorBars]:
+    """Extract error bar settings from a series element.
+
+    Args:
+        ser_elem: c:ser element.
+
+    Returns:
+        Error bars in document order (scatter series may have both x and y).
+    """
+    error_bars: list[ChartErrorBars] = []
+    for err_bars in ser_elem.findall("c:errBars", NS):
+        dir_elem = err_bars.find("c:errDir", NS)
+        type_elem = err_bars.find("c:errBarType", NS)
+        val_type_elem = err_bars.find("c:errValType", NS)
+        value_elem = err_bars.find("c:val", NS)
+        value: float | None = None
+        if value_elem is not None:
+            try:
+                value = float(value_elem.get("val", ""))
+            except ValueError:
+                value = None
+        direction = dir_elem.get("val", "") if dir_elem is not None else ""
+        bar_type = type_elem.get("val", "both") if type_elem is not None else "both"
+        val_type = (
+            val_type_elem.get("val", "fixedVal")
+            if val_type_elem is not None
+            else "fixedVal"
+        )
+        error_bars.append(
+            ChartErrorBars(
+                direction=ERROR_DIRECTION_MAP.get(direction),
+                bar_type=ERROR_BAR_TYPE_MAP.get(bar_type, "both"),
+                value_type=ERROR_VALUE_TYPE_MAP.get(val_type),
+                value=value,
+            )
+        )
+    return error_bars
+
+
+def _read_cache_points(cache: Element) -> list[str | None]:
+    """Read c:pt values of a str/num cache, filling gaps up to ptCount."""
+    points: dict[int, str] = {}
+    for pt in cache.findall("c:pt", NS):
+        v_elem = pt.find("c:v", NS)
+        try:
+            idx = int(pt.get("idx", ""))
+        except ValueError:
+            continue
+        if not 0 <= idx < MAX_CACHE_POINTS:
+            continue
+        if v_elem is not None and v_elem.text is not None:
+            points[idx] = v_elem.text
+    count = _int_child(cache, "c:ptCount")
+    if count is None:
+        count = max(points) + 1 if points else 0
+    count = min(count, MAX_CACHE_POINTS)
+    return [points.get(idx) for idx in range(count)]
+
+
+def _find_cache(parent: Element | None, paths: tuple[str, ...]) -> Element | None:
+    """Return the first cache element found under a series data element."""
+    if parent is None:
+        return None
+    for path in paths:
+        cache = parent.find(path, NS)
+        if cache is not None:
+            return cache
+    return None
+
+
+def parse_series_cache(
+    ser_elem: Element,
+) -> tuple[list[str | None] | None, list[float | None] | None]:
+    """Extract cached category labels and values from a series element.
+
+    The caches keep the last displayed data, so they remain available when
+    the source range is on another or deleted sheet.
+
+    Args:
+        ser_elem: c:ser element.
+
+    Returns:
+        Tuple of (categories, values); None when the series has no cache.
+    """
+    cat_cache = _find_cache(
+        ser_elem.find("c:cat", NS),
+        (
+            "c:strRef/c:strCache",
+            "c:numRef/c:numCache",
+            "c:multiLvlStrRef/c:multiLvlStrCache/c:lvl",
+            "c:strLit",
+            "c:numLit",
+        ),
+    )
+    val_cache = _find_cache(
+        ser_elem.find("c:val", NS), ("c:numRef/c:numCache", "c:numLit")
+    )
+    categories = _read_cache_points(cat_cache) if cat_cache is not None else None
+    values: list[float | None] | None = None
+    if val_cache is not None:
+        values = []
+        for raw in _read_cache_points(val_cache):
+            try:
+                values.append(float(raw) if raw is not None else None)
+            except ValueError:
+                values.append(None)
+    return (categories, values)
+
+
+def parse_data_labels(dlbls: Element | None) -> ChartDataLabels | None:
+    """Extract data label settings from a c:dLbls element.
+
+    Args:
+        dlbls: c:dLbls element of a series or chart group.
+
+    Returns:
+        ChartDataLabels model, or None when no label content is shown.
+    """
+    if dlbls is None or _bool_child(dlbls, "c:delete"):
+        return None
+    labels = ChartDataLabels(
+        show_value=_bool_child(dlbls, "c:showVal"),
+        show_percentage=_bool_child(dlbls, "c:showPercent"),
+        show_category_name=_bool_child(dlbls, "c:showCatName"),
+        show_series_name=_bool_child(dlbls, "c:showSerName"),
+    )
+    if not (
+        labels.show_value
+        or labels.show_percentage
+        or labels.show_category_name
+        or labels.show_series_name
+    ):
+        return None
+    num_fmt = dlbls.find("c:numFmt", NS)
+    if num_fmt is not None:
+        labels.number_format = num_fmt.get("formatCode")
+    return labels
+
 
 def _get_chart_title(chart_elem: Element) -> str | None:
     """Extract chart title from chart element.
@@ -96,6 +587,22 @@ def _get_chart_title(chart_elem: Element) -> str | None:
     return None
 
 
+def _find_chart_type_elem(plot_area: Element) -> tuple[str, Element] | None:
+    """Find the primary chart type element in a plot area.
+
+    Args:
+        plot_area: c:plotArea element.
+
+    Returns:
+        Tuple of (tag, element) or None if no known chart type is present.
+    """
+    for tag in CHART_TYPE_MAP:
+        elem = plot_area.find(f"c:{tag}", NS)
+        if elem is not None:
+            return (tag, elem)
+    return None
+
+
 def _get_chart_type(plot_area: Element) -> str:
     """Determine chart type from plot area element.
 
@@ -105,10 +612,12 @@ def _get_chart_type(plot_area: Element) -> str:
     Returns:
         Chart type name.
     """
-    for tag, type_name in CHART_TYPE_MAP.items():
-        if plot_area.find(f"c:{tag}", NS) is not None:
-            return type_name
-    return "unknown"
+    found = _find_chart_type_elem(plot_area)
+    if found is None:
+        return "unknown"
+    tag, elem = found
+    grouping, bar_direction = parse_chart_grouping(elem)
+    return grouped_chart_type(tag, grouping, bar_direction) or CHART_TYPE_MAP[tag]
 
 
 def _extract_series_name(ser_elem: Element) -> tuple[str, str | None]:
@@ -165,24 +674,57 @@ def _extract_range_from_ref(parent: Element | None, ref_types: list[str]) -> str
     return None
 
 
-def _get_series_data(ser_elem: Element) -> ChartSeries:
+def _get_series_data(
+    ser_elem: Element,
+    group_dlbls: Element | None = None,
+    *,
+    include_cache: bool = False,
+) -> ChartSeries:
     """Extract series data from series element.
 
     Args:
         ser_elem: c:ser element.
+        group_dlbls: Chart-group c:dLbls used when the series has none.
+        include_cache: Whether to include cached categories and values.
 
     Returns:
         ChartSeries model.
     """
     name, name_range = _extract_series_name(ser_elem)
-    x_range = _extract_range_from_ref(ser_elem.find("c:cat", NS), ["c:strRef", "c:numRef"])
-    y_range = _extract_range_from_ref(ser_elem.find("c:val", NS), ["c:numRef"])
+    # Scatter and bubble series use c:xVal/c:yVal instead of c:cat/c:val
+    x_elem = ser_elem.find("c:cat", NS)
+    if x_elem is None:
+        x_elem = ser_elem.find("c:xVal", NS)
+    y_elem = ser_elem.find("c:val", NS)
+    if y_elem is None:
+        y_elem = ser_elem.find("c:yVal", NS)
+    x_range = _extract_range_from_ref(x_elem, ["c:strRef", "c:numRef"])
+    y_range = _extract_range_from_ref(y_elem, ["c:numRef"])
+    size_range = _extract_range_from_ref(
+        ser_elem.find("c:bubbleSize", NS), ["c:numRef"]
+    )
+    marker, smooth = parse_series_style(ser_elem)
+
+    ser_dlbls = ser_elem.find("c:dLbls", NS)
+    categories, values = (
+        parse_series_cache(ser_elem) if include_cache else (None, None)
+    )
 
     return ChartSeries(
         name=name,
         name_range=name_range,
         x_range=x_range,
         y_range=y_range,
+        size_range=size_range,
+        marker=marker,
+        smooth=smooth,
+        categories=categories,
+        values=values,
+        data_labels=parse_data_labels(
+            ser_dlbls if ser_dlbls is not None else group_dlbls
+        ),
+        trendlines=parse_trendlines(ser_elem),
+        error_bars=parse_error_bars(ser_elem),
     )
 
 
@@ -199,7 +741,18 @@ def _get_axis_range(plot_area: Element, axis_type: str) -> list[float]:
     axis = plot_area.find(f"c:{axis_type}", NS)
     if axis is None:
         return []
+    return _get_axis_elem_range(axis)
+
+
+def _get_axis_elem_range(axis: Element) -> list[float]:
+    """Extract min/max range from an axis element.
+
+    Args:
+        axis: Axis element (c:valAx, c:catAx, ...).
 
+    Returns:
+        List of [min, max] or empty list.
+    """
     scaling = axis.find("c:scaling", NS)
     if scaling is None:
         return []
@@ -240,7 +793,18 @@ def _get_axis_title(plot_area: Element, axis_type: str) -> str:
     axis = plot_area.find(f"c:{axis_type}", NS)
     if axis is None:
         return ""
+    return _get_axis_elem_title(axis)
+
 
+def _get_axis_elem_title(axis: Element) -> str:
+    """Extract the title text of an axis element.
+
+    Args:
+        axis: Axis element (c:valAx, c:catAx, ...).
+
+    Returns:
+        Axis title or empty string.
+    """
     title = axis.find("c:title", NS)
     if title is None:
         return ""
@@ -252,6 +816,61 @@ def _get_axis_title(plot_area: Element, axis_type: str) -> str:
     return ""
 
 
+def parse_value_axes(plot_area: Element) -> list[ChartAxis]:
+    """Extract the primary and secondary value axes of a plot area.
+
+    Each chart group lists its axis ids as (category/X axis, value/Y axis);
+    the value axis of the first group is primary, any other is secondary.
+
+    Args:
+        plot_area: c:plotArea element.
+
+    Returns:
+        Value axes, primary first.
+    """
+    axes_by_id: dict[str, Element] = {}
+    for axis in plot_area.findall("c:valAx", NS):
+        ax_id = axis.find("c:axId", NS)
+        if ax_id is not None:
+            axes_by_id[ax_id.get("val", "")] = axis
+
+    value_axis_ids: list[str] = []
+    for group in plot_area:
+        if group.tag.split("}")[-1] not in CHART_TYPE_MAP:
+            continue
+        group_ax_ids = [elem.get("val", "") for elem in group.findall("c:axId", NS)]
+        if len(group_ax_ids) >= 2 and group_ax_ids[1] not in value_axis_ids:
+            value_axis_ids.append(group_ax_ids[1])
+
+    result: list[ChartAxis] = []
+    for index, ax_id in enumerate(value_axis_ids):
+        axis = axes_by_id.get(ax_id)
+        if axis is None:
+            continue
+        num_fmt = axis.find("c:numFmt", NS)
+        log_base = axis.find("c:scaling/c:logBase", NS)
+        log_value: float | None = None
+        if log_base is not None:
+            try:
+                log_value = float(log_base.get("val", ""))
+            except ValueError:
+                log_value = None
+        result.append(
+            ChartAxis(
+                secondary=index > 0,
+                title=_get_axis_elem_title(axis),
+                range=_get_axis_elem_range(axis),
+                number_format=num_fmt.get("formatCode")
+                if num_fmt is not None
+                else None,
+                log_base=log_value,
+            )
+        )
+        if len(result) == 2:
+            break
+    return result
+
+
 def _parse_chart_xml(
     chart_xml: bytes, chart_name: str, left: int, top: int, width: int, height: int
 ) -> Chart | None:
@@ -269,7 +888,7 @@ def _parse_chart_xml(
         Chart model or None on error.
     """
     try:
-        root = ET.fromstring(chart_xml)
+        root = parse_xml(chart_xml)
     except ET.ParseError as e:
         logger.warning("Failed to parse chart XML: %s", e)
         return None
@@ -284,6 +903,10 @@ def _parse_chart_xml(
 
     # Get chart type
     chart_type = _get_chart_type(plot_area)
+    found = _find_chart_type_elem(plot_area)
+    grouping, bar_direction = (
+        parse_chart_grouping(found[1]) if found is not None else (None, None)
+    )
 
     # Get title
     title = _get_chart_title(chart_elem)
@@ -293,14 +916,19 @@ def _parse_chart_xml(
     for chart_type_elem in plot_area:
         tag = chart_type_elem.tag.split("}")[-1] if "}" in chart_type_elem.tag else chart_type_elem.tag
         if tag in CHART_TYPE_MAP:
+            group_dlbls = chart_type_elem.find("c:dLbls", NS)
             for ser in chart_type_elem.findall("c:ser", NS):
-                series = _get_series_data(ser)
+                series = _get_series_data(
+                    ser, group_dlbls, include_cache=tag in PIE_CHART_TAGS
+                )
                 series_list.append(series)
 
     # Get Y axis info
     y_axis_title = _get_axis_title(plot_area, "valAx")
     y_axis_range = _get_axis_range(plot_area, "valAx")
 
+    plot_visible_only, display_blanks_as = parse_blank_cell_settings(chart_elem)
+
     return Chart(
         name=chart_name,
         chart_type=chart_type,
@@ -310,19 +938,119 @@ def _parse_chart_xml(
         w=width,
         h=height,
         series=series_list,
+        legend=parse_legend(chart_elem),
+        grouping=grouping,
+        bar_direction=bar_direction,
+        value_axes=parse_value_axes(plot_area),
+        plot_visible_only=plot_visible_only,
+        display_blanks_as=display_blanks_as,
+        scatter_style=parse_scatter_style(plot_area),
         l=left,
         t=top,
     )
 
 
+_CDR_NS = NS["cdr"]
+_CDR_ANCHOR_TAGS = frozenset(
+    {f"{{{_CDR_NS}}}relSizeAnchor", f"{{{_CDR_NS}}}absSizeAnchor"}
+)
+_CDR_SP = f"{{{_CDR_NS}}}sp"
+_A_T = f"{{{NS['a']}}}t"
+_RELS_NS = {"": "http://schemas.openxmlformats.org/package/2006/relationships"}
+
+
+def _anchor_fraction(anchor: Element, axis: str) -> float | None:
+    """Return the ``cdr:from`` x or y of a chart drawing anchor."""
+    value = anchor.findtext(f"cdr:from/cdr:{axis}", namespaces=NS)
+    if value is None:
+        return None
+    try:
+        return float(value)
+    except ValueError:
+        return None
+
+
+def parse_chart_text_boxes(user_shapes_xml: bytes) -> list[ChartTextBox]:
+    """Parse the text of shapes in a chart drawing (``c:userShapes``) part.
+
+    Args:
+        user_shapes_xml: Raw chart drawing XML content.
+
+    Returns:
+        One entry per text-bearing shape (grouped shapes included), in
+        document order; positions are fractions of the chart area.
+    """
+    try:
+        root = parse_xml(user_shapes_xml)
+    except ET.ParseError as e:
+        logger.warning("Failed to parse chart drawing XML: %s", e)
+        return []
+    resolve_alternate_content(root)
+    boxes: list[ChartTextBox] = []
+    for anchor in root:
+        if anchor.tag not in _CDR_ANCHOR_TAGS:
+            continue
+        x = _anchor_fraction(anchor, "x")
+        y = _anchor_fraction(anchor, "y")
+        for sp in anchor.iter(_CDR_SP):
+            text = "".join(t.text for t in sp.iter(_A_T) if t.text).strip()
+            if text:
+                boxes.append(ChartTextBox(text=text, x=x, y=y))
+    return boxes
+
+
+def _load_chart_text_boxes(zf: ZipFile, chart_path: str) -> list[ChartTextBox]:
+    """Read the text boxes of the chart drawing part a chart references.
+
+    Args:
+        zf: Open xlsx package.
+        chart_path: Chart part path within the zip.
+
+    Returns:
+        Text boxes in the chart, empty when it has no chart drawing part.
+    """
+    chart_dir, _, chart_file = chart_path.rpartition("/")
+    try:
+        rels_root = parse_xml(read_part(zf, f"{chart_dir}/_rels/{chart_file}.rels"))
+    except (KeyError, ET.ParseError):
+        return []
+    for rel in rels_root.findall("Relationship", _RELS_NS):
+        if rel.get("Type", "").endswith("/chartUserShapes"):
+            target = _resolve_relative_path(rel.get("Target", ""), chart_dir)
+            try:
+                return parse_chart_text_boxes(read_part(zf, target))
+            except KeyError:
+                logger.debug("Chart drawing not found: %s", target)
+    return []
+
+
+_XDR_NS = NS["xdr"]
+# Charts can sit in any anchor kind, directly or inside a group shape.
+_ANCHOR_TAGS = frozenset(
+    {
+        f"{{{_XDR_NS}}}twoCellAnchor",
+        f"{{{_XDR_NS}}}oneCellAnchor",
+        f"{{{_XDR_NS}}}absoluteAnchor",
+    }
+)
+# Excel defaults (8.43-character columns = 64 px, 15pt rows = 20 px at 96 DPI)
+# in EMU, used to place cell-anchored charts whose graphicFrame carries no
+# transform.
+_DEFAULT_COLUMN_WIDTH_EMU = 609600
+_DEFAULT_ROW_HEIGHT_EMU = 190500
+# 400 x 300 px at 96 DPI
+_FALLBACK_CHART_SIZE_EMU = (3810000, 2857500)
+
+
 def _get_chart_positions_from_drawing(
-    zf: ZipFile, drawing_path: str
+    zf: ZipFile, drawing_path: str, scale: PositionScale = PositionScale()
 ) -> dict[str, tuple[str, int, int, int, int]]:
     """Extract chart positions from drawing XML.
 
     Args:
         zf: Open ZipFile.
         drawing_path: Path to drawing XML within zip.
+        scale: Output unit for positions and sizes.
 
     Returns:
         Dict mapping chart rId to (chart_path, left, top, width, height).
@@ -330,78 +1058,132 @@ def _get_chart_positions_from_drawing(
     result: dict[str, tuple[str, int, int, int, int]] = {}
 
     try:
-        drawing_xml = zf.read(drawing_path)
-        root = ET.fromstring(drawing_xml)
+        drawing_xml = read_part(zf, drawing_path)
+        root = parse_xml(drawing_xml)
     except (KeyError, ET.ParseError):
         return result
+    resolve_alternate_content(root)
+
+    for anchor in root:
+        if anchor.tag not in _ANCHOR_TAGS:
+            continue
+        for graphic_frame in anchor.iter(f"{{{_XDR_NS}}}graphicFrame"):
+            chart_ref = graphic_frame.find(f".//{{{NS['c']}}}chart")
+            if chart_ref is None:
+                continue
+            r_id = chart_ref.get(f"{{{NS['r']}}}id")
+            if not r_id:
+                continue
+            cnv_pr = graphic_frame.find(f".//{{{_XDR_NS}}}cNvPr")
+            chart_name = (
+                cnv_pr.get("name", f"Chart_{r_id}")
+                if cnv_pr is not None
+                else f"Chart_{r_id}"
+            )
+            box = (
+                _get_frame_box(graphic_frame, scale)
+                or _get_anchor_box(anchor, scale)
+                or _get_fallback_box(scale)
+            )
+            result[r_id] = (chart_name, *box)
 
-    # Support both prefixed (xdr:) and default namespace anchors
-    xdr_ns = "http://schemas.openxmlformats.org/drawingml/2006/spreadsheetDrawing"
-    c_ns = "http://schemas.openxmlformats.org/drawingml/2006/chart"
-    r_ns = "http://schemas.openxmlformats.org/officeDocument/2006/relationships"
+    return result
 
-    # Find all anchor elements (twoCellAnchor and oneCellAnchor)
-    anchors = (
-        root.findall(f".//{{{xdr_ns}}}twoCellAnchor")
-        + root.findall(f".//{{{xdr_ns}}}oneCellAnchor")
-    )
 
-    for anchor in anchors:
-        graphic_frame = anchor.find(f"{{{xdr_ns}}}graphicFrame")
-        if graphic_frame is None:
-            continue
+def _get_frame_box(
+    graphic_frame: Element, scale: PositionScale
+) -> tuple[int, int, int, int] | None:
+    """Return the graphicFrame's xfrm box in ``scale`` units, or None if it has no size.
 
-        # Get chart reference
-        chart_ref = graphic_frame.find(f".//{{{c_ns}}}chart")
-        if chart_ref is None:
-            continue
+    Excel writes a zero-sized xfrm for charts placed in a cell anchor; those
+    are positioned from the anchor instead.
+    """
+    xfrm = graphic_frame.find(f"{{{_XDR_NS}}}xfrm")
+    if xfrm is None:
+        return None
+    off = xfrm.find("a:off", NS)
+    ext = xfrm.find("a:ext", NS)
+    if off is None or ext is None:
+        return None
+    try:
+        x = int(off.get("x", "0"))
+        y = int(off.get("y", "0"))
+        cx = int(ext.get("cx", "0"))
+        cy = int(ext.get("cy", "0"))
+        if cx == 0 and cy == 0:
+            return None
+        return (
+            scale.from_emu(x),
+            scale.from_emu(y),
+            scale.from_emu(cx),
+            scale.from_emu(cy),
+        )
+    except (ValueError, OverflowError):
+        return None
 
-        r_id = chart_ref.get(f"{{{r_ns}}}id")
-        if not r_id:
-            continue
 
-        # Get chart name
-        cnv_pr = graphic_frame.find(f".//{{{xdr_ns}}}cNvPr")
-        chart_name = cnv_pr.get("name", f"Chart_{r_id}") if cnv_pr is not None else f"Chart_{r_id}"
-
-        # Get position from xfrm
-        xfrm = graphic_frame.find(f"{{{xdr_ns}}}xfrm")
-        if xfrm is not None:
-            a_ns = "http://schemas.openxmlformats.org/drawingml/2006/main"
-            off = xfrm.find(f"{{{a_ns}}}off")
-            ext = xfrm.find(f"{{{a_ns}}}ext")
-            if off is not None and ext is not None:
-                try:
-                    x = int(off.get("x", "0"))
-                    y = int(off.get("y", "0"))
-                    cx = int(ext.get("cx", "0"))
-                    cy = int(ext.get("cy", "0"))
-                    result[r_id] = (
-                        chart_name,
-                        emu_to_pixels(x),
-                        emu_to_pixels(y),
-                        emu_to_pixels(cx),
-                        emu_to_pixels(cy),
-                    )
-                    continue
-                except ValueError:
-                    pass
+def _get_anchor_box(
+    anchor: Element, scale: PositionScale
+) -> tuple[int, int, int, int] | None:
+    """Return an anchor's box in ``scale`` units for any of the three anchor kinds.
 
-        # Try ext on anchor itself (oneCellAnchor)
-        anchor_ext = anchor.find(f"{{{xdr_ns}}}ext")
-        if anchor_ext is not None:
-            try:
-                cx = int(anchor_ext.get("cx", "0"))
-                cy = int(anchor_ext.get("cy", "0"))
-                result[r_id] = (chart_name, 0, 0, emu_to_pixels(cx), emu_to_pixels(cy))
-                continue
-            except ValueError:
-                pass
+    ``absoluteAnchor`` is exact (``pos`` + ``ext``); ``oneCellAnchor`` and
+    ``twoCellAnchor`` markers are converted with the default column width
+    and row height, so their position is approximate.
+    """
+    start = _get_marker_position(anchor.find("xdr:from", NS), scale)
+    try:
+        if anchor.tag == f"{{{_XDR_NS}}}absoluteAnchor":
+            pos = anchor.find("xdr:pos", NS)
+            if pos is None:
+                return None
+            start = (
+                scale.from_emu(int(pos.get("x", "0"))),
+                scale.from_emu(int(pos.get("y", "0"))),
+            )
+        if anchor.tag == f"{{{_XDR_NS}}}twoCellAnchor":
+            end = _get_marker_position(anchor.find("xdr:to", NS), scale)
+            if start is None or end is None:
+                return None
+            size = (max(end[0] - start[0], 0), max(end[1] - start[1], 0))
+        else:
+            ext = anchor.find("xdr:ext", NS)
+            if ext is None:
+                return None
+            size = (
+                scale.from_emu(int(ext.get("cx", "0"))),
+                scale.from_emu(int(ext.get("cy", "0"))),
+            )
+    except (ValueError, OverflowError):
+        return None
+    if start is None:
+        return (0, 0, *size)
+    return (*start, *size)
 
-        # Fallback: estimate from anchor cells (simplified)
-        result[r_id] = (chart_name, 0, 0, 400, 300)
 
-    return result
+def _get_fallback_box(scale: PositionScale) -> tuple[int, int, int, int]:
+    """Return the box used for charts without a usable transform or anchor."""
+    width, height = _FALLBACK_CHART_SIZE_EMU
+    return (0, 0, scale.from_emu(width), scale.from_emu(height))
+
+
+def _get_marker_position(
+    marker: Element | None, scale: PositionScale
+) -> tuple[int, int] | None:
+    """Convert an ``xdr:from``/``xdr:to`` marker to an approximate position."""
+    if marker is None:
+        return None
+    try:
+        col = int(marker.findtext("xdr:col", "", NS))
+        row = int(marker.findtext("xdr:row", "", NS))
+        col_off = int(marker.findtext("xdr:colOff", "0", NS) or 0)
+        row_off = int(marker.findtext("xdr:rowOff", "0", NS) or 0)
+        return (
+            scale.from_emu(col * _DEFAULT_COLUMN_WIDTH_EMU + col_off),
+            scale.from_emu(row * _DEFAULT_ROW_HEIGHT_EMU + row_off),
+        )
+    except (ValueError, OverflowError):
+        return None
 
 
 def _resolve_chart_paths(
@@ -425,8 +1207,8 @@ def _resolve_chart_paths(
     )
 
     try:
-        rels_xml = zf.read(rels_path)
-        rels_root = ET.fromstring(rels_xml)
+        rels_xml = read_part(zf, rels_path)
+        rels_root = parse_xml(rels_xml)
     except (KeyError, ET.ParseError):
         return result
 
@@ -462,8 +1244,8 @@ def _read_sheets_info(zf: ZipFile) -> dict[str, str]:
         Dict mapping rId to sheet name.
     """
     try:
-        workbook_xml = zf.read("xl/workbook.xml")
-        wb_root = ET.fromstring(workbook_xml)
+        workbook_xml = read_part(zf, "xl/workbook.xml")
+        wb_root = parse_xml(workbook_xml)
     except (KeyError, ET.ParseError):
         return {}
 
@@ -493,8 +1275,8 @@ def _read_sheet_files(zf: ZipFile, sheets_info: dict[str, str]) -> dict[str, str
         Dict mapping sheet name to file path.
     """
     try:
-        wb_rels_xml = zf.read("xl/_rels/workbook.xml.rels")
-        rels_root = ET.fromstring(wb_rels_xml)
+        wb_rels_xml = read_part(zf, "xl/_rels/workbook.xml.rels")
+        rels_root = parse_xml(wb_rels_xml)
     except (KeyError, ET.ParseError):
         return {}
 
@@ -511,13 +1293,14 @@ def _read_sheet_files(zf: ZipFile, sheets_info: dict[str, str]) -> dict[str, str
 
 
 def _find_sheet_charts(
-    zf: ZipFile, sheet_path: str
+    zf: ZipFile, sheet_path: str, scale: PositionScale = PositionScale()
 ) -> list[tuple[str, str, int, int, int, int]]:
     """Find charts for a single sheet.
 
     Args:
         zf: Open ZipFile.
         sheet_path: Path to sheet XML.
+        scale: Output unit for positions and sizes.
 
     Returns:
         List of (name, chart_path, left, top, width, height).
@@ -528,8 +1311,8 @@ def _find_sheet_charts(
     )
 
     try:
-        sheet_rels_xml = zf.read(rels_path)
-        sheet_rels_root = ET.fromstring(sheet_rels_xml)
+        sheet_rels_xml = read_part(zf, rels_path)
+        sheet_rels_root = parse_xml(sheet_rels_xml)
     except (KeyError, ET.ParseError):
         return []
 
@@ -541,7 +1324,7 @@ def _find_sheet_charts(
         target = rel.get("Target", "")
         drawing_path = _resolve_relative_path(target, "xl/drawings")
 
-        chart_positions = _get_chart_positions_from_drawing(zf, drawing_path)
+        chart_positions = _get_chart_positions_from_drawing(zf, drawing_path, scale)
         if not chart_positions:
             continue
 
@@ -552,19 +1335,20 @@ def _find_sheet_charts(
 
 
 def _get_sheet_chart_map(
-    xlsx_path: Path,
+    xlsx_path: Path, scale: PositionScale = PositionScale()
 ) -> dict[str, list[tuple[str, str, int, int, int, int]]]:
     """Map sheet names to their chart info.
 
     Args:
         xlsx_path: Path to xlsx file.
+        scale: Output unit for positions and sizes.
 
     Returns:
         Dict mapping sheet name to list of (name, chart_path, left, top, width, height).
     """
     sheet_charts: dict[str, list[tuple[str, str, int, int, int, int]]] = {}
 
-    with ZipFile(xlsx_path, "r") as zf:
+    with open_package(xlsx_path) as zf:
         sheets_info = _read_sheets_info(zf)
         if not sheets_info:
             return sheet_charts
@@ -572,7 +1356,7 @@ def _get_sheet_chart_map(
         sheet_files = _read_sheet_files(zf, sheets_info)
 
         for sheet_name, sheet_path in sheet_files.items():
-            charts = _find_sheet_charts(zf, sheet_path)
+            charts = _find_sheet_charts(zf, sheet_path, scale)
             if charts:
                 sheet_charts[sheet_name] = charts
 
@@ -580,15 +1364,21 @@ def _get_sheet_chart_map(
 
 
 def get_charts_ooxml(
-    xlsx_path: str | Path, mode: Literal["light", "standard", "verbose"] = "standard"
+    xlsx_path: str | Path,
+    mode: Literal["light", "standard", "verbose"] = "standard",
+    *,
+    scale: PositionScale = PositionScale(),
 ) -> dict[str, list[Chart]]:
     """Extract charts from xlsx file using OOXML parsing.
 
     This function provides COM-free chart extraction for Linux/macOS.
+    Text boxes drawn inside a chart are read from its chart drawing part.
 
     Args:
         xlsx_path: Path to xlsx file.
         mode: Output mode (light, standard, verbose).
+        scale: Output unit for positions and sizes (pixels at 96 DPI by
+            default).
 
     Returns:
         Dict mapping sheet name to list of Chart models.
@@ -596,37 +1386,27 @@ def get_charts_ooxml(
     xlsx_path = Path(xlsx_path)
     result: dict[str, list[Chart]] = {}
 
-    if not xlsx_path.exists():
+    if not source_exists(xlsx_path):
         logger.warning("File not found: %s", xlsx_path)
         return result
 
-    sheet_chart_map = _get_sheet_chart_map(xlsx_path)
+    sheet_chart_map = _get_sheet_chart_map(xlsx_path, scale)
 
-    with ZipFile(xlsx_path, "r") as zf:
+    with open_package(xlsx_path) as zf:
         for sheet_name, chart_infos in sheet_chart_map.items():
             charts: list[Chart] = []
 
             for name, chart_path, left, top, width, height in chart_infos:
                 try:
-                    chart_xml = zf.read(chart_path)
+                    chart_xml = read_part(zf, chart_path)
                     chart = _parse_chart_xml(
                         chart_xml, name, left, top, width, height
                     )
                     if chart is not None:
+                        chart.text_boxes = _load_chart_text_boxes(zf, chart_path)
                         # Apply mode-specific filtering
                         if mode != "verbose":
-                            chart = Chart(
-                                name=chart.name,
-                                chart_type=chart.chart_type,
-                                title=chart.title,
-                                y_axis_title=chart.y_axis_title,
-                                y_axis_range=chart.y_axis_range,
-                                w=None,
-                                h=None,
-                                series=chart.series,
-                                l=chart.l,
-                                t=chart.t,
-                            )
+                            chart = chart.model_copy(update={"w": None, "h": None})
                         charts.append(chart)
                 except KeyError:
                     logger.debug("Chart not found: %s", chart_path)
@@ -0,0 +1,87 @@
+"""Markup Compatibility (``mc:AlternateContent``) handling for OOXML parts.
+
+Newer Excel versions wrap drawing content that relies on extensions (a14
+shapes, slicers, chartex charts, ...) in ``mc:AlternateContent``, with one or
+more ``mc:Choice`` branches and an optional ``mc:Fallback``. A consumer must
+use exactly one branch. The parsers here understand only the base DrawingML
+schemas, so the Fallback branch is used when present and the first Choice
+otherwise. Elements are matched by namespace URI, never by prefix.
+"""
+
+from __future__ import annotations
+
+from typing import TYPE_CHECKING
+
+if TYPE_CHECKING:
+    from xml.etree.ElementTree import Element
+
+MC_NS = "http://schemas.openxmlformats.org/markup-compatibility/2006"
+ALTERNATE_CONTENT = f"{{{MC_NS}}}AlternateContent"
+_CHOICE = f"{{{MC_NS}}}Choice"
+_FALLBACK = f"{{{MC_NS}}}Fallback"
+
+
+def select_alternate_content(alternate: Element) -> list[Element]:
+    """Return the content of the branch to use for one AlternateContent.
+
+    Nested AlternateContent elements at the top of the chosen branch are
+    resolved as well, so the result never contains one.
+
+    Args:
+        alternate: ``mc:AlternateContent`` element.
+
+    Returns:
+        Children of the Fallback branch, else of the first Choice; empty if
+        the element has neither.
+    """
+    selected: list[Element] = []
+    pending = _branch_children(alternate)
+    pending.reverse()
+    while pending:
+        child = pending.pop()
+        if child.tag == ALTERNATE_CONTENT:
+            pending.extend(reversed(_branch_children(child)))
+        else:
+            selected.append(child)
+    return selected
+
+
+def _branch_children(alternate: Element) -> list[Element]:
+    """Return the children of the Fallback branch, else of the first Choice."""
+    branch = alternate.find(_FALLBACK)
+    if branch is None:
+        branch = alternate.find(_CHOICE)
+    return list(branch) if branch is not None else []
+
+
+def resolve_alternate_content(root: Element) -> None:
+    """Replace every AlternateContent below ``root`` with its chosen branch.
+
+    Works in place and iteratively, so deeply nested parts cannot exhaust the
+    stack. Parts without AlternateContent are left untouched.
+
+    Args:
+        root: Element whose descendants are rewritten.
+    """
+    if next(root.iter(ALTERNATE_CONTENT), None) is None:
+        return
+    stack = [root]
+    while stack:
+        parent = stack.pop()
+        index = 0
+        while index < len(parent):
+            child = parent[index]
+            if child.tag == ALTERNATE_CONTENT:
+                # Re-examine the spliced children, which may nest further.
+                parent[index : index + 1] = _branch_children(child)
+                continue
+            stack.append(child)
+            index += 1
+
+
+__all__ = [
+    "ALTERNATE_CONTENT",
+    "MC_NS",
+    "resolve_alternate_content",
+    "select_alternate_content",
+]
@@ -0,0 +1,188 @@
+"""External data connections read from ``xl/connections.xml`` (``include_connections``).
+
+Each ``<connection>`` names an ODBC/OLEDB/web/text source and its refresh
+settings. Query tables (``xl/queryTables``) and pivot caches point back at a
+connection by ``connectionId``, which is how sheets and tables are bound to it.
+Credential fields of connection strings and URLs are masked before reporting.
+"""
+
+from __future__ import annotations
+
+from pathlib import Path
+import posixpath
+import re
+from xml.etree import ElementTree as ET
+from zipfile import ZipFile, is_zipfile
+
+from exstruct.models import ConnectionBinding, ConnectionKind, DataConnection
+from exstruct.ooxml.chart import _read_sheet_files, _read_sheets_info
+from exstruct.ooxml.pivot import _relationships
+from exstruct.ooxml.safety import open_package, parse_part
+from exstruct.sources import open_source
+
+_MAIN_NS = "http://schemas.openxmlformats.org/spreadsheetml/2006/main"
+_M = f"{{{_MAIN_NS}}}"
+_MASK = "***"
+_KINDS: dict[str, ConnectionKind] = {
+    "1": "odbc",
+    "2": "dao",
+    "3": "file",
+    "4": "web",
+    "5": "oledb",
+    "6": "text",
+    "7": "ado",
+    "8": "dsp",
+}
+_SECRET_KEYS = re.compile(
+    r"(?i)((?:^|;)\s*(?:password|pwd|user id|uid|user|username|token|"
+    r"access token|accountkey|account key|sharedaccesssignature|secret|"
+    r"client secret)\s*=\s*)(\"[^\"]*\"|'[^']*'|[^;]*)"
+)
+_URL_USERINFO = re.compile(r"(?i)(\b[a-z][a-z0-9+.-]*://)[^/@\s]+@")
+
+
+def read_connections(file_path: Path) -> list[DataConnection]:
+    """List the workbook's data connections and the objects bound to them.
+
+    Args:
+        file_path: Workbook path (.xlsx/.xlsm).
+
+    Returns:
+        One ``DataConnection`` per ``<connection>`` in document order.
+        Non-OOXML workbooks (.xls) and workbooks without connections yield an
+        empty list.
+    """
+    if not is_zipfile(open_source(file_path)):
+        return []
+    with open_package(file_path) as zf:
+        names = set(zf.namelist())
+        if "xl/connections.xml" not in names:
+            return []
+        connections = [
+            _read_connection(element)
+            for element in parse_part(zf, "xl/connections.xml").iter(f"{_M}connection")
+        ]
+        by_id = {connection.id: connection for connection in connections}
+        for connection_id, binding in _bindings(zf, names):
+            if connection_id in by_id:
+                by_id[connection_id].bindings.append(binding)
+    return connections
+
+
+def mask_credentials(text: str) -> str:
+    """Replace password/user/token values and URL user info with ``***``.
+
+    Args:
+        text: Connection string (``key=value;...``) or URL.
+
+    Returns:
+        Text with secrets masked; other parts unchanged.
+    """
+    masked = _SECRET_KEYS.sub(lambda match: f"{match.group(1)}{_MASK}", text)
+    return _URL_USERINFO.sub(lambda match: f"{match.group(1)}{_MASK}@", masked)
+
+
+def _read_connection(element: ET.Element) -> DataConnection:
+    """Convert one ``<connection>`` element."""
+    connection = DataConnection(
+        id=int(element.get("id", "0")),
+        name=element.get("name", ""),
+        description=element.get("description"),
+        kind=_KINDS.get(element.get("type", ""), "other"),
+        refresh_on_load=element.get("refreshOnLoad") in ("1", "true"),
+        background_refresh=element.get("background") in ("1", "true"),
+        refresh_interval=int(element.get("interval", "0")) or None,
+    )
+    db = element.find(f"{_M}dbPr")
+    if db is not None:
+        if db.get("connection"):
+            connection.connection_string = mask_credentials(db.get("connection", ""))
+        connection.command = db.get("command")
+    web = element.find(f"{_M}webPr")
+    if web is not None and web.get("url"):
+        connection.url = mask_credentials(web.get("url", ""))
+    text = element.find(f"{_M}textPr")
+    if text is not None:
+        connection.source_file = text.get("sourceFile")
+    if connection.source_file is None:
+        connection.source_file = element.get("sourceFile")
+    return connection
+
+
+def _bindings(zf: ZipFile, names: set[str]) -> list[tuple[int, ConnectionBinding]]:
+    """Query tables and pivot tables of each sheet, keyed by connection id."""
+    found: list[tuple[int, ConnectionBinding]] = []
+    for sheet, part in _read_sheet_files(zf, _read_sheets_info(zf)).items():
+        for target in _part_relationships(zf, part, names).values():
+            if target.startswith("xl/queryTables/"):
+                connection_id = _query_table_connection(zf, target, names)
+                if connection_id is not None:
+                    binding = ConnectionBinding(kind="query_table", sheet=sheet)
+                    found.append((connection_id, binding))
+            elif target.startswith("xl/tables/"):
+                found.extend(_table_bindings(zf, sheet, target, names))
+            elif target.startswith("xl/pivotTables/"):
+                found.extend(_pivot_bindings(zf, sheet, target, names))
+    return found
+
+
+def _table_bindings(
+    zf: ZipFile, sheet: str, part: str, names: set[str]
+) -> list[tuple[int, ConnectionBinding]]:
+    """Bind an Excel table whose rows come from a query table."""
+    if part not in names:
+        return []
+    table = parse_part(zf, part)
+    if table.get("tableType") != "queryTable":
+        return []
+    found: list[tuple[int, ConnectionBinding]] = []
+    for target in _part_relationships(zf, part, names).values():
+        connection_id = _query_table_connection(zf, target, names)
+        if connection_id is None:
+            continue
+        binding = ConnectionBinding(
+            kind="query_table",
+            sheet=sheet,
+            name=table.get("displayName") or table.get("name"),
+            range=table.get("ref"),
+        )
+        found.append((connection_id, binding))
+    return found
+
+
+def _pivot_bindings(
+    zf: ZipFile, sheet: str, part: str, names: set[str]
+) -> list[tuple[int, ConnectionBinding]]:
+    """Bind a pivot table whose cache reads from a connection."""
+    if part not in names:
+        return []
+    pivot = parse_part(zf, part)
+    location = pivot.find(f"{_M}location")
+    found: list[tuple[int, ConnectionBinding]] = []
+    for target in _part_relationships(zf, part, names).values():
+        if target not in names or "pivotCacheDefinition" not in target:
+            continue
+        source = parse_part(zf, target).find(f"{_M}cacheSource")
+        if source is None or source.get("connectionId") is None:
+            continue
+        binding = ConnectionBinding(
+            kind="pivot_table",
+            sheet=sheet,
+            name=pivot.get("name"),
+            range=location.get("ref") if location is not None else None,
+        )
+        found.append((int(source.get("connectionId", "0")), binding))
+    return found
+
+
+def _query_table_connection(zf: ZipFile, part: str, names: set[str]) -> int | None:
+    if not part.startswith("xl/queryTables/") or part not in names:
+        return None
+    value = parse_part(zf, part).get("connectionId")
+    return int(value) if value is not None else None
+
+
+def _part_relationships(zf: ZipFile, part: str, names: set[str]) -> dict[str, str]:
+    base_dir = posixpath.dirname(part)
+    rels = f"{base_dir}/_rels/{posixpath.basename(part)}.rels"
+    return _relationships(zf, rels, base_dir, names)
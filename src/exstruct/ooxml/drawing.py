@@ -8,19 +8,47 @@ from __future__ import annotations
 
 import logging
 import math
-from pathlib import Path
+from pathlib import Path, PurePosixPath
 from typing import TYPE_CHECKING, Literal
 from xml.etree import ElementTree as ET
-from zipfile import ZipFile
 
-from exstruct.models import Shape
-from exstruct.ooxml.units import emu_to_pixels
+from exstruct.models import Arrow, Shape, ShapeGeometry
+from exstruct.ooxml.compat import (
+    ALTERNATE_CONTENT,
+    MC_NS,
+    resolve_alternate_content,
+    select_alternate_content,
+)
+from exstruct.ooxml.freeform import (
+    custom_geometry_paths,
+    ink_trace_paths,
+    simplify_polyline,
+)
+from exstruct.ooxml.safety import iterparse_xml, open_package, parse_xml, read_part
+from exstruct.ooxml.units import PositionScale, emu_to_points
+from exstruct.sources import source_exists
 
 if TYPE_CHECKING:
+    from collections.abc import Mapping, Sequence
     from xml.etree.ElementTree import Element
+    from zipfile import ZipFile
+
+    from exstruct.ooxml.freeform import Point
 
 logger = logging.getLogger(__name__)
 
+CompassDirection = Literal["E", "SE", "S", "SW", "W", "NW", "N", "NE"]
+TextAlign = Literal["left", "center", "right", "justify", "distributed"]
+TextAnchor = Literal["top", "middle", "bottom"]
+TextOrientation = Literal[
+    "horizontal",
+    "vertical",
+    "vertical270",
+    "stacked",
+    "east_asian_vertical",
+    "mongolian_vertical",
+]
+
 
 def _resolve_relative_path(target: str, base_dir: str) -> str:
     """Resolve relative path from target.
@@ -47,6 +75,7 @@ NS = {
     "xdr": "http://schemas.openxmlformats.org/drawingml/2006/spreadsheetDrawing",
     "a": "http://schemas.openxmlformats.org/drawingml/2006/main",
     "r": "http://schemas.openxmlformats.org/officeDocument/2006/relationships",
+    "xdr14": "http://schemas.microsoft.com/office/excel/2010/spreadsheetDrawing",
 }
 
 # Mapping from OOXML preset geometry to ExStruct type labels
@@ -148,6 +177,81 @@ ARROW_HEAD_MAP: dict[str, int] = {
     "arrow": 2,
 }
 
+# Mapping from OOXML arrowhead w/len to MsoArrowheadWidth / MsoArrowheadLength
+ARROW_SIZE_MAP: dict[str, int] = {
+    "sm": 1,
+    "med": 2,
+    "lg": 3,
+}
+
+# Mapping from OOXML paragraph algn to text alignment
+TEXT_ALIGN_MAP: dict[str, TextAlign] = {
+    "l": "left",
+    "ctr": "center",
+    "r": "right",
+    "just": "justify",
+    "justLow": "justify",
+    "dist": "distributed",
+    "thaiDist": "distributed",
+}
+
+# Mapping from OOXML bodyPr anchor to vertical text anchor
+TEXT_ANCHOR_MAP: dict[str, TextAnchor] = {
+    "t": "top",
+    "ctr": "middle",
+    "b": "bottom",
+    "just": "middle",
+    "dist": "middle",
+}
+
+# Mapping from OOXML bodyPr vert to text orientation
+TEXT_ORIENTATION_MAP: dict[str, TextOrientation] = {
+    "horz": "horizontal",
+    "vert": "vertical",
+    "vert270": "vertical270",
+    "wordArtVert": "stacked",
+    "wordArtVertRtl": "stacked",
+    "eaVert": "east_asian_vertical",
+    "mongolianVert": "mongolian_vertical",
+}
+
+# Mapping from OOXML preset dash to MsoLineDashStyle
+LINE_DASH_MAP: dict[str, int] = {
+    "solid": 1,
+    "dot": 3,
+    "dash": 4,
+    "dashDot": 5,
+    "sysDashDotDot": 6,
+    "lgDash": 7,
+    "lgDashDot": 8,
+    "lgDashDotDot": 9,
+    "sysDash": 10,
+    "sysDot": 11,
+    "sysDashDot": 12,
+}
+
+# Deeper xdr:grpSp nesting is skipped instead of exhausting the stack.
+MAX_GROUP_DEPTH = 64
+
+# Polylines are simplified to this fraction of the bounding box diagonal
+# (at least one output unit).
+PATH_TOLERANCE_RATIO = 0.01
+
+# Clark-notation tags for the hot lookups, so they skip ElementPath parsing.
+_A_T = f"{{{NS['a']}}}t"
+_ANCHOR_TAGS = frozenset(
+    {
+        f"{{{NS['xdr']}}}twoCellAnchor",
+        f"{{{NS['xdr']}}}oneCellAnchor",
+        f"{{{NS['xdr']}}}absoluteAnchor",
+    }
+)
+_MC_CHOICE = f"{{{MC_NS}}}Choice"
+_R_ID = f"{{{NS['r']}}}id"
+_RELS_NS = {"": "http://schemas.openxmlformats.org/package/2006/relationships"}
+# Macro references in drawings point at the own workbook as "[0]!Name".
+_OWN_WORKBOOK_MACRO_PREFIX = "[0]!"
+
 
 def _get_text_from_element(elem: Element) -> str:
     """Extract all text content from a shape element.
@@ -158,23 +262,62 @@ def _get_text_from_element(elem: Element) -> str:
     Returns:
         Concatenated text content, stripped.
     """
-    texts: list[str] = []
-    for t_elem in elem.findall(".//a:t", NS):
-        if t_elem.text:
-            texts.append(t_elem.text)
-    return "".join(texts).strip()
+    return "".join(t.text for t in elem.iter(_A_T) if t.text).strip()
+
+
+def _get_text_layout(
+    elem: Element,
+) -> tuple[TextAlign | None, TextAnchor | None, bool | None, TextOrientation | None]:
+    """Extract text alignment, vertical anchor, wrap, and orientation from txBody.
+
+    Unspecified attributes resolve to DrawingML defaults (left, top, wrap,
+    horizontal).
+
+    Args:
+        elem: Shape element.
+
+    Returns:
+        Tuple of (align, anchor, wrap, orientation), all None when there is no
+        text body.
+    """
+    tx_body = elem.find("xdr:txBody", NS)
+    if tx_body is None:
+        return (None, None, None, None)
+
+    align: TextAlign = "left"
+    for p_pr in tx_body.findall("a:p/a:pPr", NS):
+        algn = p_pr.get("algn")
+        if algn is not None:
+            align = TEXT_ALIGN_MAP.get(algn, "left")
+            break
+
+    anchor: TextAnchor = "top"
+    wrap = True
+    orientation: TextOrientation = "horizontal"
+    body_pr = tx_body.find("a:bodyPr", NS)
+    if body_pr is not None:
+        anchor = TEXT_ANCHOR_MAP.get(body_pr.get("anchor", "t"), "top")
+        wrap = body_pr.get("wrap", "square") != "none"
+        orientation = TEXT_ORIENTATION_MAP.get(
+            body_pr.get("vert", "horz"), "horizontal"
+        )
 
+    return (align, anchor, wrap, orientation)
 
-def _get_xfrm_position(elem: Element) -> tuple[int, int, int, int] | None:
+
+def _get_xfrm_position(
+    xfrm: Element | None, scale: PositionScale = PositionScale()
+) -> tuple[int, int, int, int] | None:
     """Extract position and size from xfrm element.
 
     Args:
-        elem: XML element containing spPr/xfrm.
+        xfrm: The shape's a:xfrm element, if any.
+        scale: Output unit (pixels at 96 DPI by default).
 
     Returns:
-        Tuple of (left, top, width, height) in pixels, or None if not found.
+        Tuple of (left, top, width, height) in the output unit, or None if
+        not found.
     """
-    xfrm = elem.find(".//a:xfrm", NS)
     if xfrm is None:
         return None
 
@@ -190,12 +333,12 @@ def _get_xfrm_position(elem: Element) -> tuple[int, int, int, int] | None:
         cx = int(ext.get("cx", "0"))
         cy = int(ext.get("cy", "0"))
         return (
-            emu_to_pixels(x),
-            emu_to_pixels(y),
-            emu_to_pixels(cx),
-            emu_to_pixels(cy),
+            scale.from_emu(x),
+            scale.from_emu(y),
+            scale.from_emu(cx),
+            scale.from_emu(cy),
         )
-    except ValueError:
+    except (ValueError, OverflowError):
         return None
 
 
@@ -214,11 +357,11 @@ def _get_preset_geometry(elem: Element) -> str | None:
     return None
 
 
-def _get_arrow_styles(elem: Element) -> tuple[int | None, int | None]:
+def _get_arrow_styles(ln: Element | None) -> tuple[int | None, int | None]:
     """Extract arrow head styles from connector line.
 
     Args:
-        elem: XML element containing line properties.
+        ln: The shape's a:ln element, if any.
 
     Returns:
         Tuple of (begin_arrow_style, end_arrow_style).
@@ -226,7 +369,6 @@ def _get_arrow_styles(elem: Element) -> tuple[int | None, int | None]:
     begin_style: int | None = None
     end_style: int | None = None
 
-    ln = elem.find(".//a:ln", NS)
     if ln is None:
         return (None, None)
 
@@ -244,20 +386,139 @@ def _get_arrow_styles(elem: Element) -> tuple[int | None, int | None]:
     return (begin_style, end_style)
 
 
-def _compute_direction(width: int, height: int) -> str | None:
-    """Compute compass direction from connector dimensions.
+def _get_arrow_sizes(
+    ln: Element | None,
+) -> tuple[int | None, int | None, int | None, int | None]:
+    """Extract arrowhead width and length enums from connector line.
+
+    Args:
+        ln: The shape's a:ln element, if any.
+
+    Returns:
+        Tuple of (begin_width, begin_length, end_width, end_length).
+    """
+    if ln is None:
+        return (None, None, None, None)
+
+    def _sizes(end: Element | None) -> tuple[int | None, int | None]:
+        if end is None or end.get("type", "none") == "none":
+            return (None, None)
+        # Unspecified w/len default to "med" in DrawingML.
+        return (
+            ARROW_SIZE_MAP.get(end.get("w", "med")),
+            ARROW_SIZE_MAP.get(end.get("len", "med")),
+        )
+
+    begin_w, begin_len = _sizes(ln.find("a:headEnd", NS))
+    end_w, end_len = _sizes(ln.find("a:tailEnd", NS))
+    return (begin_w, begin_len, end_w, end_len)
+
+
+def _get_line_format(ln: Element | None) -> tuple[int | None, float | None]:
+    """Extract dash style and weight from connector line.
 
     Args:
-        width: Connector width in pixels.
-        height: Connector height in pixels.
+        ln: The shape's a:ln element, if any.
+
+    Returns:
+        Tuple of (dash_style, weight_points).
+    """
+    if ln is None:
+        return (None, None)
+
+    dash_style: int | None = None
+    prst_dash = ln.find("a:prstDash", NS)
+    if prst_dash is not None:
+        dash_style = LINE_DASH_MAP.get(prst_dash.get("val", "solid"))
+
+    weight: float | None = None
+    width_str = ln.get("w")
+    if width_str is not None:
+        try:
+            weight = emu_to_points(int(width_str))
+        except (ValueError, OverflowError):
+            weight = None
+
+    return (dash_style, weight)
+
+
+def _get_xfrm_flips(xfrm: Element | None) -> tuple[bool, bool]:
+    """Extract flipH/flipV flags from xfrm element.
+
+    Args:
+        xfrm: The shape's a:xfrm element, if any.
+
+    Returns:
+        Tuple of (flip_h, flip_v).
+    """
+    if xfrm is None:
+        return (False, False)
+    return (
+        xfrm.get("flipH") in ("1", "true"),
+        xfrm.get("flipV") in ("1", "true"),
+    )
+
+
+def compute_connector_points(
+    left: float,
+    top: float,
+    width: float,
+    height: float,
+    *,
+    flip_h: bool = False,
+    flip_v: bool = False,
+    rotation: float | None = None,
+) -> tuple[tuple[float, float], tuple[float, float]]:
+    """Compute connector start and end points from its bounding box.
+
+    An unflipped connector runs from the top-left to the bottom-right corner.
+    Flips mirror the endpoints within the box; rotation (clockwise, degrees)
+    is then applied around the box center, matching DrawingML semantics.
+
+    Args:
+        left: Box left.
+        top: Box top.
+        width: Box width.
+        height: Box height.
+        flip_h: Whether the connector is flipped horizontally.
+        flip_v: Whether the connector is flipped vertically.
+        rotation: Rotation in degrees, if any.
+
+    Returns:
+        Tuple of ((start_x, start_y), (end_x, end_y)).
+    """
+    start = (left + width if flip_h else left, top + height if flip_v else top)
+    end = (left if flip_h else left + width, top if flip_v else top + height)
+    if not rotation:
+        return (start, end)
+    cx = left + width / 2
+    cy = top + height / 2
+    rad = math.radians(rotation)
+    cos_r = math.cos(rad)
+    sin_r = math.sin(rad)
+
+    def _rotate(point: tuple[float, float]) -> tuple[float, float]:
+        x = point[0] - cx
+        y = point[1] - cy
+        return (cx + x * cos_r - y * sin_r, cy + x * sin_r + y * cos_r)
+
+    return (_rotate(start), _rotate(end))
+
+
+def _compute_direction(dx: float, dy: float) -> CompassDirection | None:
+    """Compute compass direction from a connector start-to-end vector.
+
+    Args:
+        dx: Horizontal delta in pixels (positive to the right).
+        dy: Vertical delta in pixels (positive downward).
 
     Returns:
         Compass direction (N, NE, E, SE, S, SW, W, NW) or None.
     """
-    if width == 0 and height == 0:
+    if math.isclose(dx, 0.0, abs_tol=1e-9) and math.isclose(dy, 0.0, abs_tol=1e-9):
         return None
 
-    angle = math.degrees(math.atan2(-height, width))
+    angle = math.degrees(math.atan2(-dy, dx))
     if angle < 0:
         angle += 360
 
@@ -280,16 +541,15 @@ def _compute_direction(width: int, height: int) -> str | None:
         return "SE"
 
 
-def _get_rotation(elem: Element) -> float | None:
+def _get_rotation(xfrm: Element | None) -> float | None:
     """Extract rotation angle from xfrm element.
 
     Args:
-        elem: XML element containing xfrm.
+        xfrm: The shape's a:xfrm element, if any.
 
     Returns:
         Rotation in degrees or None.
     """
-    xfrm = elem.find(".//a:xfrm", NS)
     if xfrm is None:
         return None
 
@@ -304,7 +564,7 @@ def _get_rotation(elem: Element) -> float | None:
         if abs(rot_deg) < 1e-6:
             return None
         return rot_deg
-    except ValueError:
+    except (ValueError, OverflowError):
         return None
 
 
@@ -335,6 +595,7 @@ def _should_include_shape(
     type_label: str,
     is_connector: bool,
     mode: str,
+    has_geometry: bool = False,
 ) -> bool:
     """Decide whether to emit a shape given output mode.
 
@@ -343,6 +604,7 @@ def _should_include_shape(
         type_label: Shape type label.
         is_connector: Whether shape is a connector/line.
         mode: Output mode (light, standard, verbose).
+        has_geometry: Whether shape is a freeform or ink annotation.
 
     Returns:
         True if shape should be included.
@@ -353,11 +615,12 @@ def _should_include_shape(
     if mode == "verbose":
         return True
 
-    # standard mode: emit if text exists OR the shape is a connector/arrow
+    # standard mode: emit if text exists, the shape is a connector/arrow, or
+    # it is hand-drawn markup
     if text:
         return True
 
-    if is_connector:
+    if is_connector or has_geometry:
         return True
 
     # Check for arrow shapes
@@ -367,6 +630,82 @@ def _should_include_shape(
     return False
 
 
+def _get_xfrm_extent(xfrm: Element | None) -> tuple[float, float]:
+    """Return the xfrm extent in EMU, or (0, 0) when missing."""
+    ext = xfrm.find("a:ext", NS) if xfrm is not None else None
+    if ext is None:
+        return (0.0, 0.0)
+    try:
+        return (float(ext.get("cx", "0")), float(ext.get("cy", "0")))
+    except ValueError:
+        return (0.0, 0.0)
+
+
+def _build_geometry(
+    kind: Literal["freeform", "ink"],
+    polylines: Sequence[Sequence[Point]],
+    box: tuple[int, int, int, int],
+    *,
+    flip_h: bool = False,
+    flip_v: bool = False,
+    include_paths: bool = False,
+) -> ShapeGeometry:
+    """Map normalized polylines onto the shape box.
+
+    Args:
+        kind: Geometry kind.
+        polylines: Polylines normalized to the shape box (0.0-1.0).
+        box: Shape (left, top, width, height) in the output unit.
+        flip_h: Whether the shape is flipped horizontally.
+        flip_v: Whether the shape is flipped vertically.
+        include_paths: Whether to keep the simplified polylines.
+
+    Returns:
+        Geometry with the path bounding box; the shape box when there are no
+        usable points. Rotation is not applied.
+    """
+    left, top, width, height = box
+    mapped = [
+        [
+            (
+                left + (1.0 - x if flip_h else x) * width,
+                top + (1.0 - y if flip_v else y) * height,
+            )
+            for x, y in polyline
+        ]
+        for polyline in polylines
+        if polyline
+    ]
+    points = [point for polyline in mapped for point in polyline]
+    if not points:
+        return ShapeGeometry(kind=kind, l=left, t=top, w=width, h=height)
+    min_x = min(x for x, _ in points)
+    min_y = min(y for _, y in points)
+    max_x = max(x for x, _ in points)
+    max_y = max(y for _, y in points)
+    paths: list[list[tuple[int, int]]] | None = None
+    if include_paths:
+        tolerance = max(
+            1.0, PATH_TOLERANCE_RATIO * math.hypot(max_x - min_x, max_y - min_y)
+        )
+        paths = []
+        for polyline in mapped:
+            path: list[tuple[int, int]] = []
+            for x, y in simplify_polyline(polyline, tolerance):
+                point = (round(x), round(y))
+                if not path or path[-1] != point:
+                    path.append(point)
+            paths.append(path)
+    return ShapeGeometry(
+        kind=kind,
+        l=round(min_x),
+        t=round(min_y),
+        w=round(max_x - min_x),
+        h=round(max_y - min_y),
+        paths=paths,
+    )
+
+
 def _get_connector_endpoints(elem: Element) -> tuple[str | None, str | None]:
     """Extract connector start and end shape IDs.
 
@@ -397,6 +736,31 @@ def _get_connector_endpoints(elem: Element) -> tuple[str | None, str | None]:
     return (start_id, end_id)
 
 
+def _get_macro(elem: Element) -> str | None:
+    """Extract the assigned macro name from a shape element.
+
+    Args:
+        elem: Shape element with an optional ``macro`` attribute.
+
+    Returns:
+        Macro name without the own-workbook ``[0]!`` prefix, or None.
+    """
+    macro = (elem.get("macro") or "").strip()
+    if macro.startswith(_OWN_WORKBOOK_MACRO_PREFIX):
+        macro = macro[len(_OWN_WORKBOOK_MACRO_PREFIX) :]
+    return macro or None
+
+
+def _get_click_link_id(cnv_pr: Element | None) -> str | None:
+    """Return the relationship id of a shape's ``a:hlinkClick``, if any."""
+    if cnv_pr is None:
+        return None
+    hlink = cnv_pr.find("a:hlinkClick", NS)
+    if hlink is None:
+        return None
+    return hlink.get(_R_ID) or None
+
+
 def _get_shape_excel_id(elem: Element) -> str | None:
     """Extract Excel shape ID from cNvPr element.
 
@@ -417,22 +781,24 @@ class _ShapeParseResult:
 
     def __init__(
         self,
-        shape: Shape,
+        shape: Shape | Arrow,
         excel_id: str | None,
         excel_name: str | None,
         is_connector: bool,
         start_cxn_id: str | None,
         end_cxn_id: str | None,
+        link_id: str | None = None,
     ) -> None:
         """Initialize parse result.
 
         Args:
-            shape: Parsed Shape model.
+            shape: Parsed Shape (or Arrow for connectors) model.
             excel_id: Excel shape ID from cNvPr.
             excel_name: Excel shape name from cNvPr.
             is_connector: Whether this is a connector shape.
             start_cxn_id: Connected start shape Excel ID.
             end_cxn_id: Connected end shape Excel ID.
+            link_id: Relationship id of the click hyperlink.
         """
         self.shape = shape
         self.excel_id = excel_id
@@ -440,12 +806,15 @@ class _ShapeParseResult:
         self.is_connector = is_connector
         self.start_cxn_id = start_cxn_id
         self.end_cxn_id = end_cxn_id
+        self.link_id = link_id
 
 
 def _parse_shape_element(
     elem: Element,
     mode: str,
     is_cxn_sp: bool = False,
+    scale: PositionScale = PositionScale(),
+    include_paths: bool = False,
 ) -> _ShapeParseResult | None:
     """Parse a single shape element into Shape model.
 
@@ -453,6 +822,8 @@ def _parse_shape_element(
         elem: xdr:sp or xdr:cxnSp element.
         mode: Output mode (light, standard, verbose).
         is_cxn_sp: Whether this is a connector shape element.
+        scale: Output unit for positions and sizes.
+        include_paths: Keep simplified polylines of freeform geometry.
 
     Returns:
         ShapeParseResult or None if should be skipped.
@@ -462,8 +833,9 @@ def _parse_shape_element(
     shape_name = cnv_pr.get("name", "") if cnv_pr is not None else ""
     excel_id = cnv_pr.get("id") if cnv_pr is not None else None
 
-    # Get position and size
-    pos = _get_xfrm_position(elem)
+    # Get position and size; xfrm is looked up once and shared by the helpers
+    xfrm = elem.find(".//a:xfrm", NS)
+    pos = _get_xfrm_position(xfrm, scale)
     if pos is None:
         return None
 
@@ -486,42 +858,86 @@ def _parse_shape_element(
     # Check if connector
     is_connector = is_cxn_sp or _is_connector_shape(prst, type_label)
 
+    cust_geom = None if is_connector else elem.find("xdr:spPr/a:custGeom", NS)
+
     # Apply filtering based on mode
-    if not _should_include_shape(text, type_label, is_connector, mode):
+    if not _should_include_shape(
+        text, type_label, is_connector, mode, has_geometry=cust_geom is not None
+    ):
         return None
 
-    # Build shape object
-    shape = Shape(
-        text=text,
-        l=left,
-        t=top,
-        w=width if mode == "verbose" else None,
-        h=height if mode == "verbose" else None,
-        type=type_label,
-    )
+    rotation = _get_rotation(xfrm)
 
     # Get connector endpoints
     start_cxn_id: str | None = None
     end_cxn_id: str | None = None
+    link_id: str | None = None
 
-    # Add connector-specific properties
+    # Build shape object (connectors become Arrow models)
+    shape: Shape | Arrow
     if is_connector:
-        direction = _compute_direction(width, height)
-        if direction:
-            shape.direction = direction  # type: ignore[assignment]
-
-        begin_style, end_style = _get_arrow_styles(elem)
-        if begin_style is not None:
-            shape.begin_arrow_style = begin_style
-        if end_style is not None:
-            shape.end_arrow_style = end_style
+        flip_h, flip_v = _get_xfrm_flips(xfrm)
+        start, end = compute_connector_points(
+            left, top, width, height, flip_h=flip_h, flip_v=flip_v, rotation=rotation
+        )
+        ln = elem.find(".//a:ln", NS)
+        begin_style, end_style = _get_arrow_styles(ln)
+        begin_w, begin_len, end_w, end_len = _get_arrow_sizes(ln)
+        dash_style, line_weight = _get_line_format(ln)
+        shape = Arrow(
+            text=text,
+            l=left,
+            t=top,
+            w=width if mode == "verbose" else None,
+            h=height if mode == "verbose" else None,
+            begin_arrow_style=begin_style,
+            end_arrow_style=end_style,
+            begin_arrow_width=begin_w,
+            begin_arrow_length=begin_len,
+            end_arrow_width=end_w,
+            end_arrow_length=end_len,
+            line_dash_style=dash_style,
+            line_weight=line_weight,
+            direction=_compute_direction(end[0] - start[0], end[1] - start[1]),
+            begin_x=round(start[0]),
+            begin_y=round(start[1]),
+            end_x=round(end[0]),
+            end_y=round(end[1]),
+        )
 
         # Get connector endpoints if this is a cxnSp
         if is_cxn_sp:
             start_cxn_id, end_cxn_id = _get_connector_endpoints(elem)
+    else:
+        text_align, text_anchor, text_wrap, text_orientation = (
+            _get_text_layout(elem) if text else (None, None, None, None)
+        )
+        shape = Shape(
+            text=text,
+            l=left,
+            t=top,
+            w=width if mode == "verbose" else None,
+            h=height if mode == "verbose" else None,
+            type=type_label,
+            text_align=text_align,
+            text_anchor=text_anchor,
+            text_wrap=text_wrap,
+            text_orientation=text_orientation,
+            on_action=_get_macro(elem),
+        )
+        link_id = _get_click_link_id(cnv_pr)
+        if cust_geom is not None:
+            flip_h, flip_v = _get_xfrm_flips(xfrm)
+            shape.geometry = _build_geometry(
+                "freeform",
+                custom_geometry_paths(cust_geom, _get_xfrm_extent(xfrm)),
+                pos,
+                flip_h=flip_h,
+                flip_v=flip_v,
+                include_paths=include_paths,
+            )
 
     # Add rotation if present
-    rotation = _get_rotation(elem)
     if rotation is not None:
         shape.rotation = rotation
 
@@ -532,49 +948,70 @@ def _parse_shape_element(
         is_connector=is_connector,
         start_cxn_id=start_cxn_id,
         end_cxn_id=end_cxn_id,
+        link_id=link_id,
     )
 
 
 def _parse_group_shapes(
     grp_sp: Element,
     mode: str,
+    depth: int = 1,
+    scale: PositionScale = PositionScale(),
+    include_paths: bool = False,
 ) -> list[_ShapeParseResult]:
     """Parse shapes within a group recursively.
 
     Args:
         grp_sp: xdr:grpSp element.
         mode: Output mode.
+        depth: Nesting depth of ``grp_sp`` (1 for top-level groups).
+        scale: Output unit for positions and sizes.
+        include_paths: Keep simplified polylines of freeform geometry.
 
     Returns:
         List of ShapeParseResult from group children.
     """
     results: list[_ShapeParseResult] = []
+    if depth > MAX_GROUP_DEPTH:
+        logger.debug("Skipping groups nested deeper than %d", MAX_GROUP_DEPTH)
+        return results
 
     # Parse regular shapes in group
-    for sp in grp_sp.findall("xdr:sp", NS):
-        result = _parse_shape_element(sp, mode, is_cxn_sp=False)
+    for sp in grp_sp.iterfind("xdr:sp", NS):
+        result = _parse_shape_element(
+            sp, mode, is_cxn_sp=False, scale=scale, include_paths=include_paths
+        )
         if result is not None:
             results.append(result)
 
     # Parse connector shapes in group
-    for cxn_sp in grp_sp.findall("xdr:cxnSp", NS):
-        result = _parse_shape_element(cxn_sp, mode, is_cxn_sp=True)
+    for cxn_sp in grp_sp.iterfind("xdr:cxnSp", NS):
+        result = _parse_shape_element(cxn_sp, mode, is_cxn_sp=True, scale=scale)
         if result is not None:
             results.append(result)
 
     # Recursively parse nested groups
-    for nested_grp in grp_sp.findall("xdr:grpSp", NS):
-        results.extend(_parse_group_shapes(nested_grp, mode))
+    for nested_grp in grp_sp.iterfind("xdr:grpSp", NS):
+        results.extend(
+            _parse_group_shapes(nested_grp, mode, depth + 1, scale, include_paths)
+        )
 
     return results
 
 
-def _parse_anchor_shapes(anchor: Element, mode: str) -> list[_ShapeParseResult]:
+def _parse_anchor_shapes(
+    anchor: Element,
+    mode: str,
+    scale: PositionScale = PositionScale(),
+    include_paths: bool = False,
+) -> list[_ShapeParseResult]:
     """Parse all shapes within an anchor element.
 
     Args:
         anchor: Anchor element (twoCellAnchor, oneCellAnchor, absoluteAnchor).
         mode: Output mode.
+        scale: Output unit for positions and sizes.
+        include_paths: Keep simplified polylines of freeform geometry.
 
     Returns:
         List of ShapeParseResult.
@@ -582,81 +1019,260 @@ def _parse_anchor_shapes(anchor: Element, mode: str) -> list[_ShapeParseResult]:
     results: list[_ShapeParseResult] = []
 
     # Regular shapes
-    for sp in anchor.findall("xdr:sp", NS):
-        result = _parse_shape_element(sp, mode, is_cxn_sp=False)
+    for sp in anchor.iterfind("xdr:sp", NS):
+        result = _parse_shape_element(
+            sp, mode, is_cxn_sp=False, scale=scale, include_paths=include_paths
+        )
         if result is not None:
             results.append(result)
 
     # Connector shapes
-    for cxn_sp in anchor.findall("xdr:cxnSp", NS):
-        result = _parse_shape_element(cxn_sp, mode, is_cxn_sp=True)
+    for cxn_sp in anchor.iterfind("xdr:cxnSp", NS):
+        result = _parse_shape_element(cxn_sp, mode, is_cxn_sp=True, scale=scale)
         if result is not None:
             results.append(result)
 
     # Group shapes (flatten recursively)
-    for grp_sp in anchor.findall("xdr:grpSp", NS):
-        results.extend(_parse_group_shapes(grp_sp, mode))
+    for grp_sp in anchor.iterfind("xdr:grpSp", NS):
+        results.extend(
+            _parse_group_shapes(
+                grp_sp, mode, scale=scale, include_paths=include_paths
+            )
+        )
 
     return results
 
 
-def _assign_shape_ids(parse_results: list[_ShapeParseResult]) -> None:
+def _parse_ink_content(
+    alternate: Element,
+    mode: str,
+    scale: PositionScale = PositionScale(),
+    ink_parts: Mapping[str, bytes] | None = None,
+    include_paths: bool = False,
+) -> _ShapeParseResult | None:
+    """Parse an ink annotation from the Choice branch of AlternateContent.
+
+    Excel stores ink as an ``xdr14:contentPart`` referencing an InkML part,
+    with a picture rendering in the Fallback branch.
+
+    Args:
+        alternate: Top-level ``mc:AlternateContent`` element.
+        mode: Output mode.
+        scale: Output unit for positions and sizes.
+        ink_parts: InkML content by drawing relationship id.
+        include_paths: Decode the strokes into simplified polylines.
+
+    Returns:
+        ShapeParseResult for the ink, or None when the element is not ink.
+    """
+    content_part = None
+    for choice in alternate.iterfind(_MC_CHOICE):
+        content_part = choice.find("*/xdr14:contentPart", NS)
+        if content_part is not None:
+            break
+    if content_part is None:
+        return None
+    xfrm = content_part.find("xdr14:xfrm", NS)
+    pos = _get_xfrm_position(xfrm, scale)
+    if pos is None or not _should_include_shape(
+        "", "Ink", False, mode, has_geometry=True
+    ):
+        return None
+    cnv_pr = content_part.find("xdr14:nvContentPartPr/xdr14:cNvPr", NS)
+    shape_name = cnv_pr.get("name", "") if cnv_pr is not None else ""
+    strokes: list[list[Point]] = []
+    if include_paths and ink_parts is not None:
+        ink_xml = ink_parts.get(content_part.get(_R_ID, ""))
+        if ink_xml is not None:
+            strokes = ink_trace_paths(ink_xml)
+    left, top, width, height = pos
+    shape = Shape(
+        text="",
+        l=left,
+        t=top,
+        w=width if mode == "verbose" else None,
+        h=height if mode == "verbose" else None,
+        type="Ink",
+        geometry=_build_geometry("ink", strokes, pos, include_paths=include_paths),
+    )
+    rotation = _get_rotation(xfrm)
+    if rotation is not None:
+        shape.rotation = rotation
+    return _ShapeParseResult(
+        shape=shape,
+        excel_id=cnv_pr.get("id") if cnv_pr is not None else None,
+        excel_name=shape_name or None,
+        is_connector=False,
+        start_cxn_id=None,
+        end_cxn_id=None,
+    )
+
+
+def resolve_stable_ids(drawing_ids: Sequence[int | None]) -> list[int]:
+    """Map drawing (cNvPr) ids to emitted shape ids for ``stable_ids``.
+
+    Each shape keeps its drawing id. Shapes without one, or repeating an id
+    already taken, get ids above the largest drawing id so ids stay unique
+    within the sheet.
+
+    Args:
+        drawing_ids: Drawing id per emitted shape, in emission order.
+
+    Returns:
+        Shape id per input entry.
+    """
+    taken: set[int] = set()
+    kept: list[int | None] = []
+    for drawing_id in drawing_ids:
+        if drawing_id is not None and drawing_id > 0 and drawing_id not in taken:
+            taken.add(drawing_id)
+            kept.append(drawing_id)
+        else:
+            kept.append(None)
+    next_id = max(taken, default=0)
+    resolved: list[int] = []
+    for shape_id in kept:
+        if shape_id is None:
+            next_id += 1
+            shape_id = next_id
+        resolved.append(shape_id)
+    return resolved
+
+
+def _parse_drawing_id(excel_id: str | None) -> int | None:
+    if excel_id is None:
+        return None
+    try:
+        return int(excel_id)
+    except ValueError:
+        return None
+
+
+def _assign_shape_ids(
+    parse_results: list[_ShapeParseResult],
+    *,
+    stable_ids: bool = False,
+    part_name: str | None = None,
+) -> None:
     """Assign IDs to shapes and resolve connector endpoints.
 
     Args:
         parse_results: List of parse results to process (modified in place).
+        stable_ids: Use the cNvPr ids instead of sequential numbering.
+        part_name: Drawing part name recorded in ``source_id`` (stable ids).
     """
     excel_id_to_node_id: dict[str, int] = {}
-    node_index = 0
+    nodes = [
+        (result, result.excel_id)
+        for result in parse_results
+        if not result.is_connector and result.excel_id
+    ]
+    node_ids = (
+        resolve_stable_ids([_parse_drawing_id(excel_id) for _, excel_id in nodes])
+        if stable_ids
+        else range(1, len(nodes) + 1)
+    )
 
     # First pass: assign node IDs to non-connector shapes
-    for result in parse_results:
-        if not result.is_connector and result.excel_id:
-            node_index += 1
-            result.shape.id = node_index
-            excel_id_to_node_id[result.excel_id] = node_index
+    for (result, excel_id), node_id in zip(nodes, node_ids, strict=True):
+        result.shape.id = node_id
+        excel_id_to_node_id[excel_id] = node_id
+    if stable_ids and part_name is not None:
+        for result in parse_results:
+            if result.excel_id:
+                result.shape.source_id = f"{part_name}#{result.excel_id}"
 
     # Second pass: resolve connector endpoints
     for result in parse_results:
         if result.is_connector:
+            if not isinstance(result.shape, Arrow):
+                continue
             if result.start_cxn_id and result.start_cxn_id in excel_id_to_node_id:
                 result.shape.begin_id = excel_id_to_node_id[result.start_cxn_id]
             if result.end_cxn_id and result.end_cxn_id in excel_id_to_node_id:
                 result.shape.end_id = excel_id_to_node_id[result.end_cxn_id]
 
 
-def _parse_drawing_xml(drawing_xml: bytes, mode: str) -> list[Shape]:
+def _parse_drawing_xml(
+    drawing_xml: bytes,
+    mode: str,
+    *,
+    stable_ids: bool = False,
+    part_name: str | None = None,
+    scale: PositionScale = PositionScale(),
+    include_paths: bool = False,
+    ink_parts: Mapping[str, bytes] | None = None,
+    hyperlinks: Mapping[str, str] | None = None,
+) -> list[Shape | Arrow]:
     """Parse a drawing XML file and extract shapes.
 
     Args:
         drawing_xml: Raw XML content.
         mode: Output mode.
+        stable_ids: Use cNvPr ids as shape ids instead of sequential numbering.
+        part_name: Drawing part name for ``source_id`` (with ``stable_ids``).
+        scale: Output unit for positions and sizes.
+        include_paths: Keep simplified polylines of freeform and ink geometry.
+        ink_parts: InkML content by drawing relationship id.
+        hyperlinks: Hyperlink targets by drawing relationship id.
 
     Returns:
-        List of Shape models.
+        List of Shape and Arrow models.
     """
+    parse_results: list[_ShapeParseResult] = []
+
+    # Top-level anchors are parsed as their end tags stream in (document
+    # order, i.e. back-to-front stacking) and cleared, so the full tree is
+    # never held. Anchors wrapped in mc:AlternateContent use one branch only,
+    # except ink, which only the Choice branch describes.
+    depth = 0
     try:
-        root = ET.fromstring(drawing_xml)
+        for event, elem in iterparse_xml(drawing_xml, ("start", "end")):
+            if event == "start":
+                depth += 1
+                continue
+            depth -= 1
+            if depth != 1:
+                continue
+            if elem.tag == ALTERNATE_CONTENT:
+                ink = _parse_ink_content(
+                    elem, mode, scale, ink_parts, include_paths
+                )
+                if ink is not None:
+                    parse_results.append(ink)
+                    elem.clear()
+                    continue
+            for anchor in _top_level_anchors(elem):
+                resolve_alternate_content(anchor)
+                parse_results.extend(
+                    _parse_anchor_shapes(anchor, mode, scale, include_paths)
+                )
+            elem.clear()
     except ET.ParseError as e:
         logger.warning("Failed to parse drawing XML: %s", e)
         return []
 
-    parse_results: list[_ShapeParseResult] = []
+    for z_order, result in enumerate(parse_results, start=1):
+        result.shape.z_order = z_order
+        if result.link_id and hyperlinks and isinstance(result.shape, Shape):
+            result.shape.link = hyperlinks.get(result.link_id)
 
-    # Process all anchor types
-    anchor_xpaths = [
-        ".//xdr:twoCellAnchor",
-        ".//xdr:oneCellAnchor",
-        ".//xdr:absoluteAnchor",
-    ]
+    _assign_shape_ids(parse_results, stable_ids=stable_ids, part_name=part_name)
 
-    for anchor_xpath in anchor_xpaths:
-        for anchor in root.findall(anchor_xpath, NS):
-            parse_results.extend(_parse_anchor_shapes(anchor, mode))
+    return [r.shape for r in parse_results]
 
-    _assign_shape_ids(parse_results)
 
-    return [r.shape for r in parse_results]
+def _top_level_anchors(elem: Element) -> list[Element]:
+    """Return the anchors a top-level drawing element stands for."""
+    if elem.tag in _ANCHOR_TAGS:
+        return [elem]
+    if elem.tag == ALTERNATE_CONTENT:
+        return [
+            child
+            for child in select_alternate_content(elem)
+            if child.tag in _ANCHOR_TAGS
+        ]
+    return []
 
 
 def _get_sheet_drawing_map(xlsx_path: Path) -> dict[str, str]:
@@ -670,11 +1286,11 @@ def _get_sheet_drawing_map(xlsx_path: Path) -> dict[str, str]:
     """
     sheet_drawing_map: dict[str, str] = {}
 
-    with ZipFile(xlsx_path, "r") as zf:
+    with open_package(xlsx_path) as zf:
         # Read workbook.xml to get sheet names and rIds
         try:
-            workbook_xml = zf.read("xl/workbook.xml")
-            wb_root = ET.fromstring(workbook_xml)
+            workbook_xml = read_part(zf, "xl/workbook.xml")
+            wb_root = parse_xml(workbook_xml)
         except (KeyError, ET.ParseError):
             return sheet_drawing_map
 
@@ -693,8 +1309,8 @@ def _get_sheet_drawing_map(xlsx_path: Path) -> dict[str, str]:
 
         # Read workbook.xml.rels to map rId to sheet file
         try:
-            wb_rels_xml = zf.read("xl/_rels/workbook.xml.rels")
-            rels_root = ET.fromstring(wb_rels_xml)
+            wb_rels_xml = read_part(zf, "xl/_rels/workbook.xml.rels")
+            rels_root = parse_xml(wb_rels_xml)
         except (KeyError, ET.ParseError):
             return sheet_drawing_map
 
@@ -714,8 +1330,8 @@ def _get_sheet_drawing_map(xlsx_path: Path) -> dict[str, str]:
             ).replace(".xml", ".xml.rels")
 
             try:
-                sheet_rels_xml = zf.read(rels_path)
-                sheet_rels_root = ET.fromstring(sheet_rels_xml)
+                sheet_rels_xml = read_part(zf, rels_path)
+                sheet_rels_root = parse_xml(sheet_rels_xml)
             except (KeyError, ET.ParseError):
                 continue
 
@@ -731,24 +1347,90 @@ def _get_sheet_drawing_map(xlsx_path: Path) -> dict[str, str]:
     return sheet_drawing_map
 
 
+def _read_drawing_rels(zf: ZipFile, drawing_path: str) -> dict[str, tuple[str, str]]:
+    """Read a drawing's relationships.
+
+    Args:
+        zf: Open xlsx package.
+        drawing_path: Drawing part path within the zip.
+
+    Returns:
+        Dict mapping relationship id to (type name, raw target), where the
+        type name is the last segment of the relationship type URI (e.g.
+        ``hyperlink``, ``ink``).
+    """
+    drawing = PurePosixPath(drawing_path)
+    rels_path = f"{drawing.parent}/_rels/{drawing.name}.rels"
+    try:
+        rels_root = parse_xml(read_part(zf, rels_path))
+    except (KeyError, ET.ParseError):
+        return {}
+    return {
+        rel.get("Id", ""): (
+            rel.get("Type", "").rsplit("/", 1)[-1],
+            rel.get("Target", ""),
+        )
+        for rel in rels_root.findall("Relationship", _RELS_NS)
+    }
+
+
+def _load_ink_parts(
+    zf: ZipFile, drawing_path: str, rels: Mapping[str, tuple[str, str]]
+) -> dict[str, bytes]:
+    """Read the InkML parts a drawing references, keyed by relationship id.
+
+    Args:
+        zf: Open xlsx package.
+        drawing_path: Drawing part path within the zip.
+        rels: Drawing relationships from ``_read_drawing_rels``.
+
+    Returns:
+        Dict mapping relationship id to InkML content.
+    """
+    base_dir = str(PurePosixPath(drawing_path).parent)
+    ink_parts: dict[str, bytes] = {}
+    for r_id, (rel_type, target) in rels.items():
+        if rel_type != "ink":
+            continue
+        part = _resolve_relative_path(target, base_dir)
+        try:
+            ink_parts[r_id] = read_part(zf, part)
+        except KeyError:
+            logger.debug("Ink part not found: %s", part)
+    return ink_parts
+
+
 def get_shapes_ooxml(
-    xlsx_path: str | Path, mode: Literal["light", "standard", "verbose"] = "standard"
-) -> dict[str, list[Shape]]:
+    xlsx_path: str | Path,
+    mode: Literal["light", "standard", "verbose"] = "standard",
+    *,
+    stable_ids: bool = False,
+    scale: PositionScale = PositionScale(),
+    include_paths: bool = False,
+) -> dict[str, list[Shape | Arrow]]:
     """Extract shapes from xlsx file using OOXML parsing.
 
     This function provides COM-free shape extraction for Linux/macOS.
+    Freeform shapes and ink annotations carry ``geometry`` with their path
+    bounding box; shapes with a click hyperlink or an assigned macro carry
+    ``link`` / ``on_action``.
 
     Args:
         xlsx_path: Path to xlsx file.
         mode: Output mode (light, standard, verbose).
+        stable_ids: Use cNvPr ids as shape ids and record ``source_id``.
+        scale: Output unit for positions and sizes (pixels at 96 DPI by
+            default).
+        include_paths: Also emit simplified polylines of freeform paths and
+            ink strokes (``geometry.paths``).
 
     Returns:
-        Dict mapping sheet name to list of Shape models.
+        Dict mapping sheet name to list of Shape and Arrow models.
     """
     xlsx_path = Path(xlsx_path)
-    result: dict[str, list[Shape]] = {}
+    result: dict[str, list[Shape | Arrow]] = {}
 
-    if not xlsx_path.exists():
+    if not source_exists(xlsx_path):
         logger.warning("File not found: %s", xlsx_path)
         return result
 
@@ -758,11 +1440,29 @@ def get_shapes_ooxml(
 
     sheet_drawing_map = _get_sheet_drawing_map(xlsx_path)
 
-    with ZipFile(xlsx_path, "r") as zf:
+    with open_package(xlsx_path) as zf:
         for sheet_name, drawing_path in sheet_drawing_map.items():
             try:
-                drawing_xml = zf.read(drawing_path)
-                shapes = _parse_drawing_xml(drawing_xml, mode)
+                drawing_xml = read_part(zf, drawing_path)
+                rels = _read_drawing_rels(zf, drawing_path)
+                shapes = _parse_drawing_xml(
+                    drawing_xml,
+                    mode,
+                    stable_ids=stable_ids,
+                    part_name=drawing_path,
+                    scale=scale,
+                    include_paths=include_paths,
+                    ink_parts=(
+                        _load_ink_parts(zf, drawing_path, rels)
+                        if include_paths
+                        else None
+                    ),
+                    hyperlinks={
+                        r_id: target
+                        for r_id, (rel_type, target) in rels.items()
+                        if rel_type == "hyperlink"
+                    },
+                )
                 result[sheet_name] = shapes
             except KeyError:
                 logger.debug("Drawing not found: %s", drawing_path)
@@ -0,0 +1,223 @@
+"""Geometry of freeform (``a:custGeom``) shapes and ink annotations.
+
+Paths are flattened to polylines in coordinates normalized to the shape box
+(0.0-1.0 on both axes): straight segments keep their vertices, and Bezier
+curves and arcs are sampled. ``drawing.py`` maps them onto the sheet and
+derives the bounding box and the simplified polylines of ``ShapeGeometry``.
+
+Ink is stored as InkML (``xl/ink/inkN.xml``) referenced from an
+``xdr14:contentPart``; traces may use first and second difference encoding
+(``'`` and ``"`` prefixes), which is decoded here.
+"""
+
+from __future__ import annotations
+
+import math
+import re
+from typing import TYPE_CHECKING
+import xml.etree.ElementTree as ET
+
+from exstruct.ooxml.safety import parse_xml
+
+if TYPE_CHECKING:
+    from collections.abc import Sequence
+    from xml.etree.ElementTree import Element
+
+Point = tuple[float, float]
+
+_A_NS = "http://schemas.openxmlformats.org/drawingml/2006/main"
+_INKML_TRACE = "{http://www.w3.org/2003/InkML}trace"
+# Bezier curves and arcs are sampled with this many segments.
+_CURVE_STEPS = 8
+# Arc angles are stored in 60000ths of a degree.
+_ANGLE_UNIT = 60000.0
+_INK_VALUE = re.compile(r"""([!'"]?)\s*(-?(?:\d+\.?\d*|\.\d+)(?:[eE][-+]?\d+)?)""")
+
+
+def custom_geometry_paths(
+    cust_geom: Element, default_size: tuple[float, float]
+) -> list[list[Point]]:
+    """Flatten the paths of an ``a:custGeom`` element.
+
+    Args:
+        cust_geom: ``a:custGeom`` element.
+        default_size: Shape extent (EMU) used for paths without ``w``/``h``.
+
+    Returns:
+        One normalized polyline per subpath (``moveTo`` starts a new one).
+        Points given as guide names instead of numbers are skipped.
+    """
+    polylines: list[list[Point]] = []
+    for path in cust_geom.iterfind(f"{{{_A_NS}}}pathLst/{{{_A_NS}}}path"):
+        width = _number(path.get("w")) or default_size[0] or 1.0
+        height = _number(path.get("h")) or default_size[1] or 1.0
+        current: list[Point] = []
+        for command in path:
+            name = command.tag.rpartition("}")[2]
+            points = [
+                (x / width, y / height)
+                for x, y in (
+                    (_number(pt.get("x")), _number(pt.get("y")))
+                    for pt in command.iterfind(f"{{{_A_NS}}}pt")
+                )
+                if x is not None and y is not None
+            ]
+            if name == "moveTo":
+                if len(current) > 1:
+                    polylines.append(current)
+                current = points[:1]
+            elif name == "lnTo":
+                current.extend(points[:1])
+            elif name in ("cubicBezTo", "quadBezTo") and current and points:
+                current.extend(_sample_bezier([current[-1], *points]))
+            elif name == "arcTo" and current:
+                current.extend(_sample_arc(command, current[-1], width, height))
+            elif name == "close" and current:
+                current.append(current[0])
+        if len(current) > 1:
+            polylines.append(current)
+    return polylines
+
+
+def ink_trace_paths(ink_xml: bytes) -> list[list[Point]]:
+    """Decode the strokes of an InkML part.
+
+    Args:
+        ink_xml: Raw ``xl/ink/inkN.xml`` content.
+
+    Returns:
+        One polyline per trace, normalized to the bounding box of all traces;
+        empty when the part cannot be parsed.
+    """
+    try:
+        root = parse_xml(ink_xml)
+    except ET.ParseError:
+        return []
+    traces = [_decode_trace(trace.text or "") for trace in root.iter(_INKML_TRACE)]
+    traces = [trace for trace in traces if trace]
+    if not traces:
+        return []
+    xs = [x for trace in traces for x, _ in trace]
+    ys = [y for trace in traces for _, y in trace]
+    min_x, min_y = min(xs), min(ys)
+    span_x = (max(xs) - min_x) or 1.0
+    span_y = (max(ys) - min_y) or 1.0
+    return [
+        [((x - min_x) / span_x, (y - min_y) / span_y) for x, y in trace]
+        for trace in traces
+    ]
+
+
+def simplify_polyline(points: Sequence[Point], tolerance: float) -> list[Point]:
+    """Drop points closer than ``tolerance`` to the simplified line.
+
+    Iterative Ramer-Douglas-Peucker; the first and last points are kept.
+    """
+    if len(points) < 3:
+        return list(points)
+    keep = [False] * len(points)
+    keep[0] = keep[-1] = True
+    stack = [(0, len(points) - 1)]
+    while stack:
+        start, end = stack.pop()
+        index, distance = 0, 0.0
+        for i in range(start + 1, end):
+            d = _segment_distance(points[i], points[start], points[end])
+            if d > distance:
+                index, distance = i, d
+        if distance > tolerance:
+            keep[index] = True
+            stack.extend([(start, index), (index, end)])
+    return [point for point, kept in zip(points, keep, strict=True) if kept]
+
+
+def _number(value: str | None) -> float | None:
+    if value is None:
+        return None
+    try:
+        return float(value)
+    except ValueError:
+        return None
+
+
+def _sample_bezier(control: Sequence[Point]) -> list[Point]:
+    """Points along a quadratic or cubic Bezier, excluding its start."""
+    samples: list[Point] = []
+    for step in range(1, _CURVE_STEPS + 1):
+        t = step / _CURVE_STEPS
+        level = list(control)
+        while len(level) > 1:
+            level = [
+                (a[0] + (b[0] - a[0]) * t, a[1] + (b[1] - a[1]) * t)
+                for a, b in zip(level, level[1:], strict=False)
+            ]
+        samples.append(level[0])
+    return samples
+
+
+def _sample_arc(
+    command: Element, start: Point, width: float, height: float
+) -> list[Point]:
+    """Points along an ``a:arcTo`` continuing from ``start``."""
+    rx = (_number(command.get("wR")) or 0.0) / width
+    ry = (_number(command.get("hR")) or 0.0) / height
+    start_angle = math.radians((_number(command.get("stAng")) or 0.0) / _ANGLE_UNIT)
+    sweep = math.radians((_number(command.get("swAng")) or 0.0) / _ANGLE_UNIT)
+    cx = start[0] - rx * math.cos(start_angle)
+    cy = start[1] - ry * math.sin(start_angle)
+    return [
+        (
+            cx + rx * math.cos(start_angle + sweep * step / _CURVE_STEPS),
+            cy + ry * math.sin(start_angle + sweep * step / _CURVE_STEPS),
+        )
+        for step in range(1, _CURVE_STEPS + 1)
+    ]
+
+
+def _decode_trace(text: str) -> list[Point]:
+    """Decode X/Y of an InkML trace with explicit and difference encoding."""
+    modes: list[str] = []
+    values: list[float] = []
+    velocities: list[float] = []
+    points: list[Point] = []
+    for raw_point in text.split(","):
+        tokens = _INK_VALUE.findall(raw_point)
+        if len(tokens) < 2:
+            continue
+        for channel, (prefix, number) in enumerate(tokens[:2]):
+            if channel == len(modes):
+                modes.append("!")
+                values.append(0.0)
+                velocities.append(0.0)
+            if prefix:
+                modes[channel] = prefix
+            amount = float(number)
+            if modes[channel] == "'":
+                velocities[channel] = amount
+            elif modes[channel] == '"':
+                velocities[channel] += amount
+            else:
+                velocities[channel] = amount - values[channel] if points else 0.0
+                values[channel] = amount
+                continue
+            values[channel] += velocities[channel]
+        points.append((values[0], values[1]))
+    return points
+
+
+def _segment_distance(point: Point, start: Point, end: Point) -> float:
+    dx, dy = end[0] - start[0], end[1] - start[1]
+    length = dx * dx + dy * dy
+    if length == 0:
+        return math.dist(point, start)
+    t = ((point[0] - start[0]) * dx + (point[1] - start[1]) * dy) / length
+    t = max(0.0, min(1.0, t))
+    return math.dist(point, (start[0] + t * dx, start[1] + t * dy))
+
+
+__all__ = [
+    "Point",
+    "custom_geometry_paths",
+    "ink_trace_paths",
+    "simplify_polyline",
+]
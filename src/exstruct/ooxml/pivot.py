@@ -0,0 +1,171 @@
+"""Pivot cache records read from the xlsx package (``include_pivot_caches``).
+
+A pivot table keeps a copy of its source rows in ``pivotCacheRecords*.xml``,
+with repeated values stored once in the cache definition's ``sharedItems`` and
+referenced by index. When the source sheet or range has been deleted, or lives
+in another workbook, this cache is the only remaining copy of the data.
+"""
+
+from __future__ import annotations
+
+from pathlib import Path
+import posixpath
+from xml.etree import ElementTree as ET
+from zipfile import ZipFile, is_zipfile
+
+from exstruct.models import CellValue, PivotCache
+from exstruct.ooxml.chart import _read_sheets_info
+from exstruct.ooxml.safety import iterparse_part, open_package, parse_part
+from exstruct.sources import open_source
+
+_MAIN_NS = "http://schemas.openxmlformats.org/spreadsheetml/2006/main"
+_REL_NS = "http://schemas.openxmlformats.org/officeDocument/2006/relationships"
+_RELS_NS = "http://schemas.openxmlformats.org/package/2006/relationships"
+_M = f"{{{_MAIN_NS}}}"
+
+
+def read_pivot_caches(
+    file_path: Path, *, orphaned_only: bool = True
+) -> list[PivotCache]:
+    """Rebuild the source records kept in the workbook's pivot caches.
+
+    Args:
+        file_path: Workbook path (.xlsx/.xlsm).
+        orphaned_only: Only return caches whose source no longer exists in the
+            workbook (deleted sheet, sheet without the named range, or an
+            external workbook).
+
+    Returns:
+        One ``PivotCache`` per cache in workbook order. Non-OOXML workbooks
+        (.xls) and workbooks without pivot tables yield an empty list.
+    """
+    if not is_zipfile(open_source(file_path)):
+        return []
+    caches: list[PivotCache] = []
+    with open_package(file_path) as zf:
+        names = set(zf.namelist())
+        if "xl/workbook.xml" not in names:
+            return []
+        workbook = parse_part(zf, "xl/workbook.xml")
+        sheets = set(_read_sheets_info(zf).values())
+        defined = _source_names(zf, workbook, names)
+        targets = _relationships(zf, "xl/_rels/workbook.xml.rels", "xl", names)
+        for element in workbook.iter(f"{_M}pivotCache"):
+            definition = targets.get(element.get(f"{{{_REL_NS}}}id", ""))
+            if definition is None or definition not in names:
+                continue
+            cache = _read_cache(zf, int(element.get("cacheId", "0")), definition, names)
+            cache.source_missing = _source_missing(cache, sheets, defined)
+            if cache.source_missing or not orphaned_only:
+                caches.append(cache)
+    return caches
+
+
+def _read_cache(
+    zf: ZipFile, cache_id: int, definition: str, names: set[str]
+) -> PivotCache:
+    """Read one cache definition and its records part."""
+    root = parse_part(zf, definition)
+    cache = PivotCache(cache_id=cache_id)
+    source = root.find(f"{_M}cacheSource")
+    if source is not None and source.get("type", "worksheet") != "worksheet":
+        cache.source_external = True
+    worksheet = source.find(f"{_M}worksheetSource") if source is not None else None
+    if worksheet is not None:
+        cache.source_sheet = worksheet.get("sheet")
+        cache.source_range = worksheet.get("ref")
+        cache.source_name = worksheet.get("name")
+        cache.source_external = cache.source_external or bool(
+            worksheet.get(f"{{{_REL_NS}}}id")
+        )
+    shared: list[list[CellValue | None]] = []
+    for field in root.iter(f"{_M}cacheField"):
+        cache.columns.append(field.get("name", ""))
+        items = field.find(f"{_M}sharedItems")
+        shared.append([] if items is None else [_item_value(item) for item in items])
+    base_dir = posixpath.dirname(definition)
+    rels = f"{base_dir}/_rels/{posixpath.basename(definition)}.rels"
+    records = next(iter(_relationships(zf, rels, base_dir, names).values()), None)
+    if records is not None and records in names:
+        cache.records = _read_records(zf, records, shared)
+    return cache
+
+
+def _read_records(
+    zf: ZipFile, part: str, shared: list[list[CellValue | None]]
+) -> list[list[CellValue | None]]:
+    """Stream ``<r>`` rows, resolving ``<x v="i"/>`` through shared items."""
+    records: list[list[CellValue | None]] = []
+    for _, element in iterparse_part(zf, part, ("end",)):
+        if element.tag != f"{_M}r":
+            continue
+        row: list[CellValue | None] = []
+        for index, item in enumerate(element):
+            if item.tag == f"{_M}x":
+                items = shared[index] if index < len(shared) else []
+                position = int(item.get("v", "-1"))
+                row.append(items[position] if 0 <= position < len(items) else None)
+            else:
+                row.append(_item_value(item))
+        records.append(row)
+        element.clear()
+    return records
+
+
+def _item_value(item: ET.Element) -> CellValue | None:
+    """Convert a cache item (``s``, ``n``, ``d``, ``b``, ``e``, ``m``)."""
+    kind = item.tag.removeprefix(_M)
+    value = item.get("v")
+    if kind == "m" or value is None:
+        return None
+    if kind == "n":
+        number = float(value)
+        return int(number) if number.is_integer() else number
+    if kind == "d":
+        return value.removesuffix("T00:00:00")
+    if kind == "b":
+        return "TRUE" if value in ("1", "true") else "FALSE"
+    return value
+
+
+def _source_missing(cache: PivotCache, sheets: set[str], defined: set[str]) -> bool:
+    if cache.source_external:
+        return True
+    if cache.source_sheet is not None:
+        return cache.source_sheet not in sheets
+    if cache.source_name is not None:
+        return cache.source_name not in defined
+    return False
+
+
+def _source_names(zf: ZipFile, workbook: ET.Element, names: set[str]) -> set[str]:
+    """Defined names and Excel table names a cache source may refer to."""
+    found = {
+        element.get("name", "") for element in workbook.iter(f"{_M}definedName")
+    }
+    for part in names:
+        if part.startswith("xl/tables/") and part.endswith(".xml"):
+            table = parse_part(zf, part)
+            found.add(table.get("displayName") or table.get("name") or "")
+    found.discard("")
+    return found
+
+
+def _relationships(
+    zf: ZipFile, rels_path: str, base_dir: str, names: set[str]
+) -> dict[str, str]:
+    """Map relationship ids of a rels part to package part names."""
+    if rels_path not in names:
+        return {}
+    targets: dict[str, str] = {}
+    for rel in parse_part(zf, rels_path).iter(f"{{{_RELS_NS}}}Relationship"):
+        target = rel.get("Target", "")
+        if rel.get("TargetMode") == "External" or not target:
+            continue
+        path = (
+            target.lstrip("/")
+            if target.startswith("/")
+            else posixpath.normpath(f"{base_dir}/{target}")
+        )
+        targets[rel.get("Id", "")] = path
+    return targets
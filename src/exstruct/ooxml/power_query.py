@@ -0,0 +1,140 @@
+"""Power Query definitions read from the DataMashup part (``include_power_queries``).
+
+Excel stores Get & Transform queries in a ``customXml/item*.xml`` part whose
+root is ``<DataMashup>``. Its text is base64 of a binary stream that starts
+with a version and a length-prefixed zip package; ``Formulas/Section1.m`` in
+that package holds every query as a ``shared <name> = <expression>;`` member.
+"""
+
+from __future__ import annotations
+
+import base64
+import binascii
+import io
+from pathlib import Path
+import re
+import struct
+from xml.etree import ElementTree as ET
+from zipfile import BadZipFile, ZipFile, is_zipfile
+
+from exstruct.models import PowerQuery
+from exstruct.ooxml.safety import check_package, open_package, parse_part, read_part
+from exstruct.sources import open_source
+
+_MASHUP_TAG = "{http://schemas.microsoft.com/DataMashup}DataMashup"
+_SECTION_PART = "Formulas/Section1.m"
+_SHARED = re.compile(
+    r'(?:\s|//[^\n]*|/\*.*?\*/)*'  # leading comments (query descriptions)
+    r'shared\s+(#"(?:[^"]|"")*"|[A-Za-z_][\w.]*)\s*=\s*(.*)',
+    re.DOTALL,
+)
+
+
+def read_power_queries(file_path: Path) -> list[PowerQuery]:
+    """List the workbook's Power Query names and M source code.
+
+    Args:
+        file_path: Workbook path (.xlsx/.xlsm).
+
+    Returns:
+        One ``PowerQuery`` per query in section order. Non-OOXML workbooks
+        (.xls), workbooks without queries, and undecodable mashups yield an
+        empty list.
+    """
+    if not is_zipfile(open_source(file_path)):
+        return []
+    with open_package(file_path) as zf:
+        for name in sorted(zf.namelist()):
+            if not (name.startswith("customXml/item") and name.endswith(".xml")):
+                continue
+            try:
+                root = parse_part(zf, name)
+            except ET.ParseError:
+                continue
+            if root.tag == _MASHUP_TAG:
+                return _decode_mashup(root.text or "")
+    return []
+
+
+def _decode_mashup(text: str) -> list[PowerQuery]:
+    """Decode the base64 stream and parse ``Section1.m`` of its package."""
+    try:
+        data = base64.b64decode("".join(text.split()), validate=True)
+        _, size = struct.unpack_from("<ii", data, 0)
+        package = data[8 : 8 + size]
+        with ZipFile(io.BytesIO(package)) as zf:
+            check_package(zf)
+            source = read_part(zf, _SECTION_PART).decode("utf-8-sig")
+    except (binascii.Error, struct.error, BadZipFile, KeyError, UnicodeDecodeError):
+        return []
+    return parse_section(source)
+
+
+def parse_section(source: str) -> list[PowerQuery]:
+    """Split an M section document into its ``shared`` members.
+
+    Args:
+        source: Text of ``Section1.m`` (``section Section1; shared ...;``).
+
+    Returns:
+        Queries in document order; the expression excludes the trailing ``;``.
+    """
+    queries: list[PowerQuery] = []
+    for statement in _statements(source):
+        match = _SHARED.match(statement)
+        if match is None:
+            continue
+        name = match.group(1)
+        if name.startswith("#"):
+            name = name[2:-1].replace('""', '"')
+        queries.append(PowerQuery(name=name, formula=match.group(2).strip()))
+    return queries
+
+
+def _statements(source: str) -> list[str]:
+    """Split on ``;`` outside string literals, quoted identifiers, and comments."""
+    statements: list[str] = []
+    current: list[str] = []
+    index = 0
+    while index < len(source):
+        char = source[index]
+        if source.startswith("//", index):
+            end = source.find("\n", index)
+            end = len(source) if end < 0 else end
+            current.append(source[index:end])
+            index = end
+            continue
+        if source.startswith("/*", index):
+            end = source.find("*/", index + 2)
+            end = len(source) if end < 0 else end + 2
+            current.append(source[index:end])
+            index = end
+            continue
+        if char == '"':
+            end = _string_end(source, index)
+            current.append(source[index:end])
+            index = end
+            continue
+        if char == ";":
+            statements.append("".join(current).strip())
+            current = []
+        else:
+            current.append(char)
+        index += 1
+    tail = "".join(current).strip()
+    if tail:
+        statements.append(tail)
+    return statements
+
+
+def _string_end(source: str, start: int) -> int:
+    """Index just past the string literal opening at ``start`` (``""`` escapes)."""
+    index = start + 1
+    while index < len(source):
+        if source[index] == '"':
+            if source.startswith('""', index):
+                index += 2
+                continue
+            return index + 1
+        index += 1
+    return len(source)
@@ -0,0 +1,192 @@
+"""Bounded access to OOXML packages from untrusted sources.
+
+Every raw OOXML read goes through these helpers so that zip bombs (huge
+uncompressed sizes or extreme compression ratios) and XML entity-expansion
+attacks fail with UnsafeWorkbookError instead of exhausting memory.
+"""
+
+from __future__ import annotations
+
+from collections.abc import Iterator
+from contextlib import contextmanager
+import io
+from pathlib import Path
+from xml.etree import ElementTree as ET
+from zipfile import ZipFile, ZipInfo
+
+from defusedxml import DefusedXmlException
+from defusedxml import ElementTree as SafeET
+
+from exstruct.errors import UnsafeWorkbookError
+from exstruct.sources import open_source
+
+MAX_PART_BYTES = 512 * 1024 * 1024  # uncompressed size of one part
+MAX_PACKAGE_BYTES = 2 * 1024 * 1024 * 1024  # uncompressed size of all parts
+MAX_COMPRESSION_RATIO = 200  # uncompressed/compressed, for parts >= 1 MiB
+MAX_PARTS = 100_000
+
+_RATIO_MIN_BYTES = 1024 * 1024
+_CHUNK_SIZE = 1024 * 1024
+
+
+def check_package(zf: ZipFile) -> None:
+    """Validate declared part sizes and compression ratios of a package.
+
+    Args:
+        zf: Open package.
+
+    Raises:
+        UnsafeWorkbookError: If the package exceeds a size or ratio limit.
+    """
+    infos = zf.infolist()
+    if len(infos) > MAX_PARTS:
+        raise UnsafeWorkbookError(
+            f"Package has {len(infos)} parts (limit {MAX_PARTS})."
+        )
+    total = 0
+    for info in infos:
+        _check_part(info)
+        total += info.file_size
+    if total > MAX_PACKAGE_BYTES:
+        raise UnsafeWorkbookError(
+            f"Package expands to {total} bytes (limit {MAX_PACKAGE_BYTES})."
+        )
+
+
+def check_workbook_file(file_path: Path) -> None:
+    """Validate a workbook package; non-zip files are ignored.
+
+    Raises:
+        UnsafeWorkbookError: If the package exceeds a size or ratio limit.
+    """
+    try:
+        zf = ZipFile(open_source(file_path), "r")
+    except (OSError, ValueError):
+        return
+    with zf:
+        check_package(zf)
+
+
+@contextmanager
+def open_package(file_path: Path) -> Iterator[ZipFile]:
+    """Open a package for reading after validating it with check_package."""
+    with ZipFile(open_source(file_path), "r") as zf:
+        check_package(zf)
+        yield zf
+
+
+def read_part(zf: ZipFile, name: str, *, max_bytes: int = MAX_PART_BYTES) -> bytes:
+    """Read one part, aborting once more than ``max_bytes`` are decompressed.
+
+    Raises:
+        KeyError: If the part does not exist.
+        UnsafeWorkbookError: If the part is larger than allowed.
+    """
+    _check_part(zf.getinfo(name), max_bytes=max_bytes)
+    chunks: list[bytes] = []
+    size = 0
+    with zf.open(name) as stream:
+        while chunk := stream.read(_CHUNK_SIZE):
+            size += len(chunk)
+            if size > max_bytes:
+                raise UnsafeWorkbookError(
+                    f"Part {name} expands beyond {max_bytes} bytes."
+                )
+            chunks.append(chunk)
+    return b"".join(chunks)
+
+
+def parse_xml(data: bytes | str) -> ET.Element:
+    """Parse XML while rejecting DTDs and entity declarations.
+
+    Raises:
+        xml.etree.ElementTree.ParseError: If the XML is malformed, including
+            an unknown or unsupported declared encoding.
+        UnsafeWorkbookError: If the XML declares a DTD or entities.
+    """
+    try:
+        root: ET.Element = SafeET.fromstring(data, forbid_dtd=True)
+    except DefusedXmlException as exc:
+        raise UnsafeWorkbookError(f"Rejected XML declaration: {exc}") from exc
+    except (LookupError, ValueError) as exc:
+        raise _encoding_error(exc) from exc
+    return root
+
+
+def parse_part(zf: ZipFile, name: str) -> ET.Element:
+    """Read and parse one XML part with read_part and parse_xml."""
+    return parse_xml(read_part(zf, name))
+
+
+def iterparse_part(
+    zf: ZipFile, name: str, events: tuple[str, ...]
+) -> Iterator[tuple[str, ET.Element]]:
+    """Stream-parse one XML part, rejecting DTDs and entity declarations.
+
+    Raises:
+        xml.etree.ElementTree.ParseError: If the XML is malformed.
+        UnsafeWorkbookError: If the part is too large or declares a DTD.
+    """
+    _check_part(zf.getinfo(name))
+    with zf.open(name) as stream:
+        try:
+            yield from SafeET.iterparse(stream, events=events, forbid_dtd=True)
+        except DefusedXmlException as exc:
+            raise UnsafeWorkbookError(f"Rejected XML declaration: {exc}") from exc
+        except (LookupError, ValueError) as exc:
+            raise _encoding_error(exc) from exc
+
+
+def iterparse_xml(
+    data: bytes, events: tuple[str, ...]
+) -> Iterator[tuple[str, ET.Element]]:
+    """Stream-parse in-memory XML, rejecting DTDs and entity declarations.
+
+    Lets callers clear elements as they go instead of holding the whole tree.
+
+    Raises:
+        xml.etree.ElementTree.ParseError: If the XML is malformed.
+        UnsafeWorkbookError: If the XML declares a DTD or entities.
+    """
+    try:
+        yield from SafeET.iterparse(io.BytesIO(data), events=events, forbid_dtd=True)
+    except DefusedXmlException as exc:
+        raise UnsafeWorkbookError(f"Rejected XML declaration: {exc}") from exc
+    except (LookupError, ValueError) as exc:
+        raise _encoding_error(exc) from exc
+
+
+def _encoding_error(exc: Exception) -> ET.ParseError:
+    """Wrap pyexpat's LookupError/ValueError for a bad declared encoding."""
+    return ET.ParseError(f"unsupported XML encoding: {exc}")
+
+
+def _check_part(info: ZipInfo, *, max_bytes: int = MAX_PART_BYTES) -> None:
+    if info.file_size > max_bytes:
+        raise UnsafeWorkbookError(
+            f"Part {info.filename} expands to {info.file_size} bytes "
+            f"(limit {max_bytes})."
+        )
+    if (
+        info.file_size >= _RATIO_MIN_BYTES
+        and info.file_size > info.compress_size * MAX_COMPRESSION_RATIO
+    ):
+        raise UnsafeWorkbookError(
+            f"Part {info.filename} has a compression ratio above "
+            f"{MAX_COMPRESSION_RATIO}:1."
+        )
+
+
+__all__ = [
+    "MAX_COMPRESSION_RATIO",
+    "MAX_PACKAGE_BYTES",
+    "MAX_PART_BYTES",
+    "MAX_PARTS",
+    "check_package",
+    "check_workbook_file",
+    "iterparse_part",
+    "open_package",
+    "parse_part",
+    "parse_xml",
+    "read_part",
+]
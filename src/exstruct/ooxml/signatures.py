@@ -0,0 +1,233 @@
+"""Package digital signatures for the security report (``include_security_report``).
+
+Office signs a workbook with XML-DSig parts under ``_xmlsignatures/``, reached
+from the package ``_rels/.rels`` through the ``origin.sigs`` part. Each
+signature's ``Manifest`` lists the signed parts with their digests; a part
+whose current digest differs was modified after signing. The signature value
+itself is not verified cryptographically, only the part digests.
+"""
+
+from __future__ import annotations
+
+import base64
+import binascii
+import hashlib
+from pathlib import Path
+import posixpath
+from xml.etree import ElementTree as ET
+from zipfile import ZipFile, is_zipfile
+
+from exstruct.models import DigitalSignature, SecurityReport
+from exstruct.ooxml.pivot import _relationships
+from exstruct.ooxml.safety import open_package, parse_part, read_part
+from exstruct.sources import open_source
+
+_DSIG = "{http://www.w3.org/2000/09/xmldsig#}"
+_XADES = "{http://uri.etsi.org/01903/v1.3.2#}"
+_OFFICE = "{http://schemas.microsoft.com/office/2006/digsig}"
+_MDSSI = "{http://schemas.openxmlformats.org/package/2006/digital-signature}"
+_ORIGIN_TYPE = (
+    "http://schemas.openxmlformats.org/package/2006/relationships/"
+    "digital-signature/origin"
+)
+_DIGESTS = {
+    "http://www.w3.org/2000/09/xmldsig#sha1": "sha1",
+    "http://www.w3.org/2001/04/xmlenc#sha256": "sha256",
+    "http://www.w3.org/2001/04/xmldsig-more#sha384": "sha384",
+    "http://www.w3.org/2001/04/xmlenc#sha512": "sha512",
+}
+# Attribute type OIDs of X.500 names, shortened as in RFC 4514.
+_NAME_KEYS = {
+    "2.5.4.3": "CN",
+    "2.5.4.6": "C",
+    "2.5.4.7": "L",
+    "2.5.4.8": "ST",
+    "2.5.4.10": "O",
+    "2.5.4.11": "OU",
+    "1.2.840.113549.1.9.1": "E",
+}
+
+
+def read_security_report(file_path: Path) -> SecurityReport:
+    """Report the package's digital signatures and parts changed after signing.
+
+    Args:
+        file_path: Workbook path (.xlsx/.xlsm).
+
+    Returns:
+        ``SecurityReport``; unsigned and non-OOXML workbooks report no
+        signatures.
+    """
+    report = SecurityReport()
+    if not is_zipfile(open_source(file_path)):
+        return report
+    with open_package(file_path) as zf:
+        names = set(zf.namelist())
+        for part in _signature_parts(zf, names):
+            try:
+                signature = _read_signature(zf, part, names)
+            except ET.ParseError:
+                continue
+            report.signatures.append(signature)
+        signed = {
+            name for signature in report.signatures for name in signature.signed_parts
+        }
+        if report.signatures:
+            report.unsigned_parts = sorted(
+                name
+                for name in names
+                if name not in signed
+                and name != "[Content_Types].xml"
+                and not name.startswith("_xmlsignatures/")
+                and not name.endswith("/")
+            )
+    report.signed = bool(report.signatures)
+    return report
+
+
+def _signature_parts(zf: ZipFile, names: set[str]) -> list[str]:
+    """Signature parts referenced from the package's signature origin part."""
+    if "_rels/.rels" not in names:
+        return []
+    origins = [
+        rel.get("Target", "").lstrip("/")
+        for rel in parse_part(zf, "_rels/.rels").iter(
+            "{http://schemas.openxmlformats.org/package/2006/relationships}"
+            "Relationship"
+        )
+        if rel.get("Type") == _ORIGIN_TYPE
+    ]
+    parts: list[str] = []
+    for origin in origins:
+        base_dir = posixpath.dirname(origin)
+        rels = f"{base_dir}/_rels/{posixpath.basename(origin)}.rels"
+        parts.extend(sorted(_relationships(zf, rels, base_dir, names).values()))
+    return [part for part in parts if part in names]
+
+
+def _read_signature(zf: ZipFile, part: str, names: set[str]) -> DigitalSignature:
+    """Read signer info and check each manifest digest of one signature."""
+    root = parse_part(zf, part)
+    signature = DigitalSignature(part=part)
+    certificate = root.find(f".//{_DSIG}X509Certificate")
+    if certificate is not None and certificate.text:
+        _apply_certificate(signature, certificate.text)
+    signing_time = root.find(f".//{_XADES}SigningTime")
+    if signing_time is None:
+        signing_time = root.find(f".//{_MDSSI}SignatureTime/{_MDSSI}Value")
+    if signing_time is not None and signing_time.text:
+        signature.signed_at = signing_time.text.strip()
+    comment = root.find(f".//{_OFFICE}SignatureComments")
+    if comment is not None and comment.text:
+        signature.comment = comment.text
+    for reference in root.iterfind(f".//{_DSIG}Manifest/{_DSIG}Reference"):
+        target = reference.get("URI", "").split("?", 1)[0].lstrip("/")
+        if not target:
+            continue
+        signature.signed_parts.append(target)
+        if target not in names:
+            signature.modified_parts.append(target)
+            continue
+        digest = _digest_matches(zf, target, reference)
+        if digest is None:
+            signature.unverified_parts.append(target)
+        elif not digest:
+            signature.modified_parts.append(target)
+    signature.intact = not signature.modified_parts
+    return signature
+
+
+def _digest_matches(zf: ZipFile, part: str, reference: ET.Element) -> bool | None:
+    """Compare a part's digest with the reference; None when it can't be checked.
+
+    Relationship parts are signed through the OPC relationship transform, and
+    unknown digest algorithms cannot be recomputed, so both are unverified.
+    """
+    if reference.find(f"{_DSIG}Transforms") is not None:
+        return None
+    method = reference.find(f"{_DSIG}DigestMethod")
+    expected = reference.find(f"{_DSIG}DigestValue")
+    algorithm = _DIGESTS.get(method.get("Algorithm", "") if method is not None else "")
+    if algorithm is None or expected is None or not expected.text:
+        return None
+    actual = hashlib.new(algorithm, read_part(zf, part)).digest()
+    try:
+        return actual == base64.b64decode("".join(expected.text.split()))
+    except binascii.Error:
+        return False
+
+
+def _apply_certificate(signature: DigitalSignature, text: str) -> None:
+    """Fill subject, issuer, serial, and validity from a base64 DER certificate."""
+    try:
+        der = base64.b64decode("".join(text.split()))
+        certificate = _der_children(_der_read(der, 0)[1])
+        tbs = _der_children(certificate[0][1])
+        if tbs and tbs[0][0] == 0xA0:  # explicit [0] version
+            tbs = tbs[1:]
+        serial, _, issuer, validity, subject = tbs[:5]
+        times = _der_children(validity[1])
+        signature.serial = serial[1].hex().upper()
+        signature.issuer = _der_name(issuer[1])
+        signature.signer = _der_name(subject[1])
+        signature.valid_from = _der_time(*times[0])
+        signature.valid_to = _der_time(*times[1])
+    except (binascii.Error, IndexError, ValueError):
+        return
+
+
+def _der_read(data: bytes, offset: int) -> tuple[int, bytes, int]:
+    """Read one DER TLV at ``offset``; returns (tag, value, next offset)."""
+    tag = data[offset]
+    length = data[offset + 1]
+    offset += 2
+    if length & 0x80:
+        count = length & 0x7F
+        length = int.from_bytes(data[offset : offset + count], "big")
+        offset += count
+    if offset + length > len(data):
+        raise ValueError("Truncated DER value.")
+    return tag, data[offset : offset + length], offset + length
+
+
+def _der_children(data: bytes) -> list[tuple[int, bytes]]:
+    children: list[tuple[int, bytes]] = []
+    offset = 0
+    while offset < len(data):
+        tag, value, offset = _der_read(data, offset)
+        children.append((tag, value))
+    return children
+
+
+def _der_name(data: bytes) -> str:
+    """Format an X.500 Name as ``CN=..., O=...`` (most specific first)."""
+    parts: list[str] = []
+    for _, rdn in _der_children(data):
+        for _, attribute in _der_children(rdn):
+            oid, value = _der_children(attribute)[:2]
+            key = _NAME_KEYS.get(_der_oid(oid[1]), _der_oid(oid[1]))
+            text = value[1].decode("utf-16-be" if value[0] == 0x1E else "utf-8")
+            parts.append(f"{key}={text}")
+    return ", ".join(reversed(parts))
+
+
+def _der_oid(data: bytes) -> str:
+    numbers = [data[0] // 40, data[0] % 40]
+    current = 0
+    for byte in data[1:]:
+        current = (current << 7) | (byte & 0x7F)
+        if not byte & 0x80:
+            numbers.append(current)
+            current = 0
+    return ".".join(str(number) for number in numbers)
+
+
+def _der_time(tag: int, data: bytes) -> str:
+    """Convert UTCTime/GeneralizedTime (``YYMMDDhhmmssZ``) to ISO 8601."""
+    text = data.decode("ascii").rstrip("Z")
+    if tag == 0x17:  # UTCTime: two-digit year, 1950-2049
+        text = ("19" if int(text[:2]) >= 50 else "20") + text
+    return (
+        f"{text[0:4]}-{text[4:6]}-{text[6:8]}T"
+        f"{text[8:10]}:{text[10:12]}:{text[12:14]}Z"
+    )
@@ -0,0 +1,238 @@
+"""Workbook style table read from ``xl/styles.xml`` (``include_styles``).
+
+Cells reference a cell format (an ``xf`` in ``cellXfs``) by its index in the
+``s`` attribute, and each cell format references fonts, fills, borders, and a
+number format by index. The tables are exported once per workbook and each
+sheet only lists which cells use which cell format, so verbose style output
+stays proportional to the number of distinct styles rather than cells.
+"""
+
+from __future__ import annotations
+
+from pathlib import Path
+from xml.etree import ElementTree as ET
+from zipfile import ZipFile, is_zipfile
+
+from openpyxl.styles.numbers import BUILTIN_FORMATS
+
+from exstruct.models import (
+    BorderSide,
+    CellFormat,
+    NumberFormat,
+    StyleBorder,
+    StyleFill,
+    StyleFont,
+    StyleTable,
+)
+from exstruct.ooxml.chart import _read_sheet_files, _read_sheets_info
+from exstruct.ooxml.safety import iterparse_part, open_package, parse_part
+from exstruct.sources import open_source
+
+_MAIN_NS = "http://schemas.openxmlformats.org/spreadsheetml/2006/main"
+_M = f"{{{_MAIN_NS}}}"
+_STYLES_PART = "xl/styles.xml"
+_BORDER_SIDES = ("left", "right", "top", "bottom", "diagonal")
+
+
+def read_style_table(file_path: Path) -> StyleTable | None:
+    """Read the workbook's fonts, fills, borders, number formats, and cell formats.
+
+    Args:
+        file_path: Workbook path (.xlsx/.xlsm).
+
+    Returns:
+        The style table, or None for non-OOXML workbooks (.xls) and packages
+        without ``xl/styles.xml``.
+    """
+    if not is_zipfile(open_source(file_path)):
+        return None
+    with open_package(file_path) as zf:
+        if _STYLES_PART not in zf.namelist():
+            return None
+        root = parse_part(zf, _STYLES_PART)
+    fonts = [_font(element) for element in root.iterfind(f"{_M}fonts/{_M}font")]
+    cell_formats = [
+        _cell_format(element) for element in root.iterfind(f"{_M}cellXfs/{_M}xf")
+    ]
+    return StyleTable(
+        default_font=_default_font(root, fonts),
+        fonts=fonts,
+        fills=[_fill(element) for element in root.iterfind(f"{_M}fills/{_M}fill")],
+        borders=[
+            _border(element) for element in root.iterfind(f"{_M}borders/{_M}border")
+        ],
+        number_formats=_number_formats(root, cell_formats),
+        cell_formats=cell_formats,
+    )
+
+
+def read_style_maps(file_path: Path) -> dict[str, dict[str, list[tuple[int, int]]]]:
+    """Group each sheet's cells by the cell format they use.
+
+    Cells using the default format 0 are left out, as are cells that only
+    inherit a row or column style without a ``<c>`` element.
+
+    Args:
+        file_path: Workbook path (.xlsx/.xlsm).
+
+    Returns:
+        Mapping of sheet name to ``{cell format ID: [(row, col), ...]}`` with
+        1-based rows and 0-based columns; empty for non-OOXML workbooks.
+    """
+    if not is_zipfile(open_source(file_path)):
+        return {}
+    result: dict[str, dict[str, list[tuple[int, int]]]] = {}
+    with open_package(file_path) as zf:
+        names = set(zf.namelist())
+        sheet_files = _read_sheet_files(zf, _read_sheets_info(zf))
+        for name, path in sheet_files.items():
+            if path in names:
+                result[name] = _sheet_style_map(zf, path)
+    return result
+
+
+def _sheet_style_map(zf: ZipFile, path: str) -> dict[str, list[tuple[int, int]]]:
+    style_map: dict[str, list[tuple[int, int]]] = {}
+    row = 0
+    for _event, elem in iterparse_part(zf, path, ("end",)):
+        if elem.tag != f"{_M}row":
+            continue
+        row = _int(elem.get("r"), row + 1)
+        col = -1
+        for cell in elem.iter(f"{_M}c"):
+            col = _column_index(cell.get("r"), col + 1)
+            style = cell.get("s")
+            if style and style != "0":
+                style_map.setdefault(style, []).append((row, col))
+        elem.clear()
+    return style_map
+
+
+def _column_index(ref: str | None, fallback: int) -> int:
+    """Return the 0-based column of a cell reference such as ``AB12``."""
+    letters = "".join(ch for ch in (ref or "") if ch.isalpha()).upper()
+    if not letters:
+        return fallback
+    index = 0
+    for ch in letters:
+        index = index * 26 + ord(ch) - ord("A") + 1
+    return index - 1
+
+
+def _color(element: ET.Element | None) -> str | None:
+    """Return a color key in the ``colors_map`` notation."""
+    if element is None:
+        return None
+    rgb = element.get("rgb")
+    if rgb:
+        return rgb[-6:].upper()
+    theme = element.get("theme")
+    if theme is not None:
+        tint = element.get("tint")
+        return f"theme:{theme}" if tint is None else f"theme:{theme}:{tint}"
+    indexed = element.get("indexed")
+    if indexed is not None:
+        return f"indexed:{indexed}"
+    return "auto" if element.get("auto") in {"1", "true"} else None
+
+
+def _flag(element: ET.Element, tag: str) -> bool:
+    child = element.find(f"{_M}{tag}")
+    return child is not None and child.get("val", "1") not in {"0", "false"}
+
+
+def _font(element: ET.Element) -> StyleFont:
+    size = element.find(f"{_M}sz")
+    name = element.find(f"{_M}name")
+    underline = element.find(f"{_M}u")
+    return StyleFont(
+        name=name.get("val") if name is not None else None,
+        size=_float(size.get("val")) if size is not None else None,
+        bold=_flag(element, "b"),
+        italic=_flag(element, "i"),
+        underline=underline.get("val", "single") if underline is not None else None,
+        strike=_flag(element, "strike"),
+        color=_color(element.find(f"{_M}color")),
+    )
+
+
+def _fill(element: ET.Element) -> StyleFill:
+    if element.find(f"{_M}gradientFill") is not None:
+        return StyleFill(pattern="gradient")
+    pattern = element.find(f"{_M}patternFill")
+    if pattern is None:
+        return StyleFill()
+    pattern_type = pattern.get("patternType")
+    return StyleFill(
+        pattern=None if pattern_type in (None, "none") else pattern_type,
+        fg_color=_color(pattern.find(f"{_M}fgColor")),
+        bg_color=_color(pattern.find(f"{_M}bgColor")),
+    )
+
+
+def _border(element: ET.Element) -> StyleBorder:
+    sides: dict[str, BorderSide] = {}
+    for side in _BORDER_SIDES:
+        edge = element.find(f"{_M}{side}")
+        style = edge.get("style") if edge is not None else None
+        if edge is not None and style and style != "none":
+            sides[side] = BorderSide(style=style, color=_color(edge.find(f"{_M}color")))
+    return StyleBorder(**sides)
+
+
+def _cell_format(element: ET.Element) -> CellFormat:
+    alignment = element.find(f"{_M}alignment")
+    return CellFormat(
+        font_id=_int(element.get("fontId"), 0),
+        fill_id=_int(element.get("fillId"), 0),
+        border_id=_int(element.get("borderId"), 0),
+        num_fmt_id=_int(element.get("numFmtId"), 0),
+        horizontal=alignment.get("horizontal") if alignment is not None else None,
+        vertical=alignment.get("vertical") if alignment is not None else None,
+        wrap_text=alignment is not None
+        and alignment.get("wrapText") in {"1", "true"},
+    )
+
+
+def _default_font(root: ET.Element, fonts: list[StyleFont]) -> StyleFont | None:
+    """Return the Normal style's font (``cellStyleXfs`` entry of builtinId 0)."""
+    style_xfs = list(root.iterfind(f"{_M}cellStyleXfs/{_M}xf"))
+    xf_id = 0
+    for style in root.iterfind(f"{_M}cellStyles/{_M}cellStyle"):
+        if style.get("builtinId") == "0":
+            xf_id = _int(style.get("xfId"), 0)
+            break
+    font_id = _int(style_xfs[xf_id].get("fontId"), 0) if xf_id < len(style_xfs) else 0
+    return fonts[font_id] if font_id < len(fonts) else None
+
+
+def _number_formats(
+    root: ET.Element, cell_formats: list[CellFormat]
+) -> list[NumberFormat]:
+    """Return the formats referenced by cell formats, built-in codes resolved."""
+    codes: dict[int, str] = {}
+    for element in root.iterfind(f"{_M}numFmts/{_M}numFmt"):
+        fmt_id = _int(element.get("numFmtId"), -1)
+        if fmt_id >= 0:
+            codes[fmt_id] = element.get("formatCode", "")
+    used = sorted({cell_format.num_fmt_id for cell_format in cell_formats})
+    return [
+        NumberFormat(id=fmt_id, code=codes.get(fmt_id) or BUILTIN_FORMATS[fmt_id])
+        for fmt_id in used
+        if fmt_id in codes or fmt_id in BUILTIN_FORMATS
+    ]
+
+
+def _int(value: str | None, default: int) -> int:
+    try:
+        return int(value) if value is not None else default
+    except ValueError:
+        return default
+
+
+def _float(value: str | None) -> float | None:
+    try:
+        return float(value) if value is not None else None
+    except ValueError:
+        return None
+
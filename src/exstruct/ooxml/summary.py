@@ -0,0 +1,247 @@
+"""Workbook summary read directly from the xlsx package.
+
+Streams each worksheet part to count cells and formulas, and reads sheet
+relationships for drawings and tables, without building full sheet data.
+"""
+
+from __future__ import annotations
+
+from dataclasses import dataclass
+from pathlib import Path
+import posixpath
+import re
+import time
+from xml.etree import ElementTree as ET
+from zipfile import ZipFile
+
+from exstruct.models import (
+    SheetMetrics,
+    SheetSummary,
+    WorkbookSummary,
+    col_index_to_alpha,
+)
+from exstruct.ooxml.chart import (
+    _read_sheet_files,
+    _read_sheets_info,
+    _resolve_relative_path,
+)
+from exstruct.ooxml.compat import resolve_alternate_content
+from exstruct.ooxml.pivot import _relationships
+from exstruct.ooxml.safety import iterparse_part, open_package, parse_xml, read_part
+
+_MAIN_NS = "http://schemas.openxmlformats.org/spreadsheetml/2006/main"
+_RELS_NS = "http://schemas.openxmlformats.org/package/2006/relationships"
+_XDR_NS = "http://schemas.openxmlformats.org/drawingml/2006/spreadsheetDrawing"
+_CHART_NS = "http://schemas.openxmlformats.org/drawingml/2006/chart"
+_ANCHOR_TAGS = ("twoCellAnchor", "oneCellAnchor", "absoluteAnchor")
+_CELL_REF = re.compile(r"^([A-Z]+)(\d+)$")
+
+
+@dataclass
+class _CellStats:
+    """Running cell counts for a worksheet."""
+
+    non_empty: int = 0
+    formulas: int = 0
+    min_row: int | None = None
+    max_row: int = 0
+    min_col: int | None = None
+    max_col: int = 0
+
+    def add(self, row: int, col: int) -> None:
+        """Record a non-empty cell at a 1-based row and 0-based column."""
+        self.non_empty += 1
+        self.min_row = row if self.min_row is None else min(self.min_row, row)
+        self.max_row = max(self.max_row, row)
+        self.min_col = col if self.min_col is None else min(self.min_col, col)
+        self.max_col = max(self.max_col, col)
+
+
+def summarize_workbook_ooxml(file_path: Path) -> WorkbookSummary:
+    """Summarize an xlsx/xlsm workbook without full extraction.
+
+    Args:
+        file_path: Workbook path.
+
+    Returns:
+        WorkbookSummary with per-sheet counts and package part sizes.
+
+    Raises:
+        zipfile.BadZipFile: If the file is not an OOXML package (e.g. .xls).
+    """
+    with open_package(file_path) as zf:
+        part_sizes = {info.filename: info.file_size for info in zf.infolist()}
+        sheets_info = _read_sheets_info(zf)
+        sheet_files = _read_sheet_files(zf, sheets_info)
+        sheets = [
+            _summarize_sheet(zf, name, sheet_files[name], part_sizes)
+            for name in sheets_info.values()
+            if name in sheet_files
+        ]
+    return WorkbookSummary(
+        book_name=file_path.name,
+        file_size=file_path.stat().st_size,
+        sheets=sheets,
+        part_sizes=dict(sorted(part_sizes.items())),
+    )
+
+
+def measure_sheet_parts(file_path: Path) -> dict[str, SheetMetrics]:
+    """Time a streaming parse of each worksheet part and measure its parts.
+
+    Args:
+        file_path: Workbook path (.xlsx/.xlsm).
+
+    Returns:
+        ``SheetMetrics`` per sheet name with ``parse_seconds``, ``part_bytes``,
+        and ``related_bytes`` set; counts are left at 0.
+
+    Raises:
+        zipfile.BadZipFile: If the file is not an OOXML package (e.g. .xls).
+    """
+    metrics: dict[str, SheetMetrics] = {}
+    with open_package(file_path) as zf:
+        part_sizes = {info.filename: info.file_size for info in zf.infolist()}
+        sheet_files = _read_sheet_files(zf, _read_sheets_info(zf))
+        for name, sheet_path in sheet_files.items():
+            if sheet_path not in part_sizes:
+                continue
+            start = time.perf_counter()
+            _scan_cells(zf, sheet_path)
+            metrics[name] = SheetMetrics(
+                parse_seconds=round(time.perf_counter() - start, 6),
+                part_bytes=part_sizes[sheet_path],
+                related_bytes=sum(
+                    part_sizes[part]
+                    for part in _related_parts(zf, sheet_path, set(part_sizes))
+                ),
+            )
+    return metrics
+
+
+def _related_parts(zf: ZipFile, part: str, names: set[str]) -> set[str]:
+    """Return the parts reachable from a part's relationships (transitively)."""
+    found: set[str] = set()
+    pending = [part]
+    while pending:
+        source = pending.pop()
+        base_dir = posixpath.dirname(source)
+        rels = f"{base_dir}/_rels/{posixpath.basename(source)}.rels"
+        for target in _relationships(zf, rels, base_dir, names).values():
+            if target in names and target != part and target not in found:
+                found.add(target)
+                pending.append(target)
+    return found
+
+
+def _summarize_sheet(
+    zf: ZipFile, name: str, sheet_path: str, part_sizes: dict[str, int]
+) -> SheetSummary:
+    """Summarize one worksheet part."""
+    stats = _scan_cells(zf, sheet_path) if sheet_path in part_sizes else _CellStats()
+    shape_count, chart_count, table_count = _count_related_parts(zf, sheet_path)
+    used_range: str | None = None
+    row_count = column_count = 0
+    if stats.min_row is not None and stats.min_col is not None:
+        start = f"{col_index_to_alpha(stats.min_col)}{stats.min_row}"
+        end = f"{col_index_to_alpha(stats.max_col)}{stats.max_row}"
+        used_range = start if start == end else f"{start}:{end}"
+        row_count = stats.max_row - stats.min_row + 1
+        column_count = stats.max_col - stats.min_col + 1
+    return SheetSummary(
+        name=name,
+        used_range=used_range,
+        row_count=row_count,
+        column_count=column_count,
+        non_empty_cells=stats.non_empty,
+        formula_count=stats.formulas,
+        shape_count=shape_count,
+        chart_count=chart_count,
+        table_count=table_count,
+        part_size=part_sizes.get(sheet_path, 0),
+    )
+
+
+def _scan_cells(zf: ZipFile, sheet_path: str) -> _CellStats:
+    """Stream a worksheet part and count non-empty and formula cells."""
+    stats = _CellStats()
+    row_tag = f"{{{_MAIN_NS}}}row"
+    cell_tag = f"{{{_MAIN_NS}}}c"
+    row_index = 0
+    col_index = -1
+    for event, elem in iterparse_part(zf, sheet_path, ("start", "end")):
+        if event == "start":
+            if elem.tag == row_tag:
+                row_attr = elem.get("r")
+                row_index = int(row_attr) if row_attr else row_index + 1
+                col_index = -1
+            continue
+        if elem.tag == cell_tag:
+            col_index = _cell_column(elem.get("r"), col_index)
+            has_formula = elem.find(f"{{{_MAIN_NS}}}f") is not None
+            if has_formula:
+                stats.formulas += 1
+            if has_formula or _has_value(elem):
+                stats.add(row_index, col_index)
+            elem.clear()
+        elif elem.tag == row_tag:
+            elem.clear()
+    return stats
+
+
+def _cell_column(ref: str | None, previous: int) -> int:
+    """Return the 0-based column of a cell, following the previous one if unset."""
+    match = _CELL_REF.match(ref or "")
+    if match is None:
+        return previous + 1
+    index = 0
+    for ch in match.group(1):
+        index = index * 26 + ord(ch) - ord("A") + 1
+    return index - 1
+
+
+def _has_value(cell: ET.Element) -> bool:
+    """Return whether a cell element carries a cached or inline value."""
+    value = cell.find(f"{{{_MAIN_NS}}}v")
+    if value is not None and value.text:
+        return True
+    return cell.find(f"{{{_MAIN_NS}}}is") is not None
+
+
+def _count_related_parts(zf: ZipFile, sheet_path: str) -> tuple[int, int, int]:
+    """Count shapes, charts, and tables related to a worksheet."""
+    rels_path = sheet_path.replace("worksheets/", "worksheets/_rels/").replace(
+        ".xml", ".xml.rels"
+    )
+    try:
+        rels_root = parse_xml(read_part(zf, rels_path))
+    except (KeyError, ET.ParseError):
+        return 0, 0, 0
+    shapes = charts = tables = 0
+    for rel in rels_root.findall(f"{{{_RELS_NS}}}Relationship"):
+        rel_type = rel.get("Type", "").rsplit("/", 1)[-1]
+        if rel_type == "table":
+            tables += 1
+        elif rel_type == "drawing":
+            target = _resolve_relative_path(rel.get("Target", ""), "xl/drawings")
+            drawing_shapes, drawing_charts = _count_drawing_objects(zf, target)
+            shapes += drawing_shapes
+            charts += drawing_charts
+    return shapes, charts, tables
+
+
+def _count_drawing_objects(zf: ZipFile, drawing_path: str) -> tuple[int, int]:
+    """Count top-level drawing anchors, split into shapes and charts."""
+    try:
+        root = parse_xml(read_part(zf, drawing_path))
+    except (KeyError, ET.ParseError):
+        return 0, 0
+    resolve_alternate_content(root)
+    shapes = charts = 0
+    for tag in _ANCHOR_TAGS:
+        for anchor in root.findall(f"{{{_XDR_NS}}}{tag}"):
+            if anchor.find(f".//{{{_CHART_NS}}}chart") is not None:
+                charts += 1
+            else:
+                shapes += 1
+    return shapes, charts
@@ -6,6 +6,12 @@ EMU (English Metric Units) is the base unit in OOXML:
 - 1 point ≈ 1.333 pixels at 96 DPI
 """
 
+from __future__ import annotations
+
+from dataclasses import dataclass
+
+from ..models.types import PositionUnit
+
 # EMU per inch
 EMU_PER_INCH: int = 914400
 
@@ -41,3 +47,76 @@ def emu_to_points(emu: int) -> float:
     """
     inches = emu / EMU_PER_INCH
     return inches * POINTS_PER_INCH
+
+
+def pixels_to_emu(pixels: float, dpi: int = DEFAULT_DPI) -> int:
+    """Convert pixels to EMU.
+
+    Args:
+        pixels: Value in pixels.
+        dpi: Dots per inch (default 96).
+
+    Returns:
+        Value in EMU (rounded to nearest integer).
+    """
+    return round(pixels / dpi * EMU_PER_INCH)
+
+
+def points_to_emu(points: float) -> int:
+    """Convert points to EMU.
+
+    Args:
+        points: Value in points.
+
+    Returns:
+        Value in EMU (rounded to nearest integer).
+    """
+    return round(points / POINTS_PER_INCH * EMU_PER_INCH)
+
+
+# EMU per point and per millimeter
+EMU_PER_POINT: int = 12700
+EMU_PER_MM: int = 36000
+
+
+@dataclass(frozen=True)
+class PositionScale:
+    """Target unit for shape and chart positions.
+
+    The default (pixels at 96 DPI) matches the historical OOXML output.
+
+    Attributes:
+        unit: Output unit.
+        dpi: Dots per inch; only used for ``pixels``.
+    """
+
+    unit: PositionUnit = "pixels"
+    dpi: int = DEFAULT_DPI
+
+    @property
+    def emu_per_unit(self) -> float:
+        """Return how many EMU make up one output unit."""
+        if self.unit == "pixels":
+            return EMU_PER_INCH / self.dpi
+        if self.unit == "points":
+            return EMU_PER_POINT
+        if self.unit == "millimeters":
+            return EMU_PER_MM
+        return 1
+
+    @property
+    def points_per_unit(self) -> float:
+        """Return how many points make up one output unit."""
+        if self.unit == "pixels":
+            return POINTS_PER_INCH / self.dpi
+        return self.emu_per_unit / EMU_PER_POINT
+
+    def from_emu(self, emu: int) -> int:
+        """Convert EMU to the output unit (rounded to nearest integer)."""
+        if self.unit == "pixels":
+            return emu_to_pixels(emu, self.dpi)
+        return round(emu / self.emu_per_unit)
+
+    def from_points(self, points: float) -> int:
+        """Convert points to the output unit (rounded to nearest integer)."""
+        return round(points / self.points_per_unit)
@@ -0,0 +1,213 @@
+"""Masking or hashing of personal data in extracted workbooks."""
+
+from __future__ import annotations
+
+from fnmatch import fnmatchcase
+import hashlib
+import re
+from typing import Literal
+
+from openpyxl.utils import column_index_from_string
+from pydantic import BaseModel, ConfigDict, Field, field_validator
+
+from .models import CellRow, SheetData, SmartArt, SmartArtNode, WorkbookData
+
+# Built-in rules run in this order so national IDs are not mistaken for phones.
+BUILTIN_PATTERNS: dict[str, str] = {
+    "email": r"[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}",
+    "national_id": (
+        r"(?<!\d)(?:\d{3}-\d{2}-\d{4}"  # US SSN
+        r"|\d{4}[ -]?\d{4}[ -]?\d{4})(?!\d)"  # JP My Number
+    ),
+    "phone": (
+        r"(?<![\d+])(?:\+\d{1,3}[ -]?)?\(?\d{2,4}\)?[ -]?\d{3,4}[ -]\d{4}(?!\d)"
+    ),
+}
+
+
+class RedactionRule(BaseModel):
+    """One redaction rule.
+
+    A rule with ``pattern`` replaces every match inside cell values, hyperlinks,
+    and shape texts. A rule with ``columns`` replaces whole cell values in those
+    columns. ``sheets`` limits the rule to sheet names matching the globs.
+    """
+
+    model_config = ConfigDict(extra="forbid")
+
+    name: str = Field(description="Rule name, shown in masks.")
+    pattern: str | None = Field(default=None, description="Regular expression.")
+    columns: list[str] = Field(
+        default_factory=list, description="Column letters (A, B, ...) to redact."
+    )
+    sheets: list[str] | None = Field(
+        default=None, description="Sheet name globs (None applies to all sheets)."
+    )
+
+    @field_validator("pattern")
+    @classmethod
+    def _validate_pattern(cls, value: str | None) -> str | None:
+        if value is not None:
+            try:
+                re.compile(value)
+            except re.error as exc:
+                raise ValueError(f"invalid pattern: {exc}") from exc
+        return value
+
+    @classmethod
+    def builtin(cls, name: str) -> RedactionRule:
+        """Return a built-in rule (email, phone, or national_id).
+
+        Raises:
+            ValueError: If the name is unknown.
+        """
+        pattern = BUILTIN_PATTERNS.get(name)
+        if pattern is None:
+            allowed = ", ".join(BUILTIN_PATTERNS)
+            raise ValueError(f"Unknown redaction rule '{name}'. Allowed: {allowed}.")
+        return cls(name=name, pattern=pattern)
+
+    def applies_to(self, sheet_name: str) -> bool:
+        return self.sheets is None or any(
+            fnmatchcase(sheet_name, pattern) for pattern in self.sheets
+        )
+
+
+class RedactionOptions(BaseModel):
+    """Redaction rules and how matches are replaced.
+
+    ``mask`` replaces matches with ``[REDACTED:<rule>]``; ``hash`` replaces
+    them with ``<rule>:<first 16 hex of SHA-256(salt + value)>`` so equal
+    values stay joinable across files without revealing them.
+    """
+
+    model_config = ConfigDict(extra="forbid")
+
+    rules: list[RedactionRule] = Field(
+        default_factory=list, description="Rules applied in order."
+    )
+    method: Literal["mask", "hash"] = Field(
+        default="mask", description="Replacement method."
+    )
+    salt: str = Field(default="", description="Salt prepended before hashing.")
+    include_shapes: bool = Field(
+        default=True, description="Also redact shape and SmartArt texts."
+    )
+
+    @classmethod
+    def from_names(
+        cls, names: list[str], *, method: Literal["mask", "hash"] = "mask"
+    ) -> RedactionOptions:
+        """Build options from built-in rule names (see ``BUILTIN_PATTERNS``)."""
+        rules = [RedactionRule.builtin(name) for name in names]
+        order = list(BUILTIN_PATTERNS)
+        rules.sort(key=lambda rule: order.index(rule.name))
+        return cls(rules=rules, method=method)
+
+
+class Redactor:
+    """Workbook transform applying RedactionOptions in place.
+
+    Usable directly in ``StructOptions.transforms``.
+    """
+
+    __name__ = "redact"
+
+    def __init__(self, options: RedactionOptions) -> None:
+        self.options = options
+        self._patterns = [
+            (rule, re.compile(rule.pattern))
+            for rule in options.rules
+            if rule.pattern is not None
+        ]
+        self._columns = [
+            (rule, {column_index_from_string(col.upper()) - 1 for col in rule.columns})
+            for rule in options.rules
+            if rule.columns
+        ]
+
+    def __call__(self, workbook: WorkbookData) -> None:
+        for sheet_name, sheet in workbook.sheets.items():
+            self.redact_sheet(sheet_name, sheet)
+
+    def _replacement(self, rule: RedactionRule, value: str) -> str:
+        if self.options.method == "mask":
+            return f"[REDACTED:{rule.name}]"
+        digest = hashlib.sha256((self.options.salt + value).encode("utf-8"))
+        return f"{rule.name}:{digest.hexdigest()[:16]}"
+
+    def redact_text(self, sheet_name: str, text: str) -> str:
+        """Replace every pattern match in ``text``."""
+        for rule, pattern in self._patterns:
+            if rule.applies_to(sheet_name):
+                text = pattern.sub(
+                    lambda match, rule=rule: self._replacement(rule, match.group(0)),
+                    text,
+                )
+        return text
+
+    def _column_rule(self, sheet_name: str, key: str) -> RedactionRule | None:
+        try:
+            col = int(key) if key.isdigit() else column_index_from_string(key) - 1
+        except ValueError:
+            return None
+        for rule, columns in self._columns:
+            if col in columns and rule.applies_to(sheet_name):
+                return rule
+        return None
+
+    def _redact_row(self, sheet_name: str, row: CellRow) -> None:
+        cells: dict[str, int | float | str] = {}
+        for key, value in row.c.items():
+            rule = self._column_rule(sheet_name, key)
+            if rule is not None:
+                cells[key] = self._replacement(rule, str(value))
+                continue
+            redacted = self.redact_text(sheet_name, str(value))
+            cells[key] = value if redacted == str(value) else redacted
+        row.c = cells
+        if row.links:
+            row.links = {
+                key: self.redact_text(sheet_name, link)
+                for key, link in row.links.items()
+            }
+
+    def _redact_nodes(self, sheet_name: str, nodes: list[SmartArtNode]) -> None:
+        for node in nodes:
+            node.text = self.redact_text(sheet_name, node.text)
+            self._redact_nodes(sheet_name, node.kids)
+
+    def redact_sheet(self, sheet_name: str, sheet: SheetData) -> None:
+        """Redact one sheet in place."""
+        for row in sheet.rows:
+            self._redact_row(sheet_name, row)
+        if not self.options.include_shapes:
+            return
+        for shape in sheet.shapes:
+            shape.text = self.redact_text(sheet_name, shape.text)
+            if isinstance(shape, SmartArt):
+                self._redact_nodes(sheet_name, shape.nodes)
+
+
+def redact_workbook(workbook: WorkbookData, options: RedactionOptions) -> WorkbookData:
+    """Return a redacted copy of a workbook.
+
+    Args:
+        workbook: Workbook to redact (left unchanged).
+        options: Redaction rules and method.
+
+    Returns:
+        Deep copy with matching cell values, links, and shape texts replaced.
+    """
+    redacted = workbook.model_copy(deep=True)
+    Redactor(options)(redacted)
+    return redacted
+
+
+__all__ = [
+    "BUILTIN_PATTERNS",
+    "RedactionOptions",
+    "RedactionRule",
+    "Redactor",
+    "redact_workbook",
+]
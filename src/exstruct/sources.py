@@ -0,0 +1,116 @@
+"""Workbook sources held in memory instead of on the local disk.
+
+Object storage inputs (``exstruct.io.remote``) are downloaded into memory and
+registered under their URI. Each registration yields a virtual path
+``<memory>/<id>/<name>`` that keeps the object's file name and suffix, so
+``book_name`` and format detection work unchanged, and that can never be
+mistaken for a local file. Readers open workbooks via ``open_source``, which
+returns a fresh in-memory buffer for such paths and the path itself
+otherwise; both are accepted by ``zipfile``, openpyxl and pandas.
+"""
+
+from __future__ import annotations
+
+from collections.abc import Callable, Iterator
+from contextlib import contextmanager
+from dataclasses import dataclass
+import io
+from pathlib import Path
+import threading
+from typing import BinaryIO
+import uuid
+
+MEMORY_ROOT = Path("<memory>")
+
+_LOCK = threading.Lock()
+
+
+@dataclass
+class _MemorySource:
+    """One in-memory workbook and the number of blocks using it."""
+
+    path: Path
+    data: bytes
+    refs: int = 1
+
+
+_BY_KEY: dict[str, _MemorySource] = {}
+_BY_PATH: dict[Path, _MemorySource] = {}
+
+
+@contextmanager
+def memory_source(key: str, name: str, load: Callable[[], bytes]) -> Iterator[Path]:
+    """Hold the workbook identified by ``key`` in memory while the block runs.
+
+    ``load`` is called only when ``key`` is not held already: concurrent
+    blocks for the same key (e.g. two jobs reading one object) share the copy
+    loaded first, and it is released when the last of them exits.
+
+    Args:
+        key: Identity of the source, such as its URI.
+        name: File name for the virtual path (``book.xlsx``).
+        load: Returns the workbook bytes.
+
+    Yields:
+        Virtual path to pass to readers.
+    """
+    entry = _acquire(key)
+    if entry is None:
+        data = load()
+        with _LOCK:
+            entry = _BY_KEY.get(key)
+            if entry is None:
+                entry = _MemorySource(MEMORY_ROOT / uuid.uuid4().hex / name, data)
+                _BY_KEY[key] = entry
+                _BY_PATH[entry.path] = entry
+            else:
+                entry.refs += 1
+    try:
+        yield entry.path
+    finally:
+        with _LOCK:
+            entry.refs -= 1
+            if entry.refs == 0:
+                _BY_KEY.pop(key, None)
+                _BY_PATH.pop(entry.path, None)
+
+
+def _acquire(key: str) -> _MemorySource | None:
+    """Take another reference to a held source, or return None."""
+    with _LOCK:
+        entry = _BY_KEY.get(key)
+        if entry is not None:
+            entry.refs += 1
+        return entry
+
+
+def is_memory_source(path: Path) -> bool:
+    """Return whether ``path`` names a workbook held in memory."""
+    return path in _BY_PATH
+
+
+def open_source(path: Path) -> Path | BinaryIO:
+    """Return a readable buffer for an in-memory workbook, else ``path``."""
+    entry = _BY_PATH.get(path)
+    return path if entry is None else io.BytesIO(entry.data)
+
+
+def source_exists(path: Path) -> bool:
+    """Return whether the workbook exists in memory or on disk."""
+    return is_memory_source(path) or path.exists()
+
+
+def source_size(path: Path) -> int:
+    """Return the workbook size in bytes."""
+    entry = _BY_PATH.get(path)
+    return path.stat().st_size if entry is None else len(entry.data)
+
+
+__all__ = [
+    "MEMORY_ROOT",
+    "is_memory_source",
+    "memory_source",
+    "open_source",
+    "source_exists",
+    "source_size",
+]
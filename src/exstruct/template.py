@@ -0,0 +1,199 @@
+"""Template-based extraction of named fields from a workbook.
+
+A template maps field names to cell addresses, ranges, or labels::
+
+    sheet: Invoice
+    fields:
+      invoice_no: B2
+      items: A5:D9
+      customer:
+        label: Customer
+        direction: right
+
+``apply_template`` reads those fields from extracted ``WorkbookData`` and
+returns one flat record, so forms can be pulled without post-processing the
+full extraction output.
+"""
+
+from __future__ import annotations
+
+from pathlib import Path
+import re
+from typing import Any
+
+from pydantic import BaseModel, ConfigDict, Field, ValidationError, model_validator
+
+from .analysis.label_lookup import (
+    LabelDirection,
+    LabelMatchMode,
+    _cell_grid,
+    find_by_label,
+)
+from .core.ranges import parse_range_zero_based
+from .errors import ConfigError
+from .models import SheetData, WorkbookData
+
+CellValue = int | float | str | None
+FieldValue = CellValue | list[list[CellValue]]
+
+
+class TemplateField(BaseModel):
+    """Location of one field.
+
+    Exactly one of ``cell``, ``range``, or ``label`` is set. A plain string in a
+    template is shorthand for ``cell`` (or ``range`` when it contains ``:``).
+    """
+
+    model_config = ConfigDict(extra="forbid")
+
+    sheet: str | None = Field(
+        default=None, description="Sheet name (None: the template's sheet)."
+    )
+    cell: str | None = Field(default=None, description="Cell address, e.g. B2.")
+    range: str | None = Field(default=None, description="Range, e.g. A5:D9.")
+    label: str | None = Field(
+        default=None,
+        description="Label text; the value is read next to the first cell "
+        "that matches it.",
+    )
+    match: LabelMatchMode = Field(
+        default="exact", description="How label text is matched (see find_by_label)."
+    )
+    direction: LabelDirection = Field(
+        default="right", description="Where the value sits relative to the label."
+    )
+    offset: int = Field(
+        default=1, ge=1, description="Distance in cells from the label to the value."
+    )
+
+    @model_validator(mode="before")
+    @classmethod
+    def _expand_shorthand(cls, value: Any) -> Any:
+        if isinstance(value, str):
+            return {"range": value} if ":" in value else {"cell": value}
+        return value
+
+    @model_validator(mode="after")
+    def _check_location(self) -> TemplateField:
+        locations = [self.cell, self.range, self.label]
+        if sum(1 for location in locations if location is not None) != 1:
+            raise ValueError("Set exactly one of 'cell', 'range', or 'label'.")
+        for address in (self.cell, self.range):
+            if address is not None and parse_range_zero_based(address) is None:
+                raise ValueError(f"Invalid cell address or range: {address!r}")
+        if self.label is not None and self.match == "regex":
+            try:
+                re.compile(self.label)
+            except re.error as exc:
+                raise ValueError(f"Invalid label pattern: {exc}") from exc
+        return self
+
+
+class ExtractionTemplate(BaseModel):
+    """Named fields to read from a workbook."""
+
+    model_config = ConfigDict(extra="forbid")
+
+    sheet: str | None = Field(
+        default=None, description="Default sheet (None: the first sheet)."
+    )
+    fields: dict[str, TemplateField] = Field(
+        description="Field name to location, in output order."
+    )
+
+
+def load_template(path: str | Path) -> ExtractionTemplate:
+    """Load and validate a template file.
+
+    Args:
+        path: YAML (requires pyyaml), JSON, or TOML template file.
+
+    Returns:
+        Validated template.
+
+    Raises:
+        ConfigError: If the file cannot be read, parsed, or validated.
+    """
+    from .config import _read_config_data
+
+    template_path = Path(path)
+    try:
+        data = _read_config_data(template_path)
+    except ConfigError:
+        raise
+    except OSError as exc:
+        raise ConfigError(
+            f"Failed to read template '{template_path}': {exc}"
+        ) from exc
+    except ValueError as exc:
+        raise ConfigError(
+            f"Failed to parse template '{template_path}': {exc}"
+        ) from exc
+    try:
+        return ExtractionTemplate.model_validate(data or {})
+    except ValidationError as exc:
+        raise ConfigError(f"Invalid template '{template_path}': {exc}") from exc
+
+
+def apply_template(
+    workbook: WorkbookData, template: ExtractionTemplate
+) -> dict[str, FieldValue]:
+    """Read a template's fields from an extracted workbook.
+
+    Args:
+        workbook: Extracted workbook (numeric or alpha column keys).
+        template: Fields to read.
+
+    Returns:
+        Flat record keyed by field name. Cells yield their value, ranges a list
+        of rows (empty cells as None), and labels the value next to the label;
+        missing cells and labels yield None.
+
+    Raises:
+        ConfigError: If a field names a sheet the workbook does not have.
+    """
+    record: dict[str, FieldValue] = {}
+    for name, field in template.fields.items():
+        sheet_name = _resolve_sheet_name(workbook, field.sheet or template.sheet)
+        record[name] = _read_field(workbook.sheets[sheet_name], field)
+    return record
+
+
+def _resolve_sheet_name(workbook: WorkbookData, name: str | None) -> str:
+    if name is None:
+        if not workbook.sheets:
+            raise ConfigError("Workbook has no sheets.")
+        return next(iter(workbook.sheets))
+    if name not in workbook.sheets:
+        raise ConfigError(f"Sheet '{name}' not found in workbook.")
+    return name
+
+
+def _read_field(sheet: SheetData, field: TemplateField) -> FieldValue:
+    if field.label is not None:
+        matches = find_by_label(
+            sheet,
+            field.label,
+            match=field.match,
+            direction=field.direction,
+            offset=field.offset,
+        )
+        return matches[0].values[0] if matches else None
+    bounds = parse_range_zero_based(field.cell or field.range or "")
+    if bounds is None:
+        return None
+    grid = _cell_grid(sheet.rows)
+    if field.cell is not None:
+        return grid.get((bounds.r1, bounds.c1))
+    return [
+        [grid.get((row, col)) for col in range(bounds.c1, bounds.c2 + 1)]
+        for row in range(bounds.r1, bounds.r2 + 1)
+    ]
+
+
+__all__ = [
+    "ExtractionTemplate",
+    "TemplateField",
+    "apply_template",
+    "load_template",
+]
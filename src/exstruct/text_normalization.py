@@ -0,0 +1,79 @@
+"""Unicode normalization and whitespace cleanup of extracted text."""
+
+from __future__ import annotations
+
+import re
+import unicodedata
+
+from .models import CellRow, SheetData, SmartArt, SmartArtNode, WorkbookData
+
+# Zero-width characters, BOM, and soft hyphen, which NFKC keeps but are invisible.
+_INVISIBLE = dict.fromkeys(map(ord, "\u200b\u200c\u200d\u2060\ufeff\u00ad"))
+_HORIZONTAL_SPACE_RE = re.compile(r"[^\S\n]+")
+_LINE_EDGE_RE = re.compile(r" ?\n ?")
+
+
+def normalize_text(text: str) -> str:
+    """Return ``text`` NFKC-normalized with whitespace cleaned up.
+
+    Non-breaking and full-width spaces become plain spaces (NFKC), zero-width
+    characters are removed, runs of spaces and tabs collapse to one space,
+    line breaks are kept (``\\r\\n`` becomes ``\\n``) without spaces around
+    them, and the result is trimmed.
+
+    Args:
+        text: Text to normalize.
+
+    Returns:
+        Normalized text.
+    """
+    normalized = unicodedata.normalize("NFKC", text).translate(_INVISIBLE)
+    normalized = normalized.replace("\r\n", "\n").replace("\r", "\n")
+    normalized = _HORIZONTAL_SPACE_RE.sub(" ", normalized)
+    return _LINE_EDGE_RE.sub("\n", normalized).strip()
+
+
+class TextNormalizer:
+    """Workbook transform applying ``normalize_text`` in place.
+
+    Cell values that are strings and shape (and SmartArt node) texts are
+    normalized; cells left empty are dropped, as empty cells are at
+    extraction. Usable directly in ``StructOptions.transforms``.
+    """
+
+    __name__ = "normalize_text"
+
+    def __call__(self, workbook: WorkbookData) -> None:
+        for sheet in workbook.sheets.values():
+            self.normalize_sheet(sheet)
+
+    def normalize_sheet(self, sheet: SheetData) -> None:
+        """Normalize one sheet in place."""
+        rows: list[CellRow] = []
+        for row in sheet.rows:
+            self._normalize_row(row)
+            if row.c or row.links:
+                rows.append(row)
+        sheet.rows = rows
+        for shape in sheet.shapes:
+            shape.text = normalize_text(shape.text)
+            if isinstance(shape, SmartArt):
+                self._normalize_nodes(shape.nodes)
+
+    def _normalize_row(self, row: CellRow) -> None:
+        cells: dict[str, int | float | str] = {}
+        for key, value in row.c.items():
+            if isinstance(value, str):
+                value = normalize_text(value)
+                if not value:
+                    continue
+            cells[key] = value
+        row.c = cells
+
+    def _normalize_nodes(self, nodes: list[SmartArtNode]) -> None:
+        for node in nodes:
+            node.text = normalize_text(node.text)
+            self._normalize_nodes(node.kids)
+
+
+__all__ = ["TextNormalizer", "normalize_text"]
@@ -0,0 +1,69 @@
+"""Tests for the chart source cross-reference index."""
+
+from exstruct.analysis import build_chart_source_index, split_range_reference
+from exstruct.models import Chart, ChartRef, ChartSeries, SheetData
+
+
+def _chart(name: str, series: list[ChartSeries]) -> Chart:
+    return Chart(
+        name=name,
+        chart_type="Column",
+        y_axis_title="",
+        series=series,
+        l=0,
+        t=0,
+    )
+
+
+def test_split_range_reference_handles_quotes_and_unions() -> None:
+    parts = split_range_reference("=('My ''Data''!$A$1:$A$3,Sheet1!C1:C3)")
+    assert parts == [("My 'Data'", "A1:A3"), ("Sheet1", "C1:C3")]
+    assert split_range_reference("$B$2:$B$4") == [(None, "B2:B4")]
+
+
+def test_build_chart_source_index_links_series_to_tables() -> None:
+    series = ChartSeries(
+        name="Sales",
+        name_range="Data!$B$1",
+        x_range="Data!$A$2:$A$4",
+        y_range="Data!$B$2:$B$4",
+    )
+    sheets = {
+        "Data": SheetData(table_candidates=["A1:B4"]),
+        "Report": SheetData(
+            charts=[_chart("Chart 1", [series]), _chart("Chart 2", [series])]
+        ),
+    }
+    index = build_chart_source_index(sheets)
+    assert index is not None
+    assert len(index.sources) == 6
+    first = index.sources[0]
+    assert (first.sheet, first.chart, first.role) == ("Report", "Chart 1", "name")
+    assert (first.source_sheet, first.range) == ("Data", "B1")
+    assert {source.table_candidate for source in index.sources} == {"A1:B4"}
+    assert len(index.table_consumers) == 1
+    consumers = index.table_consumers[0]
+    assert (consumers.sheet, consumers.table_candidate) == ("Data", "A1:B4")
+    assert consumers.charts == [
+        ChartRef(sheet="Report", chart="Chart 1"),
+        ChartRef(sheet="Report", chart="Chart 2"),
+    ]
+
+
+def test_build_chart_source_index_resolves_unqualified_to_own_sheet() -> None:
+    series = ChartSeries(name="S1", y_range="$D$2:$D$5")
+    sheets = {
+        "Sheet1": SheetData(
+            table_candidates=["A1:B4"], charts=[_chart("Chart 1", [series])]
+        )
+    }
+    index = build_chart_source_index(sheets)
+    assert index is not None
+    assert index.sources[0].source_sheet is None
+    assert index.sources[0].table_candidate is None
+    assert index.table_consumers == []
+
+
+def test_build_chart_source_index_returns_none_without_charts() -> None:
+    sheets = {"Sheet1": SheetData(table_candidates=["A1:B4"])}
+    assert build_chart_source_index(sheets) is None
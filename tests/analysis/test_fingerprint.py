@@ -0,0 +1,44 @@
+"""Tests for sheet and table content hashes."""
+
+from exstruct.analysis import sheet_content_hash, table_content_hashes
+from exstruct.models import CellRow
+
+
+def test_sheet_content_hash_is_stable_and_position_sensitive() -> None:
+    rows = [CellRow(r=1, c={"1": "b", "0": "a"}), CellRow(r=2, c={"0": 1})]
+    reordered = [CellRow(r=2, c={"0": 1}), CellRow(r=1, c={"0": "a", "1": "b"})]
+    shifted = [CellRow(r=2, c={"1": "b", "0": "a"}), CellRow(r=3, c={"0": 1})]
+
+    digest = sheet_content_hash(rows)
+
+    assert digest is not None and len(digest) == 64
+    assert sheet_content_hash(reordered) == digest
+    assert sheet_content_hash(shifted) != digest
+    assert sheet_content_hash([CellRow(r=1, c={"0": "1"})]) != sheet_content_hash(
+        [CellRow(r=1, c={"0": 1})]
+    )
+    assert sheet_content_hash([]) is None
+
+
+def test_table_content_hashes_ignore_position_and_row_order() -> None:
+    original = [
+        CellRow(r=1, c={"0": "id", "1": "name"}),
+        CellRow(r=2, c={"0": 1, "1": "x"}),
+        CellRow(r=3, c={"0": 2, "1": "y"}),
+    ]
+    moved = [
+        CellRow(r=5, c={"2": "id", "3": "name", "9": "outside"}),
+        CellRow(r=6, c={"2": 2, "3": "y"}),
+        CellRow(r=7, c={"2": 1, "3": "x"}),
+    ]
+
+    first = table_content_hashes(original, ["A1:B3"])
+    second = table_content_hashes(moved, ["C5:D7"])
+
+    assert first["A1:B3"] == second["C5:D7"]
+    assert table_content_hashes(original, ["A1:B2"])["A1:B2"] != first["A1:B3"]
+
+
+def test_table_content_hashes_skip_empty_and_invalid_ranges() -> None:
+    rows = [CellRow(r=1, c={"0": "a"})]
+    assert table_content_hashes(rows, ["D4:E5", "not a range"]) == {}
@@ -0,0 +1,110 @@
+"""Tests for flowchart reconstruction from shapes and connectors."""
+
+from exstruct.analysis import build_flowcharts, classify_node_kind
+from exstruct.core.modeling import SheetRawData, build_sheet_data
+from exstruct.models import Arrow, Shape
+
+
+def _node(shape_id: int, text: str, shape_type: str) -> Shape:
+    return Shape(id=shape_id, text=text, l=0, t=shape_id * 50, type=shape_type)
+
+
+def _arrow(
+    begin_id: int | None,
+    end_id: int | None,
+    *,
+    text: str = "",
+    begin_style: int | None = 1,
+    end_style: int | None = 2,
+) -> Arrow:
+    return Arrow(
+        text=text,
+        l=0,
+        t=0,
+        begin_id=begin_id,
+        end_id=end_id,
+        begin_arrow_style=begin_style,
+        end_arrow_style=end_style,
+        direction="S",
+    )
+
+
+def test_classify_node_kind_uses_type_suffix() -> None:
+    assert classify_node_kind("AutoShape-FlowchartDecision") == "decision"
+    assert classify_node_kind("AutoShape-FlowchartTerminator") == "terminator"
+    assert classify_node_kind("AutoShape-Rectangle") == "process"
+    assert classify_node_kind("AutoShape-5pointStar") == "other"
+    assert classify_node_kind(None) == "other"
+
+
+def test_build_flowcharts_orders_nodes_and_labels_edges() -> None:
+    shapes = [
+        _node(1, "Start", "AutoShape-FlowchartTerminator"),
+        _node(2, "OK?", "AutoShape-FlowchartDecision"),
+        _node(3, "Fix", "AutoShape-FlowchartProcess"),
+        _arrow(1, 2),
+        _arrow(2, 3, text="No"),
+    ]
+    flowcharts = build_flowcharts(shapes)
+    assert len(flowcharts) == 1
+    chart = flowcharts[0]
+    assert [n.kind for n in chart.nodes] == ["terminator", "decision", "process"]
+    assert chart.order == [1, 2, 3]
+    assert chart.edges[1].label == "No"
+    assert chart.edges[1].direction == "S"
+
+
+def test_build_flowcharts_reverses_begin_arrowheads() -> None:
+    shapes = [
+        _node(1, "A", "AutoShape-FlowchartProcess"),
+        _node(2, "B", "AutoShape-FlowchartProcess"),
+        _arrow(1, 2, begin_style=2, end_style=1),
+    ]
+    edge = build_flowcharts(shapes)[0].edges[0]
+    assert (edge.source, edge.target) == (2, 1)
+    assert edge.directed is True
+
+
+def test_build_flowcharts_marks_cycles_unordered() -> None:
+    shapes = [
+        _node(1, "A", "AutoShape-FlowchartProcess"),
+        _node(2, "B", "AutoShape-FlowchartProcess"),
+        _arrow(1, 2),
+        _arrow(2, 1),
+    ]
+    assert build_flowcharts(shapes)[0].order is None
+
+
+def test_build_flowcharts_splits_components_and_skips_dangling() -> None:
+    shapes = [
+        _node(1, "A", "AutoShape-FlowchartProcess"),
+        _node(2, "B", "AutoShape-FlowchartProcess"),
+        _node(3, "C", "AutoShape-FlowchartProcess"),
+        _node(4, "D", "AutoShape-FlowchartProcess"),
+        _node(5, "lonely", "AutoShape-FlowchartProcess"),
+        _arrow(1, 2),
+        _arrow(3, 4, begin_style=1, end_style=1),
+        _arrow(4, None),
+    ]
+    flowcharts = build_flowcharts(shapes)
+    assert [[n.id for n in f.nodes] for f in flowcharts] == [[1, 2], [3, 4]]
+    assert flowcharts[1].edges[0].directed is False
+
+
+def test_build_sheet_data_populates_flowcharts() -> None:
+    raw = SheetRawData(
+        rows=[],
+        shapes=[
+            _node(1, "A", "AutoShape-FlowchartProcess"),
+            _node(2, "B", "AutoShape-FlowchartProcess"),
+            _arrow(1, 2),
+        ],
+        charts=[],
+        table_candidates=[],
+        print_areas=[],
+        auto_print_areas=[],
+        formulas_map={},
+        colors_map={},
+        merged_cells=[],
+    )
+    assert len(build_sheet_data(raw).flowcharts) == 1
@@ -0,0 +1,57 @@
+"""Tests for formula auditing."""
+
+from exstruct.analysis import (
+    audit_formulas,
+    find_circular_references,
+    find_formula_functions,
+    parse_formula_references,
+)
+
+
+def test_find_formula_functions_ignores_strings_and_sheet_names() -> None:
+    formula = "=IF('NOW(x)'!A1>0,\"RAND()\",_xlfn.RANDARRAY(2)+Sum(A1:A3))"
+    assert find_formula_functions(formula) == {"IF", "RANDARRAY", "SUM"}
+
+
+def test_audit_formulas_groups_cells_by_function() -> None:
+    formulas_map = {
+        "=NOW()": [(3, 1), (1, 0)],
+        "=OFFSET(A1,1,0)+NOW()": [(2, 27)],
+        '=RTD("srv",,"topic")': [(5, 2)],
+        "=SUM(A1:A3)": [(4, 0)],
+    }
+    audit = audit_formulas(formulas_map)
+    assert audit is not None
+    assert [(u.function, u.cells) for u in audit.volatile] == [
+        ("NOW", ["A1", "AB2", "B3"]),
+        ("OFFSET", ["AB2"]),
+    ]
+    assert [(u.function, u.cells) for u in audit.external] == [("RTD", ["C5"])]
+
+
+def test_audit_formulas_returns_none_without_flagged_functions() -> None:
+    assert audit_formulas({"=SUM(A1:A3)": [(4, 0)]}) is None
+    assert audit_formulas({}) is None
+
+
+def test_find_circular_references_reports_cycles_and_self_references() -> None:
+    formulas_map = {
+        "=B1+1": [(1, 0)],
+        "=A1*2": [(1, 1)],
+        "=SUM(C:C)": [(3, 2)],
+        "=Sheet2!A1": [(5, 0)],
+        '="A5"&D1': [(2, 3)],
+    }
+    assert find_circular_references(formulas_map) == [[(1, 0), (1, 1)], [(3, 2)]]
+
+
+def test_parse_formula_references_skips_qualified_and_function_names() -> None:
+    refs = parse_formula_references("=SUM($A$1:B3,'My S'!C1,2:3)+LOG10(D4)")
+    assert refs == [(1, 0, 3, 1), (2, 0, 3, 16383), (4, 3, 4, 3)]
+
+
+def test_audit_formulas_lists_circular_reference_cells() -> None:
+    audit = audit_formulas({"=B2": [(1, 0)], "=A1": [(2, 1)]})
+    assert audit is not None
+    assert audit.circular_references == [["A1", "B2"]]
+    assert audit.volatile == []
@@ -0,0 +1,101 @@
+"""Tests for R1C1 formula notation."""
+
+from __future__ import annotations
+
+from pathlib import Path
+
+from openpyxl import Workbook
+
+from exstruct.analysis import compress_formulas, r1c1_formulas_map, to_r1c1
+from exstruct.engine import ExStructEngine, StructOptions
+from exstruct.models import FormulaBlock
+
+
+def test_to_r1c1_relative_and_absolute_references() -> None:
+    # C4 is row 4, column index 2.
+    assert to_r1c1("=SUM(B$1:B3)", 4, 2) == "=SUM(R1C[-1]:R[-1]C[-1])"
+    assert to_r1c1("=$A$1*C4", 4, 2) == "=R1C1*RC"
+    assert to_r1c1("='Q1, Q2'!D5+Data!$B2", 4, 2) == "='Q1, Q2'!R[1]C[1]+Data!R[-2]C2"
+    assert to_r1c1("=SUM(A:A,$3:4)", 4, 2) == "=SUM(C[-2],R3:R)"
+
+
+def test_to_r1c1_leaves_names_and_text_alone() -> None:
+    assert to_r1c1('=Total*"A1"', 2, 0) == '=Total*"A1"'
+    assert to_r1c1("=Sales[Amount]", 2, 0) == "=Sales[Amount]"
+    assert to_r1c1("A1+1", 2, 0) == "=R[-1]C+1"
+    assert to_r1c1("=XFE1", 1, 0) == "=XFE1"
+
+
+def test_r1c1_formulas_map_groups_copied_formulas() -> None:
+    formulas_map = {
+        "=B2*$E$1": [(2, 2)],
+        "=B3*$E$1": [(3, 2)],
+        "=B4*E1": [(4, 2)],
+    }
+
+    assert r1c1_formulas_map(formulas_map) == {
+        "=RC[-1]*R1C5": [(2, 2), (3, 2)],
+        "=RC[-1]*R[-3]C[2]": [(4, 2)],
+    }
+
+
+def test_include_formulas_r1c1_option(tmp_path: Path) -> None:
+    wb = Workbook()
+    ws = wb.active
+    assert ws is not None
+    ws.title = "Data"
+    for row in range(1, 4):
+        ws.cell(row=row, column=1, value=row)
+        ws.cell(row=row, column=2, value=f"=A{row}*2")
+    path = tmp_path / "book.xlsx"
+    wb.save(path)
+
+    plain = ExStructEngine(options=StructOptions(mode="light")).extract(path)
+    workbook = ExStructEngine(
+        options=StructOptions(mode="light", include_formulas_r1c1=True)
+    ).extract(path)
+
+    assert plain.sheets["Data"].formulas_map_r1c1 == {}
+    sheet = workbook.sheets["Data"]
+    assert sheet.formulas_map["=A1*2"] == [(1, 1)]
+    assert sheet.formulas_map_r1c1 == {"=RC[-1]*2": [(1, 1), (2, 1), (3, 1)]}
+
+
+def test_compress_formulas_builds_rectangular_blocks() -> None:
+    # C2:D4 copies =A2+$E$1 across two columns; F2 and C6 stay single cells.
+    formulas_map: dict[str, list[tuple[int, int]]] = {}
+    for row in range(2, 5):
+        formulas_map[f"=A{row}+$E$1"] = [(row, 2)]
+        formulas_map[f"=B{row}+$E$1"] = [(row, 3)]
+    formulas_map["=SUM(C2:C4)"] = [(6, 2)]
+    formulas_map["=A2*2"] = [(2, 5)]
+
+    blocks, remaining = compress_formulas(formulas_map)
+
+    assert blocks == [
+        FormulaBlock(range="C2:D4", r1c1="=RC[-2]+R1C5", formula="=A2+$E$1")
+    ]
+    assert remaining == {"=SUM(C2:C4)": [(6, 2)], "=A2*2": [(2, 5)]}
+
+
+def test_compress_formulas_option(tmp_path: Path) -> None:
+    wb = Workbook()
+    ws = wb.active
+    assert ws is not None
+    ws.title = "Data"
+    for row in range(1, 5):
+        ws.cell(row=row, column=1, value=row)
+        ws.cell(row=row, column=2, value=f"=A{row}*2")
+    ws["C1"] = "=SUM(B1:B4)"
+    path = tmp_path / "book.xlsx"
+    wb.save(path)
+
+    workbook = ExStructEngine(
+        options=StructOptions(mode="light", compress_formulas=True)
+    ).extract(path)
+
+    sheet = workbook.sheets["Data"]
+    assert sheet.formula_blocks == [
+        FormulaBlock(range="B1:B4", r1c1="=RC[-1]*2", formula="=A1*2")
+    ]
+    assert sheet.formulas_map == {"=SUM(B1:B4)": [(1, 2)]}
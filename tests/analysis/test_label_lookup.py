@@ -0,0 +1,58 @@
+"""Tests for label-anchored cell lookup."""
+
+import pytest
+
+from exstruct.analysis import LabelMatch, find_by_label
+from exstruct.models import CellRow, SheetData
+
+
+def _form() -> SheetData:
+    return SheetData(
+        rows=[
+            CellRow(r=1, c={"0": "請求書", "3": "No.", "4": "A-12"}),
+            CellRow(r=3, c={"0": "合計金額：", "2": 12000, "3": "円"}),
+            CellRow(r=4, c={"0": " 合計金額 ", "1": 500}),
+            CellRow(r=5, c={"0": "Due", "1": "Date"}),
+            CellRow(r=6, c={"0": "2024-05-31", "1": "Net 30"}),
+        ]
+    )
+
+
+def test_find_by_label_exact_reads_nearest_value() -> None:
+    matches = find_by_label(_form(), "合計金額")
+
+    assert matches == [
+        LabelMatch(row=4, col=0, text=" 合計金額 ", score=1.0, values=[500])
+    ]
+
+
+def test_find_by_label_offset_count_and_direction() -> None:
+    sheet = _form()
+
+    right = find_by_label(sheet, "No.", offset=1, count=2)
+    below = find_by_label(sheet.rows, "Due", direction="below")
+
+    assert right[0].values == ["A-12", None]
+    assert below[0].values == ["2024-05-31"]
+
+
+def test_find_by_label_regex_and_fuzzy() -> None:
+    sheet = _form()
+
+    regex = find_by_label(sheet, r"^合計", match="regex", count=2)
+    fuzzy = find_by_label(sheet, "合計金額", match="fuzzy")
+
+    assert [(m.row, m.values) for m in regex] == [(3, [12000, "円"]), (4, [500])]
+    assert [m.row for m in fuzzy] == [4, 3]
+    assert fuzzy[0].score == 1.0
+    assert 0.8 <= fuzzy[1].score < 1.0
+    assert find_by_label(sheet, "合計", match="fuzzy", threshold=0.9) == []
+
+
+def test_find_by_label_alpha_keys_and_invalid_pattern() -> None:
+    sheet = SheetData(rows=[CellRow(r=2, c={"B": "Total", "C": 42})])
+
+    assert find_by_label(sheet, "Total")[0].values == [42]
+    assert find_by_label(sheet, "Total")[0].col == 1
+    with pytest.raises(ValueError, match="Invalid label pattern"):
+        find_by_label(sheet, "(", match="regex")
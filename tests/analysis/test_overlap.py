@@ -0,0 +1,51 @@
+"""Tests for shape overlap detection."""
+
+from exstruct.analysis import find_shape_overlaps
+from exstruct.models import Arrow, Shape
+
+
+def _shape(
+    shape_id: int,
+    left: int,
+    top: int,
+    width: int | None,
+    height: int | None,
+    z: int | None = None,
+) -> Shape:
+    return Shape(
+        id=shape_id,
+        text=f"s{shape_id}",
+        l=left,
+        t=top,
+        w=width,
+        h=height,
+        z_order=z,
+    )
+
+
+def test_find_shape_overlaps_uses_z_order_for_front() -> None:
+    shapes = [
+        _shape(1, 0, 0, 100, 100, z=2),
+        _shape(2, 50, 50, 100, 100, z=1),
+    ]
+    overlaps = find_shape_overlaps(shapes)
+    assert len(overlaps) == 1
+    assert (overlaps[0].back_id, overlaps[0].front_id) == (2, 1)
+    assert overlaps[0].overlap_ratio == 0.25
+
+
+def test_find_shape_overlaps_falls_back_to_document_order() -> None:
+    shapes = [_shape(1, 0, 0, 40, 40), _shape(2, 10, 10, 10, 10)]
+    overlap = find_shape_overlaps(shapes)[0]
+    assert (overlap.back_id, overlap.front_id) == (1, 2)
+    assert overlap.overlap_ratio == 1.0
+
+
+def test_find_shape_overlaps_ignores_touching_unsized_and_connectors() -> None:
+    shapes = [
+        _shape(1, 0, 0, 50, 50),
+        _shape(2, 50, 0, 50, 50),
+        _shape(3, 10, 10, None, None),
+        Arrow(text="", l=0, t=0, w=100, h=100, begin_id=1, end_id=2),
+    ]
+    assert find_shape_overlaps(shapes) == []
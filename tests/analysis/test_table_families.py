@@ -0,0 +1,64 @@
+"""Tests for grouping tables with identical headers across sheets."""
+
+from exstruct.analysis import build_table_families, filter_table_families
+from exstruct.models import CellRow, SheetData, TableFamilyMember
+
+
+def _monthly_sheet(first_row: int = 1) -> SheetData:
+    return SheetData(
+        rows=[
+            CellRow(r=first_row, c={"0": "Date", "1": " Item ", "2": "Amount"}),
+            CellRow(r=first_row + 1, c={"0": "2024-01-02", "1": "Pen", "2": 3}),
+            CellRow(r=first_row + 2, c={"0": "2024-01-05", "1": "Ink", "2": 7}),
+        ],
+        table_candidates=[f"A{first_row}:C{first_row + 2}"],
+    )
+
+
+def test_build_table_families_groups_identical_headers() -> None:
+    sheets = {
+        "Jan": _monthly_sheet(),
+        "Feb": _monthly_sheet(first_row=3),
+        "Summary": SheetData(
+            rows=[CellRow(r=1, c={"0": "Month", "1": "Total"})],
+            table_candidates=["A1:B1"],
+        ),
+        "Mar": _monthly_sheet(),
+    }
+
+    families = build_table_families(sheets)
+
+    assert len(families) == 1
+    assert families[0].headers == ["Date", "Item", "Amount"]
+    assert families[0].members == [
+        TableFamilyMember(sheet="Jan", table="A1:C3"),
+        TableFamilyMember(sheet="Feb", table="A3:C5"),
+        TableFamilyMember(sheet="Mar", table="A1:C3"),
+    ]
+
+
+def test_build_table_families_requires_two_sheets_and_text_headers() -> None:
+    numeric = SheetData(
+        rows=[CellRow(r=1, c={"0": 1, "1": 2}), CellRow(r=2, c={"0": 3, "1": 4})],
+        table_candidates=["A1:B2"],
+    )
+    sheets = {
+        "Only": SheetData(
+            rows=_monthly_sheet().rows + _monthly_sheet(first_row=10).rows,
+            table_candidates=["A1:C3", "A10:C12"],
+        ),
+        "N1": numeric,
+        "N2": numeric,
+    }
+
+    assert build_table_families(sheets) == []
+
+
+def test_filter_table_families_drops_removed_sheets() -> None:
+    sheets = {"Jan": _monthly_sheet(), "Feb": _monthly_sheet()}
+    families = build_table_families(sheets)
+
+    assert filter_table_families(families, sheets) == families
+    assert filter_table_families(families, {"Jan": sheets["Jan"]}) == []
+    without_tables = {"Jan": sheets["Jan"], "Feb": SheetData()}
+    assert filter_table_families(families, without_tables) == []
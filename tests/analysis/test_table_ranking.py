@@ -0,0 +1,88 @@
+"""Tests for table candidate scoring and ranking."""
+
+from __future__ import annotations
+
+from pathlib import Path
+
+from openpyxl import Workbook
+from openpyxl.styles import Border, Font, Side
+from openpyxl.worksheet.table import Table
+
+from exstruct.analysis import rank_table_candidates, table_confidence_scores
+from exstruct.core.cells import TableStyleSignals, extract_table_style_signals
+from exstruct.engine import ExStructEngine, StructOptions
+from exstruct.models import CellRow
+
+
+def _rows() -> list[CellRow]:
+    return [
+        CellRow(r=1, c={"0": "id", "1": "name", "3": "a"}),
+        CellRow(r=2, c={"0": 1, "1": "x"}),
+        CellRow(r=3, c={"0": 2, "1": "y"}),
+        CellRow(r=4, c={"5": 1}),
+    ]
+
+
+def test_table_confidence_scores_combine_signals() -> None:
+    plain = table_confidence_scores(_rows(), ["A1:B3", "D1:F4", "bad"])
+    styled = table_confidence_scores(
+        _rows(), ["D1:F4"], {"D1:F4": TableStyleSignals(1.0, 1.0)}
+    )
+
+    assert plain == {"A1:B3": 0.625, "D1:F4": 0.167}
+    assert styled == {"D1:F4": 0.542}
+    assert rank_table_candidates(["D1:F4", "A1:B3", "Z9:Z10"], plain) == [
+        "A1:B3",
+        "D1:F4",
+        "Z9:Z10",
+    ]
+
+
+def test_extract_table_style_signals(tmp_path: Path) -> None:
+    wb = Workbook()
+    ws = wb.active
+    assert ws is not None
+    ws.title = "Data"
+    thin = Side(style="thin")
+    for row in (["id", "qty"], [1, 10], [2, 20]):
+        ws.append(row)
+    for row in ws["A1:B3"]:
+        for cell in row:
+            cell.border = Border(left=thin, right=thin, top=thin, bottom=thin)
+    for cell in ws[1]:
+        cell.font = Font(bold=True)
+    ws["D1"], ws["D2"] = "k", 1
+    ws["E1"], ws["E2"] = "v", 2
+    ws.add_table(Table(displayName="Listed", ref="D1:E2"))
+    path = tmp_path / "styled.xlsx"
+    wb.save(path)
+
+    signals = extract_table_style_signals(path, {"Data": ["A1:B3", "D1:E2", "G1:H2"]})
+
+    assert signals == {
+        "Data": {
+            "A1:B3": TableStyleSignals(1.0, 1.0),
+            "D1:E2": TableStyleSignals(1.0, 1.0),
+            "G1:H2": TableStyleSignals(0.0, 0.0),
+        }
+    }
+
+
+def test_rank_tables_option(tmp_path: Path) -> None:
+    wb = Workbook()
+    ws = wb.active
+    assert ws is not None
+    ws.title = "Data"
+    for row in (["id", "qty"], [1, 10], [2, 20], [3, 30]):
+        ws.append(row)
+    path = tmp_path / "book.xlsx"
+    wb.save(path)
+
+    plain = ExStructEngine().extract(path)
+    ranked = ExStructEngine(options=StructOptions(rank_tables=True)).extract(path)
+
+    sheet = ranked.sheets["Data"]
+    assert plain.sheets["Data"].table_scores == {}
+    assert set(sheet.table_scores) == set(sheet.table_candidates)
+    scores = [sheet.table_scores[ref] for ref in sheet.table_candidates]
+    assert scores == sorted(scores, reverse=True)
@@ -0,0 +1,150 @@
+"""Tests for per-column table statistics."""
+
+from __future__ import annotations
+
+from datetime import datetime
+from pathlib import Path
+
+from openpyxl import Workbook
+
+from exstruct.analysis import table_column_stats
+from exstruct.analysis.table_stats import format_currency, header_unit
+from exstruct.core.cells import extract_number_formats
+from exstruct.engine import ExStructEngine, StructOptions
+from exstruct.models import CellRow, ColumnAnomaly, ColumnStats
+
+
+def test_table_column_stats_profiles_each_column() -> None:
+    rows = [
+        CellRow(r=2, c={"1": "id", "2": "amount", "3": "note"}),
+        CellRow(r=3, c={"1": 1, "2": 10.5, "3": "a"}),
+        CellRow(r=4, c={"1": 2, "2": "n/a", "3": " "}),
+        CellRow(r=6, c={"1": 3, "2": 4, "3": "a"}),
+    ]
+
+    stats = table_column_stats(rows, ["B2:D6", "not a range"])
+
+    assert stats == {
+        "B2:D6": [
+            ColumnStats(
+                column="B",
+                header="id",
+                count=3,
+                null_rate=0.25,
+                distinct_count=3,
+                min=1.0,
+                max=3.0,
+                mean=2.0,
+            ),
+            ColumnStats(
+                column="C",
+                header="amount",
+                count=3,
+                null_rate=0.25,
+                distinct_count=3,
+                min=4.0,
+                max=10.5,
+                mean=7.25,
+            ),
+            ColumnStats(
+                column="D", header="note", count=2, null_rate=0.5, distinct_count=1
+            ),
+        ]
+    }
+
+
+def test_table_column_stats_flags_anomalies() -> None:
+    rows = [CellRow(r=1, c={"0": "qty", "1": "due"})]
+    for r, qty in enumerate([10, 12, 11, 13, 95], start=2):
+        rows.append(CellRow(r=r, c={"0": qty, "1": f"2024-0{r}-01"}))
+    rows.append(CellRow(r=7, c={"0": "twelve", "1": "2031-01-01"}))
+
+    stats = table_column_stats(rows, ["A1:B7"], now=datetime(2025, 1, 1))
+
+    qty, due = stats["A1:B7"]
+    assert qty.anomalies == [
+        ColumnAnomaly(
+            kind="outlier",
+            cell="A6",
+            value=95,
+            message="outside 1.5x IQR [8, 16]",
+        ),
+        ColumnAnomaly(
+            kind="type_mismatch",
+            cell="A7",
+            value="twelve",
+            message="text in a column of number values",
+        ),
+    ]
+    assert due.anomalies == [
+        ColumnAnomaly(
+            kind="future_date",
+            cell="B7",
+            value="2031-01-01",
+            message="date is in the future",
+        )
+    ]
+    wide = table_column_stats(rows, ["A1:A7"], iqr_factor=100.0)["A1:A7"][0]
+    assert [anomaly.kind for anomaly in wide.anomalies] == ["type_mismatch"]
+
+
+def test_header_units_and_format_currencies() -> None:
+    assert header_unit("Weight [kg]") == "kg"
+    assert header_unit("金額(千円)") == "千円"
+    assert header_unit("単価【 円 】") == "円"
+    assert header_unit("Name") is None
+    assert format_currency("[$€-407]#,##0.00") == "EUR"
+    assert format_currency("[$CAD] #,##0") == "CAD"
+    assert format_currency('#,##0"円";[Red]-#,##0"円"') == "JPY"
+    assert format_currency("\\$#,##0_);(\\$#,##0)") == "USD"
+    assert format_currency("[$-409]mmm d, yyyy") is None
+    assert format_currency("0.0%") is None
+
+
+def test_table_column_stats_units_from_headers_and_formats(tmp_path: Path) -> None:
+    wb = Workbook()
+    ws = wb.active
+    assert ws is not None
+    ws.title = "Data"
+    ws.append(["金額(千円)", "Weight [kg]", "Price"])
+    ws.append([1200, 3.5, 9.99])
+    ws["C2"].number_format = "[$€-407]#,##0.00"
+    path = tmp_path / "units.xlsx"
+    wb.save(path)
+    rows = [
+        CellRow(r=1, c={"0": "金額(千円)", "1": "Weight [kg]", "2": "Price"}),
+        CellRow(r=2, c={"0": 1200, "1": 3.5, "2": 9.99}),
+    ]
+
+    formats = extract_number_formats(path, {"Data": ["A1:C2", "bad"], "Gone": ["A1"]})
+    stats = table_column_stats(rows, ["A1:C2"], number_formats=formats["Data"])
+
+    assert formats == {"Data": {(2, 2): "[$€-407]#,##0.00"}}
+    assert [(column.unit, column.currency) for column in stats["A1:C2"]] == [
+        ("千円", "JPY"),
+        ("kg", None),
+        (None, "EUR"),
+    ]
+
+
+def test_include_table_stats_option(tmp_path: Path) -> None:
+    wb = Workbook()
+    ws = wb.active
+    assert ws is not None
+    ws.title = "Data"
+    ws.append(["id", "qty"])
+    for index in range(1, 6):
+        ws.append([index, index * 10])
+    path = tmp_path / "book.xlsx"
+    wb.save(path)
+
+    plain = ExStructEngine().extract(path)
+    workbook = ExStructEngine(options=StructOptions(include_table_stats=True)).extract(
+        path
+    )
+
+    sheet = workbook.sheets["Data"]
+    assert plain.sheets["Data"].table_stats == {}
+    assert set(sheet.table_stats) == set(sheet.table_candidates)
+    for columns in sheet.table_stats.values():
+        assert all(column.null_rate <= 1.0 for column in columns)
@@ -42,6 +42,20 @@ def test_extract_passes_auto_page_break_flag(
         include_formulas_map: bool | None = None,
         include_merged_cells: bool | None = None,
         include_merged_values_in_rows: bool = True,
+        include_dimensions: bool | None = None,
+        include_cell_errors: bool | None = None,
+        include_outline: bool | None = None,
+        include_cells: bool = True,
+        include_shapes: bool = True,
+        include_charts: bool = True,
+        include_tables: bool = True,
+        best_effort: bool = False,
+        fast_cells: bool = False,
+        stable_ids: bool = False,
+        position_unit: str | None = None,
+        position_dpi: int | None = None,
+        locale: str | None = None,
+        include_phonetic: bool = False,
     ) -> WorkbookData:
         """
         Test stub for workbook extraction that records the auto page breaks flag.
@@ -108,6 +122,20 @@ def test_process_passes_auto_page_break_flag_from_per_call_destination(
         include_formulas_map: bool | None = None,
         include_merged_cells: bool | None = None,
         include_merged_values_in_rows: bool = True,
+        include_dimensions: bool | None = None,
+        include_cell_errors: bool | None = None,
+        include_outline: bool | None = None,
+        include_cells: bool = True,
+        include_shapes: bool = True,
+        include_charts: bool = True,
+        include_tables: bool = True,
+        best_effort: bool = False,
+        fast_cells: bool = False,
+        stable_ids: bool = False,
+        position_unit: str | None = None,
+        position_dpi: int | None = None,
+        locale: str | None = None,
+        include_phonetic: bool = False,
     ) -> WorkbookData:
         _ = (
             include_cell_links,
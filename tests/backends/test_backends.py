@@ -13,11 +13,15 @@ def test_openpyxl_backend_extract_cells_switches_link_mode(
 ) -> None:
     calls: list[str] = []
 
-    def fake_cells(file_path: Path) -> dict[str, list[object]]:
+    def fake_cells(
+        file_path: Path, *, sheets: object = None
+    ) -> dict[str, list[object]]:
         calls.append("cells")
         return {}
 
-    def fake_cells_links(file_path: Path) -> dict[str, list[object]]:
+    def fake_cells_links(
+        file_path: Path, *, fast: bool = False
+    ) -> dict[str, list[object]]:
         calls.append("links")
         return {}
 
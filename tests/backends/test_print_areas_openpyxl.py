@@ -122,3 +122,69 @@ def test_append_print_areas_skips_invalid_ranges() -> None:
     _append_print_areas(areas, "Sheet1", "A1:B2,INVALID")
     assert "Sheet1" in areas
     assert len(areas["Sheet1"]) == 1
+
+
+def test_print_areas_with_quoted_sheet_names(tmp_path: Path) -> None:
+    """Attribute print areas by sheet even when names hold "!" or commas."""
+    path = tmp_path / "quoted.xlsx"
+    wb = Workbook()
+    plain = wb.active
+    plain.title = "Sales"
+    plain.print_area = "A1:A2"
+    comma = wb.create_sheet("Q1, Q2")
+    comma.print_area = "A1:B2,D3:E4"
+    bang = wb.create_sheet("Sales!2024")
+    bang.print_area = "C1:D5"
+    wb.save(path)
+    wb.close()
+
+    areas = OpenpyxlBackend(path).extract_print_areas()
+
+    def ranges(name: str) -> list[tuple[int, int, int, int]]:
+        return [(a.r1, a.c1, a.r2, a.c2) for a in areas[name]]
+
+    assert ranges("Sales") == [(1, 0, 2, 0)]
+    assert ranges("Q1, Q2") == [(1, 0, 2, 1), (3, 3, 4, 4)]
+    assert ranges("Sales!2024") == [(1, 2, 5, 3)]
+
+
+def test_extract_print_areas_from_defined_names_uses_scope() -> None:
+    """Prefer the defined name's sheet scope over the sheet in its formula."""
+
+    class _Defined:
+        def __init__(self, attr_text: str, local_sheet_id: int | None) -> None:
+            self.attr_text = attr_text
+            self.localSheetId = local_sheet_id
+            self.destinations = [("Other", "Z1:Z2")]
+
+    class _Names:
+        def __init__(self, defined: _Defined | None) -> None:
+            self._defined = defined
+
+        def get(self, _name: str) -> _Defined | None:
+            return self._defined
+
+    class _Sheet:
+        title = "Local"
+        defined_names = _Names(_Defined("'Local'!$A$1:$B$2,'Local'!$D$1:$D$1", 1))
+
+    class _DummyWorkbook:
+        worksheets = [_Sheet()]
+        sheetnames = ["Other", "Local", "Scoped, too"]
+        defined_names = _Names(_Defined("'Other'!$C$3:$C$4", 2))
+
+    areas = _extract_print_areas_from_defined_names(_DummyWorkbook())
+
+    assert [(a.r1, a.c1, a.r2, a.c2) for a in areas["Local"]] == [
+        (1, 0, 2, 1),
+        (1, 3, 1, 3),
+    ]
+    assert [(a.r1, a.c1, a.r2, a.c2) for a in areas["Scoped, too"]] == [
+        (3, 2, 4, 2)
+    ]
+    assert "Other" not in areas
+
+
+def test_parse_print_area_range_with_bang_in_sheet_name() -> None:
+    """Only the last "!" separates the sheet name from the cell reference."""
+    assert _parse_print_area_range("'Sales!2024'!$B$2:$C$3") == (1, 1, 2, 2)
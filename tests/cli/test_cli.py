@@ -317,7 +317,7 @@ def test_CLIで無効ファイルは安全終了する(tmp_path: Path) -> None:
     bad_path = tmp_path / "nope.xlsx"
     out_json = tmp_path / "out.json"
     result = _run_cli([str(bad_path), "-o", str(out_json)])
-    assert result.returncode == 0
+    assert result.returncode == 3
     combined_output = _stdout_text(result) + _stderr_text(result)
     assert "not found" in combined_output.lower() or combined_output == ""
 
@@ -340,6 +340,44 @@ def test_cli_forwards_include_backend_metadata_flag(
     assert captured["include_backend_metadata"] is True
 
 
+def test_cli_forwards_table_detection_flags(
+    monkeypatch: pytest.MonkeyPatch, tmp_path: Path
+) -> None:
+    """Verify that table threshold flags reach process_excel as table_params."""
+
+    xlsx = _prepare_sample_excel(tmp_path)
+    captured: dict[str, object] = {}
+
+    def _capture_process_excel(*_args: object, **kwargs: object) -> None:
+        captured.update(kwargs)
+
+    monkeypatch.setattr("exstruct.cli.main.process_excel", _capture_process_excel)
+    result = _run_cli(
+        [str(xlsx), "--table-density-min", "0.02", "--table-gap-tolerance", "1"]
+    )
+    assert result.returncode == 0
+    assert captured["table_params"] == {"density_min": 0.02, "gap_tolerance": 1}
+
+    captured.clear()
+    assert _run_cli([str(xlsx)]).returncode == 0
+    assert captured["table_params"] is None
+
+
+def test_cli_passes_object_storage_uris(monkeypatch: pytest.MonkeyPatch) -> None:
+    """Verify that s3:// inputs skip the local existence check and stay URIs."""
+
+    captured: dict[str, object] = {}
+
+    def _capture_process_excel(*_args: object, **kwargs: object) -> None:
+        captured.update(kwargs)
+
+    monkeypatch.setattr("exstruct.cli.main.process_excel", _capture_process_excel)
+    result = _run_cli(["s3://bucket/in/book.xlsx", "-o", "gs://bucket/out.json"])
+    assert result.returncode == 0
+    assert captured["file_path"] == "s3://bucket/in/book.xlsx"
+    assert captured["output_path"] == "gs://bucket/out.json"
+
+
 def test_CLI_print_areas_dir_outputs_files(tmp_path: Path) -> None:
     """Verify that the CLI writes print-area JSON files to the target directory."""
 
@@ -69,10 +69,10 @@ def test_cli_alpha_col_parser_present() -> None:
     assert args.alpha_col is True
 
 
-def test_cli_alpha_col_default_false() -> None:
-    """Without --alpha-col, default should be False."""
+def test_cli_alpha_col_default_unset() -> None:
+    """Without --alpha-col, the flag is unset (off unless a profile enables it)."""
     from exstruct.cli.main import build_parser
 
     parser = build_parser()
-    args = parser.parse_args(["dummy.xlsx"])
-    assert args.alpha_col is False
+    assert parser.parse_args(["dummy.xlsx"]).alpha_col is None
+    assert parser.parse_args(["dummy.xlsx", "--no-alpha-col"]).alpha_col is False
@@ -0,0 +1,121 @@
+"""Tests for overlaying findings and the annotate subcommand."""
+
+from __future__ import annotations
+
+from contextlib import redirect_stderr, redirect_stdout
+import io
+import json
+from pathlib import Path
+
+from openpyxl import Workbook, load_workbook
+from openpyxl.comments import Comment
+import pytest
+
+from exstruct.annotate import Finding, annotate_workbook, parse_findings
+from exstruct.cli.main import is_annotate_subcommand, main as cli_main
+from exstruct.errors import ConfigError
+
+
+def _make_book(path: Path) -> None:
+    wb = Workbook()
+    ws = wb.active
+    assert ws is not None
+    ws.title = "Data"
+    ws["A1"] = "Total"
+    ws["B1"] = 120
+    ws["B2"] = "n/a"
+    ws["B2"].comment = Comment("Entered by hand", "owner")
+    wb.save(path)
+
+
+def test_parse_findings_accepts_list_and_mapping() -> None:
+    mapping = parse_findings(
+        {
+            "Data!$b$1": "Sum mismatch",
+            "'My Sheet'!C3": {"message": "Blank", "severity": "error"},
+        }
+    )
+    listed = parse_findings([{"sheet": "Data", "cell": "B1", "message": "x"}])
+
+    assert mapping == [
+        Finding(sheet="Data", cell="B1", message="Sum mismatch"),
+        Finding(sheet="My Sheet", cell="C3", message="Blank", severity="error"),
+    ]
+    assert listed[0].severity == "warning"
+    with pytest.raises(ConfigError, match="sheet-qualified"):
+        parse_findings({"B1": "x"})
+    with pytest.raises(ConfigError, match="Invalid findings"):
+        parse_findings([{"sheet": "Data", "cell": "B1:C2", "message": "x"}])
+    with pytest.raises(ConfigError):
+        parse_findings("B1")
+
+
+def test_annotate_workbook_adds_comments_and_fills(tmp_path: Path) -> None:
+    source = tmp_path / "book.xlsx"
+    _make_book(source)
+    findings = [
+        Finding(sheet="Data", cell="B1", message="Sum mismatch", severity="info"),
+        Finding(sheet="Data", cell="B1", message="Negative?", severity="error"),
+        Finding(sheet="Data", cell="B2", message="Not a number", color="#00FF00"),
+    ]
+
+    output = annotate_workbook(source, findings, tmp_path / "out" / "review.xlsx")
+
+    ws = load_workbook(output)["Data"]
+    assert ws["B1"].comment.text == "[info] Sum mismatch\n[error] Negative?"
+    assert ws["B1"].comment.author == "exstruct"
+    assert ws["B1"].fill.start_color.rgb == "FFF8CBAD"
+    assert ws["B2"].comment.text == "Entered by hand\n[warning] Not a number"
+    assert ws["B2"].fill.start_color.rgb == "FF00FF00"
+    assert ws["B1"].value == 120
+    assert load_workbook(source)["Data"]["B1"].comment is None
+
+
+def test_annotate_workbook_rejects_bad_targets(tmp_path: Path) -> None:
+    source = tmp_path / "book.xlsx"
+    _make_book(source)
+    missing_sheet = [Finding(sheet="Other", cell="A1", message="x")]
+
+    with pytest.raises(ConfigError, match="must differ"):
+        annotate_workbook(source, [], source)
+    with pytest.raises(ConfigError, match="Sheet 'Other' not found"):
+        annotate_workbook(source, missing_sheet, tmp_path / "out.xlsx")
+    with pytest.raises(ConfigError, match=r"\.xlsx/\.xlsm"):
+        annotate_workbook(tmp_path / "book.xls", [], tmp_path / "out.xlsx")
+
+
+def test_annotate_cli(tmp_path: Path) -> None:
+    source = tmp_path / "book.xlsx"
+    _make_book(source)
+    findings = tmp_path / "findings.json"
+    findings.write_text(json.dumps({"Data!A1": "Label"}), encoding="utf-8")
+    output = tmp_path / "review.xlsx"
+    stdout = io.StringIO()
+    stderr = io.StringIO()
+    missing = str(tmp_path / "missing.json")
+
+    assert is_annotate_subcommand(["annotate", str(source)])
+    with redirect_stdout(stdout):
+        code = cli_main(
+            [
+                "annotate",
+                str(source),
+                str(findings),
+                "-o",
+                str(output),
+                "--author",
+                "qa",
+                "--no-highlight",
+            ]
+        )
+    with redirect_stderr(stderr):
+        failed = cli_main(["annotate", str(source), missing, "-o", "y.xlsx"])
+
+    assert code == 0
+    assert "Annotated 1 finding(s)" in stdout.getvalue()
+    cell = load_workbook(output)["Data"]["A1"]
+    assert cell.comment.text == "[warning] Label"
+    assert cell.comment.author == "qa"
+    assert cell.fill.fill_type is None
+    assert failed == 1
+    assert "Error:" in stderr.getvalue()
@@ -0,0 +1,125 @@
+"""Tests for the batch and watch subcommands."""
+
+from __future__ import annotations
+
+import json
+import os
+from pathlib import Path
+
+from openpyxl import Workbook
+import pytest
+
+from exstruct.cli.main import main as cli_main
+
+
+def _make_book(path: Path, value: object = 1) -> None:
+    wb = Workbook()
+    ws = wb.active
+    assert ws is not None
+    ws["A1"] = value
+    wb.save(path)
+
+
+def _cell(path: Path) -> object:
+    data = json.loads(path.read_text(encoding="utf-8"))
+    return data["sheets"]["Sheet"]["rows"][0]["c"]["0"]
+
+
+def test_batch_extracts_files_and_directories(tmp_path: Path) -> None:
+    books = tmp_path / "books"
+    (books / "nested").mkdir(parents=True)
+    _make_book(books / "a.xlsx", "a")
+    _make_book(books / "nested" / "b.xlsx", "b")
+    _make_book(tmp_path / "single.xlsx", "single")
+    out = tmp_path / "out"
+
+    code = cli_main(
+        ["batch", str(books), str(tmp_path / "single.xlsx"), "-o", str(out)]
+        + ["-m", "light"]
+    )
+
+    assert code == 0
+    assert _cell(out / "a.json") == "a"
+    assert _cell(out / "nested" / "b.json") == "b"
+    assert _cell(out / "single.json") == "single"
+
+
+def test_batch_keeps_going_past_failures(
+    tmp_path: Path, capsys: pytest.CaptureFixture[str]
+) -> None:
+    books = tmp_path / "books"
+    books.mkdir()
+    (books / "broken.xlsx").write_bytes(b"not a zip")
+    _make_book(books / "ok.xlsx")
+    out = tmp_path / "out"
+
+    code = cli_main(["batch", str(books), "-o", str(out), "-m", "light"])
+
+    assert code == 1
+    assert (out / "ok.json").exists()
+    assert not (out / "broken.json").exists()
+    assert "broken.xlsx" in capsys.readouterr().err
+
+
+def test_batch_does_not_overwrite_outputs_of_the_same_name(tmp_path: Path) -> None:
+    books = tmp_path / "books"
+    books.mkdir()
+    _make_book(books / "book.xlsx", "dir")
+    _make_book(tmp_path / "book.xlsx", "file")
+    out = tmp_path / "out"
+
+    code = cli_main(
+        ["batch", str(books), str(tmp_path / "book.xlsx"), "-o", str(out)]
+        + ["-m", "light"]
+    )
+
+    assert code == 1
+    assert _cell(out / "book.json") == "dir"
+
+
+def test_batch_reports_missing_inputs(
+    tmp_path: Path, capsys: pytest.CaptureFixture[str]
+) -> None:
+    code = cli_main(["batch", str(tmp_path / "missing"), "-o", str(tmp_path)])
+
+    assert code == 1
+    assert "File not found" in capsys.readouterr().err
+
+
+def test_watch_extracts_added_and_saved_workbooks(
+    monkeypatch: pytest.MonkeyPatch, tmp_path: Path
+) -> None:
+    inbox = tmp_path / "inbox"
+    inbox.mkdir()
+    book = inbox / "book.xlsx"
+    _make_book(book, "first")
+    out = tmp_path / "out"
+    seen: list[object] = []
+
+    def _sleep(_seconds: float) -> None:
+        if (out / "book.json").exists():
+            seen.append(_cell(out / "book.json"))
+        if len(seen) == 1:
+            _make_book(book, "second")
+            stat = book.stat()
+            os.utime(book, ns=(stat.st_atime_ns, stat.st_mtime_ns + 1_000_000_000))
+
+    monkeypatch.setattr("exstruct.cli.bulk.time.sleep", _sleep)
+
+    code = cli_main(
+        ["watch", str(inbox), "-o", str(out), "-m", "light"]
+        + ["--interval", "0", "--max-scans", "5"]
+    )
+
+    assert code == 0
+    assert seen[0] == "first"
+    assert _cell(out / "book.json") == "second"
+
+
+def test_watch_requires_directory(
+    tmp_path: Path, capsys: pytest.CaptureFixture[str]
+) -> None:
+    code = cli_main(["watch", str(tmp_path / "missing"), "-o", str(tmp_path)])
+
+    assert code == 1
+    assert "Directory not found" in capsys.readouterr().err
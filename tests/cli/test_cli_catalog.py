@@ -0,0 +1,104 @@
+"""Tests for the catalog subcommand and workbook inventory."""
+
+from __future__ import annotations
+
+from contextlib import redirect_stdout
+import csv
+import io
+import json
+from pathlib import Path
+import zipfile
+
+from openpyxl import Workbook
+
+from exstruct.cli.main import main as cli_main
+from exstruct.ooxml.catalog import (
+    build_workbook_catalog,
+    catalog_to_csv,
+    iter_catalog_files,
+)
+
+
+def _make_book(path: Path, *, formula: bool = False) -> None:
+    wb = Workbook()
+    ws = wb.active
+    assert ws is not None
+    ws["A1"] = 1
+    if formula:
+        ws["A2"] = "=A1*2"
+    wb.save(path)
+
+
+def _make_estate(root: Path) -> None:
+    (root / "nested").mkdir()
+    _make_book(root / "a.xlsx", formula=True)
+    _make_book(root / "nested" / "b.xlsm")
+    (root / "~$a.xlsx").write_bytes(b"lock")
+    (root / "broken.xlsx").write_bytes(b"not a zip")
+    (root / "notes.txt").write_text("skip", encoding="utf-8")
+    with zipfile.ZipFile(root / "a.xlsx", "a") as zf:
+        zf.writestr("xl/vbaProject.bin", b"\x00")
+
+
+def test_iter_catalog_files_skips_lock_and_other_files(tmp_path: Path) -> None:
+    _make_estate(tmp_path)
+    found = [p.relative_to(tmp_path).as_posix() for p in iter_catalog_files(tmp_path)]
+    assert found == ["a.xlsx", "broken.xlsx", "nested/b.xlsm"]
+    top_level = iter_catalog_files(tmp_path, recursive=False)
+    assert [p.name for p in top_level] == ["a.xlsx", "broken.xlsx"]
+
+
+def test_build_workbook_catalog_aggregates_and_records_errors(
+    tmp_path: Path,
+) -> None:
+    _make_estate(tmp_path)
+
+    catalog = build_workbook_catalog(tmp_path)
+
+    entries = {entry.path: entry for entry in catalog.entries}
+    book_a = entries["a.xlsx"]
+    assert book_a.sheets == ["Sheet"]
+    assert (book_a.non_empty_cells, book_a.formula_count) == (2, 1)
+    assert book_a.features == ["formulas", "macros"]
+    assert entries["nested/b.xlsm"].features == []
+    assert entries["broken.xlsx"].error is not None
+    assert entries["broken.xlsx"].sheets == []
+
+
+def test_catalog_to_csv_joins_list_columns(tmp_path: Path) -> None:
+    _make_estate(tmp_path)
+    catalog = build_workbook_catalog(tmp_path)
+
+    rows = list(csv.DictReader(io.StringIO(catalog_to_csv(catalog.entries))))
+
+    assert [row["path"] for row in rows] == ["a.xlsx", "broken.xlsx", "nested/b.xlsm"]
+    assert rows[0]["features"] == "formulas|macros"
+    assert rows[0]["error"] == ""
+
+
+def test_catalog_cli_writes_json(tmp_path: Path) -> None:
+    estate = tmp_path / "estate"
+    estate.mkdir()
+    _make_estate(estate)
+    stdout = io.StringIO()
+    with redirect_stdout(stdout):
+        code = cli_main(argv=["catalog", str(estate), "--no-recursive"])
+
+    assert code == 0
+    payload = json.loads(stdout.getvalue())
+    assert [entry["path"] for entry in payload["entries"]] == [
+        "a.xlsx",
+        "broken.xlsx",
+    ]
+
+
+def test_catalog_cli_writes_csv_file(tmp_path: Path) -> None:
+    estate = tmp_path / "estate"
+    estate.mkdir()
+    _make_estate(estate)
+    output = tmp_path / "inventory.csv"
+
+    code = cli_main(argv=["catalog", str(estate), "-f", "csv", "-o", str(output)])
+
+    assert code == 0
+    assert output.read_text(encoding="utf-8").startswith("path,file_size,sheets,")
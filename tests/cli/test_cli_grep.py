@@ -0,0 +1,159 @@
+"""Tests for workbook full-text search and the grep subcommand."""
+
+from __future__ import annotations
+
+from contextlib import redirect_stderr, redirect_stdout
+import io
+import json
+from pathlib import Path
+
+from openpyxl import Workbook
+from openpyxl.comments import Comment
+import pytest
+
+from exstruct.analysis import search_workbook
+from exstruct.cli.main import is_grep_subcommand, main as cli_main
+from exstruct.core.cells import extract_cell_comments
+from exstruct.models import (
+    CellRow,
+    Chart,
+    SearchHit,
+    Shape,
+    SheetData,
+    SmartArt,
+    SmartArtNode,
+)
+
+
+def _sheets() -> dict[str, SheetData]:
+    return {
+        "Sales": SheetData(
+            rows=[
+                CellRow(r=1, c={"0": "Region", "1": "Invoice total"}),
+                CellRow(r=2, c={"1": 1200.5, "0": "East"}),
+            ],
+            shapes=[
+                Shape(id=3, text="Approve invoice\nthen file", l=0, t=0),
+                SmartArt(
+                    text="",
+                    l=0,
+                    t=0,
+                    layout="Process",
+                    nodes=[SmartArtNode(text="Draft", kids=[])],
+                    covered_range="D2:F4",
+                ),
+            ],
+            charts=[
+                Chart(
+                    name="Chart 1",
+                    chart_type="Column",
+                    title="Invoices by month",
+                    y_axis_title="",
+                    series=[],
+                    l=0,
+                    t=0,
+                )
+            ],
+        ),
+        "Notes": SheetData(rows=[CellRow(r=1, c={"A": "nothing here"})]),
+    }
+
+
+def test_search_workbook_covers_all_text_sources() -> None:
+    hits = search_workbook(
+        _sheets(),
+        "invoice",
+        ignore_case=True,
+        comments={"Sales": {"C2": "Check invoice number"}},
+    )
+
+    assert [(hit.kind, hit.location, hit.match) for hit in hits] == [
+        ("cell", "B1", "Invoice"),
+        ("comment", "C2", "invoice"),
+        ("shape", "shape 3", "invoice"),
+        ("chart", "Chart 1", "Invoice"),
+    ]
+    assert hits[2].context == "Approve invoice then file"
+
+
+def test_search_workbook_numbers_fixed_strings_and_context() -> None:
+    sheets = _sheets()
+    long_text = "x" * 50 + "needle" + "y" * 50
+    sheets["Notes"] = SheetData(rows=[CellRow(r=7, c={"2": long_text})])
+
+    numbers = search_workbook(sheets, "1200.5", fixed_strings=True)
+    smartart = search_workbook(sheets, "Draft")
+    clipped = search_workbook(sheets, "needle")
+
+    assert numbers == [
+        SearchHit(
+            sheet="Sales", kind="cell", location="B2", match="1200.5", context="1200.5"
+        )
+    ]
+    assert smartart[0].location == "D2:F4"
+    assert clipped[0].location == "C7"
+    assert clipped[0].context == "..." + "x" * 30 + "needle" + "y" * 30 + "..."
+    assert search_workbook(sheets, "invoice") == [
+        search_workbook(sheets, "invoice", ignore_case=True)[1]
+    ]
+    with pytest.raises(ValueError, match="Invalid search pattern"):
+        search_workbook(sheets, "(")
+
+
+def _make_book(path: Path) -> None:
+    wb = Workbook()
+    ws = wb.active
+    assert ws is not None
+    ws.title = "Data"
+    ws["A1"] = "Invoice"
+    ws["B2"] = "paid"
+    ws["B2"].comment = Comment("Invoice paid late", "auditor")
+    wb.save(path)
+
+
+def test_extract_cell_comments(tmp_path: Path) -> None:
+    path = tmp_path / "book.xlsx"
+    _make_book(path)
+
+    assert extract_cell_comments(path) == {"Data": {"B2": "Invoice paid late"}}
+
+
+def test_grep_cli_text_and_json(tmp_path: Path) -> None:
+    path = tmp_path / "book.xlsx"
+    _make_book(path)
+    text_out = io.StringIO()
+    json_out = io.StringIO()
+
+    assert is_grep_subcommand(["grep", "x", str(path)])
+    with redirect_stdout(text_out):
+        code = cli_main(["grep", "Invoice", str(path), "--mode", "light"])
+    with redirect_stdout(json_out):
+        json_code = cli_main(["grep", "-i", "PAID", str(path), "-m", "light", "--json"])
+
+    assert code == 0
+    assert text_out.getvalue().splitlines() == [
+        "Data!A1 [cell]: Invoice",
+        "Data!B2 [comment]: Invoice paid late",
+    ]
+    assert json_code == 0
+    assert [hit["kind"] for hit in json.loads(json_out.getvalue())] == [
+        "cell",
+        "comment",
+    ]
+
+
+def test_grep_cli_exit_codes(tmp_path: Path) -> None:
+    path = tmp_path / "book.xlsx"
+    _make_book(path)
+    stderr = io.StringIO()
+
+    with redirect_stdout(io.StringIO()):
+        no_match = cli_main(["grep", "absent", str(path), "--mode", "light"])
+    with redirect_stderr(stderr):
+        missing = cli_main(["grep", "x", str(tmp_path / "missing.xlsx")])
+        bad_pattern = cli_main(["grep", "(", str(path), "--mode", "light"])
+
+    assert no_match == 1
+    assert missing == 2
+    assert bad_pattern == 2
+    assert "File not found" in stderr.getvalue()
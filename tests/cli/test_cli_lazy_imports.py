@@ -57,6 +57,7 @@ print(json.dumps({
     "core_cells": "exstruct.core.cells" in sys.modules,
     "core_integrate": "exstruct.core.integrate" in sys.modules,
     "io": "exstruct.io" in sys.modules,
+    "ooxml": "exstruct.ooxml" in sys.modules,
     "render": "exstruct.render" in sys.modules,
     "numpy": "numpy" in sys.modules,
     "pandas": "pandas" in sys.modules,
@@ -72,6 +73,7 @@ print(json.dumps({
         "core_cells": False,
         "core_integrate": False,
         "io": False,
+        "ooxml": False,
         "render": False,
         "numpy": False,
         "pandas": False,
@@ -0,0 +1,153 @@
+"""Tests for CLI exit codes and the --report run report."""
+
+from __future__ import annotations
+
+import json
+import logging
+from pathlib import Path
+from zipfile import BadZipFile
+
+import pytest
+
+from exstruct.cli.main import (
+    EXIT_FAILURE,
+    EXIT_INVALID_INPUT,
+    EXIT_NOT_FOUND,
+    EXIT_OK,
+    EXIT_PARTIAL,
+    main as cli_main,
+)
+from exstruct.engine import RunReport
+from exstruct.models import CellRow, SheetData, WorkbookData
+
+
+def _workbook(warnings: list[str] | None = None) -> WorkbookData:
+    return WorkbookData(
+        book_name="book.xlsx",
+        sheets={
+            "Sheet1": SheetData(
+                rows=[CellRow(r=1, c={"0": "a", "1": "b"}), CellRow(r=2, c={"0": 1})],
+                table_candidates=["A1:B2"],
+            )
+        },
+        warnings=warnings or [],
+    )
+
+
+def _book(tmp_path: Path) -> Path:
+    path = tmp_path / "book.xlsx"
+    path.write_bytes(b"placeholder")
+    return path
+
+
+def _read_report(path: Path) -> dict[str, object]:
+    return json.loads(path.read_text(encoding="utf-8"))
+
+
+def test_run_report_records_workbook_counts() -> None:
+    report = RunReport(file="book.xlsx")
+    with report.timed("extract"):
+        pass
+    report.record_workbook(_workbook())
+
+    assert report.counts == {
+        "sheets": 1,
+        "rows": 2,
+        "cells": 3,
+        "shapes": 0,
+        "charts": 0,
+        "table_candidates": 1,
+    }
+    assert report.status == "ok"
+    assert report.durations["extract"] >= 0
+    report.record_workbook(_workbook(["Skipped part xl/x.xml: bad"]))
+    assert report.status == "partial"
+
+
+def test_cli_missing_input_exits_not_found(tmp_path: Path) -> None:
+    report_path = tmp_path / "report.json"
+
+    code = cli_main([str(tmp_path / "nope.xlsx"), "--report", str(report_path)])
+
+    assert code == EXIT_NOT_FOUND
+    report = _read_report(report_path)
+    assert report["status"] == "error"
+    assert report["exit_code"] == EXIT_NOT_FOUND
+    assert "File not found" in str(report["error"])
+
+
+def test_cli_report_success(tmp_path: Path, monkeypatch: pytest.MonkeyPatch) -> None:
+    def _process(**kwargs: object) -> None:
+        report = kwargs["report"]
+        assert isinstance(report, RunReport)
+        report.record_workbook(_workbook())
+        logging.getLogger("exstruct.test").warning("COM unavailable")
+
+    monkeypatch.setattr("exstruct.cli.main.process_excel", _process)
+    report_path = tmp_path / "report.json"
+
+    code = cli_main([str(_book(tmp_path)), "--report", str(report_path)])
+
+    assert code == EXIT_OK
+    report = _read_report(report_path)
+    assert report["status"] == "ok"
+    assert report["exit_code"] == EXIT_OK
+    assert report["counts"] == {
+        "sheets": 1,
+        "rows": 2,
+        "cells": 3,
+        "shapes": 0,
+        "charts": 0,
+        "table_candidates": 1,
+    }
+    durations = report["durations"]
+    assert isinstance(durations, dict)
+    assert "total" in durations
+    assert report["log_warnings"] == ["COM unavailable"]
+
+
+def test_cli_partial_extraction_exit_code(
+    tmp_path: Path, monkeypatch: pytest.MonkeyPatch
+) -> None:
+    def _process(**kwargs: object) -> None:
+        report = kwargs["report"]
+        assert isinstance(report, RunReport)
+        report.record_workbook(_workbook(["Skipped sheet 'Bad'"]))
+
+    monkeypatch.setattr("exstruct.cli.main.process_excel", _process)
+    report_path = tmp_path / "report.json"
+
+    code = cli_main([str(_book(tmp_path)), "--report", str(report_path)])
+
+    assert code == EXIT_PARTIAL
+    report = _read_report(report_path)
+    assert report["status"] == "partial"
+    assert report["warnings"] == ["Skipped sheet 'Bad'"]
+
+
+@pytest.mark.parametrize(
+    ("error", "expected"),
+    [
+        (BadZipFile("File is not a zip file"), EXIT_INVALID_INPUT),
+        (RuntimeError("boom"), EXIT_FAILURE),
+    ],
+)
+def test_cli_failure_exit_codes(
+    tmp_path: Path,
+    monkeypatch: pytest.MonkeyPatch,
+    error: Exception,
+    expected: int,
+) -> None:
+    def _process(**_kwargs: object) -> None:
+        raise error
+
+    monkeypatch.setattr("exstruct.cli.main.process_excel", _process)
+    report_path = tmp_path / "report.json"
+
+    code = cli_main([str(_book(tmp_path)), "--report", str(report_path)])
+
+    assert code == expected
+    report = _read_report(report_path)
+    assert report["status"] == "error"
+    assert report["error"] == str(error)
+    assert report["exit_code"] == expected
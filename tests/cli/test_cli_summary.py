@@ -0,0 +1,85 @@
+"""Tests for the summary subcommand and OOXML workbook summary."""
+
+from __future__ import annotations
+
+from contextlib import redirect_stderr, redirect_stdout
+import io
+import json
+from pathlib import Path
+
+from openpyxl import Workbook
+from openpyxl.chart import BarChart, Reference
+from openpyxl.worksheet.table import Table
+import pytest
+
+from exstruct.cli.main import is_inspect_subcommand, main as cli_main
+from exstruct.ooxml.summary import summarize_workbook_ooxml
+
+
+def _make_book(path: Path) -> None:
+    wb = Workbook()
+    ws = wb.active
+    assert ws is not None
+    ws.title = "Data"
+    ws.append(["name", "value"])
+    ws.append(["a", 1])
+    ws.append(["b", 2])
+    ws["D5"] = "=SUM(B2:B3)"
+    ws.add_table(Table(displayName="Items", ref="A1:B3"))
+    chart = BarChart()
+    values = Reference(ws, min_col=2, min_row=1, max_row=3)
+    chart.add_data(values, titles_from_data=True)
+    ws.add_chart(chart, "F2")
+    wb.create_sheet("Empty")
+    wb.save(path)
+
+
+def test_summarize_workbook_ooxml_counts_sheet_content(tmp_path: Path) -> None:
+    path = tmp_path / "book.xlsx"
+    _make_book(path)
+
+    summary = summarize_workbook_ooxml(path)
+
+    assert summary.book_name == "book.xlsx"
+    assert summary.file_size == path.stat().st_size
+    assert [sheet.name for sheet in summary.sheets] == ["Data", "Empty"]
+    data, empty = summary.sheets
+    assert data.used_range == "A1:D5"
+    assert (data.row_count, data.column_count) == (5, 4)
+    assert data.non_empty_cells == 7
+    assert data.formula_count == 1
+    assert (data.chart_count, data.shape_count, data.table_count) == (1, 0, 1)
+    assert data.part_size == summary.part_sizes["xl/worksheets/sheet1.xml"]
+    assert empty.used_range is None
+    assert empty.non_empty_cells == 0
+
+
+def test_summary_cli_prints_json(tmp_path: Path) -> None:
+    path = tmp_path / "book.xlsx"
+    _make_book(path)
+    stdout = io.StringIO()
+    with redirect_stdout(stdout):
+        code = cli_main(argv=["summary", str(path)])
+
+    assert code == 0
+    payload = json.loads(stdout.getvalue())
+    assert payload["sheets"][0]["formula_count"] == 1
+
+
+def test_summary_cli_reports_missing_file(tmp_path: Path) -> None:
+    stderr = io.StringIO()
+    with redirect_stderr(stderr):
+        code = cli_main(argv=["summary", str(tmp_path / "missing.xlsx")])
+
+    assert code == 1
+    assert "File not found" in stderr.getvalue()
+
+
+def test_is_inspect_subcommand_prefers_existing_file(
+    tmp_path: Path, monkeypatch: pytest.MonkeyPatch
+) -> None:
+    monkeypatch.chdir(tmp_path)
+    assert is_inspect_subcommand(["summary", "book.xlsx"])
+    assert is_inspect_subcommand(["catalog", "books"])
+    (tmp_path / "summary").write_text("", encoding="utf-8")
+    assert not is_inspect_subcommand(["summary"])
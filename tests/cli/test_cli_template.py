@@ -0,0 +1,128 @@
+"""Tests for template-based extraction and the apply-template subcommand."""
+
+from __future__ import annotations
+
+from contextlib import redirect_stderr, redirect_stdout
+import io
+import json
+from pathlib import Path
+
+from openpyxl import Workbook
+import pytest
+
+from exstruct.cli.main import is_template_subcommand, main as cli_main
+from exstruct.errors import ConfigError
+from exstruct.models import CellRow, SheetData, WorkbookData
+from exstruct.template import ExtractionTemplate, apply_template, load_template
+
+
+def _workbook() -> WorkbookData:
+    return WorkbookData(
+        book_name="form.xlsx",
+        sheets={
+            "Invoice": SheetData(
+                rows=[
+                    CellRow(r=1, c={"0": "Invoice No", "1": "INV-7"}),
+                    CellRow(r=2, c={"0": " Customer ", "2": "Acme"}),
+                    CellRow(r=4, c={"0": "Item", "1": "Qty"}),
+                    CellRow(r=5, c={"0": "Pen", "1": 3}),
+                    CellRow(r=6, c={"0": "Ink"}),
+                ]
+            ),
+            "Notes": SheetData(rows=[CellRow(r=1, c={"A": "Total", "B": 42})]),
+        },
+    )
+
+
+def test_apply_template_reads_cells_ranges_and_labels() -> None:
+    template = ExtractionTemplate.model_validate(
+        {
+            "sheet": "Invoice",
+            "fields": {
+                "invoice_no": "B1",
+                "items": "A5:B6",
+                "customer": {"label": "customer:", "match": "fuzzy", "offset": 2},
+                "first_item": {"label": "Item", "direction": "below"},
+                "total": {"sheet": "Notes", "label": "Total"},
+                "missing": {"label": "Due date"},
+                "empty": "Z9",
+            },
+        }
+    )
+
+    record = apply_template(_workbook(), template)
+
+    assert record == {
+        "invoice_no": "INV-7",
+        "items": [["Pen", 3], ["Ink", None]],
+        "customer": "Acme",
+        "first_item": "Pen",
+        "total": 42,
+        "missing": None,
+        "empty": None,
+    }
+
+
+def test_template_validation_and_unknown_sheet() -> None:
+    with pytest.raises(ValueError):
+        ExtractionTemplate.model_validate(
+            {"fields": {"x": {"cell": "A1", "label": "y"}}}
+        )
+    with pytest.raises(ValueError):
+        ExtractionTemplate.model_validate({"fields": {"x": "not a cell"}})
+    with pytest.raises(ValueError):
+        ExtractionTemplate.model_validate(
+            {"fields": {"x": {"label": "(", "match": "regex"}}}
+        )
+    template = ExtractionTemplate.model_validate(
+        {"sheet": "Missing", "fields": {"x": "A1"}}
+    )
+    with pytest.raises(ConfigError):
+        apply_template(_workbook(), template)
+
+
+def test_load_template_errors(tmp_path: Path) -> None:
+    bad = tmp_path / "form.json"
+    bad.write_text('{"fields": {"x": {"offset": 0, "label": "a"}}}', encoding="utf-8")
+
+    with pytest.raises(ConfigError, match="Invalid template"):
+        load_template(bad)
+    with pytest.raises(ConfigError, match="Unsupported"):
+        load_template(tmp_path / "form.txt")
+
+
+def test_apply_template_cli(tmp_path: Path) -> None:
+    wb = Workbook()
+    ws = wb.active
+    assert ws is not None
+    ws.title = "Form"
+    ws["A1"] = "Name"
+    ws["B1"] = "Taro"
+    ws["A2"] = "Age"
+    ws["B2"] = 30
+    book = tmp_path / "form.xlsx"
+    wb.save(book)
+    template = tmp_path / "form.json"
+    template.write_text(
+        json.dumps({"fields": {"name": {"label": "Name"}, "age": "B2"}}),
+        encoding="utf-8",
+    )
+    stdout = io.StringIO()
+
+    assert is_template_subcommand(["apply-template", str(template), str(book)])
+    with redirect_stdout(stdout):
+        code = cli_main(["apply-template", str(template), str(book)])
+
+    assert code == 0
+    assert json.loads(stdout.getvalue()) == {"name": "Taro", "age": 30}
+
+
+def test_apply_template_cli_reports_errors(tmp_path: Path) -> None:
+    stderr = io.StringIO()
+    with redirect_stderr(stderr):
+        code = cli_main(
+            ["apply-template", str(tmp_path / "t.json"), str(tmp_path / "x.xlsx")]
+        )
+
+    assert code == 1
+    assert "File not found" in stderr.getvalue()
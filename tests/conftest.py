@@ -72,6 +72,16 @@ def pytest_configure(config: pytest.Config) -> None:
     )
 
 
+def pytest_addoption(parser: pytest.Parser) -> None:
+    """Register suite-wide command-line options."""
+    parser.addoption(
+        "--update-golden",
+        action="store_true",
+        default=False,
+        help="Rewrite tests/golden/testdata/*.golden.json from current output.",
+    )
+
+
 @lru_cache(maxsize=1)
 def _has_excel_com() -> bool:
     """Return True if Excel COM can be opened via xlwings."""
@@ -0,0 +1,114 @@
+"""Tests for error value cell extraction."""
+
+import logging
+from pathlib import Path
+import re
+import zipfile
+
+from _pytest.monkeypatch import MonkeyPatch
+from openpyxl import Workbook
+import pytest
+
+from exstruct.core.backends.openpyxl_backend import OpenpyxlBackend
+from exstruct.core.cells import extract_sheet_cell_errors
+from exstruct.core.pipeline import (
+    ExtractionArtifacts,
+    ExtractionInputs,
+    ExtractionMode,
+    build_pre_com_pipeline,
+    resolve_extraction_inputs,
+    step_extract_cell_errors_openpyxl,
+)
+from exstruct.models import CellError
+
+
+def _resolve(
+    path: Path, mode: ExtractionMode, include_cell_errors: bool | None = None
+) -> ExtractionInputs:
+    return resolve_extraction_inputs(
+        path,
+        mode=mode,
+        include_cell_links=None,
+        include_print_areas=None,
+        include_auto_page_breaks=False,
+        include_colors_map=None,
+        include_default_background=False,
+        ignore_colors=None,
+        include_formulas_map=None,
+        include_merged_cells=None,
+        include_merged_values_in_rows=True,
+        include_cell_errors=include_cell_errors,
+    )
+
+
+def _make_error_book(path: Path) -> None:
+    wb = Workbook()
+    ws = wb.active
+    ws.title = "Sheet1"
+    ws["A1"] = "ok"
+    ws["C2"] = "#N/A"
+    ws["B3"] = "=1/0"
+    wb.save(path)
+    # openpyxl does not write cached values, so inject the cached formula error.
+    with zipfile.ZipFile(path) as src:
+        entries = {name: src.read(name) for name in src.namelist()}
+    sheet_xml = entries["xl/worksheets/sheet1.xml"].decode("utf-8")
+    sheet_xml = re.sub(
+        r'<c r="B3"[^>]*>.*?</c>',
+        '<c r="B3" t="e"><f>1/0</f><v>#DIV/0!</v></c>',
+        sheet_xml,
+    )
+    entries["xl/worksheets/sheet1.xml"] = sheet_xml.encode("utf-8")
+    with zipfile.ZipFile(path, "w") as dst:
+        for name, data in entries.items():
+            dst.writestr(name, data)
+
+
+def test_extract_sheet_cell_errors_reports_constants_and_formulas(
+    tmp_path: Path,
+) -> None:
+    path = tmp_path / "errors.xlsx"
+    _make_error_book(path)
+
+    errors = extract_sheet_cell_errors(path)["Sheet1"]
+
+    assert errors == [
+        CellError(cell="C2", error="#N/A"),
+        CellError(cell="B3", error="#DIV/0!", formula="=1/0"),
+    ]
+
+
+def test_extract_cell_errors_returns_empty_on_failure(
+    tmp_path: Path, monkeypatch: MonkeyPatch, caplog: "pytest.LogCaptureFixture"
+) -> None:
+    def _raise(_: Path) -> object:
+        raise RuntimeError("boom")
+
+    monkeypatch.setattr(
+        "exstruct.core.backends.openpyxl_backend.extract_sheet_cell_errors", _raise
+    )
+    backend = OpenpyxlBackend(tmp_path / "book.xlsx")
+    with caplog.at_level(logging.WARNING):
+        assert backend.extract_cell_errors() == {}
+    assert "Cell error extraction failed" in caplog.text
+
+
+def test_resolve_extraction_inputs_cell_errors_defaults(tmp_path: Path) -> None:
+    light = _resolve(tmp_path / "book.xlsx", "light")
+    standard = _resolve(tmp_path / "book.xlsx", "standard")
+    forced = _resolve(tmp_path / "book.xlsx", "light", include_cell_errors=True)
+    assert light.include_cell_errors is False
+    assert standard.include_cell_errors is True
+    assert step_extract_cell_errors_openpyxl in build_pre_com_pipeline(forced)
+    assert step_extract_cell_errors_openpyxl not in build_pre_com_pipeline(light)
+
+
+def test_step_extract_cell_errors_openpyxl_sets_data(tmp_path: Path) -> None:
+    path = tmp_path / "errors.xlsx"
+    _make_error_book(path)
+    inputs = _resolve(path, "light", include_cell_errors=True)
+    artifacts = ExtractionArtifacts()
+
+    step_extract_cell_errors_openpyxl(inputs, artifacts)
+
+    assert [e.cell for e in artifacts.cell_error_data["Sheet1"]] == ["C2", "B3"]
@@ -2,13 +2,17 @@ from pathlib import Path
 
 from _pytest.monkeypatch import MonkeyPatch
 from openpyxl import Workbook
+from openpyxl.styles import Border, Side
 from openpyxl.worksheet.table import Table, TableStyleInfo
+import pytest
 
 from exstruct.core import cells
 from exstruct.core.cells import (
     _coerce_numeric_preserve_format,
+    _nonempty_clusters,
     _normalize_formula_from_com,
     _normalize_formula_value,
+    _split_on_blank_lines,
     detect_tables_openpyxl,
     extract_sheet_formulas_map,
     extract_sheet_formulas_map_com,
@@ -70,6 +74,78 @@ def test_detect_tables_openpyxl_respects_table_params(
     assert "A1:B2" in tables
 
 
+def test_detect_tables_openpyxl_keeps_bordered_grid_whole(tmp_path: Path) -> None:
+    path = tmp_path / "form.xlsx"
+    wb = Workbook()
+    ws = wb.active
+    ws.title = "Sheet1"
+    thin = Side(style="thin")
+    ws.append(["品名", "数量", "金額"])
+    ws.append(["A", 1, 100])
+    ws.append([None, None, None])  # blank entry row splits the value clusters
+    ws.append(["B", 2, 200])
+    ws.append(["C", 3, 300])
+    for row in ws["A1:C5"]:
+        for cell in row:
+            cell.border = Border(left=thin, right=thin, top=thin, bottom=thin)
+    wb.save(path)
+    wb.close()
+
+    tables = detect_tables_openpyxl(path, "Sheet1")
+
+    assert "A1:C5" in tables
+    assert "A1:C2" not in tables
+
+
+def test_nonempty_clusters_bridge_gaps() -> None:
+    matrix = [
+        ["a", "b", None, "c"],
+        [1, 2, None, 3],
+        [None, None, None, None],
+        [4, 5, None, 6],
+    ]
+
+    assert _nonempty_clusters(matrix) == [
+        (0, 0, 1, 1),
+        (0, 3, 1, 3),
+        (3, 0, 3, 1),
+        (3, 3, 3, 3),
+    ]
+    assert _nonempty_clusters(matrix, gap_tolerance=1) == [(0, 0, 3, 3)]
+
+
+def test_split_on_blank_lines_separates_side_by_side_tables() -> None:
+    # The merged title filled across row 0 joins both tables into one cluster.
+    matrix = [
+        ["Report", "Report", "Report", "Report", "Report"],
+        ["a", "b", None, "x", "y"],
+        [1, 2, None, 3, 4],
+        [5, 6, None, 7, 8],
+        [None, None, None, None, None],
+        ["p", "q", "Note", "r", "s"],
+        [9, 9, None, 9, 9],
+    ]
+
+    assert _nonempty_clusters(matrix[:4]) == [(0, 0, 3, 4)]
+    assert _split_on_blank_lines(matrix[:4]) == [(0, 0, 3, 1), (0, 3, 3, 4)]
+    assert _split_on_blank_lines(matrix[:4], gap_tolerance=1) == [(0, 0, 3, 4)]
+    assert _split_on_blank_lines(matrix) == [
+        (0, 0, 3, 1),
+        (0, 3, 3, 4),
+        (5, 0, 6, 4),
+    ]
+
+
+def test_set_table_detection_params_gap_tolerance(monkeypatch: MonkeyPatch) -> None:
+    monkeypatch.setattr(cells, "_DETECTION_CONFIG", dict(cells._DETECTION_CONFIG))
+
+    cells.set_table_detection_params(gap_tolerance=2)
+
+    assert cells._DETECTION_CONFIG["gap_tolerance"] == 2
+    with pytest.raises(ValueError):
+        cells.set_table_detection_params(gap_tolerance=-1)
+
+
 def test_normalize_formula_value_prefers_array_text() -> None:
     """
     Verify that _normalize_formula_value prefers an array-like object's text and treats an empty string as no formula.
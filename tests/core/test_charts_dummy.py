@@ -1,6 +1,14 @@
 from dataclasses import dataclass
 
-from exstruct.core.charts import get_charts
+import pytest
+
+from exstruct.core.charts import (
+    _get_error_bars,
+    _get_series_cache,
+    _get_trendlines,
+    _grouping_from_label,
+    get_charts,
+)
 from exstruct.models.maps import XL_CHART_TYPE_MAP
 
 
@@ -114,6 +122,10 @@ def test_get_charts_builds_chart_and_series() -> None:
     assert chart.series
     assert chart.series[0].name == "Series1"
     assert chart.series[0].name_range is None
+    assert len(chart.value_axes) == 1
+    assert chart.value_axes[0].secondary is False
+    assert chart.value_axes[0].title == "Y Axis"
+    assert chart.value_axes[0].range == [0.0, 100.0]
 
 
 def test_get_charts_sets_error_on_failure() -> None:
@@ -131,3 +143,179 @@ def test_get_charts_sets_error_on_failure() -> None:
 
     assert len(charts) == 1
     assert charts[0].error is not None
+
+
+@dataclass(frozen=True)
+class _DummyLegend:
+    Position: int
+
+
+@dataclass(frozen=True)
+class _DummyDataLabels:
+    ShowValue: bool
+    ShowPercentage: bool
+    ShowCategoryName: bool
+    ShowSeriesName: bool
+    NumberFormat: str
+
+
+@dataclass(frozen=True)
+class _DummyLabeledSeries(_DummySeries):
+    HasDataLabels: bool
+    _labels: _DummyDataLabels
+
+    def DataLabels(self) -> _DummyDataLabels:
+        return self._labels
+
+
+@dataclass(frozen=True)
+class _DummyLegendChartCom(_DummyChartCom):
+    HasLegend: bool
+    Legend: _DummyLegend
+
+
+def test_get_charts_reads_legend_and_data_labels() -> None:
+    labels = _DummyDataLabels(
+        ShowValue=True,
+        ShowPercentage=False,
+        ShowCategoryName=False,
+        ShowSeriesName=False,
+        NumberFormat="0.0%",
+    )
+    series = [
+        _DummyLabeledSeries(
+            Name="Series1",
+            Formula="=SERIES(,Sheet1!$A$1:$A$2,Sheet1!$B$1:$B$2,1)",
+            HasDataLabels=True,
+            _labels=labels,
+        )
+    ]
+    axis = _DummyAxis(
+        HasTitle=False,
+        AxisTitle=_DummyAxisTitle(Text=""),
+        MinimumScale=0.0,
+        MaximumScale=1.0,
+    )
+    chart_com = _DummyLegendChartCom(
+        ChartType=4,
+        _series=series,
+        _axis=axis,
+        HasTitle=False,
+        ChartTitle=_DummyChartTitle(Text=""),
+        HasLegend=True,
+        Legend=_DummyLegend(Position=-4107),
+    )
+    chart_shape = _DummyChartShape(
+        name="Chart1", width=200.0, height=100.0, left=10.0, top=20.0
+    )
+    sheet = _DummySheet(
+        charts=[chart_shape],
+        api=_DummySheetApi({"Chart1": _DummyChartObject(Chart=chart_com)}),
+    )
+
+    chart = get_charts(sheet)[0]
+
+    assert chart.legend is not None
+    assert chart.legend.visible is True
+    assert chart.legend.position == "bottom"
+    data_labels = chart.series[0].data_labels
+    assert data_labels is not None
+    assert data_labels.show_value is True
+    assert data_labels.show_percentage is False
+    assert data_labels.number_format == "0.0%"
+
+
+@pytest.mark.parametrize(
+    ("label", "expected"),
+    [
+        ("ColumnClustered", ("clustered", "vertical")),
+        ("BarStacked100", ("percent_stacked", "horizontal")),
+        ("CylinderBarStacked", ("stacked", "horizontal")),
+        ("AreaStacked", ("stacked", None)),
+        ("Line", ("standard", None)),
+        ("BarOfPie", (None, None)),
+        ("Pie", (None, None)),
+    ],
+)
+def test_grouping_from_label(
+    label: str, expected: tuple[str | None, str | None]
+) -> None:
+    assert _grouping_from_label(label) == expected
+
+
+@dataclass(frozen=True)
+class _DummyTrendline:
+    Type: int
+    Name: str
+    Order: int
+    Period: int
+    DisplayEquation: bool
+    DisplayRSquared: bool
+
+
+@dataclass(frozen=True)
+class _DummyTrendlines:
+    _items: list[_DummyTrendline]
+
+    @property
+    def Count(self) -> int:
+        return len(self._items)
+
+    def Item(self, index: int) -> _DummyTrendline:
+        return self._items[index - 1]
+
+
+@dataclass(frozen=True)
+class _DummyAnalyticSeries:
+    _trendlines: _DummyTrendlines
+    HasErrorBars: bool
+
+    def Trendlines(self) -> _DummyTrendlines:
+        return self._trendlines
+
+
+def test_get_trendlines_and_error_bars() -> None:
+    series = _DummyAnalyticSeries(
+        _trendlines=_DummyTrendlines(
+            [
+                _DummyTrendline(
+                    Type=-4132,
+                    Name="",
+                    Order=2,
+                    Period=2,
+                    DisplayEquation=True,
+                    DisplayRSquared=False,
+                ),
+                _DummyTrendline(
+                    Type=99,
+                    Name="",
+                    Order=2,
+                    Period=2,
+                    DisplayEquation=False,
+                    DisplayRSquared=False,
+                ),
+            ]
+        ),
+        HasErrorBars=True,
+    )
+
+    trendlines = _get_trendlines(series)
+    assert len(trendlines) == 1
+    assert trendlines[0].type == "linear"
+    assert trendlines[0].order is None
+    assert trendlines[0].display_equation is True
+    assert len(_get_error_bars(series)) == 1
+    assert _get_error_bars(object()) == []
+
+
+@dataclass(frozen=True)
+class _DummyCachedSeries:
+    XValues: tuple[object, ...]
+    Values: tuple[object, ...]
+
+
+def test_get_series_cache_converts_values() -> None:
+    series = _DummyCachedSeries(XValues=("A", None, 3), Values=(1, None, "x"))
+    categories, values = _get_series_cache(series)
+    assert categories == ["A", None, "3"]
+    assert values == [1.0, None, None]
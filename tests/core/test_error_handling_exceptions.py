@@ -53,12 +53,13 @@ def test_save_as_json_write_failure(
     """IO failures should surface as OutputError."""
     workbook = _minimal_workbook()
 
-    def _fail_write(self: Path, *args: object, **kwargs: object) -> None:
+    def _fail_open(self: Path, *args: object, **kwargs: object) -> None:
         raise OSError("disk full")
 
-    monkeypatch.setattr(Path, "write_text", _fail_write)
+    monkeypatch.setattr(Path, "open", _fail_open)
     with pytest.raises(OutputError):
         save_as_json(workbook, tmp_path / "out.json")
+    assert list(tmp_path.iterdir()) == []
 
 
 def test_export_auto_page_breaks_raises_print_area_error(tmp_path: Path) -> None:
@@ -0,0 +1,123 @@
+"""Tests for the custom extractor registry."""
+
+from __future__ import annotations
+
+from collections.abc import Iterator
+from dataclasses import dataclass
+import json
+from pathlib import Path
+
+from openpyxl import Workbook
+from pydantic import JsonValue
+import pytest
+
+from exstruct import process_excel
+from exstruct.core.extractors import (
+    Extractor,
+    ExtractorContext,
+    register_extractor,
+    registered_extractors,
+    run_extractors,
+    unregister_extractor,
+)
+from exstruct.errors import ExtractionError
+from exstruct.models import SheetData, WorkbookData
+
+
+@dataclass
+class _TitleExtractor:
+    name: str = "title"
+
+    def extract(
+        self, context: ExtractorContext, sheet_name: str, sheet: SheetData
+    ) -> JsonValue | None:
+        assert context.package is not None
+        assert "xl/workbook.xml" in context.package.namelist()
+        value = context.workbook[sheet_name]["A1"].value
+        return {"title": value} if value else None
+
+
+class _FailingExtractor:
+    name = "broken"
+
+    def extract(
+        self, context: ExtractorContext, sheet_name: str, sheet: SheetData
+    ) -> JsonValue | None:
+        raise RuntimeError("boom")
+
+
+@pytest.fixture(autouse=True)
+def _clean_registry() -> Iterator[None]:
+    yield
+    for extractor in registered_extractors():
+        unregister_extractor(extractor.name)
+
+
+def _book(tmp_path: Path) -> Path:
+    wb = Workbook()
+    ws = wb.active
+    assert ws is not None
+    ws.title = "Meta"
+    ws["A1"] = "Quarterly"
+    wb.create_sheet("Empty")
+    path = tmp_path / "book.xlsx"
+    wb.save(path)
+    return path
+
+
+def _workbook() -> WorkbookData:
+    return WorkbookData(
+        book_name="book.xlsx", sheets={"Meta": SheetData(), "Empty": SheetData()}
+    )
+
+
+def test_registered_extractor_results_land_in_extensions(tmp_path: Path) -> None:
+    assert isinstance(_TitleExtractor(), Extractor)
+    register_extractor(_TitleExtractor())
+
+    workbook = run_extractors(_workbook(), _book(tmp_path), mode="light")
+
+    assert workbook.sheets["Meta"].extensions == {"title": {"title": "Quarterly"}}
+    assert workbook.sheets["Empty"].extensions == {}
+    payload = json.loads(workbook.to_json())
+    assert payload["sheets"]["Meta"]["extensions"]["title"]["title"] == "Quarterly"
+    assert "extensions" not in payload["sheets"]["Empty"]
+
+
+def test_register_extractor_rejects_duplicates() -> None:
+    register_extractor(_TitleExtractor())
+
+    with pytest.raises(ValueError, match="already registered"):
+        register_extractor(_TitleExtractor())
+    register_extractor(_TitleExtractor(), replace=True)
+    assert len(registered_extractors()) == 1
+
+
+def test_run_extractors_wraps_failures(tmp_path: Path) -> None:
+    with pytest.raises(ExtractionError, match="Extractor 'broken' failed"):
+        run_extractors(
+            _workbook(),
+            _book(tmp_path),
+            mode="light",
+            extractors=[_FailingExtractor()],
+        )
+
+
+def test_run_extractors_without_extractors_skips_file(tmp_path: Path) -> None:
+    workbook = _workbook()
+
+    assert run_extractors(workbook, tmp_path / "missing.xlsx", mode="light") is (
+        workbook
+    )
+
+
+def test_process_excel_exports_extensions(tmp_path: Path) -> None:
+    register_extractor(_TitleExtractor())
+    out = tmp_path / "out.json"
+
+    process_excel(_book(tmp_path), output_path=out, mode="light")
+
+    payload = json.loads(out.read_text(encoding="utf-8"))
+    assert payload["sheets"]["Meta"]["extensions"] == {
+        "title": {"title": "Quarterly"}
+    }
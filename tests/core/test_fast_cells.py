@@ -0,0 +1,138 @@
+"""Tests for the streaming cell reader behind ``fast_cells``."""
+
+from __future__ import annotations
+
+from datetime import datetime
+from pathlib import Path
+import zipfile
+
+from openpyxl import Workbook
+import pytest
+
+from exstruct.cli.main import main as cli_main
+from exstruct.core.backends import openpyxl_backend
+from exstruct.core.cells import extract_sheet_cells
+from exstruct.core.fast_cells import extract_sheet_cells_fast
+from exstruct.engine import ExStructEngine, StructOptions
+from exstruct.models import CellRow
+
+_MAIN_NS = "http://schemas.openxmlformats.org/spreadsheetml/2006/main"
+
+
+def _book(tmp_path: Path) -> Path:
+    wb = Workbook()
+    ws = wb.active
+    assert ws is not None
+    ws.title = "Data"
+    ws["B2"] = "name"
+    ws["C2"] = 42
+    ws["D2"] = 1.5
+    ws["E2"] = 2.0
+    ws["F2"] = True
+    ws["G2"] = datetime(2024, 1, 2, 3, 4, 5)
+    ws["B3"] = "  "
+    ws["C3"] = "00123"
+    ws["D3"] = "N/A"
+    ws["E3"] = "=1/0"
+    ws["B5"] = "after gap"
+    other = wb.create_sheet("Other")
+    other["A1"] = "name"
+    other["AB1"] = "far"
+    path = tmp_path / "book.xlsx"
+    wb.save(path)
+    return path
+
+
+def _with_sheet_xml(path: Path, sheet_data: str) -> Path:
+    with zipfile.ZipFile(path) as src:
+        parts = {name: src.read(name) for name in src.namelist()}
+    parts["xl/worksheets/sheet1.xml"] = (
+        f'<worksheet xmlns="{_MAIN_NS}"><sheetData>{sheet_data}</sheetData>'
+        "</worksheet>"
+    ).encode()
+    patched = path.with_name(f"patched_{path.name}")
+    with zipfile.ZipFile(patched, "w") as out:
+        for name, data in parts.items():
+            out.writestr(name, data)
+    return patched
+
+
+def test_fast_reader_matches_default_reader(tmp_path: Path) -> None:
+    path = _book(tmp_path)
+
+    assert extract_sheet_cells_fast(path) == extract_sheet_cells(path)
+
+
+def test_fast_reader_values_and_positions(tmp_path: Path) -> None:
+    rows = extract_sheet_cells_fast(_book(tmp_path))
+
+    assert list(rows) == ["Data", "Other"]
+    data = rows["Data"]
+    assert [row.r for row in data] == [2, 3, 5]
+    assert data[0].c == {
+        "1": "name",
+        "2": 42,
+        "3": 1.5,
+        "4": 2,
+        "5": "True",
+        "6": "2024-01-02 03:04:05",
+    }
+    assert data[1].c == {"2": 123}
+    assert rows["Other"][0].c == {"0": "name", "27": "far"}
+
+
+def test_fast_reader_inline_and_error_cells(tmp_path: Path) -> None:
+    path = _with_sheet_xml(
+        _book(tmp_path),
+        '<row r="1">'
+        '<c r="A1" t="inlineStr"><is><r><t>rich </t></r><r><t>text</t></r>'
+        "<rPh><t>ruby</t></rPh></is></c>"
+        '<c r="B1" t="e"><v>#DIV/0!</v></c>'
+        '<c r="C1" t="str"><v>formula text</v></c>'
+        "</row>"
+        "<row><c><v>7</v></c><c><v>8.25</v></c></row>",
+    )
+
+    rows = extract_sheet_cells_fast(path)["Data"]
+
+    assert rows == [
+        CellRow(r=1, c={"0": "rich text", "2": "formula text"}),
+        CellRow(r=2, c={"0": 7, "1": 8.25}),
+    ]
+
+
+def test_fast_cells_option_selects_streaming_reader(
+    tmp_path: Path, monkeypatch: pytest.MonkeyPatch
+) -> None:
+    path = _book(tmp_path)
+    calls: list[Path] = []
+
+    def _fake_fast(file_path: Path) -> dict[str, list[CellRow]]:
+        calls.append(file_path)
+        return extract_sheet_cells(file_path)
+
+    monkeypatch.setattr(openpyxl_backend, "extract_sheet_cells_fast", _fake_fast)
+
+    ExStructEngine(options=StructOptions(mode="light")).extract(path)
+    assert calls == []
+    workbook = ExStructEngine(
+        options=StructOptions(mode="light", fast_cells=True)
+    ).extract(path)
+    assert calls == [path]
+    assert workbook.sheets["Data"].rows[0].c["1"] == "name"
+
+
+def test_cli_fast_cells_flag(tmp_path: Path) -> None:
+    path = _book(tmp_path)
+    fast_out = tmp_path / "fast.json"
+    default_out = tmp_path / "default.json"
+
+    assert cli_main([str(path), "--mode", "light", "-o", str(default_out)]) == 0
+    code = cli_main(
+        [str(path), "--mode", "light", "--fast-cells", "-o", str(fast_out)]
+    )
+
+    assert code == 0
+    assert fast_out.read_text(encoding="utf-8") == default_out.read_text(
+        encoding="utf-8"
+    )
@@ -350,10 +350,11 @@ def test_process_excel_sheets_dir_output(tmp_path: Path) -> None:
     process_excel(path, output_path=None, mode="light", sheets_dir=sheets_dir)
 
     files = list(sheets_dir.glob("*.json"))
-    assert len(files) == 2
+    assert len(files) == 3
     names = {f.stem for f in files}
     assert "Sheet1" in names
     assert "Data 02" in names
+    assert "index" in names
 
 
 def test_CLI_defaults_to_stdout(tmp_path: Path) -> None:
@@ -0,0 +1,89 @@
+"""Tests for defined-name resolution and range targets."""
+
+import json
+from pathlib import Path
+
+from openpyxl import Workbook
+from openpyxl.workbook.defined_name import DefinedName
+import pytest
+
+from exstruct.core.cells import extract_named_ranges
+from exstruct.core.ranges import RangeBounds, clip_rows, resolve_range_targets
+from exstruct.engine import ExStructEngine, FilterOptions, OutputOptions, StructOptions
+from exstruct.errors import ConfigError
+from exstruct.models import CellRow, NamedRange, SheetData
+
+
+def _book(tmp_path: Path) -> Path:
+    path = tmp_path / "names.xlsx"
+    wb = Workbook()
+    ws = wb.active
+    ws.title = "Data"
+    for row in range(1, 6):
+        for col in range(1, 5):
+            ws.cell(row=row, column=col, value=row * 10 + col)
+    other = wb.create_sheet("Other")
+    other["A1"] = "x"
+    wb.defined_names["SalesData"] = DefinedName("SalesData", attr_text="Data!$B$2:$C$3")
+    wb.defined_names["TaxRate"] = DefinedName("TaxRate", attr_text="0.1")
+    wb.defined_names["Ids"] = DefinedName("Ids", attr_text="Data!$A:$A")
+    other.defined_names["Local"] = DefinedName("Local", attr_text="Other!$A$1")
+    wb.save(path)
+    return path
+
+
+def test_extract_named_ranges_resolves_bounds(tmp_path: Path) -> None:
+    named = extract_named_ranges(_book(tmp_path))
+
+    assert sorted((n.name, n.r1, n.c1, n.r2, n.c2, n.local) for n in named["Data"]) == [
+        ("Ids", 1, 0, 5, 0, False),
+        ("SalesData", 2, 1, 3, 2, False),
+    ]
+    assert [(n.name, n.local) for n in named["Other"]] == [("Local", True)]
+
+
+def test_resolve_range_targets_names_and_addresses() -> None:
+    sheets = {
+        "Data": SheetData(
+            named_ranges=[NamedRange(name="SalesData", r1=2, c1=1, r2=3, c2=2)]
+        ),
+        "Q1, Q2": SheetData(),
+    }
+
+    targets = resolve_range_targets(["name:salesdata", "'Q1, Q2'!A1:B2"], sheets)
+
+    assert targets == {
+        "Data": [RangeBounds(r1=1, c1=1, r2=2, c2=2)],
+        "Q1, Q2": [RangeBounds(r1=0, c1=0, r2=1, c2=1)],
+    }
+    with pytest.raises(ConfigError):
+        resolve_range_targets(["name:Missing"], sheets)
+    with pytest.raises(ConfigError):
+        resolve_range_targets(["A1:B2"], sheets)
+
+
+def test_clip_rows_handles_numeric_and_alpha_keys() -> None:
+    rows = [
+        CellRow(r=1, c={"0": 1, "1": 2}),
+        CellRow(r=2, c={"A": 3, "B": 4, "C": 5}, links={"C": "https://x"}),
+    ]
+
+    clipped = clip_rows(rows, [RangeBounds(r1=1, c1=1, r2=1, c2=2)])
+
+    assert clipped == [CellRow(r=2, c={"B": 4, "C": 5}, links={"C": "https://x"})]
+
+
+def test_range_target_clips_output(tmp_path: Path) -> None:
+    engine = ExStructEngine(
+        options=StructOptions(mode="light"),
+        output=OutputOptions(filters=FilterOptions(ranges=["name:SalesData"])),
+    )
+
+    payload = json.loads(engine.serialize(engine.extract(_book(tmp_path))))
+
+    assert list(payload["sheets"]) == ["Data"]
+    assert payload["sheets"]["Data"]["rows"] == [
+        {"r": 2, "c": {"1": 22, "2": 23}},
+        {"r": 3, "c": {"1": 32, "2": 33}},
+    ]
+    assert "named_ranges" not in payload["sheets"]["Data"]
@@ -0,0 +1,111 @@
+"""Tests for inferred print areas (``infer_print_areas``)."""
+
+from __future__ import annotations
+
+from pathlib import Path
+
+from openpyxl import Workbook
+from openpyxl.worksheet.pagebreak import Break
+from openpyxl.worksheet.properties import PageSetupProperties
+
+from exstruct.cli.main import main as cli_main
+from exstruct.core.page_layout import infer_print_areas
+from exstruct.engine import ExStructEngine, StructOptions
+from exstruct.models import PrintArea
+
+
+def _book(tmp_path: Path) -> Path:
+    wb = Workbook()
+    ws = wb.active
+    assert ws is not None
+    # A4 portrait with 0.5in margins: 10 default columns and 51 rows per page.
+    ws.title = "Plain"
+    ws.page_setup.paperSize = 9
+    for side in ("left", "right", "top", "bottom"):
+        setattr(ws.page_margins, side, 0.5)
+    ws["B2"] = "start"
+    ws["O60"] = "end"
+
+    fit = wb.create_sheet("Fit")
+    fit.sheet_properties.pageSetUpPr = PageSetupProperties(fitToPage=True)
+    fit.page_setup.fitToWidth = 1
+    fit.page_setup.fitToHeight = 0
+    fit.row_breaks.append(Break(id=5))
+    fit["A1"] = "left"
+    fit["Z20"] = "right"
+
+    defined = wb.create_sheet("Defined")
+    defined["A1"] = "x"
+    defined.print_area = "A1:C3"
+    wb.create_sheet("Empty")
+    path = tmp_path / "book.xlsx"
+    wb.save(path)
+    return path
+
+
+def test_infer_print_areas_paginates_used_range(tmp_path: Path) -> None:
+    areas = infer_print_areas(_book(tmp_path), skip={"Defined"})
+
+    assert areas["Plain"] == [
+        PrintArea(r1=2, c1=1, r2=52, c2=10),
+        PrintArea(r1=53, c1=1, r2=60, c2=10),
+        PrintArea(r1=2, c1=11, r2=52, c2=14),
+        PrintArea(r1=53, c1=11, r2=60, c2=14),
+    ]
+    assert areas["Fit"] == [
+        PrintArea(r1=1, c1=0, r2=5, c2=25),
+        PrintArea(r1=6, c1=0, r2=20, c2=25),
+    ]
+    assert set(areas) == {"Plain", "Fit"}
+
+
+def test_infer_print_areas_page_order_and_xls(tmp_path: Path) -> None:
+    wb = Workbook()
+    ws = wb.active
+    assert ws is not None
+    ws.page_setup.pageOrder = "overThenDown"
+    ws.page_setup.orientation = "landscape"
+    for side in ("left", "right", "top", "bottom"):
+        setattr(ws.page_margins, side, 0.5)
+    ws["A1"] = 1
+    ws["Z60"] = 2
+    path = tmp_path / "wide.xlsx"
+    wb.save(path)
+    xls = tmp_path / "old.xls"
+    xls.write_bytes(b"\xd0\xcf\x11\xe0")
+
+    pages = infer_print_areas(path)[ws.title]
+
+    assert [(page.r1, page.c1) for page in pages[:3]] == [(1, 0), (1, 16), (35, 0)]
+    assert infer_print_areas(xls) == {}
+
+
+def test_infer_print_areas_option_keeps_defined_areas(tmp_path: Path) -> None:
+    path = _book(tmp_path)
+
+    plain = ExStructEngine(options=StructOptions(mode="light")).extract(path)
+    inferred = ExStructEngine(
+        options=StructOptions(mode="light", infer_print_areas=True)
+    ).extract(path)
+    out_dir = tmp_path / "areas"
+    code = cli_main(
+        [
+            str(path),
+            "--mode",
+            "light",
+            "--infer-print-areas",
+            "--print-areas-dir",
+            str(out_dir),
+            "-o",
+            str(tmp_path / "out.json"),
+        ]
+    )
+
+    assert plain.sheets["Plain"].print_areas == []
+    assert len(inferred.sheets["Plain"].print_areas) == 4
+    assert inferred.sheets["Defined"].print_areas == [
+        PrintArea(r1=1, c1=0, r2=3, c2=2)
+    ]
+    assert inferred.sheets["Empty"].print_areas == []
+    assert code == 0
+    assert len(list(out_dir.iterdir())) == 7
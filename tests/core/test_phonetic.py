@@ -0,0 +1,112 @@
+"""Tests for phonetic (furigana) readings (``include_phonetic``)."""
+
+from __future__ import annotations
+
+import json
+from pathlib import Path
+from xml.etree import ElementTree as ET
+import zipfile
+
+from openpyxl import Workbook
+
+from exstruct.cli.main import main as cli_main
+from exstruct.core.phonetic import apply_phonetic, extract_phonetic, phonetic_reading
+from exstruct.engine import ExStructEngine, StructOptions
+from exstruct.models import CellRow, convert_row_keys_to_alpha
+
+_MAIN_NS = "http://schemas.openxmlformats.org/spreadsheetml/2006/main"
+
+
+def _element(xml: str) -> ET.Element:
+    return ET.fromstring(f'<si xmlns="{_MAIN_NS}">{xml}</si>')
+
+
+def _book(tmp_path: Path) -> Path:
+    wb = Workbook()
+    ws = wb.active
+    assert ws is not None
+    ws.title = "Names"
+    ws["A1"] = "東京都"
+    ws["B1"] = "Tokyo"
+    ws["A2"] = "読み方"
+    path = tmp_path / "book.xlsx"
+    wb.save(path)
+    with zipfile.ZipFile(path) as src:
+        parts = {name: src.read(name) for name in src.namelist()}
+    # openpyxl drops phonetic runs, so the shared strings are written by hand.
+    parts["xl/sharedStrings.xml"] = (
+        f'<sst xmlns="{_MAIN_NS}" count="3" uniqueCount="3">'
+        "<si><t>東京都</t>"
+        '<rPh sb="0" eb="2"><t>トウキョウ</t></rPh>'
+        '<rPh sb="2" eb="3"><t>ト</t></rPh>'
+        '<phoneticPr fontId="1"/></si>'
+        "<si><t>Tokyo</t></si>"
+        '<si><t>読み方</t><rPh sb="0" eb="1"><t>ヨ</t></rPh>'
+        '<rPh sb="2" eb="3"><t>カタ</t></rPh></si>'
+        "</sst>"
+    ).encode()
+    patched = tmp_path / "phonetic.xlsx"
+    with zipfile.ZipFile(patched, "w") as out:
+        for name, data in parts.items():
+            out.writestr(name, data)
+    return patched
+
+
+def test_phonetic_reading_replaces_covered_spans() -> None:
+    reading = phonetic_reading(
+        _element(
+            "<r><t>山田</t></r><r><t>太郎</t></r>"
+            '<rPh sb="2" eb="4"><t>タロウ</t></rPh>'
+            '<rPh sb="0" eb="2"><t>ヤマダ</t></rPh>'
+        )
+    )
+
+    assert reading == "ヤマダタロウ"
+
+
+def test_phonetic_reading_without_runs() -> None:
+    assert phonetic_reading(_element("<t>Tokyo</t>")) is None
+    out_of_range = '<t>東京</t><rPh sb="0" eb="9"><t>トウ</t></rPh>'
+    assert phonetic_reading(_element(out_of_range)) is None
+
+
+def test_extract_phonetic_from_shared_strings(tmp_path: Path) -> None:
+    assert extract_phonetic(_book(tmp_path)) == {
+        "Names": {1: {"0": "トウキョウト"}, 2: {"0": "ヨみカタ"}}
+    }
+
+
+def test_apply_phonetic_only_touches_extracted_cells() -> None:
+    row = CellRow(r=1, c={"0": "東京都", "1": "Tokyo"})
+    plain = CellRow(r=2, c={"0": "x"})
+
+    result = apply_phonetic(
+        {"S": [row, plain]}, {"S": {1: {"0": "トウキョウト", "5": "カ"}}}
+    )
+
+    assert result["S"][0].phonetic == {"0": "トウキョウト"}
+    assert result["S"][1] is plain
+    assert row.phonetic is None
+    alpha = convert_row_keys_to_alpha(result["S"][0])
+    assert alpha.phonetic == {"A": "トウキョウト"}
+
+
+def test_include_phonetic_option_and_cli(tmp_path: Path) -> None:
+    path = _book(tmp_path)
+
+    plain = ExStructEngine(options=StructOptions(mode="light")).extract(path)
+    with_phonetic = ExStructEngine(
+        options=StructOptions(mode="light", include_phonetic=True)
+    ).extract(path)
+    out = tmp_path / "out.json"
+    code = cli_main(
+        [str(path), "--mode", "light", "--include-phonetic", "-o", str(out)]
+    )
+
+    assert plain.sheets["Names"].rows[0].phonetic is None
+    rows = with_phonetic.sheets["Names"].rows
+    assert rows[0].c == {"0": "東京都", "1": "Tokyo"}
+    assert rows[0].phonetic == {"0": "トウキョウト"}
+    assert code == 0
+    data = json.loads(out.read_text(encoding="utf-8"))
+    assert data["sheets"]["Names"]["rows"][1]["phonetic"] == {"0": "ヨみカタ"}
@@ -1,9 +1,11 @@
 """Tests for extraction pipeline planning and step orchestration."""
 
+from dataclasses import replace
 import logging
 from pathlib import Path
 
 from _pytest.monkeypatch import MonkeyPatch
+from openpyxl import Workbook
 import pytest
 
 from exstruct.core.backends.com_backend import ComBackend
@@ -19,7 +21,9 @@ from exstruct.core.pipeline import (
     ExtractionArtifacts,
     ExtractionInputs,
     PipelinePlan,
+    _annotate_covered_ranges,
     _col_in_intervals,
+    _convert_point_positions,
     _filter_rows_excluding_merged_values,
     _merge_intervals,
     _resolve_sheet_colors_map,
@@ -31,6 +35,7 @@ from exstruct.core.pipeline import (
     run_com_pipeline,
     run_extraction_pipeline,
     step_extract_auto_page_breaks_com,
+    step_extract_cells,
     step_extract_charts_com,
     step_extract_colors_map_com,
     step_extract_colors_map_openpyxl,
@@ -39,7 +44,7 @@ from exstruct.core.pipeline import (
     step_extract_print_areas_com,
     step_extract_shapes_com,
 )
-from exstruct.models import CellRow, PrintArea, Shape
+from exstruct.models import Arrow, CellRow, Chart, PrintArea, Shape
 
 
 def test_build_pre_com_pipeline_respects_flags(
@@ -238,6 +243,88 @@ def test_resolve_extraction_inputs_defaults_for_libreoffice(tmp_path: Path) -> N
     assert inputs.include_merged_cells is True
 
 
+def _resolve_inputs(tmp_path: Path, mode: str, **overrides: object) -> ExtractionInputs:
+    return resolve_extraction_inputs(
+        tmp_path / "book.xlsx",
+        mode=mode,  # type: ignore[arg-type]
+        include_cell_links=None,
+        include_print_areas=None,
+        include_auto_page_breaks=False,
+        include_colors_map=None,
+        include_default_background=False,
+        ignore_colors=None,
+        include_formulas_map=None,
+        include_merged_cells=None,
+        include_merged_values_in_rows=True,
+        **overrides,  # type: ignore[arg-type]
+    )
+
+
+def test_resolve_extraction_inputs_covered_ranges_follow_dimensions(
+    tmp_path: Path,
+) -> None:
+    """Verify that covered ranges default to on only when dimensions are read."""
+
+    assert _resolve_inputs(tmp_path, "standard").include_covered_ranges is False
+    assert _resolve_inputs(tmp_path, "verbose").include_covered_ranges is True
+    assert (
+        _resolve_inputs(
+            tmp_path, "standard", include_dimensions=True
+        ).include_covered_ranges
+        is True
+    )
+    assert (
+        _resolve_inputs(
+            tmp_path, "standard", include_covered_ranges=True
+        ).include_covered_ranges
+        is True
+    )
+
+
+def test_annotate_covered_ranges_skips_dimension_load_when_disabled(
+    monkeypatch: MonkeyPatch, tmp_path: Path
+) -> None:
+    """Verify that shapes are left alone without loading the workbook again."""
+
+    def fail(self: OpenpyxlBackend) -> dict[str, object]:
+        raise AssertionError("dimensions must not be loaded")
+
+    monkeypatch.setattr(OpenpyxlBackend, "extract_dimensions", fail)
+    shape_data = {"Sheet1": [Shape(id=1, text="box", l=0, t=0, w=10, h=10)]}
+
+    result = _annotate_covered_ranges(
+        _resolve_inputs(tmp_path, "standard"),
+        ExtractionArtifacts(),
+        shape_data,
+        unit="points",
+    )
+
+    assert result is shape_data
+    assert result["Sheet1"][0].covered_range is None
+
+
+def test_annotate_covered_ranges_loads_dimensions_when_requested(
+    monkeypatch: MonkeyPatch, tmp_path: Path
+) -> None:
+    """Verify that an explicit request loads dimensions once and annotates."""
+
+    calls: list[Path] = []
+
+    def fake_dimensions(self: OpenpyxlBackend) -> dict[str, object]:
+        calls.append(self.file_path)
+        return {}
+
+    monkeypatch.setattr(OpenpyxlBackend, "extract_dimensions", fake_dimensions)
+    artifacts = ExtractionArtifacts()
+    inputs = _resolve_inputs(tmp_path, "standard", include_covered_ranges=True)
+    shape_data = {"Sheet1": [Shape(id=1, text="box", l=0, t=0, w=10, h=10)]}
+
+    result = _annotate_covered_ranges(inputs, artifacts, shape_data, unit="points")
+
+    assert calls == [tmp_path / "book.xlsx"]
+    assert result["Sheet1"][0].covered_range == "A1"
+
+
 def test_resolve_extraction_inputs_forces_merged_cells_when_excluding_values(
     tmp_path: Path,
 ) -> None:
@@ -752,7 +839,9 @@ def test_step_extract_shapes_com_sets_data(
 
     shapes_data = {"Sheet1": [object()]}
 
-    def _fake(_: object, *, mode: str) -> dict[str, list[object]]:
+    def _fake(
+        _: object, *, mode: str, stable_ids: bool = False
+    ) -> dict[str, list[object]]:
         """Return the shape payload captured in the enclosing test."""
         _ = mode
         return shapes_data
@@ -1104,3 +1193,170 @@ def test_run_extraction_pipeline_com_success(
     assert result.state.com_attempted is True
     assert result.state.com_succeeded is True
     assert "Sheet1" in result.workbook.sheets
+
+
+def _component_inputs(tmp_path: Path, **components: bool) -> ExtractionInputs:
+    """Build standard-mode inputs with only the given component flags changed."""
+
+    return ExtractionInputs(
+        file_path=tmp_path / "book.xlsx",
+        mode="standard",
+        include_cell_links=False,
+        include_print_areas=False,
+        include_auto_page_breaks=False,
+        include_colors_map=False,
+        include_default_background=False,
+        ignore_colors=None,
+        include_formulas_map=False,
+        use_com_for_formulas=False,
+        include_merged_cells=False,
+        include_merged_values_in_rows=True,
+        **components,
+    )
+
+
+def test_build_com_pipeline_skips_disabled_shapes_and_charts(tmp_path: Path) -> None:
+    """Verify that component flags drop the COM shape and chart steps."""
+
+    steps = build_com_pipeline(
+        _component_inputs(tmp_path, include_shapes=False, include_charts=False)
+    )
+    assert steps == []
+
+    steps = build_com_pipeline(_component_inputs(tmp_path, include_charts=False))
+    assert [step.__name__ for step in steps] == ["step_extract_shapes_com"]
+
+
+def test_step_extract_cells_lists_sheets_when_cells_disabled(
+    monkeypatch: MonkeyPatch, tmp_path: Path
+) -> None:
+    """Verify that disabling cells keeps sheet names without reading values."""
+
+    def _fail_extract_cells(_: Path) -> dict[str, list[CellRow]]:
+        raise AssertionError("cells should not be read")
+
+    monkeypatch.setattr(
+        "exstruct.core.backends.openpyxl_backend.extract_sheet_cells",
+        _fail_extract_cells,
+    )
+    monkeypatch.setattr(
+        "exstruct.core.backends.openpyxl_backend.extract_sheet_names",
+        lambda _: ["One", "Two"],
+    )
+    artifacts = ExtractionArtifacts()
+
+    step_extract_cells(_component_inputs(tmp_path, include_cells=False), artifacts)
+
+    assert artifacts.cell_data == {"One": [], "Two": []}
+
+
+def test_step_extract_cells_reads_only_selected_sheets(tmp_path: Path) -> None:
+    """Verify that inputs.sheets limits which sheets are read and assembled."""
+
+    path = tmp_path / "book.xlsx"
+    wb = Workbook()
+    wb.active.title = "One"
+    wb.active["A1"] = "first"
+    wb.create_sheet("Two")["A1"] = "second"
+    wb.save(path)
+    inputs = replace(
+        _component_inputs(tmp_path), file_path=path, sheets=frozenset({"Two"})
+    )
+    artifacts = ExtractionArtifacts()
+    names_only = ExtractionArtifacts()
+
+    step_extract_cells(inputs, artifacts)
+    step_extract_cells(replace(inputs, include_cells=False), names_only)
+
+    assert list(artifacts.cell_data) == ["Two"]
+    assert artifacts.cell_data["Two"][0].c == {"0": "second"}
+    assert names_only.cell_data == {"Two": []}
+
+
+def test_build_cells_tables_workbook_skips_disabled_components(
+    monkeypatch: MonkeyPatch, tmp_path: Path
+) -> None:
+    """Verify that the fallback skips table detection and OOXML shapes/charts."""
+
+    calls: list[str] = []
+
+    def _record(name: str, result: object) -> object:
+        def _fake(*_args: object, **_kwargs: object) -> object:
+            calls.append(name)
+            return result
+
+        return _fake
+
+    monkeypatch.setattr(
+        "exstruct.core.backends.openpyxl_backend.detect_tables_openpyxl",
+        _record("tables", ["A1:B2"]),
+    )
+    monkeypatch.setattr(
+        "exstruct.core.pipeline.get_shapes_ooxml", _record("shapes", {})
+    )
+    monkeypatch.setattr(
+        "exstruct.core.pipeline.get_charts_ooxml", _record("charts", {})
+    )
+    artifacts = ExtractionArtifacts(cell_data={"Sheet1": [CellRow(r=1, c={"0": "v"})]})
+
+    wb = build_cells_tables_workbook(
+        inputs=_component_inputs(
+            tmp_path, include_shapes=False, include_charts=False, include_tables=False
+        ),
+        artifacts=artifacts,
+        reason="test",
+    )
+
+    assert calls == []
+    assert wb.sheets["Sheet1"].table_candidates == []
+
+
+def test_convert_point_positions_to_requested_unit(tmp_path: Path) -> None:
+    """Verify that COM/LibreOffice point positions follow position_unit."""
+
+    shapes = {
+        "Sheet1": [
+            Shape(text="box", l=72, t=36, w=9, h=None),
+            Arrow(text="", l=0, t=0, begin_x=0, begin_y=3, end_x=72, end_y=36),
+        ]
+    }
+    charts = {
+        "Sheet1": [
+            Chart(
+                name="c", chart_type="Line", y_axis_title="", series=[], l=36, t=72
+            )
+        ]
+    }
+    inputs = _component_inputs(tmp_path)
+
+    assert _convert_point_positions(inputs, shapes, charts) == (shapes, charts)
+
+    new_shapes, new_charts = _convert_point_positions(
+        replace(inputs, position_unit="pixels"), shapes, charts
+    )
+    box, arrow = new_shapes["Sheet1"]
+    assert (box.l, box.t, box.w, box.h) == (96, 48, 12, None)
+    assert isinstance(arrow, Arrow)
+    assert (arrow.begin_x, arrow.begin_y, arrow.end_x, arrow.end_y) == (0, 4, 96, 48)
+    assert (new_charts["Sheet1"][0].l, new_charts["Sheet1"][0].t) == (48, 96)
+    assert shapes["Sheet1"][0].l == 72
+
+
+def test_resolve_extraction_inputs_rejects_non_positive_dpi(tmp_path: Path) -> None:
+    """Verify that position_dpi must be positive."""
+
+    with pytest.raises(ValueError, match="position_dpi"):
+        resolve_extraction_inputs(
+            tmp_path / "book.xlsx",
+            mode="standard",
+            include_cell_links=None,
+            include_print_areas=None,
+            include_auto_page_breaks=False,
+            include_colors_map=None,
+            include_default_background=False,
+            ignore_colors=None,
+            include_formulas_map=None,
+            include_merged_cells=None,
+            include_merged_values_in_rows=True,
+            position_dpi=0,
+        )
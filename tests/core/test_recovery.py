@@ -0,0 +1,123 @@
+"""Tests for best-effort recovery of corrupted workbook packages."""
+
+from __future__ import annotations
+
+from pathlib import Path
+import zipfile
+
+from openpyxl import Workbook
+import pytest
+
+from exstruct.cli.main import EXIT_PARTIAL, main as cli_main
+from exstruct.core.recovery import repair_package
+from exstruct.engine import ExStructEngine, StructOptions
+from exstruct.errors import ExtractionError
+
+_TRUNCATED = b"<worksheet><sheetData><row>"
+
+
+def _book(tmp_path: Path) -> Path:
+    wb = Workbook()
+    ws = wb.active
+    assert ws is not None
+    ws.title = "Good"
+    ws["A1"] = "kept"
+    wb.create_sheet("Bad")["A1"] = "lost"
+    path = tmp_path / "book.xlsx"
+    wb.save(path)
+    return path
+
+
+def _rewrite(path: Path, replacements: dict[str, bytes]) -> Path:
+    """Copy the package with some parts replaced (or added)."""
+    with zipfile.ZipFile(path) as src:
+        parts = {name: src.read(name) for name in src.namelist()}
+    parts.update(replacements)
+    broken = path.with_name(f"broken_{path.name}")
+    with zipfile.ZipFile(broken, "w", compression=zipfile.ZIP_DEFLATED) as out:
+        for name, data in parts.items():
+            out.writestr(name, data)
+    return broken
+
+
+def _with_broken_extra_part(path: Path) -> Path:
+    with zipfile.ZipFile(path) as src:
+        rels = src.read("xl/_rels/workbook.xml.rels")
+        content_types = src.read("[Content_Types].xml")
+    link = (
+        b'<Relationship Id="rIdExtra" Type="urn:test" Target="extra.xml"/>'
+        b"</Relationships>"
+    )
+    override = (
+        b'<Override PartName="/xl/extra.xml" ContentType="application/xml"/>'
+        b"</Types>"
+    )
+    return _rewrite(
+        path,
+        {
+            "xl/extra.xml": b"<extra>",
+            "xl/_rels/workbook.xml.rels": rels.replace(b"</Relationships>", link),
+            "[Content_Types].xml": content_types.replace(b"</Types>", override),
+        },
+    )
+
+
+def test_broken_sheet_is_skipped_and_reported(tmp_path: Path) -> None:
+    path = _rewrite(_book(tmp_path), {"xl/worksheets/sheet2.xml": _TRUNCATED})
+    engine = ExStructEngine(options=StructOptions(mode="light", best_effort=True))
+
+    workbook = engine.extract(path)
+
+    assert list(workbook.sheets) == ["Good"]
+    assert workbook.sheets["Good"].rows[0].c == {"0": "kept"}
+    assert len(workbook.warnings) == 1
+    assert "Skipped sheet 'Bad'" in workbook.warnings[0]
+    assert "malformed XML" in workbook.warnings[0]
+
+
+def test_broken_part_is_unlinked(tmp_path: Path) -> None:
+    path = _with_broken_extra_part(_book(tmp_path))
+    out_dir = tmp_path / "repaired"
+    out_dir.mkdir()
+
+    result = repair_package(path, out_dir)
+
+    assert result is not None
+    repaired, report = result
+    assert report.broken_sheets == []
+    assert report.warnings[0].startswith("Skipped part xl/extra.xml")
+    with zipfile.ZipFile(repaired) as zf:
+        assert "xl/extra.xml" not in zf.namelist()
+        assert b"extra.xml" not in zf.read("xl/_rels/workbook.xml.rels")
+        assert b"extra.xml" not in zf.read("[Content_Types].xml")
+        assert b"sheet2.xml" in zf.read("xl/_rels/workbook.xml.rels")
+
+
+def test_intact_package_is_not_rewritten(tmp_path: Path) -> None:
+    path = _book(tmp_path)
+
+    assert repair_package(path, tmp_path) is None
+    workbook = ExStructEngine(
+        options=StructOptions(mode="light", best_effort=True)
+    ).extract(path)
+    assert list(workbook.sheets) == ["Good", "Bad"]
+    assert workbook.warnings == []
+
+
+def test_broken_workbook_part_is_not_recoverable(tmp_path: Path) -> None:
+    path = _rewrite(_book(tmp_path), {"xl/workbook.xml": b"<workbook>"})
+
+    with pytest.raises(ExtractionError, match="xl/workbook.xml is corrupted"):
+        repair_package(path, tmp_path)
+
+
+def test_cli_best_effort_flag(tmp_path: Path) -> None:
+    path = _rewrite(_book(tmp_path), {"xl/worksheets/sheet2.xml": _TRUNCATED})
+    out = tmp_path / "out.json"
+
+    code = cli_main([str(path), "--mode", "light", "--best-effort", "-o", str(out)])
+
+    assert code == EXIT_PARTIAL
+    text = out.read_text(encoding="utf-8")
+    assert '"Bad"' not in text
+    assert "Skipped sheet 'Bad'" in text
@@ -0,0 +1,93 @@
+"""Tests for shape-to-cell range association."""
+
+import pytest
+
+from exstruct.core.cells import SheetDimensions
+from exstruct.core.shape_ranges import (
+    assign_covered_ranges,
+    column_width_to_points,
+    compute_covered_range,
+)
+from exstruct.models import Arrow, Shape
+
+
+def test_column_width_to_points_default_width() -> None:
+    assert column_width_to_points(9.140625) == pytest.approx(48.0)
+    assert column_width_to_points(8.43) == pytest.approx(44.25)
+    assert column_width_to_points(0) == 0.0
+
+
+def test_compute_covered_range_with_default_grid() -> None:
+    # Default column = 48pt, default row = 15pt.
+    shape = Shape(text="note", l=50, t=16, w=90, h=40)
+    assert compute_covered_range(shape, None) == "B2:C4"
+
+
+def test_compute_covered_range_edge_aligned_end_stays_in_prior_cell() -> None:
+    shape = Shape(text="cell", l=0, t=0, w=48, h=15)
+    assert compute_covered_range(shape, None) == "A1"
+
+
+def test_compute_covered_range_respects_explicit_sizes() -> None:
+    dims = SheetDimensions(
+        column_widths={"0": 20.0, "1": 0.0},
+        row_heights={"1": 40.0},
+        default_column_width=8.43,
+        default_row_height=15.0,
+    )
+    # Column A is 105pt wide, column B is hidden, row 1 is 40pt tall.
+    shape = Shape(text="wide", l=110, t=41, w=10, h=10)
+    assert compute_covered_range(shape, dims) == "C2"
+
+
+def test_compute_covered_range_converts_pixels() -> None:
+    shape = Shape(text="px", l=64, t=20, w=10, h=10)
+    assert compute_covered_range(shape, None, unit="pixels") == "B2"
+
+
+def test_compute_covered_range_other_units() -> None:
+    assert (
+        compute_covered_range(
+            Shape(text="hi-dpi", l=128, t=40, w=20, h=20), None, unit="pixels", dpi=192
+        )
+        == "B2"
+    )
+    assert (
+        compute_covered_range(
+            Shape(text="mm", l=17, t=6, w=3, h=3), None, unit="millimeters"
+        )
+        == "B2"
+    )
+
+
+def test_compute_covered_range_requires_size() -> None:
+    shape = Shape(text="unsized", l=0, t=0)
+    assert compute_covered_range(shape, None) is None
+
+
+def test_assign_covered_ranges_returns_copies() -> None:
+    shapes = [
+        Shape(text="a", l=0, t=0, w=10, h=10),
+        Arrow(text="", l=0, t=0, w=200, h=0),
+    ]
+    result = assign_covered_ranges(shapes, None)
+    assert [s.covered_range for s in result] == ["A1", "A1:E1"]
+    assert shapes[0].covered_range is None
+
+
+def test_assign_covered_ranges_resolves_connector_endpoint_cells() -> None:
+    arrow = Arrow(
+        text="",
+        l=10,
+        t=5,
+        w=100,
+        h=20,
+        begin_x=110,
+        begin_y=5,
+        end_x=10,
+        end_y=25,
+    )
+    (result,) = assign_covered_ranges([arrow], None)
+    assert isinstance(result, Arrow)
+    assert result.begin_cell == "C1"
+    assert result.end_cell == "A2"
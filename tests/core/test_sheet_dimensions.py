@@ -0,0 +1,150 @@
+"""Tests for row height and column width extraction."""
+
+import logging
+from pathlib import Path
+
+from _pytest.monkeypatch import MonkeyPatch
+from openpyxl import Workbook
+import pytest
+
+from exstruct.core.backends.openpyxl_backend import OpenpyxlBackend
+from exstruct.core.cells import SheetDimensions, extract_sheet_dimensions
+from exstruct.core.modeling import SheetRawData, build_sheet_data
+from exstruct.core.pipeline import (
+    ExtractionArtifacts,
+    ExtractionInputs,
+    ExtractionMode,
+    build_pre_com_pipeline,
+    resolve_extraction_inputs,
+    step_extract_dimensions_openpyxl,
+)
+from exstruct.models import SheetData, convert_sheet_keys_to_alpha
+
+
+def _resolve(
+    path: Path, mode: ExtractionMode, include_dimensions: bool | None = None
+) -> ExtractionInputs:
+    return resolve_extraction_inputs(
+        path,
+        mode=mode,
+        include_cell_links=None,
+        include_print_areas=None,
+        include_auto_page_breaks=False,
+        include_colors_map=None,
+        include_default_background=False,
+        ignore_colors=None,
+        include_formulas_map=None,
+        include_merged_cells=None,
+        include_merged_values_in_rows=True,
+        include_dimensions=include_dimensions,
+    )
+
+
+def _make_sized_book(path: Path) -> None:
+    wb = Workbook()
+    ws = wb.active
+    ws.title = "Sheet1"
+    ws["A1"] = "x"
+    ws["D5"] = "y"
+    ws.column_dimensions["B"].width = 20
+    ws.column_dimensions["C"].hidden = True
+    ws.row_dimensions[3].height = 30
+    ws.row_dimensions[4].hidden = True
+    wb.save(path)
+
+
+def test_extract_sheet_dimensions_basic(tmp_path: Path) -> None:
+    path = tmp_path / "sized.xlsx"
+    _make_sized_book(path)
+
+    dims = extract_sheet_dimensions(path)["Sheet1"]
+    assert dims.column_widths["1"] == pytest.approx(20)
+    assert dims.column_widths["2"] == 0.0
+    assert dims.row_heights["3"] == pytest.approx(30)
+    assert dims.row_heights["4"] == 0.0
+    assert dims.default_column_width is not None
+    assert dims.default_row_height is not None
+
+
+def test_extract_sheet_dimensions_uses_default_font(tmp_path: Path) -> None:
+    path = tmp_path / "big_font.xlsx"
+    wb = Workbook()
+    wb._fonts[0].sz = 20  # Normal style font, as Excel stores it.
+    wb.active.title = "Sheet1"
+    wb.active["A1"] = "x"
+    wb.save(path)
+
+    dims = extract_sheet_dimensions(path)["Sheet1"]
+    assert dims.max_digit_width == 14
+    assert dims.default_column_width == pytest.approx(8.5703125)
+
+
+def test_extract_dimensions_returns_empty_on_failure(
+    tmp_path: Path, monkeypatch: MonkeyPatch, caplog: "pytest.LogCaptureFixture"
+) -> None:
+    def _raise(_: Path) -> object:
+        raise RuntimeError("boom")
+
+    monkeypatch.setattr(
+        "exstruct.core.backends.openpyxl_backend.extract_sheet_dimensions", _raise
+    )
+    backend = OpenpyxlBackend(tmp_path / "book.xlsx")
+    with caplog.at_level(logging.WARNING):
+        assert backend.extract_dimensions() == {}
+    assert "Dimension extraction failed" in caplog.text
+
+
+def test_resolve_extraction_inputs_dimensions_defaults(tmp_path: Path) -> None:
+    standard = _resolve(tmp_path / "book.xlsx", "standard")
+    verbose = _resolve(tmp_path / "book.xlsx", "verbose")
+    forced = _resolve(tmp_path / "book.xlsx", "light", include_dimensions=True)
+    assert standard.include_dimensions is False
+    assert verbose.include_dimensions is True
+    assert forced.include_dimensions is True
+    assert step_extract_dimensions_openpyxl in build_pre_com_pipeline(forced)
+    assert step_extract_dimensions_openpyxl not in build_pre_com_pipeline(standard)
+
+
+def test_step_extract_dimensions_openpyxl_sets_data(tmp_path: Path) -> None:
+    path = tmp_path / "sized.xlsx"
+    _make_sized_book(path)
+    inputs = _resolve(path, "light", include_dimensions=True)
+    artifacts = ExtractionArtifacts()
+
+    step_extract_dimensions_openpyxl(inputs, artifacts)
+
+    assert artifacts.dimension_data["Sheet1"].row_heights["3"] == pytest.approx(30)
+
+
+def test_build_sheet_data_maps_dimensions() -> None:
+    raw = SheetRawData(
+        rows=[],
+        shapes=[],
+        charts=[],
+        table_candidates=[],
+        print_areas=[],
+        auto_print_areas=[],
+        formulas_map={},
+        colors_map={},
+        merged_cells=[],
+        dimensions=SheetDimensions(
+            column_widths={"27": 12.5},
+            row_heights={"2": 18.0},
+            default_column_width=8.43,
+            default_row_height=15.0,
+        ),
+    )
+    sheet = build_sheet_data(raw)
+    assert sheet.column_widths == {"27": 12.5}
+    assert sheet.row_heights == {"2": 18.0}
+    assert sheet.default_row_height == 15.0
+    assert sheet.max_digit_width == 7
+
+    alpha = convert_sheet_keys_to_alpha(sheet)
+    assert alpha.column_widths == {"AB": 12.5}
+
+
+def test_sheet_data_omits_empty_dimensions() -> None:
+    payload = SheetData().to_json()
+    assert "column_widths" not in payload
+    assert "row_heights" not in payload
@@ -0,0 +1,158 @@
+"""Tests for row/column outline (grouping) extraction."""
+
+import json
+import logging
+from pathlib import Path
+
+from _pytest.monkeypatch import MonkeyPatch
+from openpyxl import Workbook
+import pytest
+
+from exstruct import process_excel
+from exstruct.core.backends.openpyxl_backend import OpenpyxlBackend
+from exstruct.core.cells import extract_sheet_outlines
+from exstruct.core.modeling import SheetRawData, build_sheet_data
+from exstruct.core.pipeline import (
+    ExtractionArtifacts,
+    ExtractionInputs,
+    ExtractionMode,
+    build_pre_com_pipeline,
+    resolve_extraction_inputs,
+    step_extract_outline_openpyxl,
+)
+from exstruct.models import OutlineGroup, SheetData, SheetOutline
+
+
+def _resolve(
+    path: Path, mode: ExtractionMode, include_outline: bool | None = None
+) -> ExtractionInputs:
+    return resolve_extraction_inputs(
+        path,
+        mode=mode,
+        include_cell_links=None,
+        include_print_areas=None,
+        include_auto_page_breaks=False,
+        include_colors_map=None,
+        include_default_background=False,
+        ignore_colors=None,
+        include_formulas_map=None,
+        include_merged_cells=None,
+        include_merged_values_in_rows=True,
+        include_outline=include_outline,
+    )
+
+
+def _make_grouped_book(path: Path) -> None:
+    wb = Workbook()
+    ws = wb.active
+    assert ws is not None
+    ws.title = "Report"
+    for row in range(1, 9):
+        ws.cell(row=row, column=1, value=f"r{row}")
+    ws.row_dimensions.group(2, 7, outline_level=1)
+    ws.row_dimensions.group(3, 4, outline_level=2, hidden=True)
+    ws.row_dimensions[5].collapsed = True
+    ws.column_dimensions.group("B", "D", outline_level=1, hidden=True)
+    wb.create_sheet("Flat")["A1"] = "x"
+    wb.save(path)
+
+
+def test_extract_sheet_outlines_groups_and_collapsed(tmp_path: Path) -> None:
+    path = tmp_path / "grouped.xlsx"
+    _make_grouped_book(path)
+
+    outlines = extract_sheet_outlines(path)
+
+    assert list(outlines) == ["Report"]
+    outline = outlines["Report"]
+    assert outline.rows == [
+        OutlineGroup(start=2, end=7, level=1, collapsed=False, summary=8),
+        OutlineGroup(start=3, end=4, level=2, collapsed=True, summary=5),
+    ]
+    assert outline.columns == [
+        OutlineGroup(start=1, end=3, level=1, collapsed=True, summary=4)
+    ]
+    assert outline.summary_below is True
+
+
+def test_extract_sheet_outlines_summary_above(tmp_path: Path) -> None:
+    wb = Workbook()
+    ws = wb.active
+    assert ws is not None
+    ws["A1"] = "total"
+    ws.row_dimensions.group(2, 3, outline_level=1)
+    ws.sheet_properties.outlinePr.summaryBelow = False
+    path = tmp_path / "above.xlsx"
+    wb.save(path)
+
+    outline = extract_sheet_outlines(path)[ws.title]
+
+    assert outline.summary_below is False
+    assert outline.rows == [OutlineGroup(start=2, end=3, level=1, summary=1)]
+
+
+def test_extract_outlines_returns_empty_on_failure(
+    tmp_path: Path, monkeypatch: MonkeyPatch, caplog: "pytest.LogCaptureFixture"
+) -> None:
+    def _raise(_: Path) -> object:
+        raise RuntimeError("boom")
+
+    monkeypatch.setattr(
+        "exstruct.core.backends.openpyxl_backend.extract_sheet_outlines", _raise
+    )
+    backend = OpenpyxlBackend(tmp_path / "book.xlsx")
+    with caplog.at_level(logging.WARNING):
+        assert backend.extract_outlines() == {}
+    assert "Outline extraction failed" in caplog.text
+
+
+def test_resolve_extraction_inputs_outline_defaults(tmp_path: Path) -> None:
+    standard = _resolve(tmp_path / "book.xlsx", "standard")
+    verbose = _resolve(tmp_path / "book.xlsx", "verbose")
+    forced = _resolve(tmp_path / "book.xlsx", "light", include_outline=True)
+    assert standard.include_outline is False
+    assert verbose.include_outline is True
+    assert step_extract_outline_openpyxl in build_pre_com_pipeline(forced)
+    assert step_extract_outline_openpyxl not in build_pre_com_pipeline(standard)
+
+
+def test_step_extract_outline_openpyxl_sets_data(tmp_path: Path) -> None:
+    path = tmp_path / "grouped.xlsx"
+    _make_grouped_book(path)
+    inputs = _resolve(path, "light", include_outline=True)
+    artifacts = ExtractionArtifacts()
+
+    step_extract_outline_openpyxl(inputs, artifacts)
+
+    assert artifacts.outline_data["Report"].rows[0].level == 1
+
+
+def test_build_sheet_data_maps_outline() -> None:
+    outline = SheetOutline(rows=[OutlineGroup(start=2, end=3, level=1)])
+    raw = SheetRawData(
+        rows=[],
+        shapes=[],
+        charts=[],
+        table_candidates=[],
+        print_areas=[],
+        auto_print_areas=[],
+        formulas_map={},
+        colors_map={},
+        merged_cells=[],
+        outline=outline,
+    )
+    assert build_sheet_data(raw).outline == outline
+    assert "outline" not in SheetData().to_json()
+
+
+def test_process_excel_writes_outline(tmp_path: Path) -> None:
+    path = tmp_path / "grouped.xlsx"
+    _make_grouped_book(path)
+    out = tmp_path / "out.json"
+
+    process_excel(path, output_path=out, mode="light", include_outline=True)
+
+    payload = json.loads(out.read_text(encoding="utf-8"))
+    outline = payload["sheets"]["Report"]["outline"]
+    assert [group["level"] for group in outline["rows"]] == [1, 2]
+    assert outline["columns"][0]["collapsed"] is True
@@ -0,0 +1,93 @@
+"""Tests for locale-aware parsing of cell text (``locale``)."""
+
+from __future__ import annotations
+
+from pathlib import Path
+
+from openpyxl import Workbook
+import pytest
+
+from exstruct import extract
+from exstruct.core.value_locale import (
+    apply_value_locale,
+    parse_localized_value,
+    resolve_value_locale,
+)
+from exstruct.engine import ExStructEngine, StructOptions
+from exstruct.models import CellRow
+
+
+@pytest.mark.parametrize(
+    ("tag", "text", "expected"),
+    [
+        ("en-US", "1,234.56", 1234.56),
+        ("en", "-1,234,567", -1234567),
+        ("de-DE", "1.234,56", 1234.56),
+        ("de", "1234,5", 1234.5),
+        ("fr-FR", "1 234,5", 1234.5),
+        ("de-CH", "1'234.50", 1234.5),
+        ("ja", "１，２３４．５", 1234.5),
+        ("ja", "▲1,000", -1000),
+        ("en", "−5", -5),
+        ("ja", "2024年1月15日", "2024-01-15 00:00:00"),
+        ("ja", "２０２４／１／５", "2024-01-05 00:00:00"),
+        ("en-US", "01/02/2024", "2024-01-02 00:00:00"),
+        ("en-GB", "01/02/2024", "2024-02-01 00:00:00"),
+        ("de", "15.01.2024", "2024-01-15 00:00:00"),
+    ],
+)
+def test_parse_localized_value(
+    tag: str, text: str, expected: int | float | str
+) -> None:
+    assert parse_localized_value(text, resolve_value_locale(tag)) == expected
+
+
+@pytest.mark.parametrize(
+    ("tag", "text"),
+    [
+        ("en", "12,34"),
+        ("en", "1,234,"),
+        ("en", "2024-02-30"),
+        ("ja", "15/01/2024"),
+        ("de", "Artikel 7"),
+        ("en", "  "),
+    ],
+)
+def test_parse_localized_value_keeps_other_text(tag: str, text: str) -> None:
+    assert parse_localized_value(text, resolve_value_locale(tag)) == text
+
+
+def test_resolve_value_locale_rejects_unknown() -> None:
+    assert resolve_value_locale("pt_BR") == resolve_value_locale("pt")
+    with pytest.raises(ValueError, match="Unsupported locale"):
+        resolve_value_locale("xx-YY")
+
+
+def test_apply_value_locale_only_touches_text() -> None:
+    row = CellRow(r=1, c={"0": "1.234,5", "1": 1.5, "2": "Summe"})
+    untouched = CellRow(r=2, c={"0": "Summe"})
+
+    result = apply_value_locale({"S": [row, untouched]}, "de")
+
+    assert result["S"][0].c == {"0": 1234.5, "1": 1.5, "2": "Summe"}
+    assert result["S"][1] is untouched
+    assert row.c["0"] == "1.234,5"
+
+
+def test_locale_option_end_to_end(tmp_path: Path) -> None:
+    wb = Workbook()
+    ws = wb.active
+    assert ws is not None
+    ws["A1"] = "1.234,56"
+    ws["B1"] = "１２３"
+    ws["C1"] = 2.5
+    path = tmp_path / "book.xlsx"
+    wb.save(path)
+
+    plain = extract(path, mode="light")
+    localized = ExStructEngine(
+        options=StructOptions(mode="light", locale="de-DE")
+    ).extract(path)
+
+    assert plain.sheets["Sheet"].rows[0].c["0"] == "1.234,56"
+    assert localized.sheets["Sheet"].rows[0].c == {"0": 1234.56, "1": 123, "2": 2.5}
@@ -2,7 +2,7 @@ from pathlib import Path
 
 from _pytest.monkeypatch import MonkeyPatch
 
-from exstruct.core.workbook import openpyxl_workbook
+from exstruct.core.workbook import openpyxl_workbook, shared_openpyxl_workbooks
 
 
 def test_openpyxl_workbook_closes(monkeypatch: MonkeyPatch, tmp_path: Path) -> None:
@@ -63,3 +63,39 @@ def test_openpyxl_workbook_sets_warning_filters(
     }
     recorded = {message for _action, message, _category, _module in calls}
     assert expected_messages.issubset(recorded)
+
+
+def test_shared_openpyxl_workbooks_loads_once(
+    monkeypatch: MonkeyPatch, tmp_path: Path
+) -> None:
+    loads: list[tuple[bool, bool]] = []
+    closed: list[object] = []
+
+    class DummyWorkbook:
+        def close(self) -> None:
+            closed.append(self)
+
+    def fake_load_workbook(
+        path: Path, *, data_only: bool, read_only: bool
+    ) -> DummyWorkbook:
+        loads.append((data_only, read_only))
+        return DummyWorkbook()
+
+    monkeypatch.setattr("exstruct.core.workbook.load_workbook", fake_load_workbook)
+    path = tmp_path / "book.xlsx"
+
+    with shared_openpyxl_workbooks():
+        with openpyxl_workbook(path, data_only=True, read_only=False) as first:
+            pass
+        with shared_openpyxl_workbooks():
+            with openpyxl_workbook(path, data_only=True, read_only=False) as second:
+                pass
+        with openpyxl_workbook(path, data_only=False, read_only=False):
+            pass
+        with openpyxl_workbook(path, data_only=True, read_only=True):
+            pass
+        assert second is first
+        assert len(closed) == 1
+
+    assert loads == [(True, False), (False, False), (True, True)]
+    assert len(closed) == 3
@@ -0,0 +1,251 @@
+"""Tests for config-file extraction profiles."""
+
+from __future__ import annotations
+
+import json
+from pathlib import Path
+
+import pytest
+
+from exstruct import process_excel
+from exstruct.cli.main import main as cli_main
+from exstruct.config import ExtractionProfile, load_config, load_profile
+from exstruct.engine import ExStructEngine, FilterOptions, OutputOptions
+from exstruct.errors import ConfigError
+from exstruct.models import CellRow, SheetData, WorkbookData
+
+_CONFIG = {
+    "default_profile": "fast",
+    "profiles": {
+        "fast": {
+            "mode": "light",
+            "format": "yaml",
+            "sheets": ["Data*"],
+            "exclude_sheets": ["*_old"],
+            "include_shapes": False,
+            "table_detection": {"density_min": 0.3, "min_nonempty_cells": 4},
+        },
+        "full": {"mode": "verbose", "include_colors_map": True},
+    },
+}
+
+
+def _write_json_config(tmp_path: Path) -> Path:
+    path = tmp_path / "exstruct.json"
+    path.write_text(json.dumps(_CONFIG), encoding="utf-8")
+    return path
+
+
+def test_load_config_selects_default_and_named_profiles(tmp_path: Path) -> None:
+    config = load_config(_write_json_config(tmp_path))
+
+    assert config.get_profile().mode == "light"
+    assert config.get_profile("full").include_colors_map is True
+    with pytest.raises(ConfigError, match="Available: fast, full"):
+        config.get_profile("missing")
+
+
+def test_load_config_reads_toml(tmp_path: Path) -> None:
+    path = tmp_path / "exstruct.toml"
+    path.write_text(
+        '[profiles.only]\nmode = "standard"\nexclude_sheets = ["Tmp"]\n',
+        encoding="utf-8",
+    )
+
+    profile = load_profile(path)
+
+    assert profile.exclude_sheets == ["Tmp"]
+
+
+@pytest.mark.parametrize(
+    ("name", "content"),
+    [
+        ("bad.json", "{"),
+        ("unknown_key.json", '{"profiles": {"p": {"colour": true}}}'),
+        ("exstruct.ini", "[profiles]"),
+    ],
+)
+def test_load_config_rejects_invalid_files(
+    tmp_path: Path, name: str, content: str
+) -> None:
+    path = tmp_path / name
+    path.write_text(content, encoding="utf-8")
+
+    with pytest.raises(ConfigError):
+        load_config(path)
+
+
+def test_profile_builds_engine_options(tmp_path: Path) -> None:
+    engine = ExStructEngine.from_config(_write_json_config(tmp_path))
+
+    assert engine.options.mode == "light"
+    assert engine.options.table_params == {"density_min": 0.3, "min_nonempty_cells": 4}
+    assert engine.output.format.fmt == "yaml"
+    assert engine.output.filters.include_shapes is False
+    assert engine.output.filters.include_charts is True
+
+
+def test_profile_leaves_unset_flags_on_auto() -> None:
+    options = ExtractionProfile(mode="verbose").to_struct_options()
+
+    assert options.include_colors_map is None
+    assert options.table_params is None
+
+
+def test_sheet_filters_drop_unmatched_sheets() -> None:
+    workbook = WorkbookData(
+        book_name="b.xlsx",
+        sheets={
+            name: SheetData(rows=[CellRow(r=1, c={"0": name})])
+            for name in ("Data1", "Data_old", "Notes")
+        },
+    )
+    engine = ExStructEngine(
+        output=OutputOptions(
+            filters=FilterOptions(sheets=["Data*"], exclude_sheets=["*_old"])
+        )
+    )
+
+    payload = json.loads(engine.serialize(workbook))
+
+    assert list(payload["sheets"]) == ["Data1"]
+
+
+def test_cli_profile_defaults_yield_to_explicit_flags(
+    monkeypatch: pytest.MonkeyPatch, tmp_path: Path
+) -> None:
+    config = _write_json_config(tmp_path)
+    xlsx = tmp_path / "book.xlsx"
+    xlsx.write_bytes(b"")
+    captured: dict[str, object] = {}
+
+    def _capture_process_excel(*_args: object, **kwargs: object) -> None:
+        captured.update(kwargs)
+
+    monkeypatch.setattr("exstruct.cli.main.process_excel", _capture_process_excel)
+
+    assert cli_main([str(xlsx), "--config", str(config), "-f", "json"]) == 0
+    assert (captured["mode"], captured["out_fmt"]) == ("light", "json")
+    profile = captured["profile"]
+    assert isinstance(profile, ExtractionProfile)
+    assert profile.include_shapes is False
+
+
+def test_cli_profile_requires_config(
+    tmp_path: Path, capsys: pytest.CaptureFixture[str]
+) -> None:
+    xlsx = tmp_path / "book.xlsx"
+    xlsx.write_bytes(b"")
+
+    assert cli_main([str(xlsx), "--profile", "fast"]) == 1
+    assert "--profile requires --config" in capsys.readouterr().out
+
+
+def _capture_engine(
+    monkeypatch: pytest.MonkeyPatch,
+) -> list[ExStructEngine]:
+    engines: list[ExStructEngine] = []
+
+    def _process(self: ExStructEngine, **_kwargs: object) -> None:
+        engines.append(self)
+
+    monkeypatch.setattr(ExStructEngine, "process", _process)
+    return engines
+
+
+def test_process_excel_uses_profile_mode_and_filters(
+    monkeypatch: pytest.MonkeyPatch, tmp_path: Path
+) -> None:
+    engines = _capture_engine(monkeypatch)
+    verbose = ExtractionProfile(mode="verbose", format="yaml", include_charts=False)
+    light = ExtractionProfile(mode="light", infer_print_areas=True)
+
+    process_excel(tmp_path / "book.xlsx", profile=verbose)
+    process_excel(tmp_path / "book.xlsx", profile=verbose, mode="standard")
+    process_excel(tmp_path / "book.xlsx", profile=light)
+
+    profiled, overridden, inferred = engines
+    assert profiled.options.mode == "verbose"
+    assert profiled.output.format.fmt == "yaml"
+    assert profiled.output.filters.include_shape_size is True
+    assert profiled.output.filters.include_chart_size is True
+    assert profiled.output.filters.include_charts is False
+    assert overridden.options.mode == "standard"
+    assert overridden.output.filters.include_shape_size is False
+    assert inferred.output.filters.include_print_areas is True
+
+
+def test_cli_and_process_excel_resolve_profiles_alike(
+    monkeypatch: pytest.MonkeyPatch, tmp_path: Path
+) -> None:
+    config = _write_json_config(tmp_path)
+    xlsx = tmp_path / "book.xlsx"
+    xlsx.write_bytes(b"")
+    engines = _capture_engine(monkeypatch)
+
+    assert (
+        cli_main(
+            [str(xlsx), "--config", str(config), "--profile", "full", "--pretty"]
+            + ["--table-density-min", "0.5", "--named-ranges"]
+        )
+        == 0
+    )
+    process_excel(
+        xlsx,
+        profile=load_profile(config, "full"),
+        pretty=True,
+        table_params={"density_min": 0.5},
+        include_named_ranges=True,
+    )
+
+    from_cli, from_api = engines
+    assert from_cli.options == from_api.options
+    assert from_cli.output.format == from_api.output.format
+    assert from_cli.output.filters == from_api.output.filters
+    assert from_cli.options.mode == "verbose"
+    assert from_cli.options.include_colors_map is True
+
+
+def test_explicit_false_turns_off_profile_switches(
+    monkeypatch: pytest.MonkeyPatch, tmp_path: Path
+) -> None:
+    config = tmp_path / "exstruct.json"
+    config.write_text(
+        json.dumps({"profiles": {"rich": {"pretty": True, "include_styles": True}}}),
+        encoding="utf-8",
+    )
+    xlsx = tmp_path / "book.xlsx"
+    xlsx.write_bytes(b"")
+    engines = _capture_engine(monkeypatch)
+
+    assert cli_main([str(xlsx), "--config", str(config), "--no-styles"]) == 0
+    assert cli_main([str(xlsx), "--config", str(config), "--no-pretty"]) == 0
+    process_excel(
+        xlsx, profile=load_profile(config), pretty=False, include_styles=False
+    )
+
+    no_styles, no_pretty, from_api = engines
+    assert no_styles.options.include_styles is False
+    assert no_styles.output.format.pretty is True
+    assert no_pretty.options.include_styles is True
+    assert no_pretty.output.format.pretty is False
+    assert from_api.options.include_styles is False
+    assert from_api.output.format.pretty is False
+
+
+def test_cli_covered_ranges_switch(
+    monkeypatch: pytest.MonkeyPatch, tmp_path: Path
+) -> None:
+    xlsx = tmp_path / "book.xlsx"
+    xlsx.write_bytes(b"")
+    engines = _capture_engine(monkeypatch)
+
+    assert cli_main([str(xlsx)]) == 0
+    assert cli_main([str(xlsx), "--covered-ranges"]) == 0
+    assert cli_main([str(xlsx), "--no-covered-ranges"]) == 0
+
+    assert [engine.options.include_covered_ranges for engine in engines] == [
+        None,
+        True,
+        False,
+    ]
@@ -4,6 +4,7 @@ from pathlib import Path
 from _pytest.monkeypatch import MonkeyPatch
 
 from exstruct.engine import (
+    ComponentsOptions,
     DestinationOptions,
     ExStructEngine,
     FilterOptions,
@@ -37,6 +38,20 @@ def test_engine_extract_uses_mode(monkeypatch: MonkeyPatch, tmp_path: Path) -> N
         include_formulas_map: bool | None = None,
         include_merged_cells: bool | None = None,
         include_merged_values_in_rows: bool = True,
+        include_dimensions: bool | None = None,
+        include_cell_errors: bool | None = None,
+        include_outline: bool | None = None,
+        include_cells: bool = True,
+        include_shapes: bool = True,
+        include_charts: bool = True,
+        include_tables: bool = True,
+        best_effort: bool = False,
+        fast_cells: bool = False,
+        stable_ids: bool = False,
+        position_unit: str | None = None,
+        position_dpi: int | None = None,
+        locale: str | None = None,
+        include_phonetic: bool = False,
     ) -> WorkbookData:
         """
         Test helper that simulates workbook extraction for unit tests.
@@ -149,6 +164,34 @@ def test_engine_serialize_filters_merged_cells() -> None:
     assert "merged_cells" not in text
 
 
+def test_engine_filter_keeps_fields_without_filters() -> None:
+    sheet = _sample_workbook().sheets["Sheet1"].model_copy(
+        update={
+            "content_hash": "abc",
+            "column_widths": {"A": 12.0},
+            "extensions": {"custom": {"k": 1}},
+        }
+    )
+    engine = ExStructEngine(
+        output=OutputOptions(filters=FilterOptions(include_shapes=False))
+    )
+
+    filtered = engine._filter_sheet(sheet)
+
+    assert filtered.shapes == []
+    untouched = set(SheetData.model_fields) - {
+        "shapes",
+        "flowcharts",
+        "shape_overlaps",
+        "charts",
+        "print_areas",
+        "auto_print_areas",
+        "named_ranges",
+    }
+    for name in untouched:
+        assert getattr(filtered, name) == getattr(sheet, name), name
+
+
 def test_engine_include_cell_links_toggle() -> None:
     wb = _sample_workbook()
     # By default links remain (already present)
@@ -196,7 +239,7 @@ def test_engine_export_respects_sheets_dir(tmp_path: Path) -> None:
     assert out.exists()
     assert sheets_dir.exists()
     files = list(sheets_dir.glob("*.json"))
-    assert len(files) == 1
+    assert sorted(f.name for f in files) == ["Sheet1.json", "index.json"]
 
 
 def test_engine_export_print_areas_dir(tmp_path: Path) -> None:
@@ -331,3 +374,50 @@ def test_engine_process_normalizes_string_paths(
     assert isinstance(calls["images_dir"], Path)
     assert calls["images_dir"].name.endswith("_images")
     assert calls["dpi"] == 144
+
+
+def test_engine_process_markdown_computes_covered_ranges(
+    monkeypatch: MonkeyPatch, tmp_path: Path
+) -> None:
+    seen: list[bool | None] = []
+
+    def fake_extract(
+        self: ExStructEngine, file_path: Path, *, mode: str | None = None
+    ) -> WorkbookData:
+        seen.append(self.options.include_covered_ranges)
+        return _sample_workbook()
+
+    monkeypatch.setattr(ExStructEngine, "extract", fake_extract, raising=True)
+    input_path = tmp_path / "input.xlsx"
+    input_path.write_text("", encoding="utf-8")
+
+    ExStructEngine().process(input_path, tmp_path / "out.md", out_fmt="markdown")
+    ExStructEngine().process(input_path, tmp_path / "out.json", out_fmt="json")
+    ExStructEngine(StructOptions(include_covered_ranges=False)).process(
+        input_path, tmp_path / "off.md", out_fmt="markdown"
+    )
+
+    assert seen == [True, None, False]
+
+
+def test_engine_extract_forwards_components(
+    monkeypatch: MonkeyPatch, tmp_path: Path
+) -> None:
+    called: dict[str, object] = {}
+
+    def fake_extract(path: Path, **kwargs: object) -> WorkbookData:
+        called.update(kwargs)
+        return WorkbookData(book_name=path.name, sheets={})
+
+    monkeypatch.setattr("exstruct.engine.extract_workbook", fake_extract)
+    engine = ExStructEngine(
+        options=StructOptions(
+            components=ComponentsOptions(cells=False, tables=False, print_areas=False)
+        )
+    )
+    engine.extract(tmp_path / "book.xlsx")
+
+    assert called["include_cells"] is False
+    assert called["include_tables"] is False
+    assert called["include_print_areas"] is False
+    assert called["include_shapes"] is True
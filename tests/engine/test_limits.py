@@ -0,0 +1,154 @@
+"""Tests for cell/sheet/output size limits."""
+
+from __future__ import annotations
+
+from pathlib import Path
+
+from openpyxl import Workbook
+import pytest
+
+from exstruct.cli.main import main as cli_main
+from exstruct.engine import ExStructEngine, LimitsOptions, StructOptions
+from exstruct.errors import LimitExceededError
+from exstruct.models import (
+    CellError,
+    CellRow,
+    SheetData,
+    SheetTruncation,
+    WorkbookData,
+)
+
+
+def _book(tmp_path: Path) -> Path:
+    wb = Workbook()
+    ws = wb.active
+    assert ws is not None
+    ws.title = "One"
+    for row in range(1, 4):
+        ws.append([row, row * 2])
+    wb.create_sheet("Two")["A1"] = "x"
+    wb.create_sheet("Three")["A1"] = "y"
+    path = tmp_path / "book.xlsx"
+    wb.save(path)
+    return path
+
+
+def _workbook() -> WorkbookData:
+    return WorkbookData(
+        book_name="book.xlsx",
+        sheets={
+            "One": SheetData(
+                rows=[CellRow(r=1, c={"0": 1, "1": 2}), CellRow(r=2, c={"0": 3})]
+            ),
+            "Two": SheetData(rows=[CellRow(r=1, c={"0": "x"})]),
+            "Three": SheetData(rows=[CellRow(r=1, c={"0": "y"})]),
+        },
+    )
+
+
+def test_package_limits_fail_before_extraction(
+    tmp_path: Path, monkeypatch: pytest.MonkeyPatch
+) -> None:
+    def fail_extract(*_args: object, **_kwargs: object) -> WorkbookData:
+        raise AssertionError("extraction should not run")
+
+    monkeypatch.setattr("exstruct.engine.extract_workbook", fail_extract)
+    path = _book(tmp_path)
+
+    sheets = ExStructEngine(options=StructOptions(limits=LimitsOptions(max_sheets=2)))
+    with pytest.raises(LimitExceededError, match="3 sheets, exceeding max_sheets=2"):
+        sheets.extract(path)
+
+    cells = ExStructEngine(options=StructOptions(limits=LimitsOptions(max_cells=7)))
+    with pytest.raises(LimitExceededError, match="8 non-empty cells"):
+        cells.extract(path)
+
+
+def test_truncate_keeps_first_sheets_and_cells(
+    monkeypatch: pytest.MonkeyPatch,
+) -> None:
+    monkeypatch.setattr(
+        "exstruct.engine.extract_workbook", lambda *_args, **_kwargs: _workbook()
+    )
+    limits = LimitsOptions(max_sheets=2, max_cells=2, on_exceed="truncate")
+
+    workbook = ExStructEngine(options=StructOptions(limits=limits)).extract("b.xlsx")
+
+    assert list(workbook.sheets) == ["One", "Two"]
+    assert [row.c for row in workbook.sheets["One"].rows] == [{"0": 1, "1": 2}]
+    assert workbook.sheets["Two"].rows == []
+
+
+def test_extracted_data_is_checked_when_package_cannot_be(
+    monkeypatch: pytest.MonkeyPatch,
+) -> None:
+    monkeypatch.setattr(
+        "exstruct.engine.extract_workbook", lambda *_args, **_kwargs: _workbook()
+    )
+    engine = ExStructEngine(options=StructOptions(limits=LimitsOptions(max_cells=3)))
+
+    with pytest.raises(LimitExceededError, match="max_cells=3"):
+        engine.extract("legacy.xls")
+
+
+def test_max_output_bytes() -> None:
+    engine = ExStructEngine(
+        options=StructOptions(limits=LimitsOptions(max_output_bytes=20))
+    )
+
+    with pytest.raises(LimitExceededError, match="max_output_bytes=20"):
+        engine.serialize(_workbook())
+
+
+def test_cli_limit_flags(tmp_path: Path, capsys: pytest.CaptureFixture[str]) -> None:
+    path = _book(tmp_path)
+
+    code = cli_main([str(path), "--mode", "light", "--max-sheets", "1"])
+
+    assert code == 1
+    assert "exceeding max_sheets=1" in capsys.readouterr().out
+
+    out = tmp_path / "out.json"
+    code = cli_main(
+        [str(path), "--mode", "light", "--max-sheets", "1", "--truncate-on-limit"]
+        + ["-o", str(out)]
+    )
+
+    assert code == 0
+    assert '"Two"' not in out.read_text(encoding="utf-8")
+
+
+def test_clip_sheet_extents_marks_truncated_sheets(
+    monkeypatch: pytest.MonkeyPatch,
+) -> None:
+    def wide_workbook(*_args: object, **_kwargs: object) -> WorkbookData:
+        return WorkbookData(
+            book_name="book.xlsx",
+            sheets={
+                "Wide": SheetData(
+                    rows=[
+                        CellRow(r=1, c={"0": "a", "3": "d"}, links={"3": "x"}),
+                        CellRow(r=5, c={"1": "b"}),
+                    ],
+                    formulas_map={"=A1": [(1, 0), (5, 1)]},
+                    colors_map={"FF0000": [(1, 3)]},
+                    errors=[CellError(cell="B5", error="#N/A")],
+                ),
+                "Small": SheetData(rows=[CellRow(r=1, c={"0": "y"})]),
+            },
+        )
+
+    monkeypatch.setattr("exstruct.engine.extract_workbook", wide_workbook)
+    limits = LimitsOptions(max_rows_per_sheet=4, max_cols_per_sheet=2)
+
+    workbook = ExStructEngine(options=StructOptions(limits=limits)).extract("b.xlsx")
+
+    wide = workbook.sheets["Wide"]
+    assert [(row.r, row.c, row.links) for row in wide.rows] == [(1, {"0": "a"}, {})]
+    assert wide.formulas_map == {"=A1": [(1, 0)]}
+    assert wide.colors_map == {}
+    assert wide.errors == []
+    assert wide.truncation == SheetTruncation(
+        original_rows=5, original_cols=4, max_rows=4, max_cols=2
+    )
+    assert workbook.sheets["Small"].truncation is None
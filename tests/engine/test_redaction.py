@@ -0,0 +1,154 @@
+"""Tests for the PII redaction pass."""
+
+from __future__ import annotations
+
+import json
+from pathlib import Path
+
+from openpyxl import Workbook
+from pydantic import ValidationError
+import pytest
+
+from exstruct.cli.main import main as cli_main
+from exstruct.config import ExtractionProfile
+from exstruct.models import (
+    CellRow,
+    Shape,
+    SheetData,
+    SmartArt,
+    SmartArtNode,
+    WorkbookData,
+)
+from exstruct.redaction import (
+    RedactionOptions,
+    RedactionRule,
+    Redactor,
+    redact_workbook,
+)
+
+
+def _workbook() -> WorkbookData:
+    return WorkbookData(
+        book_name="people.xlsx",
+        sheets={
+            "People": SheetData(
+                rows=[
+                    CellRow(
+                        r=1,
+                        c={"0": "Mail taro@example.com now", "1": 123456789, "2": 7},
+                        links={"0": "mailto:taro@example.com"},
+                    ),
+                    CellRow(r=2, c={"0": "SSN 123-45-6789", "1": "090-1234-5678"}),
+                ],
+                shapes=[
+                    Shape(id=1, text="call 03-1234-5678", l=0, t=0),
+                    SmartArt(
+                        id=2,
+                        text="",
+                        l=0,
+                        t=0,
+                        layout="list",
+                        nodes=[
+                            SmartArtNode(
+                                text="root",
+                                kids=[SmartArtNode(text="hanako@example.jp")],
+                            )
+                        ],
+                    ),
+                ],
+            ),
+            "Public": SheetData(rows=[CellRow(r=1, c={"1": "secret"})]),
+        },
+    )
+
+
+def test_builtin_rules_mask_cells_links_and_shapes() -> None:
+    options = RedactionOptions.from_names(["email", "phone", "national_id"])
+    source = _workbook()
+
+    redacted = redact_workbook(source, options)
+
+    sheet = redacted.sheets["People"]
+    assert sheet.rows[0].c == {
+        "0": "Mail [REDACTED:email] now",
+        "1": 123456789,
+        "2": 7,
+    }
+    assert sheet.rows[0].links == {"0": "mailto:[REDACTED:email]"}
+    assert sheet.rows[1].c == {
+        "0": "SSN [REDACTED:national_id]",
+        "1": "[REDACTED:phone]",
+    }
+    assert sheet.shapes[0].text == "call [REDACTED:phone]"
+    smartart = sheet.shapes[1]
+    assert isinstance(smartart, SmartArt)
+    assert smartart.nodes[0].kids[0].text == "[REDACTED:email]"
+    # The source workbook is left untouched.
+    assert source.sheets["People"].rows[0].c["0"] == "Mail taro@example.com now"
+
+
+def test_column_rules_and_sheet_scope() -> None:
+    options = RedactionOptions(
+        rules=[RedactionRule(name="account", columns=["B"], sheets=["Peo*"])],
+        include_shapes=False,
+    )
+
+    redacted = redact_workbook(_workbook(), options)
+
+    assert redacted.sheets["People"].rows[0].c["1"] == "[REDACTED:account]"
+    assert redacted.sheets["People"].rows[0].c["0"] == "Mail taro@example.com now"
+    assert redacted.sheets["People"].shapes[0].text == "call 03-1234-5678"
+    assert redacted.sheets["Public"].rows[0].c == {"1": "secret"}
+
+
+def test_hash_method_is_stable_and_salted() -> None:
+    plain = RedactionOptions.from_names(["email"], method="hash")
+    salted = RedactionOptions(rules=plain.rules, method="hash", salt="pepper")
+
+    first = redact_workbook(_workbook(), plain).sheets["People"].rows[0]
+    second = redact_workbook(_workbook(), plain).sheets["People"].rows[0]
+    other = redact_workbook(_workbook(), salted).sheets["People"].rows[0]
+
+    assert first.c["0"] == second.c["0"]
+    assert str(first.c["0"]).startswith("Mail email:")
+    assert "example.com" not in str(first.c["0"])
+    assert other.c["0"] != first.c["0"]
+
+
+def test_invalid_rules_are_rejected() -> None:
+    with pytest.raises(ValueError, match="Unknown redaction rule"):
+        RedactionOptions.from_names(["passport"])
+    with pytest.raises(ValidationError):
+        RedactionRule(name="bad", pattern="(")
+
+
+def test_profile_redaction_becomes_transform() -> None:
+    profile = ExtractionProfile.model_validate(
+        {"redaction": {"rules": [{"name": "email", "pattern": r"\S+@\S+"}]}}
+    )
+
+    (transform,) = profile.to_struct_options().transforms
+
+    assert isinstance(transform, Redactor)
+    assert ExtractionProfile().to_struct_options().transforms == ()
+
+
+def test_cli_redact_flag(tmp_path: Path) -> None:
+    wb = Workbook()
+    ws = wb.active
+    assert ws is not None
+    ws.title = "Sheet1"
+    ws.append(["Name", "Email"])
+    ws.append(["Taro", "taro@example.com"])
+    xlsx = tmp_path / "people.xlsx"
+    wb.save(xlsx)
+    out = tmp_path / "out.json"
+
+    code = cli_main(
+        [str(xlsx), "-o", str(out), "--mode", "light", "--redact", "email"]
+    )
+
+    assert code == 0
+    text = out.read_text(encoding="utf-8")
+    assert "taro@example.com" not in text
+    assert "[REDACTED:email]" in json.dumps(json.loads(text))
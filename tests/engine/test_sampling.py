@@ -0,0 +1,101 @@
+"""Tests for row sampling."""
+
+from __future__ import annotations
+
+from pathlib import Path
+
+from openpyxl import Workbook
+from pydantic import ValidationError
+import pytest
+
+from exstruct.cli.main import EXIT_FAILURE, main as cli_main
+from exstruct.core.sampling import sample_workbook_rows
+from exstruct.engine import ExStructEngine, SampleRowsOptions, StructOptions
+from exstruct.models import CellRow, RowSample, SheetData, WorkbookData
+
+
+def _workbook() -> WorkbookData:
+    return WorkbookData(
+        book_name="book.xlsx",
+        sheets={
+            "Big": SheetData(rows=[CellRow(r=r, c={"0": r}) for r in range(1, 11)]),
+            "Small": SheetData(rows=[CellRow(r=1, c={"0": "x"})]),
+        },
+    )
+
+
+def _row_numbers(workbook: WorkbookData, name: str = "Big") -> list[int]:
+    return [row.r for row in workbook.sheets[name].rows]
+
+
+def test_head_and_tail_keep_first_and_last_rows() -> None:
+    head = sample_workbook_rows(_workbook(), strategy="head", n=3)
+    tail = sample_workbook_rows(_workbook(), strategy="tail", n=3)
+
+    assert _row_numbers(head) == [1, 2, 3]
+    assert _row_numbers(tail) == [8, 9, 10]
+    assert head.sheets["Big"].sample == RowSample(
+        strategy="head", n=3, total_rows=10, sampled_rows=3
+    )
+    assert head.sheets["Small"].sample is None
+    assert _row_numbers(head, "Small") == [1]
+
+
+def test_random_sample_is_reproducible_and_ordered() -> None:
+    first = sample_workbook_rows(_workbook(), strategy="random", n=4, seed=7)
+    second = sample_workbook_rows(_workbook(), strategy="random", n=4, seed=7)
+
+    rows = _row_numbers(first)
+    assert rows == _row_numbers(second)
+    assert rows == sorted(rows)
+    assert len(set(rows)) == 4
+    sample = first.sheets["Big"].sample
+    assert sample is not None
+    assert sample.seed == 7
+
+
+def test_engine_applies_sample_rows(monkeypatch: pytest.MonkeyPatch) -> None:
+    monkeypatch.setattr(
+        "exstruct.engine.extract_workbook", lambda *_args, **_kwargs: _workbook()
+    )
+    options = StructOptions(sample_rows=SampleRowsOptions(strategy="tail", n=2))
+    engine = ExStructEngine(options=options)
+
+    workbook = engine.extract("book.xlsx")
+
+    assert _row_numbers(workbook) == [9, 10]
+    text = engine.serialize(workbook).replace(" ", "")
+    assert '"sample":{"strategy":"tail"' in text
+
+
+def test_sample_rows_rejects_non_positive() -> None:
+    with pytest.raises(ValidationError):
+        SampleRowsOptions(n=0)
+    with pytest.raises(ValueError):
+        sample_workbook_rows(_workbook(), strategy="head", n=0)
+
+
+def test_cli_sample_rows(tmp_path: Path) -> None:
+    wb = Workbook()
+    ws = wb.active
+    assert ws is not None
+    for row in range(1, 21):
+        ws.append([row])
+    book = tmp_path / "book.xlsx"
+    wb.save(book)
+    out = tmp_path / "out.json"
+    args = [str(book), "-o", str(out), "--mode", "light", "--sample-rows", "5"]
+
+    code = cli_main(args)
+
+    assert code == 0
+    text = out.read_text(encoding="utf-8").replace(" ", "")
+    assert '"total_rows":20' in text
+    assert '"sampled_rows":5' in text
+
+
+def test_cli_sample_strategy_requires_sample_rows(tmp_path: Path) -> None:
+    book = tmp_path / "book.xlsx"
+    Workbook().save(book)
+
+    assert cli_main([str(book), "--sample-strategy", "tail"]) == EXIT_FAILURE
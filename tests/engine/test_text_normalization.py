@@ -0,0 +1,97 @@
+"""Tests for the Unicode normalization and whitespace cleanup pass."""
+
+from __future__ import annotations
+
+import json
+from pathlib import Path
+
+from openpyxl import Workbook
+import pytest
+
+from exstruct.cli.main import main as cli_main
+from exstruct.engine import ExStructEngine, StructOptions
+from exstruct.models import (
+    CellRow,
+    Shape,
+    SheetData,
+    SmartArt,
+    SmartArtNode,
+    WorkbookData,
+)
+from exstruct.text_normalization import TextNormalizer, normalize_text
+
+
+@pytest.mark.parametrize(
+    ("text", "expected"),
+    [
+        ("\xa0 Total\u3000 amount\u200b ", "Total amount"),
+        ("Ａｂｃ１２３", "Abc123"),
+        ("ｶﾀｶﾅ", "カタカナ"),
+        ("line1  \r\n\tline2\t\tend", "line1\nline2 end"),
+        ("a \n\n b", "a\n\nb"),
+        ("\ufeff", ""),
+    ],
+)
+def test_normalize_text(text: str, expected: str) -> None:
+    assert normalize_text(text) == expected
+
+
+def test_text_normalizer_cells_and_shapes() -> None:
+    workbook = WorkbookData(
+        book_name="book.xlsx",
+        sheets={
+            "S": SheetData(
+                rows=[
+                    CellRow(r=1, c={"0": " Ｎａｍｅ\xa0", "1": 12, "2": "\u200b"}),
+                    CellRow(r=2, c={"0": "\u200b"}),
+                    CellRow(r=3, c={"0": "\u2060"}, links={"0": "https://x"}),
+                ],
+                shapes=[
+                    Shape(id=1, text="Start\u3000\u3000here", l=0, t=0),
+                    SmartArt(
+                        id=2,
+                        text="",
+                        l=0,
+                        t=0,
+                        layout="list",
+                        nodes=[SmartArtNode(text=" root ", kids=[])],
+                    ),
+                ],
+            )
+        },
+    )
+
+    TextNormalizer()(workbook)
+
+    sheet = workbook.sheets["S"]
+    assert [row.r for row in sheet.rows] == [1, 3]
+    assert sheet.rows[0].c == {"0": "Name", "1": 12}
+    assert sheet.rows[1].c == {}
+    assert sheet.shapes[0].text == "Start here"
+    smartart = sheet.shapes[1]
+    assert isinstance(smartart, SmartArt)
+    assert smartart.nodes[0].text == "root"
+
+
+def test_normalize_text_option_and_cli(tmp_path: Path) -> None:
+    wb = Workbook()
+    ws = wb.active
+    assert ws is not None
+    ws["A1"] = "Ｔｏｔａｌ\xa0\xa0 sales\u200b"
+    path = tmp_path / "book.xlsx"
+    wb.save(path)
+
+    plain = ExStructEngine(options=StructOptions(mode="light")).extract(path)
+    normalized = ExStructEngine(
+        options=StructOptions(mode="light", normalize_text=True)
+    ).extract(path)
+    out = tmp_path / "out.json"
+    code = cli_main(
+        [str(path), "--mode", "light", "--normalize-text", "-o", str(out)]
+    )
+
+    assert plain.sheets["Sheet"].rows[0].c["0"] != "Total sales"
+    assert normalized.sheets["Sheet"].rows[0].c["0"] == "Total sales"
+    assert code == 0
+    data = json.loads(out.read_text(encoding="utf-8"))
+    assert data["sheets"]["Sheet"]["rows"][0]["c"]["0"] == "Total sales"
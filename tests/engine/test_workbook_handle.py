@@ -0,0 +1,127 @@
+"""Tests for open workbook handles and partial re-extraction."""
+
+from __future__ import annotations
+
+from collections.abc import Collection
+from pathlib import Path
+
+from openpyxl import Workbook, load_workbook
+import pytest
+
+from exstruct.engine import ExStructEngine, StructOptions
+from exstruct.models import WorkbookData
+
+
+def _write_book(path: Path) -> Path:
+    wb = Workbook()
+    wb.active.title = "One"
+    wb.active["A1"] = "one"
+    wb.create_sheet("Two")["A1"] = "two"
+    wb.save(path)
+    return path
+
+
+def _set_cell(path: Path, sheet: str, value: str) -> None:
+    wb = load_workbook(path)
+    wb[sheet]["A1"] = value
+    wb.save(path)
+
+
+def _record_runs(
+    monkeypatch: pytest.MonkeyPatch,
+) -> list[tuple[list[str] | None, bool]]:
+    """Record (sheets, cells component) for every engine extraction."""
+    runs: list[tuple[list[str] | None, bool]] = []
+    original = ExStructEngine._extract_workbook_with_options
+
+    def recording(
+        self: ExStructEngine,
+        file_path: str | Path,
+        *,
+        sheets: Collection[str] | None = None,
+        **kwargs: object,
+    ) -> WorkbookData:
+        selected = None if sheets is None else list(sheets)
+        runs.append((selected, self.options.components.cells))
+        return original(
+            self, file_path, sheets=sheets, **kwargs  # type: ignore[arg-type]
+        )
+
+    monkeypatch.setattr(ExStructEngine, "_extract_workbook_with_options", recording)
+    return runs
+
+
+def _value(workbook: WorkbookData, sheet: str) -> object:
+    return workbook.sheets[sheet].rows[0].c["0"]
+
+
+def test_invalidate_sheet_re_extracts_only_that_sheet(
+    monkeypatch: pytest.MonkeyPatch, tmp_path: Path
+) -> None:
+    path = _write_book(tmp_path / "book.xlsx")
+    runs = _record_runs(monkeypatch)
+    handle = ExStructEngine(StructOptions(mode="light")).open(path)
+
+    assert handle.refresh() == handle.refresh()
+    assert runs == [(None, True)]
+
+    _set_cell(path, "One", "one-edited")
+    _set_cell(path, "Two", "two-edited")
+    handle.invalidate("Two")
+    workbook = handle.refresh()
+
+    assert runs == [(None, True), (["Two"], True)]
+    assert _value(workbook, "One") == "one"
+    assert _value(workbook, "Two") == "two-edited"
+    assert list(workbook.sheets) == ["One", "Two"]
+
+
+def test_invalidate_components_merges_only_those_components(
+    monkeypatch: pytest.MonkeyPatch, tmp_path: Path
+) -> None:
+    path = _write_book(tmp_path / "book.xlsx")
+    runs = _record_runs(monkeypatch)
+    handle = ExStructEngine(StructOptions(mode="light")).open(path)
+
+    _set_cell(path, "One", "one-edited")
+    handle.invalidate("One", ["print_areas"])
+    workbook = handle.refresh()
+
+    assert runs[-1] == (["One"], False)
+    assert _value(workbook, "One") == "one"
+
+    handle.invalidate("One", ["cells"])
+    assert _value(handle.refresh(), "One") == "one-edited"
+
+
+def test_invalidate_workbook_picks_up_new_sheets(
+    monkeypatch: pytest.MonkeyPatch, tmp_path: Path
+) -> None:
+    path = _write_book(tmp_path / "book.xlsx")
+    runs = _record_runs(monkeypatch)
+    handle = ExStructEngine(StructOptions(mode="light")).open(path)
+    wb = load_workbook(path)
+    wb.create_sheet("Three")["A1"] = "three"
+    del wb["One"]
+    wb.save(path)
+
+    handle.invalidate("Three", ["shapes"])
+    handle.invalidate("One")
+    workbook = handle.refresh()
+
+    assert runs[-1] == (["Three", "One"], True)
+    assert list(workbook.sheets) == ["Two", "Three"]
+    assert _value(workbook, "Three") == "three"
+
+    handle.invalidate()
+    handle.refresh()
+    assert runs[-1] == (None, True)
+    assert handle.sheet_names == ["Two", "Three"]
+
+
+def test_invalidate_rejects_unknown_component(tmp_path: Path) -> None:
+    path = _write_book(tmp_path / "book.xlsx")
+    handle = ExStructEngine(StructOptions(mode="light")).open(path)
+
+    with pytest.raises(ValueError, match="Unknown component"):
+        handle.invalidate("One", ["formulas"])  # type: ignore[list-item]
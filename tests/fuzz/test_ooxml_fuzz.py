@@ -0,0 +1,175 @@
+"""Mutation fuzzing of the drawing, chart, and relationships parsers.
+
+Seeds come from ``tests/fuzz/corpus`` (regenerate with ``task gen-corpus``).
+Each target must either return normally or raise one of the documented
+parse errors, whatever the input. Set ``EXSTRUCT_FUZZ_ITERATIONS`` to run
+more mutations per seed locally; ``EXSTRUCT_FUZZ_SEED`` reproduces a run.
+"""
+
+from __future__ import annotations
+
+from collections.abc import Callable
+import io
+import os
+from pathlib import Path
+import random
+import re
+from xml.etree import ElementTree as ET
+import zipfile
+
+import pytest
+
+from exstruct.core.ooxml_drawing import _read_relationships
+from exstruct.errors import UnsafeWorkbookError
+from exstruct.ooxml.chart import MAX_CACHE_POINTS, _parse_chart_xml, parse_series_cache
+from exstruct.ooxml.drawing import MAX_GROUP_DEPTH, _parse_drawing_xml
+
+_CORPUS = Path(__file__).parent / "corpus"
+_ITERATIONS = int(os.environ.get("EXSTRUCT_FUZZ_ITERATIONS", "50"))
+_SEED = int(os.environ.get("EXSTRUCT_FUZZ_SEED", "20240601"))
+_ALLOWED = (ET.ParseError, UnsafeWorkbookError)
+
+_NUMERIC_ATTR = re.compile(rb'(\w+)="(-?\d+)"')
+_NUMERIC_TEXT = re.compile(rb">(-?\d+)<")
+_HOSTILE_NUMBERS = (
+    b"",
+    b"-1",
+    b"abc",
+    b"NaN",
+    b"1e999",
+    b"99999999999",
+    b"9" * 400,
+)
+_XDR = "http://schemas.openxmlformats.org/drawingml/2006/spreadsheetDrawing"
+_A = "http://schemas.openxmlformats.org/drawingml/2006/main"
+_C = "http://schemas.openxmlformats.org/drawingml/2006/chart"
+
+
+def _drawing_target(data: bytes) -> None:
+    _parse_drawing_xml(data, "verbose")
+
+
+def _chart_target(data: bytes) -> None:
+    _parse_chart_xml(data, "Chart 1", 0, 0, 400, 300)
+
+
+def _rels_target(data: bytes) -> None:
+    buffer = io.BytesIO()
+    with zipfile.ZipFile(buffer, "w") as zf:
+        zf.writestr("xl/drawings/_rels/drawing1.xml.rels", data)
+    with zipfile.ZipFile(buffer) as zf:
+        _read_relationships(zf, "xl/drawings/_rels/drawing1.xml.rels")
+
+
+_TARGETS: dict[str, Callable[[bytes], None]] = {
+    "drawing": _drawing_target,
+    "chart": _chart_target,
+    "rels": _rels_target,
+}
+
+
+def _seeds(kind: str) -> list[Path]:
+    return sorted((_CORPUS / kind).glob("*"))
+
+
+def _replace_number(data: bytes, rng: random.Random) -> bytes:
+    matches = [*_NUMERIC_ATTR.finditer(data), *_NUMERIC_TEXT.finditer(data)]
+    if not matches:
+        return data
+    match = rng.choice(matches)
+    start, end = match.span(match.lastindex or 0)
+    return data[:start] + rng.choice(_HOSTILE_NUMBERS) + data[end:]
+
+
+def _mutate(data: bytes, rng: random.Random) -> bytes:
+    """Apply one random structural or value-level mutation."""
+    if not data:
+        return data
+    pos = rng.randrange(len(data))
+    span = rng.randint(1, 64)
+    strategy = rng.randrange(5)
+    if strategy == 0:
+        return data[:pos]
+    if strategy == 1:
+        flipped = bytes([data[pos] ^ (1 << rng.randrange(8))])
+        return data[:pos] + flipped + data[pos + 1 :]
+    if strategy == 2:
+        return data[:pos] + data[pos + span :]
+    if strategy == 3:
+        return data[:pos] + data[pos : pos + span] * rng.randint(2, 8) + data[pos:]
+    return _replace_number(data, rng)
+
+
+@pytest.mark.parametrize("kind", sorted(_TARGETS))
+def test_corpus_is_present(kind: str) -> None:
+    assert _seeds(kind), f"empty fuzz corpus for {kind}; run scripts/gen_corpus.py"
+
+
+@pytest.mark.parametrize(
+    "kind, seed_path",
+    [(kind, path) for kind in sorted(_TARGETS) for path in _seeds(kind)],
+    ids=lambda value: value.name if isinstance(value, Path) else value,
+)
+def test_mutated_seeds_do_not_crash(kind: str, seed_path: Path) -> None:
+    target = _TARGETS[kind]
+    seed = seed_path.read_bytes()
+    target(seed)
+    rng = random.Random(f"{_SEED}:{seed_path.name}")
+    for _ in range(_ITERATIONS):
+        data = seed
+        for _ in range(rng.randint(1, 3)):
+            data = _mutate(data, rng)
+        try:
+            target(data)
+        except _ALLOWED:
+            pass
+
+
+def _drawing(body: str) -> bytes:
+    return (
+        f'<xdr:wsDr xmlns:xdr="{_XDR}" xmlns:a="{_A}">'
+        f"<xdr:absoluteAnchor>{body}</xdr:absoluteAnchor></xdr:wsDr>"
+    ).encode()
+
+
+def _shape(xfrm_attrs: str = "", x: str = "0") -> str:
+    return (
+        '<xdr:sp><xdr:nvSpPr><xdr:cNvPr id="2" name="Box"/></xdr:nvSpPr>'
+        f'<xdr:spPr><a:xfrm{xfrm_attrs}><a:off x="{x}" y="0"/>'
+        '<a:ext cx="10" cy="10"/></a:xfrm></xdr:spPr>'
+        "<xdr:txBody><a:p><a:r><a:t>box</a:t></a:r></a:p></xdr:txBody></xdr:sp>"
+    )
+
+
+def test_deeply_nested_groups_are_bounded() -> None:
+    nested = MAX_GROUP_DEPTH * 20
+    body = "<xdr:grpSp>" * nested + _shape() + "</xdr:grpSp>" * nested
+
+    assert _parse_drawing_xml(_drawing(body), "verbose") == []
+    shallow = "<xdr:grpSp>" * 3 + _shape() + "</xdr:grpSp>" * 3
+    assert len(_parse_drawing_xml(_drawing(shallow), "verbose")) == 1
+
+
+def test_huge_cache_point_counts_are_capped() -> None:
+    ser = ET.fromstring(
+        f'<c:ser xmlns:c="{_C}"><c:val><c:numLit>'
+        '<c:ptCount val="99999999999"/>'
+        '<c:pt idx="99999999998"><c:v>1</c:v></c:pt>'
+        '<c:pt idx="0"><c:v>2</c:v></c:pt>'
+        "</c:numLit></c:val></c:ser>"
+    )
+
+    _categories, values = parse_series_cache(ser)
+
+    assert values is not None
+    assert len(values) == MAX_CACHE_POINTS
+    assert values[0] == 2.0
+
+
+@pytest.mark.parametrize("value", ["9" * 400, "-" + "9" * 400])
+def test_oversized_numbers_are_ignored(value: str) -> None:
+    rotated = _parse_drawing_xml(_drawing(_shape(f' rot="{value}"')), "verbose")
+    moved = _parse_drawing_xml(_drawing(_shape(x=value)), "verbose")
+
+    assert [(shape.text, shape.rotation) for shape in rotated] == [("box", None)]
+    assert moved == []
@@ -0,0 +1,89 @@
+"""Tests for the public coordinate mapping helpers."""
+
+from __future__ import annotations
+
+import pytest
+
+from exstruct.geometry import (
+    CellRect,
+    SheetGeometry,
+    column_width_to_pixels,
+    default_column_width_from_base,
+    emu_to_points,
+    format_cell,
+    format_range,
+    parse_cell,
+    parse_range,
+    max_digit_width,
+    pixels_to_emu,
+    points_to_emu,
+)
+from exstruct.models import SheetData
+
+
+def test_parse_and_format_cell() -> None:
+    assert parse_cell("B3") == (3, 1)
+    assert parse_cell("'My!Sheet'!$AA$10") == (10, 26)
+    assert format_cell(10, 26) == "AA10"
+    with pytest.raises(ValueError):
+        parse_cell("B0")
+    with pytest.raises(ValueError):
+        format_cell(0, 0)
+
+
+def test_parse_range_normalizes_order() -> None:
+    assert parse_range("Sheet1!$C$5:$A$1") == (1, 0, 5, 2)
+    assert parse_range("B2") == (2, 1, 2, 1)
+    assert format_range(1, 0, 5, 2) == "A1:C5"
+    assert format_range(2, 1, 2, 1) == "B2"
+
+
+def test_emu_round_trip() -> None:
+    assert points_to_emu(1) == 12700
+    assert pixels_to_emu(96) == 914400
+    assert emu_to_points(points_to_emu(12.5)) == pytest.approx(12.5)
+
+
+def test_cell_rect_uses_explicit_and_default_sizes() -> None:
+    sheet = SheetData(
+        column_widths={"1": 20.0},
+        row_heights={"2": 30.0},
+    )
+    geometry = SheetGeometry.from_sheet(sheet)
+
+    # Default column 9.140625 chars = 64px = 48pt, column B 20 chars = 140px.
+    assert geometry.cell_rect(2, 1) == pytest.approx(CellRect(48.0, 15.0, 105.0, 30.0))
+    assert geometry.range_rect("A1:C3") == pytest.approx(
+        CellRect(0.0, 0.0, 201.0, 60.0)
+    )
+    assert geometry.cell_rect(1, 0, unit="pixels") == pytest.approx(
+        CellRect(0.0, 0.0, 64.0, 20.0)
+    )
+
+
+def test_cell_at_and_range_at() -> None:
+    geometry = SheetGeometry.from_sizes({"1": 20.0}, {"2": 30.0})
+
+    assert geometry.cell_at(50, 16) == (2, 1)
+    assert geometry.cell_at(0, 0) == (1, 0)
+    assert geometry.range_at(0, 0, 48, 15) == "A1"
+    assert geometry.range_at(64, 20, 100, 40, unit="pixels") == "B2"
+    assert geometry.range_at(50, 16, 120, 40) == "B2:C3"
+
+
+def test_column_width_conversion_follows_default_font() -> None:
+    assert max_digit_width("Calibri", 11) == 7
+    assert max_digit_width("Arial", 10) == 7
+    assert max_digit_width("Calibri", 20) == 14
+    assert max_digit_width(None, None) == 7
+    assert column_width_to_pixels(8.43) == 59
+    assert column_width_to_pixels(20.0, 14) == 280
+    assert default_column_width_from_base() == 9.140625
+    assert default_column_width_from_base(8, 14) == pytest.approx(8.5703125)
+
+
+def test_geometry_scales_widths_with_max_digit_width() -> None:
+    geometry = SheetGeometry.from_sizes({"1": 20.0}, max_digit_width=14)
+
+    left, _, width, _ = geometry.cell_rect(1, 1, unit="pixels")
+    assert (left, width) == pytest.approx((120.0, 280.0))
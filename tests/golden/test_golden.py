@@ -0,0 +1,67 @@
+"""Golden-file tests: full extraction of fixture workbooks vs. stored JSON.
+
+Every ``testdata/<name>.xlsx`` is extracted in standard mode (COM is disabled
+for non-COM tests, so shapes and charts come from the OOXML fallback) and
+compared with ``testdata/<name>.golden.json``. After an intended output
+change, regenerate the goldens and review the diff::
+
+    pytest tests/golden --update-golden
+"""
+
+from __future__ import annotations
+
+import difflib
+from pathlib import Path
+
+import pytest
+
+from exstruct import extract, serialize_workbook
+
+_TESTDATA = Path(__file__).parent / "testdata"
+_MAX_DIFF_LINES = 80
+
+
+def _fixtures() -> list[Path]:
+    return sorted(_TESTDATA.glob("*.xlsx"))
+
+
+def _render(path: Path) -> str:
+    workbook = extract(path, mode="standard")
+    return serialize_workbook(workbook, fmt="json", pretty=True, indent=2) + "\n"
+
+
+def _diff(expected: str, actual: str, golden: Path) -> str:
+    lines = list(
+        difflib.unified_diff(
+            expected.splitlines(),
+            actual.splitlines(),
+            fromfile=golden.name,
+            tofile="actual",
+            lineterm="",
+        )
+    )
+    if len(lines) > _MAX_DIFF_LINES:
+        omitted = len(lines) - _MAX_DIFF_LINES
+        lines = [*lines[:_MAX_DIFF_LINES], f"... ({omitted} more lines)"]
+    return "\n".join(lines)
+
+
+def test_fixtures_are_present() -> None:
+    assert _fixtures(), f"no fixture workbooks in {_TESTDATA}"
+
+
+@pytest.mark.parametrize("path", _fixtures(), ids=lambda path: path.stem)
+def test_extraction_matches_golden(path: Path, request: pytest.FixtureRequest) -> None:
+    golden = path.with_suffix(".golden.json")
+    actual = _render(path)
+
+    if request.config.getoption("--update-golden"):
+        golden.write_text(actual, encoding="utf-8", newline="\n")
+        return
+    if not golden.exists():
+        pytest.skip(f"{golden.name} is missing; run with --update-golden to create it")
+    expected = golden.read_text(encoding="utf-8")
+    assert actual == expected, (
+        f"{path.name} output differs from its golden file "
+        f"(rerun with --update-golden if intended):\n{_diff(expected, actual, golden)}"
+    )
@@ -0,0 +1,65 @@
+"""Tests for flat per-cell event output."""
+
+from __future__ import annotations
+
+import json
+from pathlib import Path
+
+import pytest
+
+from exstruct.engine import ExStructEngine
+from exstruct.errors import ConfigError
+from exstruct.io import serialize_workbook
+from exstruct.io.events import iter_cell_events
+from exstruct.models import CellRow, SheetData, WorkbookData
+
+
+def _workbook() -> WorkbookData:
+    sheet = SheetData(
+        rows=[
+            CellRow(r=2, c={"1": 1.5, "0": "名前"}, links={"0": "https://e.com"}),
+            CellRow(r=1, c={"0": 10}),
+        ]
+    )
+    return WorkbookData(book_name="book.xlsx", sheets={"Data": sheet})
+
+
+def test_iter_cell_events_orders_and_flattens_cells() -> None:
+    events = list(iter_cell_events(_workbook()))
+
+    assert [event["a1"] for event in events] == ["A1", "A2", "B2"]
+    assert events[0] == {
+        "book": "book.xlsx",
+        "sheet": "Data",
+        "row": 1,
+        "col": 0,
+        "a1": "A1",
+        "value": "10",
+        "type": "int",
+    }
+    assert events[1]["link"] == "https://e.com"
+    assert events[2]["type"] == "float"
+
+
+def test_iter_cell_events_accepts_alpha_keys() -> None:
+    sheet = SheetData(rows=[CellRow(r=3, c={"AA": "x"})])
+    workbook = WorkbookData(book_name="b.xlsx", sheets={"S": sheet})
+
+    (event,) = iter_cell_events(workbook)
+
+    assert (event["col"], event["a1"]) == (26, "AA3")
+
+
+def test_serialize_workbook_events_is_ndjson() -> None:
+    text = serialize_workbook(_workbook(), fmt="events")
+
+    lines = text.splitlines()
+    assert text.endswith("\n")
+    assert [json.loads(line)["value"] for line in lines] == ["10", "名前", "1.5"]
+
+
+def test_engine_rejects_events_with_side_outputs(tmp_path: Path) -> None:
+    with pytest.raises(ConfigError):
+        ExStructEngine().export(
+            _workbook(), fmt="events", sheets_dir=tmp_path / "sheets"
+        )
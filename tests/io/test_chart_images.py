@@ -0,0 +1,126 @@
+"""Tests for chart preview image rendering."""
+
+from __future__ import annotations
+
+import json
+from pathlib import Path
+
+import pytest
+
+from exstruct.errors import SerializationError
+from exstruct.io.chart_images import (
+    chart_scene,
+    plot_series,
+    render_svg,
+    resolve_range_values,
+    save_chart_images,
+)
+from exstruct.models import CellRow, Chart, ChartSeries, SheetData, WorkbookData
+
+
+def _chart(name: str, chart_type: str, series: list[ChartSeries]) -> Chart:
+    return Chart(
+        name=name,
+        chart_type=chart_type,
+        title=f"{name} & more",
+        y_axis_title="",
+        series=series,
+        l=0,
+        t=0,
+    )
+
+
+def _workbook() -> WorkbookData:
+    data = SheetData(
+        rows=[
+            CellRow(r=1, c={"0": "x", "1": "y"}),
+            CellRow(r=2, c={"0": 1, "1": 10}),
+            CellRow(r=3, c={"0": 2, "1": "1,200"}),
+            CellRow(r=4, c={"0": 4}),
+        ]
+    )
+    report = SheetData(
+        charts=[
+            _chart(
+                "Sales",
+                "Column",
+                [ChartSeries(name="y", y_range="Data!$B$2:$B$4")],
+            ),
+            _chart(
+                "Share",
+                "Pie",
+                [ChartSeries(name="share", values=[1.0, 3.0])],
+            ),
+        ]
+    )
+    return WorkbookData(
+        book_name="book.xlsx", sheets={"Data": data, "Report": report}
+    )
+
+
+def test_resolve_range_values_reads_extracted_cells() -> None:
+    workbook = _workbook()
+
+    values = resolve_range_values(workbook, "Report", "Data!$B$2:$B$4")
+
+    assert values == [10, "1,200", None]
+    assert resolve_range_values(workbook, "Report", "Missing!A1:A2") == [None, None]
+
+
+def test_plot_series_prefers_cache_and_parses_text_numbers() -> None:
+    workbook = _workbook()
+    cached = ChartSeries(name="c", values=[5.0], y_range="Data!$B$2:$B$4")
+    scatter = ChartSeries(name="s", x_range="Data!$A$2:$A$4", y_range="Data!B2:B4")
+
+    assert plot_series(workbook, "Data", cached).values == [5.0]
+    resolved = plot_series(workbook, "Data", scatter)
+    assert resolved.values == [10.0, 1200.0, None]
+    assert resolved.x_values == [1.0, 2.0, 4.0]
+
+
+def test_render_svg_draws_bars_and_escapes_title() -> None:
+    workbook = _workbook()
+    chart = workbook.sheets["Report"].charts[0]
+    series = [plot_series(workbook, "Report", s) for s in chart.series]
+
+    svg = render_svg(chart_scene(chart, series))
+
+    assert svg.startswith("<svg ")
+    assert svg.count("<rect ") == 3  # background + two bars
+    assert "Sales &amp; more" in svg
+
+
+def test_save_chart_images_writes_files_and_index(tmp_path: Path) -> None:
+    written = save_chart_images(_workbook(), tmp_path)
+
+    assert sorted(p.name for p in written.values()) == [
+        "Report_Sales.svg",
+        "Report_Share.svg",
+    ]
+    assert "<path " in written["Report/Share"].read_text(encoding="utf-8")
+    index = json.loads((tmp_path / "index.json").read_text(encoding="utf-8"))
+    assert index["charts"][0] == {
+        "sheet_name": "Report",
+        "chart_name": "Sales",
+        "file": "Report_Sales.svg",
+    }
+
+
+def test_save_chart_images_skips_workbooks_without_charts(tmp_path: Path) -> None:
+    workbook = WorkbookData(book_name="b.xlsx", sheets={"S": SheetData()})
+
+    assert save_chart_images(workbook, tmp_path / "out") == {}
+    assert not (tmp_path / "out").exists()
+
+
+def test_save_chart_images_rejects_unknown_format(tmp_path: Path) -> None:
+    with pytest.raises(SerializationError):
+        save_chart_images(_workbook(), tmp_path, fmt="gif")  # type: ignore[arg-type]
+
+
+def test_save_chart_images_png(tmp_path: Path) -> None:
+    pytest.importorskip("PIL")
+
+    written = save_chart_images(_workbook(), tmp_path, fmt="png")
+
+    assert written["Report/Sales"].read_bytes().startswith(b"\x89PNG")
@@ -0,0 +1,79 @@
+import json
+from pathlib import Path
+
+from exstruct.io import save_charts, save_shapes
+from exstruct.models import Chart, Shape, SheetData, WorkbookData
+
+
+def _workbook() -> WorkbookData:
+    chart = Chart(
+        name="c1",
+        chart_type="Line",
+        title=None,
+        y_axis_title="",
+        y_axis_range=[],
+        w=50,
+        h=30,
+        series=[],
+        l=0,
+        t=0,
+        error=None,
+    )
+    return WorkbookData(
+        book_name="book.xlsx",
+        sheets={
+            "Sheet1": SheetData(
+                shapes=[
+                    Shape(
+                        id=1,
+                        text="box",
+                        l=10,
+                        t=5,
+                        w=20,
+                        h=10,
+                        type="Rect",
+                        provenance="excel_com",
+                    )
+                ],
+                charts=[chart],
+            ),
+            "Data/2024": SheetData(
+                shapes=[Shape(id=2, text="note", l=1, t=1, w=5, h=5, type="Rect")]
+            ),
+            "Empty": SheetData(),
+        },
+    )
+
+
+def test_save_shapes_writes_one_file_per_sheet(tmp_path: Path) -> None:
+    written = save_shapes(_workbook(), tmp_path)
+    assert set(written) == {"Sheet1", "Data/2024"}
+    payload = json.loads(written["Sheet1"].read_text(encoding="utf-8"))
+    assert payload["book_name"] == "book.xlsx"
+    assert payload["sheet_name"] == "Sheet1"
+    assert payload["shapes"][0]["text"] == "box"
+    assert "charts" not in payload
+    assert written["Data/2024"].parent == tmp_path
+
+
+def test_save_shapes_strips_backend_metadata_by_default(tmp_path: Path) -> None:
+    written = save_shapes(_workbook(), tmp_path)
+    shape = json.loads(written["Sheet1"].read_text(encoding="utf-8"))["shapes"][0]
+    assert "provenance" not in shape
+    kept = save_shapes(_workbook(), tmp_path, include_backend_metadata=True)
+    shape = json.loads(kept["Sheet1"].read_text(encoding="utf-8"))["shapes"][0]
+    assert shape["provenance"] == "excel_com"
+
+
+def test_save_charts_skips_sheets_without_charts(tmp_path: Path) -> None:
+    written = save_charts(_workbook(), tmp_path, pretty=True)
+    assert list(written) == ["Sheet1"]
+    payload = json.loads(written["Sheet1"].read_text(encoding="utf-8"))
+    assert payload["charts"][0]["name"] == "c1"
+    assert "shapes" not in payload
+
+
+def test_save_components_returns_empty_when_nothing_to_write(tmp_path: Path) -> None:
+    workbook = WorkbookData(book_name="book.xlsx", sheets={"Empty": SheetData()})
+    assert save_charts(workbook, tmp_path / "charts") == {}
+    assert not (tmp_path / "charts").exists()
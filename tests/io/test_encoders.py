@@ -0,0 +1,140 @@
+"""Tests for the custom output encoder registry."""
+
+from __future__ import annotations
+
+from collections.abc import Iterator
+import gzip
+import io
+from pathlib import Path
+
+from openpyxl import Workbook
+import pytest
+
+from exstruct.cli.main import main as cli_main
+from exstruct.encoders import (
+    ByteWriter,
+    Encoder,
+    register_encoder,
+    registered_encoders,
+    unregister_encoder,
+)
+from exstruct.engine import ExStructEngine, FormatOptions, OutputOptions
+from exstruct.errors import ConfigError, OutputError, SerializationError
+from exstruct.models import CellRow, SheetData, WorkbookData
+
+
+class _SheetNamesEncoder:
+    name = "names"
+
+    def encode(self, output: ByteWriter, workbook: WorkbookData) -> None:
+        output.write("\n".join(workbook.sheets).encode("utf-8"))
+
+
+class _BinaryEncoder:
+    name = "binary"
+
+    def encode(self, output: ByteWriter, workbook: WorkbookData) -> None:
+        output.write(b"\xff\x00")
+
+
+class _FailingEncoder:
+    name = "broken"
+
+    def encode(self, output: ByteWriter, workbook: WorkbookData) -> None:
+        output.write(b"partial")
+        raise RuntimeError("boom")
+
+
+@pytest.fixture(autouse=True)
+def _clean_registry() -> Iterator[None]:
+    yield
+    for encoder in registered_encoders():
+        unregister_encoder(encoder.name)
+
+
+def _workbook() -> WorkbookData:
+    return WorkbookData(
+        book_name="b.xlsx",
+        sheets={
+            "One": SheetData(rows=[CellRow(r=1, c={"0": "a"})]),
+            "Two": SheetData(),
+        },
+    )
+
+
+def _engine(fmt: str, *, jq: str | None = None) -> ExStructEngine:
+    return ExStructEngine(output=OutputOptions(format=FormatOptions(fmt=fmt, jq=jq)))
+
+
+def test_register_rejects_builtin_and_duplicate_names() -> None:
+    assert isinstance(_SheetNamesEncoder(), Encoder)
+    register_encoder(_SheetNamesEncoder())
+
+    with pytest.raises(ValueError, match="already registered"):
+        register_encoder(_SheetNamesEncoder())
+    register_encoder(_SheetNamesEncoder(), replace=True)
+
+    builtin = _SheetNamesEncoder()
+    builtin.name = "JSON"
+    with pytest.raises(ValueError, match="built-in format"):
+        register_encoder(builtin)
+
+
+def test_encoder_writes_files_and_streams(tmp_path: Path) -> None:
+    register_encoder(_SheetNamesEncoder())
+    engine = _engine("names")
+
+    out = tmp_path / "out.txt.gz"
+    engine.export(_workbook(), out)
+    assert gzip.decompress(out.read_bytes()) == b"One\nTwo"
+
+    stream = io.StringIO()
+    engine.export(_workbook(), stream=stream)
+    assert stream.getvalue() == "One\nTwo"
+
+
+def test_encoder_errors_keep_previous_output(tmp_path: Path) -> None:
+    register_encoder(_FailingEncoder())
+    register_encoder(_BinaryEncoder())
+    out = tmp_path / "out.bin"
+    out.write_bytes(b"previous")
+
+    with pytest.raises(OutputError):
+        _engine("broken").export(_workbook(), out)
+    assert out.read_bytes() == b"previous"
+    assert list(tmp_path.iterdir()) == [out]
+
+    with pytest.raises(SerializationError, match="binary"):
+        _engine("binary").export(_workbook(), stream=io.StringIO())
+
+
+def test_encoder_rejects_payload_transforms_and_side_outputs(tmp_path: Path) -> None:
+    register_encoder(_SheetNamesEncoder())
+
+    with pytest.raises(ConfigError, match="names output"):
+        _engine("names", jq=".").export(_workbook(), tmp_path / "out.txt")
+    with pytest.raises(ConfigError, match="per-sheet"):
+        _engine("names").export(
+            _workbook(), tmp_path / "out.txt", sheets_dir=tmp_path / "sheets"
+        )
+
+
+def test_cli_accepts_registered_format(tmp_path: Path) -> None:
+    register_encoder(_SheetNamesEncoder())
+    wb = Workbook()
+    ws = wb.active
+    assert ws is not None
+    ws.title = "Data"
+    ws["A1"] = "x"
+    path = tmp_path / "book.xlsx"
+    wb.save(path)
+    out = tmp_path / "out.txt"
+
+    code = cli_main([str(path), "--mode", "light", "-f", "names", "-o", str(out)])
+
+    assert code == 0
+    assert out.read_text(encoding="utf-8") == "Data"
+    unregister_encoder("names")
+    with pytest.raises(SystemExit) as exc_info:
+        cli_main([str(path), "-f", "names", "-o", str(out)])
+    assert exc_info.value.code == 2
@@ -0,0 +1,93 @@
+"""Tests for --fields projections over the output payload."""
+
+from __future__ import annotations
+
+import json
+from pathlib import Path
+
+from openpyxl import Workbook
+import pytest
+
+from exstruct.cli.main import main as cli_main
+from exstruct.engine import ExStructEngine, FormatOptions, OutputOptions
+from exstruct.errors import ConfigError, SerializationError
+from exstruct.io import serialize_workbook
+from exstruct.models import CellRow, Chart, ChartSeries, SheetData, WorkbookData
+
+
+def _workbook() -> WorkbookData:
+    chart = Chart(
+        name="c1",
+        chart_type="Line",
+        title="Sales",
+        y_axis_title="",
+        series=[ChartSeries(name="s1", y_range="Sheet1!B2:B5")],
+        l=0,
+        t=0,
+    )
+    return WorkbookData(
+        book_name="b.xlsx",
+        sheets={
+            "Sheet1": SheetData(
+                rows=[CellRow(r=1, c={"0": "a"}, links={"0": "https://x"})],
+                charts=[chart],
+                print_areas=[],
+            )
+        },
+        warnings=["skipped part"],
+    )
+
+
+def _project(fields: list[str]) -> dict[str, object]:
+    return json.loads(serialize_workbook(_workbook(), fields=fields))
+
+
+def test_include_keeps_only_listed_sections() -> None:
+    payload = _project(["charts"])
+
+    assert set(payload) == {"book_name", "sheets"}
+    assert set(payload["sheets"]["Sheet1"]) == {"charts"}
+
+
+def test_exclude_and_field_trimming() -> None:
+    payload = _project(["-rows", "-charts.series"])
+
+    sheet = payload["sheets"]["Sheet1"]
+    assert "rows" not in sheet
+    assert "series" not in sheet["charts"][0]
+    assert sheet["charts"][0]["title"] == "Sales"
+    assert payload["warnings"] == ["skipped part"]
+
+    rows = _project(["rows.r"])["sheets"]["Sheet1"]["rows"]
+    assert rows == [{"r": 1}]
+
+
+def test_unknown_section_is_rejected() -> None:
+    with pytest.raises(SerializationError, match="Unknown field 'cels'"):
+        _project(["cels"])
+    with pytest.raises(SerializationError, match="field projections"):
+        serialize_workbook(_workbook(), fmt="text", fields=["rows"])
+
+
+def test_fields_engine_and_cli(tmp_path: Path) -> None:
+    engine = ExStructEngine(output=OutputOptions(format=FormatOptions(fields=["rows"])))
+    assert set(json.loads(engine.serialize(_workbook()))["sheets"]["Sheet1"]) == {
+        "rows"
+    }
+    with pytest.raises(ConfigError):
+        engine.export(_workbook(), tmp_path / "out.sqlite", fmt="sqlite")
+
+    wb = Workbook()
+    ws = wb.active
+    assert ws is not None
+    ws.title = "Data"
+    ws["A1"] = "x"
+    path = tmp_path / "book.xlsx"
+    wb.save(path)
+    out = tmp_path / "out.json"
+
+    code = cli_main([str(path), "--mode", "light", "--fields=rows.r", "-o", str(out)])
+
+    assert code == 0
+    sheet = json.loads(out.read_text(encoding="utf-8"))["sheets"]["Data"]
+    assert sheet == {"rows": [{"r": 1}]}
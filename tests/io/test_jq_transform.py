@@ -0,0 +1,103 @@
+"""Tests for jq output expressions and workbook transforms."""
+
+from __future__ import annotations
+
+import json
+from pathlib import Path
+import sys
+
+import pytest
+
+from exstruct.engine import (
+    ExStructEngine,
+    FormatOptions,
+    OutputOptions,
+    StructOptions,
+)
+from exstruct.errors import (
+    ConfigError,
+    ExtractionError,
+    MissingDependencyError,
+    SerializationError,
+)
+from exstruct.io import serialize_workbook
+from exstruct.models import CellRow, SheetData, WorkbookData
+
+
+def _workbook() -> WorkbookData:
+    return WorkbookData(
+        book_name="b.xlsx",
+        sheets={
+            "One": SheetData(rows=[CellRow(r=1, c={"0": "secret", "1": 2})]),
+            "Two": SheetData(rows=[CellRow(r=1, c={"0": 3})]),
+        },
+    )
+
+
+def test_serialize_workbook_applies_jq() -> None:
+    pytest.importorskip("jq")
+
+    text = serialize_workbook(_workbook(), jq="[.sheets | keys[]]")
+    assert json.loads(text) == ["One", "Two"]
+
+    text = serialize_workbook(_workbook(), jq=".sheets[] | .rows[0].r")
+    assert json.loads(text) == [1, 1]
+
+
+def test_serialize_workbook_reports_invalid_jq() -> None:
+    pytest.importorskip("jq")
+
+    with pytest.raises(SerializationError, match="jq expression failed"):
+        serialize_workbook(_workbook(), jq=".sheets[")
+
+
+def test_serialize_workbook_jq_requires_package(
+    monkeypatch: pytest.MonkeyPatch,
+) -> None:
+    monkeypatch.setitem(sys.modules, "jq", None)
+
+    with pytest.raises(MissingDependencyError, match="'jq' extra"):
+        serialize_workbook(_workbook(), jq=".")
+
+
+def test_jq_rejected_for_non_payload_formats(tmp_path: Path) -> None:
+    with pytest.raises(SerializationError):
+        serialize_workbook(_workbook(), fmt="text", jq=".")
+
+    engine = ExStructEngine(output=OutputOptions(format=FormatOptions(jq=".")))
+    with pytest.raises(ConfigError):
+        engine.export(_workbook(), tmp_path / "out.sqlite", fmt="sqlite")
+
+
+def test_engine_runs_transforms_in_order(monkeypatch: pytest.MonkeyPatch) -> None:
+    def fake_extract(path: Path, **_kwargs: object) -> WorkbookData:
+        return _workbook()
+
+    def redact(workbook: WorkbookData) -> None:
+        for sheet in workbook.sheets.values():
+            for row in sheet.rows:
+                row.c = {key: "***" for key in row.c}
+
+    def drop_two(workbook: WorkbookData) -> WorkbookData:
+        return workbook.model_copy(update={"sheets": {"One": workbook.sheets["One"]}})
+
+    monkeypatch.setattr("exstruct.engine.extract_workbook", fake_extract)
+    engine = ExStructEngine(options=StructOptions(transforms=(redact, drop_two)))
+
+    workbook = engine.extract("book.xlsx")
+
+    assert list(workbook.sheets) == ["One"]
+    assert workbook.sheets["One"].rows[0].c == {"0": "***", "1": "***"}
+
+
+def test_engine_wraps_transform_failures(monkeypatch: pytest.MonkeyPatch) -> None:
+    def broken(_workbook: WorkbookData) -> None:
+        raise RuntimeError("boom")
+
+    monkeypatch.setattr(
+        "exstruct.engine.extract_workbook", lambda path, **_kwargs: _workbook()
+    )
+    engine = ExStructEngine(options=StructOptions(transforms=(broken,)))
+
+    with pytest.raises(ExtractionError, match="Transform 'broken' failed"):
+        engine.extract("book.xlsx")
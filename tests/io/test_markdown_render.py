@@ -0,0 +1,90 @@
+"""Tests for Markdown document rendering."""
+
+from __future__ import annotations
+
+import pytest
+
+from exstruct.errors import SerializationError
+from exstruct.io import serialize_workbook
+from exstruct.io.markdown import render_sheet_markdown
+from exstruct.models import (
+    CellRow,
+    Chart,
+    ChartSeries,
+    MergedCells,
+    Shape,
+    SheetData,
+    SmartArt,
+    SmartArtNode,
+    WorkbookData,
+)
+
+
+def _sheet() -> SheetData:
+    return SheetData(
+        rows=[
+            CellRow(r=1, c={"0": "Order Spec"}),
+            CellRow(r=2, c={"0": "Owner", "1": "Sales"}),
+            CellRow(r=3, c={"0": "Updated", "1": "2024-04-01"}),
+            CellRow(r=5, c={"0": "Items"}),
+            CellRow(r=6, c={"0": "name", "1": "qty"}),
+            CellRow(r=7, c={"0": "a|b", "1": 2}),
+            CellRow(r=8, c={"0": "c", "1": 3.5}),
+            CellRow(r=10, c={"0": "Line one\nLine two"}),
+        ],
+        table_candidates=["A6:B8"],
+        merged_cells=MergedCells(items=[(1, 0, 1, 3, "Order Spec")]),
+        shapes=[
+            Shape(id=1, text="Check\nqty", l=0, t=0, covered_range="D7:E8"),
+            Shape(id=2, text="", l=0, t=0),
+            SmartArt(
+                id=3,
+                text="",
+                l=0,
+                t=0,
+                layout="Process",
+                nodes=[SmartArtNode(text="Draft"), SmartArtNode(text="Review")],
+            ),
+        ],
+        charts=[
+            Chart(
+                name="c1",
+                chart_type="Bar",
+                title="Qty",
+                y_axis_title="",
+                series=[ChartSeries(name="qty", y_range="Spec!$B$7:$B$8")],
+                l=0,
+                t=0,
+            )
+        ],
+    )
+
+
+def test_render_sheet_markdown_walks_top_to_bottom() -> None:
+    text = render_sheet_markdown("Spec", _sheet())
+
+    assert text.split("\n\n") == [
+        "# Spec",
+        "## Order Spec",
+        "Owner | Sales  \nUpdated | 2024-04-01",
+        "### Items",
+        "| name | qty |\n| --- | --- |\n| a\\|b | 2 |\n| c | 3.5 |",
+        "> [!NOTE]\n> Check\n> qty",
+        "Line one<br>Line two",
+        "> [!NOTE]\n> Process:\n> - Draft\n> - Review",
+        '*Chart c1 "Qty" (Bar): qty (Spec!$B$7:$B$8)*\n',
+    ]
+
+
+def test_serialize_workbook_markdown_format() -> None:
+    workbook = WorkbookData(
+        book_name="b.xlsx",
+        sheets={
+            "One": SheetData(rows=[CellRow(r=1, c={"0": "x"})]),
+            "Two": SheetData(),
+        },
+    )
+
+    assert serialize_workbook(workbook, fmt="markdown") == "# One\n\nx\n\n# Two\n"
+    with pytest.raises(SerializationError):
+        serialize_workbook(workbook, fmt="markdown", query="$")
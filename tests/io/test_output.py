@@ -0,0 +1,155 @@
+"""Tests for compressed and split output files."""
+
+from __future__ import annotations
+
+import gzip
+import hashlib
+import json
+from pathlib import Path
+import sys
+
+import pytest
+
+from exstruct.cli.main import build_parser, main as cli_main
+from exstruct.errors import LimitExceededError, MissingDependencyError, OutputError
+from exstruct.io.output import (
+    atomic_write,
+    detect_compression,
+    manifest_path,
+    parse_size,
+    part_path,
+    split_utf8,
+    strip_compression_suffix,
+    write_output_json,
+    write_output_text,
+)
+
+
+def test_compression_suffix_helpers() -> None:
+    assert detect_compression(Path("out.json.gz")) == "gzip"
+    assert detect_compression(Path("out.json.ZST")) == "zstd"
+    assert detect_compression(Path("out.json")) is None
+    assert strip_compression_suffix(Path("out.json.gz")) == Path("out.json")
+    assert part_path(Path("d/out.json.gz"), 2) == Path("d/out.part002.json.gz")
+    assert part_path(Path("out.yaml"), 12, width=4) == Path("out.part0012.yaml")
+    assert manifest_path(Path("out.json.gz")) == Path("out.manifest.json")
+
+
+@pytest.mark.parametrize(
+    ("text", "expected"),
+    [("1024", 1024), ("512K", 512 * 1024), ("100m", 100 * 1024**2), ("2GB", 2 << 30)],
+)
+def test_parse_size(text: str, expected: int) -> None:
+    assert parse_size(text) == expected
+
+
+@pytest.mark.parametrize("text", ["", "0", "-1", "10X", "1.5M"])
+def test_parse_size_rejects_invalid(text: str) -> None:
+    with pytest.raises(ValueError):
+        parse_size(text)
+
+
+def test_split_utf8_keeps_characters_whole() -> None:
+    data = "aあいb".encode()
+    chunks = split_utf8(data, 4)
+    assert b"".join(chunks) == data
+    assert [chunk.decode("utf-8") for chunk in chunks] == ["aあ", "いb"]
+    assert split_utf8("あ".encode(), 1) == ["あ".encode()]
+
+
+def test_write_output_text_gzip(tmp_path: Path) -> None:
+    path = tmp_path / "out.json.gz"
+    assert write_output_text(path, '{"a": 1}') == [path]
+    assert gzip.decompress(path.read_bytes()) == b'{"a": 1}'
+
+
+def test_write_output_text_splits_with_manifest(tmp_path: Path) -> None:
+    path = tmp_path / "out.json.gz"
+    text = json.dumps({"rows": list(range(200))})
+
+    written = write_output_text(path, text, split_size=100)
+
+    manifest = json.loads(manifest_path(path).read_text(encoding="utf-8"))
+    parts = [tmp_path / part["file"] for part in manifest["parts"]]
+    assert written == [*parts, manifest_path(path)]
+    assert not path.exists()
+    assert manifest["compression"] == "gzip"
+    assert manifest["total_uncompressed_size"] == len(text)
+    assert all(part["uncompressed_size"] <= 100 for part in manifest["parts"])
+    assert manifest["parts"][0]["sha256"] == (
+        hashlib.sha256(parts[0].read_bytes()).hexdigest()
+    )
+    joined = b"".join(p.read_bytes() for p in parts)
+    assert gzip.decompress(joined).decode("utf-8") == text
+
+
+def test_write_output_text_zstd_requires_dependency(
+    tmp_path: Path, monkeypatch: pytest.MonkeyPatch
+) -> None:
+    monkeypatch.setitem(sys.modules, "zstandard", None)
+    with pytest.raises(MissingDependencyError, match="'zstd' extra"):
+        write_output_text(tmp_path / "out.json.zst", "{}")
+
+
+def test_atomic_write_keeps_previous_file_on_failure(tmp_path: Path) -> None:
+    path = tmp_path / "out.json"
+    path.write_text("old", encoding="utf-8")
+
+    with pytest.raises(RuntimeError), atomic_write(path) as handle:
+        handle.write(b"partial")
+        raise RuntimeError("boom")
+
+    assert path.read_text(encoding="utf-8") == "old"
+    assert list(tmp_path.iterdir()) == [path]
+
+
+def test_write_output_json_streams_payload(tmp_path: Path) -> None:
+    payload = {"rows": [{"v": "あ" * 10, "n": i} for i in range(20000)]}
+    plain = tmp_path / "out.json"
+    packed = tmp_path / "out.json.gz"
+
+    assert write_output_json(plain, payload, indent=2) == [plain]
+    write_output_json(packed, payload)
+
+    assert json.loads(plain.read_text(encoding="utf-8")) == payload
+    assert plain.read_text(encoding="utf-8") == json.dumps(
+        payload, ensure_ascii=False, indent=2
+    )
+    assert json.loads(gzip.decompress(packed.read_bytes())) == payload
+    assert sorted(tmp_path.iterdir()) == [plain, packed]
+
+
+def test_write_output_json_limit_leaves_no_partial_file(tmp_path: Path) -> None:
+    path = tmp_path / "out.json"
+    path.write_text("old", encoding="utf-8")
+    payload = {"rows": list(range(100000))}
+
+    with pytest.raises(LimitExceededError, match="max_output_bytes=1000"):
+        write_output_json(path, payload, max_bytes=1000)
+
+    assert path.read_text(encoding="utf-8") == "old"
+    assert list(tmp_path.iterdir()) == [path]
+
+
+def test_write_output_json_wraps_io_errors(tmp_path: Path) -> None:
+    with pytest.raises(OutputError):
+        write_output_json(tmp_path / "missing" / "out.json", {"a": 1})
+
+
+def test_cli_parses_split_size() -> None:
+    args = build_parser().parse_args(
+        ["book.xlsx", "-o", "out.json", "--split-size", "1M"]
+    )
+    assert args.split_size == 1024**2
+
+
+def test_cli_split_size_requires_output(
+    tmp_path: Path, capsys: pytest.CaptureFixture[str]
+) -> None:
+    book = tmp_path / "book.xlsx"
+    book.write_bytes(b"")
+
+    code = cli_main(argv=[str(book), "--split-size", "10K"])
+
+    assert code == 1
+    assert "--split-size requires --output" in capsys.readouterr().out
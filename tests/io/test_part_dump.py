@@ -0,0 +1,120 @@
+"""Tests for the raw OOXML part dump (``--dump-parts``)."""
+
+from __future__ import annotations
+
+from pathlib import Path
+import zipfile
+
+import pytest
+
+from exstruct import process_excel
+from exstruct.cli.main import main as cli_main
+from exstruct.io.parts import dump_parts
+
+_PARTS = {
+    "[Content_Types].xml": b"<Types/>",
+    "xl/workbook.xml": b"<workbook/>",
+    "xl/drawings/drawing1.xml": b"<wsDr/>",
+    "xl/drawings/_rels/drawing1.xml.rels": b"<Relationships/>",
+    "xl/charts/chart1.xml": b"<chartSpace/>",
+    "xl/tables/table1.xml": b"<table/>",
+    "xl/worksheets/sheet1.xml": b"<worksheet/>",
+}
+
+
+def _package(tmp_path: Path, extra: dict[str, bytes] | None = None) -> Path:
+    path = tmp_path / "book.xlsx"
+    with zipfile.ZipFile(path, "w") as zf:
+        for name, data in {**_PARTS, **(extra or {})}.items():
+            zf.writestr(name, data)
+    return path
+
+
+def test_dump_parts_keeps_layout(tmp_path: Path) -> None:
+    out = tmp_path / "parts"
+
+    written = dump_parts(_package(tmp_path), out)
+
+    assert sorted(written) == [
+        "xl/charts/chart1.xml",
+        "xl/drawings/_rels/drawing1.xml.rels",
+        "xl/drawings/drawing1.xml",
+        "xl/tables/table1.xml",
+    ]
+    assert (out / "charts" / "chart1.xml").read_bytes() == b"<chartSpace/>"
+    assert (out / "drawings" / "_rels" / "drawing1.xml.rels").exists()
+    assert not (out / "worksheets").exists()
+
+
+def test_dump_parts_selected_kinds(tmp_path: Path) -> None:
+    written = dump_parts(_package(tmp_path), tmp_path / "parts", kinds=("charts",))
+
+    assert list(written) == ["xl/charts/chart1.xml"]
+
+
+def test_dump_parts_skips_unsafe_names(tmp_path: Path) -> None:
+    path = _package(
+        tmp_path,
+        {
+            "xl/charts/../../../escape.xml": b"<x/>",
+            "xl/charts/a:b*c.xml": b"<y/>",
+        },
+    )
+    out = tmp_path / "nested" / "parts"
+
+    written = dump_parts(path, out)
+
+    assert "xl/charts/../../../escape.xml" not in written
+    assert not (tmp_path / "escape.xml").exists()
+    assert written["xl/charts/a:b*c.xml"] == out / "charts" / "a_b_c.xml"
+
+
+def test_dump_parts_skips_non_ooxml(
+    tmp_path: Path, caplog: pytest.LogCaptureFixture
+) -> None:
+    path = tmp_path / "book.xls"
+    path.write_bytes(b"\xd0\xcf\x11\xe0 not a zip")
+
+    assert dump_parts(path, tmp_path / "parts") == {}
+    assert "Not an OOXML package" in caplog.text
+    assert not (tmp_path / "parts").exists()
+
+
+def test_process_excel_and_cli_dump_parts(tmp_path: Path) -> None:
+    from openpyxl import Workbook
+    from openpyxl.chart import BarChart, Reference
+
+    wb = Workbook()
+    ws = wb.active
+    assert ws is not None
+    for row in (["x", "y"], [1, 2], [2, 4]):
+        ws.append(row)
+    chart = BarChart()
+    chart.add_data(Reference(ws, min_col=2, min_row=1, max_row=3))
+    ws.add_chart(chart, "D2")
+    path = tmp_path / "chart.xlsx"
+    wb.save(path)
+
+    process_excel(
+        path,
+        output_path=tmp_path / "out.json",
+        mode="light",
+        dump_parts_dir=tmp_path / "api_parts",
+    )
+    code = cli_main(
+        [
+            str(path),
+            "--mode",
+            "light",
+            "-o",
+            str(tmp_path / "cli.json"),
+            "--dump-parts",
+            str(tmp_path / "cli_parts"),
+        ]
+    )
+
+    assert code == 0
+    for parts_dir in (tmp_path / "api_parts", tmp_path / "cli_parts"):
+        assert (parts_dir / "charts" / "chart1.xml").exists()
+        assert (parts_dir / "drawings" / "drawing1.xml").exists()
+    assert (tmp_path / "out.json").exists()
@@ -0,0 +1,103 @@
+"""Tests for protobuf encoding against schemas/exstruct.proto."""
+
+from __future__ import annotations
+
+from pathlib import Path
+import re
+
+from exstruct.io.protobuf import (
+    PROTO_SCHEMA,
+    ProtoField,
+    encode_message,
+    save_as_protobuf,
+    to_protobuf,
+)
+from exstruct.models import Arrow, CellRow, Shape, SheetData, WorkbookData
+
+_PROTO_PATH = Path(__file__).resolve().parents[2] / "schemas" / "exstruct.proto"
+_MESSAGE = re.compile(r"^message (\w+) \{$")
+_FIELD = re.compile(r"^(optional |repeated )?(\w+) (\w+) = (\d+);$")
+
+
+def _parse_proto(text: str) -> dict[str, tuple[ProtoField, ...]]:
+    messages: dict[str, list[ProtoField]] = {}
+    current: list[ProtoField] = []
+    for raw in text.splitlines():
+        line = raw.strip()
+        if match := _MESSAGE.match(line):
+            current = messages.setdefault(match.group(1), [])
+        elif match := _FIELD.match(line):
+            label, field_type, name, number = match.groups()
+            current.append(
+                ProtoField(name, int(number), field_type, label == "repeated ")
+            )
+    return {name: tuple(fields) for name, fields in messages.items()}
+
+
+def _read_varint(data: bytes, pos: int) -> tuple[int, int]:
+    result = shift = 0
+    while True:
+        byte = data[pos]
+        pos += 1
+        result |= (byte & 0x7F) << shift
+        shift += 7
+        if not byte & 0x80:
+            return result, pos
+
+
+def _top_level_fields(data: bytes) -> list[tuple[int, bytes]]:
+    """Split length-delimited top-level fields of a message."""
+    fields: list[tuple[int, bytes]] = []
+    pos = 0
+    while pos < len(data):
+        tag, pos = _read_varint(data, pos)
+        assert tag & 0x07 == 2
+        length, pos = _read_varint(data, pos)
+        fields.append((tag >> 3, data[pos : pos + length]))
+        pos += length
+    return fields
+
+
+def test_schema_matches_proto_file() -> None:
+    parsed = _parse_proto(_PROTO_PATH.read_text(encoding="utf-8"))
+    assert parsed == PROTO_SCHEMA
+
+
+def test_encode_message_wire_format() -> None:
+    row = {"r": 1, "c": [{"key": "0", "value": {"int_value": 150}}], "ignored": 1}
+    assert encode_message("CellRow", row).hex() == "080112080a01301203089601"
+
+    chart = {"name": "c", "y_axis_range": [1.0, 2.0], "l": -1, "title": None}
+    assert encode_message("Chart", chart).hex() == (
+        "0a0163"
+        "2a10000000000000f03f0000000000000040"
+        "48ffffffffffffffffff01"
+    )
+
+
+def test_to_protobuf_encodes_sheets_in_order(tmp_path: Path) -> None:
+    sheet = SheetData(
+        rows=[CellRow(r=1, c={"0": "あ", "1": 1.5})],
+        shapes=[
+            Shape(id=1, text="box", l=0, t=0, type="Rect"),
+            Arrow(id=2, text="", l=0, t=0, provenance="excel_com"),
+        ],
+        table_candidates=["A1:B1"],
+    )
+    workbook = WorkbookData(
+        book_name="book.xlsx", sheets={"B": sheet, "A": SheetData()}
+    )
+
+    data = to_protobuf(workbook)
+
+    fields = _top_level_fields(data)
+    assert fields[0] == (1, b"book.xlsx")
+    named = [_top_level_fields(payload) for number, payload in fields[1:]]
+    assert [entry[0] for entry in named] == [(1, b"B"), (1, b"A")]
+    assert "あ".encode() in data
+    assert b"excel_com" not in data
+    assert b"excel_com" in to_protobuf(workbook, include_backend_metadata=True)
+
+    path = tmp_path / "book.pb"
+    save_as_protobuf(workbook, path)
+    assert path.read_bytes() == data
@@ -0,0 +1,98 @@
+"""Tests for JSONPath/JMESPath query expressions on the output payload."""
+
+from __future__ import annotations
+
+import json
+from pathlib import Path
+import sys
+
+from openpyxl import Workbook
+import pytest
+
+from exstruct.cli.main import main as cli_main
+from exstruct.engine import ExStructEngine, FormatOptions, OutputOptions
+from exstruct.errors import ConfigError, MissingDependencyError, SerializationError
+from exstruct.io import serialize_workbook
+from exstruct.io.transform import apply_query
+from exstruct.models import CellRow, Chart, SheetData, WorkbookData
+
+
+def _chart(title: str) -> Chart:
+    return Chart(
+        name=title, chart_type="Line", title=title, y_axis_title="", series=[], l=0, t=0
+    )
+
+
+def _workbook() -> WorkbookData:
+    return WorkbookData(
+        book_name="b.xlsx",
+        sheets={
+            "One": SheetData(
+                rows=[CellRow(r=1, c={"0": "a"}), CellRow(r=2, c={"0": "b"})],
+                charts=[_chart("Sales")],
+            ),
+            "Two": SheetData(rows=[CellRow(r=1, c={"0": 3})], charts=[_chart("Costs")]),
+        },
+    )
+
+
+def test_jsonpath_queries() -> None:
+    text = serialize_workbook(_workbook(), query="$.sheets.*.charts[*].title")
+    assert json.loads(text) == ["Sales", "Costs"]
+
+    payload = json.loads(serialize_workbook(_workbook()))
+    assert apply_query(payload, "$..title") == ["Sales", "Costs"]
+    assert apply_query(payload, "$.sheets['One'].rows[-1].r") == [2]
+    assert apply_query(payload, "$.sheets.One.rows[0:1]") == [
+        {"r": 1, "c": {"0": "a"}}
+    ]
+    assert apply_query(payload, "$.sheets.Three.rows") == []
+
+
+def test_jsonpath_rejects_unsupported_syntax() -> None:
+    with pytest.raises(SerializationError, match="unsupported selector"):
+        serialize_workbook(_workbook(), query="$.sheets[?(@.rows)]")
+    with pytest.raises(SerializationError, match="query expression failed"):
+        serialize_workbook(_workbook(), query="$.")
+    with pytest.raises(SerializationError):
+        serialize_workbook(_workbook(), fmt="text", query="$")
+
+
+def test_jmespath_queries() -> None:
+    pytest.importorskip("jmespath")
+
+    text = serialize_workbook(_workbook(), query="sheets.Two.rows[0].c")
+    assert json.loads(text) == {"0": 3}
+    with pytest.raises(SerializationError, match="query expression failed"):
+        serialize_workbook(_workbook(), query="sheets.[")
+
+
+def test_jmespath_requires_package(monkeypatch: pytest.MonkeyPatch) -> None:
+    monkeypatch.setitem(sys.modules, "jmespath", None)
+
+    with pytest.raises(MissingDependencyError, match="'query' extra"):
+        serialize_workbook(_workbook(), query="sheets")
+
+
+def test_query_engine_and_cli(tmp_path: Path) -> None:
+    engine = ExStructEngine(
+        output=OutputOptions(format=FormatOptions(query="$.book_name"))
+    )
+    assert json.loads(engine.serialize(_workbook())) == ["b.xlsx"]
+    with pytest.raises(ConfigError):
+        engine.export(_workbook(), tmp_path / "out.sqlite", fmt="sqlite")
+
+    wb = Workbook()
+    ws = wb.active
+    assert ws is not None
+    ws.title = "Data"
+    ws["A1"] = "x"
+    path = tmp_path / "book.xlsx"
+    wb.save(path)
+    out = tmp_path / "out.json"
+    query = "$.sheets.*.rows[0].c"
+
+    code = cli_main([str(path), "--mode", "light", "--query", query, "-o", str(out)])
+
+    assert code == 0
+    assert json.loads(out.read_text(encoding="utf-8")) == [{"0": "x"}]
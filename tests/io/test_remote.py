@@ -0,0 +1,232 @@
+"""Tests for object storage input and output URIs."""
+
+from __future__ import annotations
+
+from pathlib import Path
+import sqlite3
+import sys
+from typing import BinaryIO
+
+from openpyxl import Workbook
+import pytest
+
+from exstruct import process_excel
+from exstruct.errors import ConfigError, MissingDependencyError, OutputError
+from exstruct.io import remote
+from exstruct.io.output import write_output_text
+from exstruct.io.remote import (
+    RemoteUri,
+    is_remote_uri,
+    parse_remote_uri,
+    remote_input,
+    remote_output,
+    validate_remote_request,
+)
+from exstruct.sources import MEMORY_ROOT, is_memory_source, open_source
+
+
+def test_parse_remote_uri() -> None:
+    uri = parse_remote_uri("S3://bucket/in/book.xlsx")
+
+    assert uri == RemoteUri("s3", "bucket", "in/book.xlsx")
+    assert uri.name == "book.xlsx"
+    assert str(uri.sibling("book.json")) == "s3://bucket/in/book.json"
+    assert str(parse_remote_uri("gs://b/top.xlsx").sibling("x")) == "gs://b/x"
+
+
+@pytest.mark.parametrize(
+    "value", ["ftp://bucket/key", "s3://bucket", "s3://bucket/", "s3:///key"]
+)
+def test_parse_remote_uri_rejects(value: str) -> None:
+    with pytest.raises(ValueError):
+        parse_remote_uri(value)
+
+
+def test_is_remote_uri() -> None:
+    assert is_remote_uri("azblob://container/book.xlsx")
+    assert not is_remote_uri("book.xlsx")
+    assert not is_remote_uri(Path("s3://bucket/key"))
+
+
+def test_remote_input_downloads_into_memory(
+    monkeypatch: pytest.MonkeyPatch,
+) -> None:
+    def fake_download(uri: RemoteUri, handle: BinaryIO) -> None:
+        handle.write(b"payload")
+
+    monkeypatch.setattr(remote, "download", fake_download)
+
+    with remote_input("gs://bucket/dir/book.xlsx") as source:
+        assert source.name == "book.xlsx"
+        assert is_memory_source(source)
+        assert not source.exists()
+        buffer = open_source(source)
+        assert not isinstance(buffer, Path)
+        assert buffer.read() == b"payload"
+        assert source.is_relative_to(MEMORY_ROOT)
+    assert not is_memory_source(source)
+
+
+def test_remote_input_shares_one_download_per_uri(
+    monkeypatch: pytest.MonkeyPatch,
+) -> None:
+    calls: list[str] = []
+
+    def fake_download(uri: RemoteUri, handle: BinaryIO) -> None:
+        calls.append(str(uri))
+        handle.write(b"payload")
+
+    monkeypatch.setattr(remote, "download", fake_download)
+
+    with remote_input("s3://bucket/book.xlsx") as first:
+        with remote_input("s3://bucket/book.xlsx") as second:
+            assert second == first
+        assert is_memory_source(first)
+    assert not is_memory_source(first)
+    assert calls == ["s3://bucket/book.xlsx"]
+
+
+def test_remote_output_uploads_split_parts_from_memory(
+    monkeypatch: pytest.MonkeyPatch,
+) -> None:
+    uploaded: dict[str, bytes] = {}
+
+    def fake_upload(handle: BinaryIO, uri: RemoteUri) -> None:
+        uploaded[str(uri)] = handle.read()
+
+    monkeypatch.setattr(remote, "upload", fake_upload)
+
+    with remote_output("s3://bucket/out/book.json") as target:
+        assert target is not None
+        written = write_output_text(target, "abcdef", split_size=4)
+        assert not any(path.exists() for path in written)
+
+    assert uploaded["s3://bucket/out/book.part001.json"] == b"abcd"
+    assert uploaded["s3://bucket/out/book.part002.json"] == b"ef"
+    assert b'"parts"' in uploaded["s3://bucket/out/book.manifest.json"]
+
+
+def test_remote_output_allows_concurrent_blocks_for_one_uri(
+    monkeypatch: pytest.MonkeyPatch,
+) -> None:
+    uploaded: list[tuple[str, bytes]] = []
+
+    def fake_upload(handle: BinaryIO, uri: RemoteUri) -> None:
+        uploaded.append((str(uri), handle.read()))
+
+    monkeypatch.setattr(remote, "upload", fake_upload)
+
+    with remote_output("s3://bucket/book.json") as first:
+        with remote_output("s3://bucket/book.json") as second:
+            assert first is not None and second is not None
+            assert first != second
+            write_output_text(second, "2")
+        write_output_text(first, "1")
+
+    assert uploaded == [
+        ("s3://bucket/book.json", b"2"),
+        ("s3://bucket/book.json", b"1"),
+    ]
+
+
+def test_remote_output_wraps_upload_errors(monkeypatch: pytest.MonkeyPatch) -> None:
+    def fake_upload(handle: BinaryIO, uri: RemoteUri) -> None:
+        raise ConnectionError("denied")
+
+    monkeypatch.setattr(remote, "upload", fake_upload)
+
+    with pytest.raises(OutputError, match="s3://bucket/book.json"):
+        with remote_output("s3://bucket/book.json") as target:
+            assert target is not None
+            write_output_text(target, "{}")
+
+
+def test_azure_requires_connection_string(monkeypatch: pytest.MonkeyPatch) -> None:
+    monkeypatch.setattr(remote, "_require", lambda *args: object())
+    monkeypatch.delenv("AZURE_STORAGE_CONNECTION_STRING", raising=False)
+
+    with pytest.raises(ConfigError, match="AZURE_STORAGE_CONNECTION_STRING"):
+        remote._azure_blob(parse_remote_uri("azblob://container/book.xlsx"))
+
+
+def test_validate_remote_request_rejects_local_only_features() -> None:
+    with pytest.raises(ConfigError, match="rendering"):
+        validate_remote_request(
+            "book.xlsx", "s3://bucket/out.json", mode="standard", render=True
+        )
+    with pytest.raises(ConfigError, match="libreoffice"):
+        validate_remote_request(
+            "s3://bucket/book.xlsx", None, mode="libreoffice", render=False
+        )
+    validate_remote_request(
+        "s3://bucket/book.xlsx", "gs://out/book.json", mode="verbose", render=False
+    )
+
+
+def test_local_paths_pass_through(tmp_path: Path) -> None:
+    with remote_input(tmp_path / "a.xlsx") as local:
+        assert local == tmp_path / "a.xlsx"
+    with remote_output(None) as out:
+        assert out is None
+
+
+def test_missing_sdk_raises(monkeypatch: pytest.MonkeyPatch, tmp_path: Path) -> None:
+    monkeypatch.setitem(sys.modules, "boto3", None)
+
+    with pytest.raises(MissingDependencyError, match="'s3' extra"):
+        with (tmp_path / "x").open("wb") as handle:
+            remote.download(parse_remote_uri("s3://bucket/book.xlsx"), handle)
+
+
+def test_process_excel_reads_and_writes_uris(
+    monkeypatch: pytest.MonkeyPatch, tmp_path: Path
+) -> None:
+    source = tmp_path / "source.xlsx"
+    wb = Workbook()
+    wb.active["A1"] = "hello"
+    wb.save(source)
+    uploaded: dict[str, bytes] = {}
+
+    def fake_download(uri: RemoteUri, handle: BinaryIO) -> None:
+        handle.write(source.read_bytes())
+
+    def fake_upload(handle: BinaryIO, uri: RemoteUri) -> None:
+        uploaded[str(uri)] = handle.read()
+
+    monkeypatch.setattr(remote, "download", fake_download)
+    monkeypatch.setattr(remote, "upload", fake_upload)
+
+    process_excel("s3://in/book.xlsx", "gs://out/result.json", mode="light")
+
+    payload = uploaded["gs://out/result.json"].decode("utf-8")
+    assert '"book_name":"book.xlsx"' in payload.replace(" ", "")
+    assert "hello" in payload
+
+
+def test_process_excel_uploads_sqlite_from_memory(
+    monkeypatch: pytest.MonkeyPatch, tmp_path: Path
+) -> None:
+    source = tmp_path / "source.xlsx"
+    wb = Workbook()
+    wb.active["A1"] = "hello"
+    wb.save(source)
+    uploaded: dict[str, bytes] = {}
+
+    def fake_download(uri: RemoteUri, handle: BinaryIO) -> None:
+        handle.write(source.read_bytes())
+
+    def fake_upload(handle: BinaryIO, uri: RemoteUri) -> None:
+        uploaded[str(uri)] = handle.read()
+
+    monkeypatch.setattr(remote, "download", fake_download)
+    monkeypatch.setattr(remote, "upload", fake_upload)
+
+    process_excel(
+        "s3://in/book.xlsx", "s3://out/book.sqlite", out_fmt="sqlite", mode="light"
+    )
+
+    database = tmp_path / "book.sqlite"
+    database.write_bytes(uploaded["s3://out/book.sqlite"])
+    with sqlite3.connect(database) as connection:
+        rows = connection.execute("SELECT book_name FROM sheets").fetchall()
+    assert rows == [("book.xlsx",)]
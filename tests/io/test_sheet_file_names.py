@@ -0,0 +1,69 @@
+import json
+from pathlib import Path
+
+import pytest
+
+from exstruct.io import (
+    SHEET_INDEX_FILENAME,
+    _sanitize_sheet_filename,
+    _unique_sheet_stems,
+    save_sheets,
+)
+from exstruct.models import SheetData, WorkbookData
+
+
+@pytest.mark.parametrize(
+    ("name", "expected"),
+    [
+        ("2024/04", "2024_04"),
+        ("a:b*c?", "a_b_c_"),
+        ("tab\there", "tab_here"),
+        ("CON", "_CON"),
+        ("com1", "_com1"),
+        ("nul.data", "_nul.data"),
+        ("Console", "Console"),
+        ("Report. ", "Report"),
+        ("..", "sheet"),
+        ("", "sheet"),
+    ],
+)
+def test_sanitize_sheet_filename(name: str, expected: str) -> None:
+    assert _sanitize_sheet_filename(name) == expected
+
+
+def test_unique_sheet_stems_resolves_collisions_case_insensitively() -> None:
+    stems = _unique_sheet_stems(
+        ["2024/04", "2024_04", "Data", "DATA", "index"], reserved=("index",)
+    )
+    assert stems == {
+        "2024/04": "2024_04",
+        "2024_04": "2024_04_2",
+        "Data": "Data",
+        "DATA": "DATA_2",
+        "index": "index_2",
+    }
+
+
+def test_save_sheets_writes_index_mapping_files_to_sheets(tmp_path: Path) -> None:
+    workbook = WorkbookData(
+        book_name="book.xlsx",
+        sheets={"2024/04": SheetData(), "2024_04": SheetData(), "CON": SheetData()},
+    )
+    written = save_sheets(workbook, tmp_path)
+
+    assert {name: path.name for name, path in written.items()} == {
+        "2024/04": "2024_04.json",
+        "2024_04": "2024_04_2.json",
+        "CON": "_CON.json",
+    }
+    index = json.loads((tmp_path / SHEET_INDEX_FILENAME).read_text(encoding="utf-8"))
+    assert index == {
+        "book_name": "book.xlsx",
+        "sheets": [
+            {"sheet_name": "2024/04", "file": "2024_04.json"},
+            {"sheet_name": "2024_04", "file": "2024_04_2.json"},
+            {"sheet_name": "CON", "file": "_CON.json"},
+        ],
+    }
+    payload = json.loads(written["2024_04"].read_text(encoding="utf-8"))
+    assert payload["sheet_name"] == "2024_04"
@@ -0,0 +1,72 @@
+import json
+from pathlib import Path
+
+import pytest
+
+from exstruct.io import SHEET_INDEX_FILENAME, save_sheets
+from exstruct.models import CellRow, SheetData, WorkbookData
+
+
+def _workbook(rows: int) -> WorkbookData:
+    return WorkbookData(
+        book_name="book.xlsx",
+        sheets={
+            "Big": SheetData(
+                rows=[CellRow(r=r, c={"0": f"v{r}"}) for r in range(1, rows + 1)],
+                table_candidates=["A1:A5"],
+            ),
+            "Small": SheetData(rows=[CellRow(r=1, c={"0": "x"})]),
+        },
+    )
+
+
+def _read(path: Path) -> dict[str, object]:
+    return json.loads(path.read_text(encoding="utf-8"))
+
+
+def test_rows_per_file_splits_large_sheets_into_pages(tmp_path: Path) -> None:
+    written = save_sheets(_workbook(5), tmp_path, rows_per_file=2)
+
+    assert written["Big"].name == "Big.page001.json"
+    assert written["Small"].name == "Small.json"
+    assert not (tmp_path / "Big.json").exists()
+    pages = [_read(tmp_path / f"Big.page00{i}.json") for i in (1, 2, 3)]
+    first, middle, last = pages
+    assert first["page"] == {
+        "index": 1,
+        "count": 3,
+        "rows_per_file": 2,
+        "total_rows": 5,
+        "first_row": 1,
+        "last_row": 2,
+        "next": "Big.page002.json",
+    }
+    assert first["sheet"]["table_candidates"] == ["A1:A5"]  # type: ignore[index]
+    assert middle["page"]["prev"] == "Big.page001.json"  # type: ignore[index]
+    middle_sheet = middle["sheet"]
+    assert isinstance(middle_sheet, dict)
+    assert list(middle_sheet) == ["rows"]
+    assert [row["r"] for row in middle_sheet["rows"]] == [3, 4]
+    assert last["page"]["last_row"] == 5  # type: ignore[index]
+    assert "next" not in last["page"]  # type: ignore[operator]
+    index = _read(tmp_path / SHEET_INDEX_FILENAME)
+    assert index["sheets"] == [
+        {
+            "sheet_name": "Big",
+            "file": "Big.page001.json",
+            "files": ["Big.page001.json", "Big.page002.json", "Big.page003.json"],
+        },
+        {"sheet_name": "Small", "file": "Small.json"},
+    ]
+
+
+def test_rows_per_file_keeps_sheets_within_limit(tmp_path: Path) -> None:
+    written = save_sheets(_workbook(2), tmp_path, rows_per_file=2)
+
+    assert written["Big"].name == "Big.json"
+    assert "page" not in _read(written["Big"])
+
+
+def test_rows_per_file_rejects_zero(tmp_path: Path) -> None:
+    with pytest.raises(ValueError):
+        save_sheets(_workbook(2), tmp_path, rows_per_file=0)
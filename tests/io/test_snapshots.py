@@ -0,0 +1,133 @@
+"""Tests for sheet snapshot layout and rendering."""
+
+from __future__ import annotations
+
+import json
+from pathlib import Path
+
+import pytest
+
+from exstruct.core.ranges import RangeBounds
+from exstruct.errors import SerializationError
+from exstruct.io.scene import Label, Rect
+from exstruct.io.snapshots import (
+    render_sheet_snapshot,
+    save_sheet_snapshots,
+    sheet_grid,
+    sheet_snapshot_scene,
+    used_region,
+)
+from exstruct.models import (
+    Arrow,
+    CellRow,
+    MergedCells,
+    Shape,
+    SheetData,
+    WorkbookData,
+)
+
+
+def _sheet() -> SheetData:
+    return SheetData(
+        rows=[
+            CellRow(r=1, c={"0": "Title"}),
+            CellRow(r=2, c={"0": "qty", "1": 12}),
+        ],
+        merged_cells=MergedCells(items=[(1, 0, 1, 2, "Title")]),
+        colors_map={"FFFF00": [(2, 1)], "theme:4": [(2, 0)]},
+        column_widths={"1": 20.0},
+        row_heights={"2": 30.0},
+        shapes=[
+            Shape(id=1, text="Check", l=200, t=0, w=64, h=20, covered_range="D1:E2"),
+            Arrow(id=2, text="", l=0, t=0, begin_x=0, begin_y=0, end_x=10, end_y=10),
+        ],
+    )
+
+
+def test_sheet_grid_uses_widths_and_heights() -> None:
+    grid = sheet_grid(_sheet(), RangeBounds(r1=0, c1=0, r2=1, c2=1))
+
+    # Default 9.140625 chars -> 64px, 20 chars -> 140px; 15pt -> 20px, 30pt -> 40px.
+    assert grid.col_edges == pytest.approx([0.0, 64.0, 204.0])
+    assert grid.row_edges == pytest.approx([0.0, 20.0, 60.0])
+    assert grid.box(0, 0, 0, 5) == pytest.approx((0.0, 0.0, 204.0, 20.0))
+
+
+def test_used_region_covers_values_merges_and_shapes() -> None:
+    assert used_region(_sheet()) == RangeBounds(r1=0, c1=0, r2=1, c2=4)
+    assert used_region(SheetData()) is None
+
+
+def test_snapshot_scene_draws_merge_once_and_aligns_numbers() -> None:
+    laid_out = sheet_snapshot_scene(_sheet(), "A1:C2")
+    assert laid_out is not None
+    scene, width, height = laid_out
+
+    labels = {item.text: item for item in scene if isinstance(item, Label)}
+    assert labels["Title"].anchor == "start"
+    assert labels["12"].anchor == "end"
+    assert any(isinstance(item, Rect) and item.fill == "#FFFF00" for item in scene)
+    merged = [item for item in scene if isinstance(item, Rect) and item.stroke]
+    assert merged[0].w == pytest.approx(64 + 140 + 64)
+    assert (width, height) == (268, 60)
+
+
+def test_render_sheet_snapshot_svg_escapes_and_truncates() -> None:
+    sheet = SheetData(rows=[CellRow(r=1, c={"0": "a<b " + "x" * 40})])
+
+    svg = render_sheet_snapshot(sheet)
+
+    assert svg is not None
+    text = svg.decode("utf-8")
+    assert "a&lt;b" in text
+    assert "x" * 20 not in text
+    assert render_sheet_snapshot(SheetData()) is None
+
+
+def test_save_sheet_snapshots_writes_index(tmp_path: Path) -> None:
+    workbook = WorkbookData(
+        book_name="b.xlsx", sheets={"Main": _sheet(), "Empty": SheetData()}
+    )
+
+    written = save_sheet_snapshots(workbook, tmp_path)
+
+    assert list(written) == ["Main"]
+    assert written["Main"].name == "Main.svg"
+    index = json.loads((tmp_path / "index.json").read_text(encoding="utf-8"))
+    assert index["sheets"] == [{"sheet_name": "Main", "file": "Main.svg"}]
+
+
+def test_save_sheet_snapshots_rejects_unknown_format(tmp_path: Path) -> None:
+    workbook = WorkbookData(book_name="b.xlsx", sheets={"Main": _sheet()})
+
+    with pytest.raises(SerializationError):
+        save_sheet_snapshots(workbook, tmp_path, fmt="bmp")  # type: ignore[arg-type]
+
+
+def test_vertical_shape_text_is_rotated_or_stacked() -> None:
+    def sheet_with(orientation: str) -> SheetData:
+        shape = Shape(
+            id=1,
+            text="縦書き",
+            l=0,
+            t=0,
+            w=20,
+            h=80,
+            text_orientation=orientation,  # type: ignore[arg-type]
+        )
+        return SheetData(rows=[CellRow(r=1, c={"0": "x"})], shapes=[shape])
+
+    def labels(orientation: str) -> list[Label]:
+        laid_out = sheet_snapshot_scene(sheet_with(orientation))
+        assert laid_out is not None
+        return [item for item in laid_out[0] if isinstance(item, Label)]
+
+    rotated = [item for item in labels("vertical") if item.text == "縦書き"]
+    assert [item.rotation for item in rotated] == [90.0]
+    stacked = [
+        item.text for item in labels("east_asian_vertical") if item.text in "縦書き"
+    ]
+    assert stacked == ["縦", "書", "き"]
+    svg = render_sheet_snapshot(sheet_with("vertical270"))
+    assert svg is not None
+    assert 'transform="rotate(270' in svg.decode("utf-8")
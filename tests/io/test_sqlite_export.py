@@ -0,0 +1,108 @@
+"""Tests for SQLite export of extraction results."""
+
+from __future__ import annotations
+
+from pathlib import Path
+import sqlite3
+
+import pytest
+
+from exstruct.engine import DestinationOptions, ExStructEngine, OutputOptions
+from exstruct.errors import ConfigError, SerializationError
+from exstruct.io.sqlite import save_as_sqlite
+from exstruct.models import (
+    Arrow,
+    CellRow,
+    Chart,
+    ChartSeries,
+    Shape,
+    SheetData,
+    WorkbookData,
+)
+
+
+def _workbook() -> WorkbookData:
+    sheet = SheetData(
+        rows=[
+            CellRow(r=1, c={"0": "name", "1": "qty"}),
+            CellRow(r=2, c={"0": "apple", "1": 3}, links={"0": "https://e.com"}),
+        ],
+        shapes=[
+            Shape(id=1, text="Start", l=10, t=5, w=20, h=10, type="Rect"),
+            Arrow(id=2, text="", l=5, t=5, w=20, h=2, provenance="excel_com"),
+        ],
+        charts=[
+            Chart(
+                name="c1",
+                chart_type="Line",
+                y_axis_title="",
+                series=[ChartSeries(name="qty", y_range="Data!$B$2:$B$2")],
+                l=0,
+                t=0,
+            )
+        ],
+        table_candidates=["A1:B2"],
+        table_hashes={"A1:B2": "abc"},
+    )
+    return WorkbookData(
+        book_name="book.xlsx", sheets={"Data": sheet, "Empty": SheetData()}
+    )
+
+
+def _query(path: Path, sql: str) -> list[tuple[object, ...]]:
+    connection = sqlite3.connect(path)
+    try:
+        return connection.execute(sql).fetchall()
+    finally:
+        connection.close()
+
+
+def test_save_as_sqlite_writes_tables(tmp_path: Path) -> None:
+    path = tmp_path / "out.sqlite"
+    path.write_bytes(b"stale")
+
+    save_as_sqlite(_workbook(), path)
+
+    assert _query(path, "SELECT sheet_id, name, position FROM sheets") == [
+        (1, "Data", 0),
+        (2, "Empty", 1),
+    ]
+    assert _query(
+        path, "SELECT a1, value, value_type, link FROM cells WHERE row = 2"
+    ) == [("A2", "apple", "str", "https://e.com"), ("B2", 3, "int", None)]
+    assert _query(path, "SELECT shape_id, kind, type FROM shapes") == [
+        (1, "shape", "Rect"),
+        (2, "arrow", None),
+    ]
+    assert _query(path, "SELECT name, series_count FROM charts") == [("c1", 1)]
+    assert _query(path, "SELECT * FROM tables") == [(1, "A1:B2", 1, 1, 2, 2, "abc")]
+    data = _query(path, "SELECT data FROM shapes WHERE kind = 'arrow'")[0][0]
+    assert isinstance(data, str) and "provenance" not in data
+
+
+def test_engine_export_sqlite_requires_output_path() -> None:
+    engine = ExStructEngine()
+    with pytest.raises(ConfigError):
+        engine.export(_workbook(), fmt="sqlite")
+
+
+def test_engine_export_sqlite_rejects_side_outputs(tmp_path: Path) -> None:
+    engine = ExStructEngine(
+        output=OutputOptions(
+            destinations=DestinationOptions(sheets_dir=tmp_path / "sheets")
+        )
+    )
+    with pytest.raises(ConfigError):
+        engine.export(_workbook(), tmp_path / "out.sqlite", fmt="sqlite")
+
+
+def test_engine_export_sqlite_writes_database(tmp_path: Path) -> None:
+    path = tmp_path / "out.sqlite"
+    ExStructEngine().export(_workbook(), path, fmt="sqlite")
+    assert _query(path, "SELECT COUNT(*) FROM cells") == [(4,)]
+
+
+def test_engine_serialize_rejects_sqlite() -> None:
+    engine = ExStructEngine()
+    with pytest.raises(SerializationError):
+        engine.serialize(_workbook(), fmt="sqlite")  # type: ignore[arg-type]
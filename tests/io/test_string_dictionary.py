@@ -0,0 +1,84 @@
+"""Tests for string dictionary encoding of cell values."""
+
+from __future__ import annotations
+
+import json
+from pathlib import Path
+
+from openpyxl import Workbook
+import pytest
+
+from exstruct.cli.main import main as cli_main
+from exstruct.engine import ExStructEngine, FormatOptions, OutputOptions
+from exstruct.errors import ConfigError, SerializationError
+from exstruct.io import serialize_workbook, workbook_payload
+from exstruct.io.transform import decode_strings
+from exstruct.models import CellRow, SheetData, WorkbookData
+
+
+def _workbook() -> WorkbookData:
+    return WorkbookData(
+        book_name="b.xlsx",
+        sheets={
+            "Orders": SheetData(
+                rows=[
+                    CellRow(r=1, c={"0": "id", "1": "status"}),
+                    CellRow(r=2, c={"0": 1, "1": "Open"}),
+                    CellRow(r=3, c={"0": 2, "1": "Closed"}),
+                    CellRow(r=4, c={"0": 3, "1": "Open", "2": "Closed"}),
+                ]
+            ),
+            "Notes": SheetData(rows=[CellRow(r=1, c={"0": "unique"})]),
+        },
+    )
+
+
+def test_repeated_strings_are_stored_once_per_sheet() -> None:
+    sheets = json.loads(serialize_workbook(_workbook(), dedupe_strings=True))["sheets"]
+
+    orders = sheets["Orders"]
+    assert orders["strings"] == ["Open", "Closed"]
+    assert orders["rows"] == [
+        {"r": 1, "c": {"0": "id", "1": "status"}},
+        {"r": 2, "c": {"0": 1}, "s": {"1": 0}},
+        {"r": 3, "c": {"0": 2}, "s": {"1": 1}},
+        {"r": 4, "c": {"0": 3}, "s": {"1": 0, "2": 1}},
+    ]
+    assert "strings" not in sheets["Notes"]
+
+
+def test_decode_strings_restores_plain_rows() -> None:
+    plain = workbook_payload(_workbook())
+
+    assert decode_strings(workbook_payload(_workbook(), dedupe_strings=True)) == plain
+
+
+def test_engine_and_cli_write_encoded_output(tmp_path: Path) -> None:
+    engine = ExStructEngine(
+        output=OutputOptions(format=FormatOptions(dedupe_strings=True))
+    )
+    sheets = json.loads(engine.serialize(_workbook()))["sheets"]
+    assert sheets["Orders"]["strings"] == ["Open", "Closed"]
+    with pytest.raises(ConfigError):
+        engine.export(_workbook(), tmp_path / "out.sqlite", fmt="sqlite")
+
+    wb = Workbook()
+    ws = wb.active
+    assert ws is not None
+    for value in ("yes", "no", "yes", "yes"):
+        ws.append([value])
+    book = tmp_path / "book.xlsx"
+    wb.save(book)
+    out = tmp_path / "out.json"
+
+    code = cli_main([str(book), "-o", str(out), "--mode", "light", "--dedupe-strings"])
+
+    assert code == 0
+    sheet = json.loads(out.read_text(encoding="utf-8"))["sheets"]["Sheet"]
+    assert sheet["strings"] == ["yes", "no"]
+    assert sheet["rows"][2] == {"r": 3, "s": {"0": 0}}
+
+
+def test_dedupe_strings_rejected_for_non_payload_formats() -> None:
+    with pytest.raises(SerializationError, match="string dictionary encoding"):
+        serialize_workbook(_workbook(), fmt="text", dedupe_strings=True)
@@ -0,0 +1,86 @@
+"""Tests for Parquet/Arrow export of table candidates."""
+
+from __future__ import annotations
+
+from pathlib import Path
+import sys
+
+import pytest
+
+from exstruct.errors import MissingDependencyError, SerializationError
+from exstruct.io.tables import build_table_frame, infer_column_type, save_tables
+from exstruct.models import CellRow, SheetData, WorkbookData
+
+
+def _rows() -> list[CellRow]:
+    return [
+        CellRow(r=1, c={"0": "title"}),
+        CellRow(r=2, c={"1": "name", "2": "qty", "3": "price", "4": "name"}),
+        CellRow(r=3, c={"1": "apple", "2": 3, "3": 1.5, "4": "x"}),
+        CellRow(r=4, c={"1": "pear", "2": 5, "3": 2, "4": 7}),
+        CellRow(r=5, c={"1": "plum", "3": 0.5}),
+    ]
+
+
+def _workbook() -> WorkbookData:
+    sheet = SheetData(rows=_rows(), table_candidates=["B2:E5"])
+    return WorkbookData(book_name="book.xlsx", sheets={"Data": sheet})
+
+
+def test_infer_column_type() -> None:
+    assert infer_column_type([1, None, 2]) == "int64"
+    assert infer_column_type([1, 2.5]) == "float64"
+    assert infer_column_type([1, "a"]) == "string"
+    assert infer_column_type([None]) == "string"
+
+
+def test_build_table_frame_uses_header_and_types() -> None:
+    frame = build_table_frame("Data", _rows(), "B2:E5")
+
+    assert frame is not None
+    assert frame.columns == ["name", "qty", "price", "name_2"]
+    assert frame.types == ["string", "int64", "float64", "string"]
+    assert frame.values[1] == [3, 5, None]
+    assert frame.values[2] == [1.5, 2.0, 0.5]
+    assert frame.values[3] == ["x", "7", None]
+
+
+def test_build_table_frame_without_header_uses_letters() -> None:
+    rows = [CellRow(r=1, c={"A": 1, "B": 2}), CellRow(r=2, c={"A": 3})]
+    frame = build_table_frame("S", rows, "A1:B2")
+
+    assert frame is not None
+    assert frame.columns == ["A", "B"]
+    assert frame.values == [[1, 3], [2, None]]
+    assert build_table_frame("S", rows, "D1:E2") is None
+
+
+def test_save_tables_rejects_unknown_format(tmp_path: Path) -> None:
+    with pytest.raises(SerializationError):
+        save_tables(_workbook(), tmp_path, fmt="csv")  # type: ignore[arg-type]
+
+
+def test_save_tables_requires_pyarrow(
+    tmp_path: Path, monkeypatch: pytest.MonkeyPatch
+) -> None:
+    monkeypatch.setitem(sys.modules, "pyarrow", None)
+    with pytest.raises(MissingDependencyError, match="'parquet' extra"):
+        save_tables(_workbook(), tmp_path)
+
+
+@pytest.mark.parametrize("fmt", ["parquet", "arrow"])
+def test_save_tables_writes_typed_columns(tmp_path: Path, fmt: str) -> None:
+    pa = pytest.importorskip("pyarrow")
+
+    written = save_tables(_workbook(), tmp_path, fmt=fmt)  # type: ignore[arg-type]
+
+    path = written["Data!B2:E5"]
+    assert path.name == f"Data_B2-E5.{fmt}"
+    if fmt == "parquet":
+        table = pytest.importorskip("pyarrow.parquet").read_table(path)
+    else:
+        table = pa.ipc.open_file(path).read_all()
+    assert table.column_names == ["name", "qty", "price", "name_2"]
+    assert str(table.schema.field("qty").type) == "int64"
+    assert table.column("name").to_pylist() == ["apple", "pear", "plum"]
+    assert table.schema.metadata[b"range"] == b"B2:E5"
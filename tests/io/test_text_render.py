@@ -0,0 +1,77 @@
+"""Tests for plain-text workbook rendering."""
+
+from __future__ import annotations
+
+from exstruct.io import serialize_workbook
+from exstruct.io.text import render_sheet_text
+from exstruct.models import (
+    Arrow,
+    CellRow,
+    Chart,
+    ChartSeries,
+    Shape,
+    SheetData,
+    WorkbookData,
+)
+
+
+def _sheet() -> SheetData:
+    return SheetData(
+        rows=[
+            CellRow(r=2, c={"1": "名前", "2": "qty"}),
+            CellRow(r=4, c={"1": "a\nb", "3": 1.5}),
+        ],
+        shapes=[
+            Shape(id=1, text="Start", l=0, t=0, type="Rect", covered_range="F2:G3"),
+            Shape(id=2, text="", l=0, t=0),
+            Arrow(id=3, text="", l=0, t=0, begin_id=1, end_id=None),
+        ],
+        charts=[
+            Chart(
+                name="c1",
+                chart_type="Line",
+                title="Sales",
+                y_axis_title="",
+                series=[ChartSeries(name="qty", y_range="Data!$C$3:$C$4")],
+                l=0,
+                t=0,
+            )
+        ],
+    )
+
+
+def test_render_sheet_text_tab_grid_with_annotations() -> None:
+    text = render_sheet_text("Data", _sheet())
+
+    assert text.splitlines() == [
+        "=== Data ===",
+        "\tB\tC\tD",
+        "2\t名前\tqty",
+        "4\ta\\nb\t\t1.5",
+        "",
+        "[Rect#1 @F2:G3] Start",
+        "[arrow#3] #1 -> ?",
+        '[chart c1] Line "Sales": qty (Data!$C$3:$C$4)',
+    ]
+
+
+def test_render_sheet_text_aligned_counts_wide_characters() -> None:
+    sheet = SheetData(rows=[CellRow(r=1, c={"0": "名前", "1": "x"})])
+    sheet_two = SheetData(rows=[CellRow(r=1, c={"0": "abcd", "1": "y"})])
+
+    first = render_sheet_text("S", sheet, aligned=True).splitlines()[2]
+    second = render_sheet_text("S", sheet_two, aligned=True).splitlines()[2]
+
+    assert first == "1  名前  x"
+    assert second == "1  abcd  y"
+
+
+def test_serialize_workbook_text_separates_sheets() -> None:
+    workbook = WorkbookData(
+        book_name="b.xlsx",
+        sheets={"One": SheetData(rows=[CellRow(r=1, c={"0": 1})]), "Two": SheetData()},
+    )
+
+    text = serialize_workbook(workbook, fmt="text")
+
+    assert text == "=== One ===\n\tA\n1\t1\n\n=== Two ===\n"
@@ -6,8 +6,10 @@ from pathlib import Path
 
 import pytest
 
+from exstruct.engine import RunReport
 from exstruct.mcp import extract_runner
 from exstruct.mcp.io import PathPolicy
+from exstruct.metrics import MetricsRegistry
 
 
 def test_resolve_input_path_missing(tmp_path: Path) -> None:
@@ -184,3 +186,33 @@ def test_try_read_workbook_meta_load_failure(
     meta, warnings = extract_runner._try_read_workbook_meta(path)
     assert meta is None
     assert any("Failed to read workbook metadata" in warning for warning in warnings)
+
+
+def test_run_extract_records_metrics(
+    monkeypatch: pytest.MonkeyPatch, tmp_path: Path
+) -> None:
+    input_path = tmp_path / "input.xlsx"
+    input_path.write_text("x", encoding="utf-8")
+    registry = MetricsRegistry()
+
+    def _fill_report(*_args: object, **kwargs: object) -> None:
+        report = kwargs["report"]
+        assert isinstance(report, RunReport)
+        report.counts = {"sheets": 2, "rows": 5}
+
+    def _raise(*_args: object, **_kwargs: object) -> None:
+        raise RuntimeError("boom")
+
+    monkeypatch.setattr(extract_runner, "EXTRACTION_METRICS", registry)
+    monkeypatch.setattr(extract_runner, "_try_read_workbook_meta", lambda _: (None, []))
+    request = extract_runner.ExtractRequest(xlsx_path=input_path, out_dir=tmp_path)
+    monkeypatch.setattr(extract_runner, "process_excel", _fill_report)
+    extract_runner.run_extract(request)
+    monkeypatch.setattr(extract_runner, "process_excel", _raise)
+    with pytest.raises(RuntimeError):
+        extract_runner.run_extract(request)
+
+    text = registry.render()
+    assert 'exstruct_extractions_total{status="ok"} 1' in text
+    assert 'exstruct_extractions_total{status="error"} 1' in text
+    assert 'exstruct_extracted_items_total{kind="rows"} 5' in text
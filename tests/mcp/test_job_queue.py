@@ -0,0 +1,110 @@
+from __future__ import annotations
+
+from pathlib import Path
+import threading
+
+import pytest
+
+from exstruct.mcp.extract_runner import ExtractRequest, ExtractResult
+from exstruct.mcp.io import PathPolicy
+from exstruct.mcp.job_queue import ExtractJobQueue
+
+
+def _workbook(tmp_path: Path, size: int = 10) -> Path:
+    path = tmp_path / "book.xlsx"
+    path.write_bytes(b"x" * size)
+    return path
+
+
+def test_job_runs_and_returns_result(tmp_path: Path) -> None:
+    path = _workbook(tmp_path)
+
+    def runner(request: ExtractRequest, *, policy: PathPolicy | None) -> ExtractResult:
+        return ExtractResult(out_path=str(request.xlsx_path.with_suffix(".json")))
+
+    queue = ExtractJobQueue(runner=runner)
+    job = queue.submit(ExtractRequest(xlsx_path=path))
+    queue.shutdown()
+
+    assert job.status == "queued"
+    done = queue.get(job.job_id)
+    assert done.status == "succeeded"
+    assert done.result is not None
+    assert done.result.out_path == str(path.with_suffix(".json"))
+    assert done.started_at is not None
+    assert done.finished_at is not None
+
+
+def test_job_records_failure(tmp_path: Path) -> None:
+    def runner(request: ExtractRequest, *, policy: PathPolicy | None) -> ExtractResult:
+        raise RuntimeError("broken workbook")
+
+    queue = ExtractJobQueue(runner=runner)
+    job = queue.submit(ExtractRequest(xlsx_path=_workbook(tmp_path)))
+    queue.shutdown()
+
+    failed = queue.get(job.job_id)
+    assert failed.status == "failed"
+    assert failed.error == "broken workbook"
+    assert failed.result is None
+
+
+def test_worker_pool_is_bounded(tmp_path: Path) -> None:
+    path = _workbook(tmp_path)
+    release = threading.Event()
+    running = 0
+    peak = 0
+    lock = threading.Lock()
+
+    def runner(request: ExtractRequest, *, policy: PathPolicy | None) -> ExtractResult:
+        nonlocal running, peak
+        with lock:
+            running += 1
+            peak = max(peak, running)
+        release.wait(5)
+        with lock:
+            running -= 1
+        return ExtractResult(out_path="out.json")
+
+    queue = ExtractJobQueue(max_workers=2, runner=runner)
+    jobs = [queue.submit(ExtractRequest(xlsx_path=path)) for _ in range(5)]
+    release.set()
+    queue.shutdown()
+
+    assert peak <= 2
+    assert all(queue.get(job.job_id).status == "succeeded" for job in jobs)
+
+
+def test_submit_rejects_large_input(tmp_path: Path) -> None:
+    queue = ExtractJobQueue(max_input_bytes=5)
+
+    with pytest.raises(ValueError, match="over the limit"):
+        queue.submit(ExtractRequest(xlsx_path=_workbook(tmp_path, size=6)))
+    queue.shutdown()
+
+
+def test_submit_rejects_missing_input(tmp_path: Path) -> None:
+    queue = ExtractJobQueue()
+
+    with pytest.raises(FileNotFoundError):
+        queue.submit(ExtractRequest(xlsx_path=tmp_path / "missing.xlsx"))
+    queue.shutdown()
+
+
+def test_finished_jobs_expire(tmp_path: Path) -> None:
+    now = [100.0]
+
+    def runner(request: ExtractRequest, *, policy: PathPolicy | None) -> ExtractResult:
+        return ExtractResult(out_path="out.json")
+
+    queue = ExtractJobQueue(
+        result_ttl_seconds=60, runner=runner, clock=lambda: now[0]
+    )
+    job = queue.submit(ExtractRequest(xlsx_path=_workbook(tmp_path)))
+    queue.shutdown()
+
+    now[0] = 159.0
+    assert queue.get(job.job_id).status == "succeeded"
+    now[0] = 160.0
+    with pytest.raises(KeyError):
+        queue.get(job.job_id)
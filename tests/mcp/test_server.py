@@ -10,18 +10,25 @@ from typing import Any, cast
 import pytest
 
 from exstruct.mcp import server
-from exstruct.mcp.extract_runner import OnConflictPolicy
+from exstruct.mcp.extract_runner import (
+    ExtractRequest,
+    ExtractResult,
+    OnConflictPolicy,
+)
 from exstruct.mcp.io import PathPolicy
+from exstruct.mcp.job_queue import ExtractJobQueue
 from exstruct.mcp.patch import normalize as patch_normalize
 from exstruct.mcp.tools import (
     CaptureSheetImagesToolInput,
     CaptureSheetImagesToolOutput,
     DescribeOpToolOutput,
+    ExtractJobToolOutput,
     ExtractToolInput,
     ExtractToolOutput,
     ListOpsToolOutput,
     MakeToolInput,
     MakeToolOutput,
+    MetricsToolOutput,
     PatchToolInput,
     PatchToolOutput,
     ReadCellsToolInput,
@@ -70,6 +77,9 @@ def test_parse_args_defaults(tmp_path: Path) -> None:
     assert config.on_conflict == "overwrite"
     assert config.artifact_bridge_dir is None
     assert config.warmup is False
+    assert config.job_workers == 2
+    assert config.max_input_bytes is None
+    assert config.job_result_ttl == 3600.0
 
 
 def test_parse_args_with_options(tmp_path: Path) -> None:
@@ -92,6 +102,12 @@ def test_parse_args_with_options(tmp_path: Path) -> None:
             "--artifact-bridge-dir",
             str(bridge_dir),
             "--warmup",
+            "--job-workers",
+            "4",
+            "--max-input-bytes",
+            "1048576",
+            "--job-result-ttl",
+            "60",
         ]
     )
     assert config.deny_globs == ["**/*.tmp", "**/*.secret"]
@@ -100,6 +116,9 @@ def test_parse_args_with_options(tmp_path: Path) -> None:
     assert config.on_conflict == "rename"
     assert config.artifact_bridge_dir == bridge_dir
     assert config.warmup is True
+    assert config.job_workers == 4
+    assert config.max_input_bytes == 1048576
+    assert config.job_result_ttl == 60.0
 
 
 def test_get_capture_sheet_images_timeout_seconds(
@@ -405,6 +424,63 @@ def test_register_tools_returns_runtime_info(tmp_path: Path) -> None:
     )
 
 
+def test_register_tools_returns_metrics(tmp_path: Path) -> None:
+    app = DummyApp()
+    server._register_tools(app, PathPolicy(root=tmp_path), default_on_conflict="skip")
+
+    metrics_tool = cast(
+        Callable[..., Awaitable[object]], app.tools["exstruct_get_metrics"]
+    )
+    result = cast(MetricsToolOutput, anyio.run(_call_async, metrics_tool, {}))
+    assert "# TYPE exstruct_extraction_duration_seconds histogram" in result.text
+    assert result.content_type.startswith("text/plain")
+
+
+def test_register_tools_runs_extract_jobs(
+    monkeypatch: pytest.MonkeyPatch, tmp_path: Path
+) -> None:
+    app = DummyApp()
+    (tmp_path / "in.xlsx").write_bytes(b"x")
+    calls: list[object] = []
+
+    def fake_run_extract(request: object, *, policy: PathPolicy) -> ExtractResult:
+        calls.append(request)
+        return ExtractResult(out_path=str(tmp_path / "in.json"))
+
+    async def fake_run_sync(func: Callable[[], object]) -> object:
+        return func()
+
+    monkeypatch.setattr(anyio.to_thread, "run_sync", fake_run_sync)
+    queue = ExtractJobQueue(max_workers=1, runner=fake_run_extract)
+    server._register_tools(
+        app,
+        PathPolicy(root=tmp_path),
+        default_on_conflict="rename",
+        job_queue=queue,
+    )
+
+    submit_tool = cast(
+        Callable[..., Awaitable[object]], app.tools["exstruct_submit_extract"]
+    )
+    submitted = cast(
+        ExtractJobToolOutput,
+        anyio.run(_call_async, submit_tool, {"xlsx_path": "in.xlsx"}),
+    )
+    queue.shutdown()
+    get_tool = cast(
+        Callable[..., Awaitable[object]], app.tools["exstruct_get_extract_job"]
+    )
+    job = cast(
+        ExtractJobToolOutput,
+        anyio.run(_call_async, get_tool, {"job_id": submitted.job_id}),
+    )
+
+    assert job.status == "succeeded"
+    assert job.result is not None
+    assert job.result.out_path == str(tmp_path / "in.json")
+    assert cast(ExtractRequest, calls[0]).on_conflict == "rename"
+
+
 def test_register_tools_passes_capture_sheet_images_arguments(
     monkeypatch: pytest.MonkeyPatch, tmp_path: Path
 ) -> None:
@@ -1514,6 +1590,7 @@ def test_run_server_sets_env(monkeypatch: pytest.MonkeyPatch, tmp_path: Path) ->
         *,
         on_conflict: OnConflictPolicy,
         artifact_bridge_dir: Path | None = None,
+        job_queue: ExtractJobQueue | None = None,
     ) -> _App:
         created["policy"] = policy
         created["on_conflict"] = on_conflict
@@ -1559,6 +1636,7 @@ def test_run_server_preserves_existing_render_subprocess_env(
         *,
         on_conflict: OnConflictPolicy,
         artifact_bridge_dir: Path | None = None,
+        job_queue: ExtractJobQueue | None = None,
     ) -> _App:
         created["policy"] = policy
         created["on_conflict"] = on_conflict
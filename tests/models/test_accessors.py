@@ -0,0 +1,69 @@
+"""Tests for typed cell accessors on models."""
+
+from datetime import datetime
+
+import pytest
+
+from exstruct import as_datetime, as_float, col_alpha_to_index
+from exstruct.models import CellRow, SheetData, convert_sheet_keys_to_alpha
+
+
+def _sheet() -> SheetData:
+    return SheetData(
+        rows=[
+            CellRow(r=1, c={"0": "Date", "1": "Amount"}),
+            CellRow(r=2, c={"0": "2024-05-31", "1": "1,250.5"}),
+            CellRow(r=4, c={"0": 45443, "2": 7}),
+        ]
+    )
+
+
+def test_sheet_cell_reads_numeric_and_alpha_keys() -> None:
+    sheet = _sheet()
+    alpha = convert_sheet_keys_to_alpha(sheet)
+
+    assert sheet.cell("B2") == "1,250.5"
+    assert sheet.cell("$C$4") == 7
+    assert sheet.cell("Sheet1!a1") == "Date"
+    assert sheet.cell("B3") is None
+    assert alpha.cell("C4") == 7
+    with pytest.raises(ValueError, match="Invalid cell address"):
+        sheet.cell("A1:B2")
+
+
+def test_sheet_cell_range_fills_gaps_with_none() -> None:
+    sheet = _sheet()
+
+    assert sheet.cell_range("A2:C4") == [
+        ["2024-05-31", "1,250.5", None],
+        [None, None, None],
+        [45443, None, 7],
+    ]
+    assert sheet.cell_range("B2:A1") == [["Date", "Amount"], ["2024-05-31", "1,250.5"]]
+    assert sheet.cell_range("C4") == [[7]]
+
+
+def test_value_conversions() -> None:
+    sheet = _sheet()
+
+    assert as_float(sheet.cell("B2")) == 1250.5
+    assert as_float(sheet.cell("C4")) == 7.0
+    assert as_float(sheet.cell("A1")) is None
+    assert as_float(None) is None
+    assert as_datetime(sheet.cell("A2")) == datetime(2024, 5, 31)
+    assert as_datetime(sheet.cell("A4")) == datetime(2024, 5, 31)
+    assert as_datetime(1) == datetime(1900, 1, 1)
+    assert as_datetime(45443.5) == datetime(2024, 5, 31, 12)
+    assert as_datetime("2024-05-31T09:30:00") == datetime(2024, 5, 31, 9, 30)
+    assert as_datetime("Amount") is None
+
+
+def test_col_alpha_to_index() -> None:
+    assert [col_alpha_to_index(name) for name in ("A", "z", "AA", "XFD")] == [
+        0,
+        25,
+        26,
+        16383,
+    ]
+    with pytest.raises(ValueError):
+        col_alpha_to_index("A1")
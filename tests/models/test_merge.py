@@ -0,0 +1,76 @@
+"""Tests for combining workbooks and appending sheets."""
+
+import pytest
+
+from exstruct import append_sheet, merge_workbooks
+from exstruct.models import CellRow, SheetData, WorkbookData
+
+
+def _month(book: str, amount: int) -> WorkbookData:
+    return WorkbookData(
+        book_name=book,
+        sheets={
+            "Report": SheetData(
+                rows=[
+                    CellRow(r=2, c={"0": "Item", "1": "Amount"}),
+                    CellRow(r=3, c={"0": "Pen", "1": amount}),
+                ],
+                table_candidates=["A2:B3"],
+            ),
+            book: SheetData(rows=[CellRow(r=1, c={"0": book})]),
+        },
+        warnings=["sheet 'Old' skipped"],
+    )
+
+
+def test_merge_workbooks_renames_duplicates_by_default() -> None:
+    jan, feb, mar = _month("jan", 1), _month("feb", 2), _month("mar", 3)
+
+    merged = merge_workbooks(jan, feb, mar, book_name="q1")
+
+    assert merged.book_name == "q1"
+    assert list(merged.sheets) == [
+        "Report",
+        "jan",
+        "Report (2)",
+        "feb",
+        "Report (3)",
+        "mar",
+    ]
+    assert merged.sheets["Report (2)"] is feb.sheets["Report"]
+    assert merged.warnings[1] == "feb: sheet 'Old' skipped"
+    assert list(jan.sheets) == ["Report", "jan"]
+
+
+def test_merge_workbooks_conflict_policies() -> None:
+    jan, feb = _month("jan", 1), _month("feb", 2)
+
+    def amount(workbook: WorkbookData) -> object:
+        return workbook.sheets["Report"].rows[1].c["1"]
+
+    assert amount(merge_workbooks(jan, feb, on_conflict="replace")) == 2
+    assert amount(merge_workbooks(jan, feb, on_conflict="keep")) == 1
+    appended = merge_workbooks(jan, feb, on_conflict="append")
+    assert [row.r for row in appended.sheets["Report"].rows] == [2, 3, 4, 5]
+    assert merge_workbooks(jan).book_name == "jan"
+    with pytest.raises(ValueError, match="Duplicate sheet name 'Report'"):
+        merge_workbooks(jan, feb, on_conflict="error")
+    with pytest.raises(ValueError):
+        merge_workbooks()
+
+
+def test_append_sheet_renumbers_rows_and_tables() -> None:
+    jan = _month("jan", 1).sheets["Report"]
+    feb = _month("feb", 2).sheets["Report"]
+
+    combined = append_sheet(jan, feb, skip_rows=1)
+
+    assert [(row.r, row.c) for row in combined.rows] == [
+        (2, {"0": "Item", "1": "Amount"}),
+        (3, {"0": "Pen", "1": 1}),
+        (4, {"0": "Pen", "1": 2}),
+    ]
+    assert combined.table_candidates == ["A2:B3", "A4:B4"]
+    assert append_sheet(jan, feb, skip_rows=5).rows == jan.rows
+    assert append_sheet(SheetData(), feb).rows == feb.rows
+    assert len(jan.rows) == 2
@@ -0,0 +1,85 @@
+"""Tests for extraction timing and size metrics."""
+
+from pathlib import Path
+
+from openpyxl import Workbook
+from openpyxl.chart import BarChart, Reference
+
+from exstruct.engine import ExStructEngine, StructOptions
+from exstruct.metrics import MetricsRegistry, collect_metrics
+from exstruct.models import CellRow, SheetData, WorkbookData
+from exstruct.ooxml.summary import measure_sheet_parts
+
+
+def _book(tmp_path: Path) -> Path:
+    path = tmp_path / "metrics.xlsx"
+    wb = Workbook()
+    ws = wb.active
+    ws.title = "Data"
+    for row in range(1, 6):
+        ws.append([f"item{row}", row])
+    chart = BarChart()
+    chart.add_data(Reference(ws, min_col=2, min_row=1, max_row=5))
+    ws.add_chart(chart, "D2")
+    wb.create_sheet("Empty")
+    wb.save(path)
+    return path
+
+
+def test_measure_sheet_parts_sizes_related_parts(tmp_path: Path) -> None:
+    parts = measure_sheet_parts(_book(tmp_path))
+
+    assert parts["Data"].part_bytes > 0
+    assert parts["Data"].parse_seconds >= 0
+    assert parts["Data"].related_bytes > 0  # drawing + chart parts
+    assert parts["Empty"].related_bytes == 0
+
+
+def test_collect_metrics_counts_extracted_items(tmp_path: Path) -> None:
+    path = _book(tmp_path)
+    workbook = WorkbookData(
+        book_name=path.name,
+        sheets={"Data": SheetData(rows=[CellRow(r=1, c={"0": "a", "1": 1})])},
+    )
+
+    metrics = collect_metrics(workbook, path, extract_seconds=1.23456)
+
+    assert metrics.extract_seconds == 1.2346
+    assert metrics.file_bytes == path.stat().st_size
+    assert list(metrics.sheets) == ["Data"]
+    assert (metrics.sheets["Data"].rows, metrics.sheets["Data"].cells) == (1, 2)
+    assert metrics.sheets["Data"].part_bytes > 0
+
+
+def test_include_metrics_option(tmp_path: Path) -> None:
+    path = _book(tmp_path)
+
+    plain = ExStructEngine(options=StructOptions(mode="light")).extract(path)
+    workbook = ExStructEngine(
+        options=StructOptions(mode="light", include_metrics=True)
+    ).extract(path)
+
+    assert plain.metrics is None
+    assert workbook.metrics is not None
+    assert workbook.metrics.sheets["Data"].rows == 5
+    assert workbook.metrics.extract_seconds >= 0
+
+
+def test_metrics_registry_renders_prometheus_text() -> None:
+    registry = MetricsRegistry(buckets=(1, 5))
+    registry.observe("ok", 0.5, {"sheets": 2, "rows": 10, "unknown": 1})
+    registry.observe("ok", 3.0)
+    registry.observe("error", 9.0)
+
+    lines = registry.render().splitlines()
+
+    assert "# TYPE exstruct_extractions_total counter" in lines
+    assert 'exstruct_extractions_total{status="ok"} 2' in lines
+    assert 'exstruct_extractions_total{status="error"} 1' in lines
+    assert 'exstruct_extraction_duration_seconds_bucket{le="1"} 1' in lines
+    assert 'exstruct_extraction_duration_seconds_bucket{le="5"} 2' in lines
+    assert 'exstruct_extraction_duration_seconds_bucket{le="+Inf"} 3' in lines
+    assert "exstruct_extraction_duration_seconds_sum 12.500000" in lines
+    assert "exstruct_extraction_duration_seconds_count 3" in lines
+    assert 'exstruct_extracted_items_total{kind="rows"} 10' in lines
+    assert not any("unknown" in line for line in lines)
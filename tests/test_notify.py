@@ -0,0 +1,206 @@
+"""Tests for run completion notifications."""
+
+from __future__ import annotations
+
+from collections.abc import Iterator
+from http.server import BaseHTTPRequestHandler, HTTPServer
+import json
+from pathlib import Path
+import threading
+
+from openpyxl import Workbook
+import pytest
+
+from exstruct.cli.main import EXIT_NOT_FOUND, EXIT_OK, main as cli_main
+from exstruct.engine import RunReport
+from exstruct.errors import OutputError
+from exstruct.notify import build_run_summary, post_run_summary
+
+
+@pytest.fixture
+def webhook() -> Iterator[tuple[str, list[dict[str, object]]]]:
+    received: list[dict[str, object]] = []
+
+    class _Handler(BaseHTTPRequestHandler):
+        def do_POST(self) -> None:  # noqa: N802
+            length = int(self.headers["Content-Length"])
+            received.append(json.loads(self.rfile.read(length)))
+            status = 500 if self.path == "/fail" else 204
+            self.send_response(status)
+            self.end_headers()
+
+        def log_message(self, *_args: object) -> None:
+            return
+
+    server = HTTPServer(("127.0.0.1", 0), _Handler)
+    thread = threading.Thread(target=server.serve_forever, daemon=True)
+    thread.start()
+    try:
+        yield f"http://127.0.0.1:{server.server_port}", received
+    finally:
+        server.shutdown()
+        server.server_close()
+
+
+def test_build_run_summary_counts_failures() -> None:
+    ok = RunReport(file="a.xlsx", exit_code=0, counts={"sheets": 2})
+    ok.durations["total"] = 1.5
+    failed = RunReport(file="b.xlsx", status="error", error="bad zip", exit_code=4)
+
+    summary = build_run_summary([ok, failed], ["out/"])
+
+    assert summary["status"] == "error"
+    assert summary["files_processed"] == 2
+    assert summary["failures"] == 1
+    assert summary["outputs"] == ["out/"]
+    files = summary["files"]
+    assert isinstance(files, list)
+    assert files[0]["duration_seconds"] == 1.5
+    assert files[1]["error"] == "bad zip"
+    partial = build_run_summary([RunReport(file="c.xlsx", status="partial")], [])
+    assert partial["status"] == "partial"
+
+
+def test_post_run_summary(webhook: tuple[str, list[dict[str, object]]]) -> None:
+    url, received = webhook
+
+    status = post_run_summary(url, {"event": "run.finished"})
+
+    assert status == 204
+    assert received == [{"event": "run.finished"}]
+    with pytest.raises(OutputError):
+        post_run_summary(f"{url}/fail", {"event": "run.finished"})
+
+
+def test_post_run_summary_rejects_non_http() -> None:
+    with pytest.raises(ValueError):
+        post_run_summary("file:///tmp/hook", {})
+
+
+def test_cli_notify_url_posts_summary(
+    tmp_path: Path,
+    monkeypatch: pytest.MonkeyPatch,
+    webhook: tuple[str, list[dict[str, object]]],
+) -> None:
+    url, received = webhook
+    book = tmp_path / "book.xlsx"
+    book.write_bytes(b"placeholder")
+    out = tmp_path / "out.json"
+    monkeypatch.setattr("exstruct.cli.main.process_excel", lambda **_: None)
+
+    code = cli_main([str(book), "-o", str(out), "--notify-url", url])
+
+    assert code == EXIT_OK
+    assert received[0]["status"] == "ok"
+    assert received[0]["files_processed"] == 1
+    assert received[0]["outputs"] == [str(out)]
+
+
+def test_cli_notify_url_reports_early_failure(
+    tmp_path: Path, webhook: tuple[str, list[dict[str, object]]]
+) -> None:
+    url, received = webhook
+
+    code = cli_main([str(tmp_path / "missing.xlsx"), "--notify-url", url])
+
+    assert code == EXIT_NOT_FOUND
+    assert received[0]["failures"] == 1
+    assert received[0]["outputs"] == ["stdout"]
+
+
+def test_cli_notify_failure_keeps_exit_code(
+    tmp_path: Path,
+    monkeypatch: pytest.MonkeyPatch,
+    capsys: pytest.CaptureFixture[str],
+    webhook: tuple[str, list[dict[str, object]]],
+) -> None:
+    url, _ = webhook
+    book = tmp_path / "book.xlsx"
+    book.write_bytes(b"placeholder")
+    monkeypatch.setattr("exstruct.cli.main.process_excel", lambda **_: None)
+
+    code = cli_main([str(book), "--notify-url", f"{url}/fail"])
+
+    assert code == EXIT_OK
+    assert "Failed to send notification" in capsys.readouterr().out
+
+
+def test_catalog_notify_url_posts_aggregated_summary(
+    tmp_path: Path, webhook: tuple[str, list[dict[str, object]]]
+) -> None:
+    url, received = webhook
+    books = tmp_path / "books"
+    books.mkdir()
+    Workbook().save(books / "good.xlsx")
+    (books / "broken.xlsx").write_bytes(b"placeholder")
+    out = tmp_path / "catalog.json"
+
+    code = cli_main(["catalog", str(books), "-o", str(out), "--notify-url", url])
+
+    assert code == EXIT_OK
+    assert len(received) == 1
+    summary = received[0]
+    assert summary["event"] == "catalog.finished"
+    assert summary["status"] == "error"
+    assert summary["files_processed"] == 2
+    assert summary["failures"] == 1
+    assert summary["outputs"] == [str(out)]
+    files = {Path(str(f["file"])).name: f for f in summary["files"]}
+    assert files["good.xlsx"]["counts"]["sheets"] == 1
+    assert files["broken.xlsx"]["error"]
+
+
+def test_catalog_notify_url_reports_missing_directory(
+    tmp_path: Path, webhook: tuple[str, list[dict[str, object]]]
+) -> None:
+    url, received = webhook
+
+    code = cli_main(["catalog", str(tmp_path / "missing"), "--notify-url", url])
+
+    assert code == 1
+    assert received[0]["event"] == "catalog.finished"
+    assert received[0]["failures"] == 1
+    assert received[0]["outputs"] == ["stdout"]
+
+
+def test_batch_notify_url_posts_one_summary(
+    tmp_path: Path, webhook: tuple[str, list[dict[str, object]]]
+) -> None:
+    url, received = webhook
+    books = tmp_path / "books"
+    books.mkdir()
+    Workbook().save(books / "good.xlsx")
+    (books / "broken.xlsx").write_bytes(b"placeholder")
+    out = tmp_path / "out"
+
+    code = cli_main(
+        ["batch", str(books), "-o", str(out), "-m", "light", "--notify-url", url]
+    )
+
+    assert code == 1
+    assert len(received) == 1
+    summary = received[0]
+    assert summary["event"] == "batch.finished"
+    assert summary["files_processed"] == 2
+    assert summary["failures"] == 1
+    assert summary["outputs"] == [str(out / "good.json")]
+
+
+def test_watch_notify_url_posts_after_each_extracting_scan(
+    tmp_path: Path, webhook: tuple[str, list[dict[str, object]]]
+) -> None:
+    url, received = webhook
+    inbox = tmp_path / "inbox"
+    inbox.mkdir()
+    Workbook().save(inbox / "book.xlsx")
+    out = tmp_path / "out"
+
+    code = cli_main(
+        ["watch", str(inbox), "-o", str(out), "-m", "light", "--notify-url", url]
+        + ["--interval", "0", "--max-scans", "3"]
+    )
+
+    assert code == 0
+    assert len(received) == 1
+    assert received[0]["event"] == "watch.finished"
+    assert received[0]["outputs"] == [str(out / "book.json")]
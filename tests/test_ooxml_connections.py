@@ -0,0 +1,108 @@
+"""Tests for external data connection listing."""
+
+from pathlib import Path
+import zipfile
+
+from openpyxl import Workbook
+
+from exstruct.engine import ExStructEngine, StructOptions
+from exstruct.ooxml.connections import mask_credentials, read_connections
+
+_MAIN = "http://schemas.openxmlformats.org/spreadsheetml/2006/main"
+_REL = "http://schemas.openxmlformats.org/officeDocument/2006/relationships"
+_RELS = "http://schemas.openxmlformats.org/package/2006/relationships"
+
+_CONNECTIONS = (
+    f'<connections xmlns="{_MAIN}">'
+    '<connection id="1" name="Sales DB" type="5" refreshOnLoad="1" '
+    'background="1" interval="30">'
+    '<dbPr connection="Provider=SQLOLEDB;Data Source=db01;User ID=sa;'
+    'Password=hunter2" command="SELECT * FROM sales" commandType="2"/>'
+    "</connection>"
+    '<connection id="2" name="Rates" type="4">'
+    '<webPr url="https://bob:pw@example.com/rates"/></connection>'
+    "</connections>"
+)
+
+
+def _book(tmp_path: Path) -> Path:
+    path = tmp_path / "connections.xlsx"
+    wb = Workbook()
+    wb.active.title = "Data"
+    wb.save(path)
+    with zipfile.ZipFile(path, "a") as zf:
+        zf.writestr("xl/connections.xml", _CONNECTIONS)
+        zf.writestr(
+            "xl/worksheets/_rels/sheet1.xml.rels",
+            f'<Relationships xmlns="{_RELS}">'
+            f'<Relationship Id="rId1" Type="{_REL}/table" '
+            'Target="../tables/table1.xml"/></Relationships>',
+        )
+        zf.writestr(
+            "xl/tables/table1.xml",
+            f'<table xmlns="{_MAIN}" id="1" name="Sales" displayName="Sales" '
+            'ref="A1:C20" tableType="queryTable"/>',
+        )
+        zf.writestr(
+            "xl/tables/_rels/table1.xml.rels",
+            f'<Relationships xmlns="{_RELS}">'
+            f'<Relationship Id="rId1" Type="{_REL}/queryTable" '
+            'Target="../queryTables/queryTable1.xml"/></Relationships>',
+        )
+        zf.writestr(
+            "xl/queryTables/queryTable1.xml",
+            f'<queryTable xmlns="{_MAIN}" name="Sales" connectionId="1"/>',
+        )
+    return path
+
+
+def test_mask_credentials() -> None:
+    assert (
+        mask_credentials('DSN=x;UID=bob;PWD="a;b";Database=db')
+        == "DSN=x;UID=***;PWD=***;Database=db"
+    )
+    assert mask_credentials("https://bob:pw@example.com/a") == (
+        "https://***@example.com/a"
+    )
+    assert mask_credentials("Data Source=db01") == "Data Source=db01"
+
+
+def test_read_connections_reports_sources_and_bindings(tmp_path: Path) -> None:
+    connections = read_connections(_book(tmp_path))
+
+    sales, rates = connections
+    assert (sales.id, sales.name, sales.kind) == (1, "Sales DB", "oledb")
+    assert sales.connection_string == (
+        "Provider=SQLOLEDB;Data Source=db01;User ID=***;Password=***"
+    )
+    assert sales.command == "SELECT * FROM sales"
+    assert (sales.refresh_on_load, sales.background_refresh) == (True, True)
+    assert sales.refresh_interval == 30
+    assert [(b.kind, b.sheet, b.name, b.range) for b in sales.bindings] == [
+        ("query_table", "Data", "Sales", "A1:C20")
+    ]
+    assert rates.kind == "web"
+    assert rates.url == "https://***@example.com/rates"
+    assert rates.bindings == []
+
+
+def test_read_connections_without_part(tmp_path: Path) -> None:
+    path = tmp_path / "plain.xlsx"
+    Workbook().save(path)
+
+    assert read_connections(path) == []
+
+
+def test_include_connections_option(tmp_path: Path) -> None:
+    path = _book(tmp_path)
+
+    plain = ExStructEngine(options=StructOptions(mode="light")).extract(path)
+    workbook = ExStructEngine(
+        options=StructOptions(mode="light", include_connections=True)
+    ).extract(path)
+
+    assert plain.connections == []
+    assert [connection.name for connection in workbook.connections] == [
+        "Sales DB",
+        "Rates",
+    ]
@@ -12,7 +12,7 @@ from pathlib import Path
 import pytest
 
 from exstruct.ooxml import get_charts_ooxml, get_shapes_ooxml
-from exstruct.ooxml.units import emu_to_pixels, emu_to_points
+from exstruct.ooxml.units import PositionScale, emu_to_pixels, emu_to_points
 
 
 # ---------------------------------------------------------------------------
@@ -120,6 +120,20 @@ class TestUnits:
     def test_emu_to_points_half_inch(self) -> None:
         assert emu_to_points(457200) == 36.0
 
+    def test_position_scale_from_emu(self) -> None:
+        assert PositionScale().from_emu(914400) == 96
+        assert PositionScale("pixels", dpi=300).from_emu(914400) == 300
+        assert PositionScale("points").from_emu(914400) == 72
+        assert PositionScale("millimeters").from_emu(914400) == 25
+        assert PositionScale("emu").from_emu(914400) == 914400
+
+    def test_position_scale_from_points(self) -> None:
+        assert PositionScale().points_per_unit == 0.75
+        assert PositionScale().from_points(72) == 96
+        assert PositionScale("pixels", dpi=72).from_points(72) == 72
+        assert PositionScale("emu").from_points(1) == 12700
+        assert PositionScale("millimeters").from_points(72) == 25
+
 
 # ---------------------------------------------------------------------------
 # Shape extraction tests
@@ -254,6 +268,12 @@ class TestShapesEquivalentToCom:
             ids = [s.id for s in shapes if s.id is not None]
             assert len(ids) == len(set(ids))
 
+    def test_図形に文書順のz_orderが割り当てられる(self, ooxml_test_xlsx: Path) -> None:
+        shapes_by_sheet = get_shapes_ooxml(ooxml_test_xlsx)
+        for shapes in shapes_by_sheet.values():
+            z_orders = [s.z_order for s in shapes]
+            assert z_orders == list(range(1, len(shapes) + 1))
+
     def test_lightモードでは図形が抽出されない(self, ooxml_test_xlsx: Path) -> None:
         result = get_shapes_ooxml(ooxml_test_xlsx, mode="light")
         assert result == {}
@@ -349,3 +369,1034 @@ class TestOoxmlIntegration:
         charts_by_sheet = get_charts_ooxml(ooxml_test_xlsx)
         all_titles = [c.title for charts in charts_by_sheet.values() for c in charts if c.title]
         assert "売上データ" in all_titles
+
+
+# ---------------------------------------------------------------------------
+# Connector direction tests
+# ---------------------------------------------------------------------------
+
+def _connector_drawing_xml(xfrm_attrs: str) -> bytes:
+    """Build a drawing part with a single straight connector."""
+    return f"""<?xml version="1.0" encoding="UTF-8"?>
+<xdr:wsDr xmlns:xdr="http://schemas.openxmlformats.org/drawingml/2006/spreadsheetDrawing"
+          xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main">
+  <xdr:twoCellAnchor>
+    <xdr:cxnSp>
+      <xdr:nvCxnSpPr><xdr:cNvPr id="5" name="Connector 4"/><xdr:cNvCxnSpPr/></xdr:nvCxnSpPr>
+      <xdr:spPr>
+        <a:xfrm {xfrm_attrs}><a:off x="0" y="0"/><a:ext cx="952500" cy="952500"/></a:xfrm>
+        <a:prstGeom prst="straightConnector1"/>
+        <a:ln><a:tailEnd type="triangle"/></a:ln>
+      </xdr:spPr>
+    </xdr:cxnSp>
+  </xdr:twoCellAnchor>
+</xdr:wsDr>""".encode()
+
+
+class TestConnectorLineFormat:
+    """Tests for connector arrowhead size and line style."""
+
+    def test_arrow_sizes_and_line_style(self) -> None:
+        from exstruct.models import Arrow
+        from exstruct.ooxml.drawing import _parse_drawing_xml
+
+        xml = _connector_drawing_xml("").replace(
+            b'<a:ln><a:tailEnd type="triangle"/></a:ln>',
+            b'<a:ln w="25400"><a:prstDash val="dash"/>'
+            b'<a:headEnd type="oval" w="sm" len="lg"/>'
+            b'<a:tailEnd type="triangle"/></a:ln>',
+        )
+        shapes = _parse_drawing_xml(xml, "standard")
+        arrow = shapes[0]
+        assert isinstance(arrow, Arrow)
+        assert arrow.begin_arrow_width == 1
+        assert arrow.begin_arrow_length == 3
+        assert arrow.end_arrow_width == 2
+        assert arrow.end_arrow_length == 2
+        assert arrow.line_dash_style == 4
+        assert arrow.line_weight == pytest.approx(2.0)
+
+    def test_line_format_absent_without_ln(self) -> None:
+        from exstruct.models import Arrow
+        from exstruct.ooxml.drawing import _parse_drawing_xml
+
+        xml = _connector_drawing_xml("").replace(
+            b'<a:ln><a:tailEnd type="triangle"/></a:ln>', b""
+        )
+        arrow = _parse_drawing_xml(xml, "standard")[0]
+        assert isinstance(arrow, Arrow)
+        assert arrow.end_arrow_width is None
+        assert arrow.line_dash_style is None
+        assert arrow.line_weight is None
+
+
+class TestConnectorDirection:
+    """Tests for flip-aware connector direction."""
+
+    @pytest.mark.parametrize(
+        ("xfrm_attrs", "expected"),
+        [
+            ("", "SE"),
+            ('flipH="1"', "SW"),
+            ('flipV="1"', "NE"),
+            ('flipH="1" flipV="1"', "NW"),
+            ('rot="5400000"', "SW"),
+        ],
+    )
+    def test_connector_direction_honors_flips(
+        self, xfrm_attrs: str, expected: str
+    ) -> None:
+        from exstruct.models import Arrow
+        from exstruct.ooxml.drawing import _parse_drawing_xml
+
+        shapes = _parse_drawing_xml(_connector_drawing_xml(xfrm_attrs), "standard")
+        assert len(shapes) == 1
+        arrow = shapes[0]
+        assert isinstance(arrow, Arrow)
+        assert arrow.direction == expected
+        assert arrow.end_arrow_style == 2
+
+    def test_connector_records_endpoint_coordinates(self) -> None:
+        from exstruct.models import Arrow
+        from exstruct.ooxml.drawing import _parse_drawing_xml
+
+        shapes = _parse_drawing_xml(_connector_drawing_xml('flipH="1"'), "standard")
+        arrow = shapes[0]
+        assert isinstance(arrow, Arrow)
+        assert arrow.begin_x is not None and arrow.end_x is not None
+        assert arrow.begin_x > arrow.end_x
+        assert arrow.begin_y is not None and arrow.end_y is not None
+        assert arrow.begin_y < arrow.end_y
+
+    def test_connector_points_follow_flips(self) -> None:
+        from exstruct.ooxml.drawing import compute_connector_points
+
+        start, end = compute_connector_points(10, 20, 100, 50, flip_h=True)
+        assert start == (110, 20)
+        assert end == (10, 70)
+
+
+def _text_shape_drawing_xml(body_pr: str, p_pr: str) -> bytes:
+    """Build a drawing part with a single rectangle holding text."""
+    return f"""<?xml version="1.0" encoding="UTF-8"?>
+<xdr:wsDr xmlns:xdr="http://schemas.openxmlformats.org/drawingml/2006/spreadsheetDrawing"
+          xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main">
+  <xdr:twoCellAnchor>
+    <xdr:sp>
+      <xdr:nvSpPr><xdr:cNvPr id="2" name="Title"/><xdr:cNvSpPr/></xdr:nvSpPr>
+      <xdr:spPr>
+        <a:xfrm><a:off x="0" y="0"/><a:ext cx="952500" cy="476250"/></a:xfrm>
+        <a:prstGeom prst="rect"/>
+      </xdr:spPr>
+      <xdr:txBody>
+        {body_pr}
+        <a:p>{p_pr}<a:r><a:t>見出し</a:t></a:r></a:p>
+      </xdr:txBody>
+    </xdr:sp>
+  </xdr:twoCellAnchor>
+</xdr:wsDr>""".encode()
+
+
+class TestShapeTextLayout:
+    """Tests for text alignment, anchor, and wrap from txBody."""
+
+    def test_explicit_layout(self) -> None:
+        from exstruct.models import Shape
+        from exstruct.ooxml.drawing import _parse_drawing_xml
+
+        xml = _text_shape_drawing_xml(
+            '<a:bodyPr anchor="ctr" wrap="none"/>', '<a:pPr algn="r"/>'
+        )
+        shape = _parse_drawing_xml(xml, "standard")[0]
+        assert isinstance(shape, Shape)
+        assert shape.text_align == "right"
+        assert shape.text_anchor == "middle"
+        assert shape.text_wrap is False
+
+    def test_defaults_when_unspecified(self) -> None:
+        from exstruct.models import Shape
+        from exstruct.ooxml.drawing import _parse_drawing_xml
+
+        xml = _text_shape_drawing_xml("<a:bodyPr/>", "")
+        shape = _parse_drawing_xml(xml, "standard")[0]
+        assert isinstance(shape, Shape)
+        assert shape.text_align == "left"
+        assert shape.text_anchor == "top"
+        assert shape.text_wrap is True
+        assert shape.text_orientation == "horizontal"
+
+    @pytest.mark.parametrize(
+        ("vert", "expected"),
+        [
+            ("eaVert", "east_asian_vertical"),
+            ("vert", "vertical"),
+            ("vert270", "vertical270"),
+            ("wordArtVert", "stacked"),
+        ],
+    )
+    def test_vertical_text(self, vert: str, expected: str) -> None:
+        from exstruct.models import Shape
+        from exstruct.ooxml.drawing import _parse_drawing_xml
+
+        xml = _text_shape_drawing_xml(f'<a:bodyPr vert="{vert}"/>', "")
+        shape = _parse_drawing_xml(xml, "standard")[0]
+        assert isinstance(shape, Shape)
+        assert shape.text_orientation == expected
+        assert shape.text == "見出し"
+
+
+def _stable_ids_drawing_xml() -> bytes:
+    """Build a drawing part with two boxes joined by a connector."""
+    box = (
+        '<xdr:twoCellAnchor><xdr:sp><xdr:nvSpPr><xdr:cNvPr id="{id}" name="Box {id}"/>'
+        "<xdr:cNvSpPr/></xdr:nvSpPr><xdr:spPr>"
+        '<a:xfrm><a:off x="{x}" y="0"/><a:ext cx="952500" cy="476250"/></a:xfrm>'
+        '<a:prstGeom prst="rect"/></xdr:spPr><xdr:txBody><a:bodyPr/>'
+        "<a:p><a:r><a:t>Box {id}</a:t></a:r></a:p></xdr:txBody></xdr:sp>"
+        "</xdr:twoCellAnchor>"
+    )
+    return f"""<?xml version="1.0" encoding="UTF-8"?>
+<xdr:wsDr xmlns:xdr="http://schemas.openxmlformats.org/drawingml/2006/spreadsheetDrawing"
+          xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main">
+  {box.format(id=7, x=0)}
+  {box.format(id=3, x=1905000)}
+  <xdr:twoCellAnchor>
+    <xdr:cxnSp>
+      <xdr:nvCxnSpPr><xdr:cNvPr id="9" name="Connector 8"/>
+        <xdr:cNvCxnSpPr><a:stCxn id="7" idx="3"/><a:endCxn id="3" idx="1"/></xdr:cNvCxnSpPr>
+      </xdr:nvCxnSpPr>
+      <xdr:spPr>
+        <a:xfrm><a:off x="952500" y="238125"/><a:ext cx="952500" cy="0"/></a:xfrm>
+        <a:prstGeom prst="straightConnector1"/>
+      </xdr:spPr>
+    </xdr:cxnSp>
+  </xdr:twoCellAnchor>
+</xdr:wsDr>""".encode()
+
+
+class TestStableShapeIds:
+    """Tests for the ``stable_ids`` option of the drawing parser."""
+
+    def test_sequential_ids_by_default(self) -> None:
+        from exstruct.models import Arrow
+        from exstruct.ooxml.drawing import _parse_drawing_xml
+
+        shapes = _parse_drawing_xml(_stable_ids_drawing_xml(), "standard")
+        assert [s.id for s in shapes] == [1, 2, None]
+        arrow = shapes[2]
+        assert isinstance(arrow, Arrow)
+        assert (arrow.begin_id, arrow.end_id) == (1, 2)
+        assert all(s.source_id is None for s in shapes)
+
+    def test_stable_ids_use_cnvpr_ids(self) -> None:
+        from exstruct.models import Arrow
+        from exstruct.ooxml.drawing import _parse_drawing_xml
+
+        shapes = _parse_drawing_xml(
+            _stable_ids_drawing_xml(),
+            "standard",
+            stable_ids=True,
+            part_name="xl/drawings/drawing1.xml",
+        )
+        assert [s.id for s in shapes] == [7, 3, None]
+        arrow = shapes[2]
+        assert isinstance(arrow, Arrow)
+        assert (arrow.begin_id, arrow.end_id) == (7, 3)
+        assert [s.source_id for s in shapes] == [
+            "xl/drawings/drawing1.xml#7",
+            "xl/drawings/drawing1.xml#3",
+            "xl/drawings/drawing1.xml#9",
+        ]
+
+    def test_stable_ids_from_workbook(self, ooxml_test_xlsx: Path) -> None:
+        default = get_shapes_ooxml(ooxml_test_xlsx)
+        stable = get_shapes_ooxml(ooxml_test_xlsx, stable_ids=True)
+        for sheet, shapes in stable.items():
+            assert len(shapes) == len(default[sheet])
+            for shape in shapes:
+                assert shape.source_id is not None
+                part, _, drawing_id = shape.source_id.partition("#")
+                assert part.startswith("xl/drawings/")
+                if shape.id is not None:
+                    assert shape.id == int(drawing_id)
+
+    def test_resolve_stable_ids_renumbers_missing_and_duplicates(self) -> None:
+        from exstruct.ooxml.drawing import resolve_stable_ids
+
+        assert resolve_stable_ids([4, None, 2, 4, 0]) == [4, 5, 2, 6, 7]
+        assert resolve_stable_ids([None, None]) == [1, 2]
+
+
+def _chart_xml(plot_area: str, chart_extra: str = "") -> bytes:
+    """Build a chart part with the given plot area body."""
+    return f"""<?xml version="1.0" encoding="UTF-8"?>
+<c:chartSpace xmlns:c="http://schemas.openxmlformats.org/drawingml/2006/chart"
+              xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main">
+  <c:chart>
+    <c:plotArea>{plot_area}</c:plotArea>
+    {chart_extra}
+  </c:chart>
+</c:chartSpace>""".encode()
+
+
+def _series_xml(index: int, body: str = "") -> str:
+    """Build a c:ser element referencing Sheet1 columns."""
+    return f"""<c:ser><c:idx val="{index}"/><c:order val="{index}"/>
+<c:tx><c:v>S{index}</c:v></c:tx>{body}
+<c:val><c:numRef><c:f>Sheet1!$B$2:$B$4</c:f></c:numRef></c:val></c:ser>"""
+
+
+class TestChartLegendAndLabels:
+    """Tests for chart legend and data label parsing."""
+
+    def test_legend_position_and_series_labels(self) -> None:
+        from exstruct.ooxml.chart import _parse_chart_xml
+
+        labels = (
+            '<c:dLbls><c:numFmt formatCode="0%" sourceLinked="0"/>'
+            '<c:showVal val="0"/><c:showPercent val="1"/></c:dLbls>'
+        )
+        xml = _chart_xml(
+            f"<c:pieChart>{_series_xml(0, labels)}</c:pieChart>",
+            '<c:legend><c:legendPos val="b"/></c:legend>',
+        )
+        chart = _parse_chart_xml(xml, "Chart 1", 0, 0, 100, 100)
+        assert chart is not None
+        assert chart.legend is not None
+        assert chart.legend.visible is True
+        assert chart.legend.position == "bottom"
+        data_labels = chart.series[0].data_labels
+        assert data_labels is not None
+        assert data_labels.show_percentage is True
+        assert data_labels.show_value is False
+        assert data_labels.number_format == "0%"
+
+    def test_group_labels_apply_and_missing_legend_is_hidden(self) -> None:
+        from exstruct.ooxml.chart import _parse_chart_xml
+
+        deleted = '<c:dLbls><c:delete val="1"/></c:dLbls>'
+        xml = _chart_xml(
+            f"<c:lineChart>{_series_xml(0)}{_series_xml(1, deleted)}"
+            "<c:dLbls><c:showVal/></c:dLbls></c:lineChart>"
+        )
+        chart = _parse_chart_xml(xml, "Chart 1", 0, 0, 100, 100)
+        assert chart is not None
+        assert chart.legend is not None
+        assert chart.legend.visible is False
+        first = chart.series[0].data_labels
+        assert first is not None and first.show_value is True
+        assert chart.series[1].data_labels is None
+
+
+class TestChartGrouping:
+    """Tests for bar/area grouping and bar direction."""
+
+    @pytest.mark.parametrize(
+        ("plot_area", "chart_type", "grouping", "bar_direction"),
+        [
+            (
+                '<c:barChart><c:barDir val="col"/><c:grouping val="clustered"/>'
+                "</c:barChart>",
+                "ColumnClustered",
+                "clustered",
+                "vertical",
+            ),
+            (
+                '<c:barChart><c:barDir val="bar"/><c:grouping val="stacked"/>'
+                "</c:barChart>",
+                "BarStacked",
+                "stacked",
+                "horizontal",
+            ),
+            (
+                '<c:barChart><c:barDir val="col"/>'
+                '<c:grouping val="percentStacked"/></c:barChart>',
+                "ColumnStacked100",
+                "percent_stacked",
+                "vertical",
+            ),
+            (
+                '<c:areaChart><c:grouping val="stacked"/></c:areaChart>',
+                "AreaStacked",
+                "stacked",
+                None,
+            ),
+            ("<c:pieChart/>", "Pie", None, None),
+        ],
+    )
+    def test_grouping_and_direction(
+        self,
+        plot_area: str,
+        chart_type: str,
+        grouping: str | None,
+        bar_direction: str | None,
+    ) -> None:
+        from exstruct.ooxml.chart import _parse_chart_xml
+
+        chart = _parse_chart_xml(_chart_xml(plot_area), "Chart 1", 0, 0, 100, 100)
+        assert chart is not None
+        assert chart.chart_type == chart_type
+        assert chart.grouping == grouping
+        assert chart.bar_direction == bar_direction
+
+
+class TestChartTrendlinesAndErrorBars:
+    """Tests for series trendline and error bar parsing."""
+
+    def test_trendline_and_error_bars(self) -> None:
+        from exstruct.ooxml.chart import _parse_chart_xml
+
+        body = (
+            "<c:trendline><c:trendlineType val=\"poly\"/><c:order val=\"3\"/>"
+            "<c:dispRSqr val=\"1\"/><c:dispEq val=\"1\"/></c:trendline>"
+            "<c:trendline><c:name>MA</c:name><c:trendlineType val=\"movingAvg\"/>"
+            "<c:period val=\"4\"/></c:trendline>"
+            "<c:errBars><c:errDir val=\"y\"/><c:errBarType val=\"plus\"/>"
+            "<c:errValType val=\"percentage\"/><c:val val=\"5\"/></c:errBars>"
+        )
+        xml = _chart_xml(f"<c:lineChart>{_series_xml(0, body)}</c:lineChart>")
+        chart = _parse_chart_xml(xml, "Chart 1", 0, 0, 100, 100)
+        assert chart is not None
+        series = chart.series[0]
+        assert [t.type for t in series.trendlines] == ["polynomial", "moving_average"]
+        poly, moving = series.trendlines
+        assert poly.order == 3
+        assert poly.display_equation is True
+        assert poly.display_r_squared is True
+        assert moving.name == "MA"
+        assert moving.period == 4
+        assert moving.order is None
+        assert len(series.error_bars) == 1
+        bars = series.error_bars[0]
+        assert bars.direction == "y"
+        assert bars.bar_type == "plus"
+        assert bars.value_type == "percentage"
+        assert bars.value == pytest.approx(5.0)
+
+    def test_series_without_extras(self) -> None:
+        from exstruct.ooxml.chart import _parse_chart_xml
+
+        xml = _chart_xml(f"<c:lineChart>{_series_xml(0)}</c:lineChart>")
+        chart = _parse_chart_xml(xml, "Chart 1", 0, 0, 100, 100)
+        assert chart is not None
+        assert chart.series[0].trendlines == []
+        assert chart.series[0].error_bars == []
+
+
+class TestPieSeriesCache:
+    """Tests for cached categories and values on pie/doughnut series."""
+
+    def test_pie_series_includes_caches(self) -> None:
+        from exstruct.ooxml.chart import _parse_chart_xml
+
+        ser = """<c:ser><c:idx val="0"/><c:order val="0"/>
+<c:cat><c:strRef><c:f>Deleted!$A$1:$A$3</c:f><c:strCache><c:ptCount val="3"/>
+<c:pt idx="0"><c:v>East</c:v></c:pt><c:pt idx="2"><c:v>West</c:v></c:pt>
+</c:strCache></c:strRef></c:cat>
+<c:val><c:numRef><c:f>Deleted!$B$1:$B$3</c:f><c:numCache><c:ptCount val="3"/>
+<c:pt idx="0"><c:v>10</c:v></c:pt><c:pt idx="1"><c:v>20.5</c:v></c:pt>
+<c:pt idx="2"><c:v>30</c:v></c:pt></c:numCache></c:numRef></c:val></c:ser>"""
+        xml = _chart_xml(f"<c:doughnutChart>{ser}</c:doughnutChart>")
+        chart = _parse_chart_xml(xml, "Chart 1", 0, 0, 100, 100)
+        assert chart is not None
+        series = chart.series[0]
+        assert series.x_range == "Deleted!$A$1:$A$3"
+        assert series.categories == ["East", None, "West"]
+        assert series.values == [10.0, 20.5, 30.0]
+
+    def test_non_pie_series_omits_caches(self) -> None:
+        from exstruct.ooxml.chart import _parse_chart_xml
+
+        xml = _chart_xml(f"<c:lineChart>{_series_xml(0)}</c:lineChart>")
+        chart = _parse_chart_xml(xml, "Chart 1", 0, 0, 100, 100)
+        assert chart is not None
+        assert chart.series[0].categories is None
+        assert chart.series[0].values is None
+
+
+class TestChartValueAxes:
+    """Tests for primary/secondary value axis parsing."""
+
+    def test_primary_and_secondary_axes(self) -> None:
+        from exstruct.ooxml.chart import _parse_chart_xml
+
+        plot_area = f"""
+<c:barChart><c:barDir val="col"/>{_series_xml(0)}
+<c:axId val="10"/><c:axId val="20"/></c:barChart>
+<c:lineChart>{_series_xml(1)}<c:axId val="30"/><c:axId val="40"/></c:lineChart>
+<c:catAx><c:axId val="10"/></c:catAx>
+<c:valAx><c:axId val="20"/><c:scaling><c:min val="0"/><c:max val="100"/></c:scaling>
+<c:title><c:tx><c:rich><a:p><a:r><a:t>Sales</a:t></a:r></a:p></c:rich></c:tx></c:title>
+<c:numFmt formatCode="#,##0" sourceLinked="0"/></c:valAx>
+<c:catAx><c:axId val="30"/></c:catAx>
+<c:valAx><c:axId val="40"/><c:scaling><c:logBase val="10"/></c:scaling>
+<c:numFmt formatCode="0%" sourceLinked="0"/></c:valAx>"""
+        chart = _parse_chart_xml(_chart_xml(plot_area), "Chart 1", 0, 0, 100, 100)
+        assert chart is not None
+        assert len(chart.value_axes) == 2
+        primary, secondary = chart.value_axes
+        assert primary.secondary is False
+        assert primary.title == "Sales"
+        assert primary.range == [0.0, 100.0]
+        assert primary.number_format == "#,##0"
+        assert primary.log_base is None
+        assert secondary.secondary is True
+        assert secondary.number_format == "0%"
+        assert secondary.log_base == pytest.approx(10.0)
+        assert chart.y_axis_title == "Sales"
+
+
+_USER_SHAPES_XML = """<?xml version="1.0" encoding="UTF-8"?>
+<c:userShapes xmlns:c="http://schemas.openxmlformats.org/drawingml/2006/chart"
+              xmlns:cdr="http://schemas.openxmlformats.org/drawingml/2006/chartDrawing"
+              xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main">
+  <cdr:relSizeAnchor>
+    <cdr:from><cdr:x>0.05</cdr:x><cdr:y>0.9</cdr:y></cdr:from>
+    <cdr:to><cdr:x>0.6</cdr:x><cdr:y>1</cdr:y></cdr:to>
+    <cdr:sp><cdr:nvSpPr><cdr:cNvPr id="2" name="TextBox 1"/><cdr:cNvSpPr/></cdr:nvSpPr>
+      <cdr:txBody><a:bodyPr/><a:p><a:r><a:t>Source: </a:t></a:r>
+        <a:r><a:t>2025 survey</a:t></a:r></a:p></cdr:txBody></cdr:sp>
+  </cdr:relSizeAnchor>
+  <cdr:absSizeAnchor>
+    <cdr:from><cdr:x>0.7</cdr:x><cdr:y>0.1</cdr:y></cdr:from>
+    <cdr:ext cx="952500" cy="476250"/>
+    <cdr:grpSp>
+      <cdr:sp><cdr:txBody><a:bodyPr/><a:p><a:r><a:t>Peak</a:t></a:r></a:p>
+      </cdr:txBody></cdr:sp>
+      <cdr:sp><cdr:txBody><a:bodyPr/><a:p/></cdr:txBody></cdr:sp>
+    </cdr:grpSp>
+  </cdr:absSizeAnchor>
+</c:userShapes>"""
+
+
+class TestChartTextBoxes:
+    """Tests for text boxes inside charts (chart drawing parts)."""
+
+    def test_parse_chart_text_boxes(self) -> None:
+        from exstruct.models import ChartTextBox
+        from exstruct.ooxml.chart import parse_chart_text_boxes
+
+        assert parse_chart_text_boxes(_USER_SHAPES_XML.encode()) == [
+            ChartTextBox(text="Source: 2025 survey", x=0.05, y=0.9),
+            ChartTextBox(text="Peak", x=0.7, y=0.1),
+        ]
+        assert parse_chart_text_boxes(b"<broken") == []
+
+    def test_text_boxes_follow_chart_rels(self, tmp_path: Path) -> None:
+        import zipfile
+
+        from exstruct.ooxml.chart import _load_chart_text_boxes
+
+        rels = (
+            '<Relationships xmlns="http://schemas.openxmlformats.org/package/'
+            '2006/relationships"><Relationship Id="rId1" Type="http://schemas.'
+            "openxmlformats.org/officeDocument/2006/relationships/"
+            'chartUserShapes" Target="../drawings/drawing2.xml"/></Relationships>'
+        )
+        path = tmp_path / "chart.zip"
+        with zipfile.ZipFile(path, "w") as zf:
+            zf.writestr("xl/charts/chart1.xml", _chart_xml(""))
+            zf.writestr("xl/charts/_rels/chart1.xml.rels", rels)
+            zf.writestr("xl/drawings/drawing2.xml", _USER_SHAPES_XML)
+            zf.writestr("xl/charts/chart2.xml", _chart_xml(""))
+
+        with zipfile.ZipFile(path) as zf:
+            boxes = _load_chart_text_boxes(zf, "xl/charts/chart1.xml")
+            none = _load_chart_text_boxes(zf, "xl/charts/chart2.xml")
+
+        assert [box.text for box in boxes] == ["Source: 2025 survey", "Peak"]
+        assert none == []
+
+
+class TestChartBlankCells:
+    """Tests for plotVisOnly / dispBlanksAs parsing."""
+
+    @pytest.mark.parametrize(
+        ("extra", "expected"),
+        [
+            (
+                '<c:plotVisOnly val="1"/><c:dispBlanksAs val="gap"/>',
+                (True, "gap"),
+            ),
+            (
+                '<c:plotVisOnly val="0"/><c:dispBlanksAs val="span"/>',
+                (False, "span"),
+            ),
+            ("<c:plotVisOnly/><c:dispBlanksAs/>", (True, "zero")),
+            ("", (None, None)),
+        ],
+    )
+    def test_blank_cell_settings(
+        self, extra: str, expected: tuple[bool | None, str | None]
+    ) -> None:
+        from exstruct.ooxml.chart import _parse_chart_xml
+
+        xml = _chart_xml(f"<c:lineChart>{_series_xml(0)}</c:lineChart>", extra)
+        chart = _parse_chart_xml(xml, "Chart 1", 0, 0, 100, 100)
+        assert chart is not None
+        assert (chart.plot_visible_only, chart.display_blanks_as) == expected
+
+
+def _xy_series_xml(index: int, body: str = "", tail: str = "") -> str:
+    """Build a scatter/bubble c:ser element with x and y value references."""
+    return f"""<c:ser><c:idx val="{index}"/><c:order val="{index}"/>
+<c:tx><c:v>S{index}</c:v></c:tx>{body}
+<c:xVal><c:numRef><c:f>Sheet1!$A$2:$A$4</c:f></c:numRef></c:xVal>
+<c:yVal><c:numRef><c:f>Sheet1!$B$2:$B$4</c:f></c:numRef></c:yVal>{tail}
+</c:ser>"""
+
+
+class TestScatterAndBubbleSeries:
+    """Tests for xVal/yVal/bubbleSize ranges, scatter style, and markers."""
+
+    def test_bubble_size_range(self) -> None:
+        from exstruct.ooxml.chart import _parse_chart_xml
+
+        size = (
+            "<c:bubbleSize><c:numRef><c:f>Sheet1!$C$2:$C$4</c:f></c:numRef>"
+            "</c:bubbleSize>"
+        )
+        xml = _chart_xml(
+            f"<c:bubbleChart>{_xy_series_xml(0, tail=size)}</c:bubbleChart>"
+        )
+        chart = _parse_chart_xml(xml, "Chart 1", 0, 0, 100, 100)
+        assert chart is not None
+        assert chart.chart_type == "Bubble"
+        assert chart.scatter_style is None
+        series = chart.series[0]
+        assert series.x_range == "Sheet1!$A$2:$A$4"
+        assert series.y_range == "Sheet1!$B$2:$B$4"
+        assert series.size_range == "Sheet1!$C$2:$C$4"
+        assert series.marker is None
+        assert series.smooth is None
+
+    def test_scatter_style_markers_and_smoothing(self) -> None:
+        from exstruct.ooxml.chart import _parse_chart_xml
+
+        marker = '<c:marker><c:symbol val="diamond"/><c:size val="9"/></c:marker>'
+        hidden = '<c:marker><c:symbol val="none"/></c:marker>'
+        smooth = _xy_series_xml(0, marker, '<c:smooth val="1"/>')
+        straight = _xy_series_xml(1, hidden, '<c:smooth val="0"/>')
+        xml = _chart_xml(
+            '<c:scatterChart><c:scatterStyle val="smoothMarker"/>'
+            f"{smooth}{straight}</c:scatterChart>"
+        )
+        chart = _parse_chart_xml(xml, "Chart 1", 0, 0, 100, 100)
+        assert chart is not None
+        assert chart.scatter_style == "smooth_marker"
+        first, second = chart.series
+        assert first.marker is not None
+        assert (first.marker.symbol, first.marker.size) == ("diamond", 9)
+        assert first.smooth is True
+        assert second.marker is not None
+        assert (second.marker.symbol, second.marker.size) == ("none", None)
+        assert second.smooth is False
+
+
+def _chart_frame_xml(r_id: str, xfrm: str = "") -> str:
+    return (
+        '<xdr:graphicFrame><xdr:nvGraphicFramePr><xdr:cNvPr id="2" '
+        f'name="Chart {r_id}"/></xdr:nvGraphicFramePr>{xfrm}<a:graphic>'
+        '<a:graphicData><c:chart r:id="' + r_id + '"/></a:graphicData>'
+        "</a:graphic></xdr:graphicFrame>"
+    )
+
+
+class TestChartAnchors:
+    """Tests for chart discovery across anchor kinds."""
+
+    def test_all_anchor_kinds_and_grouped_charts(self, tmp_path: Path) -> None:
+        import zipfile
+
+        from exstruct.ooxml.chart import _get_chart_positions_from_drawing
+
+        zero_xfrm = (
+            '<xdr:xfrm><a:off x="0" y="0"/><a:ext cx="0" cy="0"/></xdr:xfrm>'
+        )
+        marker = (
+            "<xdr:{tag}><xdr:col>{col}</xdr:col><xdr:colOff>0</xdr:colOff>"
+            "<xdr:row>{row}</xdr:row><xdr:rowOff>0</xdr:rowOff></xdr:{tag}>"
+        )
+        two_cell = (
+            marker.format(tag="from", col=1, row=2)
+            + marker.format(tag="to", col=4, row=12)
+            + _chart_frame_xml("rId1", zero_xfrm)
+        )
+        one_cell = (
+            marker.format(tag="from", col=2, row=1)
+            + '<xdr:ext cx="1905000" cy="952500"/>'
+            + _chart_frame_xml("rId2", zero_xfrm)
+        )
+        frame3 = _chart_frame_xml("rId3")
+        grouped = _chart_frame_xml(
+            "rId4",
+            '<xdr:xfrm><a:off x="95250" y="190500"/>'
+            '<a:ext cx="952500" cy="476250"/></xdr:xfrm>',
+        )
+        drawing = f"""<?xml version="1.0" encoding="UTF-8"?>
+<xdr:wsDr xmlns:xdr="http://schemas.openxmlformats.org/drawingml/2006/spreadsheetDrawing"
+          xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main"
+          xmlns:c="http://schemas.openxmlformats.org/drawingml/2006/chart"
+          xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+  <xdr:twoCellAnchor>{two_cell}</xdr:twoCellAnchor>
+  <xdr:oneCellAnchor>{one_cell}</xdr:oneCellAnchor>
+  <xdr:absoluteAnchor><xdr:pos x="952500" y="476250"/>
+    <xdr:ext cx="2857500" cy="1905000"/>{frame3}
+  </xdr:absoluteAnchor>
+  <xdr:absoluteAnchor><xdr:pos x="0" y="0"/><xdr:ext cx="0" cy="0"/>
+    <xdr:grpSp>{grouped}</xdr:grpSp>
+  </xdr:absoluteAnchor>
+</xdr:wsDr>"""
+        path = tmp_path / "drawing.zip"
+        with zipfile.ZipFile(path, "w") as zf:
+            zf.writestr("xl/drawings/drawing1.xml", drawing)
+
+        with zipfile.ZipFile(path) as zf:
+            positions = _get_chart_positions_from_drawing(
+                zf, "xl/drawings/drawing1.xml"
+            )
+
+        assert positions == {
+            "rId1": ("Chart rId1", 64, 40, 192, 200),
+            "rId2": ("Chart rId2", 128, 20, 200, 100),
+            "rId3": ("Chart rId3", 100, 50, 300, 200),
+            "rId4": ("Chart rId4", 10, 20, 100, 50),
+        }
+
+
+class TestPositionUnits:
+    """Tests for shape and chart positions in configurable units."""
+
+    def test_shape_positions_follow_scale(self) -> None:
+        from exstruct.ooxml.drawing import _parse_drawing_xml
+
+        xml = (
+            '<xdr:wsDr xmlns:xdr="http://schemas.openxmlformats.org/drawingml/'
+            '2006/spreadsheetDrawing" xmlns:a="http://schemas.openxmlformats.org/'
+            f'drawingml/2006/main">{_text_anchor_xml("box", 2)}</xdr:wsDr>'
+        ).encode()
+
+        def box(scale: PositionScale) -> tuple[int, int, int | None, int | None]:
+            (shape,) = _parse_drawing_xml(xml, "verbose", scale=scale)
+            return (shape.l, shape.t, shape.w, shape.h)
+
+        assert box(PositionScale()) == (0, 0, 100, 50)
+        assert box(PositionScale("pixels", dpi=192)) == (0, 0, 200, 100)
+        assert box(PositionScale("points")) == (0, 0, 75, 38)
+        assert box(PositionScale("millimeters")) == (0, 0, 26, 13)
+        assert box(PositionScale("emu")) == (0, 0, 952500, 476250)
+
+    def test_chart_anchor_in_points(self, tmp_path: Path) -> None:
+        import zipfile
+
+        from exstruct.ooxml.chart import _get_chart_positions_from_drawing
+
+        drawing = (
+            '<xdr:wsDr xmlns:xdr="http://schemas.openxmlformats.org/drawingml/'
+            '2006/spreadsheetDrawing" xmlns:a="http://schemas.openxmlformats.org/'
+            'drawingml/2006/main" xmlns:c="http://schemas.openxmlformats.org/'
+            'drawingml/2006/chart" xmlns:r="http://schemas.openxmlformats.org/'
+            'officeDocument/2006/relationships"><xdr:oneCellAnchor><xdr:from>'
+            "<xdr:col>2</xdr:col><xdr:colOff>0</xdr:colOff><xdr:row>1</xdr:row>"
+            '<xdr:rowOff>0</xdr:rowOff></xdr:from><xdr:ext cx="1905000" '
+            f'cy="952500"/>{_chart_frame_xml("rId1")}</xdr:oneCellAnchor>'
+            "</xdr:wsDr>"
+        )
+        path = tmp_path / "drawing.zip"
+        with zipfile.ZipFile(path, "w") as zf:
+            zf.writestr("xl/drawings/drawing1.xml", drawing)
+
+        with zipfile.ZipFile(path) as zf:
+            positions = _get_chart_positions_from_drawing(
+                zf, "xl/drawings/drawing1.xml", PositionScale("points")
+            )
+
+        assert positions == {"rId1": ("Chart rId1", 96, 15, 150, 75)}
+
+
+def _text_anchor_xml(text: str, shape_id: int) -> str:
+    return (
+        f'<xdr:twoCellAnchor><xdr:sp><xdr:nvSpPr><xdr:cNvPr id="{shape_id}" '
+        f'name="Box {shape_id}"/></xdr:nvSpPr><xdr:spPr><a:xfrm>'
+        '<a:off x="0" y="0"/><a:ext cx="952500" cy="476250"/></a:xfrm>'
+        '<a:prstGeom prst="rect"/></xdr:spPr><xdr:txBody><a:bodyPr/>'
+        f"<a:p><a:r><a:t>{text}</a:t></a:r></a:p></xdr:txBody></xdr:sp>"
+        "</xdr:twoCellAnchor>"
+    )
+
+
+class TestAlternateContent:
+    """Tests for mc:AlternateContent branch selection in drawings."""
+
+    _MC = "http://schemas.openxmlformats.org/markup-compatibility/2006"
+
+    def _drawing(self, body: str) -> bytes:
+        return (
+            '<xdr:wsDr xmlns:xdr="http://schemas.openxmlformats.org/drawingml/'
+            '2006/spreadsheetDrawing" xmlns:a="http://schemas.openxmlformats.org/'
+            f'drawingml/2006/main" xmlns:compat="{self._MC}">{body}</xdr:wsDr>'
+        ).encode()
+
+    def test_wrapped_anchors_use_one_branch(self) -> None:
+        from exstruct.ooxml.drawing import _parse_drawing_xml
+
+        xml = self._drawing(
+            _text_anchor_xml("before", 2)
+            + '<compat:AlternateContent><compat:Choice Requires="a14">'
+            + _text_anchor_xml("choice", 3)
+            + "</compat:Choice><compat:Fallback>"
+            + _text_anchor_xml("fallback", 4)
+            + "</compat:Fallback></compat:AlternateContent>"
+            + '<compat:AlternateContent><compat:Choice Requires="a14">'
+            + _text_anchor_xml("choice only", 5)
+            + "</compat:Choice></compat:AlternateContent>"
+        )
+        shapes = _parse_drawing_xml(xml, "standard")
+        assert [s.text for s in shapes] == ["before", "fallback", "choice only"]
+        assert [s.z_order for s in shapes] == [1, 2, 3]
+
+    def test_wrapped_shape_inside_anchor(self) -> None:
+        from exstruct.ooxml.drawing import _parse_drawing_xml
+
+        anchor = _text_anchor_xml("inner", 2).replace(
+            "<xdr:sp>",
+            '<compat:AlternateContent><compat:Choice Requires="a14"><xdr:sp>',
+        ).replace(
+            "</xdr:sp>",
+            "</xdr:sp></compat:Choice></compat:AlternateContent>",
+        )
+        shapes = _parse_drawing_xml(self._drawing(anchor), "standard")
+        assert [s.text for s in shapes] == ["inner"]
+
+    def test_resolve_nested_alternate_content(self) -> None:
+        from xml.etree import ElementTree as ET
+
+        from exstruct.ooxml.compat import resolve_alternate_content
+
+        root = ET.fromstring(
+            f'<root xmlns:mc="{self._MC}"><a/><mc:AlternateContent>'
+            "<mc:Choice><skip/></mc:Choice><mc:Fallback><b/>"
+            "<mc:AlternateContent><mc:Choice><c/></mc:Choice>"
+            "</mc:AlternateContent></mc:Fallback></mc:AlternateContent>"
+            "<d><mc:AlternateContent/></d></root>"
+        )
+        resolve_alternate_content(root)
+        assert [child.tag for child in root] == ["a", "b", "c", "d"]
+        assert len(root[3]) == 0
+
+
+def _freeform_drawing_xml() -> bytes:
+    """Build a drawing part with an untitled freeform triangle and an ink mark."""
+    return b"""<?xml version="1.0" encoding="UTF-8"?>
+<xdr:wsDr xmlns:xdr="http://schemas.openxmlformats.org/drawingml/2006/spreadsheetDrawing"
+          xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main"
+          xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"
+          xmlns:mc="http://schemas.openxmlformats.org/markup-compatibility/2006">
+  <xdr:twoCellAnchor>
+    <xdr:sp>
+      <xdr:nvSpPr><xdr:cNvPr id="2" name="Freeform 1"/><xdr:cNvSpPr/></xdr:nvSpPr>
+      <xdr:spPr>
+        <a:xfrm flipH="1">
+          <a:off x="952500" y="0"/><a:ext cx="1905000" cy="952500"/>
+        </a:xfrm>
+        <a:custGeom>
+          <a:pathLst>
+            <a:path w="200" h="100">
+              <a:moveTo><a:pt x="0" y="50"/></a:moveTo>
+              <a:lnTo><a:pt x="100" y="51"/></a:lnTo>
+              <a:lnTo><a:pt x="200" y="50"/></a:lnTo>
+              <a:lnTo><a:pt x="100" y="100"/></a:lnTo>
+              <a:close/>
+            </a:path>
+          </a:pathLst>
+        </a:custGeom>
+      </xdr:spPr>
+    </xdr:sp>
+  </xdr:twoCellAnchor>
+  <mc:AlternateContent>
+    <mc:Choice xmlns:xdr14="http://schemas.microsoft.com/office/excel/2010/spreadsheetDrawing"
+               Requires="xdr14">
+      <xdr:twoCellAnchor editAs="oneCell">
+        <xdr14:contentPart r:id="rId1">
+          <xdr14:nvContentPartPr>
+            <xdr14:cNvPr id="5" name="Ink 4"/><xdr14:cNvContentPartPr/>
+          </xdr14:nvContentPartPr>
+          <xdr14:xfrm><a:off x="0" y="952500"/><a:ext cx="952500" cy="476250"/></xdr14:xfrm>
+        </xdr14:contentPart>
+      </xdr:twoCellAnchor>
+    </mc:Choice>
+    <mc:Fallback>
+      <xdr:twoCellAnchor editAs="oneCell">
+        <xdr:pic>
+          <xdr:nvPicPr><xdr:cNvPr id="5" name="Ink 4"/><xdr:cNvPicPr/></xdr:nvPicPr>
+          <xdr:blipFill><a:blip r:embed="rId2"/></xdr:blipFill>
+          <xdr:spPr>
+            <a:xfrm><a:off x="0" y="952500"/><a:ext cx="952500" cy="476250"/></a:xfrm>
+          </xdr:spPr>
+        </xdr:pic>
+      </xdr:twoCellAnchor>
+    </mc:Fallback>
+  </mc:AlternateContent>
+</xdr:wsDr>"""
+
+
+_INKML = b"""<inkml:ink xmlns:inkml="http://www.w3.org/2003/InkML">
+  <inkml:trace>1000 2000 0,'0'10'0,'0'10'0</inkml:trace>
+  <inkml:trace>1000 2000 0,'20'0'0,"0"0'0</inkml:trace>
+</inkml:ink>"""
+
+
+class TestFreeformGeometry:
+    """Tests for freeform and ink geometry from custGeom and InkML."""
+
+    def test_freeform_bounding_box(self) -> None:
+        from exstruct.models import Shape
+        from exstruct.ooxml.drawing import _parse_drawing_xml
+
+        shape = _parse_drawing_xml(_freeform_drawing_xml(), "standard")[0]
+        assert isinstance(shape, Shape)
+        assert shape.text == ""
+        assert shape.geometry is not None
+        assert shape.geometry.kind == "freeform"
+        assert (
+            shape.geometry.l,
+            shape.geometry.t,
+            shape.geometry.w,
+            shape.geometry.h,
+        ) == (100, 50, 200, 50)
+        assert shape.geometry.paths is None
+
+    def test_freeform_paths_are_simplified_and_flipped(self) -> None:
+        from exstruct.models import Shape
+        from exstruct.ooxml.drawing import _parse_drawing_xml
+
+        shape = _parse_drawing_xml(
+            _freeform_drawing_xml(), "standard", include_paths=True
+        )[0]
+        assert isinstance(shape, Shape)
+        assert shape.geometry is not None
+        # The near-collinear (100, 51) vertex is dropped; flipH mirrors x.
+        assert shape.geometry.paths == [[(300, 50), (100, 50), (200, 100), (300, 50)]]
+
+    def test_ink_from_content_part(self) -> None:
+        from exstruct.models import Shape
+        from exstruct.ooxml.drawing import _parse_drawing_xml
+
+        shapes = _parse_drawing_xml(
+            _freeform_drawing_xml(),
+            "standard",
+            stable_ids=True,
+            include_paths=True,
+            ink_parts={"rId1": _INKML},
+        )
+        assert len(shapes) == 2
+        ink = shapes[1]
+        assert isinstance(ink, Shape)
+        assert (ink.type, ink.id, ink.z_order) == ("Ink", 5, 2)
+        assert ink.geometry is not None
+        assert ink.geometry.kind == "ink"
+        assert (ink.geometry.l, ink.geometry.t) == (0, 100)
+        assert (ink.geometry.w, ink.geometry.h) == (100, 50)
+        assert ink.geometry.paths == [[(0, 100), (0, 150)], [(0, 100), (100, 100)]]
+
+    def test_ink_without_parts_keeps_box(self) -> None:
+        from exstruct.ooxml.drawing import _parse_drawing_xml
+
+        ink = _parse_drawing_xml(_freeform_drawing_xml(), "standard")[1]
+        assert ink.geometry is not None  # type: ignore[union-attr]
+        assert ink.geometry.paths is None  # type: ignore[union-attr]
+        assert (ink.l, ink.t) == (0, 100)
+
+    def test_light_mode_skips_geometry_shapes(self) -> None:
+        from exstruct.ooxml.drawing import _parse_drawing_xml
+
+        assert _parse_drawing_xml(_freeform_drawing_xml(), "light") == []
+
+    def test_ink_trace_difference_encoding(self) -> None:
+        from exstruct.ooxml.freeform import ink_trace_paths
+
+        assert ink_trace_paths(_INKML) == [
+            [(0.0, 0.0), (0.0, 0.5), (0.0, 1.0)],
+            [(0.0, 0.0), (0.5, 0.0), (1.0, 0.0)],
+        ]
+        assert ink_trace_paths(b"<broken") == []
+
+    def test_simplify_polyline(self) -> None:
+        from exstruct.ooxml.freeform import simplify_polyline
+
+        points = [(0.0, 0.0), (1.0, 0.1), (2.0, 0.0), (3.0, 5.0)]
+        assert simplify_polyline(points, 0.5) == [(0.0, 0.0), (2.0, 0.0), (3.0, 5.0)]
+        assert simplify_polyline(points[:2], 0.5) == points[:2]
+
+
+class TestShapeClickActions:
+    """Tests for shape hyperlinks (hlinkClick) and assigned macros."""
+
+    _RELS = (
+        '<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/'
+        'relationships"><Relationship Id="rId1" Type="http://schemas.'
+        'openxmlformats.org/officeDocument/2006/relationships/hyperlink" '
+        'Target="https://example.com/form" TargetMode="External"/>'
+        '<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/'
+        'officeDocument/2006/relationships/hyperlink" Target="#Input!B2" '
+        'TargetMode="External"/></Relationships>'
+    )
+
+    def _drawing(self) -> bytes:
+        anchors = [
+            _text_anchor_xml("Submit", 2)
+            .replace("<xdr:sp>", '<xdr:sp macro="[0]!Submit_Click">')
+            .replace(
+                'name="Box 2"/>',
+                'name="Box 2"><a:hlinkClick r:id="rId1"/></xdr:cNvPr>',
+            ),
+            _text_anchor_xml("Next", 3).replace(
+                'name="Box 3"/>',
+                'name="Box 3"><a:hlinkClick r:id="rId2"/></xdr:cNvPr>',
+            ),
+            _text_anchor_xml("Plain", 4).replace(
+                "<xdr:sp>", "<xdr:sp macro=\"'Other.xlsm'!Run\">"
+            ),
+        ]
+        return (
+            '<xdr:wsDr xmlns:xdr="http://schemas.openxmlformats.org/drawingml/'
+            '2006/spreadsheetDrawing" xmlns:a="http://schemas.openxmlformats.org/'
+            'drawingml/2006/main" xmlns:r="http://schemas.openxmlformats.org/'
+            f'officeDocument/2006/relationships">{"".join(anchors)}</xdr:wsDr>'
+        ).encode()
+
+    def test_links_and_macros(self) -> None:
+        from exstruct.models import Shape
+        from exstruct.ooxml.drawing import _parse_drawing_xml
+
+        shapes = _parse_drawing_xml(
+            self._drawing(),
+            "standard",
+            hyperlinks={"rId1": "https://example.com/form", "rId2": "#Input!B2"},
+        )
+        assert all(isinstance(s, Shape) for s in shapes)
+        assert [(s.link, s.on_action) for s in shapes] == [  # type: ignore[union-attr]
+            ("https://example.com/form", "Submit_Click"),
+            ("#Input!B2", None),
+            (None, "'Other.xlsm'!Run"),
+        ]
+
+    def test_hyperlink_targets_from_drawing_rels(self, tmp_path: Path) -> None:
+        import zipfile
+
+        from exstruct.ooxml.drawing import _read_drawing_rels
+
+        path = tmp_path / "drawing.zip"
+        with zipfile.ZipFile(path, "w") as zf:
+            zf.writestr("xl/drawings/drawing1.xml", self._drawing())
+            zf.writestr("xl/drawings/_rels/drawing1.xml.rels", self._RELS)
+
+        with zipfile.ZipFile(path) as zf:
+            rels = _read_drawing_rels(zf, "xl/drawings/drawing1.xml")
+            missing = _read_drawing_rels(zf, "xl/drawings/drawing2.xml")
+
+        assert rels == {
+            "rId1": ("hyperlink", "https://example.com/form"),
+            "rId2": ("hyperlink", "#Input!B2"),
+        }
+        assert missing == {}
@@ -0,0 +1,126 @@
+"""Tests for pivot cache record recovery."""
+
+from pathlib import Path
+import zipfile
+
+from exstruct.engine import ExStructEngine, StructOptions
+from exstruct.ooxml.pivot import read_pivot_caches
+
+_MAIN = "http://schemas.openxmlformats.org/spreadsheetml/2006/main"
+_REL = "http://schemas.openxmlformats.org/officeDocument/2006/relationships"
+_RELS = "http://schemas.openxmlformats.org/package/2006/relationships"
+
+_PARTS = {
+    "[Content_Types].xml": (
+        '<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">'
+        '<Default Extension="xml" ContentType="application/xml"/>'
+        '<Default Extension="rels" '
+        'ContentType="application/vnd.openxmlformats-package.relationships+xml"/>'
+        '<Override PartName="/xl/workbook.xml" ContentType="application/'
+        'vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>'
+        '<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/'
+        'vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>'
+        "</Types>"
+    ),
+    "_rels/.rels": (
+        f'<Relationships xmlns="{_RELS}"><Relationship Id="rId1" '
+        'Type="http://schemas.openxmlformats.org/officeDocument/2006/'
+        'relationships/officeDocument" Target="xl/workbook.xml"/></Relationships>'
+    ),
+    "xl/workbook.xml": (
+        f'<workbook xmlns="{_MAIN}" xmlns:r="{_REL}">'
+        '<sheets><sheet name="Report" sheetId="1" r:id="rId1"/></sheets>'
+        '<pivotCaches><pivotCache cacheId="3" r:id="rId2"/>'
+        '<pivotCache cacheId="4" r:id="rId3"/></pivotCaches></workbook>'
+    ),
+    "xl/_rels/workbook.xml.rels": (
+        f'<Relationships xmlns="{_RELS}">'
+        f'<Relationship Id="rId1" Type="{_REL}/worksheet" '
+        'Target="worksheets/sheet1.xml"/>'
+        f'<Relationship Id="rId2" Type="{_REL}/pivotCacheDefinition" '
+        'Target="pivotCache/pivotCacheDefinition1.xml"/>'
+        f'<Relationship Id="rId3" Type="{_REL}/pivotCacheDefinition" '
+        'Target="pivotCache/pivotCacheDefinition2.xml"/>'
+        "</Relationships>"
+    ),
+    "xl/worksheets/sheet1.xml": (
+        f'<worksheet xmlns="{_MAIN}"><sheetData><row r="1">'
+        '<c r="A1" t="inlineStr"><is><t>pivot</t></is></c></row></sheetData>'
+        "</worksheet>"
+    ),
+    "xl/pivotCache/pivotCacheDefinition1.xml": (
+        f'<pivotCacheDefinition xmlns="{_MAIN}" xmlns:r="{_REL}" r:id="rId1">'
+        '<cacheSource type="worksheet"><worksheetSource ref="A1:C3" sheet="Raw"/>'
+        '</cacheSource><cacheFields count="3">'
+        '<cacheField name="Region"><sharedItems count="2"><s v="East"/>'
+        '<s v="West"/></sharedItems></cacheField>'
+        '<cacheField name="Amount"><sharedItems containsNumber="1"/></cacheField>'
+        '<cacheField name="Date"><sharedItems containsDate="1"/></cacheField>'
+        "</cacheFields></pivotCacheDefinition>"
+    ),
+    "xl/pivotCache/_rels/pivotCacheDefinition1.xml.rels": (
+        f'<Relationships xmlns="{_RELS}">'
+        f'<Relationship Id="rId1" Type="{_REL}/pivotCacheRecords" '
+        'Target="pivotCacheRecords1.xml"/></Relationships>'
+    ),
+    "xl/pivotCache/pivotCacheRecords1.xml": (
+        f'<pivotCacheRecords xmlns="{_MAIN}" count="2">'
+        '<r><x v="1"/><n v="10"/><d v="2024-01-31T00:00:00"/></r>'
+        '<r><x v="0"/><n v="2.5"/><m/></r></pivotCacheRecords>'
+    ),
+    "xl/pivotCache/pivotCacheDefinition2.xml": (
+        f'<pivotCacheDefinition xmlns="{_MAIN}">'
+        '<cacheSource type="worksheet"><worksheetSource ref="A1:A1" sheet="Report"/>'
+        '</cacheSource><cacheFields count="1"><cacheField name="pivot"/>'
+        "</cacheFields></pivotCacheDefinition>"
+    ),
+}
+
+
+def _book(tmp_path: Path) -> Path:
+    path = tmp_path / "pivot.xlsx"
+    with zipfile.ZipFile(path, "w") as zf:
+        for name, data in _PARTS.items():
+            zf.writestr(name, data)
+    return path
+
+
+def test_read_pivot_caches_recovers_orphaned_records(tmp_path: Path) -> None:
+    path = _book(tmp_path)
+
+    caches = read_pivot_caches(path)
+    everything = read_pivot_caches(path, orphaned_only=False)
+
+    assert len(caches) == 1
+    cache = caches[0]
+    assert (cache.cache_id, cache.source_sheet, cache.source_range) == (
+        3,
+        "Raw",
+        "A1:C3",
+    )
+    assert cache.source_missing is True
+    assert cache.columns == ["Region", "Amount", "Date"]
+    assert cache.records == [["West", 10, "2024-01-31"], ["East", 2.5, None]]
+    assert [(c.cache_id, c.source_missing) for c in everything] == [
+        (3, True),
+        (4, False),
+    ]
+
+
+def test_read_pivot_caches_skips_non_ooxml(tmp_path: Path) -> None:
+    xls = tmp_path / "old.xls"
+    xls.write_bytes(b"\xd0\xcf\x11\xe0")
+
+    assert read_pivot_caches(xls) == []
+
+
+def test_include_pivot_caches_option(tmp_path: Path) -> None:
+    path = _book(tmp_path)
+
+    plain = ExStructEngine(options=StructOptions(mode="light")).extract(path)
+    workbook = ExStructEngine(
+        options=StructOptions(mode="light", include_pivot_caches=True)
+    ).extract(path)
+
+    assert plain.pivot_caches == []
+    assert [cache.cache_id for cache in workbook.pivot_caches] == [3]
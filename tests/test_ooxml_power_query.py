@@ -0,0 +1,86 @@
+"""Tests for Power Query extraction from the DataMashup part."""
+
+import base64
+import io
+from pathlib import Path
+import struct
+import zipfile
+
+from openpyxl import Workbook
+
+from exstruct.engine import ExStructEngine, StructOptions
+from exstruct.ooxml.power_query import parse_section, read_power_queries
+
+_SECTION = (
+    "section Section1;\r\n\r\n"
+    "// Monthly sales\r\n"
+    "shared Sales = let\r\n"
+    '    Source = Excel.CurrentWorkbook(){[Name="Sales;2024"]}[Content]\r\n'
+    "in\r\n"
+    "    Source;\r\n\r\n"
+    'shared #"Top ""N""" = Table.FirstN(Sales, 10);\r\n'
+)
+
+
+def _mashup_part(section: str) -> str:
+    buffer = io.BytesIO()
+    with zipfile.ZipFile(buffer, "w") as package:
+        package.writestr("Config/Package.xml", "<Package/>")
+        package.writestr("Formulas/Section1.m", section)
+    data = buffer.getvalue()
+    stream = struct.pack("<ii", 0, len(data)) + data + struct.pack("<i", 0)
+    return (
+        '<?xml version="1.0" encoding="utf-8"?>'
+        '<DataMashup xmlns="http://schemas.microsoft.com/DataMashup">'
+        f"{base64.b64encode(stream).decode('ascii')}</DataMashup>"
+    )
+
+
+def _book(tmp_path: Path, mashup: str | None) -> Path:
+    path = tmp_path / "queries.xlsx"
+    wb = Workbook()
+    wb.active["A1"] = "data"
+    wb.save(path)
+    if mashup is not None:
+        with zipfile.ZipFile(path, "a") as zf:
+            zf.writestr("customXml/item1.xml", mashup)
+    return path
+
+
+def test_parse_section_splits_shared_members() -> None:
+    queries = parse_section(_SECTION)
+
+    assert [query.name for query in queries] == ["Sales", 'Top "N"']
+    assert queries[0].formula.startswith("let\r\n    Source = Excel.CurrentWorkbook")
+    assert queries[0].formula.endswith("in\r\n    Source")
+    assert queries[1].formula == "Table.FirstN(Sales, 10)"
+
+
+def test_read_power_queries_decodes_data_mashup(tmp_path: Path) -> None:
+    path = _book(tmp_path, _mashup_part(_SECTION))
+
+    queries = read_power_queries(path)
+
+    assert [query.name for query in queries] == ["Sales", 'Top "N"']
+
+
+def test_read_power_queries_ignores_missing_or_broken_mashup(tmp_path: Path) -> None:
+    broken = (
+        '<DataMashup xmlns="http://schemas.microsoft.com/DataMashup">'
+        "not base64!</DataMashup>"
+    )
+
+    assert read_power_queries(_book(tmp_path, None)) == []
+    assert read_power_queries(_book(tmp_path, broken)) == []
+
+
+def test_include_power_queries_option(tmp_path: Path) -> None:
+    path = _book(tmp_path, _mashup_part(_SECTION))
+
+    plain = ExStructEngine(options=StructOptions(mode="light")).extract(path)
+    workbook = ExStructEngine(
+        options=StructOptions(mode="light", include_power_queries=True)
+    ).extract(path)
+
+    assert plain.power_queries == []
+    assert [query.name for query in workbook.power_queries] == ["Sales", 'Top "N"']
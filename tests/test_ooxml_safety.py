@@ -0,0 +1,92 @@
+"""Tests for zip-bomb and XML entity defenses in the OOXML parsing path."""
+
+from pathlib import Path
+from xml.etree import ElementTree as ET
+import zipfile
+
+from openpyxl import Workbook
+import pytest
+
+from exstruct import extract
+from exstruct.errors import UnsafeWorkbookError
+from exstruct.ooxml import safety
+from exstruct.ooxml.summary import summarize_workbook_ooxml
+
+_BILLION_LAUGHS = b"""<?xml version="1.0"?>
+<!DOCTYPE lolz [
+  <!ENTITY lol "lol">
+  <!ENTITY lol2 "&lol;&lol;&lol;&lol;&lol;&lol;&lol;&lol;&lol;&lol;">
+]>
+<worksheet>&lol2;</worksheet>
+"""
+
+
+def _book(tmp_path: Path) -> Path:
+    wb = Workbook()
+    ws = wb.active
+    assert ws is not None
+    ws["A1"] = "ok"
+    path = tmp_path / "book.xlsx"
+    wb.save(path)
+    return path
+
+
+def _with_extra_part(path: Path, name: str, data: bytes) -> Path:
+    with zipfile.ZipFile(path, "a", compression=zipfile.ZIP_DEFLATED) as zf:
+        zf.writestr(name, data)
+    return path
+
+
+def test_high_compression_ratio_is_rejected(tmp_path: Path) -> None:
+    path = _with_extra_part(_book(tmp_path), "xl/bomb.xml", b"\0" * (8 * 1024 * 1024))
+
+    with pytest.raises(UnsafeWorkbookError, match="compression ratio"):
+        summarize_workbook_ooxml(path)
+    with pytest.raises(UnsafeWorkbookError):
+        extract(path, mode="light")
+
+
+def test_part_size_cap_applies_while_reading(
+    tmp_path: Path, monkeypatch: pytest.MonkeyPatch
+) -> None:
+    path = _book(tmp_path)
+    monkeypatch.setattr(safety, "MAX_PACKAGE_BYTES", 10)
+
+    with pytest.raises(UnsafeWorkbookError, match="Package expands"):
+        safety.check_workbook_file(path)
+    with zipfile.ZipFile(path) as zf:
+        with pytest.raises(UnsafeWorkbookError, match="expands"):
+            safety.read_part(zf, "xl/workbook.xml", max_bytes=16)
+
+
+def test_entity_declarations_are_rejected() -> None:
+    with pytest.raises(UnsafeWorkbookError, match="Rejected XML declaration"):
+        safety.parse_xml(_BILLION_LAUGHS)
+    with pytest.raises(UnsafeWorkbookError, match="Rejected XML declaration"):
+        list(safety.iterparse_xml(_BILLION_LAUGHS, ("end",)))
+
+
+def test_streamed_parts_reject_entity_declarations(tmp_path: Path) -> None:
+    path = _with_extra_part(_book(tmp_path), "xl/evil.xml", _BILLION_LAUGHS)
+
+    with zipfile.ZipFile(path) as zf:
+        with pytest.raises(UnsafeWorkbookError):
+            list(safety.iterparse_part(zf, "xl/evil.xml", ("end",)))
+
+
+def test_normal_workbooks_pass(tmp_path: Path) -> None:
+    path = _book(tmp_path)
+
+    safety.check_workbook_file(path)
+    safety.check_workbook_file(tmp_path / "missing.xlsx")
+    assert summarize_workbook_ooxml(path).sheets[0].non_empty_cells == 1
+
+
+@pytest.mark.parametrize("encoding", ["bogus", "shift_jis"])
+def test_unusable_encoding_is_a_parse_error(encoding: str) -> None:
+    data = f'<?xml version="1.0" encoding="{encoding}"?><a/>'.encode()
+
+    with pytest.raises(ET.ParseError, match="unsupported XML encoding"):
+        safety.parse_xml(data)
+    with pytest.raises(ET.ParseError, match="unsupported XML encoding"):
+        list(safety.iterparse_xml(data, ("end",)))
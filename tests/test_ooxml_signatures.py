@@ -0,0 +1,144 @@
+"""Tests for the digital signature security report."""
+
+import base64
+import hashlib
+from pathlib import Path
+import zipfile
+
+from openpyxl import Workbook
+
+from exstruct.engine import ExStructEngine, StructOptions
+from exstruct.ooxml.signatures import read_security_report
+
+_RELS = "http://schemas.openxmlformats.org/package/2006/relationships"
+_SIG_TYPE = f"{_RELS}/digital-signature"
+
+
+def _tlv(tag: int, value: bytes) -> bytes:
+    length = len(value)
+    if length < 0x80:
+        return bytes([tag, length]) + value
+    size = length.to_bytes((length.bit_length() + 7) // 8, "big")
+    return bytes([tag, 0x80 | len(size)]) + size + value
+
+
+def _name(common_name: str, organization: str) -> bytes:
+    def attribute(oid: bytes, value: str) -> bytes:
+        pair = _tlv(0x06, oid) + _tlv(0x0C, value.encode("utf-8"))
+        return _tlv(0x31, _tlv(0x30, pair))
+
+    return _tlv(
+        0x30,
+        attribute(b"\x55\x04\x0a", organization)
+        + attribute(b"\x55\x04\x03", common_name),
+    )
+
+
+def _certificate() -> str:
+    tbs = _tlv(
+        0x30,
+        _tlv(0xA0, _tlv(0x02, b"\x02"))
+        + _tlv(0x02, b"\x01\x2a")
+        + _tlv(0x30, _tlv(0x06, b"\x2a\x86\x48\x86\xf7\x0d\x01\x01\x0b"))
+        + _name("Test CA", "Acme")
+        + _tlv(0x30, _tlv(0x17, b"240101000000Z") + _tlv(0x18, b"20261231235959Z"))
+        + _name("Jane Doe", "Acme")
+        + _tlv(0x30, b""),
+    )
+    return base64.b64encode(_tlv(0x30, tbs + _tlv(0x30, b""))).decode("ascii")
+
+
+def _signed_book(tmp_path: Path, *, tamper: bool) -> Path:
+    path = tmp_path / "signed.xlsx"
+    wb = Workbook()
+    wb.active["A1"] = "approved"
+    wb.save(path)
+    with zipfile.ZipFile(path) as zf:
+        parts = {name: zf.read(name) for name in zf.namelist()}
+    digest = base64.b64encode(
+        hashlib.sha256(parts["xl/worksheets/sheet1.xml"]).digest()
+    ).decode("ascii")
+    parts["_rels/.rels"] = parts["_rels/.rels"].replace(
+        b"</Relationships>",
+        f'<Relationship Id="rIdSig" Type="{_SIG_TYPE}/origin" '
+        'Target="_xmlsignatures/origin.sigs"/></Relationships>'.encode(),
+    )
+    parts["_xmlsignatures/origin.sigs"] = b""
+    parts["_xmlsignatures/_rels/origin.sigs.rels"] = (
+        f'<Relationships xmlns="{_RELS}"><Relationship Id="rId1" '
+        f'Type="{_SIG_TYPE}/signature" Target="sig1.xml"/></Relationships>'
+    ).encode()
+    parts["_xmlsignatures/sig1.xml"] = (
+        '<Signature xmlns="http://www.w3.org/2000/09/xmldsig#">'
+        "<KeyInfo><X509Data><X509Certificate>"
+        f"{_certificate()}</X509Certificate></X509Data></KeyInfo>"
+        '<Object Id="idPackageObject"><Manifest>'
+        '<Reference URI="/xl/worksheets/sheet1.xml?ContentType=application/xml">'
+        '<DigestMethod Algorithm="http://www.w3.org/2001/04/xmlenc#sha256"/>'
+        f"<DigestValue>{digest}</DigestValue></Reference>"
+        '<Reference URI="/_rels/.rels?ContentType=application/xml">'
+        "<Transforms><Transform Algorithm="
+        '"http://schemas.openxmlformats.org/package/2006/RelationshipTransform"/>'
+        "</Transforms>"
+        '<DigestMethod Algorithm="http://www.w3.org/2000/09/xmldsig#sha1"/>'
+        "<DigestValue>AAAA</DigestValue></Reference>"
+        "</Manifest></Object></Signature>"
+    ).encode()
+    if tamper:
+        parts["xl/worksheets/sheet1.xml"] = parts["xl/worksheets/sheet1.xml"].replace(
+            b"approved", b"rejected"
+        )
+    with zipfile.ZipFile(path, "w") as zf:
+        for name, data in parts.items():
+            zf.writestr(name, data)
+    return path
+
+
+def test_security_report_reads_signer_and_verifies_digests(tmp_path: Path) -> None:
+    report = read_security_report(_signed_book(tmp_path, tamper=False))
+
+    assert report.signed is True
+    (signature,) = report.signatures
+    assert signature.part == "_xmlsignatures/sig1.xml"
+    assert signature.signer == "CN=Jane Doe, O=Acme"
+    assert signature.issuer == "CN=Test CA, O=Acme"
+    assert signature.serial == "012A"
+    assert signature.valid_from == "2024-01-01T00:00:00Z"
+    assert signature.valid_to == "2026-12-31T23:59:59Z"
+    assert signature.intact is True
+    assert signature.unverified_parts == ["_rels/.rels"]
+    assert "xl/workbook.xml" in report.unsigned_parts
+
+
+def test_security_report_flags_parts_modified_after_signing(tmp_path: Path) -> None:
+    report = read_security_report(_signed_book(tmp_path, tamper=True))
+
+    (signature,) = report.signatures
+    assert signature.intact is False
+    assert signature.modified_parts == ["xl/worksheets/sheet1.xml"]
+
+
+def test_security_report_unsigned_workbook(tmp_path: Path) -> None:
+    path = tmp_path / "plain.xlsx"
+    Workbook().save(path)
+
+    report = read_security_report(path)
+
+    assert (report.signed, report.signatures, report.unsigned_parts) == (
+        False,
+        [],
+        [],
+    )
+
+
+def test_include_security_report_option(tmp_path: Path) -> None:
+    path = _signed_book(tmp_path, tamper=False)
+
+    plain = ExStructEngine(options=StructOptions(mode="light")).extract(path)
+    workbook = ExStructEngine(
+        options=StructOptions(mode="light", include_security_report=True)
+    ).extract(path)
+
+    assert plain.security is None
+    assert workbook.security is not None
+    assert workbook.security.signed is True
@@ -0,0 +1,89 @@
+"""Tests for the workbook style table export."""
+
+from pathlib import Path
+
+from openpyxl import Workbook
+from openpyxl.styles import Alignment, Border, Font, PatternFill, Side
+
+from exstruct.engine import ExStructEngine, StructOptions
+from exstruct.ooxml.styles import read_style_maps, read_style_table
+
+
+def _book(tmp_path: Path) -> Path:
+    path = tmp_path / "styled.xlsx"
+    wb = Workbook()
+    ws = wb.active
+    ws.title = "Report"
+    header = Font(name="Arial", size=14, bold=True, color="FF0000")
+    fill = PatternFill("solid", fgColor="FFFF00")
+    for cell in ("A1", "B1"):
+        ws[cell] = "head"
+        ws[cell].font = header
+        ws[cell].fill = fill
+    ws["A2"] = 1234.5
+    ws["A2"].number_format = "#,##0.00"
+    ws["B2"].border = Border(bottom=Side(style="thin", color="0000FF"))
+    ws["B2"].alignment = Alignment(horizontal="center", wrap_text=True)
+    ws["C2"] = "plain"
+    wb.save(path)
+    return path
+
+
+def test_read_style_table_resolves_entries(tmp_path: Path) -> None:
+    table = read_style_table(_book(tmp_path))
+
+    assert table is not None
+    assert table.default_font is not None
+    assert (table.default_font.name, table.default_font.size) == ("Calibri", 11.0)
+    assert table.cell_formats[0].font_id == 0
+    maps = read_style_maps(tmp_path / "styled.xlsx")["Report"]
+    head_id, amount_id, border_id = (
+        next(key for key, cells in maps.items() if cell in cells)
+        for cell in ((1, 0), (2, 0), (2, 1))
+    )
+    head = table.cell_formats[int(head_id)]
+    font = table.fonts[head.font_id]
+    assert (font.name, font.size, font.bold) == ("Arial", 14.0, True)
+    assert font.color == "FF0000"
+    fill = table.fills[head.fill_id]
+    assert (fill.pattern, fill.fg_color) == ("solid", "FFFF00")
+    amount = table.cell_formats[int(amount_id)]
+    codes = {fmt.id: fmt.code for fmt in table.number_formats}
+    assert codes[amount.num_fmt_id] == "#,##0.00"
+    assert codes[0] == "General"
+    bordered = table.cell_formats[int(border_id)]
+    border = table.borders[bordered.border_id]
+    assert border.bottom is not None
+    assert (border.bottom.style, border.bottom.color) == ("thin", "0000FF")
+    assert border.top is None
+    assert (bordered.horizontal, bordered.wrap_text) == ("center", True)
+
+
+def test_read_style_maps_groups_cells_and_skips_default(tmp_path: Path) -> None:
+    maps = read_style_maps(_book(tmp_path))["Report"]
+
+    head = [cells for cells in maps.values() if (1, 0) in cells]
+    assert head == [[(1, 0), (1, 1)]]
+    assert all((2, 2) not in cells for cells in maps.values())
+
+
+def test_styles_not_zip(tmp_path: Path) -> None:
+    path = tmp_path / "book.xls"
+    path.write_bytes(b"not a zip")
+
+    assert read_style_table(path) is None
+    assert read_style_maps(path) == {}
+
+
+def test_include_styles_option(tmp_path: Path) -> None:
+    path = _book(tmp_path)
+
+    plain = ExStructEngine(options=StructOptions(mode="light")).extract(path)
+    workbook = ExStructEngine(
+        options=StructOptions(mode="light", include_styles=True)
+    ).extract(path)
+
+    assert plain.styles is None
+    assert plain["Report"].style_map == {}
+    assert workbook.styles is not None
+    assert any((1, 0) in cells for cells in workbook["Report"].style_map.values())
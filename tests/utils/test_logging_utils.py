@@ -1,9 +1,53 @@
+from collections.abc import Iterator
+import json
 import logging
+from pathlib import Path
 
+from openpyxl import Workbook
 import pytest
 
-from exstruct.core.logging_utils import log_fallback
+from exstruct.cli.main import main as cli_main
+from exstruct.core.logging_utils import (
+    configure_cli_logging,
+    log_fallback,
+    route_logs_to,
+)
+from exstruct.engine import ExStructEngine, StructOptions
 from exstruct.errors import FallbackReason
+from exstruct.models import WorkbookData
+
+
+class _ListHandler(logging.Handler):
+    def __init__(self) -> None:
+        super().__init__()
+        self.records: list[logging.LogRecord] = []
+
+    def emit(self, record: logging.LogRecord) -> None:
+        self.records.append(record)
+
+
+@pytest.fixture
+def package_logger() -> Iterator[logging.Logger]:
+    logger = logging.getLogger("exstruct")
+    saved = (list(logger.handlers), logger.level, logger.propagate)
+    yield logger
+    logger.handlers[:] = saved[0]
+    logger.setLevel(saved[1])
+    logger.propagate = saved[2]
+
+
+def _injected_logger(level: int) -> tuple[logging.Logger, _ListHandler]:
+    logger = logging.getLogger("app.injected")
+    logger.handlers.clear()
+    logger.propagate = False
+    logger.setLevel(level)
+    handler = _ListHandler()
+    logger.addHandler(handler)
+    return logger, handler
+
+
+def _cli_handlers(logger: logging.Logger) -> list[logging.Handler]:
+    return [h for h in logger.handlers if type(h).__name__ == "_CliHandler"]
 
 
 def test_log_fallback_includes_reason(caplog: pytest.LogCaptureFixture) -> None:
@@ -12,3 +56,65 @@ def test_log_fallback_includes_reason(caplog: pytest.LogCaptureFixture) -> None:
         log_fallback(logger, FallbackReason.LIGHT_MODE, "fallback")
 
     assert any("[light_mode] fallback" in record.message for record in caplog.records)
+
+
+def test_route_logs_to_forwards_and_restores(
+    package_logger: logging.Logger,
+) -> None:
+    target, handler = _injected_logger(logging.DEBUG)
+
+    with route_logs_to(target):
+        logging.getLogger("exstruct.core.shapes").debug("shape %s", "x")
+    logging.getLogger("exstruct.core.shapes").warning("after")
+
+    assert [record.getMessage() for record in handler.records] == ["shape x"]
+    assert package_logger.propagate is True
+    assert handler not in package_logger.handlers
+
+
+def test_engine_routes_logs_to_injected_logger(
+    monkeypatch: pytest.MonkeyPatch, package_logger: logging.Logger
+) -> None:
+    def fake_extract(path: Path, **_kwargs: object) -> WorkbookData:
+        logging.getLogger("exstruct.core.charts").warning("chart failed")
+        logging.getLogger("exstruct.core.charts").debug("detail")
+        return WorkbookData(book_name="b.xlsx", sheets={})
+
+    monkeypatch.setattr("exstruct.engine.extract_workbook", fake_extract)
+    target, handler = _injected_logger(logging.WARNING)
+
+    ExStructEngine(options=StructOptions(logger=target)).extract("b.xlsx")
+
+    assert [record.getMessage() for record in handler.records] == ["chart failed"]
+
+
+def test_configure_cli_logging_levels(package_logger: logging.Logger) -> None:
+    configure_cli_logging(verbosity=2)
+    configure_cli_logging(verbosity=1, log_format="json")
+
+    (handler,) = _cli_handlers(package_logger)
+    assert handler.level == logging.INFO
+
+    configure_cli_logging(verbosity=2, quiet=True)
+    (handler,) = _cli_handlers(package_logger)
+    assert handler.level == logging.ERROR
+
+
+def test_cli_json_logs_go_to_stderr(
+    tmp_path: Path,
+    capsys: pytest.CaptureFixture[str],
+    package_logger: logging.Logger,
+) -> None:
+    wb = Workbook()
+    path = tmp_path / "book.xlsx"
+    wb.save(path)
+
+    code = cli_main([str(path), "--mode", "light", "-vv", "--log-format", "json"])
+
+    assert code == 0
+    captured = capsys.readouterr()
+    lines = [line for line in captured.err.splitlines() if line.startswith("{")]
+    assert lines
+    record = json.loads(lines[0])
+    assert set(record) >= {"time", "level", "logger", "message"}
+    assert record["logger"].startswith("exstruct")